@@ -2,16 +2,22 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"net/http"
 	_ "net/http/pprof"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"syscall"
 	"time"
+	_ "time/tzdata"
 
 	_ "github.com/dowglassantana/product-redis-api/docs"
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
 	"github.com/dowglassantana/product-redis-api/internal/application/usecase"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
 	"github.com/dowglassantana/product-redis-api/internal/infrastructure/cache"
 	"github.com/dowglassantana/product-redis-api/internal/infrastructure/config"
 	"github.com/dowglassantana/product-redis-api/internal/infrastructure/database"
@@ -19,7 +25,10 @@ import (
 	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/middleware"
 	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/router"
 	"github.com/dowglassantana/product-redis-api/internal/infrastructure/logger"
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/metrics"
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/webhook"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
@@ -44,6 +53,9 @@ import (
 // @description Type "Bearer" followed by a space and JWT token.
 
 func main() {
+	warmCache := flag.Bool("warm-cache", false, "reload every product and index into Redis before serving /api/v1")
+	flag.Parse()
+
 	cfg, err := config.Load()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
@@ -55,6 +67,7 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
 		os.Exit(1)
 	}
+	log = logger.WithRedaction(log, cfg.App.LogRedactFields)
 	defer log.Sync()
 
 	log.Info("starting product API",
@@ -74,57 +87,476 @@ func main() {
 	if err != nil {
 		log.Fatal("failed to initialize database", zap.Error(err))
 	}
-	defer dbPool.Close()
 	log.Info("database connection established")
 
 	redisClient, err := initRedis(cfg.Redis)
 	if err != nil {
 		log.Fatal("failed to initialize redis", zap.Error(err))
 	}
-	defer redisClient.Close()
 	log.Info("redis connection established")
 
-	productRepo := database.NewPostgresProductRepository(dbPool)
-	cacheRepo := cache.NewRedisRepository(redisClient)
+	replicaPool, err := initReadReplicas(cfg.Redis, log)
+	if err != nil {
+		log.Fatal("failed to initialize redis read replicas", zap.Error(err))
+	}
+
+	stockIsolation, err := database.ParseIsolationLevel(cfg.Database.StockIsolationLevel)
+	if err != nil {
+		log.Fatal("invalid database configuration", zap.Error(err))
+	}
+
+	var productRepo repository.ProductRepository = database.NewPostgresProductRepositoryWithOptions(dbPool, database.PostgresOptions{
+		StockIsolation: stockIsolation,
+	})
+
+	var dbBreaker *database.CircuitBreaker
+	if cfg.Database.CircuitBreakerEnabled {
+		dbBreaker = database.NewCircuitBreaker(cfg.Database.CircuitBreakerThreshold, cfg.Database.CircuitBreakerOpenTimeout, log)
+		productRepo = database.NewCircuitBreakerRepository(productRepo, dbBreaker)
+		log.Info("database circuit breaker enabled",
+			zap.Int("threshold", cfg.Database.CircuitBreakerThreshold),
+			zap.Duration("open_timeout", cfg.Database.CircuitBreakerOpenTimeout),
+		)
+	}
+
+	// Loops periódicos (monitor do banco, checagem das réplicas) param no
+	// shutdown antes de Redis e banco serem fechados.
+	loopsCtx, stopLoops := context.WithCancel(context.Background())
+	defer stopLoops()
+
+	// A reconciliação de versões e a revalidação do GET leem o banco sem o
+	// decorator do modo degradado, que responderia "não encontrado" e faria
+	// entradas válidas do cache parecerem órfãs. As escritas também não passam
+	// por ele: com o banco fora, respondem 503 em vez de 404.
+	reconcileRepo := productRepo
+	writeRepo := productRepo
+
+	var routerOptions router.Options
+	if cfg.Database.DegradedMode {
+		dbMonitor := database.NewHealthMonitor(productRepo, log)
+		go dbMonitor.Start(loopsCtx, cfg.Database.HealthInterval)
+
+		productRepo = database.NewDegradedReadRepository(productRepo, dbMonitor)
+		writeRepo = database.NewDegradedWriteRepository(writeRepo, dbMonitor)
+		routerOptions.Degraded = dbMonitor.Degraded
+		if dbBreaker != nil {
+			routerOptions.Degraded = func() bool {
+				return dbMonitor.Degraded() || dbBreaker.Open()
+			}
+		}
+		log.Info("database degraded mode enabled", zap.Duration("health_interval", cfg.Database.HealthInterval))
+	}
+	serializer, specCipher, err := initSerializer(cfg.Redis, log)
+	if err != nil {
+		log.Fatal("invalid redis serializer configuration", zap.Error(err))
+	}
+	cacheRepo := cache.NewRedisRepositoryWithSerializer(redisClient, serializer).
+		WithTTL(cfg.Redis.CacheTTL).
+		WithIndexTTL(cfg.Redis.IndexTTL).
+		WithMaxEntrySize(cfg.Redis.MaxEntryBytes, cfg.Redis.LargeEntryTTL)
+	if cfg.Redis.MaxEntryBytes > 0 {
+		log.Info("cache entry size limit configured",
+			zap.Int("max_entry_bytes", cfg.Redis.MaxEntryBytes),
+			zap.Duration("large_entry_ttl", cfg.Redis.LargeEntryTTL),
+		)
+	}
+	cacheOOMRecorder, err := metrics.NewPrometheusCacheOOMRecorder(prometheus.DefaultRegisterer)
+	if err != nil {
+		log.Fatal("failed to register metrics", zap.Error(err))
+	}
+	cacheRepo.WithOOMPolicy(cache.OOMPolicy{
+		Pause:         cfg.Redis.OOMWritePause,
+		EvictPatterns: cfg.Redis.OOMEvictPatterns,
+		Recorder:      cacheOOMRecorder,
+	})
+	if cfg.Redis.OOMWritePause > 0 || len(cfg.Redis.OOMEvictPatterns) > 0 {
+		log.Info("cache OOM handling configured",
+			zap.Duration("write_pause", cfg.Redis.OOMWritePause),
+			zap.Strings("evict_patterns", cfg.Redis.OOMEvictPatterns),
+		)
+	}
+	if replicaPool != nil {
+		go replicaPool.Start(loopsCtx, cfg.Redis.ReplicaHealthInterval)
+
+		cacheRepo.WithReadReplicas(replicaPool)
+		log.Info("redis read replicas configured", zap.Int("healthy", replicaPool.HealthyCount()))
+	}
 	cacheKeys := cache.NewRedisCacheKeyGenerator()
 
 	appLogger := logger.NewZapAdapter(log)
+	background := usecase.NewBackgroundTasks()
+
+	fallbackRecorder, err := metrics.NewPrometheusFallbackRecorder(prometheus.DefaultRegisterer)
+	if err != nil {
+		log.Fatal("failed to register metrics", zap.Error(err))
+	}
+	cacheRecorder, err := metrics.NewPrometheusCacheRecorder(prometheus.DefaultRegisterer)
+	if err != nil {
+		log.Fatal("failed to register metrics", zap.Error(err))
+	}
 
-	createUseCase := usecase.NewCreateProductUseCase(productRepo, cacheRepo, cacheKeys, appLogger)
-	updateUseCase := usecase.NewUpdateProductUseCase(productRepo, cacheRepo, cacheKeys, appLogger)
-	deleteUseCase := usecase.NewDeleteProductUseCase(productRepo, cacheRepo, cacheKeys, appLogger)
-	getUseCase := usecase.NewGetProductUseCase(productRepo, cacheRepo, cacheKeys, appLogger)
-	listUseCase := usecase.NewListProductsUseCase(productRepo, cacheRepo, cacheKeys, appLogger)
-	searchByNameUseCase := usecase.NewSearchProductsByNameUseCase(productRepo, cacheRepo, cacheKeys, appLogger)
-	searchByCategoryUseCase := usecase.NewSearchProductsByCategoryUseCase(productRepo, cacheRepo, cacheKeys, appLogger)
+	var changePublisher port.ChangePublisher = port.NoopChangePublisher{}
+	var webhookPublisher *webhook.Publisher
+	var deadLetters port.DeadLetterQueue
+	if cfg.Webhook.URL != "" {
+		var deadLetterStore webhook.DeadLetterStore
+		if cfg.Webhook.DeadLetterEnabled {
+			deadLetterStore = webhook.NewRedisDeadLetterStore(redisClient, cfg.Webhook.DeadLetterKey, cfg.Webhook.DeadLetterMaxLen)
+		}
+
+		webhookRecorder, err := metrics.NewPrometheusWebhookRecorder(prometheus.DefaultRegisterer)
+		if err != nil {
+			log.Fatal("failed to register metrics", zap.Error(err))
+		}
+
+		webhookPublisher = webhook.NewPublisher(webhook.Options{
+			URL:          cfg.Webhook.URL,
+			Timeout:      cfg.Webhook.Timeout,
+			MaxRetries:   cfg.Webhook.MaxRetries,
+			RetryBackoff: cfg.Webhook.RetryBackoff,
+			BatchWindow:  cfg.Webhook.BatchWindow,
+			MaxBatchSize: cfg.Webhook.BatchMaxSize,
+			QueueSize:    cfg.Webhook.QueueSize,
+			DeadLetter:   deadLetterStore,
+			Recorder:     webhookRecorder,
+		}, log)
+		changePublisher = webhookPublisher
+		if deadLetterStore != nil {
+			deadLetters = webhookPublisher
+		}
+		log.Info("change event webhook enabled",
+			zap.Duration("batch_window", cfg.Webhook.BatchWindow),
+			zap.Int("batch_max_size", cfg.Webhook.BatchMaxSize),
+			zap.Bool("dead_letter", cfg.Webhook.DeadLetterEnabled),
+		)
+	}
+
+	allowedCategories := entity.NewCategorySet(cfg.App.AllowedCategories)
+
+	idFields, err := entity.ParseIDFields(cfg.App.IDFields)
+	if err != nil {
+		log.Fatal("invalid product id configuration", zap.Error(err))
+	}
+	referenceNormalization, err := entity.ParseReferenceNormalization(cfg.App.ReferenceNormalization)
+	if err != nil {
+		log.Fatal("invalid reference normalization", zap.Error(err))
+	}
+	priceRounding, err := entity.ParsePriceRounding(cfg.App.PriceRounding)
+	if err != nil {
+		log.Fatal("invalid price rounding", zap.Error(err))
+	}
+	log.Info("product identity fields",
+		zap.String("id_fields", idFields.String()),
+		zap.String("reference_normalization", referenceNormalization.String()),
+	)
+
+	var productCounts port.ProductCountCache = port.NoopProductCountCache{}
+	if cfg.Redis.CountTTL > 0 {
+		productCounts = cache.NewRedisProductCountCache(redisClient, cfg.Redis.CountTTL)
+	}
+
+	var auditLogger port.AuditLogger = port.NoopAuditLogger{}
+	var auditWriter *usecase.AuditLogger
+	if cfg.Database.AuditLog {
+		auditWriter = usecase.NewAuditLoggerWithOptions(database.NewPostgresAuditRepository(dbPool), appLogger, usecase.AuditLoggerOptions{
+			QueueSize:     cfg.Database.AuditLogQueueSize,
+			BatchSize:     cfg.Database.AuditLogBatchSize,
+			FlushInterval: cfg.Database.AuditLogFlushInterval,
+		})
+		auditLogger = auditWriter
+	}
+
+	createOptions := usecase.CreateProductOptions{
+		AllowedCategories:      allowedCategories,
+		IDFields:               idFields,
+		ReferenceNormalization: referenceNormalization,
+		PriceRounding:          priceRounding,
+		UniqueNamePerCategory:  cfg.App.UniqueNamePerCategory,
+		MaxSpecDepth:           cfg.App.MaxSpecDepth,
+		Events:                 changePublisher,
+		CacheRetry: usecase.CacheRetryOptions{
+			Retries: cfg.Redis.WriteRetries,
+			Backoff: cfg.Redis.WriteRetryBackoff,
+		},
+		Background: background,
+		Counts:     productCounts,
+		Audit:      auditLogger,
+	}
+	createUseCase := usecase.NewCreateProductUseCaseWithOptions(writeRepo, cacheRepo, cacheKeys, appLogger, createOptions)
+	updateUseCase := usecase.NewUpdateProductUseCaseWithOptions(writeRepo, cacheRepo, cacheKeys, appLogger, usecase.UpdateProductOptions{
+		SerializationRetries: cfg.Database.SerializationRetries,
+		RetryBackoff:         cfg.Database.RetryBackoff,
+		AllowedCategories:    allowedCategories,
+		PriceRounding:        priceRounding,
+		MaxSpecDepth:         cfg.App.MaxSpecDepth,
+		Events:               changePublisher,
+		Audit:                auditLogger,
+	})
+	stockUseCase := usecase.NewUpdateStockUseCaseWithOptions(writeRepo, cacheRepo, cacheKeys, appLogger, usecase.UpdateStockOptions{
+		Events:               changePublisher,
+		SerializationRetries: cfg.Database.SerializationRetries,
+		RetryBackoff:         cfg.Database.RetryBackoff,
+	})
+	tagUseCase := usecase.NewProductTagsUseCaseWithOptions(writeRepo, cacheRepo, cacheKeys, appLogger, usecase.ProductTagsOptions{
+		Events: changePublisher,
+	})
+	deleteUseCase := usecase.NewDeleteProductUseCaseWithOptions(writeRepo, cacheRepo, cacheKeys, appLogger, usecase.DeleteProductOptions{
+		Background: background,
+		Events:     changePublisher,
+		Counts:     productCounts,
+		Audit:      auditLogger,
+	})
+	getUseCase := usecase.NewGetProductUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, appLogger, usecase.GetProductOptions{
+		MaxStaleness:     cfg.Redis.CacheMaxStaleness,
+		RevalidateAfter:  cfg.Redis.CacheRevalidateAfter,
+		RevalidationRepo: reconcileRepo,
+		FallbackRecorder: fallbackRecorder,
+		CacheRecorder:    cacheRecorder,
+		Background:       background,
+	})
+	listOptions := usecase.ListProductsOptions{
+		FallbackRecorder: fallbackRecorder,
+		CacheRecorder:    cacheRecorder,
+		Background:       background,
+	}
+	var listBackfill *usecase.ListCacheBackfill
+	if cfg.Redis.BackfillEnabled {
+		listBackfill = usecase.NewListCacheBackfill(productRepo, cacheRepo, cacheKeys, appLogger, usecase.ListCacheBackfillOptions{
+			BatchSize:  cfg.Redis.BackfillBatchSize,
+			BatchDelay: cfg.Redis.BackfillBatchDelay,
+			Cooldown:   cfg.Redis.BackfillCooldown,
+			Background: background,
+		})
+		listOptions.Backfill = listBackfill
+		log.Info("list cache backfill enabled",
+			zap.Int("batch_size", cfg.Redis.BackfillBatchSize),
+			zap.Duration("batch_delay", cfg.Redis.BackfillBatchDelay),
+		)
+	}
+	listUseCase := usecase.NewListProductsUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, appLogger, listOptions)
+	searchOptions := usecase.SearchProductsOptions{
+		FallbackRecorder: fallbackRecorder,
+		CacheRecorder:    cacheRecorder,
+		Background:       background,
+		Backfill:         listOptions.Backfill,
+	}
+	searchByNameUseCase := usecase.NewSearchProductsByNameUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, appLogger, searchOptions)
+	searchByCategoryUseCase := usecase.NewSearchProductsByCategoryUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, appLogger, searchOptions)
+	searchByTagUseCase := usecase.NewSearchProductsByTagUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, appLogger, searchOptions)
+	strictCache, err := usecase.ParseStrictCacheOperations(cfg.Redis.CacheStrictOperations)
+	if err != nil {
+		log.Fatal("invalid strict cache configuration", zap.Error(err))
+	}
+	warmOptions := usecase.SearchProductsOptions{
+		FallbackRecorder: fallbackRecorder,
+		PopulateCache:    true,
+		StrictCache:      strictCache.Has(usecase.StrictCacheWarm),
+	}
+	warmUseCase := usecase.NewWarmSearchCacheUseCase(
+		usecase.NewSearchProductsByNameUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, appLogger, warmOptions),
+		usecase.NewSearchProductsByCategoryUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, appLogger, warmOptions),
+		appLogger,
+	)
+	importUseCase := usecase.NewImportProductsUseCase(writeRepo, cacheRepo, cacheKeys, appLogger, usecase.ImportProductsOptions{
+		ValidateTimestamps:     cfg.Import.ValidateTimestamps,
+		MaxClockSkew:           cfg.Import.MaxClockSkew,
+		AllowedCategories:      allowedCategories,
+		IDFields:               idFields,
+		ReferenceNormalization: referenceNormalization,
+		PriceRounding:          priceRounding,
+		MaxSpecDepth:           cfg.App.MaxSpecDepth,
+		Counts:                 productCounts,
+		Audit:                  auditLogger,
+		Events:                 changePublisher,
+	})
 
 	productHandler := handler.NewProductHandler(
 		createUseCase,
+		usecase.NewBulkCreateProductsUseCase(writeRepo, cacheRepo, cacheKeys, appLogger, createOptions),
 		updateUseCase,
+		usecase.NewPatchProductUseCase(updateUseCase),
+		stockUseCase,
+		usecase.NewBulkAdjustStockUseCaseWithOptions(writeRepo, cacheRepo, cacheKeys, appLogger, usecase.BulkAdjustStockOptions{
+			Events:               changePublisher,
+			SerializationRetries: cfg.Database.SerializationRetries,
+			RetryBackoff:         cfg.Database.RetryBackoff,
+		}),
+		usecase.NewAdjustStockUseCaseWithOptions(writeRepo, cacheRepo, cacheKeys, appLogger, usecase.AdjustStockOptions{
+			Events:               changePublisher,
+			SerializationRetries: cfg.Database.SerializationRetries,
+			RetryBackoff:         cfg.Database.RetryBackoff,
+		}),
+		tagUseCase,
 		deleteUseCase,
+		usecase.NewRestoreProductUseCase(writeRepo, cacheRepo, cacheKeys, appLogger, usecase.RestoreProductOptions{
+			Events: changePublisher,
+			Counts: productCounts,
+		}),
 		getUseCase,
+		usecase.NewGetProductBySKUUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, appLogger, usecase.GetProductBySKUOptions{
+			FallbackRecorder: fallbackRecorder,
+			CacheRecorder:    cacheRecorder,
+			Background:       background,
+		}),
+		usecase.NewBatchGetProductsUseCase(productRepo, cacheRepo, cacheKeys, appLogger, usecase.BatchGetProductsOptions{
+			FallbackRecorder: fallbackRecorder,
+			CacheRecorder:    cacheRecorder,
+			Background:       background,
+		}),
 		listUseCase,
+		usecase.NewCountProductsUseCaseWithOptions(productRepo, appLogger, usecase.CountProductsOptions{
+			Cache: productCounts,
+		}),
+		usecase.NewListProductsModifiedByUseCase(productRepo, appLogger),
+		usecase.NewRecentProductsUseCase(listUseCase),
 		searchByNameUseCase,
 		searchByCategoryUseCase,
+		searchByTagUseCase,
+		importUseCase,
 		log,
-	)
-	healthHandler := handler.NewHealthHandler(productRepo, cacheRepo, log)
-
+	).WithListResponseLimit(cfg.Server.MaxListResponseBytes).
+		WithListStreaming(cfg.Server.ListStreamFlushEvery).
+		WithPagination(cfg.Pagination.DefaultLimit, cfg.Pagination.MaxLimit).
+		WithBatchGetLimit(cfg.Server.BatchGetMaxIDs).
+		WithBulkLimit(cfg.Server.MaxBulkItems).
+		WithBodyLimit(cfg.Server.MaxBodyBytes).
+		WithBulkBodyLimit(cfg.Server.MaxBulkBodyBytes).
+		WithTrustedProxy(cfg.Server.TrustProxyHeaders).
+		WithGeoFeed(usecase.NewExportGeoFeedUseCase(productRepo, appLogger, usecase.ExportGeoFeedOptions{})).
+		WithErrorDetails(!cfg.App.IsProduction())
+	if cfg.Server.IdempotencyTTL > 0 {
+		idempotencyStore := cache.NewRedisIdempotencyStore(redisClient, cfg.Server.IdempotencyTTL)
+		if specCipher != nil {
+			idempotencyStore.WithCipher(specCipher)
+		}
+		productHandler.WithIdempotency(idempotencyStore)
+	}
+	if cfg.App.TombstoneResponses {
+		productHandler.WithTombstones(usecase.NewGetTombstoneUseCase(productRepo, appLogger), cfg.Keycloak.AdminRole)
+	}
 	jwtAuth := middleware.NewJWTAuth(&cfg.Keycloak, log)
 
+	// started só vira true depois do warmup de inicialização; até lá /api/v1
+	// responde 503 com Retry-After e /health/startup e /health/ready, 503.
+	var started atomic.Bool
+	healthHandler := handler.NewHealthHandler(productRepo, cacheRepo, jwtAuth, log).WithStartup(started.Load)
+	if cfg.Redis.ReadyIndexCheck {
+		healthHandler.WithIndexCheck(cacheKeys.AllProductsKey())
+	}
+	reconcileUseCase := usecase.NewReconcileCacheVersionsUseCase(reconcileRepo, cacheRepo, cacheKeys, appLogger)
+	cacheWarmer := usecase.NewCacheWarmerUseCase(productRepo, cacheRepo, cacheKeys, appLogger, usecase.CacheWarmerOptions{
+		BatchSize:  cfg.Redis.WarmBatchSize,
+		BatchDelay: cfg.Redis.WarmBatchDelay,
+		Background: background,
+	})
+	// A invalidação também consulta o banco sem o modo degradado, para
+	// descobrir os índices do produto.
+	cacheInvalidation := usecase.NewCacheInvalidationUseCase(reconcileRepo, cacheRepo, cacheKeys, appLogger)
+	adminHandler := handler.NewAdminHandler(warmUseCase, reconcileUseCase, log).
+		WithCacheWarmer(cacheWarmer).
+		WithCacheInvalidator(cacheInvalidation).
+		WithDeadLetters(deadLetters)
+
+	roleLimits, err := cfg.RateLimit.RoleLimits()
+	if err != nil {
+		log.Fatal("invalid rate limit configuration", zap.Error(err))
+	}
+	rateLimitMode, err := middleware.ParseRateLimitMode(cfg.RateLimit.Mode)
+	if err != nil {
+		log.Fatal("invalid rate limit configuration", zap.Error(err))
+	}
 	rateLimiter := middleware.NewRateLimiter(redisClient, middleware.RateLimitConfig{
 		Enabled:           cfg.RateLimit.Enabled,
 		RequestsPerWindow: cfg.RateLimit.RequestsPerWindow,
 		WindowSize:        cfg.RateLimit.WindowSize,
+		KeyMaxAge:         cfg.RateLimit.KeyMaxAge,
+		RoleLimits:        roleLimits,
+		Mode:              rateLimitMode,
 	}, log)
+	if cfg.RateLimit.Enabled && cfg.RateLimit.KeyCountInterval > 0 {
+		rateLimitRecorder, err := metrics.NewPrometheusRateLimitRecorder(prometheus.DefaultRegisterer)
+		if err != nil {
+			log.Fatal("failed to register metrics", zap.Error(err))
+		}
+		go rateLimiter.MonitorKeys(loopsCtx, cfg.RateLimit.KeyCountInterval, rateLimitRecorder)
+	}
 
 	log.Info("rate limiter configured",
 		zap.Bool("enabled", cfg.RateLimit.Enabled),
 		zap.Int("requests_per_window", cfg.RateLimit.RequestsPerWindow),
 		zap.Duration("window_size", cfg.RateLimit.WindowSize),
+		zap.String("mode", string(rateLimitMode)),
+		zap.Any("role_limits", roleLimits),
 	)
 
-	r := router.SetupRouter(productHandler, healthHandler, jwtAuth, rateLimiter, atomicLevel, log)
+	requestIDGenerator, err := middleware.NewRequestIDGenerator(cfg.App.RequestIDFormat)
+	if err != nil {
+		log.Fatal("invalid request id configuration", zap.Error(err))
+	}
+
+	logLevelAccess, err := router.ParseLogLevelAccess(cfg.App.LogLevelEndpointAccess())
+	if err != nil {
+		log.Fatal("invalid log level endpoint configuration", zap.Error(err))
+	}
+	if logLevelAccess == router.LogLevelPublic && cfg.App.IsProduction() {
+		log.Warn("log level endpoint is public in production")
+	}
+
+	routerOptions.RequestIDGenerator = requestIDGenerator
+	routerOptions.LogLevelAccess = logLevelAccess
+	routerOptions.AdminRole = cfg.Keycloak.AdminRole
+	routerOptions.WriteRole = cfg.Keycloak.WriteRole
+	routerOptions.Started = started.Load
+	routerOptions.StartupRetryAfter = cfg.Server.StartupRetryAfter
+	routerOptions.IgnoreUnknownFields = cfg.Server.IgnoreUnknownFields
+	routerOptions.SlowRequestThreshold = cfg.App.SlowRequestThreshold
+	routerOptions.QueryLimit = middleware.QueryLimitOptions{
+		MaxParams: cfg.Server.MaxQueryParams,
+		MaxBytes:  cfg.Server.MaxQueryBytes,
+	}
+	routerOptions.CacheStatusHeader = cfg.Server.CacheStatusHeader && !cfg.App.IsProduction()
+	if cfg.Server.CacheStatusHeader && cfg.App.IsProduction() {
+		log.Warn("SERVER_CACHE_STATUS_HEADER ignored in production")
+	}
+	if cfg.HTTPS.Enforce {
+		if cfg.App.IsDevelopment() {
+			log.Info("HTTPS enforcement skipped in development")
+		} else {
+			routerOptions.HTTPS = &middleware.HTTPSOptions{
+				Redirect:              cfg.HTTPS.Redirect,
+				HSTSMaxAge:            cfg.HTTPS.HSTSMaxAge,
+				HSTSIncludeSubdomains: cfg.HTTPS.HSTSIncludeSubdomains,
+				TrustProxy:            cfg.Server.TrustProxyHeaders,
+			}
+			log.Info("HTTPS enforcement enabled",
+				zap.Bool("redirect", cfg.HTTPS.Redirect),
+				zap.Duration("hsts_max_age", cfg.HTTPS.HSTSMaxAge),
+				zap.Bool("trust_proxy_headers", cfg.Server.TrustProxyHeaders),
+			)
+		}
+	}
+
+	// As respostas guardadas são o JSON já montado, com as specifications em
+	// claro: com REDIS_ENCRYPTED_SPECS, guardá-las desfaria a cifragem.
+	if cfg.Server.ResponseCacheEnabled && len(cfg.Redis.EncryptedSpecs) > 0 {
+		log.Warn("response cache disabled: REDIS_ENCRYPTED_SPECS is set and cached responses would hold decrypted specifications")
+	} else if cfg.Server.ResponseCacheEnabled {
+		if cfg.Server.ResponseCacheTTL <= 0 {
+			log.Fatal("invalid response cache configuration", zap.Duration("ttl", cfg.Server.ResponseCacheTTL))
+		}
+		routerOptions.ResponseCache = cache.NewRedisResponseCache(redisClient, cfg.Server.ResponseCacheTTL)
+		log.Info("response cache enabled", zap.Duration("ttl", cfg.Server.ResponseCacheTTL))
+	}
+	routerOptions.Compression = middleware.CompressOptions{
+		GzipLevel:   cfg.Compression.GzipLevel,
+		BrotliLevel: cfg.Compression.BrotliLevel,
+		MinSize:     cfg.Compression.MinSize,
+	}
+	if err := routerOptions.Compression.Validate(); err != nil {
+		log.Fatal("invalid compression configuration", zap.Error(err))
+	}
+
+	r := router.SetupRouter(productHandler, healthHandler, adminHandler, jwtAuth, rateLimiter, atomicLevel, log, routerOptions)
 
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.Server.Port),
@@ -139,6 +571,24 @@ func main() {
 		serverErrors <- srv.ListenAndServe()
 	}()
 
+	go func() {
+		if *warmCache {
+			log.Info("startup: warming whole cache")
+			if report, err := cacheWarmer.WarmCache(loopsCtx); err != nil {
+				log.Warn("startup: cache warm failed", zap.Error(err))
+			} else {
+				log.Info("startup: cache warmed",
+					zap.Int("products", report.Products),
+					zap.Int("failed", report.Failed),
+					zap.Int("indexes", report.Indexes),
+				)
+			}
+		}
+		warmOnStartup(loopsCtx, warmUseCase, cfg.Redis, log)
+		started.Store(true)
+		log.Info("startup finished, accepting api traffic")
+	}()
+
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
 
@@ -149,9 +599,13 @@ func main() {
 	case sig := <-shutdown:
 		log.Info("shutdown signal received", zap.String("signal", sig.String()))
 
+		// Ordem: para de aceitar requisições e drena as em andamento, drena as
+		// tarefas em background, para os loops periódicos e só então fecha
+		// Redis e, por último, o pool do banco.
 		ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 		defer cancel()
 
+		log.Info("shutdown: stopping http server", zap.Duration("timeout", cfg.Server.ShutdownTimeout))
 		if err := srv.Shutdown(ctx); err != nil {
 			log.Error("graceful shutdown failed", zap.Error(err))
 			if err := srv.Close(); err != nil {
@@ -159,6 +613,43 @@ func main() {
 			}
 		}
 
+		log.Info("shutdown: draining background tasks", zap.Duration("timeout", cfg.Server.ShutdownDrainTimeout))
+		if listBackfill != nil {
+			listBackfill.Close()
+		}
+		cacheWarmer.Close()
+		drainCtx, cancelDrain := context.WithTimeout(context.Background(), cfg.Server.ShutdownDrainTimeout)
+		defer cancelDrain()
+		if err := background.Wait(drainCtx); err != nil {
+			log.Warn("shutdown: background tasks did not finish in time", zap.Error(err))
+		}
+		if webhookPublisher != nil {
+			log.Info("shutdown: flushing change events")
+			if err := webhookPublisher.Close(drainCtx); err != nil {
+				log.Warn("shutdown: change events not flushed in time", zap.Error(err))
+			}
+		}
+		if auditWriter != nil {
+			log.Info("shutdown: flushing audit entries")
+			if err := auditWriter.Close(drainCtx); err != nil {
+				log.Warn("shutdown: audit entries not flushed in time", zap.Error(err))
+			}
+		}
+		stopLoops()
+
+		log.Info("shutdown: closing redis")
+		if replicaPool != nil {
+			if err := replicaPool.Close(); err != nil {
+				log.Error("failed to close redis read replicas", zap.Error(err))
+			}
+		}
+		if err := redisClient.Close(); err != nil {
+			log.Error("failed to close redis", zap.Error(err))
+		}
+
+		log.Info("shutdown: closing database pool")
+		dbPool.Close()
+
 		log.Info("server stopped gracefully")
 	}
 }
@@ -175,6 +666,7 @@ func initDatabase(cfg config.DatabaseConfig) (*pgxpool.Pool, error) {
 	poolConfig.MaxConns = int32(cfg.MaxOpenConns)
 	poolConfig.MinConns = int32(cfg.MaxIdleConns)
 	poolConfig.MaxConnLifetime = cfg.ConnMaxLifetime
+	poolConfig.ConnConfig.Tracer = database.TimingTracer{}
 
 	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
@@ -200,6 +692,7 @@ func initRedis(cfg config.RedisConfig) (*redis.Client, error) {
 		ReadTimeout:  3 * time.Second,
 		WriteTimeout: 3 * time.Second,
 	})
+	client.AddHook(cache.TimingHook{})
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -211,3 +704,114 @@ func initRedis(cfg config.RedisConfig) (*redis.Client, error) {
 
 	return client, nil
 }
+
+// initSerializer retorna o serializer de REDIS_SERIALIZER, decorado com a
+// cifragem das specifications quando REDIS_ENCRYPTED_SPECS estiver definida e
+// com a compressão quando REDIS_COMPRESS_THRESHOLD for positivo. O cipher,
+// quando configurado, também é retornado para cifrar as respostas
+// idempotentes.
+func initSerializer(cfg config.RedisConfig, log *zap.Logger) (cache.Serializer, *cache.SpecCipher, error) {
+	serializer, err := cache.NewSerializer(cfg.Serializer)
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Info("redis serializer", zap.String("serializer", serializer.Name()))
+
+	var specCipher *cache.SpecCipher
+	if len(cfg.EncryptedSpecs) > 0 {
+		if specCipher, err = initSpecEncryption(cfg, log); err != nil {
+			return nil, nil, err
+		}
+		serializer = cache.NewEncryptingSerializer(serializer, specCipher)
+	}
+
+	if cfg.CompressThreshold > 0 {
+		serializer = cache.NewCompressingSerializer(serializer, cfg.CompressThreshold)
+		log.Info("redis payload compression enabled", zap.Int("threshold_bytes", cfg.CompressThreshold))
+	}
+	return serializer, specCipher, nil
+}
+
+func initSpecEncryption(cfg config.RedisConfig, log *zap.Logger) (*cache.SpecCipher, error) {
+	configured, err := cfg.SpecEncryptionKeys()
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]cache.EncryptionKey, 0, len(configured))
+	for _, key := range configured {
+		keys = append(keys, cache.EncryptionKey{ID: key.ID, Key: key.Key})
+	}
+
+	specCipher, err := cache.NewSpecCipher(cfg.EncryptedSpecs, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("redis specification encryption enabled",
+		zap.Strings("specs", cfg.EncryptedSpecs),
+		zap.String("active_key", keys[0].ID),
+		zap.Int("keys", len(keys)),
+	)
+	return specCipher, nil
+}
+
+func initReadReplicas(cfg config.RedisConfig, log *zap.Logger) (*cache.ReplicaPool, error) {
+	endpoints, err := cfg.ReadReplicaEndpoints()
+	if err != nil {
+		return nil, err
+	}
+	if len(endpoints) == 0 {
+		return nil, nil
+	}
+
+	replicas := make([]cache.ReadReplica, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		client := redis.NewClient(&redis.Options{
+			Addr:         endpoint.Addr,
+			Password:     cfg.Password,
+			DB:           cfg.DB,
+			MaxRetries:   cfg.MaxRetries,
+			PoolSize:     cfg.PoolSize,
+			DialTimeout:  5 * time.Second,
+			ReadTimeout:  3 * time.Second,
+			WriteTimeout: 3 * time.Second,
+		})
+		client.AddHook(cache.TimingHook{})
+
+		replicas = append(replicas, cache.ReadReplica{
+			Name:   endpoint.Addr,
+			Client: client,
+			Weight: endpoint.Weight,
+		})
+	}
+
+	pool := cache.NewReplicaPool(replicas, log)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	pool.CheckHealth(ctx)
+
+	return pool, nil
+}
+
+// warmOnStartup aquece as buscas configuradas antes de a API aceitar tráfego.
+// Falhas só são logadas: um warmup incompleto não deve manter a API fora.
+func warmOnStartup(ctx context.Context, warmer port.SearchWarmer, cfg config.RedisConfig, log *zap.Logger) {
+	if len(cfg.StartupWarmNames) == 0 && len(cfg.StartupWarmCategories) == 0 {
+		return
+	}
+
+	log.Info("startup: warming search cache",
+		zap.Int("names", len(cfg.StartupWarmNames)),
+		zap.Int("categories", len(cfg.StartupWarmCategories)),
+	)
+	report, err := warmer.Execute(ctx, port.WarmupInput{
+		Names:      cfg.StartupWarmNames,
+		Categories: cfg.StartupWarmCategories,
+	})
+	if err != nil {
+		log.Warn("startup: search cache warmup failed", zap.Error(err))
+		return
+	}
+	log.Info("startup: search cache warmed", zap.Int("products", report.Warmed))
+}
@@ -23,48 +23,26 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/products": {
-            "get": {
+        "/api/v1/admin/cache": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retorna uma lista paginada de produtos",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Remove só as chaves de cache (produtos, índices, contagem e respostas guardadas), com SCAN e UNLINK. Idempotência, dead letter do webhook e rate limiting ficam intactos. O catálogo volta aos poucos pelas leituras ou de uma vez por /api/v1/admin/cache/warm.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
-                ],
-                "summary": "Listar produtos",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 50,
-                        "description": "Limite de resultados (máx 5000)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset para paginação",
-                        "name": "offset",
-                        "in": "query"
-                    }
+                    "admin"
                 ],
+                "summary": "Esvaziar o cache",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.ProductResponse"
-                            }
+                            "$ref": "#/definitions/dto.CacheInvalidationResponse"
                         }
                     },
                     "401": {
@@ -73,53 +51,56 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/v1/admin/cache/products/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Cria um novo produto no sistema",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Remove do Redis a entrada do produto e tira o ID de all_products e dos índices de nome, categoria e tag, sem alterar o banco. Os índices são descobertos pelo produto no banco e pela cópia em cache. A próxima leitura recarrega o produto do banco.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "admin"
                 ],
-                "summary": "Criar produto",
+                "summary": "Invalidar o cache de um produto",
                 "parameters": [
                     {
-                        "description": "Dados do produto",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateProductRequest"
-                        }
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ProductResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.CacheInvalidationResponse"
                         }
                     },
                     "401": {
@@ -128,8 +109,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -139,75 +120,58 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/products/search/category": {
-            "get": {
+        "/api/v1/admin/cache/warm": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retorna produtos que correspondem à categoria especificada",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Dispara em background a recarga de todos os produtos do banco no Redis, em lotes de REDIS_WARM_BATCH_SIZE, reconstruindo os índices all_products, de nome, de categoria e de tag ao final. O resultado vai para o log. Responde 409 se já houver um aquecimento em andamento.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
-                ],
-                "summary": "Buscar produtos por categoria",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Nome da categoria",
-                        "name": "q",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 50,
-                        "description": "Limite de resultados (máx 5000)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset para paginação",
-                        "name": "offset",
-                        "in": "query"
-                    }
+                    "admin"
                 ],
+                "summary": "Aquecer o cache inteiro",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.ProductResponse"
-                            }
+                            "$ref": "#/definitions/dto.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -215,14 +179,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/products/search/name": {
-            "get": {
+        "/api/v1/admin/reconcile/versions": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retorna produtos que correspondem ao termo de busca no nome",
+                "description": "Sorteia produtos de all_products e compara a versão em cache com a do banco. Entradas com versão atrás da do banco são removidas do cache (recarregadas na próxima leitura); as de produtos removidos saem também dos índices. Com dry_run, só reporta. Amostra padrão de 100, até 1000.",
                 "consumes": [
                     "application/json"
                 ],
@@ -230,40 +194,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "admin"
                 ],
-                "summary": "Buscar produtos por nome",
+                "summary": "Reconciliar versões do cache",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Termo de busca",
-                        "name": "q",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 50,
-                        "description": "Limite de resultados (máx 5000)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset para paginação",
-                        "name": "offset",
-                        "in": "query"
+                        "description": "Amostra",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReconcileRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.ProductResponse"
-                            }
+                            "$ref": "#/definitions/dto.ReconcileResponse"
                         }
                     },
                     "400": {
@@ -278,6 +226,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -287,14 +247,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/products/{id}": {
-            "get": {
+        "/api/v1/admin/warm": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retorna um produto específico pelo ID",
+                "description": "Executa no servidor as buscas por nome e categoria informadas, gravando produtos e índices no Redis antes de um pico de tráfego. Até 100 buscas por requisição. Com \"warm\" em REDIS_CACHE_STRICT_OPERATIONS, uma falha do Redis aborta com 500 cache_unavailable.",
                 "consumes": [
                     "application/json"
                 ],
@@ -302,23 +262,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "admin"
                 ],
-                "summary": "Buscar produto por ID",
+                "summary": "Pré-aquecer buscas no cache",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID do produto",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Buscas a aquecer",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.WarmupRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ProductResponse"
+                            "$ref": "#/definitions/dto.WarmupResponse"
                         }
                     },
                     "400": {
@@ -333,8 +295,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -346,47 +314,37 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/v1/admin/webhook/dead-letters": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Atualiza um produto existente pelo ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lista, do mais antigo ao mais novo, os eventos de mudança que o webhook não entregou depois das novas tentativas, com o último erro. Não remove os eventos. Padrão de 100, até 1000.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "admin"
                 ],
-                "summary": "Atualizar produto",
+                "summary": "Listar eventos não entregues",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID do produto",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Dados atualizados do produto",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateProductRequest"
-                        }
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Quantidade de eventos (máx 1000)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ProductResponse"
+                            "$ref": "#/definitions/dto.DeadLetterListResponse"
                         }
                     },
                     "400": {
@@ -401,57 +359,56 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/admin/webhook/dead-letters/replay": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove um produto pelo ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Reenvia ao webhook, na ordem em que falharam, até limit eventos do dead letter, sem novas tentativas. Na primeira falha os eventos restantes voltam ao dead letter e a resposta é 502. Padrão de 100, até 1000.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "admin"
                 ],
-                "summary": "Deletar produto",
+                "summary": "Reenviar eventos não entregues",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID do produto",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Quantidade de eventos (máx 1000)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.SuccessResponse"
+                            "$ref": "#/definitions/dto.DeadLetterReplayResponse"
                         }
                     },
                     "400": {
@@ -466,8 +423,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -477,66 +434,2943 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/health/live": {
-            "get": {
-                "description": "Verifica se a aplicação está rodando",
-                "consumes": [
-                    "application/json"
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/whoami": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Retorna as claims decodificadas do JWT validado (subject, email e roles), para depurar integrações",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "admin"
                 ],
-                "summary": "Liveness check",
+                "summary": "Claims do token atual",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.HealthResponse"
+                            "$ref": "#/definitions/handler.WhoAmIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/health/ready": {
+        "/api/v1/products": {
             "get": {
-                "description": "Verifica se a aplicação está pronta para receber requisições (database e cache)",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna uma página de produtos com o total do conjunto completo (dto.PaginatedResponse). Com format=array, retorna o array simples antigo. Com SERVER_MAX_LIST_RESPONSE_BYTES definido, a resposta é um dto.ProductListResponse com meta.truncated e meta.total. Com modified_by (somente admin), retorna os produtos alterados por último por esse usuário, segundo a trilha de auditoria, do mais recente para o mais antigo.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/xml"
                 ],
                 "tags": [
-                    "health"
+                    "products"
+                ],
+                "summary": "Listar produtos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subject (claim sub) do autor da última alteração; exige o role de admin",
+                        "name": "modified_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "created_at",
+                            "updated_at",
+                            "stock"
+                        ],
+                        "type": "string",
+                        "description": "Campo de ordenação (padrão created_at decrescente)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sentido da ordenação; com sort e sem order, asc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "true traz só produtos com estoque; false, só os esgotados",
+                        "name": "in_stock",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Preço mínimo em centavos (inclusivo)",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Preço máximo em centavos (inclusivo)",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Peso mínimo em gramas (inclusivo); exclui produtos sem peso",
+                        "name": "min_weight",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Peso máximo em gramas (inclusivo); exclui produtos sem peso",
+                        "name": "max_weight",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Volume mínimo em cm³ (inclusivo); exclui produtos sem as três medidas",
+                        "name": "min_volume",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Volume máximo em cm³ (inclusivo); exclui produtos sem as três medidas",
+                        "name": "max_volume",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "array"
+                        ],
+                        "type": "string",
+                        "description": "array mantém o formato antigo, sem total",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limite de resultados (acima do máximo configurado, usa o máximo)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset para paginação",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fuso IANA para created_at e updated_at (padrão UTC)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caminhos em specifications separados por vírgula (ex.: specifications.color)",
+                        "name": "fields",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Readiness check",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.HealthResponse"
+                            "$ref": "#/definitions/dto.PaginatedResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links first, prev e next (RFC 8288)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "503": {
                         "description": "Service Unavailable",
                         "schema": {
-                            "$ref": "#/definitions/handler.HealthResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cria um novo produto no sistema e responde com Location e ETag do produto criado. Campos inválidos respondem 422 com as falhas de todos eles em fields. O ID de um produto excluído responde 409 product_deleted: a criação não restaura, use POST /api/v1/products/{id}/restore. Com Idempotency-Key, uma repetição com o mesmo corpo devolve a resposta gravada da primeira, com os mesmos Location e ETag (e Idempotent-Replayed: true); uma repetição com outro corpo responde 422 idempotency_key_reused e uma com a primeira ainda em curso responde 409 request_in_progress.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Criar produto",
+                "parameters": [
+                    {
+                        "description": "Dados do produto",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateProductRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave de idempotência, isolada por usuário (máx 255 caracteres)",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fuso IANA para created_at e updated_at (padrão UTC)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caminhos em specifications separados por vírgula (ex.: specifications.color)",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do produto criado"
+                            },
+                            "Location": {
+                                "type": "string",
+                                "description": "URL do produto criado"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.CreateProductRequest": {
-            "description": "Dados para criação de um novo produto",
+        },
+        "/api/v1/products/batch-get": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Busca os produtos dos IDs informados numa requisição só, primeiro no Redis e depois, para os que faltarem, numa única consulta ao PostgreSQL. A resposta é um mapa pelo ID pedido, na ordem do pedido, com null nos IDs não encontrados. Mais IDs que o limite configurado (SERVER_BATCH_GET_MAX_IDS, sem passar de MAX_BULK_ITEMS) respondem 400.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Buscar vários produtos por ID",
+                "parameters": [
+                    {
+                        "description": "IDs dos produtos",
+                        "name": "ids",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BatchGetRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fuso IANA para created_at e updated_at (padrão UTC)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caminhos em specifications separados por vírgula (ex.: specifications.color)",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BatchGetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cria vários produtos numa única ida ao banco. Cada item é validado como na criação unitária e o resultado vem por índice, com o status e o código de erro que a criação unitária teria retornado. Retorna 201 quando todos são criados e 207 quando algum falha.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Criar produtos em lote",
+                "parameters": [
+                    {
+                        "description": "Produtos (máx MAX_BULK_ITEMS, padrão 500)",
+                        "name": "products",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CreateProductRequest"
+                            }
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fuso IANA para created_at e updated_at (padrão UTC)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caminhos em specifications separados por vírgula (ex.: specifications.color)",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BulkCreateResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BulkCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/count": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna o total de produtos ativos sem trazer as linhas; com category, o total da categoria. O total geral vem de um cache curto, invalidado a cada criação ou exclusão.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Contar produtos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nome da categoria",
+                        "name": "category",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/geo": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Exporta como FeatureCollection GeoJSON os produtos cujas specifications têm lat e lng numéricos e dentro das faixas (lat entre -90 e 90, lng entre -180 e 180). Produtos sem coordenadas, ou com coordenadas inválidas, ficam de fora. O catálogo é lido do banco em páginas e a resposta é escrita à medida que os produtos chegam.",
+                "produces": [
+                    "application/geo+json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Feed GeoJSON de produtos",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GeoFeatureCollection"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Importa um lote de produtos preservando created_at/updated_at do sistema de origem. Linhas inválidas (timestamps malformados, no futuro ou updated_at anterior a created_at) são rejeitadas individualmente e listadas no relatório.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Importar produtos",
+                "parameters": [
+                    {
+                        "description": "Lote de produtos (máx MAX_BULK_ITEMS, padrão 500)",
+                        "name": "products",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportProductsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/recent": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna os produtos criados mais recentemente (created_at decrescente), para vitrines de novidades. Servido pelo índice all_products do cache quando disponível.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Produtos recentes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Quantidade de produtos (máx 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fuso IANA para created_at e updated_at (padrão UTC)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caminhos em specifications separados por vírgula (ex.: specifications.color)",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ProductResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/search/category": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna produtos que correspondem à categoria especificada, com o total da categoria (dto.PaginatedResponse); com format=array, o array simples antigo. A resposta traz um ETag fraco derivado dos IDs e versões; com If-None-Match igual, responde 304.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Buscar produtos por categoria",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nome da categoria",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "created_at",
+                            "updated_at",
+                            "stock"
+                        ],
+                        "type": "string",
+                        "description": "Campo de ordenação (padrão created_at decrescente)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sentido da ordenação; com sort e sem order, asc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Preço mínimo em centavos (inclusivo)",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Preço máximo em centavos (inclusivo)",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "array"
+                        ],
+                        "type": "string",
+                        "description": "array mantém o formato antigo, sem total",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limite de resultados (acima do máximo configurado, usa o máximo)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset para paginação",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag de uma resposta anterior",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fuso IANA para created_at e updated_at (padrão UTC)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caminhos em specifications separados por vírgula (ex.: specifications.color)",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaginatedResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links first, prev e next (RFC 8288)"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Resultado inalterado"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/search/name": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna produtos que correspondem ao termo de busca no nome, com o total de correspondências (dto.PaginatedResponse); com format=array, o array simples antigo. A resposta traz um ETag fraco derivado dos IDs e versões; com If-None-Match igual, responde 304.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Buscar produtos por nome",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Termo de busca",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "relevance",
+                            "name",
+                            "created_at",
+                            "updated_at",
+                            "stock"
+                        ],
+                        "type": "string",
+                        "default": "name",
+                        "description": "Ordenação: relevance (exato, prefixo, contém; alfabética dentro de cada faixa) ou um campo",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sentido da ordenação; com sort e sem order, asc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Preço mínimo em centavos (inclusivo)",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Preço máximo em centavos (inclusivo)",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "array"
+                        ],
+                        "type": "string",
+                        "description": "array mantém o formato antigo, sem total",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limite de resultados (acima do máximo configurado, usa o máximo)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset para paginação",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag de uma resposta anterior",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fuso IANA para created_at e updated_at (padrão UTC)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caminhos em specifications separados por vírgula (ex.: specifications.color)",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaginatedResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links first, prev e next (RFC 8288)"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Resultado inalterado"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/search/tag": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna os produtos marcados com a tag, do mais novo para o mais antigo. A tag é comparada sem diferenciar maiúsculas. A resposta traz um ETag fraco derivado dos IDs e versões; com If-None-Match igual, responde 304.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Buscar produtos por tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limite de resultados (acima do máximo configurado, usa o máximo)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset para paginação",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag de uma resposta anterior",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fuso IANA para created_at e updated_at (padrão UTC)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caminhos em specifications separados por vírgula (ex.: specifications.color)",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ProductResponse"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links first, prev e next (RFC 8288)"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Resultado inalterado"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/sku/{sku}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna o produto com o SKU, sem diferenciar maiúsculas. SKUs não são únicos: havendo mais de um produto com o SKU, retorna o cadastrado primeiro.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Buscar produto por SKU",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SKU do produto",
+                        "name": "sku",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fuso IANA para created_at e updated_at (padrão UTC)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caminhos em specifications separados por vírgula (ex.: specifications.color)",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/stock/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soma a variação (delta) ao estoque de cada produto, para reconciliações de armazém. Cada item é aplicado atomicamente e de forma independente: itens que deixariam o estoque negativo ou de produtos inexistentes são recusados e listados no relatório, sem afetar os demais. A versão de cada produto ajustado é incrementada e as entradas em cache são ajustadas no próprio Redis. Uma falha do banco no meio do lote o interrompe: o item atual e os seguintes vêm no relatório como não aplicados.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Ajustar estoque em lote",
+                "parameters": [
+                    {
+                        "description": "Ajustes (máx MAX_BULK_ITEMS, padrão 500)",
+                        "name": "adjustments",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BulkStockAdjustmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BulkStockAdjustmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna um produto específico pelo ID. A resposta traz o ETag fraco W/\"\u003cid\u003e-\u003cversão\u003e\"; com If-None-Match igual, responde 304 sem corpo. Com PRODUCT_TOMBSTONE_RESPONSES, um produto excluído logicamente responde 410 com id e deleted_at a usuários com o admin role; para os demais, e para IDs que nunca existiram, a resposta é 404.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Buscar produto por ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fuso IANA para created_at e updated_at (padrão UTC)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caminhos em specifications separados por vírgula (ex.: specifications.color)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag de uma resposta anterior",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Produto não mudou desde o ETag informado"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TombstoneResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Atualiza um produto existente pelo ID. Campos inválidos respondem 422 com as falhas de todos eles em fields. Com If-Match, responde 412 se o produto já estiver em outra versão.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Atualizar produto",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Versão esperada do produto (ETag)",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Dados atualizados do produto",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateProductRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fuso IANA para created_at e updated_at (padrão UTC)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caminhos em specifications separados por vírgula (ex.: specifications.color)",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove um produto pelo ID. A exclusão é lógica: o produto some das leituras e do cache, mas pode ser restaurado. Com If-Match (o ETag do produto ou \"\u003cversion\u003e\"), só exclui se a versão atual no banco for a informada, respondendo 409 version_conflict caso contrário.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Deletar produto",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag do produto ou versão esperada, entre aspas duplas",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Altera só os campos enviados; ausentes ou null mantêm o valor atual. Com If-Match, responde 412 se o produto já estiver em outra versão.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Atualizar produto parcialmente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Versão esperada do produto (ETag)",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Campos a alterar",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PatchProductRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fuso IANA para created_at e updated_at (padrão UTC)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caminhos em specifications separados por vírgula (ex.: specifications.color)",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Desfaz a exclusão lógica de um produto e o devolve ao cache e aos índices. Restaurar um produto ativo não é erro.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Restaurar produto",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fuso IANA para created_at e updated_at (padrão UTC)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caminhos em specifications separados por vírgula (ex.: specifications.color)",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}/stock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soma a variação (delta) ao estoque do produto num único UPDATE atômico, sem a leitura prévia que faria ajustes concorrentes se sobrescreverem. Se o estoque ficaria negativo, nada muda e a resposta é 409 insufficient_stock. A versão é incrementada e estoque e versão da entrada em cache são ajustados no próprio Redis.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Ajustar estoque",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variação do estoque",
+                        "name": "delta",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AdjustStockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AdjustStockResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Altera apenas o estoque do produto e incrementa a versão, para que um PUT ou PATCH concorrente não desfaça a mudança. Estoque e versão da entrada em cache são ajustados no próprio Redis.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Atualizar estoque",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Novo estoque",
+                        "name": "stock",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateStockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Estoque atualizado"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}/tags": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adiciona uma tag ao produto e incrementa a versão. Adicionar uma tag já presente não é erro e não muda a versão.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Adicionar tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag a adicionar",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AddTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Tag adicionada"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}/tags/{tag}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove uma tag do produto e incrementa a versão. Remover uma tag ausente não é erro e não muda a versão.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Remover tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag a remover",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Tag removida"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/detailed": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Verifica database, cache e Keycloak (JWKS) em paralelo, com timeout compartilhado, retornando status, latência e último erro de cada dependência. Exige o admin role, já que expõe latências e mensagens de erro das dependências",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Detailed health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.DetailedHealthResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.DetailedHealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/live": {
+            "get": {
+                "description": "Verifica se a aplicação está rodando",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Verifica se a aplicação está pronta para receber requisições (database e cache). Com REDIS_READY_INDEX_CHECK, um índice all_products vazio com produtos no banco responde 200 com status degraded",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/startup": {
+            "get": {
+                "description": "Verifica se a inicialização (warmup do cache) terminou; até lá as rotas /api/v1 respondem 503 com Retry-After",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Startup check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.HealthResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AddTagRequest": {
+            "description": "Tag a adicionar ao produto",
+            "type": "object",
+            "properties": {
+                "tag": {
+                    "type": "string",
+                    "example": "promo"
+                }
+            }
+        },
+        "dto.AdjustStockRequest": {
+            "description": "Variação (positiva ou negativa) somada ao estoque atual",
+            "type": "object",
+            "properties": {
+                "delta": {
+                    "type": "integer",
+                    "example": -3
+                }
+            }
+        },
+        "dto.AdjustStockResponse": {
+            "description": "Produto e estoque após o ajuste",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "01HN8Z9QXXXXXXXXXXXXXXXXXX"
+                },
+                "stock": {
+                    "type": "integer",
+                    "example": 97
+                }
+            }
+        },
+        "dto.BatchGetRequest": {
+            "description": "IDs dos produtos a buscar",
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "01HQZX3Y4K5M6N7P8Q9R0S1T2V",
+                        "01HQZX3Y4K5M6N7P8Q9R0S1T2W"
+                    ]
+                }
+            }
+        },
+        "dto.BatchGetResponse": {
+            "description": "Produtos indexados pelo ID pedido; IDs não encontrados vêm com null",
+            "type": "object",
+            "properties": {
+                "products": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/dto.ProductResponse"
+                    }
+                }
+            }
+        },
+        "dto.BulkCreateItemResponse": {
+            "description": "Resultado de um item; status é o código HTTP que a criação unitária teria retornado",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "product_exists"
+                },
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Product already exists"
+                },
+                "product": {
+                    "$ref": "#/definitions/dto.ProductResponse"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 201
+                }
+            }
+        },
+        "dto.BulkCreateResponse": {
+            "description": "Resultado da criação em lote",
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BulkCreateItemResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.BulkStockAdjustmentRequest": {
+            "description": "Ajustes de estoque de uma reconciliação de armazém",
+            "type": "object",
+            "properties": {
+                "adjustments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StockAdjustmentRequest"
+                    }
+                }
+            }
+        },
+        "dto.BulkStockAdjustmentResponse": {
+            "description": "Resultado do ajuste de estoque em lote",
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StockAdjustmentResultResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.CacheInvalidationResponse": {
+            "description": "Quantidade de chaves removidas ou alteradas no Redis",
+            "type": "object",
+            "properties": {
+                "keys_affected": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.CountResponse": {
+            "description": "Total de produtos ativos; com category, só os da categoria",
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "Electronics"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1250
+                }
+            }
+        },
+        "dto.CreateProductRequest": {
+            "description": "Dados para criação de um novo produto",
+            "type": "object",
+            "properties": {
+                "brand": {
+                    "type": "string",
+                    "example": "Apple"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "electronics"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Smartphone Apple com chip A17 Pro"
+                },
+                "dimensions": {
+                    "$ref": "#/definitions/dto.Dimensions"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "https://example.com/image1.jpg",
+                        "https://example.com/image2.jpg"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "iPhone 15 Pro"
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 999900
+                },
+                "price_amount": {
+                    "type": "string",
+                    "example": "9999.00"
+                },
+                "reference_number": {
+                    "type": "string",
+                    "example": "REF-12345"
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "SKU-IP15P-256"
+                },
+                "specifications": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "stock": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "promo",
+                        "apple"
+                    ]
+                },
+                "thumbnail_url": {
+                    "type": "string",
+                    "example": "https://example.com/thumb.jpg"
+                },
+                "weight_grams": {
+                    "type": "integer",
+                    "example": 187
+                }
+            }
+        },
+        "dto.DeadLetterListResponse": {
+            "description": "Eventos no dead letter do webhook, do mais antigo ao mais novo",
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DeadLetterResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "dto.DeadLetterReplayResponse": {
+            "description": "Quantidade de eventos reenviados com sucesso",
+            "type": "object",
+            "properties": {
+                "replayed": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "dto.DeadLetterResponse": {
+            "description": "Evento não entregue, com o último erro e o momento da falha",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "webhook responded 502"
+                },
+                "failed_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:02Z"
+                },
+                "occurred_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "product.updated"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.Dimensions": {
+            "description": "Comprimento, largura e altura em milímetros; zero é não informado",
+            "type": "object",
+            "properties": {
+                "height_mm": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "length_mm": {
+                    "type": "integer",
+                    "example": 160
+                },
+                "width_mm": {
+                    "type": "integer",
+                    "example": 77
+                }
+            }
+        },
+        "dto.ErrorResponse": {
+            "description": "Estrutura de resposta de erro da API",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "400"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "failed to save product: connection refused",
+                        "connection refused"
+                    ]
+                },
+                "error": {
+                    "type": "string",
+                    "example": "validation_error"
+                },
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Invalid request body"
+                }
+            }
+        },
+        "dto.GeoFeature": {
+            "description": "Ponto do produto, com coordenadas em [lng, lat]",
+            "type": "object",
+            "properties": {
+                "geometry": {
+                    "$ref": "#/definitions/dto.GeoPoint"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "properties": {
+                    "$ref": "#/definitions/dto.GeoProperties"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "Feature"
+                }
+            }
+        },
+        "dto.GeoFeatureCollection": {
+            "description": "FeatureCollection com um ponto por produto",
+            "type": "object",
+            "properties": {
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GeoFeature"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "example": "FeatureCollection"
+                }
+            }
+        },
+        "dto.GeoPoint": {
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        -46.6333,
+                        -23.5505
+                    ]
+                },
+                "type": {
+                    "type": "string",
+                    "example": "Point"
+                }
+            }
+        },
+        "dto.GeoProperties": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "brand": {
+                    "type": "string",
+                    "example": "Apple"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "electronics"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "iPhone 15 Pro"
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 999900
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "SKU-IP15P-256"
+                },
+                "stock": {
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "dto.ImportProductRow": {
+            "description": "Produto com timestamps do sistema de origem (RFC 3339)",
+            "type": "object",
+            "properties": {
+                "brand": {
+                    "type": "string",
+                    "example": "Apple"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "electronics"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2023-01-10T08:00:00Z"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Smartphone Apple com chip A17 Pro"
+                },
+                "dimensions": {
+                    "$ref": "#/definitions/dto.Dimensions"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "https://example.com/image1.jpg",
+                        "https://example.com/image2.jpg"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "iPhone 15 Pro"
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 999900
+                },
+                "price_amount": {
+                    "type": "string",
+                    "example": "9999.00"
+                },
+                "reference_number": {
+                    "type": "string",
+                    "example": "REF-12345"
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "SKU-IP15P-256"
+                },
+                "specifications": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "stock": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "promo",
+                        "apple"
+                    ]
+                },
+                "thumbnail_url": {
+                    "type": "string",
+                    "example": "https://example.com/thumb.jpg"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2023-02-10T08:00:00Z"
+                },
+                "weight_grams": {
+                    "type": "integer",
+                    "example": 187
+                }
+            }
+        },
+        "dto.ImportProductsRequest": {
+            "description": "Lote de produtos para migração",
+            "type": "object",
+            "properties": {
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ImportProductRow"
+                    }
+                }
+            }
+        },
+        "dto.ImportReportResponse": {
+            "description": "Resultado da importação em lote",
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ImportRowErrorResponse"
+                    }
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "imported": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.ImportRowErrorResponse": {
+            "description": "Erro de uma linha da importação",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "product created_at is in the future"
+                },
+                "reference_number": {
+                    "type": "string",
+                    "example": "REF-12345"
+                },
+                "row": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dto.PaginatedResponse": {
+            "description": "Página de produtos com o total do conjunto completo",
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProductResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1234
+                }
+            }
+        },
+        "dto.PatchProductRequest": {
+            "description": "Só os campos enviados são alterados; ausentes ou null mantêm o valor atual",
             "type": "object",
             "properties": {
                 "brand": {
@@ -547,31 +3381,41 @@ const docTemplate = `{
                     "type": "string",
                     "example": "electronics"
                 },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
                 "description": {
                     "type": "string",
                     "example": "Smartphone Apple com chip A17 Pro"
                 },
+                "dimensions": {
+                    "$ref": "#/definitions/dto.Dimensions"
+                },
                 "images": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     },
                     "example": [
-                        "https://example.com/image1.jpg",
-                        "https://example.com/image2.jpg"
+                        "https://example.com/image1.jpg"
                     ]
                 },
                 "name": {
                     "type": "string",
-                    "example": "iPhone 15 Pro"
+                    "example": "iPhone 15 Pro Max"
                 },
-                "reference_number": {
+                "price": {
+                    "type": "integer",
+                    "example": 1099900
+                },
+                "price_amount": {
                     "type": "string",
-                    "example": "REF-12345"
+                    "example": "10999.00"
                 },
                 "sku": {
                     "type": "string",
-                    "example": "SKU-IP15P-256"
+                    "example": "SKU-IP15PM-256"
                 },
                 "specifications": {
                     "type": "object",
@@ -579,25 +3423,25 @@ const docTemplate = `{
                 },
                 "stock": {
                     "type": "integer",
-                    "example": 100
-                }
-            }
-        },
-        "dto.ErrorResponse": {
-            "description": "Estrutura de resposta de erro da API",
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "string",
-                    "example": "400"
+                    "example": 50
                 },
-                "error": {
-                    "type": "string",
-                    "example": "validation_error"
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "promo",
+                        "apple"
+                    ]
                 },
-                "message": {
+                "thumbnail_url": {
                     "type": "string",
-                    "example": "Invalid request body"
+                    "example": "https://example.com/thumb.jpg"
+                },
+                "weight_grams": {
+                    "type": "integer",
+                    "example": 221
                 }
             }
         },
@@ -605,6 +3449,12 @@ const docTemplate = `{
             "description": "Dados completos de um produto",
             "type": "object",
             "properties": {
+                "available": {
+                    "description": "Derivado do estoque; somente leitura",
+                    "type": "boolean",
+                    "readOnly": true,
+                    "example": true
+                },
                 "brand": {
                     "type": "string",
                     "example": "Apple"
@@ -617,10 +3467,17 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2024-01-15T10:30:00Z"
                 },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
                 "description": {
                     "type": "string",
                     "example": "Smartphone Apple com chip A17 Pro"
                 },
+                "dimensions": {
+                    "$ref": "#/definitions/dto.Dimensions"
+                },
                 "id": {
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
@@ -638,6 +3495,10 @@ const docTemplate = `{
                     "type": "string",
                     "example": "iPhone 15 Pro"
                 },
+                "price": {
+                    "type": "integer",
+                    "example": 999900
+                },
                 "reference_number": {
                     "type": "string",
                     "example": "REF-12345"
@@ -647,13 +3508,26 @@ const docTemplate = `{
                     "example": "SKU-IP15P-256"
                 },
                 "specifications": {
-                    "type": "object",
-                    "additionalProperties": true
+                    "$ref": "#/definitions/dto.SpecificationMap"
                 },
                 "stock": {
                     "type": "integer",
                     "example": 100
                 },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "promo",
+                        "apple"
+                    ]
+                },
+                "thumbnail_url": {
+                    "type": "string",
+                    "example": "https://example.com/thumb.jpg"
+                },
                 "updated_at": {
                     "type": "string",
                     "example": "2024-01-15T10:30:00Z"
@@ -661,6 +3535,110 @@ const docTemplate = `{
                 "version": {
                     "type": "integer",
                     "example": 1
+                },
+                "weight_grams": {
+                    "type": "integer",
+                    "example": 187
+                }
+            }
+        },
+        "dto.ReconcileRequest": {
+            "description": "Tamanho da amostra e modo somente leitura",
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "sample_size": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "dto.ReconcileResponse": {
+            "description": "Produtos sorteados, conferidos, defasados e removidos do cache",
+            "type": "object",
+            "properties": {
+                "checked": {
+                    "type": "integer",
+                    "example": 198
+                },
+                "evicted": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sampled": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "stale": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StaleEntryResponse"
+                    }
+                }
+            }
+        },
+        "dto.SpecificationMap": {
+            "type": "object",
+            "additionalProperties": true
+        },
+        "dto.StaleEntryResponse": {
+            "description": "Versões em cache e no banco; db_version 0 indica produto removido",
+            "type": "object",
+            "properties": {
+                "cached_version": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "db_version": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "dto.StockAdjustmentRequest": {
+            "description": "Produto e variação (positiva ou negativa) do estoque",
+            "type": "object",
+            "properties": {
+                "delta": {
+                    "type": "integer",
+                    "example": -3
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01HN8Z9QXXXXXXXXXXXXXXXXXX"
+                }
+            }
+        },
+        "dto.StockAdjustmentResultResponse": {
+            "description": "Resultado de um ajuste; stock é o estoque final ou, se recusado por ficar negativo, o atual",
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "delta": {
+                    "type": "integer",
+                    "example": -3
+                },
+                "error": {
+                    "type": "string",
+                    "example": "stock adjustment would make stock negative"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01HN8Z9QXXXXXXXXXXXXXXXXXX"
+                },
+                "stock": {
+                    "type": "integer",
+                    "example": 97
                 }
             }
         },
@@ -675,6 +3653,24 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.TombstoneResponse": {
+            "description": "Resposta 410 para admins: o produto existiu e foi excluído em deleted_at",
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "type": "string",
+                    "example": "2024-01-20T14:00:00Z"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "product_deleted"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01HN8Z9QXXXXXXXXXXXXXXXXXX"
+                }
+            }
+        },
         "dto.UpdateProductRequest": {
             "description": "Dados para atualização de um produto existente",
             "type": "object",
@@ -687,10 +3683,17 @@ const docTemplate = `{
                     "type": "string",
                     "example": "electronics"
                 },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
                 "description": {
                     "type": "string",
                     "example": "Smartphone Apple com chip A17 Pro"
                 },
+                "dimensions": {
+                    "$ref": "#/definitions/dto.Dimensions"
+                },
                 "images": {
                     "type": "array",
                     "items": {
@@ -704,6 +3707,14 @@ const docTemplate = `{
                     "type": "string",
                     "example": "iPhone 15 Pro Max"
                 },
+                "price": {
+                    "type": "integer",
+                    "example": 1099900
+                },
+                "price_amount": {
+                    "type": "string",
+                    "example": "10999.00"
+                },
                 "sku": {
                     "type": "string",
                     "example": "SKU-IP15PM-256"
@@ -715,6 +3726,142 @@ const docTemplate = `{
                 "stock": {
                     "type": "integer",
                     "example": 50
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "promo",
+                        "apple"
+                    ]
+                },
+                "thumbnail_url": {
+                    "type": "string",
+                    "example": "https://example.com/thumb.jpg"
+                },
+                "weight_grams": {
+                    "type": "integer",
+                    "example": 221
+                }
+            }
+        },
+        "dto.UpdateStockRequest": {
+            "description": "Novo estoque do produto",
+            "type": "object",
+            "properties": {
+                "stock": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "dto.WarmupQueryResponse": {
+            "description": "Resultado do aquecimento de uma busca",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "failed to load products"
+                },
+                "products": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "query": {
+                    "type": "string",
+                    "example": "electronics"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "category"
+                }
+            }
+        },
+        "dto.WarmupRequest": {
+            "description": "Nomes e categorias cujas buscas serão executadas no servidor",
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "electronics"
+                    ]
+                },
+                "names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "iPhone 15 Pro"
+                    ]
+                }
+            }
+        },
+        "dto.WarmupResponse": {
+            "description": "Total de produtos aquecidos e detalhe por busca",
+            "type": "object",
+            "properties": {
+                "queries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WarmupQueryResponse"
+                    }
+                },
+                "warmed": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "handler.DependencyHealth": {
+            "description": "Status, latência e último erro observado de uma dependência",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "redis health check failed: context deadline exceeded"
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": "redis health check failed: connection refused"
+                },
+                "last_error_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "latency_ms": {
+                    "type": "number",
+                    "example": 1.25
+                },
+                "status": {
+                    "type": "string",
+                    "example": "healthy"
+                }
+            }
+        },
+        "handler.DetailedHealthResponse": {
+            "description": "Status agregado e detalhes por dependência (database, cache, keycloak)",
+            "type": "object",
+            "properties": {
+                "dependencies": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/handler.DependencyHealth"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "healthy"
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
                 }
             }
         },
@@ -737,6 +3884,34 @@ const docTemplate = `{
                     "example": "2024-01-15T10:30:00Z"
                 }
             }
+        },
+        "handler.WhoAmIResponse": {
+            "description": "Identidade e roles do token usado na requisição",
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "admin@example.com"
+                },
+                "preferred_username": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "admin",
+                        "offline_access"
+                    ]
+                },
+                "subject": {
+                    "type": "string",
+                    "example": "8d2f1c9e-4b7a-4f3e-9c1d-2a6b5e7f8a90"
+                }
+            }
         }
     },
     "securityDefinitions": {
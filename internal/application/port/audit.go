@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+)
+
+// AuditLogger registra na trilha de compliance uma alteração já gravada no
+// banco. Não retorna erro: uma falha de auditoria é logada e nunca desfaz nem
+// falha a operação principal.
+type AuditLogger interface {
+	Record(ctx context.Context, operation, productID string, changes map[string]entity.FieldChange)
+}
+
+// NoopAuditLogger descarta os registros.
+type NoopAuditLogger struct{}
+
+func (NoopAuditLogger) Record(ctx context.Context, operation, productID string, changes map[string]entity.FieldChange) {
+}
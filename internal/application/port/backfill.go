@@ -0,0 +1,12 @@
+package port
+
+// CacheBackfiller reconstrói em background o índice completo da listagem
+// quando ele é encontrado vazio.
+type CacheBackfiller interface {
+	// Trigger dispara a reconstrução se nenhuma estiver em andamento e o
+	// intervalo mínimo desde a última tiver passado. Retorna se disparou.
+	Trigger() bool
+
+	// Running informa se há uma reconstrução em andamento.
+	Running() bool
+}
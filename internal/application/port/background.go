@@ -0,0 +1,14 @@
+package port
+
+// BackgroundRunner executa tarefas fora do ciclo da requisição (limpeza de
+// cache, repopulação), permitindo que o shutdown espere por elas.
+type BackgroundRunner interface {
+	Go(task func())
+}
+
+// GoRunner dispara cada tarefa numa goroutine sem acompanhamento.
+type GoRunner struct{}
+
+func (GoRunner) Go(task func()) {
+	go task()
+}
@@ -4,5 +4,7 @@ type CacheKeyGenerator interface {
 	ProductKey(id string) string
 	NameKey(name string) string
 	CategoryKey(category string) string
+	TagKey(tag string) string
+	SKUKey(sku string) string
 	AllProductsKey() string
 }
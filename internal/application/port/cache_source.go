@@ -0,0 +1,59 @@
+package port
+
+import (
+	"context"
+	"sync"
+)
+
+// Origens de uma leitura, no formato do cabeçalho X-Cache das CDNs.
+const (
+	CacheSourceHit  = "HIT"
+	CacheSourceMiss = "MISS"
+)
+
+type cacheSourceKey struct{}
+
+// CacheSource guarda de onde os casos de uso tiraram a resposta de uma
+// requisição. Uma requisição com várias leituras é MISS se qualquer uma
+// precisou do banco.
+type CacheSource struct {
+	mu     sync.Mutex
+	source string
+}
+
+// WithCacheSource anota no contexto um CacheSource vazio, preenchido pelas
+// leituras que rodarem com o contexto devolvido.
+func WithCacheSource(ctx context.Context) (context.Context, *CacheSource) {
+	source := &CacheSource{}
+	return context.WithValue(ctx, cacheSourceKey{}, source), source
+}
+
+// RecordCacheHit e RecordCacheMiss registram a origem da leitura; sem
+// CacheSource no contexto, não fazem nada.
+func RecordCacheHit(ctx context.Context) {
+	recordCacheSource(ctx, CacheSourceHit)
+}
+
+func RecordCacheMiss(ctx context.Context) {
+	recordCacheSource(ctx, CacheSourceMiss)
+}
+
+func recordCacheSource(ctx context.Context, value string) {
+	source, ok := ctx.Value(cacheSourceKey{}).(*CacheSource)
+	if !ok {
+		return
+	}
+
+	source.mu.Lock()
+	defer source.mu.Unlock()
+	if source.source != CacheSourceMiss {
+		source.source = value
+	}
+}
+
+// Value retorna HIT, MISS ou "" quando nenhuma leitura passou pelo cache.
+func (s *CacheSource) Value() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.source
+}
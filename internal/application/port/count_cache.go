@@ -0,0 +1,22 @@
+package port
+
+import "context"
+
+// ProductCountCache guarda por pouco tempo o total de produtos ativos, para
+// que painéis consultando o contador não façam um COUNT(*) por requisição.
+// Get indica com found se havia um total guardado; criações, restaurações e
+// exclusões chamam Invalidate.
+type ProductCountCache interface {
+	Get(ctx context.Context) (total int, found bool, err error)
+	Set(ctx context.Context, total int) error
+	Invalidate(ctx context.Context) error
+}
+
+// NoopProductCountCache não guarda nada: toda contagem vai ao banco.
+type NoopProductCountCache struct{}
+
+func (NoopProductCountCache) Get(ctx context.Context) (int, bool, error) { return 0, false, nil }
+
+func (NoopProductCountCache) Set(ctx context.Context, total int) error { return nil }
+
+func (NoopProductCountCache) Invalidate(ctx context.Context) error { return nil }
@@ -0,0 +1,54 @@
+package port
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Tipos de ChangeEvent.
+const (
+	ProductCreated  = "product.created"
+	ProductUpdated  = "product.updated"
+	ProductDeleted  = "product.deleted"
+	ProductRestored = "product.restored"
+)
+
+// ChangeEvent descreve uma escrita num produto já confirmada no banco.
+type ChangeEvent struct {
+	Type       string    `json:"type"`
+	ProductID  string    `json:"product_id"`
+	Version    int       `json:"version,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// ChangePublisher entrega os eventos de mudança fora do ciclo da requisição;
+// Publish não bloqueia.
+type ChangePublisher interface {
+	Publish(event ChangeEvent)
+}
+
+// NoopChangePublisher descarta os eventos.
+type NoopChangePublisher struct{}
+
+func (NoopChangePublisher) Publish(event ChangeEvent) {}
+
+// ErrWebhookUnavailable indica que o webhook recusou ou não respondeu uma
+// entrega.
+var ErrWebhookUnavailable = errors.New("webhook unavailable")
+
+// DeadLetter é um evento de mudança cuja entrega falhou depois de todas as
+// novas tentativas.
+type DeadLetter struct {
+	Event    ChangeEvent `json:"event"`
+	Error    string      `json:"error"`
+	FailedAt time.Time   `json:"failed_at"`
+}
+
+// DeadLetterQueue expõe os eventos que o webhook não entregou. DeadLetters
+// lista os limit mais antigos e o total; Replay reenvia até limit eventos, dos
+// mais antigos para os mais novos, e retorna quantos foram entregues.
+type DeadLetterQueue interface {
+	DeadLetters(ctx context.Context, limit int) ([]DeadLetter, int, error)
+	Replay(ctx context.Context, limit int) (int, error)
+}
@@ -0,0 +1,36 @@
+package port
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrRequestInProgress indica que outra requisição com a mesma chave de
+// idempotência ainda está sendo processada.
+var ErrRequestInProgress = errors.New("request with this idempotency key is in progress")
+
+// IdempotentResponse é a resposta gravada de uma requisição idempotente,
+// devolvida como está nas repetições. RequestHash resume o corpo da requisição
+// original, para recusar a mesma chave reutilizada com outro corpo.
+type IdempotentResponse struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Location    string `json:"location,omitempty"`
+	ETag        string `json:"etag,omitempty"`
+	Body        []byte `json:"body"`
+	RequestHash string `json:"request_hash,omitempty"`
+}
+
+// IdempotencyStore guarda as respostas por chave de idempotência. As chaves
+// chegam já isoladas por usuário.
+type IdempotencyStore interface {
+	// Begin reserva a chave para a requisição atual e retorna nil, nil. Se a
+	// chave já tem resposta, retorna a resposta; se outra requisição a
+	// reservou e não terminou, ErrRequestInProgress.
+	Begin(ctx context.Context, key string) (*IdempotentResponse, error)
+	// Complete grava a resposta da chave reservada.
+	Complete(ctx context.Context, key string, response IdempotentResponse) error
+	// Release libera a chave reservada sem resposta, para que uma nova
+	// tentativa processe a requisição de novo.
+	Release(ctx context.Context, key string) error
+}
@@ -0,0 +1,28 @@
+package port
+
+import "time"
+
+// FallbackRecorder registra a latência das consultas ao banco feitas depois de
+// um cache miss (o caminho frio), separada da latência geral do banco.
+type FallbackRecorder interface {
+	ObserveDBFallback(operation string, duration time.Duration)
+}
+
+// NoopFallbackRecorder descarta as medições.
+type NoopFallbackRecorder struct{}
+
+func (NoopFallbackRecorder) ObserveDBFallback(operation string, duration time.Duration) {}
+
+// CacheRecorder conta as consultas ao cache por operação: hit quando a
+// resposta saiu do Redis, miss quando precisou ir ao banco.
+type CacheRecorder interface {
+	ObserveCacheHit(operation string)
+	ObserveCacheMiss(operation string)
+}
+
+// NoopCacheRecorder descarta as contagens.
+type NoopCacheRecorder struct{}
+
+func (NoopCacheRecorder) ObserveCacheHit(operation string) {}
+
+func (NoopCacheRecorder) ObserveCacheMiss(operation string) {}
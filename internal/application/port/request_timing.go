@@ -0,0 +1,57 @@
+package port
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+type requestTimingKey struct{}
+
+// RequestTiming acumula quanto de uma requisição foi gasto no Redis, no
+// PostgreSQL e serializando a resposta. As somas são seguras para leituras
+// concorrentes (buscas em paralelo, fallback com timeout).
+type RequestTiming struct {
+	cache     atomic.Int64
+	db        atomic.Int64
+	serialize atomic.Int64
+}
+
+// WithRequestTiming anota no contexto um RequestTiming zerado, somado pelas
+// operações que rodarem com o contexto devolvido.
+func WithRequestTiming(ctx context.Context) (context.Context, *RequestTiming) {
+	timing := &RequestTiming{}
+	return context.WithValue(ctx, requestTimingKey{}, timing), timing
+}
+
+// RecordCacheTime, RecordDBTime e RecordSerializeTime somam d à parcela
+// correspondente; sem RequestTiming no contexto, não fazem nada.
+func RecordCacheTime(ctx context.Context, d time.Duration) {
+	if timing, ok := ctx.Value(requestTimingKey{}).(*RequestTiming); ok {
+		timing.cache.Add(int64(d))
+	}
+}
+
+func RecordDBTime(ctx context.Context, d time.Duration) {
+	if timing, ok := ctx.Value(requestTimingKey{}).(*RequestTiming); ok {
+		timing.db.Add(int64(d))
+	}
+}
+
+func RecordSerializeTime(ctx context.Context, d time.Duration) {
+	if timing, ok := ctx.Value(requestTimingKey{}).(*RequestTiming); ok {
+		timing.serialize.Add(int64(d))
+	}
+}
+
+func (t *RequestTiming) Cache() time.Duration {
+	return time.Duration(t.cache.Load())
+}
+
+func (t *RequestTiming) DB() time.Duration {
+	return time.Duration(t.db.Load())
+}
+
+func (t *RequestTiming) Serialize() time.Duration {
+	return time.Duration(t.serialize.Load())
+}
@@ -0,0 +1,20 @@
+package port
+
+import "context"
+
+// CachedResponse é uma resposta 200 de listagem já serializada, devolvida
+// como está enquanto não expirar.
+type CachedResponse struct {
+	ContentType string `json:"content_type"`
+	Link        string `json:"link,omitempty"`
+	ETag        string `json:"etag,omitempty"`
+	Body        []byte `json:"body"`
+}
+
+// ResponseCache guarda respostas serializadas por chave, com expiração
+// curta definida pela implementação.
+type ResponseCache interface {
+	// Get retorna nil, nil quando a chave não tem resposta.
+	Get(ctx context.Context, key string) (*CachedResponse, error)
+	Set(ctx context.Context, key string, response CachedResponse) error
+}
@@ -2,8 +2,11 @@ package port
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
 )
 
 type CreateProductInput struct {
@@ -14,8 +17,15 @@ type CreateProductInput struct {
 	SKU             string
 	Brand           string
 	Stock           int
+	Price           int64
+	PriceAmount     string
+	Currency        string
 	Images          []string
 	Specifications  map[string]interface{}
+	ThumbnailURL    string
+	Tags            []string
+	WeightGrams     int
+	Dimensions      entity.Dimensions
 }
 
 type UpdateProductInput struct {
@@ -25,34 +35,313 @@ type UpdateProductInput struct {
 	SKU            string
 	Brand          string
 	Stock          int
+	Price          int64
+	PriceAmount    string
+	Currency       string
 	Images         []string
 	Specifications map[string]interface{}
+	ThumbnailURL   string
+	Tags           []string
+	WeightGrams    int
+	Dimensions     entity.Dimensions
+
+	// ExpectedVersion, quando positivo, é a versão que o cliente leu
+	// (If-Match): a escrita só é aplicada se o produto ainda estiver nela.
+	ExpectedVersion int
+}
+
+// PatchProductInput é uma atualização parcial: campos nil ficam como estão.
+// ExpectedVersion, quando positivo, exige que o produto esteja nessa versão
+// (If-Match).
+type PatchProductInput struct {
+	Name            *string
+	Category        *string
+	Description     *string
+	SKU             *string
+	Brand           *string
+	Stock           *int
+	Price           *int64
+	PriceAmount     *string
+	Currency        *string
+	Images          *[]string
+	Specifications  *map[string]interface{}
+	ThumbnailURL    *string
+	Tags            *[]string
+	WeightGrams     *int
+	Dimensions      *entity.Dimensions
+	ExpectedVersion int
+}
+
+// ImportProductInput é uma linha de importação (migração em lote). Os
+// timestamps vêm do sistema de origem como texto RFC 3339; vazios são gerados
+// pelo servidor.
+type ImportProductInput struct {
+	CreateProductInput
+	CreatedAt string
+	UpdatedAt string
+}
+
+// ImportRowError descreve a falha de uma linha da importação. Row começa em 1.
+type ImportRowError struct {
+	Row             int
+	ReferenceNumber string
+	Error           string
+}
+
+type ImportReport struct {
+	Total    int
+	Imported int
+	Failed   int
+	Errors   []ImportRowError
+}
+
+// WarmupInput lista as buscas a pré-aquecer no cache.
+type WarmupInput struct {
+	Names      []string
+	Categories []string
+}
+
+// WarmupQueryResult é o resultado de uma busca aquecida. Type é "name" ou
+// "category"; Products é quantos produtos ficaram no cache para a busca.
+type WarmupQueryResult struct {
+	Type     string
+	Query    string
+	Products int
+	Error    string
+}
+
+type WarmupReport struct {
+	Warmed  int
+	Queries []WarmupQueryResult
+}
+
+// ReconcileInput define a amostra da reconciliação de versões. Com DryRun,
+// as entradas defasadas são só reportadas, sem remoção.
+type ReconcileInput struct {
+	SampleSize int
+	DryRun     bool
+}
+
+// StaleEntry é uma entrada em cache cuja versão ficou atrás da do banco.
+// DBVersion é zero quando o produto não existe mais no banco.
+type StaleEntry struct {
+	ProductID     string
+	CachedVersion int
+	DBVersion     int
+}
+
+type ReconcileReport struct {
+	Sampled int
+	Checked int
+	Evicted int
+	Stale   []StaleEntry
+}
+
+// ErrCacheWarmInProgress indica que já há um aquecimento completo do cache em
+// andamento nesta instância.
+var ErrCacheWarmInProgress = errors.New("cache warm already in progress")
+
+// CacheWarmReport resume um aquecimento completo do cache: produtos gravados,
+// falhas de gravação e índices reconstruídos (all_products, nome, categoria
+// e tag).
+type CacheWarmReport struct {
+	Products int
+	Failed   int
+	Indexes  int
+	Duration time.Duration
 }
 
 type ProductCreator interface {
 	Execute(ctx context.Context, input CreateProductInput) (*entity.Product, error)
 }
 
+// BulkCreateResult descreve um item da criação em lote: Product quando criado,
+// Err com o erro de domínio quando recusado.
+type BulkCreateResult struct {
+	Index   int
+	Product *entity.Product
+	Err     error
+}
+
+type BulkCreateReport struct {
+	Total   int
+	Created int
+	Failed  int
+	Results []BulkCreateResult
+}
+
+// ProductBulkCreator cria vários produtos numa única ida ao banco. A falha de
+// um item não impede os demais.
+type ProductBulkCreator interface {
+	Execute(ctx context.Context, inputs []CreateProductInput) (*BulkCreateReport, error)
+}
+
 type ProductUpdater interface {
 	Execute(ctx context.Context, id string, input UpdateProductInput) (*entity.Product, error)
 }
 
+// ProductPatcher aplica uma atualização parcial sobre o produto atual.
+type ProductPatcher interface {
+	Execute(ctx context.Context, id string, patch PatchProductInput) (*entity.Product, error)
+}
+
+// ProductStockUpdater altera só o estoque, incrementando a versão do produto.
+type ProductStockUpdater interface {
+	Execute(ctx context.Context, id string, stock int) error
+}
+
+// ProductStockAdjuster soma uma variação ao estoque de um produto de forma
+// atômica e retorna o estoque resultante.
+type ProductStockAdjuster interface {
+	Execute(ctx context.Context, id string, delta int) (int, error)
+}
+
+// StockAdjustment é um item do ajuste em lote: Delta é somado ao estoque.
+type StockAdjustment struct {
+	ID    string
+	Delta int
+}
+
+// StockAdjustmentResult descreve um item do ajuste em lote. Stock é o estoque
+// após o ajuste ou, quando ele deixaria o estoque negativo, o estoque atual.
+type StockAdjustmentResult struct {
+	ID      string
+	Delta   int
+	Stock   int
+	Applied bool
+	Error   string
+}
+
+type StockAdjustmentReport struct {
+	Total   int
+	Applied int
+	Failed  int
+	Results []StockAdjustmentResult
+}
+
+// ProductStockBulkAdjuster aplica ajustes relativos de estoque a vários
+// produtos, cada um de forma atômica e independente dos demais.
+type ProductStockBulkAdjuster interface {
+	Execute(ctx context.Context, adjustments []StockAdjustment) (*StockAdjustmentReport, error)
+}
+
 type ProductDeleter interface {
 	Execute(ctx context.Context, id string) error
+
+	// ExecuteIfVersion exclui só se a versão atual for a informada.
+	ExecuteIfVersion(ctx context.Context, id string, version int) error
+}
+
+// ProductRestorer desfaz a exclusão lógica de um produto e devolve o produto
+// restaurado.
+type ProductRestorer interface {
+	Execute(ctx context.Context, id string) (*entity.Product, error)
 }
 
 type ProductGetter interface {
 	Execute(ctx context.Context, id string) (*entity.Product, error)
 }
 
+// ProductTombstoneFinder busca a marca de exclusão de um produto excluído
+// logicamente; IDs desconhecidos ou ativos retornam ErrProductNotFound.
+type ProductTombstoneFinder interface {
+	Execute(ctx context.Context, id string) (*entity.Tombstone, error)
+}
+
+// ProductBatchGetter busca vários produtos por ID; os não encontrados ficam
+// fora do mapa.
+type ProductBatchGetter interface {
+	Execute(ctx context.Context, ids []string) (map[string]*entity.Product, error)
+}
+
+// ProductGetterBySKU busca pelo SKU sem diferenciar maiúsculas; com SKUs
+// repetidos, devolve o cadastro mais antigo.
+type ProductGetterBySKU interface {
+	Execute(ctx context.Context, sku string) (*entity.Product, error)
+}
+
+// ProductLister lista os produtos que passam pelo filter, na ordem de sort; o
+// zero value de repository.SortOptions lista do mais novo para o mais antigo.
 type ProductLister interface {
-	Execute(ctx context.Context, limit, offset int) ([]*entity.Product, error)
+	Execute(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error)
+}
+
+// ProductModifiedByLister lista os produtos alterados por último pelo sujeito
+// informado, segundo a trilha de auditoria.
+type ProductModifiedByLister interface {
+	Execute(ctx context.Context, subject string, limit, offset int) ([]*entity.Product, error)
+}
+
+// ProductCounter informa o total de produtos de cada listagem, para que a
+// resposta paginada traga o tamanho do conjunto completo.
+type ProductCounter interface {
+	Count(ctx context.Context, filter repository.ListFilter) (int, error)
+	CountByName(ctx context.Context, name string, filter repository.ListFilter) (int, error)
+	CountByCategory(ctx context.Context, category string, filter repository.ListFilter) (int, error)
+}
+
+// RecentProductsLister retorna os produtos criados mais recentemente, do mais
+// novo para o mais antigo.
+type RecentProductsLister interface {
+	Execute(ctx context.Context, limit int) ([]*entity.Product, error)
 }
 
 type ProductSearcherByName interface {
-	Execute(ctx context.Context, name string, limit, offset int) ([]*entity.Product, error)
+	Execute(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error)
 }
 
 type ProductSearcherByCategory interface {
-	Execute(ctx context.Context, category string, limit, offset int) ([]*entity.Product, error)
+	Execute(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error)
+}
+
+// ProductTagEditor adiciona ou remove uma única tag de um produto; repetir a
+// operação não é erro.
+type ProductTagEditor interface {
+	Add(ctx context.Context, id, tag string) error
+	Remove(ctx context.Context, id, tag string) error
+}
+
+// ProductSearcherByTag busca os produtos marcados com uma tag.
+type ProductSearcherByTag interface {
+	Execute(ctx context.Context, tag string, limit, offset int) ([]*entity.Product, error)
+}
+
+// GeoFeature é um produto do feed GeoJSON, com as coordenadas já validadas.
+type GeoFeature struct {
+	Product   *entity.Product
+	Latitude  float64
+	Longitude float64
+}
+
+// ProductGeoExporter entrega, um a um, os produtos com localização.
+type ProductGeoExporter interface {
+	Execute(ctx context.Context, emit func(feature GeoFeature) error) error
+}
+
+type ProductImporter interface {
+	Execute(ctx context.Context, rows []ImportProductInput) (*ImportReport, error)
+}
+
+type SearchWarmer interface {
+	Execute(ctx context.Context, input WarmupInput) (*WarmupReport, error)
+}
+
+// CacheWarmer recarrega o catálogo inteiro do banco no cache. WarmCache
+// espera o fim; Trigger dispara em background e retorna false se já houver
+// um aquecimento em andamento.
+type CacheWarmer interface {
+	WarmCache(ctx context.Context) (*CacheWarmReport, error)
+	Trigger() bool
+}
+
+// CacheInvalidator remove dados do cache sem tocar no banco. Os dois métodos
+// retornam quantas chaves foram afetadas.
+type CacheInvalidator interface {
+	InvalidateProduct(ctx context.Context, id string) (int, error)
+	FlushAll(ctx context.Context) (int, error)
+}
+
+// CacheVersionReconciler compara a versão de produtos em cache com a do banco.
+type CacheVersionReconciler interface {
+	Execute(ctx context.Context, input ReconcileInput) (*ReconcileReport, error)
 }
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// AdjustStockOptions ajusta o ajuste relativo de estoque. Events recebe um
+// product.updated com a nova versão por ajuste aplicado; nil descarta.
+//
+// SerializationRetries e RetryBackoff repetem a escrita quando o banco a aborta
+// por conflito de serialização, como na atualização de produto.
+type AdjustStockOptions struct {
+	Events               port.ChangePublisher
+	SerializationRetries int
+	RetryBackoff         time.Duration
+}
+
+// AdjustStockUseCase soma uma variação ao estoque de um produto num único
+// UPDATE no banco, sem a leitura prévia que faria dois ajustes concorrentes
+// se sobrescreverem. Como nos demais caminhos de estoque, a versão sobe e
+// estoque e versão da entrada em cache são ajustados no próprio Redis.
+type AdjustStockUseCase struct {
+	productRepo repository.ProductRepository
+	cacheRepo   repository.CacheRepository
+	cacheKeys   port.CacheKeyGenerator
+	logger      port.Logger
+	options     AdjustStockOptions
+}
+
+func NewAdjustStockUseCase(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+) *AdjustStockUseCase {
+	return NewAdjustStockUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, logger, AdjustStockOptions{})
+}
+
+func NewAdjustStockUseCaseWithOptions(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+	options AdjustStockOptions,
+) *AdjustStockUseCase {
+	options.Events = changePublisherOrNoop(options.Events)
+
+	return &AdjustStockUseCase{
+		productRepo: productRepo,
+		cacheRepo:   cacheRepo,
+		cacheKeys:   cacheKeys,
+		logger:      logger,
+		options:     options,
+	}
+}
+
+// Execute retorna o estoque resultante. Se o ajuste deixaria o estoque
+// negativo, nada muda e o erro é entity.ErrInsufficientStock.
+func (uc *AdjustStockUseCase) Execute(ctx context.Context, id string, delta int) (int, error) {
+	var stock int
+	var revision repository.Revision
+	err := retrySerialization(ctx, uc.logger, "stock adjustment", id, uc.options.SerializationRetries, uc.options.RetryBackoff, func() error {
+		var err error
+		stock, revision, err = uc.productRepo.AdjustStock(ctx, id, delta)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrProductNotFound) {
+			return 0, err
+		}
+		if errors.Is(err, entity.ErrInsufficientStock) {
+			uc.logger.Warn("stock adjustment refused",
+				"product_id", id[:min(8, len(id))],
+				"delta", delta,
+				"stock", stock,
+			)
+			return 0, err
+		}
+
+		uc.logger.Error("failed to adjust stock in database",
+			"error", err,
+			"product_id", id[:min(8, len(id))],
+		)
+		return 0, fmt.Errorf("failed to adjust stock: %w", err)
+	}
+
+	patchCachedStock(ctx, uc.cacheRepo, uc.cacheKeys, uc.logger, id, stock, revision)
+	publishRevision(uc.options.Events, id, revision)
+
+	uc.logger.Info("stock adjusted",
+		"product_id", id[:min(8, len(id))],
+		"delta", delta,
+		"stock", stock,
+	)
+
+	return stock, nil
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+func TestAdjustStockUseCase_Execute_PatchesCache(t *testing.T) {
+	mockProductRepo := &MockProductRepository{
+		AdjustStockFunc: func(ctx context.Context, id string, delta int) (int, repository.Revision, error) {
+			return 10 + delta, repository.Revision{Version: 3}, nil
+		},
+	}
+
+	patched := make(map[string]int)
+	var patchedVersion int
+	mockCacheRepo := &MockCacheRepository{
+		PatchStockFunc: func(ctx context.Context, key string, stock int, revision repository.Revision) error {
+			patched[key] = stock
+			patchedVersion = revision.Version
+			return nil
+		},
+	}
+
+	events := &recordingPublisher{}
+	uc := NewAdjustStockUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, AdjustStockOptions{
+		Events: events,
+	})
+
+	stock, err := uc.Execute(context.Background(), "abc", -3)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stock != 7 {
+		t.Errorf("Expected stock 7, got %d", stock)
+	}
+	if patched["product_abc"] != 7 {
+		t.Errorf("Expected cached stock to be patched to 7, got %v", patched)
+	}
+	if patchedVersion != 3 {
+		t.Errorf("Expected the cached entry to get the new version 3, got %d", patchedVersion)
+	}
+	if len(events.events) != 1 || events.events[0].Type != port.ProductUpdated || events.events[0].Version != 3 {
+		t.Errorf("Expected a product.updated with version 3, got %+v", events.events)
+	}
+}
+
+func TestAdjustStockUseCase_Execute_InsufficientStock(t *testing.T) {
+	mockProductRepo := &MockProductRepository{
+		AdjustStockFunc: func(ctx context.Context, id string, delta int) (int, repository.Revision, error) {
+			return 2, repository.Revision{}, entity.ErrInsufficientStock
+		},
+	}
+	mockCacheRepo := &MockCacheRepository{
+		PatchStockFunc: func(ctx context.Context, key string, stock int, revision repository.Revision) error {
+			t.Error("Expected cache to be untouched when the adjustment is refused")
+			return nil
+		},
+	}
+
+	events := &recordingPublisher{}
+	uc := NewAdjustStockUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, AdjustStockOptions{
+		Events: events,
+	})
+
+	_, err := uc.Execute(context.Background(), "abc", -5)
+	if !errors.Is(err, entity.ErrInsufficientStock) {
+		t.Fatalf("Expected ErrInsufficientStock, got %v", err)
+	}
+	if len(events.events) != 0 {
+		t.Errorf("Expected no event for a refused adjustment, got %+v", events.events)
+	}
+}
+
+func TestAdjustStockUseCase_Execute_NotFound(t *testing.T) {
+	mockProductRepo := &MockProductRepository{
+		AdjustStockFunc: func(ctx context.Context, id string, delta int) (int, repository.Revision, error) {
+			return 0, repository.Revision{}, repository.ErrProductNotFound
+		},
+	}
+
+	uc := NewAdjustStockUseCase(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	_, err := uc.Execute(context.Background(), "missing", 1)
+	if !errors.Is(err, repository.ErrProductNotFound) {
+		t.Fatalf("Expected ErrProductNotFound, got %v", err)
+	}
+}
+
+func TestAdjustStockUseCase_Execute_RetriesSerializationFailure(t *testing.T) {
+	attempts := 0
+	mockProductRepo := &MockProductRepository{
+		AdjustStockFunc: func(ctx context.Context, id string, delta int) (int, repository.Revision, error) {
+			attempts++
+			if attempts < 3 {
+				return 0, repository.Revision{}, repository.ErrSerializationFailure
+			}
+			return 10 + delta, repository.Revision{Version: 2}, nil
+		},
+	}
+
+	uc := NewAdjustStockUseCaseWithOptions(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{}, AdjustStockOptions{
+		SerializationRetries: 3,
+	})
+
+	stock, err := uc.Execute(context.Background(), "abc", -3)
+	if err != nil {
+		t.Fatalf("Expected adjustment to succeed after retries, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+	if stock != 7 {
+		t.Errorf("Expected stock 7, got %d", stock)
+	}
+}
+
+func TestAdjustStockUseCase_Execute_SerializationRetriesExhausted(t *testing.T) {
+	attempts := 0
+	mockProductRepo := &MockProductRepository{
+		AdjustStockFunc: func(ctx context.Context, id string, delta int) (int, repository.Revision, error) {
+			attempts++
+			return 0, repository.Revision{}, repository.ErrSerializationFailure
+		},
+	}
+
+	uc := NewAdjustStockUseCaseWithOptions(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{}, AdjustStockOptions{
+		SerializationRetries: 2,
+	})
+
+	_, err := uc.Execute(context.Background(), "abc", 1)
+	if !errors.Is(err, repository.ErrSerializationFailure) {
+		t.Fatalf("Expected ErrSerializationFailure, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 1 attempt plus 2 retries, got %d", attempts)
+	}
+}
@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// auditTimeout limita a gravação de um lote de entradas de auditoria.
+const auditTimeout = 5 * time.Second
+
+// AuditLoggerOptions ajusta a gravação em lote da trilha de compliance.
+//
+// QueueSize limita as entradas pendentes; com a fila cheia a entrada é
+// descartada e registrada em log. BatchSize limita as entradas de um INSERT e
+// FlushInterval é quanto a primeira entrada de um lote espera pelas seguintes.
+type AuditLoggerOptions struct {
+	QueueSize     int
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+// AuditLogger grava cada alteração em AuditRepository com o sujeito que o
+// middleware de autenticação anotou no contexto (repository.WithActor).
+// Record só enfileira: uma goroutine agrupa as entradas e as grava num único
+// INSERT por lote, fora do ciclo da requisição. Uma falha na gravação só é
+// logada; a alteração já foi feita.
+type AuditLogger struct {
+	repo    repository.AuditRepository
+	logger  port.Logger
+	options AuditLoggerOptions
+	now     func() time.Time
+
+	mu      sync.RWMutex
+	closed  bool
+	entries chan *entity.AuditEntry
+	done    chan struct{}
+}
+
+func NewAuditLogger(repo repository.AuditRepository, logger port.Logger) *AuditLogger {
+	return NewAuditLoggerWithOptions(repo, logger, AuditLoggerOptions{})
+}
+
+func NewAuditLoggerWithOptions(repo repository.AuditRepository, logger port.Logger, options AuditLoggerOptions) *AuditLogger {
+	if options.QueueSize <= 0 {
+		options.QueueSize = 10000
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = 500
+	}
+	if options.FlushInterval <= 0 {
+		options.FlushInterval = 100 * time.Millisecond
+	}
+
+	a := &AuditLogger{
+		repo:    repo,
+		logger:  logger,
+		options: options,
+		now:     func() time.Time { return time.Now().UTC() },
+		entries: make(chan *entity.AuditEntry, options.QueueSize),
+		done:    make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+// Record enfileira a entrada sem bloquear. O horário e o sujeito são os do
+// momento da alteração, não os da gravação.
+func (a *AuditLogger) Record(ctx context.Context, operation, productID string, changes map[string]entity.FieldChange) {
+	entry := &entity.AuditEntry{
+		ProductID:  productID,
+		Subject:    repository.ActorFromContext(ctx),
+		Operation:  operation,
+		OccurredAt: a.now(),
+		Changes:    changes,
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.closed {
+		a.dropped("audit logger closed - dropping entry", entry)
+		return
+	}
+
+	select {
+	case a.entries <- entry:
+	default:
+		a.dropped("audit queue full - dropping entry", entry)
+	}
+}
+
+// Close para de aceitar entradas e espera a gravação das pendentes até o
+// contexto expirar.
+func (a *AuditLogger) Close(ctx context.Context) error {
+	a.mu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.entries)
+	}
+	a.mu.Unlock()
+
+	select {
+	case <-a.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (a *AuditLogger) run() {
+	defer close(a.done)
+
+	for entry := range a.entries {
+		batch := []*entity.AuditEntry{entry}
+		timer := time.NewTimer(a.options.FlushInterval)
+
+	collect:
+		for len(batch) < a.options.BatchSize {
+			select {
+			case next, ok := <-a.entries:
+				if !ok {
+					break collect
+				}
+				batch = append(batch, next)
+			case <-timer.C:
+				break collect
+			}
+		}
+		timer.Stop()
+
+		a.write(batch)
+	}
+}
+
+func (a *AuditLogger) write(batch []*entity.AuditEntry) {
+	ctx, cancel := context.WithTimeout(context.Background(), auditTimeout)
+	defer cancel()
+
+	if err := a.repo.Append(ctx, batch); err != nil {
+		for _, entry := range batch {
+			a.logger.Error("failed to write audit entry",
+				"error", err,
+				"operation", entry.Operation,
+				"product_id", entry.ProductID[:min(8, len(entry.ProductID))],
+				"subject", entry.Subject,
+			)
+		}
+	}
+}
+
+func (a *AuditLogger) dropped(msg string, entry *entity.AuditEntry) {
+	a.logger.Error(msg,
+		"operation", entry.Operation,
+		"product_id", entry.ProductID[:min(8, len(entry.ProductID))],
+		"subject", entry.Subject,
+	)
+}
+
+// auditLoggerOrNoop evita checagens de nil nos casos de uso.
+func auditLoggerOrNoop(audit port.AuditLogger) port.AuditLogger {
+	if audit == nil {
+		return port.NoopAuditLogger{}
+	}
+	return audit
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// recordingAudit implementa port.AuditLogger guardando os registros.
+type recordingAudit struct {
+	operations []string
+	changes    []map[string]entity.FieldChange
+}
+
+func (a *recordingAudit) Record(ctx context.Context, operation, productID string, changes map[string]entity.FieldChange) {
+	a.operations = append(a.operations, operation)
+	a.changes = append(a.changes, changes)
+}
+
+type auditRepositoryFunc func(ctx context.Context, entries []*entity.AuditEntry) error
+
+func (f auditRepositoryFunc) Append(ctx context.Context, entries []*entity.AuditEntry) error {
+	return f(ctx, entries)
+}
+
+func TestAuditLogger_Record(t *testing.T) {
+	var batches [][]*entity.AuditEntry
+	audit := NewAuditLoggerWithOptions(auditRepositoryFunc(func(ctx context.Context, entries []*entity.AuditEntry) error {
+		batches = append(batches, entries)
+		return nil
+	}), &MockLogger{}, AuditLoggerOptions{FlushInterval: time.Minute})
+
+	ctx, cancel := context.WithCancel(repository.WithActor(context.Background(), "user-1"))
+	cancel()
+	audit.Record(ctx, entity.AuditUpdate, "product-id", map[string]entity.FieldChange{"name": {Old: "A", New: "B"}})
+	audit.Record(ctx, entity.AuditDelete, "product-id", nil)
+
+	if len(batches) != 0 {
+		t.Fatal("Expected Record not to write synchronously")
+	}
+	if err := audit.Close(context.Background()); err != nil {
+		t.Fatalf("Expected pending entries to be flushed, got %v", err)
+	}
+
+	if len(batches) != 1 || len(batches[0]) != 2 {
+		t.Fatalf("Expected both entries in a single batch even after the request was canceled, got %v", batches)
+	}
+	got := batches[0][0]
+	if got.Subject != "user-1" || got.Operation != entity.AuditUpdate || got.ProductID != "product-id" || got.OccurredAt.IsZero() {
+		t.Errorf("Expected subject, operation, product and timestamp, got %+v", got)
+	}
+}
+
+func TestAuditLogger_Record_SplitsBatches(t *testing.T) {
+	var sizes []int
+	audit := NewAuditLoggerWithOptions(auditRepositoryFunc(func(ctx context.Context, entries []*entity.AuditEntry) error {
+		sizes = append(sizes, len(entries))
+		return nil
+	}), &MockLogger{}, AuditLoggerOptions{BatchSize: 2, FlushInterval: time.Minute})
+
+	for i := 0; i < 5; i++ {
+		audit.Record(context.Background(), entity.AuditCreate, "product-id", nil)
+	}
+	if err := audit.Close(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(sizes) != 3 || sizes[0] != 2 || sizes[1] != 2 || sizes[2] != 1 {
+		t.Errorf("Expected batches of 2, 2 and 1, got %v", sizes)
+	}
+}
+
+func TestAuditLogger_Record_FailureDoesNotPropagate(t *testing.T) {
+	audit := NewAuditLogger(auditRepositoryFunc(func(ctx context.Context, entries []*entity.AuditEntry) error {
+		return errors.New("audit_log does not exist")
+	}), &MockLogger{})
+	defer audit.Close(context.Background())
+
+	mockCacheRepo := &MockCacheRepository{
+		GetFunc: func(ctx context.Context, key string) (*entity.Product, error) {
+			return nil, repository.ErrCacheMiss
+		},
+	}
+	uc := NewCreateProductUseCaseWithOptions(&MockProductRepository{}, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, CreateProductOptions{Audit: audit})
+
+	if _, err := uc.Execute(context.Background(), port.CreateProductInput{Name: "Product", ReferenceNumber: "REF-001", Category: "Category"}); err != nil {
+		t.Errorf("Expected the creation to succeed despite the audit failure, got %v", err)
+	}
+}
+
+func TestMutations_RecordAuditEntries(t *testing.T) {
+	existingProduct := newTestProductWithData("Old Name", "REF-001", "Category")
+	mockCacheRepo := &MockCacheRepository{
+		GetFunc: func(ctx context.Context, key string) (*entity.Product, error) {
+			return existingProduct, nil
+		},
+	}
+	audit := &recordingAudit{}
+
+	update := NewUpdateProductUseCaseWithOptions(&MockProductRepository{}, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, UpdateProductOptions{Audit: audit})
+	_, err := update.Execute(context.Background(), existingProduct.ID, port.UpdateProductInput{
+		Name:        "New Name",
+		Category:    existingProduct.Category,
+		Description: existingProduct.Description,
+		SKU:         existingProduct.SKU,
+		Brand:       existingProduct.Brand,
+		Stock:       existingProduct.Stock,
+		Price:       existingProduct.Price,
+		Currency:    existingProduct.Currency,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error on update, got %v", err)
+	}
+
+	remove := NewDeleteProductUseCaseWithOptions(&MockProductRepository{}, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, DeleteProductOptions{Audit: audit})
+	if err := remove.Execute(context.Background(), existingProduct.ID); err != nil {
+		t.Fatalf("Expected no error on delete, got %v", err)
+	}
+
+	if len(audit.operations) != 2 || audit.operations[0] != entity.AuditUpdate || audit.operations[1] != entity.AuditDelete {
+		t.Fatalf("Expected update and delete entries, got %v", audit.operations)
+	}
+	if change, ok := audit.changes[0]["name"]; !ok || change.Old != "Old Name" || change.New != "New Name" || len(audit.changes[0]) != 1 {
+		t.Errorf("Expected only the name change in the update diff, got %v", audit.changes[0])
+	}
+	if _, ok := audit.changes[1]["deleted_at"]; !ok {
+		t.Errorf("Expected deleted_at in the delete diff, got %v", audit.changes[1])
+	}
+}
@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+const (
+	defaultBackfillBatchSize = 500
+	defaultBackfillCooldown  = time.Minute
+)
+
+// ListCacheBackfillOptions ajusta a reconstrução do índice da listagem.
+// BatchDelay é a pausa entre lotes, para limitar a carga no banco e no Redis;
+// Cooldown é o intervalo mínimo entre duas reconstruções (evita repetir a
+// varredura a cada listagem quando o catálogo está vazio).
+type ListCacheBackfillOptions struct {
+	BatchSize  int
+	BatchDelay time.Duration
+	Cooldown   time.Duration
+	Background port.BackgroundRunner
+}
+
+// ListCacheBackfill percorre o catálogo no banco em lotes, do mais novo para o
+// mais antigo, gravando cada produto e os índices all_products, de nome, de
+// categoria e de tag. Uma falha de escrita interrompe a varredura e descarta
+// todos os índices tocados. Produtos removidos durante a varredura podem fazer
+// o paginamento por offset pular um item; a próxima escrita ou remoção corrige
+// o índice.
+type ListCacheBackfill struct {
+	productRepo repository.ProductRepository
+	cacheRepo   repository.CacheRepository
+	cacheKeys   port.CacheKeyGenerator
+	logger      port.Logger
+	options     ListCacheBackfillOptions
+	now         func() time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu        sync.Mutex
+	running   bool
+	lastStart time.Time
+}
+
+func NewListCacheBackfill(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+	options ListCacheBackfillOptions,
+) *ListCacheBackfill {
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaultBackfillBatchSize
+	}
+	if options.Cooldown <= 0 {
+		options.Cooldown = defaultBackfillCooldown
+	}
+	options.Background = backgroundRunnerOrGo(options.Background)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &ListCacheBackfill{
+		productRepo: productRepo,
+		cacheRepo:   cacheRepo,
+		cacheKeys:   cacheKeys,
+		logger:      logger,
+		options:     options,
+		now:         time.Now,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+func (b *ListCacheBackfill) Trigger() bool {
+	if b.ctx.Err() != nil {
+		return false
+	}
+
+	b.mu.Lock()
+	now := b.now()
+	if b.running || (!b.lastStart.IsZero() && now.Sub(b.lastStart) < b.options.Cooldown) {
+		b.mu.Unlock()
+		return false
+	}
+	b.running = true
+	b.lastStart = now
+	b.mu.Unlock()
+
+	b.options.Background.Go(func() {
+		defer func() {
+			b.mu.Lock()
+			b.running = false
+			b.mu.Unlock()
+		}()
+		b.run(b.ctx)
+	})
+
+	return true
+}
+
+func (b *ListCacheBackfill) Running() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.running
+}
+
+// Close interrompe a reconstrução em andamento e impede novas. Usado no
+// shutdown, antes de esperar as tarefas em background.
+func (b *ListCacheBackfill) Close() {
+	b.cancel()
+}
+
+func (b *ListCacheBackfill) run(ctx context.Context) {
+	start := b.now()
+	b.logger.Info("starting list cache backfill",
+		"batch_size", b.options.BatchSize,
+	)
+
+	touched := map[string]struct{}{}
+	total := 0
+	for offset := 0; ; offset += b.options.BatchSize {
+		products, err := b.productRepo.FindAll(ctx, repository.ListFilter{}, repository.SortOptions{}, b.options.BatchSize, offset)
+		if err != nil {
+			b.abort(err, total, touched)
+			return
+		}
+
+		for _, product := range products {
+			if err := b.index(ctx, product, touched); err != nil {
+				b.abort(err, total, touched)
+				return
+			}
+		}
+		total += len(products)
+
+		if len(products) < b.options.BatchSize {
+			break
+		}
+
+		if b.options.BatchDelay > 0 {
+			timer := time.NewTimer(b.options.BatchDelay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				b.abort(ctx.Err(), total, touched)
+				return
+			case <-timer.C:
+			}
+		}
+	}
+
+	b.logger.Info("list cache backfill finished",
+		"backfilled", total,
+		"duration", b.now().Sub(start),
+	)
+}
+
+// abort descarta o all_products parcial e os índices de nome, categoria e tag
+// que a reconstrução tocou: um índice incompleto seria lido como o resultado
+// inteiro, com páginas curtas. Sem eles as leituras voltam ao banco e uma nova
+// reconstrução pode ser disparada depois do Cooldown.
+func (b *ListCacheBackfill) abort(cause error, backfilled int, touched map[string]struct{}) {
+	b.logger.Warn("list cache backfill stopped - discarding partial indexes",
+		"error", cause,
+		"backfilled", backfilled,
+		"indexes", len(touched)+1,
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), repopulateTimeout)
+	defer cancel()
+
+	if err := b.cacheRepo.DeleteSet(ctx, b.cacheKeys.AllProductsKey()); err != nil {
+		b.logger.Error("failed to discard partial all_products index",
+			"error", err,
+		)
+	}
+	for key := range touched {
+		if err := b.cacheRepo.DeleteSet(ctx, key); err != nil {
+			b.logger.Error("failed to discard partial search index",
+				"error", err,
+				"key", key,
+			)
+		}
+	}
+}
+
+// index grava o produto e o acrescenta aos índices, anotando em touched os
+// índices de busca alterados. Qualquer falha de escrita interrompe a
+// reconstrução; só o descarte por tamanho ou falta de memória do produto é
+// tolerado, já que ele segue nos índices e é lido do banco.
+func (b *ListCacheBackfill) index(ctx context.Context, product *entity.Product, touched map[string]struct{}) error {
+	if err := b.cacheRepo.Set(ctx, b.cacheKeys.ProductKey(product.ID), product); err != nil && !skippedCacheWrite(b.logger, err, product) {
+		return fmt.Errorf("failed to cache product %s: %w", product.HashID(), err)
+	}
+
+	score := float64(product.CreatedAt.UnixMilli())
+	if err := b.cacheRepo.AddToSortedSet(ctx, b.cacheKeys.AllProductsKey(), product.ID, score); err != nil {
+		return fmt.Errorf("failed to add to all_products set: %w", err)
+	}
+
+	keys := []string{b.cacheKeys.NameKey(product.Name), b.cacheKeys.CategoryKey(product.Category)}
+	for _, tag := range product.Tags {
+		keys = append(keys, b.cacheKeys.TagKey(tag))
+	}
+	for _, key := range keys {
+		touched[key] = struct{}{}
+		if err := b.cacheRepo.AddToSet(ctx, key, product.ID); err != nil {
+			return fmt.Errorf("failed to add to search index: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// backfillRunning indica se há uma reconstrução em andamento; sem backfill
+// configurado, nunca há.
+func backfillRunning(backfill port.CacheBackfiller) bool {
+	return backfill != nil && backfill.Running()
+}
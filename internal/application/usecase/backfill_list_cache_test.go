@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+func TestListCacheBackfill_Trigger(t *testing.T) {
+	catalog := make([]*entity.Product, 5)
+	for i := range catalog {
+		catalog[i] = &entity.Product{ID: string(rune('A' + i)), Name: "Phone", Category: "Smartphones", CreatedAt: time.Now()}
+	}
+
+	var pages [][2]int
+	mockProductRepo := &MockProductRepository{
+		FindAllFunc: func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			pages = append(pages, [2]int{limit, offset})
+			end := min(offset+limit, len(catalog))
+			return catalog[min(offset, end):end], nil
+		},
+	}
+
+	indexed := map[string]int{}
+	mockCacheRepo := &MockCacheRepository{
+		AddToSortedSetFunc: func(ctx context.Context, setKey, productID string, score float64) error {
+			indexed[setKey]++
+			return nil
+		},
+		AddToSetFunc: func(ctx context.Context, setKey, productID string) error {
+			indexed[setKey]++
+			return nil
+		},
+		DeleteSetFunc: func(ctx context.Context, setKey string) error {
+			t.Error("Expected complete backfill not to discard the index")
+			return nil
+		},
+	}
+
+	tasks := NewBackgroundTasks()
+	backfill := NewListCacheBackfill(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, ListCacheBackfillOptions{
+		BatchSize:  2,
+		Cooldown:   time.Hour,
+		Background: tasks,
+	})
+
+	if !backfill.Trigger() {
+		t.Fatal("Expected first trigger to start the backfill")
+	}
+	if err := tasks.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected backfill to finish, got %v", err)
+	}
+
+	if len(pages) != 3 || pages[2] != [2]int{2, 4} {
+		t.Errorf("Expected 3 pages of 2, got %v", pages)
+	}
+	if indexed["all_products"] != 5 || indexed["product_by_name_Phone"] != 5 || indexed["product_by_category_Smartphones"] != 5 {
+		t.Errorf("Expected every product in every index, got %v", indexed)
+	}
+
+	if backfill.Running() {
+		t.Error("Expected backfill not to be running after finishing")
+	}
+	if backfill.Trigger() {
+		t.Error("Expected trigger within cooldown to be ignored")
+	}
+}
+
+func TestListCacheBackfill_DiscardsPartialIndexOnError(t *testing.T) {
+	mockProductRepo := &MockProductRepository{
+		FindAllFunc: func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			if offset > 0 {
+				return nil, repository.ErrDatabaseConnection
+			}
+			return []*entity.Product{{ID: "A", Name: "Phone", Category: "Smartphones"}, {ID: "B", Name: "Phone", Category: "Smartphones"}}, nil
+		},
+	}
+
+	discarded := map[string]bool{}
+	mockCacheRepo := &MockCacheRepository{
+		DeleteSetFunc: func(ctx context.Context, setKey string) error {
+			discarded[setKey] = true
+			return nil
+		},
+	}
+
+	tasks := NewBackgroundTasks()
+	backfill := NewListCacheBackfill(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, ListCacheBackfillOptions{
+		BatchSize:  2,
+		Background: tasks,
+	})
+
+	backfill.Trigger()
+	if err := tasks.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected backfill to finish, got %v", err)
+	}
+
+	for _, key := range []string{"all_products", "product_by_name_Phone", "product_by_category_Smartphones"} {
+		if !discarded[key] {
+			t.Errorf("Expected partial %s to be discarded, got %v", key, discarded)
+		}
+	}
+}
+
+// infoLogger guarda as mensagens das chamadas a Info.
+type infoLogger struct {
+	MockLogger
+	messages []string
+}
+
+func (l *infoLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.messages = append(l.messages, msg)
+}
+
+func TestListCacheBackfill_AbortsOnWriteFailure(t *testing.T) {
+	pages := 0
+	mockProductRepo := &MockProductRepository{
+		FindAllFunc: func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			pages++
+			return []*entity.Product{
+				{ID: "A", Name: "Phone", Category: "Smartphones", Tags: []string{"5g"}},
+				{ID: "B", Name: "Tablet", Category: "Tablets"},
+			}, nil
+		},
+	}
+
+	discarded := map[string]bool{}
+	mockCacheRepo := &MockCacheRepository{
+		AddToSetFunc: func(ctx context.Context, setKey, productID string) error {
+			if setKey == "product_by_tag_5g" {
+				return repository.ErrCacheUnavailable
+			}
+			return nil
+		},
+		DeleteSetFunc: func(ctx context.Context, setKey string) error {
+			discarded[setKey] = true
+			return nil
+		},
+	}
+
+	logger := &infoLogger{}
+	tasks := NewBackgroundTasks()
+	backfill := NewListCacheBackfill(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, logger, ListCacheBackfillOptions{
+		BatchSize:  2,
+		Background: tasks,
+	})
+
+	backfill.Trigger()
+	if err := tasks.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected backfill to finish, got %v", err)
+	}
+
+	if pages != 1 {
+		t.Errorf("Expected the scan to stop at the first failed write, got %d pages", pages)
+	}
+	for _, key := range []string{"all_products", "product_by_name_Phone", "product_by_category_Smartphones", "product_by_tag_5g"} {
+		if !discarded[key] {
+			t.Errorf("Expected partial %s to be discarded, got %v", key, discarded)
+		}
+	}
+	if discarded["product_by_name_Tablet"] {
+		t.Error("Expected untouched indexes to be kept")
+	}
+	for _, msg := range logger.messages {
+		if msg == "list cache backfill finished" {
+			t.Error("Expected an aborted backfill not to be reported as finished")
+		}
+	}
+}
+
+type stubBackfiller struct {
+	triggered int
+	running   bool
+}
+
+func (s *stubBackfiller) Trigger() bool {
+	s.triggered++
+	return true
+}
+
+func (s *stubBackfiller) Running() bool {
+	return s.running
+}
+
+func TestListProductsUseCase_Execute_TriggersBackfillOnColdIndex(t *testing.T) {
+	indexExists := false
+	mockCacheRepo := &MockCacheRepository{
+		GetSortedSetRangeFunc: func(ctx context.Context, setKey string, start, stop int64) ([]string, error) {
+			return nil, nil
+		},
+		ExistsFunc: func(ctx context.Context, key string) (bool, error) {
+			return indexExists, nil
+		},
+	}
+
+	mockProductRepo := &MockProductRepository{
+		FindAllFunc: func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			return []*entity.Product{{ID: "A"}}, nil
+		},
+	}
+
+	backfill := &stubBackfiller{}
+	uc := NewListProductsUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, ListProductsOptions{
+		Backfill: backfill,
+	})
+
+	products, err := uc.Execute(context.Background(), repository.ListFilter{}, repository.SortOptions{}, 10, 0)
+	if err != nil || len(products) != 1 {
+		t.Fatalf("Expected cold list to be served from the database, got %v, %v", products, err)
+	}
+	if backfill.triggered != 1 {
+		t.Errorf("Expected backfill to be triggered once, got %d", backfill.triggered)
+	}
+
+	indexExists = true
+	if _, err := uc.Execute(context.Background(), repository.ListFilter{}, repository.SortOptions{}, 10, 100); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if backfill.triggered != 1 {
+		t.Error("Expected an offset past the end of an existing index not to trigger the backfill")
+	}
+}
+
+func TestListProductsUseCase_Execute_ShortPageDuringBackfillFallsBack(t *testing.T) {
+	mockCacheRepo := &MockCacheRepository{
+		GetSortedSetRangeFunc: func(ctx context.Context, setKey string, start, stop int64) ([]string, error) {
+			return []string{"A"}, nil
+		},
+		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
+			return []*entity.Product{{ID: "A"}}, nil
+		},
+	}
+
+	dbCalled := false
+	mockProductRepo := &MockProductRepository{
+		FindAllFunc: func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			dbCalled = true
+			return []*entity.Product{{ID: "A"}, {ID: "B"}}, nil
+		},
+	}
+
+	uc := NewListProductsUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, ListProductsOptions{
+		Backfill: &stubBackfiller{running: true},
+	})
+
+	products, err := uc.Execute(context.Background(), repository.ListFilter{}, repository.SortOptions{}, 10, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !dbCalled || len(products) != 2 {
+		t.Errorf("Expected short page during backfill to be served from the database, got %d products", len(products))
+	}
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+)
+
+// BackgroundTasks acompanha as tarefas em background dos casos de uso para
+// que o shutdown possa drená-las antes de fechar Redis e banco.
+type BackgroundTasks struct {
+	wg sync.WaitGroup
+}
+
+func NewBackgroundTasks() *BackgroundTasks {
+	return &BackgroundTasks{}
+}
+
+func (b *BackgroundTasks) Go(task func()) {
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		task()
+	}()
+}
+
+// Wait bloqueia até todas as tarefas terminarem ou o contexto expirar.
+func (b *BackgroundTasks) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// backgroundRunnerOrGo evita checagens de nil nos casos de uso.
+func backgroundRunnerOrGo(runner port.BackgroundRunner) port.BackgroundRunner {
+	if runner == nil {
+		return port.GoRunner{}
+	}
+	return runner
+}
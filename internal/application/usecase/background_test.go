@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBackgroundTasks_Wait(t *testing.T) {
+	tasks := NewBackgroundTasks()
+
+	var done atomic.Int32
+	for i := 0; i < 5; i++ {
+		tasks.Go(func() {
+			time.Sleep(10 * time.Millisecond)
+			done.Add(1)
+		})
+	}
+
+	if err := tasks.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if done.Load() != 5 {
+		t.Errorf("Expected all tasks to finish before Wait returns, got %d", done.Load())
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+	tasks.Go(func() { <-release })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := tasks.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected DeadlineExceeded for a stuck task, got %v", err)
+	}
+}
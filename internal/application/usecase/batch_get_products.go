@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+type BatchGetProductsOptions struct {
+	FallbackRecorder port.FallbackRecorder
+	CacheRecorder    port.CacheRecorder
+	Background       port.BackgroundRunner
+}
+
+// BatchGetProductsUseCase busca vários produtos por ID: os que estão no cache
+// saem de um GetMultiple, e os demais de uma única consulta ao banco, que
+// repopula o cache em background.
+type BatchGetProductsUseCase struct {
+	productRepo repository.ProductRepository
+	cacheRepo   repository.CacheRepository
+	cacheKeys   port.CacheKeyGenerator
+	logger      port.Logger
+	options     BatchGetProductsOptions
+}
+
+func NewBatchGetProductsUseCase(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+	options BatchGetProductsOptions,
+) *BatchGetProductsUseCase {
+	options.FallbackRecorder = fallbackRecorderOrNoop(options.FallbackRecorder)
+	options.CacheRecorder = cacheRecorderOrNoop(options.CacheRecorder)
+	options.Background = backgroundRunnerOrGo(options.Background)
+
+	return &BatchGetProductsUseCase{
+		productRepo: productRepo,
+		cacheRepo:   cacheRepo,
+		cacheKeys:   cacheKeys,
+		logger:      logger,
+		options:     options,
+	}
+}
+
+// Execute devolve os produtos encontrados indexados pelo ID. IDs repetidos
+// são buscados uma vez, e IDs inexistentes ou excluídos ficam fora do mapa.
+func (uc *BatchGetProductsUseCase) Execute(ctx context.Context, ids []string) (map[string]*entity.Product, error) {
+	ids = uniqueIDs(ids)
+	found := make(map[string]*entity.Product, len(ids))
+	if len(ids) == 0 {
+		return found, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = uc.cacheKeys.ProductKey(id)
+	}
+
+	cached, err := uc.cacheRepo.GetMultiple(ctx, keys)
+	if err != nil {
+		uc.logger.Debug("failed to get products from cache - fetching batch from database",
+			"error", err,
+			"ids", len(ids),
+		)
+		cached = nil
+	}
+	for _, product := range cached {
+		found[product.ID] = product
+	}
+
+	missing := make([]string, 0, len(ids)-len(found))
+	for _, id := range ids {
+		if _, ok := found[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) == 0 {
+		observeCacheHit(ctx, uc.options.CacheRecorder, "batch_get")
+		return found, nil
+	}
+	observeCacheMiss(ctx, uc.options.CacheRecorder, "batch_get")
+
+	uc.logger.Debug("batch cache miss - fetching from database",
+		"requested", len(ids),
+		"missing", len(missing),
+	)
+
+	start := time.Now()
+	products, err := uc.productRepo.FindByIDs(ctx, missing)
+	uc.options.FallbackRecorder.ObserveDBFallback("batch_get_products", time.Since(start))
+	if err != nil {
+		uc.logger.Error("failed to fetch products batch from database",
+			"error", err,
+			"missing", len(missing),
+		)
+		return nil, err
+	}
+
+	for _, product := range products {
+		found[product.ID] = product
+	}
+	uc.backfill(ctx, products)
+
+	return found, nil
+}
+
+// backfill grava em background as entradas lidas do banco. É best-effort: um
+// produto que não foi gravado volta ao banco na próxima busca.
+func (uc *BatchGetProductsUseCase) backfill(ctx context.Context, products []*entity.Product) {
+	if len(products) == 0 {
+		return
+	}
+
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), repopulateTimeout)
+
+	uc.options.Background.Go(func() {
+		defer cancel()
+
+		for _, product := range products {
+			if err := uc.cacheRepo.Set(writeCtx, uc.cacheKeys.ProductKey(product.ID), product); err != nil && !skippedCacheWrite(uc.logger, err, product) {
+				uc.logger.Warn("failed to cache product from batch get",
+					"error", err,
+					"product_id", product.HashID(),
+				)
+			}
+		}
+	})
+}
+
+// uniqueIDs remove IDs vazios e repetidos, mantendo a ordem da primeira
+// ocorrência.
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
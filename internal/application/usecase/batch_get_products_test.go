@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+func TestBatchGetProductsUseCase_Execute_FetchesOnlyMissingFromDatabase(t *testing.T) {
+	cached := newTestProductWithData("Cached", "REF-001", "Phones")
+	stored := newTestProductWithData("Stored", "REF-002", "Phones")
+
+	var queried []string
+	productRepo := &MockProductRepository{
+		FindByIDsFunc: func(ctx context.Context, ids []string) ([]*entity.Product, error) {
+			queried = ids
+			return []*entity.Product{stored}, nil
+		},
+	}
+
+	var mu sync.Mutex
+	var backfilled []string
+	cacheRepo := &MockCacheRepository{
+		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
+			return []*entity.Product{cached}, nil
+		},
+		SetFunc: func(ctx context.Context, key string, product *entity.Product) error {
+			mu.Lock()
+			defer mu.Unlock()
+			backfilled = append(backfilled, key)
+			return nil
+		},
+	}
+
+	tasks := NewBackgroundTasks()
+	uc := NewBatchGetProductsUseCase(productRepo, cacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, BatchGetProductsOptions{
+		Background: tasks,
+	})
+
+	found, err := uc.Execute(context.Background(), []string{cached.ID, stored.ID, "missing", cached.ID, ""})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := tasks.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected cache writes to finish, got %v", err)
+	}
+
+	if !reflect.DeepEqual(queried, []string{stored.ID, "missing"}) {
+		t.Errorf("Expected only the cache misses queried, got %v", queried)
+	}
+	if len(found) != 2 || found[cached.ID] != cached || found[stored.ID] != stored {
+		t.Errorf("Expected cached and stored products, got %v", found)
+	}
+	if !reflect.DeepEqual(backfilled, []string{"product_" + stored.ID}) {
+		t.Errorf("Expected only the database product backfilled, got %v", backfilled)
+	}
+}
+
+func TestBatchGetProductsUseCase_Execute_SingleDatabaseQueryForMisses(t *testing.T) {
+	stored := make([]*entity.Product, 5)
+	ids := make([]string, len(stored))
+	for i := range stored {
+		stored[i] = newTestProductWithData(fmt.Sprintf("Product %d", i), fmt.Sprintf("REF-%03d", i), "Phones")
+		ids[i] = stored[i].ID
+	}
+
+	batchQueries := 0
+	productRepo := &MockProductRepository{
+		FindByIDsFunc: func(ctx context.Context, ids []string) ([]*entity.Product, error) {
+			batchQueries++
+			return stored, nil
+		},
+		FindByIDFunc: func(ctx context.Context, id string) (*entity.Product, error) {
+			t.Errorf("Expected no per-id lookup, got one for %s", id)
+			return nil, repository.ErrProductNotFound
+		},
+	}
+
+	var mu sync.Mutex
+	backfilled := 0
+	cacheRepo := &MockCacheRepository{
+		SetFunc: func(ctx context.Context, key string, product *entity.Product) error {
+			mu.Lock()
+			defer mu.Unlock()
+			backfilled++
+			return nil
+		},
+	}
+
+	tasks := NewBackgroundTasks()
+	uc := NewBatchGetProductsUseCase(productRepo, cacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, BatchGetProductsOptions{
+		Background: tasks,
+	})
+
+	found, err := uc.Execute(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := tasks.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected cache writes to finish, got %v", err)
+	}
+
+	if batchQueries != 1 {
+		t.Errorf("Expected a single database query for %d misses, got %d", len(ids), batchQueries)
+	}
+	if len(found) != len(ids) {
+		t.Errorf("Expected %d products, got %d", len(ids), len(found))
+	}
+	if backfilled != len(ids) {
+		t.Errorf("Expected %d cache writes, got %d", len(ids), backfilled)
+	}
+}
+
+func TestBatchGetProductsUseCase_Execute_AllCached(t *testing.T) {
+	products := []*entity.Product{
+		newTestProductWithData("A", "REF-001", "Phones"),
+		newTestProductWithData("B", "REF-002", "Phones"),
+	}
+
+	productRepo := &MockProductRepository{
+		FindByIDsFunc: func(ctx context.Context, ids []string) ([]*entity.Product, error) {
+			t.Error("Expected database not to be called when every id is cached")
+			return nil, nil
+		},
+	}
+	cacheRepo := &MockCacheRepository{
+		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
+			return products, nil
+		},
+	}
+
+	uc := NewBatchGetProductsUseCase(productRepo, cacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, BatchGetProductsOptions{})
+
+	found, err := uc.Execute(context.Background(), []string{products[0].ID, products[1].ID})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(found) != 2 {
+		t.Errorf("Expected 2 products, got %d", len(found))
+	}
+}
+
+func TestBatchGetProductsUseCase_Execute_CacheErrorFallsBackToDatabase(t *testing.T) {
+	stored := newTestProductWithData("Stored", "REF-001", "Phones")
+
+	productRepo := &MockProductRepository{
+		FindByIDsFunc: func(ctx context.Context, ids []string) ([]*entity.Product, error) {
+			return []*entity.Product{stored}, nil
+		},
+	}
+	cacheRepo := &MockCacheRepository{
+		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
+			return nil, errors.New("redis down")
+		},
+	}
+
+	tasks := NewBackgroundTasks()
+	uc := NewBatchGetProductsUseCase(productRepo, cacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, BatchGetProductsOptions{
+		Background: tasks,
+	})
+
+	found, err := uc.Execute(context.Background(), []string{stored.ID})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	tasks.Wait(context.Background())
+
+	if found[stored.ID] != stored {
+		t.Errorf("Expected product from the database, got %v", found)
+	}
+}
+
+func TestBatchGetProductsUseCase_Execute_DatabaseError(t *testing.T) {
+	dbErr := errors.New("connection refused")
+	productRepo := &MockProductRepository{
+		FindByIDsFunc: func(ctx context.Context, ids []string) ([]*entity.Product, error) {
+			return nil, dbErr
+		},
+	}
+
+	uc := NewBatchGetProductsUseCase(productRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{}, BatchGetProductsOptions{})
+
+	if _, err := uc.Execute(context.Background(), []string{"a"}); !errors.Is(err, dbErr) {
+		t.Errorf("Expected database error, got %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// errAdjustmentNotApplied marca os itens que não chegaram a ser aplicados
+// porque o lote foi interrompido por uma falha do banco ou pelo cancelamento
+// da requisição.
+const errAdjustmentNotApplied = "not applied: batch interrupted"
+
+// BulkAdjustStockOptions ajusta a reconciliação em lote. Events recebe um
+// product.updated por item aplicado; nil descarta.
+//
+// SerializationRetries e RetryBackoff repetem cada item quando o banco o aborta
+// por conflito de serialização, como no ajuste individual.
+type BulkAdjustStockOptions struct {
+	Events               port.ChangePublisher
+	SerializationRetries int
+	RetryBackoff         time.Duration
+}
+
+// BulkAdjustStockUseCase aplica os ajustes de uma reconciliação de armazém.
+// Cada item é um AdjustStock atômico: um item recusado (produto inexistente ou
+// estoque que ficaria negativo) entra no relatório sem afetar os demais. Como
+// no PATCH de estoque, a versão sobe e estoque e versão da entrada em cache são
+// ajustados no próprio Redis.
+type BulkAdjustStockUseCase struct {
+	productRepo repository.ProductRepository
+	cacheRepo   repository.CacheRepository
+	cacheKeys   port.CacheKeyGenerator
+	logger      port.Logger
+	options     BulkAdjustStockOptions
+}
+
+func NewBulkAdjustStockUseCase(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+) *BulkAdjustStockUseCase {
+	return NewBulkAdjustStockUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, logger, BulkAdjustStockOptions{})
+}
+
+func NewBulkAdjustStockUseCaseWithOptions(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+	options BulkAdjustStockOptions,
+) *BulkAdjustStockUseCase {
+	options.Events = changePublisherOrNoop(options.Events)
+
+	return &BulkAdjustStockUseCase{
+		productRepo: productRepo,
+		cacheRepo:   cacheRepo,
+		cacheKeys:   cacheKeys,
+		logger:      logger,
+		options:     options,
+	}
+}
+
+// Execute aplica os ajustes na ordem recebida. Uma falha de infraestrutura do
+// banco (ou o cancelamento da requisição) interrompe o lote: os itens já
+// aplicados continuam aplicados e o item atual e os seguintes entram no
+// relatório como não aplicados, para que o cliente saiba exatamente o que
+// reenviar. Se nada foi aplicado até a falha, o erro é retornado no lugar do
+// relatório.
+func (uc *BulkAdjustStockUseCase) Execute(ctx context.Context, adjustments []port.StockAdjustment) (*port.StockAdjustmentReport, error) {
+	report := &port.StockAdjustmentReport{
+		Total:   len(adjustments),
+		Results: make([]port.StockAdjustmentResult, 0, len(adjustments)),
+	}
+
+	for i, adjustment := range adjustments {
+		err := ctx.Err()
+		var result port.StockAdjustmentResult
+		if err == nil {
+			result, err = uc.adjust(ctx, adjustment)
+		}
+		if err != nil {
+			uc.logger.Error("bulk stock adjustment interrupted",
+				"error", err,
+				"product_id", adjustment.ID[:min(8, len(adjustment.ID))],
+				"applied", report.Applied,
+				"not_applied", len(adjustments)-i,
+			)
+			if report.Applied == 0 {
+				return nil, fmt.Errorf("failed to adjust stock: %w", err)
+			}
+			uc.skipRemaining(report, adjustments[i:])
+			break
+		}
+
+		if result.Applied {
+			report.Applied++
+		} else {
+			report.Failed++
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	uc.logger.Info("bulk stock adjustment finished",
+		"total", report.Total,
+		"applied", report.Applied,
+		"failed", report.Failed,
+	)
+
+	return report, nil
+}
+
+// skipRemaining registra como não aplicados os itens que sobraram do lote.
+func (uc *BulkAdjustStockUseCase) skipRemaining(report *port.StockAdjustmentReport, remaining []port.StockAdjustment) {
+	for _, adjustment := range remaining {
+		report.Failed++
+		report.Results = append(report.Results, port.StockAdjustmentResult{
+			ID:    adjustment.ID,
+			Delta: adjustment.Delta,
+			Error: errAdjustmentNotApplied,
+		})
+	}
+}
+
+// adjust aplica um item. Recusas de domínio viram resultado com Error; só
+// falhas de infraestrutura retornam erro.
+func (uc *BulkAdjustStockUseCase) adjust(ctx context.Context, adjustment port.StockAdjustment) (port.StockAdjustmentResult, error) {
+	result := port.StockAdjustmentResult{
+		ID:    adjustment.ID,
+		Delta: adjustment.Delta,
+	}
+
+	switch {
+	case adjustment.ID == "":
+		result.Error = "id is required"
+		return result, nil
+	case adjustment.Delta == 0:
+		result.Error = "delta must not be zero"
+		return result, nil
+	}
+
+	var stock int
+	var revision repository.Revision
+	err := retrySerialization(ctx, uc.logger, "stock adjustment", adjustment.ID, uc.options.SerializationRetries, uc.options.RetryBackoff, func() error {
+		var err error
+		stock, revision, err = uc.productRepo.AdjustStock(ctx, adjustment.ID, adjustment.Delta)
+		return err
+	})
+	switch {
+	case errors.Is(err, entity.ErrInsufficientStock):
+		result.Stock = stock
+		result.Error = err.Error()
+		return result, nil
+	case errors.Is(err, repository.ErrProductNotFound):
+		result.Error = err.Error()
+		return result, nil
+	case err != nil:
+		return result, err
+	}
+
+	patchCachedStock(ctx, uc.cacheRepo, uc.cacheKeys, uc.logger, adjustment.ID, stock, revision)
+	publishRevision(uc.options.Events, adjustment.ID, revision)
+
+	result.Stock = stock
+	result.Applied = true
+	return result, nil
+}
@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+func TestBulkAdjustStockUseCase_Execute(t *testing.T) {
+	stock := map[string]int{"a": 10, "b": 2}
+
+	mockProductRepo := &MockProductRepository{
+		AdjustStockFunc: func(ctx context.Context, id string, delta int) (int, repository.Revision, error) {
+			current, ok := stock[id]
+			if !ok {
+				return 0, repository.Revision{}, repository.ErrProductNotFound
+			}
+			if current+delta < 0 {
+				return current, repository.Revision{}, entity.ErrInsufficientStock
+			}
+			stock[id] = current + delta
+			return stock[id], repository.Revision{Version: 2}, nil
+		},
+	}
+
+	patched := make(map[string]int)
+	mockCacheRepo := &MockCacheRepository{
+		PatchStockFunc: func(ctx context.Context, key string, stock int, revision repository.Revision) error {
+			if revision.Version != 2 {
+				t.Errorf("Expected the new version to be patched into %s, got %d", key, revision.Version)
+			}
+			patched[key] = stock
+			return nil
+		},
+	}
+
+	events := &recordingPublisher{}
+	uc := NewBulkAdjustStockUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, BulkAdjustStockOptions{
+		Events: events,
+	})
+
+	report, err := uc.Execute(context.Background(), []port.StockAdjustment{
+		{ID: "a", Delta: -3},
+		{ID: "b", Delta: -5},
+		{ID: "missing", Delta: 1},
+		{ID: "a", Delta: 0},
+		{ID: "", Delta: 1},
+		{ID: "b", Delta: 4},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if report.Total != 6 || report.Applied != 2 || report.Failed != 4 {
+		t.Fatalf("Expected 6 total, 2 applied, 4 failed, got %+v", report)
+	}
+
+	want := []port.StockAdjustmentResult{
+		{ID: "a", Delta: -3, Stock: 7, Applied: true},
+		{ID: "b", Delta: -5, Stock: 2, Error: entity.ErrInsufficientStock.Error()},
+		{ID: "missing", Delta: 1, Error: repository.ErrProductNotFound.Error()},
+		{ID: "a", Delta: 0, Error: "delta must not be zero"},
+		{ID: "", Delta: 1, Error: "id is required"},
+		{ID: "b", Delta: 4, Stock: 6, Applied: true},
+	}
+	for i, result := range report.Results {
+		if result != want[i] {
+			t.Errorf("Result %d = %+v, want %+v", i, result, want[i])
+		}
+	}
+
+	if len(patched) != 2 || patched["product_a"] != 7 || patched["product_b"] != 6 {
+		t.Errorf("Expected cache patched only for applied items, got %v", patched)
+	}
+	if len(events.events) != 2 || events.events[0].ProductID != "a" || events.events[1].ProductID != "b" {
+		t.Errorf("Expected one event per applied item, got %+v", events.events)
+	}
+}
+
+func TestBulkAdjustStockUseCase_Execute_DatabaseErrorAborts(t *testing.T) {
+	dbErr := errors.New("connection refused")
+	calls := 0
+
+	mockProductRepo := &MockProductRepository{
+		AdjustStockFunc: func(ctx context.Context, id string, delta int) (int, repository.Revision, error) {
+			calls++
+			return 0, repository.Revision{}, dbErr
+		},
+	}
+
+	uc := NewBulkAdjustStockUseCase(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	_, err := uc.Execute(context.Background(), []port.StockAdjustment{
+		{ID: "a", Delta: 1},
+		{ID: "b", Delta: 1},
+	})
+
+	if !errors.Is(err, dbErr) {
+		t.Errorf("Expected database error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected the batch to stop at the first database error, got %d calls", calls)
+	}
+}
+
+func TestBulkAdjustStockUseCase_Execute_DatabaseErrorReportsRemaining(t *testing.T) {
+	dbErr := errors.New("connection refused")
+	calls := 0
+
+	mockProductRepo := &MockProductRepository{
+		AdjustStockFunc: func(ctx context.Context, id string, delta int) (int, repository.Revision, error) {
+			calls++
+			if id != "a" {
+				return 0, repository.Revision{}, dbErr
+			}
+			return 5, repository.Revision{Version: 2}, nil
+		},
+	}
+
+	uc := NewBulkAdjustStockUseCase(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	report, err := uc.Execute(context.Background(), []port.StockAdjustment{
+		{ID: "a", Delta: 1},
+		{ID: "b", Delta: 2},
+		{ID: "c", Delta: 3},
+	})
+	if err != nil {
+		t.Fatalf("Expected a partial report once an item was applied, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected the batch to stop at the first database error, got %d calls", calls)
+	}
+
+	want := []port.StockAdjustmentResult{
+		{ID: "a", Delta: 1, Stock: 5, Applied: true},
+		{ID: "b", Delta: 2, Error: errAdjustmentNotApplied},
+		{ID: "c", Delta: 3, Error: errAdjustmentNotApplied},
+	}
+	if report.Total != 3 || report.Applied != 1 || report.Failed != 2 || len(report.Results) != len(want) {
+		t.Fatalf("Expected 3 total, 1 applied, 2 failed, got %+v", report)
+	}
+	for i, result := range report.Results {
+		if result != want[i] {
+			t.Errorf("Result %d = %+v, want %+v", i, result, want[i])
+		}
+	}
+}
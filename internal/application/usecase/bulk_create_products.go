@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// BulkCreateProductsUseCase valida cada item como a criação unitária e grava
+// os válidos num único lote do repositório.
+type BulkCreateProductsUseCase struct {
+	productRepo repository.ProductRepository
+	creator     *CreateProductUseCase
+	logger      port.Logger
+}
+
+func NewBulkCreateProductsUseCase(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+	options CreateProductOptions,
+) *BulkCreateProductsUseCase {
+	return &BulkCreateProductsUseCase{
+		productRepo: productRepo,
+		creator:     NewCreateProductUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, logger, options),
+		logger:      logger,
+	}
+}
+
+// Execute devolve um resultado por item, na ordem da entrada. Erros de
+// validação e de duplicidade ficam no item; só uma falha do lote inteiro (banco
+// indisponível, por exemplo) é retornada como erro.
+func (uc *BulkCreateProductsUseCase) Execute(ctx context.Context, inputs []port.CreateProductInput) (*port.BulkCreateReport, error) {
+	report := &port.BulkCreateReport{
+		Total:   len(inputs),
+		Results: make([]port.BulkCreateResult, len(inputs)),
+	}
+
+	products := make([]*entity.Product, 0, len(inputs))
+	positions := make([]int, 0, len(inputs))
+	for i, input := range inputs {
+		report.Results[i].Index = i
+
+		product, err := uc.creator.newProduct(input)
+		if err == nil && uc.creator.options.UniqueNamePerCategory {
+			err = uc.creator.checkNameInCategory(ctx, product)
+		}
+		if err != nil {
+			report.Results[i].Err = err
+			continue
+		}
+
+		products = append(products, product)
+		positions = append(positions, i)
+	}
+
+	if len(products) > 0 {
+		errs, err := uc.productRepo.CreateBatch(ctx, products)
+		if err != nil {
+			uc.logger.Error("failed to create products in batch",
+				"error", err,
+				"count", len(products),
+			)
+			return nil, fmt.Errorf("failed to save products: %w", err)
+		}
+
+		for j, product := range products {
+			i := positions[j]
+			if errs[j] != nil {
+				report.Results[i].Err = errs[j]
+				continue
+			}
+
+			report.Results[i].Product = product
+			uc.creator.auditCreated(ctx, product)
+			uc.creator.updateCache(ctx, product)
+			uc.creator.publishCreated(product)
+		}
+	}
+
+	for _, result := range report.Results {
+		if result.Err != nil {
+			report.Failed++
+			continue
+		}
+		report.Created++
+	}
+
+	uc.logger.Info("bulk product creation finished",
+		"total", report.Total,
+		"created", report.Created,
+		"failed", report.Failed,
+	)
+
+	return report, nil
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+func bulkCreateInput(name, ref string) port.CreateProductInput {
+	return port.CreateProductInput{
+		Name:            name,
+		ReferenceNumber: ref,
+		Category:        "Electronics",
+		Stock:           10,
+	}
+}
+
+func TestBulkCreateProductsUseCase_Execute(t *testing.T) {
+	var batched []*entity.Product
+	mockProductRepo := &MockProductRepository{
+		CreateBatchFunc: func(ctx context.Context, products []*entity.Product) ([]error, error) {
+			batched = products
+			errs := make([]error, len(products))
+			errs[1] = repository.ErrProductAlreadyExists
+			return errs, nil
+		},
+	}
+
+	indexed := make(map[string][]string)
+	mockCacheRepo := &MockCacheRepository{
+		AddToSetFunc: func(ctx context.Context, setKey, productID string) error {
+			indexed[setKey] = append(indexed[setKey], productID)
+			return nil
+		},
+		AddToSortedSetFunc: func(ctx context.Context, setKey, productID string, score float64) error {
+			indexed[setKey] = append(indexed[setKey], productID)
+			return nil
+		},
+	}
+
+	uc := NewBulkCreateProductsUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, CreateProductOptions{})
+
+	report, err := uc.Execute(context.Background(), []port.CreateProductInput{
+		bulkCreateInput("Notebook", "REF-1"),
+		bulkCreateInput("", "REF-2"),
+		bulkCreateInput("Mouse", "REF-3"),
+		bulkCreateInput("Teclado", "REF-4"),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if report.Total != 4 || report.Created != 2 || report.Failed != 2 {
+		t.Fatalf("Expected 4 total, 2 created, 2 failed, got %+v", report)
+	}
+
+	if len(batched) != 3 {
+		t.Fatalf("Expected only valid items in the batch, got %d", len(batched))
+	}
+
+	for i, result := range report.Results {
+		if result.Index != i {
+			t.Errorf("Result %d has index %d", i, result.Index)
+		}
+	}
+
+	if !errors.Is(report.Results[1].Err, entity.ErrInvalidName) {
+		t.Errorf("Expected invalid name for item 1, got %v", report.Results[1].Err)
+	}
+	if !errors.Is(report.Results[2].Err, repository.ErrProductAlreadyExists) {
+		t.Errorf("Expected conflict for item 2, got %v", report.Results[2].Err)
+	}
+	if report.Results[0].Product == nil || report.Results[3].Product == nil {
+		t.Fatal("Expected products for created items")
+	}
+
+	all := indexed["all_products"]
+	if len(all) != 2 || all[0] != report.Results[0].Product.ID || all[1] != report.Results[3].Product.ID {
+		t.Errorf("Expected all_products updated only for created items, got %v", all)
+	}
+	if len(indexed["product_by_category_Electronics"]) != 2 {
+		t.Errorf("Expected category index updated for created items, got %v", indexed)
+	}
+}
+
+func TestBulkCreateProductsUseCase_Execute_DatabaseError(t *testing.T) {
+	dbErr := errors.New("connection refused")
+	mockProductRepo := &MockProductRepository{
+		CreateBatchFunc: func(ctx context.Context, products []*entity.Product) ([]error, error) {
+			return nil, dbErr
+		},
+	}
+
+	uc := NewBulkCreateProductsUseCase(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{}, CreateProductOptions{})
+
+	_, err := uc.Execute(context.Background(), []port.CreateProductInput{bulkCreateInput("Notebook", "REF-1")})
+	if !errors.Is(err, dbErr) {
+		t.Fatalf("Expected database error, got %v", err)
+	}
+}
+
+func TestBulkCreateProductsUseCase_Execute_AllInvalidSkipsDatabase(t *testing.T) {
+	mockProductRepo := &MockProductRepository{
+		CreateBatchFunc: func(ctx context.Context, products []*entity.Product) ([]error, error) {
+			t.Fatal("CreateBatch should not be called without valid items")
+			return nil, nil
+		},
+	}
+
+	uc := NewBulkCreateProductsUseCase(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{}, CreateProductOptions{})
+
+	report, err := uc.Execute(context.Background(), []port.CreateProductInput{bulkCreateInput("", "REF-1")})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if report.Failed != 1 || report.Created != 0 {
+		t.Errorf("Expected 1 failed, got %+v", report)
+	}
+}
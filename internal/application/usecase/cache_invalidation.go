@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// CacheInvalidationUseCase remove do cache dados de produtos sem tocar no
+// banco, para uso em incidentes.
+type CacheInvalidationUseCase struct {
+	productRepo repository.ProductRepository
+	cacheRepo   repository.CacheRepository
+	cacheKeys   port.CacheKeyGenerator
+	logger      port.Logger
+}
+
+func NewCacheInvalidationUseCase(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+) *CacheInvalidationUseCase {
+	return &CacheInvalidationUseCase{
+		productRepo: productRepo,
+		cacheRepo:   cacheRepo,
+		cacheKeys:   cacheKeys,
+		logger:      logger,
+	}
+}
+
+// InvalidateProduct apaga a entrada do produto e tira o ID de all_products e
+// dos índices de nome, categoria, SKU e tags. Os índices saem do produto no
+// banco e também da cópia em cache, que pode estar em índices antigos se o
+// banco mudou por fora da API. Se o produto não está no banco (removido ou
+// falha na consulta), só a cópia em cache é usada.
+func (uc *CacheInvalidationUseCase) InvalidateProduct(ctx context.Context, id string) (int, error) {
+	productKey := uc.cacheKeys.ProductKey(id)
+
+	current, err := uc.productRepo.FindByID(ctx, id)
+	if err != nil {
+		current = nil
+		if !errors.Is(err, repository.ErrProductNotFound) {
+			uc.logger.Warn("failed to fetch product for cache invalidation, using cached copy",
+				"error", err,
+				"product_id", id[:min(8, len(id))],
+			)
+		}
+	}
+
+	cached, err := uc.cacheRepo.Get(ctx, productKey)
+	if err != nil {
+		cached = nil
+	}
+
+	setKeys := uc.indexKeys(current, cached)
+	sortedSetKeys := []string{uc.cacheKeys.AllProductsKey()}
+
+	affected, err := uc.cacheRepo.InvalidateProduct(ctx, productKey, id, setKeys, sortedSetKeys)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", repository.ErrCacheUnavailable, err)
+	}
+
+	uc.logger.Info("product cache invalidated",
+		"product_id", id[:min(8, len(id))],
+		"keys_affected", affected,
+	)
+
+	return affected, nil
+}
+
+// FlushAll apaga todas as chaves de cache, inclusive as respostas guardadas
+// das listagens, preservando idempotência, dead letters e rate limiting.
+func (uc *CacheInvalidationUseCase) FlushAll(ctx context.Context) (int, error) {
+	affected, err := uc.cacheRepo.FlushCache(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", repository.ErrCacheUnavailable, err)
+	}
+
+	uc.logger.Info("cache flushed",
+		"keys_affected", affected,
+	)
+
+	return affected, nil
+}
+
+// indexKeys retorna, sem repetição, os índices de nome, categoria, SKU e tags
+// dos produtos informados; produtos nil são ignorados.
+func (uc *CacheInvalidationUseCase) indexKeys(products ...*entity.Product) []string {
+	seen := make(map[string]bool)
+	keys := []string{}
+	add := func(key string) {
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	for _, product := range products {
+		if product == nil {
+			continue
+		}
+		add(uc.cacheKeys.NameKey(product.Name))
+		add(uc.cacheKeys.CategoryKey(product.Category))
+		if product.SKU != "" {
+			add(uc.cacheKeys.SKUKey(product.SKU))
+		}
+		for _, tag := range product.Tags {
+			add(uc.cacheKeys.TagKey(tag))
+		}
+	}
+
+	return keys
+}
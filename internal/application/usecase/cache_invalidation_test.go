@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+func TestCacheInvalidationUseCase_InvalidateProduct_UsesDatabaseAndCachedIndexes(t *testing.T) {
+	current := newTestProductWithData("iPhone 15", "REF-001", "Phones")
+	current.Tags = []string{"promo"}
+	cached := *current
+	cached.Category = "Smartphones"
+
+	var gotKey, gotID string
+	var gotSets, gotSortedSets []string
+	productRepo := &MockProductRepository{
+		FindByIDFunc: func(ctx context.Context, id string) (*entity.Product, error) {
+			return current, nil
+		},
+	}
+	cacheRepo := &MockCacheRepository{
+		GetFunc: func(ctx context.Context, key string) (*entity.Product, error) {
+			return &cached, nil
+		},
+		InvalidateProductFunc: func(ctx context.Context, productKey, productID string, setKeys, sortedSetKeys []string) (int, error) {
+			gotKey, gotID, gotSets, gotSortedSets = productKey, productID, setKeys, sortedSetKeys
+			return 4, nil
+		},
+	}
+
+	uc := NewCacheInvalidationUseCase(productRepo, cacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	affected, err := uc.InvalidateProduct(context.Background(), current.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if affected != 4 {
+		t.Errorf("Expected 4 keys affected, got %d", affected)
+	}
+
+	if gotKey != "product_"+current.ID || gotID != current.ID {
+		t.Errorf("Unexpected product key %q and id %q", gotKey, gotID)
+	}
+	sort.Strings(gotSets)
+	wantSets := []string{
+		"product_by_category_Phones",
+		"product_by_category_Smartphones",
+		"product_by_name_iPhone 15",
+		"product_by_sku_SKU-001",
+		"product_by_tag_promo",
+	}
+	if !reflect.DeepEqual(gotSets, wantSets) {
+		t.Errorf("Expected sets %v, got %v", wantSets, gotSets)
+	}
+	if !reflect.DeepEqual(gotSortedSets, []string{"all_products"}) {
+		t.Errorf("Expected all_products sorted set, got %v", gotSortedSets)
+	}
+}
+
+func TestCacheInvalidationUseCase_InvalidateProduct_FallsBackToCachedCopy(t *testing.T) {
+	cached := newTestProductWithData("Orphan", "REF-002", "Phones")
+
+	var gotSets []string
+	productRepo := &MockProductRepository{
+		FindByIDFunc: func(ctx context.Context, id string) (*entity.Product, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+	cacheRepo := &MockCacheRepository{
+		GetFunc: func(ctx context.Context, key string) (*entity.Product, error) {
+			return cached, nil
+		},
+		InvalidateProductFunc: func(ctx context.Context, productKey, productID string, setKeys, sortedSetKeys []string) (int, error) {
+			gotSets = setKeys
+			return 3, nil
+		},
+	}
+
+	uc := NewCacheInvalidationUseCase(productRepo, cacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	if _, err := uc.InvalidateProduct(context.Background(), cached.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := []string{"product_by_name_Orphan", "product_by_category_Phones", "product_by_sku_SKU-001"}
+	if !reflect.DeepEqual(gotSets, want) {
+		t.Errorf("Expected sets %v, got %v", want, gotSets)
+	}
+}
+
+func TestCacheInvalidationUseCase_InvalidateProduct_CacheError(t *testing.T) {
+	productRepo := &MockProductRepository{
+		FindByIDFunc: func(ctx context.Context, id string) (*entity.Product, error) {
+			return nil, repository.ErrProductNotFound
+		},
+	}
+	cacheRepo := &MockCacheRepository{
+		InvalidateProductFunc: func(ctx context.Context, productKey, productID string, setKeys, sortedSetKeys []string) (int, error) {
+			return 0, errors.New("redis down")
+		},
+	}
+
+	uc := NewCacheInvalidationUseCase(productRepo, cacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	_, err := uc.InvalidateProduct(context.Background(), "missing")
+	if !errors.Is(err, repository.ErrCacheUnavailable) {
+		t.Fatalf("Expected ErrCacheUnavailable, got %v", err)
+	}
+}
+
+func TestCacheInvalidationUseCase_FlushAll(t *testing.T) {
+	cacheRepo := &MockCacheRepository{
+		FlushCacheFunc: func(ctx context.Context) (int, error) {
+			return 42, nil
+		},
+	}
+
+	uc := NewCacheInvalidationUseCase(&MockProductRepository{}, cacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	affected, err := uc.FlushAll(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if affected != 42 {
+		t.Errorf("Expected 42 keys affected, got %d", affected)
+	}
+}
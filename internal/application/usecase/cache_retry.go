@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// cacheRetryAttemptTimeout limita cada rodada de novas tentativas, que roda
+// fora do ciclo da requisição.
+const cacheRetryAttemptTimeout = 5 * time.Second
+
+// CacheRetryOptions define o orçamento de novas tentativas das escritas que
+// populam o cache. Retries é quantas vezes uma escrita que falhou por erro
+// transitório do Redis é repetida; zero desliga. Backoff é a espera antes da
+// primeira repetição, dobrada a cada nova tentativa.
+type CacheRetryOptions struct {
+	Retries int
+	Backoff time.Duration
+}
+
+// cacheWrite é uma escrita de cache de um produto. fail registra a falha
+// definitiva, depois de esgotadas as tentativas.
+type cacheWrite struct {
+	run  func(ctx context.Context) error
+	fail func(err error)
+}
+
+type pendingCacheWrite struct {
+	cacheWrite
+	err error
+}
+
+// cacheWriteRetrier executa as escritas uma vez no ciclo da requisição e
+// repete em background só as que falharam por erro transitório, para que a
+// espera não atrase a resposta.
+type cacheWriteRetrier struct {
+	cacheRepo  repository.CacheRepository
+	cacheKeys  port.CacheKeyGenerator
+	logger     port.Logger
+	background port.BackgroundRunner
+	options    CacheRetryOptions
+}
+
+func (r *cacheWriteRetrier) run(ctx context.Context, product *entity.Product, writes []cacheWrite) {
+	var pending []pendingCacheWrite
+	for _, write := range writes {
+		err := write.run(ctx)
+		if err == nil {
+			continue
+		}
+		if r.options.Retries > 0 && errors.Is(err, repository.ErrCacheTransient) {
+			pending = append(pending, pendingCacheWrite{cacheWrite: write, err: err})
+			continue
+		}
+		write.fail(err)
+	}
+
+	if len(pending) == 0 {
+		return
+	}
+
+	r.logger.Warn("transient cache failure - retrying writes in background",
+		"product_id", product.HashID(),
+		"writes", len(pending),
+		"retries", r.options.Retries,
+	)
+	r.background.Go(func() {
+		r.retry(product, pending)
+	})
+}
+
+// retry repete as escritas pendentes até o orçamento acabar. Se o cache já
+// tem uma versão mais nova do produto, uma escrita posterior já repopulou
+// entrada e índices, e repetir agora regravaria dados antigos.
+func (r *cacheWriteRetrier) retry(product *entity.Product, pending []pendingCacheWrite) {
+	backoff := r.options.Backoff
+
+	for attempt := 1; attempt <= r.options.Retries && len(pending) > 0; attempt++ {
+		time.Sleep(backoff)
+		backoff *= 2
+
+		pending = r.attempt(product, pending)
+	}
+
+	for _, write := range pending {
+		write.fail(write.err)
+	}
+}
+
+func (r *cacheWriteRetrier) attempt(product *entity.Product, pending []pendingCacheWrite) []pendingCacheWrite {
+	ctx, cancel := context.WithTimeout(context.Background(), cacheRetryAttemptTimeout)
+	defer cancel()
+
+	cached, err := r.cacheRepo.Get(ctx, r.cacheKeys.ProductKey(product.ID))
+	if err == nil && cached.Version > product.Version {
+		r.logger.Debug("cache already holds a newer version - dropping retries",
+			"product_id", product.HashID(),
+		)
+		return nil
+	}
+
+	remaining := pending[:0]
+	failed := false
+	for _, write := range pending {
+		err := write.run(ctx)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, repository.ErrCacheTransient) {
+			write.fail(err)
+			failed = true
+			continue
+		}
+		write.err = err
+		remaining = append(remaining, write)
+	}
+
+	if len(remaining) == 0 && !failed {
+		r.logger.Info("cache writes succeeded after retry",
+			"product_id", product.HashID(),
+		)
+	}
+	return remaining
+}
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+var errTransientBlip = fmt.Errorf("%w: i/o timeout", repository.ErrCacheTransient)
+
+func newRetryingCreateUseCase(cacheRepo *MockCacheRepository, tasks *BackgroundTasks) *CreateProductUseCase {
+	return NewCreateProductUseCaseWithOptions(&MockProductRepository{}, cacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, CreateProductOptions{
+		CacheRetry: CacheRetryOptions{Retries: 2, Backoff: time.Millisecond},
+		Background: tasks,
+	})
+}
+
+func createRetryInput() port.CreateProductInput {
+	return port.CreateProductInput{
+		Name:            "iPhone 15",
+		ReferenceNumber: "APL-IP15-001",
+		Category:        "Smartphones",
+	}
+}
+
+func TestCreateProductUseCase_CacheRetry_RecoversFromTransientFailure(t *testing.T) {
+	var mu sync.Mutex
+	setCalls := 0
+	cacheRepo := &MockCacheRepository{
+		SetFunc: func(ctx context.Context, key string, product *entity.Product) error {
+			mu.Lock()
+			defer mu.Unlock()
+			setCalls++
+			if setCalls == 1 {
+				return errTransientBlip
+			}
+			return nil
+		},
+	}
+	tasks := NewBackgroundTasks()
+	uc := newRetryingCreateUseCase(cacheRepo, tasks)
+
+	if _, err := uc.Execute(context.Background(), createRetryInput()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_ = tasks.Wait(context.Background())
+
+	if setCalls != 2 {
+		t.Errorf("Expected product write to be retried once, got %d calls", setCalls)
+	}
+}
+
+func TestCreateProductUseCase_CacheRetry_StopsAfterBudget(t *testing.T) {
+	var mu sync.Mutex
+	setCalls, indexCalls := 0, 0
+	cacheRepo := &MockCacheRepository{
+		SetFunc: func(ctx context.Context, key string, product *entity.Product) error {
+			mu.Lock()
+			defer mu.Unlock()
+			setCalls++
+			return errTransientBlip
+		},
+		AddToSetFunc: func(ctx context.Context, setKey, productID string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			indexCalls++
+			return nil
+		},
+	}
+	tasks := NewBackgroundTasks()
+	uc := newRetryingCreateUseCase(cacheRepo, tasks)
+
+	if _, err := uc.Execute(context.Background(), createRetryInput()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_ = tasks.Wait(context.Background())
+
+	if setCalls != 3 {
+		t.Errorf("Expected 1 attempt plus 2 retries, got %d calls", setCalls)
+	}
+	if indexCalls != 2 {
+		t.Errorf("Expected successful index writes not to be retried, got %d calls", indexCalls)
+	}
+}
+
+func TestCreateProductUseCase_CacheRetry_SkipsPermanentErrors(t *testing.T) {
+	setCalls := 0
+	cacheRepo := &MockCacheRepository{
+		SetFunc: func(ctx context.Context, key string, product *entity.Product) error {
+			setCalls++
+			return errors.New("failed to marshal product")
+		},
+	}
+	tasks := NewBackgroundTasks()
+	uc := newRetryingCreateUseCase(cacheRepo, tasks)
+
+	if _, err := uc.Execute(context.Background(), createRetryInput()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_ = tasks.Wait(context.Background())
+
+	if setCalls != 1 {
+		t.Errorf("Expected permanent error not to be retried, got %d calls", setCalls)
+	}
+}
+
+func TestCreateProductUseCase_CacheRetry_DropsWhenCacheHasNewerVersion(t *testing.T) {
+	var mu sync.Mutex
+	setCalls := 0
+	created := false
+	cacheRepo := &MockCacheRepository{
+		GetFunc: func(ctx context.Context, key string) (*entity.Product, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if !created {
+				return nil, repository.ErrCacheNotFound
+			}
+			return &entity.Product{Version: 5}, nil
+		},
+		SetFunc: func(ctx context.Context, key string, product *entity.Product) error {
+			mu.Lock()
+			defer mu.Unlock()
+			setCalls++
+			created = true
+			return errTransientBlip
+		},
+	}
+	tasks := NewBackgroundTasks()
+	uc := newRetryingCreateUseCase(cacheRepo, tasks)
+
+	if _, err := uc.Execute(context.Background(), createRetryInput()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_ = tasks.Wait(context.Background())
+
+	if setCalls != 1 {
+		t.Errorf("Expected retries to be dropped for a newer cached version, got %d calls", setCalls)
+	}
+}
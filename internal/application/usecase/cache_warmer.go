@@ -0,0 +1,269 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+const defaultCacheWarmBatchSize = 500
+
+// CacheWarmerOptions ajusta o aquecimento completo. BatchSize é o tamanho das
+// páginas lidas do banco e dos lotes de membros gravados em cada índice;
+// BatchDelay é a pausa entre páginas, para não sobrecarregar o Redis.
+// Background executa os aquecimentos disparados por Trigger.
+type CacheWarmerOptions struct {
+	BatchSize  int
+	BatchDelay time.Duration
+	Background port.BackgroundRunner
+}
+
+// CacheWarmerUseCase recarrega o catálogo inteiro do banco no cache depois de
+// um flush ou de um restart do Redis. Os produtos são gravados página a
+// página; os índices all_products, de nome, de categoria e de tag só são
+// gravados depois da varredura completa, para que uma falha no meio não deixe
+// um índice parcial sendo lido como completo. Membros de produtos removidos
+// que já estejam nos índices não são retirados.
+type CacheWarmerUseCase struct {
+	productRepo repository.ProductRepository
+	cacheRepo   repository.CacheRepository
+	cacheKeys   port.CacheKeyGenerator
+	logger      port.Logger
+	options     CacheWarmerOptions
+	now         func() time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	running bool
+}
+
+func NewCacheWarmerUseCase(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+	options CacheWarmerOptions,
+) *CacheWarmerUseCase {
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaultCacheWarmBatchSize
+	}
+	options.Background = backgroundRunnerOrGo(options.Background)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &CacheWarmerUseCase{
+		productRepo: productRepo,
+		cacheRepo:   cacheRepo,
+		cacheKeys:   cacheKeys,
+		logger:      logger,
+		options:     options,
+		now:         time.Now,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// cacheWarmIndexes acumula os membros de cada índice durante a varredura.
+type cacheWarmIndexes struct {
+	all  map[string]float64
+	sets map[string][]string
+}
+
+// WarmCache aquece o cache e espera o fim. Retorna
+// port.ErrCacheWarmInProgress se outro aquecimento estiver rodando. Uma falha
+// ao ler o banco ou ao gravar um índice interrompe o aquecimento com erro;
+// falhas ao gravar produtos só entram no relatório.
+func (uc *CacheWarmerUseCase) WarmCache(ctx context.Context) (*port.CacheWarmReport, error) {
+	if !uc.tryStart() {
+		return nil, port.ErrCacheWarmInProgress
+	}
+	defer uc.finish()
+
+	return uc.warm(ctx)
+}
+
+// Trigger dispara o aquecimento em background e retorna false se outro já
+// estiver rodando ou se o use case tiver sido fechado. O resultado vai só
+// para o log.
+func (uc *CacheWarmerUseCase) Trigger() bool {
+	if uc.ctx.Err() != nil || !uc.tryStart() {
+		return false
+	}
+
+	uc.options.Background.Go(func() {
+		defer uc.finish()
+		if _, err := uc.warm(uc.ctx); err != nil {
+			uc.logger.Error("cache warm failed",
+				"error", err,
+			)
+		}
+	})
+
+	return true
+}
+
+// Close interrompe o aquecimento disparado por Trigger e impede novos. Usado
+// no shutdown, antes de esperar as tarefas em background.
+func (uc *CacheWarmerUseCase) Close() {
+	uc.cancel()
+}
+
+func (uc *CacheWarmerUseCase) tryStart() bool {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if uc.running {
+		return false
+	}
+	uc.running = true
+	return true
+}
+
+func (uc *CacheWarmerUseCase) finish() {
+	uc.mu.Lock()
+	uc.running = false
+	uc.mu.Unlock()
+}
+
+func (uc *CacheWarmerUseCase) warm(ctx context.Context) (*port.CacheWarmReport, error) {
+	start := uc.now()
+	uc.logger.Info("starting cache warm",
+		"batch_size", uc.options.BatchSize,
+	)
+
+	report := &port.CacheWarmReport{}
+	indexes := cacheWarmIndexes{
+		all:  make(map[string]float64),
+		sets: make(map[string][]string),
+	}
+
+	for offset := 0; ; offset += uc.options.BatchSize {
+		products, err := uc.productRepo.FindAll(ctx, repository.ListFilter{}, repository.SortOptions{}, uc.options.BatchSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load products: %w", err)
+		}
+
+		for _, product := range products {
+			// No aquecimento, OOM conta como falha: o relatório deve mostrar o
+			// que não entrou no cache.
+			err := uc.cacheRepo.Set(ctx, uc.cacheKeys.ProductKey(product.ID), product)
+			if err != nil && (errors.Is(err, repository.ErrCacheOutOfMemory) || !skippedCacheWrite(uc.logger, err, product)) {
+				uc.logger.Warn("failed to cache product during warm",
+					"error", err,
+					"product_id", product.HashID(),
+				)
+				report.Failed++
+				continue
+			}
+			report.Products++
+			uc.collect(&indexes, product)
+		}
+
+		if len(products) < uc.options.BatchSize {
+			break
+		}
+
+		if err := uc.pause(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.writeIndexes(ctx, indexes); err != nil {
+		return nil, err
+	}
+	report.Indexes = len(indexes.sets)
+	if len(indexes.all) > 0 {
+		report.Indexes++
+	}
+	report.Duration = uc.now().Sub(start)
+
+	uc.logger.Info("cache warm finished",
+		"products", report.Products,
+		"failed", report.Failed,
+		"indexes", report.Indexes,
+		"duration", report.Duration,
+	)
+
+	return report, nil
+}
+
+func (uc *CacheWarmerUseCase) collect(indexes *cacheWarmIndexes, product *entity.Product) {
+	indexes.all[product.ID] = float64(product.CreatedAt.UnixMilli())
+
+	keys := []string{
+		uc.cacheKeys.NameKey(product.Name),
+		uc.cacheKeys.CategoryKey(product.Category),
+	}
+	if product.SKU != "" {
+		keys = append(keys, uc.cacheKeys.SKUKey(product.SKU))
+	}
+	for _, tag := range product.Tags {
+		keys = append(keys, uc.cacheKeys.TagKey(tag))
+	}
+	for _, key := range keys {
+		indexes.sets[key] = append(indexes.sets[key], product.ID)
+	}
+}
+
+// writeIndexes grava os índices em lotes de BatchSize membros, com a mesma
+// pausa entre lotes usada entre as páginas do banco.
+func (uc *CacheWarmerUseCase) writeIndexes(ctx context.Context, indexes cacheWarmIndexes) error {
+	pending := 0
+	throttle := func(members int) error {
+		pending += members
+		if pending < uc.options.BatchSize {
+			return nil
+		}
+		pending = 0
+		return uc.pause(ctx)
+	}
+
+	for id, score := range indexes.all {
+		if err := uc.cacheRepo.AddToSortedSet(ctx, uc.cacheKeys.AllProductsKey(), id, score); err != nil {
+			return fmt.Errorf("failed to rebuild all_products index: %w", err)
+		}
+		if err := throttle(1); err != nil {
+			return err
+		}
+	}
+
+	for key, ids := range indexes.sets {
+		for len(ids) > 0 {
+			chunk := ids[:min(len(ids), uc.options.BatchSize)]
+			ids = ids[len(chunk):]
+
+			if err := uc.cacheRepo.AddAllToSet(ctx, key, chunk); err != nil {
+				return fmt.Errorf("failed to rebuild index %s: %w", key, err)
+			}
+			if err := throttle(len(chunk)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (uc *CacheWarmerUseCase) pause(ctx context.Context) error {
+	if uc.options.BatchDelay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(uc.options.BatchDelay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+func catalogFinder(catalog []*entity.Product, pages *[][2]int) func(context.Context, repository.ListFilter, repository.SortOptions, int, int) ([]*entity.Product, error) {
+	return func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+		if pages != nil {
+			*pages = append(*pages, [2]int{limit, offset})
+		}
+		end := min(offset+limit, len(catalog))
+		return catalog[min(offset, end):end], nil
+	}
+}
+
+func TestCacheWarmerUseCase_WarmCache(t *testing.T) {
+	catalog := make([]*entity.Product, 5)
+	for i := range catalog {
+		catalog[i] = &entity.Product{ID: string(rune('A' + i)), Name: "Phone", Category: "Smartphones", CreatedAt: time.Now()}
+	}
+	catalog[0].Tags = []string{"promo"}
+
+	var pages [][2]int
+	mockProductRepo := &MockProductRepository{FindAllFunc: catalogFinder(catalog, &pages)}
+
+	cached := map[string]bool{}
+	sorted := map[string]int{}
+	sets := map[string][]string{}
+	mockCacheRepo := &MockCacheRepository{
+		SetFunc: func(ctx context.Context, key string, product *entity.Product) error {
+			cached[key] = true
+			return nil
+		},
+		AddToSortedSetFunc: func(ctx context.Context, setKey, productID string, score float64) error {
+			sorted[setKey]++
+			return nil
+		},
+		AddAllToSetFunc: func(ctx context.Context, setKey string, productIDs []string) error {
+			if len(productIDs) > 2 {
+				t.Errorf("Expected chunks of at most 2 members, got %d", len(productIDs))
+			}
+			sets[setKey] = append(sets[setKey], productIDs...)
+			return nil
+		},
+		AddToSetFunc: func(ctx context.Context, setKey, productID string) error {
+			t.Error("Expected indexes to be rebuilt with AddAllToSet")
+			return nil
+		},
+	}
+
+	uc := NewCacheWarmerUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, CacheWarmerOptions{BatchSize: 2})
+
+	report, err := uc.WarmCache(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(pages) != 3 || pages[2] != [2]int{2, 4} {
+		t.Errorf("Expected 3 pages of 2, got %v", pages)
+	}
+	if len(cached) != 5 {
+		t.Errorf("Expected 5 cached products, got %d", len(cached))
+	}
+	if sorted["all_products"] != 5 {
+		t.Errorf("Expected 5 members in all_products, got %d", sorted["all_products"])
+	}
+	if len(sets["product_by_name_Phone"]) != 5 || len(sets["product_by_category_Smartphones"]) != 5 {
+		t.Errorf("Expected every product in the name and category indexes, got %v", sets)
+	}
+	if got := sets["product_by_tag_promo"]; len(got) != 1 || got[0] != "A" {
+		t.Errorf("Expected tag index with A, got %v", got)
+	}
+	if report.Products != 5 || report.Failed != 0 || report.Indexes != 4 {
+		t.Errorf("Expected 5 products in 4 indexes, got %+v", report)
+	}
+}
+
+func TestCacheWarmerUseCase_WarmCache_SkipsProductsNotCached(t *testing.T) {
+	catalog := []*entity.Product{
+		{ID: "A", Name: "Phone", Category: "Smartphones"},
+		{ID: "B", Name: "Phone", Category: "Smartphones"},
+	}
+
+	sets := map[string][]string{}
+	mockCacheRepo := &MockCacheRepository{
+		SetFunc: func(ctx context.Context, key string, product *entity.Product) error {
+			if product.ID == "B" {
+				return errors.New("redis: connection refused")
+			}
+			return nil
+		},
+		AddAllToSetFunc: func(ctx context.Context, setKey string, productIDs []string) error {
+			sets[setKey] = append(sets[setKey], productIDs...)
+			return nil
+		},
+	}
+
+	uc := NewCacheWarmerUseCase(&MockProductRepository{FindAllFunc: catalogFinder(catalog, nil)}, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, CacheWarmerOptions{})
+
+	report, err := uc.WarmCache(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if report.Products != 1 || report.Failed != 1 {
+		t.Errorf("Expected 1 product cached and 1 failed, got %+v", report)
+	}
+	if got := sets["product_by_name_Phone"]; len(got) != 1 || got[0] != "A" {
+		t.Errorf("Expected only cached products in the index, got %v", got)
+	}
+}
+
+func TestCacheWarmerUseCase_WarmCache_DatabaseErrorLeavesIndexesUntouched(t *testing.T) {
+	mockProductRepo := &MockProductRepository{
+		FindAllFunc: func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			if offset > 0 {
+				return nil, repository.ErrDatabaseConnection
+			}
+			return []*entity.Product{{ID: "A"}, {ID: "B"}}, nil
+		},
+	}
+	mockCacheRepo := &MockCacheRepository{
+		AddToSortedSetFunc: func(ctx context.Context, setKey, productID string, score float64) error {
+			t.Error("Expected no index writes after a partial scan")
+			return nil
+		},
+		AddAllToSetFunc: func(ctx context.Context, setKey string, productIDs []string) error {
+			t.Error("Expected no index writes after a partial scan")
+			return nil
+		},
+	}
+
+	uc := NewCacheWarmerUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, CacheWarmerOptions{BatchSize: 2})
+
+	if _, err := uc.WarmCache(context.Background()); !errors.Is(err, repository.ErrDatabaseConnection) {
+		t.Errorf("Expected ErrDatabaseConnection, got %v", err)
+	}
+}
+
+func TestCacheWarmerUseCase_Trigger(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	mockProductRepo := &MockProductRepository{
+		FindAllFunc: func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			close(started)
+			<-release
+			return []*entity.Product{}, nil
+		},
+	}
+
+	tasks := NewBackgroundTasks()
+	uc := NewCacheWarmerUseCase(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{}, CacheWarmerOptions{
+		Background: tasks,
+	})
+
+	if !uc.Trigger() {
+		t.Fatal("Expected first trigger to start the warm")
+	}
+	<-started
+
+	if uc.Trigger() {
+		t.Error("Expected trigger during a running warm to be refused")
+	}
+	if _, err := uc.WarmCache(context.Background()); !errors.Is(err, port.ErrCacheWarmInProgress) {
+		t.Errorf("Expected ErrCacheWarmInProgress, got %v", err)
+	}
+
+	close(release)
+	if err := tasks.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected warm to finish, got %v", err)
+	}
+
+	uc.Close()
+	if uc.Trigger() {
+		t.Error("Expected trigger after Close to be refused")
+	}
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// CountProductsOptions ajusta a contagem de produtos.
+//
+// Cache guarda o total sem filtro por um tempo curto; nil conta sempre no
+// banco.
+type CountProductsOptions struct {
+	Cache port.ProductCountCache
+}
+
+// CountProductsUseCase conta os produtos das listagens direto no banco. Os
+// índices do cache podem estar incompletos, então não servem para o total; só
+// o total geral, já contado no banco, é guardado no cache de contagem.
+type CountProductsUseCase struct {
+	productRepo repository.ProductRepository
+	logger      port.Logger
+	options     CountProductsOptions
+}
+
+func NewCountProductsUseCase(productRepo repository.ProductRepository, logger port.Logger) *CountProductsUseCase {
+	return NewCountProductsUseCaseWithOptions(productRepo, logger, CountProductsOptions{})
+}
+
+func NewCountProductsUseCaseWithOptions(productRepo repository.ProductRepository, logger port.Logger, options CountProductsOptions) *CountProductsUseCase {
+	options.Cache = countCacheOrNoop(options.Cache)
+
+	return &CountProductsUseCase{
+		productRepo: productRepo,
+		logger:      logger,
+		options:     options,
+	}
+}
+
+func (uc *CountProductsUseCase) Count(ctx context.Context, filter repository.ListFilter) (int, error) {
+	if filter.IsZero() {
+		return uc.countAll(ctx)
+	}
+
+	total, err := uc.productRepo.Count(ctx, filter)
+	if err != nil {
+		uc.logger.Warn("failed to count products", "error", err)
+		return 0, err
+	}
+	return total, nil
+}
+
+// countAll serve o total geral do cache de contagem. Falhas do cache só
+// fazem a contagem ir ao banco.
+func (uc *CountProductsUseCase) countAll(ctx context.Context) (int, error) {
+	total, found, err := uc.options.Cache.Get(ctx)
+	if err != nil {
+		uc.logger.Warn("failed to read product count from cache", "error", err)
+	}
+	if found {
+		return total, nil
+	}
+
+	total, err = uc.productRepo.Count(ctx, repository.ListFilter{})
+	if err != nil {
+		uc.logger.Warn("failed to count products", "error", err)
+		return 0, err
+	}
+
+	if err := uc.options.Cache.Set(ctx, total); err != nil {
+		uc.logger.Warn("failed to cache product count", "error", err)
+	}
+	return total, nil
+}
+
+func (uc *CountProductsUseCase) CountByName(ctx context.Context, name string, filter repository.ListFilter) (int, error) {
+	total, err := uc.productRepo.CountByName(ctx, name, filter)
+	if err != nil {
+		uc.logger.Warn("failed to count products by name",
+			"error", err,
+			"name", name,
+		)
+		return 0, err
+	}
+	return total, nil
+}
+
+func (uc *CountProductsUseCase) CountByCategory(ctx context.Context, category string, filter repository.ListFilter) (int, error) {
+	total, err := uc.productRepo.CountByCategory(ctx, category, filter)
+	if err != nil {
+		uc.logger.Warn("failed to count products by category",
+			"error", err,
+			"category", category,
+		)
+		return 0, err
+	}
+	return total, nil
+}
+
+// countCacheOrNoop evita checagens de nil nos casos de uso.
+func countCacheOrNoop(cache port.ProductCountCache) port.ProductCountCache {
+	if cache == nil {
+		return port.NoopProductCountCache{}
+	}
+	return cache
+}
+
+// invalidateCount descarta o total em cache depois de uma escrita que muda a
+// quantidade de produtos ativos.
+func invalidateCount(ctx context.Context, cache port.ProductCountCache, logger port.Logger) {
+	if err := cache.Invalidate(ctx); err != nil {
+		logger.Warn("failed to invalidate product count", "error", err)
+	}
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// memoryCountCache implementa port.ProductCountCache em memória.
+type memoryCountCache struct {
+	total       int
+	found       bool
+	invalidated int
+}
+
+func (c *memoryCountCache) Get(ctx context.Context) (int, bool, error) {
+	return c.total, c.found, nil
+}
+
+func (c *memoryCountCache) Set(ctx context.Context, total int) error {
+	c.total, c.found = total, true
+	return nil
+}
+
+func (c *memoryCountCache) Invalidate(ctx context.Context) error {
+	c.total, c.found = 0, false
+	c.invalidated++
+	return nil
+}
+
+func TestCountProductsUseCase_Count_CachesTotal(t *testing.T) {
+	counts := 0
+	total := 10
+	mockProductRepo := &MockProductRepository{
+		CountFunc: func(ctx context.Context, filter repository.ListFilter) (int, error) {
+			counts++
+			return total, nil
+		},
+	}
+	countCache := &memoryCountCache{}
+	uc := NewCountProductsUseCaseWithOptions(mockProductRepo, &MockLogger{}, CountProductsOptions{Cache: countCache})
+
+	for i := 0; i < 2; i++ {
+		got, err := uc.Count(context.Background(), repository.ListFilter{})
+		if err != nil || got != 10 {
+			t.Fatalf("Expected total 10, got %d, %v", got, err)
+		}
+	}
+	if counts != 1 {
+		t.Errorf("Expected the second count to come from the cache, got %d database counts", counts)
+	}
+
+	total = 11
+	create := NewCreateProductUseCaseWithOptions(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{}, CreateProductOptions{Counts: countCache})
+	if _, err := create.Execute(context.Background(), port.CreateProductInput{Name: "Product", ReferenceNumber: "REF-001", Category: "Category"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got, _ := uc.Count(context.Background(), repository.ListFilter{}); got != 11 {
+		t.Errorf("Expected the creation to invalidate the cached total, got %d", got)
+	}
+
+	total = 10
+	remove := NewDeleteProductUseCaseWithOptions(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{}, DeleteProductOptions{Counts: countCache})
+	if err := remove.Execute(context.Background(), "product-id"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got, _ := uc.Count(context.Background(), repository.ListFilter{}); got != 10 {
+		t.Errorf("Expected the deletion to invalidate the cached total, got %d", got)
+	}
+
+	if countCache.invalidated != 2 || counts != 3 {
+		t.Errorf("Expected 2 invalidations and 3 database counts, got %d and %d", countCache.invalidated, counts)
+	}
+}
+
+func TestCountProductsUseCase_Count_FilteredSkipsCache(t *testing.T) {
+	mockProductRepo := &MockProductRepository{
+		CountFunc: func(ctx context.Context, filter repository.ListFilter) (int, error) {
+			return 3, nil
+		},
+	}
+	countCache := &memoryCountCache{total: 10, found: true}
+	uc := NewCountProductsUseCaseWithOptions(mockProductRepo, &MockLogger{}, CountProductsOptions{Cache: countCache})
+
+	inStock := true
+	got, err := uc.Count(context.Background(), repository.ListFilter{InStock: &inStock})
+	if err != nil || got != 3 {
+		t.Errorf("Expected the filtered count from the database, got %d, %v", got, err)
+	}
+	if countCache.total != 10 {
+		t.Errorf("Expected the cached total to stay untouched, got %d", countCache.total)
+	}
+}
+
+func TestDeleteProductUseCase_Execute_KeepsCountOnFailure(t *testing.T) {
+	mockProductRepo := &MockProductRepository{
+		DeleteFunc: func(ctx context.Context, id string) error {
+			return repository.ErrProductNotFound
+		},
+	}
+	countCache := &memoryCountCache{total: 10, found: true}
+	uc := NewDeleteProductUseCaseWithOptions(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{}, DeleteProductOptions{Counts: countCache})
+
+	if err := uc.Execute(context.Background(), "product-id"); !errors.Is(err, repository.ErrProductNotFound) {
+		t.Fatalf("Expected ErrProductNotFound, got %v", err)
+	}
+	if countCache.invalidated != 0 {
+		t.Errorf("Expected a failed deletion to keep the cached total, got %d invalidations", countCache.invalidated)
+	}
+}
@@ -4,17 +4,46 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/dowglassantana/product-redis-api/internal/application/port"
 	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
 	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
 )
 
+// CreateProductOptions ajusta a criação de produto.
+//
+// AllowedCategories restringe as categorias aceitas; vazio aceita qualquer uma.
+// IDFields define os campos que derivam o ID; vazio usa nome + referência.
+// ReferenceNormalization é aplicada ao reference_number antes de derivar o ID;
+// o valor zero mantém a referência como veio.
+// UniqueNamePerCategory recusa um produto com o mesmo nome (sem diferenciar
+// maiúsculas) de outro produto da mesma categoria.
+// MaxSpecDepth limita o aninhamento das especificações; zero desativa.
+// Events recebe um product.created por produto criado; nil descarta.
+// CacheRetry repete em background (Background; nil usa uma goroutine sem
+// acompanhamento) as escritas de cache que falharem por erro transitório.
+type CreateProductOptions struct {
+	AllowedCategories      entity.CategorySet
+	IDFields               entity.IDFields
+	ReferenceNormalization entity.ReferenceNormalization
+	PriceRounding          entity.PriceRounding
+	UniqueNamePerCategory  bool
+	MaxSpecDepth           int
+	Events                 port.ChangePublisher
+	CacheRetry             CacheRetryOptions
+	Background             port.BackgroundRunner
+	Counts                 port.ProductCountCache
+	Audit                  port.AuditLogger
+}
+
 type CreateProductUseCase struct {
 	productRepo repository.ProductRepository
 	cacheRepo   repository.CacheRepository
 	cacheKeys   port.CacheKeyGenerator
 	logger      port.Logger
+	options     CreateProductOptions
+	cacheWrites *cacheWriteRetrier
 }
 
 func NewCreateProductUseCase(
@@ -23,33 +52,41 @@ func NewCreateProductUseCase(
 	cacheKeys port.CacheKeyGenerator,
 	logger port.Logger,
 ) *CreateProductUseCase {
+	return NewCreateProductUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, logger, CreateProductOptions{})
+}
+
+func NewCreateProductUseCaseWithOptions(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+	options CreateProductOptions,
+) *CreateProductUseCase {
+	options.Events = changePublisherOrNoop(options.Events)
+	options.Background = backgroundRunnerOrGo(options.Background)
+	options.Counts = countCacheOrNoop(options.Counts)
+	options.Audit = auditLoggerOrNoop(options.Audit)
+
 	return &CreateProductUseCase{
 		productRepo: productRepo,
 		cacheRepo:   cacheRepo,
 		cacheKeys:   cacheKeys,
 		logger:      logger,
+		options:     options,
+		cacheWrites: &cacheWriteRetrier{
+			cacheRepo:  cacheRepo,
+			cacheKeys:  cacheKeys,
+			logger:     logger,
+			background: options.Background,
+			options:    options.CacheRetry,
+		},
 	}
 }
 
 func (uc *CreateProductUseCase) Execute(ctx context.Context, input port.CreateProductInput) (*entity.Product, error) {
-	product, err := entity.NewProduct(
-		input.Name,
-		input.ReferenceNumber,
-		input.Category,
-		input.Description,
-		input.SKU,
-		input.Brand,
-		input.Stock,
-		input.Images,
-		input.Specifications,
-	)
+	product, err := uc.newProduct(input)
 	if err != nil {
-		uc.logger.Error("failed to create product entity",
-			"error", err,
-			"name", input.Name,
-			"reference", input.ReferenceNumber,
-		)
-		return nil, fmt.Errorf("invalid product data: %w", err)
+		return nil, err
 	}
 
 	uc.logger.Info("attempting to create product",
@@ -82,6 +119,12 @@ func (uc *CreateProductUseCase) Execute(ctx context.Context, input port.CreatePr
 		)
 	}
 
+	if uc.options.UniqueNamePerCategory {
+		if err := uc.checkNameInCategory(ctx, product); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := uc.productRepo.Create(ctx, product); err != nil {
 		if errors.Is(err, repository.ErrProductAlreadyExists) {
 			uc.logger.Info("product already exists in database",
@@ -101,45 +144,231 @@ func (uc *CreateProductUseCase) Execute(ctx context.Context, input port.CreatePr
 		"product_id", product.HashID(),
 	)
 
+	uc.auditCreated(ctx, product)
 	uc.updateCache(ctx, product)
+	uc.publishCreated(product)
 
 	return product, nil
 }
 
-func (uc *CreateProductUseCase) updateCache(ctx context.Context, product *entity.Product) {
-	if err := uc.cacheRepo.Set(ctx, uc.cacheKeys.ProductKey(product.ID), product); err != nil {
-		uc.logger.Error("failed to cache product",
-			"error", err,
-			"product_id", product.HashID(),
-		)
+// newProduct monta e valida o produto da entrada, já com o ID derivado. As
+// falhas de campo voltam juntas num entity.ValidationErrors, para o cliente
+// corrigir tudo de uma vez.
+func (uc *CreateProductUseCase) newProduct(input port.CreateProductInput) (*entity.Product, error) {
+	invalid := entity.ValidationErrors{}
+	price, priceErr := entity.ResolvePrice(input.Price, input.PriceAmount, input.Currency, uc.options.PriceRounding)
+	invalid.Add("price", priceErr)
+	product, err := entity.NewProduct(
+		input.Name,
+		input.ReferenceNumber,
+		input.Category,
+		input.Description,
+		input.SKU,
+		input.Brand,
+		input.Stock,
+		price,
+		input.Currency,
+		input.Images,
+		input.Specifications,
+	)
+	if err != nil && !errors.As(err, &invalid) {
+		return nil, fmt.Errorf("invalid product data: %w", err)
+	}
+	if err == nil {
+		invalid.Add("reference_number", product.NormalizeReference(uc.options.ReferenceNormalization))
+	} else {
+		// As demais verificações rodam num produto vazio, só para reunir as
+		// falhas dos outros campos.
+		product = &entity.Product{}
 	}
 
-	if err := uc.cacheRepo.AddToSet(ctx, uc.cacheKeys.AllProductsKey(), product.ID); err != nil {
-		uc.logger.Error("failed to add to all_products set",
-			"error", err,
-			"product_id", product.HashID(),
+	invalid.Add("thumbnail_url", product.SetThumbnailURL(input.ThumbnailURL))
+	invalid.Add("tags", product.SetTags(input.Tags))
+	invalid.Add("weight_grams", product.SetWeight(input.WeightGrams))
+	invalid.Add("dimensions", product.SetDimensions(input.Dimensions))
+	invalid.Add("specifications", entity.CheckSpecificationsDepth(input.Specifications, uc.options.MaxSpecDepth))
+	invalid.Add("category", uc.options.AllowedCategories.Check(input.Category))
+
+	if len(invalid) > 0 {
+		uc.logger.Warn("invalid product data",
+			"fields", invalid.Messages(),
+			"reference", input.ReferenceNumber,
 		)
+		return nil, fmt.Errorf("invalid product data: %w", invalid)
 	}
 
-	nameKey := uc.cacheKeys.NameKey(product.Name)
-	if err := uc.cacheRepo.AddToSet(ctx, nameKey, product.ID); err != nil {
-		uc.logger.Error("failed to add to name index",
+	if err := product.AssignID(uc.options.IDFields); err != nil {
+		uc.logger.Warn("product is missing an identity field",
 			"error", err,
-			"product_id", product.HashID(),
-			"name", product.Name,
+			"id_fields", uc.options.IDFields.String(),
+			"reference", product.ReferenceNumber,
 		)
+		return nil, fmt.Errorf("invalid product data: %w", err)
 	}
 
+	return product, nil
+}
+
+// auditCreated registra na trilha de compliance os campos do produto criado.
+func (uc *CreateProductUseCase) auditCreated(ctx context.Context, product *entity.Product) {
+	uc.options.Audit.Record(ctx, entity.AuditCreate, product.ID, entity.DiffProducts(nil, product))
+}
+
+// publishCreated avisa os assinantes de que o produto foi criado.
+func (uc *CreateProductUseCase) publishCreated(product *entity.Product) {
+	uc.options.Events.Publish(port.ChangeEvent{
+		Type:       port.ProductCreated,
+		ProductID:  product.ID,
+		Version:    product.Version,
+		OccurredAt: product.CreatedAt,
+	})
+}
+
+func (uc *CreateProductUseCase) updateCache(ctx context.Context, product *entity.Product) {
+	invalidateCount(ctx, uc.options.Counts, uc.logger)
+
+	productKey := uc.cacheKeys.ProductKey(product.ID)
+	score := float64(product.CreatedAt.UnixMilli())
+	nameKey := uc.cacheKeys.NameKey(product.Name)
 	categoryKey := uc.cacheKeys.CategoryKey(product.Category)
-	if err := uc.cacheRepo.AddToSet(ctx, categoryKey, product.ID); err != nil {
-		uc.logger.Error("failed to add to category index",
-			"error", err,
-			"product_id", product.HashID(),
-			"category", product.Category,
-		)
+
+	writes := []cacheWrite{
+		{
+			run: func(ctx context.Context) error {
+				return uc.cacheRepo.Set(ctx, productKey, product)
+			},
+			fail: func(err error) {
+				if skippedCacheWrite(uc.logger, err, product) {
+					return
+				}
+				uc.logger.Error("failed to cache product",
+					"error", err,
+					"product_id", product.HashID(),
+				)
+			},
+		},
+		{
+			run: func(ctx context.Context) error {
+				return uc.cacheRepo.AddToSortedSet(ctx, uc.cacheKeys.AllProductsKey(), product.ID, score)
+			},
+			fail: func(err error) {
+				uc.logger.Error("failed to add to all_products set",
+					"error", err,
+					"product_id", product.HashID(),
+				)
+			},
+		},
+		{
+			run: func(ctx context.Context) error {
+				return uc.cacheRepo.AddToSet(ctx, nameKey, product.ID)
+			},
+			fail: func(err error) {
+				uc.logger.Error("failed to add to name index",
+					"error", err,
+					"product_id", product.HashID(),
+					"name", product.Name,
+				)
+			},
+		},
+		{
+			run: func(ctx context.Context) error {
+				return uc.cacheRepo.AddToSet(ctx, categoryKey, product.ID)
+			},
+			fail: func(err error) {
+				uc.logger.Error("failed to add to category index",
+					"error", err,
+					"product_id", product.HashID(),
+					"category", product.Category,
+				)
+			},
+		},
+	}
+
+	if product.SKU != "" {
+		skuKey := uc.cacheKeys.SKUKey(product.SKU)
+		writes = append(writes, cacheWrite{
+			run: func(ctx context.Context) error {
+				return uc.cacheRepo.AddToSet(ctx, skuKey, product.ID)
+			},
+			fail: func(err error) {
+				uc.logger.Error("failed to add to sku index",
+					"error", err,
+					"product_id", product.HashID(),
+					"sku", product.SKU,
+				)
+			},
+		})
 	}
 
+	for _, tag := range product.Tags {
+		tagKey := uc.cacheKeys.TagKey(tag)
+		writes = append(writes, cacheWrite{
+			run: func(ctx context.Context) error {
+				return uc.cacheRepo.AddToSet(ctx, tagKey, product.ID)
+			},
+			fail: func(err error) {
+				uc.logger.Error("failed to add to tag index",
+					"error", err,
+					"product_id", product.HashID(),
+					"tag", tag,
+				)
+			},
+		})
+	}
+
+	uc.cacheWrites.run(ctx, product, writes)
+
 	uc.logger.Info("cache and indices updated successfully",
 		"product_id", product.HashID(),
 	)
 }
+
+// changePublisherOrNoop evita checagens de nil nos casos de uso.
+func changePublisherOrNoop(publisher port.ChangePublisher) port.ChangePublisher {
+	if publisher == nil {
+		return port.NoopChangePublisher{}
+	}
+	return publisher
+}
+
+// nameCheckPageSize é o tamanho das páginas lidas na checagem de nome.
+const nameCheckPageSize = 100
+
+// checkNameInCategory procura outro produto com o mesmo nome na categoria. A
+// busca por relevância traz primeiro os nomes iguais ao termo, então basta
+// paginar enquanto a página terminar num nome igual. O próprio ID é ignorado
+// para que uma repetição idempotente não seja barrada. Há uma janela de
+// corrida entre a checagem e o INSERT; o índice único descrito no README a
+// fecha no banco.
+func (uc *CreateProductUseCase) checkNameInCategory(ctx context.Context, product *entity.Product) error {
+	category := entity.NormalizeCategory(product.Category)
+
+	for offset := 0; ; offset += nameCheckPageSize {
+		matches, err := uc.productRepo.FindByName(ctx, product.Name, repository.ListFilter{}, repository.NameOrderRelevance, repository.SortOptions{}, nameCheckPageSize, offset)
+		if err != nil {
+			uc.logger.Error("failed to check product name in category",
+				"error", err,
+				"product_id", product.HashID(),
+			)
+			return fmt.Errorf("failed to check product name: %w", err)
+		}
+
+		for _, match := range matches {
+			if !strings.EqualFold(match.Name, product.Name) {
+				return nil
+			}
+			if match.ID != product.ID && entity.NormalizeCategory(match.Category) == category {
+				uc.logger.Warn("product name already used in category",
+					"product_id", product.HashID(),
+					"existing_id", match.HashID(),
+					"category", product.Category,
+				)
+				return entity.ErrDuplicateNameInCategory
+			}
+		}
+
+		if len(matches) < nameCheckPageSize {
+			return nil
+		}
+	}
+}
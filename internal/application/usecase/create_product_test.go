@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/dowglassantana/product-redis-api/internal/application/port"
@@ -128,6 +129,8 @@ func TestCreateProductUseCase_Execute_ProductAlreadyExistsInCache(t *testing.T)
 		"APPLE-IP15",
 		"Apple",
 		50,
+		0,
+		"",
 		[]string{},
 		map[string]interface{}{},
 	)
@@ -175,6 +178,8 @@ func TestCreateProductUseCase_Execute_ProductExistsWithDifferentData(t *testing.
 		"ORIGINAL-SKU",
 		"Apple",
 		50,
+		0,
+		"",
 		[]string{},
 		map[string]interface{}{},
 	)
@@ -306,6 +311,9 @@ func TestCreateProductUseCase_Execute_CacheUpdateFailure(t *testing.T) {
 		AddToSetFunc: func(ctx context.Context, setKey, productID string) error {
 			return errors.New("cache add to set failed")
 		},
+		AddToSortedSetFunc: func(ctx context.Context, setKey, productID string, score float64) error {
+			return errors.New("cache add to sorted set failed")
+		},
 	}
 
 	mockCacheKeys := &MockCacheKeyGenerator{}
@@ -329,3 +337,365 @@ func TestCreateProductUseCase_Execute_CacheUpdateFailure(t *testing.T) {
 		t.Error("Expected product even with cache failures")
 	}
 }
+
+func TestCreateProductUseCase_Execute_CategoryNotAllowed(t *testing.T) {
+	createCalled := false
+
+	mockProductRepo := &MockProductRepository{
+		CreateFunc: func(ctx context.Context, product *entity.Product) error {
+			createCalled = true
+			return nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetFunc: func(ctx context.Context, key string) (*entity.Product, error) {
+			return nil, repository.ErrCacheNotFound
+		},
+	}
+
+	uc := NewCreateProductUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, CreateProductOptions{
+		AllowedCategories: entity.NewCategorySet([]string{"Smartphones", "Laptops"}),
+	})
+
+	_, err := uc.Execute(context.Background(), port.CreateProductInput{
+		Name:            "Teddy Bear",
+		ReferenceNumber: "TOY-001",
+		Category:        "Toys",
+		Stock:           5,
+	})
+
+	if !errors.Is(err, entity.ErrCategoryNotAllowed) {
+		t.Errorf("Expected ErrCategoryNotAllowed, got %v", err)
+	}
+
+	if createCalled {
+		t.Error("Expected product not to be saved")
+	}
+
+	product, err := uc.Execute(context.Background(), port.CreateProductInput{
+		Name:            "iPhone 15",
+		ReferenceNumber: "APL-IP15-001",
+		Category:        "smartphones",
+		Stock:           5,
+	})
+
+	if err != nil {
+		t.Errorf("Expected allowed category to match case-insensitively, got %v", err)
+	}
+
+	if product == nil || product.Category != "smartphones" {
+		t.Error("Expected product to keep the category as sent")
+	}
+}
+
+func TestCreateProductUseCase_Execute_InvalidThumbnailURL(t *testing.T) {
+	mockProductRepo := &MockProductRepository{
+		CreateFunc: func(ctx context.Context, product *entity.Product) error {
+			t.Error("Expected product not to be saved")
+			return nil
+		},
+	}
+
+	uc := NewCreateProductUseCase(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	_, err := uc.Execute(context.Background(), port.CreateProductInput{
+		Name:            "iPhone 15",
+		ReferenceNumber: "APL-IP15-001",
+		Category:        "Smartphones",
+		ThumbnailURL:    "thumb.jpg",
+	})
+
+	if !errors.Is(err, entity.ErrInvalidThumbnailURL) {
+		t.Errorf("Expected ErrInvalidThumbnailURL, got %v", err)
+	}
+}
+
+func TestCreateProductUseCase_Execute_CollectsAllFieldErrors(t *testing.T) {
+	mockProductRepo := &MockProductRepository{
+		CreateFunc: func(ctx context.Context, product *entity.Product) error {
+			t.Error("Expected product not to be saved")
+			return nil
+		},
+	}
+
+	uc := NewCreateProductUseCaseWithOptions(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{}, CreateProductOptions{
+		AllowedCategories: entity.NewCategorySet([]string{"Smartphones"}),
+	})
+
+	_, err := uc.Execute(context.Background(), port.CreateProductInput{
+		ReferenceNumber: "APL-IP15-001",
+		Category:        "Toys",
+		Stock:           -1,
+		ThumbnailURL:    "thumb.jpg",
+	})
+
+	var invalid entity.ValidationErrors
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+
+	want := map[string]string{
+		"name":          "is required",
+		"stock":         "cannot be negative",
+		"thumbnail_url": "must be an absolute http(s) URL",
+		"category":      "is not in the allowed list",
+	}
+	if got := invalid.Messages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected field errors %v, got %v", want, got)
+	}
+}
+
+func TestCreateProductUseCase_Execute_InvalidShipping(t *testing.T) {
+	mockProductRepo := &MockProductRepository{
+		CreateFunc: func(ctx context.Context, product *entity.Product) error {
+			t.Error("Expected product not to be saved")
+			return nil
+		},
+	}
+
+	uc := NewCreateProductUseCase(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	_, err := uc.Execute(context.Background(), port.CreateProductInput{
+		Name:            "iPhone 15",
+		ReferenceNumber: "APL-IP15-001",
+		Category:        "Smartphones",
+		WeightGrams:     -1,
+		Dimensions:      entity.Dimensions{LengthMM: 147, WidthMM: -1},
+	})
+
+	var invalid entity.ValidationErrors
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+
+	want := map[string]string{
+		"weight_grams": "cannot be negative",
+		"dimensions":   "cannot be negative",
+	}
+	if got := invalid.Messages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected field errors %v, got %v", want, got)
+	}
+}
+
+func TestCreateProductUseCase_Execute_SpecificationsTooDeep(t *testing.T) {
+	mockProductRepo := &MockProductRepository{
+		CreateFunc: func(ctx context.Context, product *entity.Product) error {
+			t.Error("Expected product not to be saved")
+			return nil
+		},
+	}
+
+	uc := NewCreateProductUseCaseWithOptions(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{}, CreateProductOptions{
+		MaxSpecDepth: 2,
+	})
+
+	_, err := uc.Execute(context.Background(), port.CreateProductInput{
+		Name:            "iPhone 15",
+		ReferenceNumber: "APL-IP15-001",
+		Category:        "Smartphones",
+		Specifications: map[string]interface{}{
+			"camera": map[string]interface{}{
+				"lenses": []interface{}{"wide", "ultra-wide"},
+			},
+		},
+	})
+
+	if !errors.Is(err, entity.ErrSpecificationsTooDeep) {
+		t.Errorf("Expected ErrSpecificationsTooDeep, got %v", err)
+	}
+}
+
+func TestCreateProductUseCase_Execute_PriceAmount(t *testing.T) {
+	var saved *entity.Product
+	mockProductRepo := &MockProductRepository{
+		CreateFunc: func(ctx context.Context, product *entity.Product) error {
+			saved = product
+			return nil
+		},
+	}
+	input := port.CreateProductInput{
+		Name:            "Nintendo Switch",
+		ReferenceNumber: "NIN-SW-001",
+		Category:        "Games",
+		PriceAmount:     "29980.5",
+		Currency:        "JPY",
+	}
+
+	strict := NewCreateProductUseCase(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{})
+	_, err := strict.Execute(context.Background(), input)
+	if !errors.Is(err, entity.ErrPricePrecision) {
+		t.Fatalf("Expected ErrPricePrecision for a fractional yen amount, got %v", err)
+	}
+	if saved != nil {
+		t.Fatal("Expected product not to be saved")
+	}
+
+	rounding := NewCreateProductUseCaseWithOptions(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{}, CreateProductOptions{
+		PriceRounding: entity.PriceRoundingHalfUp,
+	})
+	product, err := rounding.Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if product.Price != 29981 || saved.Price != 29981 {
+		t.Errorf("Expected price rounded to 29981 yen, got %d", product.Price)
+	}
+}
+
+func TestCreateProductUseCase_Execute_CustomIDFields(t *testing.T) {
+	var saved *entity.Product
+
+	mockProductRepo := &MockProductRepository{
+		CreateFunc: func(ctx context.Context, product *entity.Product) error {
+			saved = product
+			return nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetFunc: func(ctx context.Context, key string) (*entity.Product, error) {
+			return nil, repository.ErrCacheNotFound
+		},
+	}
+
+	uc := NewCreateProductUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, CreateProductOptions{
+		IDFields: entity.IDFields{entity.IDFieldSKU},
+	})
+
+	_, err := uc.Execute(context.Background(), port.CreateProductInput{
+		Name:            "iPhone 15",
+		ReferenceNumber: "APL-IP15-001",
+		Category:        "smartphones",
+		Stock:           5,
+	})
+
+	if !errors.Is(err, entity.ErrMissingIDField) {
+		t.Errorf("Expected ErrMissingIDField without SKU, got %v", err)
+	}
+
+	if saved != nil {
+		t.Error("Expected product not to be saved")
+	}
+
+	product, err := uc.Execute(context.Background(), port.CreateProductInput{
+		Name:            "iPhone 15",
+		ReferenceNumber: "APL-IP15-001",
+		Category:        "smartphones",
+		SKU:             "SKU-IP15",
+		Stock:           5,
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	other := &entity.Product{Name: "Another name", SKU: "sku-ip15"}
+	expected, _ := entity.IDFields{entity.IDFieldSKU}.GenerateID(other)
+
+	if product.ID != expected || saved.ID != expected {
+		t.Errorf("Expected ID derived from SKU only, got %s", product.ID)
+	}
+}
+
+func TestCreateProductUseCase_Execute_ReferenceNormalization(t *testing.T) {
+	var saved []*entity.Product
+
+	mockProductRepo := &MockProductRepository{
+		CreateFunc: func(ctx context.Context, product *entity.Product) error {
+			saved = append(saved, product)
+			return nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetFunc: func(ctx context.Context, key string) (*entity.Product, error) {
+			return nil, repository.ErrCacheNotFound
+		},
+	}
+
+	uc := NewCreateProductUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, CreateProductOptions{
+		ReferenceNormalization: entity.ReferenceNormalization{Uppercase: true, AlphanumericOnly: true},
+	})
+
+	for _, reference := range []string{"REF-001", "ref 001"} {
+		_, err := uc.Execute(context.Background(), port.CreateProductInput{
+			Name:            "iPhone 15",
+			ReferenceNumber: reference,
+			Category:        "smartphones",
+			Stock:           5,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error for %q, got %v", reference, err)
+		}
+	}
+
+	if len(saved) != 2 {
+		t.Fatalf("Expected 2 create calls, got %d", len(saved))
+	}
+	if saved[0].ReferenceNumber != "REF001" || saved[1].ReferenceNumber != "REF001" {
+		t.Errorf("Expected normalized references, got %q and %q", saved[0].ReferenceNumber, saved[1].ReferenceNumber)
+	}
+	if saved[0].ID != saved[1].ID {
+		t.Errorf("Expected equivalent references to derive the same ID, got %s and %s", saved[0].ID, saved[1].ID)
+	}
+
+	_, err := uc.Execute(context.Background(), port.CreateProductInput{
+		Name:            "iPhone 15",
+		ReferenceNumber: "---",
+		Category:        "smartphones",
+	})
+	if !errors.Is(err, entity.ErrInvalidReference) {
+		t.Errorf("Expected ErrInvalidReference for a reference with no alphanumerics, got %v", err)
+	}
+}
+
+func TestCreateProductUseCase_Execute_UniqueNamePerCategory(t *testing.T) {
+	existing := []*entity.Product{
+		{ID: "P1", Name: "iPhone 15", Category: "Tablets"},
+		{ID: "P2", Name: "IPHONE 15", Category: " smartphones"},
+		{ID: "P3", Name: "iPhone 15 Pro", Category: "Smartphones"},
+	}
+
+	var orders []repository.NameSearchOrder
+	createCalled := false
+	mockProductRepo := &MockProductRepository{
+		FindByNameFunc: func(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			orders = append(orders, order)
+			return existing, nil
+		},
+		CreateFunc: func(ctx context.Context, product *entity.Product) error {
+			createCalled = true
+			return nil
+		},
+	}
+
+	uc := NewCreateProductUseCaseWithOptions(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{}, CreateProductOptions{
+		UniqueNamePerCategory: true,
+	})
+
+	input := port.CreateProductInput{
+		Name:            "iPhone 15",
+		ReferenceNumber: "APL-IP15-002",
+		Category:        "Smartphones",
+	}
+
+	_, err := uc.Execute(context.Background(), input)
+	if !errors.Is(err, entity.ErrDuplicateNameInCategory) {
+		t.Errorf("Expected ErrDuplicateNameInCategory, got %v", err)
+	}
+	if createCalled {
+		t.Error("Expected duplicate name not to be saved")
+	}
+	if len(orders) != 1 || orders[0] != repository.NameOrderRelevance {
+		t.Errorf("Expected a single relevance-ordered lookup, got %v", orders)
+	}
+
+	input.Category = "Accessories"
+	if _, err := uc.Execute(context.Background(), input); err != nil {
+		t.Errorf("Expected same name in another category to be accepted, got %v", err)
+	}
+	if !createCalled {
+		t.Error("Expected product in another category to be saved")
+	}
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,11 +11,22 @@ import (
 	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
 )
 
+// DeleteProductOptions ajusta a remoção. Background executa a limpeza do
+// cache; nil usa uma goroutine sem acompanhamento. Events recebe um
+// product.deleted por remoção; nil descarta.
+type DeleteProductOptions struct {
+	Background port.BackgroundRunner
+	Events     port.ChangePublisher
+	Counts     port.ProductCountCache
+	Audit      port.AuditLogger
+}
+
 type DeleteProductUseCase struct {
 	productRepo repository.ProductRepository
 	cacheRepo   repository.CacheRepository
 	cacheKeys   port.CacheKeyGenerator
 	logger      port.Logger
+	options     DeleteProductOptions
 }
 
 func NewDeleteProductUseCase(
@@ -23,22 +35,59 @@ func NewDeleteProductUseCase(
 	cacheKeys port.CacheKeyGenerator,
 	logger port.Logger,
 ) *DeleteProductUseCase {
+	return NewDeleteProductUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, logger, DeleteProductOptions{})
+}
+
+func NewDeleteProductUseCaseWithOptions(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+	options DeleteProductOptions,
+) *DeleteProductUseCase {
+	options.Background = backgroundRunnerOrGo(options.Background)
+	options.Events = changePublisherOrNoop(options.Events)
+	options.Counts = countCacheOrNoop(options.Counts)
+	options.Audit = auditLoggerOrNoop(options.Audit)
+
 	return &DeleteProductUseCase{
 		productRepo: productRepo,
 		cacheRepo:   cacheRepo,
 		cacheKeys:   cacheKeys,
 		logger:      logger,
+		options:     options,
 	}
 }
 
 func (uc *DeleteProductUseCase) Execute(ctx context.Context, id string) error {
+	return uc.delete(ctx, id, func() error {
+		return uc.productRepo.Delete(ctx, id)
+	})
+}
+
+// ExecuteIfVersion só exclui se a versão atual no banco for a informada
+// (If-Match); caso contrário retorna ErrVersionConflict sem tocar no cache.
+func (uc *DeleteProductUseCase) ExecuteIfVersion(ctx context.Context, id string, version int) error {
+	return uc.delete(ctx, id, func() error {
+		return uc.productRepo.DeleteIfVersion(ctx, id, version)
+	})
+}
+
+func (uc *DeleteProductUseCase) delete(ctx context.Context, id string, remove func() error) error {
 	uc.logger.Info("deleting product",
 		"product_id", id[:min(8, len(id))],
 	)
 
 	product, _ := uc.cacheRepo.Get(ctx, uc.cacheKeys.ProductKey(id))
 
-	if err := uc.productRepo.Delete(ctx, id); err != nil {
+	if err := remove(); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			uc.logger.Warn("version conflict on conditional delete",
+				"product_id", id[:min(8, len(id))],
+			)
+			return fmt.Errorf("product was modified by another process: %w", err)
+		}
+
 		uc.logger.Error("failed to delete product from database",
 			"error", err,
 			"product_id", id[:min(8, len(id))],
@@ -49,12 +98,22 @@ func (uc *DeleteProductUseCase) Execute(ctx context.Context, id string) error {
 	uc.logger.Info("product deleted from database",
 		"product_id", id[:min(8, len(id))],
 	)
-
-	go func() {
+	deletedAt := time.Now().UTC()
+	uc.options.Audit.Record(ctx, entity.AuditDelete, id, map[string]entity.FieldChange{
+		"deleted_at": {New: deletedAt},
+	})
+	invalidateCount(ctx, uc.options.Counts, uc.logger)
+	uc.options.Events.Publish(port.ChangeEvent{
+		Type:       port.ProductDeleted,
+		ProductID:  id,
+		OccurredAt: deletedAt,
+	})
+
+	uc.options.Background.Go(func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 		uc.cleanupCache(ctx, id, product)
-	}()
+	})
 
 	return nil
 }
@@ -69,7 +128,7 @@ func (uc *DeleteProductUseCase) cleanupCache(ctx context.Context, id string, pro
 		)
 	}
 
-	if err := uc.cacheRepo.RemoveFromSet(ctx, uc.cacheKeys.AllProductsKey(), id); err != nil {
+	if err := uc.cacheRepo.RemoveFromSortedSet(ctx, uc.cacheKeys.AllProductsKey(), id); err != nil {
 		uc.logger.Debug("failed to remove from all_products index",
 			"error", err,
 			"product_id", id[:min(8, len(id))],
@@ -90,6 +149,25 @@ func (uc *DeleteProductUseCase) cleanupCache(ctx context.Context, id string, pro
 				"product_id", id[:min(8, len(id))],
 			)
 		}
+
+		if product.SKU != "" {
+			if err := uc.cacheRepo.RemoveFromSet(ctx, uc.cacheKeys.SKUKey(product.SKU), id); err != nil {
+				uc.logger.Debug("failed to remove from sku index",
+					"error", err,
+					"product_id", id[:min(8, len(id))],
+				)
+			}
+		}
+
+		for _, tag := range product.Tags {
+			if err := uc.cacheRepo.RemoveFromSet(ctx, uc.cacheKeys.TagKey(tag), id); err != nil {
+				uc.logger.Debug("failed to remove from tag index",
+					"error", err,
+					"product_id", id[:min(8, len(id))],
+					"tag", tag,
+				)
+			}
+		}
 	}
 
 	uc.logger.Info("cache cleanup completed",
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
 	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
 	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
 )
@@ -94,6 +95,12 @@ func TestDeleteProductUseCase_Execute_CacheCleanupOnSuccess(t *testing.T) {
 			mu.Unlock()
 			return nil
 		},
+		RemoveFromSortedSetFunc: func(ctx context.Context, setKey, productID string) error {
+			mu.Lock()
+			removedFromSets = append(removedFromSets, setKey)
+			mu.Unlock()
+			return nil
+		},
 	}
 
 	mockCacheKeys := &MockCacheKeyGenerator{}
@@ -147,6 +154,12 @@ func TestDeleteProductUseCase_Execute_CacheCleanupWithoutProductInfo(t *testing.
 			mu.Unlock()
 			return nil
 		},
+		RemoveFromSortedSetFunc: func(ctx context.Context, setKey, productID string) error {
+			mu.Lock()
+			removedFromSets = append(removedFromSets, setKey)
+			mu.Unlock()
+			return nil
+		},
 	}
 
 	mockCacheKeys := &MockCacheKeyGenerator{}
@@ -200,6 +213,9 @@ func TestDeleteProductUseCase_Execute_CacheErrorsDoNotFail(t *testing.T) {
 		RemoveFromSetFunc: func(ctx context.Context, setKey, productID string) error {
 			return errors.New("cache remove from set error")
 		},
+		RemoveFromSortedSetFunc: func(ctx context.Context, setKey, productID string) error {
+			return errors.New("cache remove from sorted set error")
+		},
 	}
 
 	mockCacheKeys := &MockCacheKeyGenerator{}
@@ -232,3 +248,96 @@ func TestDeleteProductUseCase_Execute_ShortProductID(t *testing.T) {
 		t.Errorf("Should handle short IDs gracefully, got %v", err)
 	}
 }
+
+type recordingPublisher struct {
+	events []port.ChangeEvent
+}
+
+func (p *recordingPublisher) Publish(event port.ChangeEvent) {
+	p.events = append(p.events, event)
+}
+
+func TestDeleteProductUseCase_Execute_PublishesEvent(t *testing.T) {
+	events := &recordingPublisher{}
+	tasks := NewBackgroundTasks()
+	uc := NewDeleteProductUseCaseWithOptions(&MockProductRepository{}, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{}, DeleteProductOptions{
+		Background: tasks,
+		Events:     events,
+	})
+
+	if err := uc.Execute(context.Background(), "abc"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_ = tasks.Wait(context.Background())
+
+	if len(events.events) != 1 || events.events[0].Type != port.ProductDeleted || events.events[0].ProductID != "abc" {
+		t.Errorf("Expected one product.deleted event for abc, got %+v", events.events)
+	}
+}
+
+func TestDeleteProductUseCase_ExecuteIfVersion_Success(t *testing.T) {
+	var gotVersion int
+	productRepo := &MockProductRepository{
+		DeleteFunc: func(ctx context.Context, id string) error {
+			t.Error("Expected conditional delete, got unconditional")
+			return nil
+		},
+		DeleteIfVersionFunc: func(ctx context.Context, id string, version int) error {
+			gotVersion = version
+			return nil
+		},
+	}
+	cacheDeleted := false
+	cacheRepo := &MockCacheRepository{
+		DeleteFunc: func(ctx context.Context, key string) error {
+			cacheDeleted = true
+			return nil
+		},
+	}
+	tasks := NewBackgroundTasks()
+	uc := NewDeleteProductUseCaseWithOptions(productRepo, cacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, DeleteProductOptions{
+		Background: tasks,
+	})
+
+	if err := uc.ExecuteIfVersion(context.Background(), "abc", 3); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_ = tasks.Wait(context.Background())
+
+	if gotVersion != 3 {
+		t.Errorf("Expected version 3 to be checked, got %d", gotVersion)
+	}
+	if !cacheDeleted {
+		t.Error("Expected cache cleanup after conditional delete")
+	}
+}
+
+func TestDeleteProductUseCase_ExecuteIfVersion_Conflict(t *testing.T) {
+	productRepo := &MockProductRepository{
+		DeleteIfVersionFunc: func(ctx context.Context, id string, version int) error {
+			return repository.ErrVersionConflict
+		},
+	}
+	cacheRepo := &MockCacheRepository{
+		DeleteFunc: func(ctx context.Context, key string) error {
+			t.Error("Expected cache to be untouched on conflict")
+			return nil
+		},
+	}
+	events := &recordingPublisher{}
+	tasks := NewBackgroundTasks()
+	uc := NewDeleteProductUseCaseWithOptions(productRepo, cacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, DeleteProductOptions{
+		Background: tasks,
+		Events:     events,
+	})
+
+	err := uc.ExecuteIfVersion(context.Background(), "abc", 2)
+	_ = tasks.Wait(context.Background())
+
+	if !errors.Is(err, repository.ErrVersionConflict) {
+		t.Fatalf("Expected ErrVersionConflict, got %v", err)
+	}
+	if len(events.events) != 0 {
+		t.Errorf("Expected no event on conflict, got %+v", events.events)
+	}
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+const defaultGeoFeedBatchSize = 500
+
+// ExportGeoFeedOptions ajusta a exportação. BatchSize é o tamanho das páginas
+// lidas do banco.
+type ExportGeoFeedOptions struct {
+	BatchSize int
+}
+
+// ExportGeoFeedUseCase percorre o catálogo no banco, página a página, e
+// entrega os produtos com lat/lng válidos nas specifications, para o feed
+// GeoJSON de localização de lojas. Só uma página fica em memória por vez.
+type ExportGeoFeedUseCase struct {
+	productRepo repository.ProductRepository
+	logger      port.Logger
+	options     ExportGeoFeedOptions
+}
+
+func NewExportGeoFeedUseCase(
+	productRepo repository.ProductRepository,
+	logger port.Logger,
+	options ExportGeoFeedOptions,
+) *ExportGeoFeedUseCase {
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaultGeoFeedBatchSize
+	}
+
+	return &ExportGeoFeedUseCase{
+		productRepo: productRepo,
+		logger:      logger,
+		options:     options,
+	}
+}
+
+// Execute chama emit para cada produto com coordenadas, na ordem da
+// listagem. Produtos sem coordenadas ficam de fora; com coordenadas fora das
+// faixas também, e são contados no log. Um erro de emit interrompe a
+// exportação e é devolvido.
+func (uc *ExportGeoFeedUseCase) Execute(ctx context.Context, emit func(feature port.GeoFeature) error) error {
+	exported, invalid := 0, 0
+
+	for offset := 0; ; offset += uc.options.BatchSize {
+		products, err := uc.productRepo.FindAll(ctx, repository.ListFilter{}, repository.SortOptions{}, uc.options.BatchSize, offset)
+		if err != nil {
+			uc.logger.Error("failed to load products for geo feed",
+				"error", err,
+				"offset", offset,
+			)
+			return fmt.Errorf("failed to load products: %w", err)
+		}
+
+		for _, product := range products {
+			lat, lng, err := product.Coordinates()
+			if err != nil {
+				if errors.Is(err, entity.ErrInvalidCoordinates) {
+					invalid++
+				}
+				continue
+			}
+
+			if err := emit(port.GeoFeature{Product: product, Latitude: lat, Longitude: lng}); err != nil {
+				return err
+			}
+			exported++
+		}
+
+		if len(products) < uc.options.BatchSize {
+			break
+		}
+	}
+
+	if invalid > 0 {
+		uc.logger.Warn("products with invalid coordinates skipped from geo feed",
+			"skipped", invalid,
+		)
+	}
+	uc.logger.Debug("geo feed exported",
+		"features", exported,
+	)
+
+	return nil
+}
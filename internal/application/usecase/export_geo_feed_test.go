@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+func TestExportGeoFeedUseCase_Execute(t *testing.T) {
+	store := newTestProductWithData("Loja Paulista", "REF-001", "Stores")
+	store.Specifications = map[string]interface{}{"lat": -23.5614, "lng": -46.6559}
+	noLocation := newTestProductWithData("Notebook", "REF-002", "Laptops")
+	outOfRange := newTestProductWithData("Loja Invalida", "REF-003", "Stores")
+	outOfRange.Specifications = map[string]interface{}{"lat": 123.0, "lng": 10.0}
+	other := newTestProductWithData("Loja Centro", "REF-004", "Stores")
+	other.Specifications = map[string]interface{}{"lat": -22.9068, "lng": -43.1729}
+
+	catalog := []*entity.Product{store, noLocation, outOfRange, other}
+	var offsets []int
+	productRepo := &MockProductRepository{
+		FindAllFunc: func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			offsets = append(offsets, offset)
+			end := min(offset+limit, len(catalog))
+			return catalog[offset:end], nil
+		},
+	}
+
+	uc := NewExportGeoFeedUseCase(productRepo, &MockLogger{}, ExportGeoFeedOptions{BatchSize: 2})
+
+	var features []port.GeoFeature
+	err := uc.Execute(context.Background(), func(feature port.GeoFeature) error {
+		features = append(features, feature)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !reflect.DeepEqual(offsets, []int{0, 2, 4}) {
+		t.Errorf("Expected pages at offsets 0, 2 and 4, got %v", offsets)
+	}
+	if len(features) != 2 || features[0].Product != store || features[1].Product != other {
+		t.Fatalf("Expected only the two stores with valid coordinates, got %d features", len(features))
+	}
+	if features[0].Latitude != -23.5614 || features[0].Longitude != -46.6559 {
+		t.Errorf("Expected store coordinates, got (%v, %v)", features[0].Latitude, features[0].Longitude)
+	}
+}
+
+func TestExportGeoFeedUseCase_Execute_StopsOnEmitError(t *testing.T) {
+	store := newTestProductWithData("Loja Paulista", "REF-001", "Stores")
+	store.Specifications = map[string]interface{}{"lat": -23.5614, "lng": -46.6559}
+
+	productRepo := &MockProductRepository{
+		FindAllFunc: func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			if offset > 0 {
+				t.Error("Expected no further pages after the emit error")
+			}
+			return []*entity.Product{store, store}, nil
+		},
+	}
+
+	uc := NewExportGeoFeedUseCase(productRepo, &MockLogger{}, ExportGeoFeedOptions{BatchSize: 2})
+
+	writeErr := errors.New("client went away")
+	calls := 0
+	err := uc.Execute(context.Background(), func(feature port.GeoFeature) error {
+		calls++
+		return writeErr
+	})
+	if !errors.Is(err, writeErr) {
+		t.Errorf("Expected the emit error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected export to stop after the first error, got %d calls", calls)
+	}
+}
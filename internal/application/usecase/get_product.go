@@ -3,17 +3,52 @@ package usecase
 import (
 	"context"
 	"errors"
+	"sync"
+	"time"
 
 	"github.com/dowglassantana/product-redis-api/internal/application/port"
 	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
 	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
 )
 
+// GetProductOptions ajusta o comportamento da leitura de produto.
+//
+// MaxStaleness, quando maior que zero, faz entradas de cache gravadas há mais
+// tempo que o limite (ou sem registro de quando foram gravadas) serem tratadas
+// como miss: o produto é relido do banco e o cache é regravado.
+//
+// RevalidateAfter, quando maior que zero, ativa o stale-while-revalidate:
+// entradas mais antigas que ele (e dentro de MaxStaleness, se definido) são
+// devolvidas na hora e relidas do banco em background por Background. Só uma
+// revalidação por produto roda de cada vez.
+//
+// RevalidationRepo, quando definido, é o repositório lido pela revalidação em
+// background. Deve ser o repositório sem o decorator do modo degradado: com o
+// banco fora, aquele decorator responde "não encontrado" e a revalidação
+// removeria do cache entradas válidas, justamente as que sustentam as leituras
+// degradadas. Sem ele, a revalidação usa o mesmo repositório das leituras.
+//
+// FallbackRecorder recebe a latência da leitura no banco após o miss, e
+// CacheRecorder conta cada leitura como hit ou miss (uma entrada velha demais
+// conta como miss).
+type GetProductOptions struct {
+	MaxStaleness     time.Duration
+	RevalidateAfter  time.Duration
+	RevalidationRepo repository.ProductRepository
+	FallbackRecorder port.FallbackRecorder
+	CacheRecorder    port.CacheRecorder
+	Background       port.BackgroundRunner
+}
+
 type GetProductUseCase struct {
 	productRepo repository.ProductRepository
 	cacheRepo   repository.CacheRepository
 	cacheKeys   port.CacheKeyGenerator
 	logger      port.Logger
+	options     GetProductOptions
+
+	// revalidating guarda os IDs com revalidação em andamento.
+	revalidating sync.Map
 }
 
 func NewGetProductUseCase(
@@ -22,11 +57,29 @@ func NewGetProductUseCase(
 	cacheKeys port.CacheKeyGenerator,
 	logger port.Logger,
 ) *GetProductUseCase {
+	return NewGetProductUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, logger, GetProductOptions{})
+}
+
+func NewGetProductUseCaseWithOptions(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+	options GetProductOptions,
+) *GetProductUseCase {
+	options.FallbackRecorder = fallbackRecorderOrNoop(options.FallbackRecorder)
+	options.CacheRecorder = cacheRecorderOrNoop(options.CacheRecorder)
+	options.Background = backgroundRunnerOrGo(options.Background)
+	if options.RevalidationRepo == nil {
+		options.RevalidationRepo = productRepo
+	}
+
 	return &GetProductUseCase{
 		productRepo: productRepo,
 		cacheRepo:   cacheRepo,
 		cacheKeys:   cacheKeys,
 		logger:      logger,
+		options:     options,
 	}
 }
 
@@ -36,20 +89,39 @@ func (uc *GetProductUseCase) Execute(ctx context.Context, id string) (*entity.Pr
 	)
 
 	cacheKey := uc.cacheKeys.ProductKey(id)
-	product, err := uc.cacheRepo.Get(ctx, cacheKey)
+	entry, err := uc.cacheRepo.GetEntry(ctx, cacheKey)
+	stale := false
 	if err == nil {
-		uc.logger.Debug("cache hit",
+		if !uc.isStale(entry) {
+			uc.logger.Debug("cache hit",
+				"product_id", id[:min(8, len(id))],
+				"age", entry.Age(),
+			)
+			observeCacheHit(ctx, uc.options.CacheRecorder, "get")
+			if uc.needsRevalidation(entry) {
+				uc.revalidate(ctx, id, cacheKey)
+			}
+			return entry.Product, nil
+		}
+
+		stale = true
+		uc.logger.Debug("stale cache entry - refetching from database",
+			"product_id", id[:min(8, len(id))],
+			"cached_at", entry.CachedAt,
+			"max_staleness", uc.options.MaxStaleness,
+		)
+	} else {
+		uc.logger.Debug("cache miss or error",
+			"error", err,
 			"product_id", id[:min(8, len(id))],
 		)
-		return product, nil
 	}
 
-	uc.logger.Debug("cache miss or error",
-		"error", err,
-		"product_id", id[:min(8, len(id))],
-	)
+	observeCacheMiss(ctx, uc.options.CacheRecorder, "get")
 
-	product, err = uc.productRepo.FindByID(ctx, id)
+	start := time.Now()
+	product, err := uc.productRepo.FindByID(ctx, id)
+	uc.options.FallbackRecorder.ObserveDBFallback("get_product", time.Since(start))
 	if err != nil {
 		if errors.Is(err, repository.ErrProductNotFound) {
 			uc.logger.Debug("product not found",
@@ -65,5 +137,110 @@ func (uc *GetProductUseCase) Execute(ctx context.Context, id string) (*entity.Pr
 		return nil, err
 	}
 
+	if stale {
+		if err := uc.cacheRepo.Set(ctx, cacheKey, product); err != nil && !skippedCacheWrite(uc.logger, err, product) {
+			uc.logger.Error("failed to refresh stale cache entry",
+				"error", err,
+				"product_id", product.HashID(),
+			)
+		}
+	}
+
 	return product, nil
 }
+
+func (uc *GetProductUseCase) isStale(entry *repository.CacheEntry) bool {
+	if uc.options.MaxStaleness <= 0 {
+		return false
+	}
+	if entry.CachedAt.IsZero() {
+		return true
+	}
+	return entry.Age() > uc.options.MaxStaleness
+}
+
+// needsRevalidation indica uma entrada ainda servível, mas mais velha que o
+// soft-TTL. Entradas sem cached_at têm idade desconhecida e são revalidadas.
+func (uc *GetProductUseCase) needsRevalidation(entry *repository.CacheEntry) bool {
+	if uc.options.RevalidateAfter <= 0 {
+		return false
+	}
+	if entry.CachedAt.IsZero() {
+		return true
+	}
+	return entry.Age() > uc.options.RevalidateAfter
+}
+
+// revalidate relê o produto do banco em background e regrava o cache, sem
+// atrasar a resposta. Se o produto não existe mais, a entrada é removida; uma
+// falha do banco mantém a entrada como está.
+func (uc *GetProductUseCase) revalidate(ctx context.Context, id, cacheKey string) {
+	if _, running := uc.revalidating.LoadOrStore(id, struct{}{}); running {
+		return
+	}
+
+	refreshCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), repopulateTimeout)
+
+	uc.options.Background.Go(func() {
+		defer cancel()
+		defer uc.revalidating.Delete(id)
+
+		product, err := uc.options.RevalidationRepo.FindByID(refreshCtx, id)
+		if err != nil {
+			if errors.Is(err, repository.ErrProductNotFound) {
+				if err := uc.cacheRepo.Delete(refreshCtx, cacheKey); err != nil {
+					uc.logger.Warn("failed to drop cache entry of deleted product",
+						"error", err,
+						"product_id", id[:min(8, len(id))],
+					)
+				}
+				return
+			}
+
+			uc.logger.Warn("failed to revalidate cache entry",
+				"error", err,
+				"product_id", id[:min(8, len(id))],
+			)
+			return
+		}
+
+		if err := uc.cacheRepo.Set(refreshCtx, cacheKey, product); err != nil && !skippedCacheWrite(uc.logger, err, product) {
+			uc.logger.Warn("failed to refresh revalidated cache entry",
+				"error", err,
+				"product_id", product.HashID(),
+			)
+			return
+		}
+
+		uc.logger.Debug("cache entry revalidated",
+			"product_id", product.HashID(),
+		)
+	})
+}
+
+// observeCacheHit e observeCacheMiss contam a leitura no recorder e anotam a
+// origem no contexto, para o cabeçalho X-Cache.
+func observeCacheHit(ctx context.Context, recorder port.CacheRecorder, operation string) {
+	recorder.ObserveCacheHit(operation)
+	port.RecordCacheHit(ctx)
+}
+
+func observeCacheMiss(ctx context.Context, recorder port.CacheRecorder, operation string) {
+	recorder.ObserveCacheMiss(operation)
+	port.RecordCacheMiss(ctx)
+}
+
+// fallbackRecorderOrNoop evita checagens de nil nos casos de uso.
+func cacheRecorderOrNoop(recorder port.CacheRecorder) port.CacheRecorder {
+	if recorder == nil {
+		return port.NoopCacheRecorder{}
+	}
+	return recorder
+}
+
+func fallbackRecorderOrNoop(recorder port.FallbackRecorder) port.FallbackRecorder {
+	if recorder == nil {
+		return port.NoopFallbackRecorder{}
+	}
+	return recorder
+}
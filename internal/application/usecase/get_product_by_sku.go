@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// GetProductBySKUOptions ajusta a busca por SKU. Background roda a gravação
+// do cache depois de uma busca no banco.
+type GetProductBySKUOptions struct {
+	FallbackRecorder port.FallbackRecorder
+	CacheRecorder    port.CacheRecorder
+	Background       port.BackgroundRunner
+}
+
+// GetProductBySKUUseCase busca um produto pelo SKU, sem diferenciar
+// maiúsculas, primeiro no índice product_by_sku_* e depois no banco. SKUs não
+// são únicos: com mais de um produto, vale o cadastro mais antigo e a
+// duplicidade é registrada em log.
+type GetProductBySKUUseCase struct {
+	productRepo repository.ProductRepository
+	cacheRepo   repository.CacheRepository
+	cacheKeys   port.CacheKeyGenerator
+	logger      port.Logger
+	options     GetProductBySKUOptions
+}
+
+func NewGetProductBySKUUseCase(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+) *GetProductBySKUUseCase {
+	return NewGetProductBySKUUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, logger, GetProductBySKUOptions{})
+}
+
+func NewGetProductBySKUUseCaseWithOptions(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+	options GetProductBySKUOptions,
+) *GetProductBySKUUseCase {
+	options.FallbackRecorder = fallbackRecorderOrNoop(options.FallbackRecorder)
+	options.CacheRecorder = cacheRecorderOrNoop(options.CacheRecorder)
+	options.Background = backgroundRunnerOrGo(options.Background)
+
+	return &GetProductBySKUUseCase{
+		productRepo: productRepo,
+		cacheRepo:   cacheRepo,
+		cacheKeys:   cacheKeys,
+		logger:      logger,
+		options:     options,
+	}
+}
+
+func (uc *GetProductBySKUUseCase) Execute(ctx context.Context, sku string) (*entity.Product, error) {
+	sku = strings.TrimSpace(sku)
+	if sku == "" {
+		return nil, repository.ErrProductNotFound
+	}
+
+	uc.logger.Debug("fetching product by sku",
+		"sku", sku,
+	)
+
+	products, err := uc.searchInCache(ctx, sku)
+	if err != nil {
+		uc.logger.Debug("sku cache lookup failed - falling back to database",
+			"error", err,
+			"sku", sku,
+		)
+	}
+	if len(products) > 0 {
+		observeCacheHit(ctx, uc.options.CacheRecorder, "get_sku")
+		return uc.first(sku, products), nil
+	}
+
+	observeCacheMiss(ctx, uc.options.CacheRecorder, "get_sku")
+	uc.logger.Debug("cache miss - searching sku in database",
+		"sku", sku,
+	)
+
+	start := time.Now()
+	product, matches, err := uc.productRepo.FindBySKU(ctx, sku)
+	uc.options.FallbackRecorder.ObserveDBFallback("get_product_by_sku", time.Since(start))
+	if err != nil {
+		if errors.Is(err, repository.ErrProductNotFound) {
+			uc.logger.Debug("product not found by sku",
+				"sku", sku,
+			)
+			return nil, err
+		}
+
+		uc.logger.Error("failed to fetch product by sku from database",
+			"error", err,
+			"sku", sku,
+		)
+		return nil, err
+	}
+
+	uc.warnDuplicates(sku, product, matches)
+	uc.cacheSKU(ctx, sku, product)
+
+	return product, nil
+}
+
+// searchInCache devolve os produtos do índice cujo SKU ainda confere; um miss
+// (índice vazio ou incompleto) não é erro, só falhas do Redis.
+func (uc *GetProductBySKUUseCase) searchInCache(ctx context.Context, sku string) ([]*entity.Product, error) {
+	productIDs, err := uc.cacheRepo.GetSet(ctx, uc.cacheKeys.SKUKey(sku))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", repository.ErrCacheUnavailable, err)
+	}
+	if len(productIDs) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(productIDs))
+	for i, id := range productIDs {
+		keys[i] = uc.cacheKeys.ProductKey(id)
+	}
+
+	products, err := uc.cacheRepo.GetMultiple(ctx, keys)
+	if err != nil {
+		uc.logger.Debug("failed to get products from cache",
+			"error", err,
+		)
+		return nil, fmt.Errorf("%w: %v", repository.ErrCacheUnavailable, err)
+	}
+
+	if len(products) < len(productIDs) {
+		return nil, nil
+	}
+
+	matching := products[:0]
+	for _, product := range products {
+		if strings.EqualFold(strings.TrimSpace(product.SKU), sku) {
+			matching = append(matching, product)
+		}
+	}
+
+	return matching, nil
+}
+
+// first escolhe, entre os produtos do índice, o mesmo que o banco devolveria:
+// o cadastro mais antigo, com o ID desempatando.
+func (uc *GetProductBySKUUseCase) first(sku string, products []*entity.Product) *entity.Product {
+	sort.Slice(products, func(i, j int) bool {
+		if !products[i].CreatedAt.Equal(products[j].CreatedAt) {
+			return products[i].CreatedAt.Before(products[j].CreatedAt)
+		}
+		return products[i].ID < products[j].ID
+	})
+
+	uc.warnDuplicates(sku, products[0], len(products))
+	return products[0]
+}
+
+func (uc *GetProductBySKUUseCase) warnDuplicates(sku string, product *entity.Product, matches int) {
+	if matches <= 1 {
+		return
+	}
+	uc.logger.Warn("sku shared by more than one product - returning the oldest",
+		"sku", sku,
+		"matches", matches,
+		"product_id", product.HashID(),
+	)
+}
+
+// cacheSKU grava em background o produto e o põe no índice do SKU. Só o
+// produto devolvido entra no índice, o que basta para as próximas buscas
+// darem a mesma resposta. É best-effort: se a escrita falhar, a próxima busca
+// volta ao banco.
+func (uc *GetProductBySKUUseCase) cacheSKU(ctx context.Context, sku string, product *entity.Product) {
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), repopulateTimeout)
+
+	uc.options.Background.Go(func() {
+		defer cancel()
+
+		if err := uc.cacheRepo.Set(writeCtx, uc.cacheKeys.ProductKey(product.ID), product); err != nil && !skippedCacheWrite(uc.logger, err, product) {
+			uc.logger.Warn("failed to cache product from sku lookup",
+				"error", err,
+				"product_id", product.HashID(),
+			)
+			return
+		}
+
+		if err := uc.cacheRepo.AddAllToSet(writeCtx, uc.cacheKeys.SKUKey(sku), []string{product.ID}); err != nil {
+			uc.logger.Warn("failed to index product sku",
+				"error", err,
+				"sku", sku,
+			)
+		}
+	})
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+func TestGetProductBySKUUseCase_Execute_CacheHitReturnsOldest(t *testing.T) {
+	older := newTestProductWithData("Older", "REF-001", "Phones")
+	older.SKU = "SKU-ABC"
+	older.CreatedAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := newTestProductWithData("Newer", "REF-002", "Phones")
+	newer.SKU = "sku-abc"
+	newer.CreatedAt = older.CreatedAt.Add(time.Hour)
+
+	productRepo := &MockProductRepository{
+		FindBySKUFunc: func(ctx context.Context, sku string) (*entity.Product, int, error) {
+			t.Error("Expected database not to be called on cache hit")
+			return nil, 0, nil
+		},
+	}
+	cacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			if setKey == "product_by_sku_Sku-Abc" {
+				return []string{newer.ID, older.ID}, nil
+			}
+			return []string{}, nil
+		},
+		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
+			return []*entity.Product{newer, older}, nil
+		},
+	}
+
+	uc := NewGetProductBySKUUseCase(productRepo, cacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	product, err := uc.Execute(context.Background(), " Sku-Abc ")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if product != older {
+		t.Errorf("Expected the oldest product, got %s", product.Name)
+	}
+}
+
+func TestGetProductBySKUUseCase_Execute_StaleIndexFallsBackToDatabase(t *testing.T) {
+	cached := newTestProductWithData("Renamed SKU", "REF-001", "Phones")
+	cached.SKU = "SKU-NEW"
+	stored := newTestProductWithData("Current", "REF-002", "Phones")
+	stored.SKU = "SKU-OLD"
+
+	var queried string
+	productRepo := &MockProductRepository{
+		FindBySKUFunc: func(ctx context.Context, sku string) (*entity.Product, int, error) {
+			queried = sku
+			return stored, 1, nil
+		},
+	}
+	cacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			return []string{cached.ID}, nil
+		},
+		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
+			return []*entity.Product{cached}, nil
+		},
+	}
+
+	tasks := NewBackgroundTasks()
+	uc := NewGetProductBySKUUseCaseWithOptions(productRepo, cacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, GetProductBySKUOptions{
+		Background: tasks,
+	})
+
+	product, err := uc.Execute(context.Background(), "SKU-OLD")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := tasks.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected cache writes to finish, got %v", err)
+	}
+
+	if queried != "SKU-OLD" {
+		t.Errorf("Expected database lookup for SKU-OLD, got %q", queried)
+	}
+	if product != stored {
+		t.Errorf("Expected the product from the database, got %s", product.Name)
+	}
+}
+
+func TestGetProductBySKUUseCase_Execute_CacheMissPopulatesIndex(t *testing.T) {
+	product := newTestProductWithData("MacBook Pro", "REF-001", "Laptops")
+
+	productRepo := &MockProductRepository{
+		FindBySKUFunc: func(ctx context.Context, sku string) (*entity.Product, int, error) {
+			return product, 3, nil
+		},
+	}
+
+	var mu sync.Mutex
+	var setKeys, indexKeys []string
+	var indexed []string
+	cacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			return nil, errors.New("redis down")
+		},
+		SetFunc: func(ctx context.Context, key string, p *entity.Product) error {
+			mu.Lock()
+			defer mu.Unlock()
+			setKeys = append(setKeys, key)
+			return nil
+		},
+		AddAllToSetFunc: func(ctx context.Context, setKey string, productIDs []string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			indexKeys = append(indexKeys, setKey)
+			indexed = productIDs
+			return nil
+		},
+	}
+
+	tasks := NewBackgroundTasks()
+	uc := NewGetProductBySKUUseCaseWithOptions(productRepo, cacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, GetProductBySKUOptions{
+		Background: tasks,
+	})
+
+	result, err := uc.Execute(context.Background(), "SKU-001")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := tasks.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected cache writes to finish, got %v", err)
+	}
+
+	if result != product {
+		t.Errorf("Expected the first match from the database")
+	}
+	if !reflect.DeepEqual(setKeys, []string{"product_" + product.ID}) {
+		t.Errorf("Expected product entry cached, got %v", setKeys)
+	}
+	if !reflect.DeepEqual(indexKeys, []string{"product_by_sku_SKU-001"}) || !reflect.DeepEqual(indexed, []string{product.ID}) {
+		t.Errorf("Expected sku index with the product, got %v %v", indexKeys, indexed)
+	}
+}
+
+func TestGetProductBySKUUseCase_Execute_NotFound(t *testing.T) {
+	uc := NewGetProductBySKUUseCase(&MockProductRepository{}, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	if _, err := uc.Execute(context.Background(), "missing"); !errors.Is(err, repository.ErrProductNotFound) {
+		t.Errorf("Expected ErrProductNotFound, got %v", err)
+	}
+	if _, err := uc.Execute(context.Background(), "   "); !errors.Is(err, repository.ErrProductNotFound) {
+		t.Errorf("Expected ErrProductNotFound for blank sku, got %v", err)
+	}
+}
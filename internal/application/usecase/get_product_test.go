@@ -0,0 +1,412 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+func TestGetProductUseCase_Execute_CacheHit(t *testing.T) {
+	cachedProduct := newTestProduct()
+
+	dbCalled := false
+	mockProductRepo := &MockProductRepository{
+		FindByIDFunc: func(ctx context.Context, id string) (*entity.Product, error) {
+			dbCalled = true
+			return nil, errors.New("should not be called")
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetFunc: func(ctx context.Context, key string) (*entity.Product, error) {
+			return cachedProduct, nil
+		},
+	}
+
+	uc := NewGetProductUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	product, err := uc.Execute(context.Background(), cachedProduct.ID)
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if product != cachedProduct {
+		t.Error("Expected cached product to be returned")
+	}
+
+	if dbCalled {
+		t.Error("Database should not be called on cache hit")
+	}
+}
+
+func TestGetProductUseCase_Execute_CacheMiss_NotFound(t *testing.T) {
+	mockProductRepo := &MockProductRepository{}
+	mockCacheRepo := &MockCacheRepository{}
+
+	uc := NewGetProductUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	_, err := uc.Execute(context.Background(), "missing-id")
+
+	if !errors.Is(err, repository.ErrProductNotFound) {
+		t.Errorf("Expected ErrProductNotFound, got %v", err)
+	}
+}
+
+func TestGetProductUseCase_Execute_RecordsCacheSource(t *testing.T) {
+	cachedProduct := newTestProduct()
+
+	mockCacheRepo := &MockCacheRepository{
+		GetFunc: func(ctx context.Context, key string) (*entity.Product, error) {
+			if key == "product_"+cachedProduct.ID {
+				return cachedProduct, nil
+			}
+			return nil, repository.ErrCacheMiss
+		},
+	}
+
+	uc := NewGetProductUseCase(&MockProductRepository{}, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	ctx, source := port.WithCacheSource(context.Background())
+	if _, err := uc.Execute(ctx, cachedProduct.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := source.Value(); got != port.CacheSourceHit {
+		t.Errorf("Expected HIT after a cache hit, got %q", got)
+	}
+
+	ctx, source = port.WithCacheSource(context.Background())
+	uc.Execute(ctx, "missing-id")
+	if got := source.Value(); got != port.CacheSourceMiss {
+		t.Errorf("Expected MISS after a cache miss, got %q", got)
+	}
+}
+
+func TestGetProductUseCase_Execute_StaleEntryRefetched(t *testing.T) {
+	cachedProduct := newTestProduct()
+	dbProduct := *cachedProduct
+	dbProduct.Stock = 7
+	dbProduct.Version = 2
+
+	refreshed := false
+	mockProductRepo := &MockProductRepository{
+		FindByIDFunc: func(ctx context.Context, id string) (*entity.Product, error) {
+			return &dbProduct, nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetEntryFunc: func(ctx context.Context, key string) (*repository.CacheEntry, error) {
+			return &repository.CacheEntry{Product: cachedProduct, CachedAt: time.Now().Add(-time.Hour)}, nil
+		},
+		SetFunc: func(ctx context.Context, key string, product *entity.Product) error {
+			refreshed = product.Version == 2
+			return nil
+		},
+	}
+
+	uc := NewGetProductUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{},
+		GetProductOptions{MaxStaleness: time.Minute})
+
+	product, err := uc.Execute(context.Background(), cachedProduct.ID)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if product.Version != 2 {
+		t.Errorf("Expected database version 2, got %d", product.Version)
+	}
+
+	if !refreshed {
+		t.Error("Expected stale cache entry to be refreshed")
+	}
+}
+
+func TestGetProductUseCase_Execute_FreshEntryWithinBound(t *testing.T) {
+	cachedProduct := newTestProduct()
+
+	mockProductRepo := &MockProductRepository{
+		FindByIDFunc: func(ctx context.Context, id string) (*entity.Product, error) {
+			t.Error("Database should not be called for a fresh entry")
+			return nil, nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetEntryFunc: func(ctx context.Context, key string) (*repository.CacheEntry, error) {
+			return &repository.CacheEntry{Product: cachedProduct, CachedAt: time.Now().Add(-10 * time.Second)}, nil
+		},
+	}
+
+	uc := NewGetProductUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{},
+		GetProductOptions{MaxStaleness: time.Minute})
+
+	product, err := uc.Execute(context.Background(), cachedProduct.ID)
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if product != cachedProduct {
+		t.Error("Expected cached product to be returned")
+	}
+}
+
+func TestGetProductUseCase_Execute_UnknownAgeTreatedAsStale(t *testing.T) {
+	cachedProduct := newTestProduct()
+
+	dbCalled := false
+	mockProductRepo := &MockProductRepository{
+		FindByIDFunc: func(ctx context.Context, id string) (*entity.Product, error) {
+			dbCalled = true
+			return cachedProduct, nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetEntryFunc: func(ctx context.Context, key string) (*repository.CacheEntry, error) {
+			return &repository.CacheEntry{Product: cachedProduct}, nil
+		},
+	}
+
+	withBound := NewGetProductUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{},
+		GetProductOptions{MaxStaleness: time.Minute})
+
+	if _, err := withBound.Execute(context.Background(), cachedProduct.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !dbCalled {
+		t.Error("Expected entry without cached_at to be refetched when a bound is set")
+	}
+
+	dbCalled = false
+	withoutBound := NewGetProductUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	if _, err := withoutBound.Execute(context.Background(), cachedProduct.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if dbCalled {
+		t.Error("Expected entry to be served from cache when no bound is set")
+	}
+}
+
+func TestGetProductUseCase_Execute_RecordsFallbackOnlyOnMiss(t *testing.T) {
+	product := newTestProductWithData("Product", "REF-001", "Category")
+	cached := false
+
+	mockProductRepo := &MockProductRepository{
+		FindByIDFunc: func(ctx context.Context, id string) (*entity.Product, error) {
+			return product, nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetFunc: func(ctx context.Context, key string) (*entity.Product, error) {
+			if cached {
+				return product, nil
+			}
+			return nil, repository.ErrCacheNotFound
+		},
+	}
+
+	recorder := &MockFallbackRecorder{}
+	uc := NewGetProductUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, GetProductOptions{
+		FallbackRecorder: recorder,
+	})
+
+	if _, err := uc.Execute(context.Background(), product.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	cached = true
+	if _, err := uc.Execute(context.Background(), product.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(recorder.Operations) != 1 || recorder.Operations[0] != "get_product" {
+		t.Errorf("Expected a single get_product fallback observation, got %v", recorder.Operations)
+	}
+}
+
+func TestGetProductUseCase_Execute_StaleWhileRevalidate(t *testing.T) {
+	cachedProduct := newTestProduct()
+	dbProduct := *cachedProduct
+	dbProduct.Version = 2
+
+	release := make(chan struct{})
+	dbCalls := 0
+	mockProductRepo := &MockProductRepository{
+		FindByIDFunc: func(ctx context.Context, id string) (*entity.Product, error) {
+			<-release
+			dbCalls++
+			return &dbProduct, nil
+		},
+	}
+
+	refreshedVersion := 0
+	mockCacheRepo := &MockCacheRepository{
+		GetEntryFunc: func(ctx context.Context, key string) (*repository.CacheEntry, error) {
+			return &repository.CacheEntry{Product: cachedProduct, CachedAt: time.Now().Add(-2 * time.Minute)}, nil
+		},
+		SetFunc: func(ctx context.Context, key string, product *entity.Product) error {
+			refreshedVersion = product.Version
+			return nil
+		},
+	}
+
+	tasks := NewBackgroundTasks()
+	uc := NewGetProductUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{},
+		GetProductOptions{RevalidateAfter: time.Minute, MaxStaleness: 10 * time.Minute, Background: tasks})
+
+	for i := 0; i < 3; i++ {
+		product, err := uc.Execute(context.Background(), cachedProduct.ID)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if product.Version != cachedProduct.Version {
+			t.Errorf("Expected the cached version to be served immediately, got %d", product.Version)
+		}
+	}
+
+	close(release)
+	if err := tasks.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected revalidation to finish, got %v", err)
+	}
+
+	if dbCalls != 1 {
+		t.Errorf("Expected a single revalidation in flight, got %d database reads", dbCalls)
+	}
+	if refreshedVersion != 2 {
+		t.Errorf("Expected cache to be refreshed with version 2, got %d", refreshedVersion)
+	}
+}
+
+func TestGetProductUseCase_Execute_RevalidationDropsDeletedProduct(t *testing.T) {
+	cachedProduct := newTestProduct()
+
+	mockProductRepo := &MockProductRepository{
+		FindByIDFunc: func(ctx context.Context, id string) (*entity.Product, error) {
+			return nil, repository.ErrProductNotFound
+		},
+	}
+
+	deleted := ""
+	mockCacheRepo := &MockCacheRepository{
+		GetEntryFunc: func(ctx context.Context, key string) (*repository.CacheEntry, error) {
+			return &repository.CacheEntry{Product: cachedProduct, CachedAt: time.Now().Add(-2 * time.Minute)}, nil
+		},
+		DeleteFunc: func(ctx context.Context, key string) error {
+			deleted = key
+			return nil
+		},
+	}
+
+	tasks := NewBackgroundTasks()
+	uc := NewGetProductUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{},
+		GetProductOptions{RevalidateAfter: time.Minute, Background: tasks})
+
+	if _, err := uc.Execute(context.Background(), cachedProduct.ID); err != nil {
+		t.Fatalf("Expected cached product to be served, got %v", err)
+	}
+	if err := tasks.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected revalidation to finish, got %v", err)
+	}
+
+	if deleted != "product_"+cachedProduct.ID {
+		t.Errorf("Expected cache entry of deleted product to be dropped, got %q", deleted)
+	}
+}
+
+func TestGetProductUseCase_Execute_RevalidationKeepsEntryWhenDatabaseDown(t *testing.T) {
+	cachedProduct := newTestProduct()
+
+	// As leituras passam pelo decorator degradado, que responde "não
+	// encontrado"; a revalidação usa o repositório de verdade, que falha.
+	degradedRepo := &MockProductRepository{
+		FindByIDFunc: func(ctx context.Context, id string) (*entity.Product, error) {
+			return nil, repository.ErrProductNotFound
+		},
+	}
+	revalidationRepo := &MockProductRepository{
+		FindByIDFunc: func(ctx context.Context, id string) (*entity.Product, error) {
+			return nil, repository.ErrCircuitOpen
+		},
+	}
+
+	deleted := false
+	mockCacheRepo := &MockCacheRepository{
+		GetEntryFunc: func(ctx context.Context, key string) (*repository.CacheEntry, error) {
+			return &repository.CacheEntry{Product: cachedProduct, CachedAt: time.Now().Add(-2 * time.Minute)}, nil
+		},
+		DeleteFunc: func(ctx context.Context, key string) error {
+			deleted = true
+			return nil
+		},
+	}
+
+	tasks := NewBackgroundTasks()
+	uc := NewGetProductUseCaseWithOptions(degradedRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{},
+		GetProductOptions{RevalidateAfter: time.Minute, RevalidationRepo: revalidationRepo, Background: tasks})
+
+	if _, err := uc.Execute(context.Background(), cachedProduct.ID); err != nil {
+		t.Fatalf("Expected cached product to be served, got %v", err)
+	}
+	if err := tasks.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected revalidation to finish, got %v", err)
+	}
+
+	if deleted {
+		t.Error("Expected the cache entry to be kept while the database is down")
+	}
+}
+
+func TestGetProductUseCase_Execute_RecordsCacheHitAndMiss(t *testing.T) {
+	product := newTestProductWithData("Product", "REF-001", "Category")
+	cached := false
+
+	mockProductRepo := &MockProductRepository{
+		FindByIDFunc: func(ctx context.Context, id string) (*entity.Product, error) {
+			return product, nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetFunc: func(ctx context.Context, key string) (*entity.Product, error) {
+			if cached {
+				return product, nil
+			}
+			return nil, repository.ErrCacheNotFound
+		},
+	}
+
+	recorder := &MockCacheRecorder{}
+	uc := NewGetProductUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, GetProductOptions{
+		CacheRecorder: recorder,
+	})
+
+	if _, err := uc.Execute(context.Background(), product.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	cached = true
+	for i := 0; i < 2; i++ {
+		if _, err := uc.Execute(context.Background(), product.ID); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	if len(recorder.Misses) != 1 || recorder.Misses[0] != "get" {
+		t.Errorf("Expected a single get miss, got %v", recorder.Misses)
+	}
+	if len(recorder.Hits) != 2 || recorder.Hits[0] != "get" {
+		t.Errorf("Expected two get hits, got %v", recorder.Hits)
+	}
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// GetTombstoneUseCase consulta no banco a exclusão lógica de um produto. O
+// cache não guarda produtos excluídos, então não há o que consultar nele.
+type GetTombstoneUseCase struct {
+	productRepo repository.ProductRepository
+	logger      port.Logger
+}
+
+func NewGetTombstoneUseCase(productRepo repository.ProductRepository, logger port.Logger) *GetTombstoneUseCase {
+	return &GetTombstoneUseCase{
+		productRepo: productRepo,
+		logger:      logger,
+	}
+}
+
+func (uc *GetTombstoneUseCase) Execute(ctx context.Context, id string) (*entity.Tombstone, error) {
+	tombstone, err := uc.productRepo.FindTombstone(ctx, id)
+	if err != nil {
+		if !errors.Is(err, repository.ErrProductNotFound) {
+			uc.logger.Error("failed to find product tombstone",
+				"error", err,
+				"product_id", id[:min(8, len(id))],
+			)
+		}
+		return nil, err
+	}
+
+	return tombstone, nil
+}
@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// errImportSaveFailed é a mensagem da linha cuja gravação falhou por um erro
+// do banco. O relatório volta ao cliente, então o erro original só vai para o
+// log.
+var errImportSaveFailed = errors.New("failed to save product")
+
+// ImportProductsOptions ajusta a validação da importação.
+//
+// Com ValidateTimestamps, linhas com created_at no futuro (além de
+// MaxClockSkew) ou updated_at anterior a created_at são rejeitadas. Timestamps
+// malformados são sempre rejeitados. AllowedCategories restringe as
+// categorias aceitas; vazio aceita qualquer uma. IDFields e
+// ReferenceNormalization definem a derivação do ID, como na criação.
+// MaxSpecDepth limita o aninhamento das especificações; zero desativa.
+// Events recebe um product.created por linha importada; nil descarta.
+type ImportProductsOptions struct {
+	ValidateTimestamps     bool
+	MaxClockSkew           time.Duration
+	AllowedCategories      entity.CategorySet
+	IDFields               entity.IDFields
+	ReferenceNormalization entity.ReferenceNormalization
+	PriceRounding          entity.PriceRounding
+	MaxSpecDepth           int
+	Counts                 port.ProductCountCache
+	Audit                  port.AuditLogger
+	Events                 port.ChangePublisher
+}
+
+type ImportProductsUseCase struct {
+	productRepo repository.ProductRepository
+	creator     *CreateProductUseCase
+	logger      port.Logger
+	options     ImportProductsOptions
+	now         func() time.Time
+}
+
+func NewImportProductsUseCase(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+	options ImportProductsOptions,
+) *ImportProductsUseCase {
+	return &ImportProductsUseCase{
+		productRepo: productRepo,
+		creator: NewCreateProductUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, logger, CreateProductOptions{
+			Events: options.Events,
+			Counts: options.Counts,
+			Audit:  options.Audit,
+		}),
+		logger:  logger,
+		options: options,
+		now:     func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Execute importa as linhas uma a uma. Falhas de uma linha entram no relatório
+// e não interrompem as demais. As mensagens do relatório são as de validação
+// e conflito do domínio; um erro do banco aparece só como "failed to save
+// product" e tem o detalhe registrado no log.
+func (uc *ImportProductsUseCase) Execute(ctx context.Context, rows []port.ImportProductInput) (*port.ImportReport, error) {
+	report := &port.ImportReport{
+		Total:  len(rows),
+		Errors: []port.ImportRowError{},
+	}
+
+	for i, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if err := uc.importRow(ctx, row); err != nil {
+			report.Failed++
+			report.Errors = append(report.Errors, port.ImportRowError{
+				Row:             i + 1,
+				ReferenceNumber: row.ReferenceNumber,
+				Error:           err.Error(),
+			})
+			continue
+		}
+
+		report.Imported++
+	}
+
+	uc.logger.Info("product import finished",
+		"total", report.Total,
+		"imported", report.Imported,
+		"failed", report.Failed,
+	)
+
+	return report, nil
+}
+
+func (uc *ImportProductsUseCase) importRow(ctx context.Context, row port.ImportProductInput) error {
+	price, err := entity.ResolvePrice(row.Price, row.PriceAmount, row.Currency, uc.options.PriceRounding)
+	if err != nil {
+		return fmt.Errorf("invalid product data: %w", err)
+	}
+
+	product, err := entity.NewProduct(
+		row.Name,
+		row.ReferenceNumber,
+		row.Category,
+		row.Description,
+		row.SKU,
+		row.Brand,
+		row.Stock,
+		price,
+		row.Currency,
+		row.Images,
+		row.Specifications,
+	)
+	if err != nil {
+		return fmt.Errorf("invalid product data: %w", err)
+	}
+
+	if err := product.NormalizeReference(uc.options.ReferenceNormalization); err != nil {
+		return fmt.Errorf("invalid product data: %w", err)
+	}
+
+	if err := product.SetThumbnailURL(row.ThumbnailURL); err != nil {
+		return fmt.Errorf("invalid product data: %w", err)
+	}
+
+	if err := product.SetTags(row.Tags); err != nil {
+		return fmt.Errorf("invalid product data: %w", err)
+	}
+
+	if err := product.SetWeight(row.WeightGrams); err != nil {
+		return fmt.Errorf("invalid product data: %w", err)
+	}
+
+	if err := product.SetDimensions(row.Dimensions); err != nil {
+		return fmt.Errorf("invalid product data: %w", err)
+	}
+
+	if err := entity.CheckSpecificationsDepth(product.Specifications, uc.options.MaxSpecDepth); err != nil {
+		return fmt.Errorf("invalid product data: %w", err)
+	}
+
+	if err := uc.options.AllowedCategories.Check(product.Category); err != nil {
+		return fmt.Errorf("invalid product data: %w", err)
+	}
+
+	if err := product.AssignID(uc.options.IDFields); err != nil {
+		return fmt.Errorf("invalid product data: %w", err)
+	}
+
+	now := uc.now()
+
+	createdAt, err := parseImportTimestamp("created_at", row.CreatedAt, now)
+	if err != nil {
+		return err
+	}
+	updatedAt, err := parseImportTimestamp("updated_at", row.UpdatedAt, createdAt)
+	if err != nil {
+		return err
+	}
+	product.CreatedAt = createdAt
+	product.UpdatedAt = updatedAt
+
+	if uc.options.ValidateTimestamps {
+		if err := product.ValidateTimestamps(now, uc.options.MaxClockSkew); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.productRepo.Create(ctx, product); err != nil {
+		if errors.Is(err, repository.ErrProductAlreadyExists) || errors.Is(err, entity.ErrDuplicateNameInCategory) {
+			return err
+		}
+		uc.logger.Error("failed to import product",
+			"error", err,
+			"product_id", product.HashID(),
+		)
+		return errImportSaveFailed
+	}
+
+	uc.creator.auditCreated(ctx, product)
+	uc.creator.updateCache(ctx, product)
+	uc.creator.publishCreated(product)
+
+	return nil
+}
+
+// parseImportTimestamp interpreta um timestamp RFC 3339. Vazio resulta em fallback.
+func parseImportTimestamp(field, value string, fallback time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return fallback, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s %q is not RFC 3339", entity.ErrInvalidTimestamp, field, value)
+	}
+
+	return parsed.UTC(), nil
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+func newImportRow(reference, createdAt, updatedAt string) port.ImportProductInput {
+	return port.ImportProductInput{
+		CreateProductInput: port.CreateProductInput{
+			Name:            "Product " + reference,
+			ReferenceNumber: reference,
+			Category:        "Category",
+			Stock:           10,
+		},
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}
+}
+
+func TestImportProductsUseCase_Execute_RejectsBadTimestamps(t *testing.T) {
+	var created []*entity.Product
+
+	mockProductRepo := &MockProductRepository{
+		CreateFunc: func(ctx context.Context, product *entity.Product) error {
+			created = append(created, product)
+			return nil
+		},
+	}
+
+	uc := NewImportProductsUseCase(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{}, ImportProductsOptions{
+		ValidateTimestamps: true,
+		MaxClockSkew:       time.Minute,
+	})
+	uc.now = func() time.Time { return time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC) }
+
+	rows := []port.ImportProductInput{
+		newImportRow("REF-001", "2023-01-10T08:00:00Z", "2023-02-10T08:00:00Z"),
+		newImportRow("REF-002", "2030-01-01T00:00:00Z", "2030-01-01T00:00:00Z"),
+		newImportRow("REF-003", "2023-03-10T08:00:00Z", "2023-01-10T08:00:00Z"),
+		newImportRow("REF-004", "10/03/2023", ""),
+		newImportRow("REF-005", "", ""),
+	}
+
+	report, err := uc.Execute(context.Background(), rows)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if report.Total != 5 || report.Imported != 2 || report.Failed != 3 {
+		t.Errorf("Expected 5 total, 2 imported, 3 failed, got %+v", report)
+	}
+
+	wantRows := []int{2, 3, 4}
+	if len(report.Errors) != len(wantRows) {
+		t.Fatalf("Expected %d row errors, got %d", len(wantRows), len(report.Errors))
+	}
+	for i, row := range wantRows {
+		if report.Errors[i].Row != row {
+			t.Errorf("Expected error for row %d, got row %d", row, report.Errors[i].Row)
+		}
+	}
+
+	if len(created) != 2 {
+		t.Fatalf("Expected 2 products saved, got %d", len(created))
+	}
+
+	wantCreatedAt := time.Date(2023, 1, 10, 8, 0, 0, 0, time.UTC)
+	if !created[0].CreatedAt.Equal(wantCreatedAt) {
+		t.Errorf("Expected imported created_at %v, got %v", wantCreatedAt, created[0].CreatedAt)
+	}
+}
+
+func TestImportProductsUseCase_Execute_ValidationDisabled(t *testing.T) {
+	mockProductRepo := &MockProductRepository{
+		CreateFunc: func(ctx context.Context, product *entity.Product) error {
+			return nil
+		},
+	}
+
+	events := &recordingPublisher{}
+	uc := NewImportProductsUseCase(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{}, ImportProductsOptions{
+		Events: events,
+	})
+
+	rows := []port.ImportProductInput{
+		newImportRow("REF-001", "2999-01-01T00:00:00Z", "2999-01-01T00:00:00Z"),
+		newImportRow("REF-002", "not-a-date", ""),
+	}
+
+	report, err := uc.Execute(context.Background(), rows)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if report.Imported != 1 || report.Failed != 1 {
+		t.Errorf("Expected future row imported and malformed row rejected, got %+v", report)
+	}
+	if len(events.events) != 1 || events.events[0].Type != port.ProductCreated {
+		t.Errorf("Expected a product.created for the imported row only, got %+v", events.events)
+	}
+}
+
+// errorLogger guarda os pares chave/valor das chamadas a Error.
+type errorLogger struct {
+	MockLogger
+	errors [][]interface{}
+}
+
+func (l *errorLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.errors = append(l.errors, keysAndValues)
+}
+
+func TestImportProductsUseCase_Execute_StableRowErrors(t *testing.T) {
+	dbErr := errors.New(`ERROR: relation "products" does not exist (SQLSTATE 42P01)`)
+	results := map[string]error{
+		"REF-001": fmt.Errorf("insert product: %w", dbErr),
+		"REF-002": repository.ErrProductDeleted,
+		"REF-003": entity.ErrDuplicateNameInCategory,
+	}
+	mockProductRepo := &MockProductRepository{
+		CreateFunc: func(ctx context.Context, product *entity.Product) error {
+			return results[product.ReferenceNumber]
+		},
+	}
+
+	logger := &errorLogger{}
+	uc := NewImportProductsUseCase(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, logger, ImportProductsOptions{})
+
+	report, err := uc.Execute(context.Background(), []port.ImportProductInput{
+		newImportRow("REF-001", "", ""),
+		newImportRow("REF-002", "", ""),
+		newImportRow("REF-003", "", ""),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := []string{
+		"failed to save product",
+		repository.ErrProductDeleted.Error(),
+		entity.ErrDuplicateNameInCategory.Error(),
+	}
+	if len(report.Errors) != len(want) {
+		t.Fatalf("Expected %d row errors, got %+v", len(want), report.Errors)
+	}
+	for i, message := range want {
+		if report.Errors[i].Error != message {
+			t.Errorf("Row %d: expected %q, got %q", i+1, message, report.Errors[i].Error)
+		}
+		if strings.Contains(report.Errors[i].Error, "SQLSTATE") {
+			t.Errorf("Row %d: expected the database error to stay out of the report", i+1)
+		}
+	}
+
+	if len(logger.errors) != 1 || !errors.Is(logger.errors[0][1].(error), dbErr) {
+		t.Errorf("Expected only the database error to be logged with its detail, got %v", logger.errors)
+	}
+}
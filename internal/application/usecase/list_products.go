@@ -2,18 +2,43 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/dowglassantana/product-redis-api/internal/application/port"
-	"github.com/dowglassantana/product-redis-api/internal/application/utils"
 	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
 	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
 )
 
+// repopulateTimeout limita a escrita de volta no cache após um miss parcial.
+const repopulateTimeout = 5 * time.Second
+
+// defaultListSort é a ordem do índice all_products.
+var defaultListSort = repository.SortOptions{Field: repository.SortByCreatedAt, Direction: repository.SortDesc}
+
+// filteredScanBatch é quantos IDs do índice all_products são lidos por vez
+// numa listagem filtrada servida pelo cache.
+const filteredScanBatch = 500
+
+// ListProductsOptions ajusta a listagem. FallbackRecorder recebe a latência
+// da consulta ao banco após um cache miss, e CacheRecorder conta as páginas
+// servidas pelo índice all_products (hit) ou pelo banco (miss); ordenações que
+// não passam pelo cache não são contadas. Background executa a repopulação
+// do cache após um miss parcial; nil usa uma goroutine sem acompanhamento.
+// Backfill, quando definido, é disparado ao encontrar o índice all_products
+// vazio, para que as próximas listagens sejam servidas pelo cache.
+type ListProductsOptions struct {
+	FallbackRecorder port.FallbackRecorder
+	CacheRecorder    port.CacheRecorder
+	Background       port.BackgroundRunner
+	Backfill         port.CacheBackfiller
+}
+
 type ListProductsUseCase struct {
 	productRepo repository.ProductRepository
 	cacheRepo   repository.CacheRepository
 	cacheKeys   port.CacheKeyGenerator
 	logger      port.Logger
+	options     ListProductsOptions
 }
 
 func NewListProductsUseCase(
@@ -22,27 +47,64 @@ func NewListProductsUseCase(
 	cacheKeys port.CacheKeyGenerator,
 	logger port.Logger,
 ) *ListProductsUseCase {
+	return NewListProductsUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, logger, ListProductsOptions{})
+}
+
+func NewListProductsUseCaseWithOptions(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+	options ListProductsOptions,
+) *ListProductsUseCase {
+	options.FallbackRecorder = fallbackRecorderOrNoop(options.FallbackRecorder)
+	options.CacheRecorder = cacheRecorderOrNoop(options.CacheRecorder)
+	options.Background = backgroundRunnerOrGo(options.Background)
+
 	return &ListProductsUseCase{
 		productRepo: productRepo,
 		cacheRepo:   cacheRepo,
 		cacheKeys:   cacheKeys,
 		logger:      logger,
+		options:     options,
 	}
 }
 
-func (uc *ListProductsUseCase) Execute(ctx context.Context, limit, offset int) ([]*entity.Product, error) {
+// Execute serve pelo cache apenas a ordem padrão (created_at decrescente), que
+// é a do índice all_products; as demais ordenações vão direto ao banco, já que
+// a janela do índice não serve para reordenar a listagem inteira. Com filtro,
+// o cache é percorrido em ordem e filtrado antes da paginação, para que a
+// página seja a mesma que o banco devolveria.
+func (uc *ListProductsUseCase) Execute(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 	uc.logger.Debug("listing products",
+		"sort", sort.Field,
+		"direction", sort.Direction,
 		"limit", limit,
 		"offset", offset,
 	)
 
-	products, cacheHit := uc.getFromCache(ctx)
-	if cacheHit && len(products) > 0 {
-		return utils.PaginateProducts(products, limit, offset), nil
+	var cached []*entity.Product
+	if sort.WithDefaults(repository.SortByCreatedAt, repository.SortDesc) == defaultListSort {
+		var cacheHit bool
+		if filter.IsZero() {
+			cached, cacheHit = uc.getFromCache(ctx, limit, offset)
+		} else {
+			cached, cacheHit = uc.getFilteredFromCache(ctx, filter, limit, offset)
+		}
+		if cacheHit {
+			observeCacheHit(ctx, uc.options.CacheRecorder, "list")
+			return cached, nil
+		}
+		observeCacheMiss(ctx, uc.options.CacheRecorder, "list")
+	} else {
+		// Outras ordenações não são cacheadas e sempre vão ao banco.
+		port.RecordCacheMiss(ctx)
 	}
 
 	uc.logger.Debug("fetching products from database")
-	products, err := uc.productRepo.FindAll(ctx, limit, offset)
+	start := time.Now()
+	products, err := uc.productRepo.FindAll(ctx, filter, sort, limit, offset)
+	uc.options.FallbackRecorder.ObserveDBFallback("list_products", time.Since(start))
 	if err != nil {
 		uc.logger.Error("failed to fetch products from database",
 			"error", err,
@@ -50,19 +112,77 @@ func (uc *ListProductsUseCase) Execute(ctx context.Context, limit, offset int) (
 		return nil, err
 	}
 
+	if cached != nil {
+		uc.repopulate(ctx, products, cached)
+	}
+
 	return products, nil
 }
 
-func (uc *ListProductsUseCase) getFromCache(ctx context.Context) ([]*entity.Product, bool) {
-	productIDs, err := uc.cacheRepo.GetSet(ctx, uc.cacheKeys.AllProductsKey())
+// repopulate grava em background as chaves individuais dos produtos que
+// faltavam no cache, para que a próxima leitura da página seja um hit. É
+// best-effort: falhas só são logadas e a resposta não espera as escritas.
+func (uc *ListProductsUseCase) repopulate(ctx context.Context, products, cached []*entity.Product) {
+	cachedIDs := make(map[string]struct{}, len(cached))
+	for _, product := range cached {
+		cachedIDs[product.ID] = struct{}{}
+	}
+
+	missing := make([]*entity.Product, 0, len(products))
+	for _, product := range products {
+		if _, ok := cachedIDs[product.ID]; !ok {
+			missing = append(missing, product)
+		}
+	}
+
+	if len(missing) == 0 {
+		return
+	}
+
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), repopulateTimeout)
+
+	uc.options.Background.Go(func() {
+		defer cancel()
+
+		for _, product := range missing {
+			if err := uc.cacheRepo.Set(writeCtx, uc.cacheKeys.ProductKey(product.ID), product); err != nil && !skippedCacheWrite(uc.logger, err, product) {
+				uc.logger.Warn("failed to repopulate product cache",
+					"error", err,
+					"product_id", product.HashID(),
+				)
+			}
+		}
+
+		uc.logger.Debug("repopulated missing products after partial cache miss",
+			"count", len(missing),
+		)
+	})
+}
+
+// getFromCache materializa apenas a janela solicitada do índice all_products,
+// que é um sorted set ordenado por data de criação (mesma ordem do FindAll).
+// Num miss parcial retorna os produtos encontrados (possivelmente nenhum, mas
+// não nil) com cacheHit false.
+func (uc *ListProductsUseCase) getFromCache(ctx context.Context, limit, offset int) ([]*entity.Product, bool) {
+	start := int64(offset)
+	stop := int64(offset + limit - 1)
+
+	productIDs, err := uc.cacheRepo.GetSortedSetRange(ctx, uc.cacheKeys.AllProductsKey(), start, stop)
 	if err != nil {
-		uc.logger.Debug("failed to get all_products set",
+		uc.logger.Debug("failed to get all_products range",
 			"error", err,
 		)
 		return nil, false
 	}
 
 	if len(productIDs) == 0 {
+		uc.triggerBackfill(ctx)
+		return nil, false
+	}
+
+	// Durante a reconstrução o índice só tem os produtos mais novos; uma
+	// página incompleta pode ser só a fronteira do que já foi indexado.
+	if len(productIDs) < limit && uc.options.Backfill != nil && uc.options.Backfill.Running() {
 		return nil, false
 	}
 
@@ -84,12 +204,101 @@ func (uc *ListProductsUseCase) getFromCache(ctx context.Context) ([]*entity.Prod
 			"expected", len(productIDs),
 			"got", len(products),
 		)
-		return nil, false
+		if products == nil {
+			products = []*entity.Product{}
+		}
+		return products, false
 	}
 
-	uc.logger.Debug("cache hit for all products",
+	uc.logger.Debug("cache hit for products page",
 		"count", len(products),
 	)
 
 	return products, true
 }
+
+// getFilteredFromCache percorre o índice all_products em lotes, na ordem do
+// FindAll, descartando os produtos fora do filtro e os primeiros offset que
+// passam nele. Qualquer entrada faltando no cache é um miss: sem ela não dá
+// para saber se o produto passaria no filtro. Um miss retorna nil, sem
+// repopulação, já que não há uma janela definida do índice a completar.
+func (uc *ListProductsUseCase) getFilteredFromCache(ctx context.Context, filter repository.ListFilter, limit, offset int) ([]*entity.Product, bool) {
+	if uc.options.Backfill != nil && uc.options.Backfill.Running() {
+		return nil, false
+	}
+
+	page := make([]*entity.Product, 0, limit)
+	skipped := 0
+	for start := int64(0); ; start += filteredScanBatch {
+		productIDs, err := uc.cacheRepo.GetSortedSetRange(ctx, uc.cacheKeys.AllProductsKey(), start, start+filteredScanBatch-1)
+		if err != nil {
+			uc.logger.Debug("failed to get all_products range",
+				"error", err,
+			)
+			return nil, false
+		}
+		if len(productIDs) == 0 {
+			if start == 0 {
+				uc.triggerBackfill(ctx)
+				return nil, false
+			}
+			break
+		}
+
+		keys := make([]string, len(productIDs))
+		for i, id := range productIDs {
+			keys[i] = uc.cacheKeys.ProductKey(id)
+		}
+
+		products, err := uc.cacheRepo.GetMultiple(ctx, keys)
+		if err != nil || len(products) < len(productIDs) {
+			uc.logger.Debug("incomplete cache for filtered listing",
+				"error", err,
+				"expected", len(productIDs),
+				"got", len(products),
+			)
+			return nil, false
+		}
+
+		for _, product := range products {
+			if !filter.Matches(product) {
+				continue
+			}
+			if skipped < offset {
+				skipped++
+				continue
+			}
+			page = append(page, product)
+			if len(page) == limit {
+				return page, true
+			}
+		}
+
+		if len(productIDs) < filteredScanBatch {
+			break
+		}
+	}
+
+	uc.logger.Debug("cache hit for filtered products page",
+		"count", len(page),
+	)
+
+	return page, true
+}
+
+// triggerBackfill dispara a reconstrução do índice quando all_products não
+// existe. Uma janela vazia com o índice presente é só um offset além do fim.
+func (uc *ListProductsUseCase) triggerBackfill(ctx context.Context) {
+	if uc.options.Backfill == nil {
+		return
+	}
+
+	exists, err := uc.cacheRepo.Exists(ctx, uc.cacheKeys.AllProductsKey())
+	if err != nil || exists {
+		return
+	}
+
+	if uc.options.Backfill.Trigger() {
+		uc.logger.Info("all_products index is empty - started cache backfill")
+	}
+}
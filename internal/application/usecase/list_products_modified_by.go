@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// ListProductsModifiedByUseCase lista os produtos alterados por último por um
+// usuário. A trilha de auditoria só existe no banco, então não há cache.
+type ListProductsModifiedByUseCase struct {
+	productRepo repository.ProductRepository
+	logger      port.Logger
+}
+
+func NewListProductsModifiedByUseCase(productRepo repository.ProductRepository, logger port.Logger) *ListProductsModifiedByUseCase {
+	return &ListProductsModifiedByUseCase{
+		productRepo: productRepo,
+		logger:      logger,
+	}
+}
+
+func (uc *ListProductsModifiedByUseCase) Execute(ctx context.Context, subject string, limit, offset int) ([]*entity.Product, error) {
+	uc.logger.Debug("listing products modified by subject",
+		"subject", subject,
+		"limit", limit,
+		"offset", offset,
+	)
+
+	products, err := uc.productRepo.FindModifiedBy(ctx, subject, limit, offset)
+	if err != nil {
+		uc.logger.Error("failed to list products modified by subject",
+			"error", err,
+			"subject", subject,
+		)
+		return nil, err
+	}
+
+	return products, nil
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+)
+
+func TestListProductsModifiedByUseCase_Execute(t *testing.T) {
+	products := []*entity.Product{newTestProductWithData("iPhone 15", "REF-001", "Smartphones")}
+
+	mockProductRepo := &MockProductRepository{
+		FindModifiedByFunc: func(ctx context.Context, subject string, limit, offset int) ([]*entity.Product, error) {
+			if subject != "user-1" || limit != 10 || offset != 20 {
+				t.Errorf("Unexpected arguments: subject=%q limit=%d offset=%d", subject, limit, offset)
+			}
+			return products, nil
+		},
+	}
+
+	uc := NewListProductsModifiedByUseCase(mockProductRepo, &MockLogger{})
+
+	result, err := uc.Execute(context.Background(), "user-1", 10, 20)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result) != 1 || result[0].ID != products[0].ID {
+		t.Errorf("Expected the repository result, got %v", result)
+	}
+}
+
+func TestListProductsModifiedByUseCase_Execute_DatabaseError(t *testing.T) {
+	dbErr := errors.New("connection refused")
+	mockProductRepo := &MockProductRepository{
+		FindModifiedByFunc: func(ctx context.Context, subject string, limit, offset int) ([]*entity.Product, error) {
+			return nil, dbErr
+		},
+	}
+
+	uc := NewListProductsModifiedByUseCase(mockProductRepo, &MockLogger{})
+
+	if _, err := uc.Execute(context.Background(), "user-1", 10, 0); !errors.Is(err, dbErr) {
+		t.Errorf("Expected database error, got %v", err)
+	}
+}
@@ -3,9 +3,12 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
 )
 
 func TestListProductsUseCase_Execute_CacheHit(t *testing.T) {
@@ -17,7 +20,7 @@ func TestListProductsUseCase_Execute_CacheHit(t *testing.T) {
 
 	mockProductRepo := &MockProductRepository{}
 	mockCacheRepo := &MockCacheRepository{
-		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+		GetSortedSetRangeFunc: func(ctx context.Context, setKey string, start, stop int64) ([]string, error) {
 			return []string{products[0].ID, products[1].ID, products[2].ID}, nil
 		},
 		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
@@ -29,7 +32,7 @@ func TestListProductsUseCase_Execute_CacheHit(t *testing.T) {
 	logger := &MockLogger{}
 	uc := NewListProductsUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), 10, 0)
+	result, err := uc.Execute(context.Background(), repository.ListFilter{}, repository.SortOptions{}, 10, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -49,14 +52,14 @@ func TestListProductsUseCase_Execute_CacheMiss_DatabaseSuccess(t *testing.T) {
 	dbCalled := false
 
 	mockProductRepo := &MockProductRepository{
-		FindAllFunc: func(ctx context.Context, limit, offset int) ([]*entity.Product, error) {
+		FindAllFunc: func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 			dbCalled = true
 			return products, nil
 		},
 	}
 
 	mockCacheRepo := &MockCacheRepository{
-		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+		GetSortedSetRangeFunc: func(ctx context.Context, setKey string, start, stop int64) ([]string, error) {
 			return []string{}, nil
 		},
 	}
@@ -65,7 +68,7 @@ func TestListProductsUseCase_Execute_CacheMiss_DatabaseSuccess(t *testing.T) {
 	logger := &MockLogger{}
 	uc := NewListProductsUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), 10, 0)
+	result, err := uc.Execute(context.Background(), repository.ListFilter{}, repository.SortOptions{}, 10, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -84,13 +87,13 @@ func TestListProductsUseCase_Execute_DatabaseError(t *testing.T) {
 	dbError := errors.New("database error")
 
 	mockProductRepo := &MockProductRepository{
-		FindAllFunc: func(ctx context.Context, limit, offset int) ([]*entity.Product, error) {
+		FindAllFunc: func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 			return nil, dbError
 		},
 	}
 
 	mockCacheRepo := &MockCacheRepository{
-		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+		GetSortedSetRangeFunc: func(ctx context.Context, setKey string, start, stop int64) ([]string, error) {
 			return []string{}, nil
 		},
 	}
@@ -99,7 +102,7 @@ func TestListProductsUseCase_Execute_DatabaseError(t *testing.T) {
 	logger := &MockLogger{}
 	uc := NewListProductsUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), 10, 0)
+	result, err := uc.Execute(context.Background(), repository.ListFilter{}, repository.SortOptions{}, 10, 0)
 
 	if err == nil {
 		t.Error("Expected error, got nil")
@@ -118,14 +121,14 @@ func TestListProductsUseCase_Execute_CacheError_FallbackToDatabase(t *testing.T)
 	dbCalled := false
 
 	mockProductRepo := &MockProductRepository{
-		FindAllFunc: func(ctx context.Context, limit, offset int) ([]*entity.Product, error) {
+		FindAllFunc: func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 			dbCalled = true
 			return products, nil
 		},
 	}
 
 	mockCacheRepo := &MockCacheRepository{
-		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+		GetSortedSetRangeFunc: func(ctx context.Context, setKey string, start, stop int64) ([]string, error) {
 			return nil, errors.New("cache error")
 		},
 	}
@@ -134,7 +137,7 @@ func TestListProductsUseCase_Execute_CacheError_FallbackToDatabase(t *testing.T)
 	logger := &MockLogger{}
 	uc := NewListProductsUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), 10, 0)
+	result, err := uc.Execute(context.Background(), repository.ListFilter{}, repository.SortOptions{}, 10, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -157,14 +160,14 @@ func TestListProductsUseCase_Execute_PartialCacheMiss(t *testing.T) {
 	dbCalled := false
 
 	mockProductRepo := &MockProductRepository{
-		FindAllFunc: func(ctx context.Context, limit, offset int) ([]*entity.Product, error) {
+		FindAllFunc: func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 			dbCalled = true
 			return products, nil
 		},
 	}
 
 	mockCacheRepo := &MockCacheRepository{
-		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+		GetSortedSetRangeFunc: func(ctx context.Context, setKey string, start, stop int64) ([]string, error) {
 			return []string{"id1", "id2", "id3"}, nil
 		},
 		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
@@ -176,7 +179,7 @@ func TestListProductsUseCase_Execute_PartialCacheMiss(t *testing.T) {
 	logger := &MockLogger{}
 	uc := NewListProductsUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), 10, 0)
+	result, err := uc.Execute(context.Background(), repository.ListFilter{}, repository.SortOptions{}, 10, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -202,15 +205,23 @@ func TestListProductsUseCase_Execute_Pagination(t *testing.T) {
 
 	mockProductRepo := &MockProductRepository{}
 	mockCacheRepo := &MockCacheRepository{
-		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
-			ids := make([]string, len(products))
-			for i, p := range products {
-				ids[i] = p.ID
+		GetSortedSetRangeFunc: func(ctx context.Context, setKey string, start, stop int64) ([]string, error) {
+			ids := make([]string, 0, len(products))
+			for i := start; i <= stop && i < int64(len(products)); i++ {
+				ids = append(ids, products[i].ID)
 			}
 			return ids, nil
 		},
 		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
-			return products, nil
+			result := make([]*entity.Product, 0, len(keys))
+			for _, key := range keys {
+				for _, p := range products {
+					if key == "product_"+p.ID {
+						result = append(result, p)
+					}
+				}
+			}
+			return result, nil
 		},
 	}
 
@@ -218,7 +229,7 @@ func TestListProductsUseCase_Execute_Pagination(t *testing.T) {
 	logger := &MockLogger{}
 	uc := NewListProductsUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), 2, 0)
+	result, err := uc.Execute(context.Background(), repository.ListFilter{}, repository.SortOptions{}, 2, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -228,7 +239,7 @@ func TestListProductsUseCase_Execute_Pagination(t *testing.T) {
 		t.Errorf("Expected 2 products with limit=2, got %d", len(result))
 	}
 
-	result, err = uc.Execute(context.Background(), 2, 2)
+	result, err = uc.Execute(context.Background(), repository.ListFilter{}, repository.SortOptions{}, 2, 2)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -237,17 +248,63 @@ func TestListProductsUseCase_Execute_Pagination(t *testing.T) {
 	if len(result) != 2 {
 		t.Errorf("Expected 2 products with limit=2 offset=2, got %d", len(result))
 	}
+
+	if result[0].ID != products[2].ID {
+		t.Errorf("Expected page to start at product 3, got %s", result[0].Name)
+	}
+}
+
+func TestListProductsUseCase_Execute_RequestsOnlyPageWindow(t *testing.T) {
+	var gotStart, gotStop int64
+	var gotKeys []string
+
+	mockProductRepo := &MockProductRepository{}
+	mockCacheRepo := &MockCacheRepository{
+		GetSortedSetRangeFunc: func(ctx context.Context, setKey string, start, stop int64) ([]string, error) {
+			gotStart, gotStop = start, stop
+			return []string{"id21", "id22"}, nil
+		},
+		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
+			gotKeys = keys
+			return []*entity.Product{
+				newTestProductWithData("Product 21", "REF-021", "Category"),
+				newTestProductWithData("Product 22", "REF-022", "Category"),
+			}, nil
+		},
+	}
+
+	mockCacheKeys := &MockCacheKeyGenerator{}
+	logger := &MockLogger{}
+	uc := NewListProductsUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
+
+	result, err := uc.Execute(context.Background(), repository.ListFilter{}, repository.SortOptions{}, 10, 20)
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if gotStart != 20 || gotStop != 29 {
+		t.Errorf("Expected range [20, 29], got [%d, %d]", gotStart, gotStop)
+	}
+
+	if len(gotKeys) != 2 {
+		t.Errorf("Expected only the page keys to be fetched, got %d", len(gotKeys))
+	}
+
+	if len(result) != 2 {
+		t.Errorf("Expected 2 products, got %d", len(result))
+	}
 }
 
 func TestListProductsUseCase_Execute_EmptyResult(t *testing.T) {
 	mockProductRepo := &MockProductRepository{
-		FindAllFunc: func(ctx context.Context, limit, offset int) ([]*entity.Product, error) {
+		FindAllFunc: func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 			return []*entity.Product{}, nil
 		},
 	}
 
 	mockCacheRepo := &MockCacheRepository{
-		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+		GetSortedSetRangeFunc: func(ctx context.Context, setKey string, start, stop int64) ([]string, error) {
 			return []string{}, nil
 		},
 	}
@@ -256,7 +313,7 @@ func TestListProductsUseCase_Execute_EmptyResult(t *testing.T) {
 	logger := &MockLogger{}
 	uc := NewListProductsUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), 10, 0)
+	result, err := uc.Execute(context.Background(), repository.ListFilter{}, repository.SortOptions{}, 10, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -275,14 +332,14 @@ func TestListProductsUseCase_Execute_GetMultipleError(t *testing.T) {
 	dbCalled := false
 
 	mockProductRepo := &MockProductRepository{
-		FindAllFunc: func(ctx context.Context, limit, offset int) ([]*entity.Product, error) {
+		FindAllFunc: func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 			dbCalled = true
 			return products, nil
 		},
 	}
 
 	mockCacheRepo := &MockCacheRepository{
-		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+		GetSortedSetRangeFunc: func(ctx context.Context, setKey string, start, stop int64) ([]string, error) {
 			return []string{"id1"}, nil
 		},
 		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
@@ -294,7 +351,7 @@ func TestListProductsUseCase_Execute_GetMultipleError(t *testing.T) {
 	logger := &MockLogger{}
 	uc := NewListProductsUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), 10, 0)
+	result, err := uc.Execute(context.Background(), repository.ListFilter{}, repository.SortOptions{}, 10, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -308,3 +365,278 @@ func TestListProductsUseCase_Execute_GetMultipleError(t *testing.T) {
 		t.Errorf("Expected 1 product, got %d", len(result))
 	}
 }
+
+func TestListProductsUseCase_Execute_PartialCacheMiss_RepopulatesMissing(t *testing.T) {
+	products := []*entity.Product{
+		newTestProductWithData("Product 1", "REF-001", "Category"),
+		newTestProductWithData("Product 2", "REF-002", "Category"),
+		newTestProductWithData("Product 3", "REF-003", "Category"),
+	}
+
+	mockProductRepo := &MockProductRepository{
+		FindAllFunc: func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			return products, nil
+		},
+	}
+
+	written := make(chan string, len(products))
+
+	mockCacheRepo := &MockCacheRepository{
+		GetSortedSetRangeFunc: func(ctx context.Context, setKey string, start, stop int64) ([]string, error) {
+			return []string{products[0].ID, products[1].ID, products[2].ID}, nil
+		},
+		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
+			return []*entity.Product{products[0]}, nil
+		},
+		SetFunc: func(ctx context.Context, key string, product *entity.Product) error {
+			written <- key
+			return nil
+		},
+	}
+
+	mockCacheKeys := &MockCacheKeyGenerator{}
+	logger := &MockLogger{}
+	uc := NewListProductsUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
+
+	result, err := uc.Execute(context.Background(), repository.ListFilter{}, repository.SortOptions{}, 10, 0)
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if len(result) != 3 {
+		t.Errorf("Expected 3 products, got %d", len(result))
+	}
+
+	got := map[string]bool{}
+	for len(got) < 2 {
+		select {
+		case key := <-written:
+			got[key] = true
+		case <-time.After(time.Second):
+			t.Fatalf("Expected missing products to be written back, got %v", got)
+		}
+	}
+
+	if got["product_"+products[0].ID] {
+		t.Error("Expected product already in cache not to be rewritten")
+	}
+
+	if !got["product_"+products[1].ID] || !got["product_"+products[2].ID] {
+		t.Errorf("Expected missing products to be cached, got %v", got)
+	}
+}
+
+func TestListProductsUseCase_Execute_CustomSortSkipsCache(t *testing.T) {
+	products := []*entity.Product{
+		newTestProductWithData("Product 1", "REF-001", "Category"),
+	}
+	sort := repository.SortOptions{Field: repository.SortByStock, Direction: repository.SortDesc}
+
+	var gotSort repository.SortOptions
+	mockProductRepo := &MockProductRepository{
+		FindAllFunc: func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			gotSort = sort
+			return products, nil
+		},
+	}
+
+	cacheCalled := false
+	mockCacheRepo := &MockCacheRepository{
+		GetSortedSetRangeFunc: func(ctx context.Context, setKey string, start, stop int64) ([]string, error) {
+			cacheCalled = true
+			return []string{products[0].ID}, nil
+		},
+	}
+
+	uc := NewListProductsUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	result, err := uc.Execute(context.Background(), repository.ListFilter{}, sort, 10, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if cacheCalled {
+		t.Error("Expected the all_products index to be skipped for a custom sort")
+	}
+	if gotSort != sort {
+		t.Errorf("Expected sort %+v to reach the repository, got %+v", sort, gotSort)
+	}
+	if len(result) != 1 {
+		t.Errorf("Expected 1 product, got %d", len(result))
+	}
+}
+
+func TestListProductsUseCase_Execute_ExplicitDefaultSortUsesCache(t *testing.T) {
+	products := []*entity.Product{
+		newTestProductWithData("Product 1", "REF-001", "Category"),
+	}
+
+	mockProductRepo := &MockProductRepository{
+		FindAllFunc: func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			t.Error("Expected the database not to be called")
+			return nil, nil
+		},
+	}
+	mockCacheRepo := &MockCacheRepository{
+		GetSortedSetRangeFunc: func(ctx context.Context, setKey string, start, stop int64) ([]string, error) {
+			return []string{products[0].ID}, nil
+		},
+		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
+			return products, nil
+		},
+	}
+
+	uc := NewListProductsUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	sort := repository.SortOptions{Field: repository.SortByCreatedAt, Direction: repository.SortDesc}
+	if _, err := uc.Execute(context.Background(), repository.ListFilter{}, sort, 10, 0); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestListProductsUseCase_Execute_RecordsCacheHitAndMiss(t *testing.T) {
+	products := []*entity.Product{
+		newTestProductWithData("Product 1", "REF-001", "Category"),
+	}
+	cached := false
+
+	mockProductRepo := &MockProductRepository{
+		FindAllFunc: func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			return products, nil
+		},
+	}
+	mockCacheRepo := &MockCacheRepository{
+		GetSortedSetRangeFunc: func(ctx context.Context, setKey string, start, stop int64) ([]string, error) {
+			if !cached {
+				return []string{}, nil
+			}
+			return []string{products[0].ID}, nil
+		},
+		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
+			return products, nil
+		},
+	}
+
+	recorder := &MockCacheRecorder{}
+	uc := NewListProductsUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, ListProductsOptions{
+		CacheRecorder: recorder,
+	})
+
+	if _, err := uc.Execute(context.Background(), repository.ListFilter{}, repository.SortOptions{}, 10, 0); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	cached = true
+	if _, err := uc.Execute(context.Background(), repository.ListFilter{}, repository.SortOptions{}, 10, 0); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := uc.Execute(context.Background(), repository.ListFilter{}, repository.SortOptions{Field: repository.SortByStock}, 10, 0); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(recorder.Misses) != 1 || recorder.Misses[0] != "list" {
+		t.Errorf("Expected a single list miss, got %v", recorder.Misses)
+	}
+	if len(recorder.Hits) != 1 || recorder.Hits[0] != "list" {
+		t.Errorf("Expected a single list hit (custom sorts skip the cache), got %v", recorder.Hits)
+	}
+}
+
+// sortedSetIndex simula o índice all_products sobre os produtos, na ordem.
+func sortedSetIndex(products []*entity.Product) (func(ctx context.Context, setKey string, start, stop int64) ([]string, error), func(ctx context.Context, keys []string) ([]*entity.Product, error)) {
+	byKey := make(map[string]*entity.Product, len(products))
+	for _, product := range products {
+		byKey["product_"+product.ID] = product
+	}
+
+	rangeFunc := func(ctx context.Context, setKey string, start, stop int64) ([]string, error) {
+		ids := []string{}
+		for i := start; i <= stop && i < int64(len(products)); i++ {
+			ids = append(ids, products[i].ID)
+		}
+		return ids, nil
+	}
+	multipleFunc := func(ctx context.Context, keys []string) ([]*entity.Product, error) {
+		found := make([]*entity.Product, 0, len(keys))
+		for _, key := range keys {
+			if product, ok := byKey[key]; ok {
+				found = append(found, product)
+			}
+		}
+		return found, nil
+	}
+	return rangeFunc, multipleFunc
+}
+
+func TestListProductsUseCase_Execute_FilteredCacheHit(t *testing.T) {
+	products := make([]*entity.Product, 6)
+	for i := range products {
+		products[i] = newTestProductWithData(fmt.Sprintf("Product %d", i), fmt.Sprintf("REF-%03d", i), "Category")
+		products[i].Stock = i % 2
+	}
+
+	rangeFunc, multipleFunc := sortedSetIndex(products)
+	mockProductRepo := &MockProductRepository{
+		FindAllFunc: func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			t.Error("Expected filtered listing to be served by the cache")
+			return nil, nil
+		},
+	}
+	mockCacheRepo := &MockCacheRepository{
+		GetSortedSetRangeFunc: rangeFunc,
+		GetMultipleFunc:       multipleFunc,
+	}
+	uc := NewListProductsUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	inStock, outOfStock := true, false
+
+	result, err := uc.Execute(context.Background(), repository.ListFilter{InStock: &inStock}, repository.SortOptions{}, 2, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result) != 2 || result[0] != products[3] || result[1] != products[5] {
+		t.Errorf("Expected the second and third in-stock products, got %v", result)
+	}
+
+	result, err = uc.Execute(context.Background(), repository.ListFilter{InStock: &outOfStock}, repository.SortOptions{}, 10, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result) != 3 || result[0] != products[0] || result[1] != products[2] || result[2] != products[4] {
+		t.Errorf("Expected the out-of-stock products in index order, got %v", result)
+	}
+}
+
+func TestListProductsUseCase_Execute_FilteredPartialMissUsesDatabase(t *testing.T) {
+	cached := newTestProductWithData("Cached", "REF-001", "Category")
+	cached.Stock = 5
+	evicted := newTestProductWithData("Evicted", "REF-002", "Category")
+
+	rangeFunc, _ := sortedSetIndex([]*entity.Product{cached, evicted})
+	var queried repository.ListFilter
+	mockProductRepo := &MockProductRepository{
+		FindAllFunc: func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			queried = filter
+			return []*entity.Product{cached}, nil
+		},
+	}
+	mockCacheRepo := &MockCacheRepository{
+		GetSortedSetRangeFunc: rangeFunc,
+		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
+			return []*entity.Product{cached}, nil
+		},
+	}
+	uc := NewListProductsUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	inStock := true
+	result, err := uc.Execute(context.Background(), repository.ListFilter{InStock: &inStock}, repository.SortOptions{}, 10, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if queried.InStock == nil || !*queried.InStock {
+		t.Errorf("Expected filter passed to the database, got %+v", queried)
+	}
+	if len(result) != 1 || result[0] != cached {
+		t.Errorf("Expected database result, got %v", result)
+	}
+}
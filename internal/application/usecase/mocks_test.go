@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
 	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
@@ -9,12 +10,28 @@ import (
 
 type MockProductRepository struct {
 	CreateFunc       func(ctx context.Context, product *entity.Product) error
+	CreateBatchFunc  func(ctx context.Context, products []*entity.Product) ([]error, error)
 	UpdateFunc       func(ctx context.Context, product *entity.Product, expectedVersion int) error
+	UpdateStockFunc  func(ctx context.Context, id string, stock int) (repository.Revision, error)
 	DeleteFunc       func(ctx context.Context, id string) error
+	DeleteIfVersionFunc func(ctx context.Context, id string, version int) error
+	RestoreFunc      func(ctx context.Context, id string) error
+	FindTombstoneFunc func(ctx context.Context, id string) (*entity.Tombstone, error)
 	FindByIDFunc     func(ctx context.Context, id string) (*entity.Product, error)
-	FindAllFunc      func(ctx context.Context, limit, offset int) ([]*entity.Product, error)
-	FindByCategoryFunc func(ctx context.Context, category string, limit, offset int) ([]*entity.Product, error)
-	FindByNameFunc   func(ctx context.Context, name string, limit, offset int) ([]*entity.Product, error)
+	FindByIDsFunc    func(ctx context.Context, ids []string) ([]*entity.Product, error)
+	FindAllFunc      func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error)
+	FindByCategoryFunc func(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error)
+	FindByNameFunc   func(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error)
+	FindByTagFunc    func(ctx context.Context, tag string, limit, offset int) ([]*entity.Product, error)
+	FindBySKUFunc    func(ctx context.Context, sku string) (*entity.Product, int, error)
+	FindModifiedByFunc func(ctx context.Context, subject string, limit, offset int) ([]*entity.Product, error)
+	CountFunc           func(ctx context.Context, filter repository.ListFilter) (int, error)
+	CountByNameFunc     func(ctx context.Context, name string, filter repository.ListFilter) (int, error)
+	CountByCategoryFunc func(ctx context.Context, category string, filter repository.ListFilter) (int, error)
+	CountByTagFunc      func(ctx context.Context, tag string) (int, error)
+	AddTagFunc       func(ctx context.Context, id, tag string) (bool, repository.Revision, error)
+	AdjustStockFunc  func(ctx context.Context, id string, delta int) (int, repository.Revision, error)
+	RemoveTagFunc    func(ctx context.Context, id, tag string) (bool, repository.Revision, error)
 	ExistsFunc       func(ctx context.Context, id string) (bool, error)
 	HealthCheckFunc  func(ctx context.Context) error
 }
@@ -26,6 +43,13 @@ func (m *MockProductRepository) Create(ctx context.Context, product *entity.Prod
 	return nil
 }
 
+func (m *MockProductRepository) CreateBatch(ctx context.Context, products []*entity.Product) ([]error, error) {
+	if m.CreateBatchFunc != nil {
+		return m.CreateBatchFunc(ctx, products)
+	}
+	return make([]error, len(products)), nil
+}
+
 func (m *MockProductRepository) Update(ctx context.Context, product *entity.Product, expectedVersion int) error {
 	if m.UpdateFunc != nil {
 		return m.UpdateFunc(ctx, product, expectedVersion)
@@ -33,6 +57,13 @@ func (m *MockProductRepository) Update(ctx context.Context, product *entity.Prod
 	return nil
 }
 
+func (m *MockProductRepository) UpdateStock(ctx context.Context, id string, stock int) (repository.Revision, error) {
+	if m.UpdateStockFunc != nil {
+		return m.UpdateStockFunc(ctx, id, stock)
+	}
+	return repository.Revision{Version: 2}, nil
+}
+
 func (m *MockProductRepository) Delete(ctx context.Context, id string) error {
 	if m.DeleteFunc != nil {
 		return m.DeleteFunc(ctx, id)
@@ -40,6 +71,27 @@ func (m *MockProductRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+func (m *MockProductRepository) DeleteIfVersion(ctx context.Context, id string, version int) error {
+	if m.DeleteIfVersionFunc != nil {
+		return m.DeleteIfVersionFunc(ctx, id, version)
+	}
+	return nil
+}
+
+func (m *MockProductRepository) Restore(ctx context.Context, id string) error {
+	if m.RestoreFunc != nil {
+		return m.RestoreFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockProductRepository) FindTombstone(ctx context.Context, id string) (*entity.Tombstone, error) {
+	if m.FindTombstoneFunc != nil {
+		return m.FindTombstoneFunc(ctx, id)
+	}
+	return nil, repository.ErrProductNotFound
+}
+
 func (m *MockProductRepository) FindByID(ctx context.Context, id string) (*entity.Product, error) {
 	if m.FindByIDFunc != nil {
 		return m.FindByIDFunc(ctx, id)
@@ -47,27 +99,104 @@ func (m *MockProductRepository) FindByID(ctx context.Context, id string) (*entit
 	return nil, repository.ErrProductNotFound
 }
 
-func (m *MockProductRepository) FindAll(ctx context.Context, limit, offset int) ([]*entity.Product, error) {
+func (m *MockProductRepository) FindByIDs(ctx context.Context, ids []string) ([]*entity.Product, error) {
+	if m.FindByIDsFunc != nil {
+		return m.FindByIDsFunc(ctx, ids)
+	}
+	return []*entity.Product{}, nil
+}
+
+func (m *MockProductRepository) FindAll(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 	if m.FindAllFunc != nil {
-		return m.FindAllFunc(ctx, limit, offset)
+		return m.FindAllFunc(ctx, filter, sort, limit, offset)
 	}
 	return []*entity.Product{}, nil
 }
 
-func (m *MockProductRepository) FindByCategory(ctx context.Context, category string, limit, offset int) ([]*entity.Product, error) {
+func (m *MockProductRepository) FindByCategory(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 	if m.FindByCategoryFunc != nil {
-		return m.FindByCategoryFunc(ctx, category, limit, offset)
+		return m.FindByCategoryFunc(ctx, category, filter, sort, limit, offset)
 	}
 	return []*entity.Product{}, nil
 }
 
-func (m *MockProductRepository) FindByName(ctx context.Context, name string, limit, offset int) ([]*entity.Product, error) {
+func (m *MockProductRepository) FindByName(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 	if m.FindByNameFunc != nil {
-		return m.FindByNameFunc(ctx, name, limit, offset)
+		return m.FindByNameFunc(ctx, name, filter, order, sort, limit, offset)
+	}
+	return []*entity.Product{}, nil
+}
+
+func (m *MockProductRepository) FindByTag(ctx context.Context, tag string, limit, offset int) ([]*entity.Product, error) {
+	if m.FindByTagFunc != nil {
+		return m.FindByTagFunc(ctx, tag, limit, offset)
 	}
 	return []*entity.Product{}, nil
 }
 
+func (m *MockProductRepository) FindBySKU(ctx context.Context, sku string) (*entity.Product, int, error) {
+	if m.FindBySKUFunc != nil {
+		return m.FindBySKUFunc(ctx, sku)
+	}
+	return nil, 0, repository.ErrProductNotFound
+}
+
+func (m *MockProductRepository) FindModifiedBy(ctx context.Context, subject string, limit, offset int) ([]*entity.Product, error) {
+	if m.FindModifiedByFunc != nil {
+		return m.FindModifiedByFunc(ctx, subject, limit, offset)
+	}
+	return []*entity.Product{}, nil
+}
+
+func (m *MockProductRepository) Count(ctx context.Context, filter repository.ListFilter) (int, error) {
+	if m.CountFunc != nil {
+		return m.CountFunc(ctx, filter)
+	}
+	return 0, nil
+}
+
+func (m *MockProductRepository) CountByName(ctx context.Context, name string, filter repository.ListFilter) (int, error) {
+	if m.CountByNameFunc != nil {
+		return m.CountByNameFunc(ctx, name, filter)
+	}
+	return 0, nil
+}
+
+func (m *MockProductRepository) CountByCategory(ctx context.Context, category string, filter repository.ListFilter) (int, error) {
+	if m.CountByCategoryFunc != nil {
+		return m.CountByCategoryFunc(ctx, category, filter)
+	}
+	return 0, nil
+}
+
+func (m *MockProductRepository) CountByTag(ctx context.Context, tag string) (int, error) {
+	if m.CountByTagFunc != nil {
+		return m.CountByTagFunc(ctx, tag)
+	}
+	return 0, nil
+}
+
+func (m *MockProductRepository) AdjustStock(ctx context.Context, id string, delta int) (int, repository.Revision, error) {
+	if m.AdjustStockFunc != nil {
+		return m.AdjustStockFunc(ctx, id, delta)
+	}
+	return delta, repository.Revision{Version: 2}, nil
+}
+
+func (m *MockProductRepository) AddTag(ctx context.Context, id, tag string) (bool, repository.Revision, error) {
+	if m.AddTagFunc != nil {
+		return m.AddTagFunc(ctx, id, tag)
+	}
+	return true, repository.Revision{Version: 2}, nil
+}
+
+func (m *MockProductRepository) RemoveTag(ctx context.Context, id, tag string) (bool, repository.Revision, error) {
+	if m.RemoveTagFunc != nil {
+		return m.RemoveTagFunc(ctx, id, tag)
+	}
+	return true, repository.Revision{Version: 2}, nil
+}
+
 func (m *MockProductRepository) Exists(ctx context.Context, id string) (bool, error) {
 	if m.ExistsFunc != nil {
 		return m.ExistsFunc(ctx, id)
@@ -84,14 +213,24 @@ func (m *MockProductRepository) HealthCheck(ctx context.Context) error {
 
 type MockCacheRepository struct {
 	GetFunc           func(ctx context.Context, key string) (*entity.Product, error)
+	GetEntryFunc      func(ctx context.Context, key string) (*repository.CacheEntry, error)
 	SetFunc           func(ctx context.Context, key string, product *entity.Product) error
+	SetWithTTLFunc    func(ctx context.Context, key string, product *entity.Product, ttl time.Duration) error
 	DeleteFunc        func(ctx context.Context, key string) error
+	PatchStockFunc    func(ctx context.Context, key string, stock int, revision repository.Revision) error
 	AddToSetFunc      func(ctx context.Context, setKey, productID string) error
+	AddAllToSetFunc   func(ctx context.Context, setKey string, productIDs []string) error
 	RemoveFromSetFunc func(ctx context.Context, setKey, productID string) error
 	GetSetFunc        func(ctx context.Context, setKey string) ([]string, error)
+	AddToSortedSetFunc      func(ctx context.Context, setKey, productID string, score float64) error
+	RemoveFromSortedSetFunc func(ctx context.Context, setKey, productID string) error
+	GetSortedSetRangeFunc   func(ctx context.Context, setKey string, start, stop int64) ([]string, error)
+	SampleSortedSetFunc   func(ctx context.Context, setKey string, count int) ([]string, error)
 	GetMultipleFunc   func(ctx context.Context, keys []string) ([]*entity.Product, error)
 	ExistsFunc        func(ctx context.Context, key string) (bool, error)
 	DeleteSetFunc     func(ctx context.Context, setKey string) error
+	InvalidateProductFunc func(ctx context.Context, productKey, productID string, setKeys, sortedSetKeys []string) (int, error)
+	FlushCacheFunc    func(ctx context.Context) (int, error)
 	HealthCheckFunc   func(ctx context.Context) error
 }
 
@@ -102,6 +241,19 @@ func (m *MockCacheRepository) Get(ctx context.Context, key string) (*entity.Prod
 	return nil, repository.ErrCacheNotFound
 }
 
+// GetEntry usa GetFunc quando GetEntryFunc não é definido, tratando o produto
+// como recém-gravado.
+func (m *MockCacheRepository) GetEntry(ctx context.Context, key string) (*repository.CacheEntry, error) {
+	if m.GetEntryFunc != nil {
+		return m.GetEntryFunc(ctx, key)
+	}
+	product, err := m.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return &repository.CacheEntry{Product: product, CachedAt: time.Now()}, nil
+}
+
 func (m *MockCacheRepository) Set(ctx context.Context, key string, product *entity.Product) error {
 	if m.SetFunc != nil {
 		return m.SetFunc(ctx, key, product)
@@ -109,6 +261,13 @@ func (m *MockCacheRepository) Set(ctx context.Context, key string, product *enti
 	return nil
 }
 
+func (m *MockCacheRepository) SetWithTTL(ctx context.Context, key string, product *entity.Product, ttl time.Duration) error {
+	if m.SetWithTTLFunc != nil {
+		return m.SetWithTTLFunc(ctx, key, product, ttl)
+	}
+	return nil
+}
+
 func (m *MockCacheRepository) Delete(ctx context.Context, key string) error {
 	if m.DeleteFunc != nil {
 		return m.DeleteFunc(ctx, key)
@@ -116,6 +275,13 @@ func (m *MockCacheRepository) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
+func (m *MockCacheRepository) PatchStock(ctx context.Context, key string, stock int, revision repository.Revision) error {
+	if m.PatchStockFunc != nil {
+		return m.PatchStockFunc(ctx, key, stock, revision)
+	}
+	return nil
+}
+
 func (m *MockCacheRepository) AddToSet(ctx context.Context, setKey, productID string) error {
 	if m.AddToSetFunc != nil {
 		return m.AddToSetFunc(ctx, setKey, productID)
@@ -123,6 +289,13 @@ func (m *MockCacheRepository) AddToSet(ctx context.Context, setKey, productID st
 	return nil
 }
 
+func (m *MockCacheRepository) AddAllToSet(ctx context.Context, setKey string, productIDs []string) error {
+	if m.AddAllToSetFunc != nil {
+		return m.AddAllToSetFunc(ctx, setKey, productIDs)
+	}
+	return nil
+}
+
 func (m *MockCacheRepository) RemoveFromSet(ctx context.Context, setKey, productID string) error {
 	if m.RemoveFromSetFunc != nil {
 		return m.RemoveFromSetFunc(ctx, setKey, productID)
@@ -137,6 +310,34 @@ func (m *MockCacheRepository) GetSet(ctx context.Context, setKey string) ([]stri
 	return []string{}, nil
 }
 
+func (m *MockCacheRepository) AddToSortedSet(ctx context.Context, setKey, productID string, score float64) error {
+	if m.AddToSortedSetFunc != nil {
+		return m.AddToSortedSetFunc(ctx, setKey, productID, score)
+	}
+	return nil
+}
+
+func (m *MockCacheRepository) RemoveFromSortedSet(ctx context.Context, setKey, productID string) error {
+	if m.RemoveFromSortedSetFunc != nil {
+		return m.RemoveFromSortedSetFunc(ctx, setKey, productID)
+	}
+	return nil
+}
+
+func (m *MockCacheRepository) GetSortedSetRange(ctx context.Context, setKey string, start, stop int64) ([]string, error) {
+	if m.GetSortedSetRangeFunc != nil {
+		return m.GetSortedSetRangeFunc(ctx, setKey, start, stop)
+	}
+	return []string{}, nil
+}
+
+func (m *MockCacheRepository) SampleSortedSet(ctx context.Context, setKey string, count int) ([]string, error) {
+	if m.SampleSortedSetFunc != nil {
+		return m.SampleSortedSetFunc(ctx, setKey, count)
+	}
+	return []string{}, nil
+}
+
 func (m *MockCacheRepository) GetMultiple(ctx context.Context, keys []string) ([]*entity.Product, error) {
 	if m.GetMultipleFunc != nil {
 		return m.GetMultipleFunc(ctx, keys)
@@ -158,6 +359,20 @@ func (m *MockCacheRepository) DeleteSet(ctx context.Context, setKey string) erro
 	return nil
 }
 
+func (m *MockCacheRepository) InvalidateProduct(ctx context.Context, productKey, productID string, setKeys, sortedSetKeys []string) (int, error) {
+	if m.InvalidateProductFunc != nil {
+		return m.InvalidateProductFunc(ctx, productKey, productID, setKeys, sortedSetKeys)
+	}
+	return 0, nil
+}
+
+func (m *MockCacheRepository) FlushCache(ctx context.Context) (int, error) {
+	if m.FlushCacheFunc != nil {
+		return m.FlushCacheFunc(ctx)
+	}
+	return 0, nil
+}
+
 func (m *MockCacheRepository) HealthCheck(ctx context.Context) error {
 	if m.HealthCheckFunc != nil {
 		return m.HealthCheckFunc(ctx)
@@ -179,6 +394,14 @@ func (m *MockCacheKeyGenerator) CategoryKey(category string) string {
 	return "product_by_category_" + category
 }
 
+func (m *MockCacheKeyGenerator) TagKey(tag string) string {
+	return "product_by_tag_" + tag
+}
+
+func (m *MockCacheKeyGenerator) SKUKey(sku string) string {
+	return "product_by_sku_" + sku
+}
+
 func (m *MockCacheKeyGenerator) AllProductsKey() string {
 	return "all_products"
 }
@@ -192,6 +415,8 @@ func newTestProduct() *entity.Product {
 		"SKU-001",
 		"TestBrand",
 		100,
+		0,
+		"",
 		[]string{"image1.jpg"},
 		map[string]interface{}{"color": "black"},
 	)
@@ -207,6 +432,8 @@ func newTestProductWithData(name, ref, category string) *entity.Product {
 		"SKU-001",
 		"Brand",
 		50,
+		0,
+		"",
 		[]string{},
 		map[string]interface{}{},
 	)
@@ -220,3 +447,24 @@ func (m *MockLogger) Debug(msg string, keysAndValues ...interface{}) {}
 func (m *MockLogger) Info(msg string, keysAndValues ...interface{})  {}
 func (m *MockLogger) Warn(msg string, keysAndValues ...interface{})  {}
 func (m *MockLogger) Error(msg string, keysAndValues ...interface{}) {}
+
+type MockFallbackRecorder struct {
+	Operations []string
+}
+
+func (m *MockFallbackRecorder) ObserveDBFallback(operation string, duration time.Duration) {
+	m.Operations = append(m.Operations, operation)
+}
+
+type MockCacheRecorder struct {
+	Hits   []string
+	Misses []string
+}
+
+func (m *MockCacheRecorder) ObserveCacheHit(operation string) {
+	m.Hits = append(m.Hits, operation)
+}
+
+func (m *MockCacheRecorder) ObserveCacheMiss(operation string) {
+	m.Misses = append(m.Misses, operation)
+}
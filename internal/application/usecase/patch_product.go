@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+)
+
+// PatchProductUseCase aplica uma atualização parcial: só os campos presentes
+// no patch mudam, os demais vêm do produto atual. Validação, cache, eventos e
+// o controle de versão (inclusive If-Match) são os da atualização completa.
+type PatchProductUseCase struct {
+	update *UpdateProductUseCase
+}
+
+func NewPatchProductUseCase(update *UpdateProductUseCase) *PatchProductUseCase {
+	return &PatchProductUseCase{update: update}
+}
+
+func (uc *PatchProductUseCase) Execute(ctx context.Context, id string, patch port.PatchProductInput) (*entity.Product, error) {
+	return uc.update.execute(ctx, id, func(current *entity.Product) (port.UpdateProductInput, error) {
+		return mergePatch(current, patch), nil
+	})
+}
+
+// mergePatch monta a entrada completa da atualização com os campos do patch
+// sobre os do produto atual. Um price_amount no patch substitui o preço atual;
+// junto com price, segue recusado como na atualização completa.
+func mergePatch(current *entity.Product, patch port.PatchProductInput) port.UpdateProductInput {
+	input := port.UpdateProductInput{
+		Name:            current.Name,
+		Category:        current.Category,
+		Description:     current.Description,
+		SKU:             current.SKU,
+		Brand:           current.Brand,
+		Stock:           current.Stock,
+		Price:           current.Price,
+		Currency:        current.Currency,
+		Images:          current.Images,
+		Specifications:  current.Specifications,
+		ThumbnailURL:    current.ThumbnailURL,
+		Tags:            current.Tags,
+		WeightGrams:     current.WeightGrams,
+		Dimensions:      current.Dimensions,
+		ExpectedVersion: patch.ExpectedVersion,
+	}
+
+	if patch.Name != nil {
+		input.Name = *patch.Name
+	}
+	if patch.Category != nil {
+		input.Category = *patch.Category
+	}
+	if patch.Description != nil {
+		input.Description = *patch.Description
+	}
+	if patch.SKU != nil {
+		input.SKU = *patch.SKU
+	}
+	if patch.Brand != nil {
+		input.Brand = *patch.Brand
+	}
+	if patch.Stock != nil {
+		input.Stock = *patch.Stock
+	}
+	if patch.PriceAmount != nil {
+		input.Price = 0
+		input.PriceAmount = *patch.PriceAmount
+	}
+	if patch.Price != nil {
+		input.Price = *patch.Price
+	}
+	if patch.Currency != nil {
+		input.Currency = *patch.Currency
+	}
+	if patch.Images != nil {
+		input.Images = *patch.Images
+	}
+	if patch.Specifications != nil {
+		input.Specifications = *patch.Specifications
+	}
+	if patch.ThumbnailURL != nil {
+		input.ThumbnailURL = *patch.ThumbnailURL
+	}
+	if patch.Tags != nil {
+		input.Tags = *patch.Tags
+	}
+	if patch.WeightGrams != nil {
+		input.WeightGrams = *patch.WeightGrams
+	}
+	if patch.Dimensions != nil {
+		input.Dimensions = *patch.Dimensions
+	}
+	return input
+}
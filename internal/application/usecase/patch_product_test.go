@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+)
+
+func TestPatchProductUseCase_Execute_KeepsOmittedFields(t *testing.T) {
+	existingProduct := newTestProductWithData("Old Name", "REF-001", "Category")
+	existingProduct.Tags = []string{"promo"}
+
+	var savedVersion int
+	mockProductRepo := &MockProductRepository{
+		UpdateFunc: func(ctx context.Context, product *entity.Product, expectedVersion int) error {
+			savedVersion = expectedVersion
+			return nil
+		},
+	}
+	mockCacheRepo := &MockCacheRepository{
+		GetFunc: func(ctx context.Context, key string) (*entity.Product, error) {
+			return existingProduct, nil
+		},
+	}
+
+	uc := NewPatchProductUseCase(NewUpdateProductUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}))
+
+	name, stock := "New Name", 7
+	product, err := uc.Execute(context.Background(), existingProduct.ID, port.PatchProductInput{Name: &name, Stock: &stock})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if product.Name != name || product.Stock != stock {
+		t.Errorf("Expected patched name and stock, got %q and %d", product.Name, product.Stock)
+	}
+	if product.Category != "Category" || product.Description != "Description" || product.SKU != "SKU-001" || product.Brand != "Brand" {
+		t.Errorf("Expected omitted fields to be untouched, got %+v", product)
+	}
+	if len(product.Tags) != 1 || product.Tags[0] != "promo" {
+		t.Errorf("Expected tags to be untouched, got %v", product.Tags)
+	}
+	if savedVersion != existingProduct.Version {
+		t.Errorf("Expected update to check version %d, got %d", existingProduct.Version, savedVersion)
+	}
+}
+
+func TestPatchProductUseCase_Execute_ExpectedVersionMismatch(t *testing.T) {
+	existingProduct := newTestProductWithData("Old Name", "REF-001", "Category")
+
+	mockProductRepo := &MockProductRepository{
+		UpdateFunc: func(ctx context.Context, product *entity.Product, expectedVersion int) error {
+			t.Error("Expected no update on version mismatch")
+			return nil
+		},
+	}
+	mockCacheRepo := &MockCacheRepository{
+		GetFunc: func(ctx context.Context, key string) (*entity.Product, error) {
+			return existingProduct, nil
+		},
+	}
+
+	uc := NewPatchProductUseCase(NewUpdateProductUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}))
+
+	name := "New Name"
+	_, err := uc.Execute(context.Background(), existingProduct.ID, port.PatchProductInput{
+		Name:            &name,
+		ExpectedVersion: existingProduct.Version + 1,
+	})
+	if !errors.Is(err, entity.ErrPreconditionFailed) {
+		t.Errorf("Expected ErrPreconditionFailed, got %v", err)
+	}
+}
+
+func TestMergePatch_PriceAmountReplacesPrice(t *testing.T) {
+	current := newTestProductWithData("Name", "REF-001", "Category")
+	current.Price = 999
+	current.Currency = "USD"
+
+	amount := "12.50"
+	input := mergePatch(current, port.PatchProductInput{PriceAmount: &amount})
+	if input.Price != 0 || input.PriceAmount != amount {
+		t.Errorf("Expected price_amount to replace price, got price %d and amount %q", input.Price, input.PriceAmount)
+	}
+
+	if input := mergePatch(current, port.PatchProductInput{}); input.Price != 999 || input.Currency != "USD" {
+		t.Errorf("Expected empty patch to keep price, got %d %q", input.Price, input.Currency)
+	}
+}
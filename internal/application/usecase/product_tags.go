@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// ProductTagsOptions ajusta a edição de tags. Events recebe um product.updated
+// com a nova versão por tag adicionada ou removida; repetições (no-op) não
+// publicam. nil descarta.
+type ProductTagsOptions struct {
+	Events port.ChangePublisher
+}
+
+// ProductTagsUseCase adiciona ou remove uma tag sem passar pela atualização
+// completa: a lista muda num único UPDATE que incrementa a versão, para que um
+// PUT ou PATCH baseado na leitura anterior responda conflito em vez de desfazer
+// a tag. A entrada do produto em cache é invalidada e o índice
+// product_by_tag_* é ajustado. Repetir a operação é um no-op.
+type ProductTagsUseCase struct {
+	productRepo repository.ProductRepository
+	cacheRepo   repository.CacheRepository
+	cacheKeys   port.CacheKeyGenerator
+	logger      port.Logger
+	options     ProductTagsOptions
+}
+
+func NewProductTagsUseCase(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+) *ProductTagsUseCase {
+	return NewProductTagsUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, logger, ProductTagsOptions{})
+}
+
+func NewProductTagsUseCaseWithOptions(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+	options ProductTagsOptions,
+) *ProductTagsUseCase {
+	options.Events = changePublisherOrNoop(options.Events)
+
+	return &ProductTagsUseCase{
+		productRepo: productRepo,
+		cacheRepo:   cacheRepo,
+		cacheKeys:   cacheKeys,
+		logger:      logger,
+		options:     options,
+	}
+}
+
+func (uc *ProductTagsUseCase) Add(ctx context.Context, id, rawTag string) error {
+	tag, err := entity.ParseTag(rawTag)
+	if err != nil {
+		return fmt.Errorf("invalid product data: %w", err)
+	}
+
+	changed, revision, err := uc.productRepo.AddTag(ctx, id, tag)
+	if err != nil {
+		return uc.repoError(err, "failed to add tag", id, tag)
+	}
+	if !changed {
+		uc.logger.Debug("tag already present - ignoring",
+			"product_id", id[:min(8, len(id))],
+			"tag", tag,
+		)
+		return nil
+	}
+
+	uc.invalidateProduct(ctx, id)
+	if err := uc.cacheRepo.AddToSet(ctx, uc.cacheKeys.TagKey(tag), id); err != nil {
+		uc.logger.Error("failed to add to tag index",
+			"error", err,
+			"product_id", id[:min(8, len(id))],
+			"tag", tag,
+		)
+	}
+
+	publishRevision(uc.options.Events, id, revision)
+
+	uc.logger.Info("tag added",
+		"product_id", id[:min(8, len(id))],
+		"tag", tag,
+	)
+
+	return nil
+}
+
+func (uc *ProductTagsUseCase) Remove(ctx context.Context, id, rawTag string) error {
+	tag, err := entity.ParseTag(rawTag)
+	if err != nil {
+		return fmt.Errorf("invalid product data: %w", err)
+	}
+
+	changed, revision, err := uc.productRepo.RemoveTag(ctx, id, tag)
+	if err != nil {
+		return uc.repoError(err, "failed to remove tag", id, tag)
+	}
+	if !changed {
+		uc.logger.Debug("tag not present - ignoring",
+			"product_id", id[:min(8, len(id))],
+			"tag", tag,
+		)
+		return nil
+	}
+
+	uc.invalidateProduct(ctx, id)
+	if err := uc.cacheRepo.RemoveFromSet(ctx, uc.cacheKeys.TagKey(tag), id); err != nil {
+		uc.logger.Error("failed to remove from tag index",
+			"error", err,
+			"product_id", id[:min(8, len(id))],
+			"tag", tag,
+		)
+	}
+
+	publishRevision(uc.options.Events, id, revision)
+
+	uc.logger.Info("tag removed",
+		"product_id", id[:min(8, len(id))],
+		"tag", tag,
+	)
+
+	return nil
+}
+
+func (uc *ProductTagsUseCase) repoError(err error, msg, id, tag string) error {
+	if errors.Is(err, repository.ErrProductNotFound) {
+		return err
+	}
+	if errors.Is(err, entity.ErrTooManyTags) {
+		return fmt.Errorf("invalid product data: %w", err)
+	}
+
+	uc.logger.Error(msg+" in database",
+		"error", err,
+		"product_id", id[:min(8, len(id))],
+		"tag", tag,
+	)
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// invalidateProduct descarta a entrada em cache; a próxima leitura a
+// recarrega do banco já com as tags novas.
+func (uc *ProductTagsUseCase) invalidateProduct(ctx context.Context, id string) {
+	if err := uc.cacheRepo.Delete(ctx, uc.cacheKeys.ProductKey(id)); err != nil {
+		uc.logger.Error("failed to invalidate cached product",
+			"error", err,
+			"product_id", id[:min(8, len(id))],
+		)
+	}
+}
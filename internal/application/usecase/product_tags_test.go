@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+func TestProductTagsUseCase_Add_Success(t *testing.T) {
+	var dbTag, deletedKey, indexKey string
+
+	mockProductRepo := &MockProductRepository{
+		AddTagFunc: func(ctx context.Context, id, tag string) (bool, repository.Revision, error) {
+			dbTag = tag
+			return true, repository.Revision{Version: 4}, nil
+		},
+		UpdateFunc: func(ctx context.Context, product *entity.Product, expectedVersion int) error {
+			t.Error("Expected tag change not to go through the full update path")
+			return nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		DeleteFunc: func(ctx context.Context, key string) error {
+			deletedKey = key
+			return nil
+		},
+		AddToSetFunc: func(ctx context.Context, setKey, productID string) error {
+			indexKey = setKey
+			return nil
+		},
+	}
+
+	events := &recordingPublisher{}
+	uc := NewProductTagsUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, ProductTagsOptions{
+		Events: events,
+	})
+
+	if err := uc.Add(context.Background(), "abc", " Promo "); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if dbTag != "promo" {
+		t.Errorf("Expected normalized tag 'promo', got %q", dbTag)
+	}
+
+	if deletedKey != "product_abc" {
+		t.Errorf("Expected product_abc to be invalidated, got %q", deletedKey)
+	}
+
+	if indexKey != "product_by_tag_promo" {
+		t.Errorf("Expected product_by_tag_promo to be updated, got %q", indexKey)
+	}
+
+	if len(events.events) != 1 || events.events[0].Type != port.ProductUpdated || events.events[0].Version != 4 {
+		t.Errorf("Expected a product.updated with version 4, got %+v", events.events)
+	}
+}
+
+func TestProductTagsUseCase_Remove_NoOpSkipsCache(t *testing.T) {
+	mockProductRepo := &MockProductRepository{
+		RemoveTagFunc: func(ctx context.Context, id, tag string) (bool, repository.Revision, error) {
+			return false, repository.Revision{}, nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		DeleteFunc: func(ctx context.Context, key string) error {
+			t.Error("Expected cache to be left alone on a no-op")
+			return nil
+		},
+		RemoveFromSetFunc: func(ctx context.Context, setKey, productID string) error {
+			t.Error("Expected tag index to be left alone on a no-op")
+			return nil
+		},
+	}
+
+	events := &recordingPublisher{}
+	uc := NewProductTagsUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, ProductTagsOptions{
+		Events: events,
+	})
+
+	if err := uc.Remove(context.Background(), "abc", "missing"); err != nil {
+		t.Errorf("Expected removing a missing tag to succeed, got %v", err)
+	}
+	if len(events.events) != 0 {
+		t.Errorf("Expected no event on a no-op, got %+v", events.events)
+	}
+}
+
+func TestProductTagsUseCase_Add_Errors(t *testing.T) {
+	uc := NewProductTagsUseCase(&MockProductRepository{}, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	if err := uc.Add(context.Background(), "abc", "   "); !errors.Is(err, entity.ErrInvalidTag) {
+		t.Errorf("Expected ErrInvalidTag for a blank tag, got %v", err)
+	}
+
+	full := NewProductTagsUseCase(&MockProductRepository{
+		AddTagFunc: func(ctx context.Context, id, tag string) (bool, repository.Revision, error) {
+			return false, repository.Revision{}, entity.ErrTooManyTags
+		},
+	}, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	if err := full.Add(context.Background(), "abc", "extra"); !errors.Is(err, entity.ErrTooManyTags) {
+		t.Errorf("Expected ErrTooManyTags, got %v", err)
+	}
+}
@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// RecentProductsUseCase lista as novidades reaproveitando a listagem, que já
+// ordena por created_at decrescente e lê do cache só a janela pedida do sorted
+// set all_products. Sempre parte do topo (offset zero).
+type RecentProductsUseCase struct {
+	lister port.ProductLister
+}
+
+func NewRecentProductsUseCase(lister port.ProductLister) *RecentProductsUseCase {
+	return &RecentProductsUseCase{
+		lister: lister,
+	}
+}
+
+func (uc *RecentProductsUseCase) Execute(ctx context.Context, limit int) ([]*entity.Product, error) {
+	return uc.lister.Execute(ctx, repository.ListFilter{}, repository.SortOptions{}, limit, 0)
+}
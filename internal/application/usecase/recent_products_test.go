@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+func TestRecentProductsUseCase_Execute_ServedFromAllProductsTop(t *testing.T) {
+	mockCacheRepo := &MockCacheRepository{
+		GetSortedSetRangeFunc: func(ctx context.Context, setKey string, start, stop int64) ([]string, error) {
+			if setKey != "all_products" || start != 0 || stop != 2 {
+				t.Errorf("Expected top window of all_products, got %s [%d, %d]", setKey, start, stop)
+			}
+			return []string{"P3", "P2", "P1"}, nil
+		},
+		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
+			return []*entity.Product{{ID: "P3"}, {ID: "P2"}, {ID: "P1"}}, nil
+		},
+	}
+
+	mockProductRepo := &MockProductRepository{
+		FindAllFunc: func(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			t.Error("Expected recent products to be served from cache")
+			return nil, nil
+		},
+	}
+
+	lister := NewListProductsUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+	uc := NewRecentProductsUseCase(lister)
+
+	products, err := uc.Execute(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(products) != 3 || products[0].ID != "P3" {
+		t.Errorf("Expected newest products first, got %v", products)
+	}
+}
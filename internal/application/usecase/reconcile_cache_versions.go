@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// ReconcileCacheVersionsUseCase sorteia produtos de all_products e compara a
+// versão em cache com a do banco. Entradas com versão atrás da do banco (uma
+// escrita fora da API ou uma atualização de cache perdida) são removidas e
+// recarregadas na próxima leitura; entradas de produtos que não existem mais
+// saem também dos índices.
+type ReconcileCacheVersionsUseCase struct {
+	productRepo repository.ProductRepository
+	cacheRepo   repository.CacheRepository
+	cacheKeys   port.CacheKeyGenerator
+	logger      port.Logger
+}
+
+func NewReconcileCacheVersionsUseCase(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+) *ReconcileCacheVersionsUseCase {
+	return &ReconcileCacheVersionsUseCase{
+		productRepo: productRepo,
+		cacheRepo:   cacheRepo,
+		cacheKeys:   cacheKeys,
+		logger:      logger,
+	}
+}
+
+// Execute para no primeiro erro do Redis ou do banco; o relatório parcial não
+// é devolvido, já que a amostra é aleatória e pode ser repetida.
+func (uc *ReconcileCacheVersionsUseCase) Execute(ctx context.Context, input port.ReconcileInput) (*port.ReconcileReport, error) {
+	report := &port.ReconcileReport{Stale: []port.StaleEntry{}}
+
+	ids, err := uc.cacheRepo.SampleSortedSet(ctx, uc.cacheKeys.AllProductsKey(), input.SampleSize)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", repository.ErrCacheUnavailable, err)
+	}
+	report.Sampled = len(ids)
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = uc.cacheKeys.ProductKey(id)
+	}
+
+	cached, err := uc.cacheRepo.GetMultiple(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", repository.ErrCacheUnavailable, err)
+	}
+
+	for _, product := range cached {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		current, err := uc.productRepo.FindByID(ctx, product.ID)
+		if err != nil && !errors.Is(err, repository.ErrProductNotFound) {
+			return nil, fmt.Errorf("failed to fetch product: %w", err)
+		}
+		report.Checked++
+
+		if current != nil && current.Version <= product.Version {
+			continue
+		}
+
+		stale := port.StaleEntry{ProductID: product.ID, CachedVersion: product.Version}
+		if current != nil {
+			stale.DBVersion = current.Version
+		}
+		report.Stale = append(report.Stale, stale)
+
+		uc.logger.Warn("stale cache entry detected",
+			"product_id", product.HashID(),
+			"cached_version", stale.CachedVersion,
+			"db_version", stale.DBVersion,
+		)
+
+		if input.DryRun {
+			continue
+		}
+		if uc.evict(ctx, product, current == nil) {
+			report.Evicted++
+		}
+	}
+
+	uc.logger.Info("cache version reconciliation finished",
+		"sampled", report.Sampled,
+		"checked", report.Checked,
+		"stale", len(report.Stale),
+		"evicted", report.Evicted,
+	)
+
+	return report, nil
+}
+
+// evict remove a entrada; se o produto não existe mais, tira o ID também de
+// all_products e dos índices de nome, categoria e tags.
+func (uc *ReconcileCacheVersionsUseCase) evict(ctx context.Context, product *entity.Product, deleted bool) bool {
+	if err := uc.cacheRepo.Delete(ctx, uc.cacheKeys.ProductKey(product.ID)); err != nil {
+		uc.logger.Error("failed to evict stale cache entry",
+			"error", err,
+			"product_id", product.HashID(),
+		)
+		return false
+	}
+	if !deleted {
+		return true
+	}
+
+	if err := uc.cacheRepo.RemoveFromSortedSet(ctx, uc.cacheKeys.AllProductsKey(), product.ID); err != nil {
+		uc.logger.Error("failed to remove from all_products set",
+			"error", err,
+			"product_id", product.HashID(),
+		)
+	}
+
+	setKeys := []string{uc.cacheKeys.NameKey(product.Name), uc.cacheKeys.CategoryKey(product.Category)}
+	if product.SKU != "" {
+		setKeys = append(setKeys, uc.cacheKeys.SKUKey(product.SKU))
+	}
+	for _, tag := range product.Tags {
+		setKeys = append(setKeys, uc.cacheKeys.TagKey(tag))
+	}
+	for _, setKey := range setKeys {
+		if err := uc.cacheRepo.RemoveFromSet(ctx, setKey, product.ID); err != nil {
+			uc.logger.Error("failed to remove from index",
+				"error", err,
+				"product_id", product.HashID(),
+				"index", setKey,
+			)
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+func TestReconcileCacheVersionsUseCase_Execute_EvictsStaleEntries(t *testing.T) {
+	fresh := newTestProductWithData("Fresh", "REF-001", "Phones")
+	behind := newTestProductWithData("Behind", "REF-002", "Phones")
+	orphan := newTestProductWithData("Orphan", "REF-003", "Phones")
+	orphan.Tags = []string{"promo"}
+
+	deleted := map[string]bool{}
+	removedFromSets := map[string]bool{}
+
+	productRepo := &MockProductRepository{
+		FindByIDFunc: func(ctx context.Context, id string) (*entity.Product, error) {
+			switch id {
+			case fresh.ID:
+				return fresh, nil
+			case behind.ID:
+				updated := *behind
+				updated.Version = behind.Version + 1
+				return &updated, nil
+			}
+			return nil, repository.ErrProductNotFound
+		},
+	}
+	cacheRepo := &MockCacheRepository{
+		SampleSortedSetFunc: func(ctx context.Context, setKey string, count int) ([]string, error) {
+			if setKey != "all_products" || count != 10 {
+				t.Errorf("Unexpected sample of %s (%d)", setKey, count)
+			}
+			return []string{fresh.ID, behind.ID, orphan.ID}, nil
+		},
+		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
+			return []*entity.Product{fresh, behind, orphan}, nil
+		},
+		DeleteFunc: func(ctx context.Context, key string) error {
+			deleted[key] = true
+			return nil
+		},
+		RemoveFromSortedSetFunc: func(ctx context.Context, setKey, productID string) error {
+			removedFromSets[setKey+":"+productID] = true
+			return nil
+		},
+		RemoveFromSetFunc: func(ctx context.Context, setKey, productID string) error {
+			removedFromSets[setKey+":"+productID] = true
+			return nil
+		},
+	}
+
+	uc := NewReconcileCacheVersionsUseCase(productRepo, cacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	report, err := uc.Execute(context.Background(), port.ReconcileInput{SampleSize: 10})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if report.Sampled != 3 || report.Checked != 3 || report.Evicted != 2 || len(report.Stale) != 2 {
+		t.Fatalf("Unexpected report %+v", report)
+	}
+
+	if deleted["product_"+fresh.ID] {
+		t.Error("Expected up-to-date entry to be kept")
+	}
+	if !deleted["product_"+behind.ID] || !deleted["product_"+orphan.ID] {
+		t.Error("Expected stale entries to be evicted")
+	}
+	if removedFromSets["all_products:"+behind.ID] {
+		t.Error("Expected entry behind the database to stay indexed")
+	}
+	if !removedFromSets["all_products:"+orphan.ID] || !removedFromSets["product_by_tag_promo:"+orphan.ID] {
+		t.Error("Expected deleted product to leave all_products and its indices")
+	}
+}
+
+func TestReconcileCacheVersionsUseCase_Execute_DryRun(t *testing.T) {
+	behind := newTestProductWithData("Behind", "REF-002", "Phones")
+
+	productRepo := &MockProductRepository{
+		FindByIDFunc: func(ctx context.Context, id string) (*entity.Product, error) {
+			updated := *behind
+			updated.Version = behind.Version + 1
+			return &updated, nil
+		},
+	}
+	cacheRepo := &MockCacheRepository{
+		SampleSortedSetFunc: func(ctx context.Context, setKey string, count int) ([]string, error) {
+			return []string{behind.ID}, nil
+		},
+		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
+			return []*entity.Product{behind}, nil
+		},
+		DeleteFunc: func(ctx context.Context, key string) error {
+			t.Error("Expected dry run not to evict")
+			return nil
+		},
+	}
+
+	uc := NewReconcileCacheVersionsUseCase(productRepo, cacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	report, err := uc.Execute(context.Background(), port.ReconcileInput{SampleSize: 10, DryRun: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(report.Stale) != 1 || report.Evicted != 0 {
+		t.Errorf("Unexpected report %+v", report)
+	}
+}
+
+func TestReconcileCacheVersionsUseCase_Execute_DatabaseError(t *testing.T) {
+	product := newTestProductWithData("Product", "REF-001", "Phones")
+
+	productRepo := &MockProductRepository{
+		FindByIDFunc: func(ctx context.Context, id string) (*entity.Product, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+	cacheRepo := &MockCacheRepository{
+		SampleSortedSetFunc: func(ctx context.Context, setKey string, count int) ([]string, error) {
+			return []string{product.ID}, nil
+		},
+		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
+			return []*entity.Product{product}, nil
+		},
+		DeleteFunc: func(ctx context.Context, key string) error {
+			t.Error("Expected database errors not to evict entries")
+			return nil
+		},
+	}
+
+	uc := NewReconcileCacheVersionsUseCase(productRepo, cacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	if _, err := uc.Execute(context.Background(), port.ReconcileInput{SampleSize: 10}); err == nil {
+		t.Error("Expected error when the database fails")
+	}
+}
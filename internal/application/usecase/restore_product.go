@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// RestoreProductOptions ajusta a restauração. Events recebe um
+// product.restored por restauração; nil descarta.
+type RestoreProductOptions struct {
+	Events port.ChangePublisher
+	Counts port.ProductCountCache
+}
+
+// RestoreProductUseCase desfaz a exclusão lógica e devolve o produto ao cache
+// e a todos os índices, como numa criação.
+type RestoreProductUseCase struct {
+	productRepo repository.ProductRepository
+	creator     *CreateProductUseCase
+	logger      port.Logger
+	options     RestoreProductOptions
+}
+
+func NewRestoreProductUseCase(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+	options RestoreProductOptions,
+) *RestoreProductUseCase {
+	options.Events = changePublisherOrNoop(options.Events)
+
+	return &RestoreProductUseCase{
+		productRepo: productRepo,
+		creator: NewCreateProductUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, logger, CreateProductOptions{
+			Counts: options.Counts,
+		}),
+		logger:  logger,
+		options: options,
+	}
+}
+
+// Execute é idempotente: restaurar um produto ativo só repopula o cache.
+func (uc *RestoreProductUseCase) Execute(ctx context.Context, id string) (*entity.Product, error) {
+	uc.logger.Info("restoring product",
+		"product_id", id[:min(8, len(id))],
+	)
+
+	if err := uc.productRepo.Restore(ctx, id); err != nil {
+		uc.logger.Error("failed to restore product in database",
+			"error", err,
+			"product_id", id[:min(8, len(id))],
+		)
+		return nil, fmt.Errorf("failed to restore product: %w", err)
+	}
+
+	product, err := uc.productRepo.FindByID(ctx, id)
+	if err != nil {
+		uc.logger.Error("failed to load restored product",
+			"error", err,
+			"product_id", id[:min(8, len(id))],
+		)
+		return nil, fmt.Errorf("failed to load restored product: %w", err)
+	}
+
+	uc.creator.updateCache(ctx, product)
+	uc.options.Events.Publish(port.ChangeEvent{
+		Type:       port.ProductRestored,
+		ProductID:  product.ID,
+		Version:    product.Version,
+		OccurredAt: time.Now().UTC(),
+	})
+
+	return product, nil
+}
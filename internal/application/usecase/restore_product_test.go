@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+func TestRestoreProductUseCase_Execute_Success(t *testing.T) {
+	product := newTestProductWithData("Notebook", "REF-001", "Electronics")
+	product.Tags = []string{"promo"}
+
+	mockProductRepo := &MockProductRepository{
+		FindByIDFunc: func(ctx context.Context, id string) (*entity.Product, error) {
+			return product, nil
+		},
+	}
+
+	cached := make(map[string]bool)
+	indexed := make(map[string]string)
+	mockCacheRepo := &MockCacheRepository{
+		SetFunc: func(ctx context.Context, key string, p *entity.Product) error {
+			cached[key] = true
+			return nil
+		},
+		AddToSortedSetFunc: func(ctx context.Context, setKey, productID string, score float64) error {
+			indexed[setKey] = productID
+			return nil
+		},
+		AddToSetFunc: func(ctx context.Context, setKey, productID string) error {
+			indexed[setKey] = productID
+			return nil
+		},
+	}
+
+	events := &recordingPublisher{}
+	uc := NewRestoreProductUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, RestoreProductOptions{
+		Events: events,
+	})
+
+	result, err := uc.Execute(context.Background(), product.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result != product {
+		t.Errorf("Expected the restored product, got %+v", result)
+	}
+
+	if !cached["product_"+product.ID] {
+		t.Error("Expected product key to be repopulated")
+	}
+	for _, key := range []string{"all_products", "product_by_name_Notebook", "product_by_category_Electronics", "product_by_tag_promo"} {
+		if indexed[key] != product.ID {
+			t.Errorf("Expected %s to index the restored product", key)
+		}
+	}
+
+	if len(events.events) != 1 || events.events[0].Type != port.ProductRestored {
+		t.Errorf("Expected one product.restored event, got %+v", events.events)
+	}
+}
+
+func TestRestoreProductUseCase_Execute_NotFound(t *testing.T) {
+	mockProductRepo := &MockProductRepository{
+		RestoreFunc: func(ctx context.Context, id string) error {
+			return repository.ErrProductNotFound
+		},
+		FindByIDFunc: func(ctx context.Context, id string) (*entity.Product, error) {
+			t.Error("Expected no lookup after a failed restore")
+			return nil, nil
+		},
+	}
+
+	uc := NewRestoreProductUseCase(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{}, RestoreProductOptions{})
+
+	_, err := uc.Execute(context.Background(), "missing")
+	if !errors.Is(err, repository.ErrProductNotFound) {
+		t.Errorf("Expected ErrProductNotFound, got %v", err)
+	}
+}
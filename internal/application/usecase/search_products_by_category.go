@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/dowglassantana/product-redis-api/internal/application/port"
 	"github.com/dowglassantana/product-redis-api/internal/application/utils"
@@ -9,11 +11,17 @@ import (
 	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
 )
 
+// readThroughLimit é o maior resultado carregado inteiro num cache miss das
+// buscas por categoria, nome e tag. Resultados maiores seguem paginados no
+// banco, sem cache: um índice com só parte deles seria servido como completo.
+const readThroughLimit = 5000
+
 type SearchProductsByCategoryUseCase struct {
 	productRepo repository.ProductRepository
 	cacheRepo   repository.CacheRepository
 	cacheKeys   port.CacheKeyGenerator
 	logger      port.Logger
+	options     SearchProductsOptions
 }
 
 func NewSearchProductsByCategoryUseCase(
@@ -22,31 +30,60 @@ func NewSearchProductsByCategoryUseCase(
 	cacheKeys port.CacheKeyGenerator,
 	logger port.Logger,
 ) *SearchProductsByCategoryUseCase {
+	return NewSearchProductsByCategoryUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, logger, SearchProductsOptions{})
+}
+
+func NewSearchProductsByCategoryUseCaseWithOptions(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+	options SearchProductsOptions,
+) *SearchProductsByCategoryUseCase {
+	options.FallbackRecorder = fallbackRecorderOrNoop(options.FallbackRecorder)
+	options.CacheRecorder = cacheRecorderOrNoop(options.CacheRecorder)
+	options.Background = backgroundRunnerOrGo(options.Background)
+
 	return &SearchProductsByCategoryUseCase{
 		productRepo: productRepo,
 		cacheRepo:   cacheRepo,
 		cacheKeys:   cacheKeys,
 		logger:      logger,
+		options:     options,
 	}
 }
 
-func (uc *SearchProductsByCategoryUseCase) Execute(ctx context.Context, category string, limit, offset int) ([]*entity.Product, error) {
+// Execute aplica o filtro em memória quando o resultado vem do índice da
+// categoria ou do read-through, que guardam a categoria inteira.
+func (uc *SearchProductsByCategoryUseCase) Execute(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 	uc.logger.Debug("searching products by category",
 		"category", category,
+		"sort", sort.Field,
+		"direction", sort.Direction,
 		"limit", limit,
 		"offset", offset,
 	)
 
-	products := uc.searchInCache(ctx, category)
+	products, cacheErr := uc.searchInCache(ctx, category)
+	if cacheErr != nil && uc.options.StrictCache {
+		return nil, cacheErr
+	}
 	if len(products) > 0 {
+		observeCacheHit(ctx, uc.options.CacheRecorder, "search_category")
+		// O índice da categoria é um set, sem ordem: ordena como o banco faria.
+		products = utils.FilterProducts(products, filter)
+		utils.SortProducts(products, sort.WithDefaults(repository.SortByCreatedAt, repository.SortDesc))
 		return utils.PaginateProducts(products, limit, offset), nil
 	}
 
+	observeCacheMiss(ctx, uc.options.CacheRecorder, "search_category")
 	uc.logger.Debug("cache miss - searching in database",
 		"category", category,
 	)
 
-	products, err := uc.productRepo.FindByCategory(ctx, category, limit, offset)
+	start := time.Now()
+	products, err := uc.searchInDatabase(ctx, category, filter, sort, limit, offset)
+	uc.options.FallbackRecorder.ObserveDBFallback("search_by_category", time.Since(start))
 	if err != nil {
 		uc.logger.Error("failed to search products by category in database",
 			"error", err,
@@ -55,15 +92,98 @@ func (uc *SearchProductsByCategoryUseCase) Execute(ctx context.Context, category
 		return nil, err
 	}
 
+	if uc.options.PopulateCache {
+		err := populateSearchCache(ctx, uc.cacheRepo, uc.cacheKeys, uc.logger, products)
+		if err != nil && uc.options.StrictCache {
+			return nil, err
+		}
+	}
+
 	return products, nil
 }
 
-func (uc *SearchProductsByCategoryUseCase) searchInCache(ctx context.Context, category string) []*entity.Product {
+// searchInDatabase carrega a categoria inteira quando ela cabe no
+// read-through, grava o cache em background e pagina em memória; senão, busca
+// só a página pedida. Com PopulateCache (aquecimento) a página já é gravada
+// por Execute.
+func (uc *SearchProductsByCategoryUseCase) searchInDatabase(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+	if uc.options.PopulateCache {
+		return uc.productRepo.FindByCategory(ctx, category, filter, sort, limit, offset)
+	}
+
+	total, err := uc.productRepo.CountByCategory(ctx, category, repository.ListFilter{})
+	if err != nil || total == 0 || total > readThroughLimit {
+		return uc.productRepo.FindByCategory(ctx, category, filter, sort, limit, offset)
+	}
+
+	products, err := uc.productRepo.FindByCategory(ctx, category, repository.ListFilter{}, sort, readThroughLimit+1, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(products) <= readThroughLimit {
+		uc.cacheCategory(ctx, category, products)
+	}
+
+	return utils.PaginateProducts(utils.FilterProducts(products, filter), limit, offset), nil
+}
+
+// cacheCategory grava em background as chaves dos produtos e reconstrói o
+// índice da categoria de uma vez, para que a próxima busca seja um hit. É
+// best-effort: falhas só são logadas. Se algum produto não for gravado, o
+// índice não é escrito: sem o produto ele ficaria incompleto e as buscas
+// seguintes o omitiriam como se fosse um hit. A exceção é o produto acima do
+// limite de tamanho, que fica indexado e faz a categoria ser servida pelo
+// banco.
+func (uc *SearchProductsByCategoryUseCase) cacheCategory(ctx context.Context, category string, products []*entity.Product) {
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), repopulateTimeout)
+	categoryKey := uc.cacheKeys.CategoryKey(category)
+
+	uc.options.Background.Go(func() {
+		defer cancel()
+
+		cached := make([]string, 0, len(products))
+		for _, product := range products {
+			if err := uc.cacheRepo.Set(writeCtx, uc.cacheKeys.ProductKey(product.ID), product); err != nil && !skippedCacheWrite(uc.logger, err, product) {
+				uc.logger.Warn("failed to cache product from category search - skipping category index",
+					"error", err,
+					"product_id", product.HashID(),
+					"category", category,
+				)
+				return
+			}
+			cached = append(cached, product.ID)
+		}
+
+		if err := uc.cacheRepo.AddAllToSet(writeCtx, categoryKey, cached); err != nil {
+			uc.logger.Warn("failed to rebuild category index",
+				"error", err,
+				"category", category,
+			)
+			return
+		}
+
+		uc.logger.Debug("category cached after search miss",
+			"category", category,
+			"count", len(cached),
+		)
+	})
+}
+
+// searchInCache devolve os produtos do índice; um miss (índice vazio ou
+// incompleto, ou ainda em reconstrução) não é erro, só falhas do Redis.
+func (uc *SearchProductsByCategoryUseCase) searchInCache(ctx context.Context, category string) ([]*entity.Product, error) {
+	if backfillRunning(uc.options.Backfill) {
+		return nil, nil
+	}
+
 	categoryKey := uc.cacheKeys.CategoryKey(category)
 
 	productIDs, err := uc.cacheRepo.GetSet(ctx, categoryKey)
-	if err != nil || len(productIDs) == 0 {
-		return nil
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", repository.ErrCacheUnavailable, err)
+	}
+	if len(productIDs) == 0 {
+		return nil, nil
 	}
 
 	keys := make([]string, len(productIDs))
@@ -76,11 +196,11 @@ func (uc *SearchProductsByCategoryUseCase) searchInCache(ctx context.Context, ca
 		uc.logger.Debug("failed to get products from cache",
 			"error", err,
 		)
-		return nil
+		return nil, fmt.Errorf("%w: %v", repository.ErrCacheUnavailable, err)
 	}
 
 	if len(products) < len(productIDs) {
-		return nil
+		return nil, nil
 	}
 
 	uc.logger.Debug("cache hit for category search",
@@ -88,5 +208,5 @@ func (uc *SearchProductsByCategoryUseCase) searchInCache(ctx context.Context, ca
 		"count", len(products),
 	)
 
-	return products
+	return products, nil
 }
@@ -3,9 +3,11 @@ package usecase
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 
 	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
 )
 
 func TestSearchProductsByCategoryUseCase_Execute_CacheHit(t *testing.T) {
@@ -31,7 +33,7 @@ func TestSearchProductsByCategoryUseCase_Execute_CacheHit(t *testing.T) {
 	logger := &MockLogger{}
 	uc := NewSearchProductsByCategoryUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), "Smartphones", 10, 0)
+	result, err := uc.Execute(context.Background(), "Smartphones", repository.ListFilter{}, repository.SortOptions{}, 10, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -42,6 +44,48 @@ func TestSearchProductsByCategoryUseCase_Execute_CacheHit(t *testing.T) {
 	}
 }
 
+func TestSearchProductsByCategoryUseCase_Execute_CacheHitFiltersPriceRange(t *testing.T) {
+	cheap := newTestProductWithData("Galaxy A15", "REF-001", "Smartphones")
+	cheap.Price = 99900
+	mid := newTestProductWithData("Pixel 8", "REF-002", "Smartphones")
+	mid.Price = 349900
+	premium := newTestProductWithData("iPhone 15 Pro", "REF-003", "Smartphones")
+	premium.Price = 799900
+
+	mockProductRepo := &MockProductRepository{
+		FindByCategoryFunc: func(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			t.Error("Expected database not to be called on cache hit")
+			return nil, nil
+		},
+	}
+	mockCacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			return []string{cheap.ID, mid.ID, premium.ID}, nil
+		},
+		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
+			return []*entity.Product{cheap, mid, premium}, nil
+		},
+	}
+
+	uc := NewSearchProductsByCategoryUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	minPrice, maxPrice := int64(99900), int64(349900)
+	filter := repository.ListFilter{MinPrice: &minPrice, MaxPrice: &maxPrice}
+	result, err := uc.Execute(context.Background(), "Smartphones", filter, repository.SortOptions{}, 10, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 products in the price range, got %d", len(result))
+	}
+	for _, product := range result {
+		if product == premium {
+			t.Errorf("Expected %s to be filtered out", premium.Name)
+		}
+	}
+}
+
 func TestSearchProductsByCategoryUseCase_Execute_CacheMiss_DatabaseSuccess(t *testing.T) {
 	products := []*entity.Product{
 		newTestProductWithData("MacBook Pro", "REF-001", "Laptops"),
@@ -50,7 +94,7 @@ func TestSearchProductsByCategoryUseCase_Execute_CacheMiss_DatabaseSuccess(t *te
 	dbCalled := false
 
 	mockProductRepo := &MockProductRepository{
-		FindByCategoryFunc: func(ctx context.Context, category string, limit, offset int) ([]*entity.Product, error) {
+		FindByCategoryFunc: func(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 			dbCalled = true
 			if category == "Laptops" {
 				return products, nil
@@ -69,7 +113,7 @@ func TestSearchProductsByCategoryUseCase_Execute_CacheMiss_DatabaseSuccess(t *te
 	logger := &MockLogger{}
 	uc := NewSearchProductsByCategoryUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), "Laptops", 10, 0)
+	result, err := uc.Execute(context.Background(), "Laptops", repository.ListFilter{}, repository.SortOptions{}, 10, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -88,7 +132,7 @@ func TestSearchProductsByCategoryUseCase_Execute_DatabaseError(t *testing.T) {
 	dbError := errors.New("database error")
 
 	mockProductRepo := &MockProductRepository{
-		FindByCategoryFunc: func(ctx context.Context, category string, limit, offset int) ([]*entity.Product, error) {
+		FindByCategoryFunc: func(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 			return nil, dbError
 		},
 	}
@@ -103,7 +147,7 @@ func TestSearchProductsByCategoryUseCase_Execute_DatabaseError(t *testing.T) {
 	logger := &MockLogger{}
 	uc := NewSearchProductsByCategoryUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), "Category", 10, 0)
+	result, err := uc.Execute(context.Background(), "Category", repository.ListFilter{}, repository.SortOptions{}, 10, 0)
 
 	if err == nil {
 		t.Error("Expected error, got nil")
@@ -122,7 +166,7 @@ func TestSearchProductsByCategoryUseCase_Execute_CacheError_FallbackToDatabase(t
 	dbCalled := false
 
 	mockProductRepo := &MockProductRepository{
-		FindByCategoryFunc: func(ctx context.Context, category string, limit, offset int) ([]*entity.Product, error) {
+		FindByCategoryFunc: func(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 			dbCalled = true
 			return products, nil
 		},
@@ -138,7 +182,7 @@ func TestSearchProductsByCategoryUseCase_Execute_CacheError_FallbackToDatabase(t
 	logger := &MockLogger{}
 	uc := NewSearchProductsByCategoryUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), "Category", 10, 0)
+	result, err := uc.Execute(context.Background(), "Category", repository.ListFilter{}, repository.SortOptions{}, 10, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -159,7 +203,7 @@ func TestSearchProductsByCategoryUseCase_Execute_PartialCacheMiss(t *testing.T)
 	dbCalled := false
 
 	mockProductRepo := &MockProductRepository{
-		FindByCategoryFunc: func(ctx context.Context, category string, limit, offset int) ([]*entity.Product, error) {
+		FindByCategoryFunc: func(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 			dbCalled = true
 			return []*entity.Product{product}, nil
 		},
@@ -178,7 +222,7 @@ func TestSearchProductsByCategoryUseCase_Execute_PartialCacheMiss(t *testing.T)
 	logger := &MockLogger{}
 	uc := NewSearchProductsByCategoryUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), "Category", 10, 0)
+	result, err := uc.Execute(context.Background(), "Category", repository.ListFilter{}, repository.SortOptions{}, 10, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -219,7 +263,7 @@ func TestSearchProductsByCategoryUseCase_Execute_Pagination(t *testing.T) {
 	logger := &MockLogger{}
 	uc := NewSearchProductsByCategoryUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), "Electronics", 2, 0)
+	result, err := uc.Execute(context.Background(), "Electronics", repository.ListFilter{}, repository.SortOptions{}, 2, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -229,7 +273,7 @@ func TestSearchProductsByCategoryUseCase_Execute_Pagination(t *testing.T) {
 		t.Errorf("Expected 2 products with limit=2, got %d", len(result))
 	}
 
-	result, err = uc.Execute(context.Background(), "Electronics", 2, 2)
+	result, err = uc.Execute(context.Background(), "Electronics", repository.ListFilter{}, repository.SortOptions{}, 2, 2)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -242,7 +286,7 @@ func TestSearchProductsByCategoryUseCase_Execute_Pagination(t *testing.T) {
 
 func TestSearchProductsByCategoryUseCase_Execute_EmptyResult(t *testing.T) {
 	mockProductRepo := &MockProductRepository{
-		FindByCategoryFunc: func(ctx context.Context, category string, limit, offset int) ([]*entity.Product, error) {
+		FindByCategoryFunc: func(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 			return []*entity.Product{}, nil
 		},
 	}
@@ -257,7 +301,7 @@ func TestSearchProductsByCategoryUseCase_Execute_EmptyResult(t *testing.T) {
 	logger := &MockLogger{}
 	uc := NewSearchProductsByCategoryUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), "NonExistent", 10, 0)
+	result, err := uc.Execute(context.Background(), "NonExistent", repository.ListFilter{}, repository.SortOptions{}, 10, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -274,7 +318,7 @@ func TestSearchProductsByCategoryUseCase_Execute_GetMultipleError(t *testing.T)
 	dbCalled := false
 
 	mockProductRepo := &MockProductRepository{
-		FindByCategoryFunc: func(ctx context.Context, category string, limit, offset int) ([]*entity.Product, error) {
+		FindByCategoryFunc: func(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 			dbCalled = true
 			return []*entity.Product{product}, nil
 		},
@@ -293,7 +337,7 @@ func TestSearchProductsByCategoryUseCase_Execute_GetMultipleError(t *testing.T)
 	logger := &MockLogger{}
 	uc := NewSearchProductsByCategoryUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), "Category", 10, 0)
+	result, err := uc.Execute(context.Background(), "Category", repository.ListFilter{}, repository.SortOptions{}, 10, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -330,7 +374,7 @@ func TestSearchProductsByCategoryUseCase_Execute_CacheKeyGeneration(t *testing.T
 	logger := &MockLogger{}
 	uc := NewSearchProductsByCategoryUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	_, err := uc.Execute(context.Background(), "SMARTPHONES", 10, 0)
+	_, err := uc.Execute(context.Background(), "SMARTPHONES", repository.ListFilter{}, repository.SortOptions{}, 10, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -340,3 +384,271 @@ func TestSearchProductsByCategoryUseCase_Execute_CacheKeyGeneration(t *testing.T
 		t.Errorf("Expected key 'product_by_category_SMARTPHONES', got '%s'", calledWithKey)
 	}
 }
+
+func TestSearchProductsByCategoryUseCase_Execute_ReadThrough(t *testing.T) {
+	products := []*entity.Product{
+		newTestProductWithData("MacBook Pro", "REF-001", "Laptops"),
+		newTestProductWithData("Dell XPS", "REF-002", "Laptops"),
+		newTestProductWithData("ThinkPad", "REF-003", "Laptops"),
+	}
+
+	var queried [2]int
+	mockProductRepo := &MockProductRepository{
+		CountByCategoryFunc: func(ctx context.Context, category string, filter repository.ListFilter) (int, error) {
+			return len(products), nil
+		},
+		FindByCategoryFunc: func(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			queried = [2]int{limit, offset}
+			return products, nil
+		},
+	}
+
+	var mu sync.Mutex
+	cachedKeys := make(map[string]bool)
+	indexed := make(map[string][]string)
+	mockCacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			return []string{}, nil
+		},
+		SetFunc: func(ctx context.Context, key string, product *entity.Product) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if product.Name == "Dell XPS" {
+				return errors.New("redis down")
+			}
+			cachedKeys[key] = true
+			return nil
+		},
+		AddAllToSetFunc: func(ctx context.Context, setKey string, productIDs []string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			indexed[setKey] = append(indexed[setKey], productIDs...)
+			return nil
+		},
+	}
+
+	tasks := NewBackgroundTasks()
+	uc := NewSearchProductsByCategoryUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, SearchProductsOptions{
+		Background: tasks,
+	})
+
+	result, err := uc.Execute(context.Background(), "Laptops", repository.ListFilter{}, repository.SortOptions{}, 2, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := tasks.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected cache writes to finish, got %v", err)
+	}
+
+	if queried != [2]int{readThroughLimit + 1, 0} {
+		t.Errorf("Expected the whole category to be loaded, got limit/offset %v", queried)
+	}
+	if len(result) != 2 || result[0] != products[1] || result[1] != products[2] {
+		t.Errorf("Expected the requested page paginated in memory, got %d products", len(result))
+	}
+
+	if len(cachedKeys) != 1 || !cachedKeys["product_"+products[0].ID] {
+		t.Errorf("Expected the writes to stop at the failed product, got %v", cachedKeys)
+	}
+	if index, ok := indexed["product_by_category_Laptops"]; ok {
+		t.Errorf("Expected no category index after a failed product write, got %v", index)
+	}
+}
+
+func TestSearchProductsByCategoryUseCase_Execute_ReadThroughIndexesCategory(t *testing.T) {
+	products := []*entity.Product{
+		newTestProductWithData("MacBook Pro", "REF-001", "Laptops"),
+		newTestProductWithData("Dell XPS", "REF-002", "Laptops"),
+	}
+
+	mockProductRepo := &MockProductRepository{
+		CountByCategoryFunc: func(ctx context.Context, category string, filter repository.ListFilter) (int, error) {
+			return len(products), nil
+		},
+		FindByCategoryFunc: func(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			return products, nil
+		},
+	}
+
+	var index []string
+	mockCacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			return []string{}, nil
+		},
+		AddAllToSetFunc: func(ctx context.Context, setKey string, productIDs []string) error {
+			index = productIDs
+			return nil
+		},
+	}
+
+	tasks := NewBackgroundTasks()
+	uc := NewSearchProductsByCategoryUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, SearchProductsOptions{
+		Background: tasks,
+	})
+
+	if _, err := uc.Execute(context.Background(), "Laptops", repository.ListFilter{}, repository.SortOptions{}, 10, 0); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := tasks.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected cache writes to finish, got %v", err)
+	}
+
+	if len(index) != 2 || index[0] != products[0].ID || index[1] != products[1].ID {
+		t.Errorf("Expected every product in the category index, got %v", index)
+	}
+}
+
+func TestSearchProductsByCategoryUseCase_Execute_LargeCategorySkipsReadThrough(t *testing.T) {
+	var queried [2]int
+	mockProductRepo := &MockProductRepository{
+		CountByCategoryFunc: func(ctx context.Context, category string, filter repository.ListFilter) (int, error) {
+			return readThroughLimit + 1, nil
+		},
+		FindByCategoryFunc: func(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			queried = [2]int{limit, offset}
+			return []*entity.Product{newTestProductWithData("MacBook Pro", "REF-001", "Laptops")}, nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			return []string{}, nil
+		},
+		AddAllToSetFunc: func(ctx context.Context, setKey string, productIDs []string) error {
+			t.Error("Expected no partial category index")
+			return nil
+		},
+	}
+
+	tasks := NewBackgroundTasks()
+	uc := NewSearchProductsByCategoryUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, SearchProductsOptions{
+		Background: tasks,
+	})
+
+	if _, err := uc.Execute(context.Background(), "Laptops", repository.ListFilter{}, repository.SortOptions{}, 10, 20); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_ = tasks.Wait(context.Background())
+
+	if queried != [2]int{10, 20} {
+		t.Errorf("Expected only the requested page from the database, got %v", queried)
+	}
+}
+
+func TestSearchProductsByCategoryUseCase_Execute_CacheHitHonorsSort(t *testing.T) {
+	low := newTestProductWithData("Low", "REF-001", "Laptops")
+	low.Stock = 1
+	high := newTestProductWithData("High", "REF-002", "Laptops")
+	high.Stock = 9
+	mid := newTestProductWithData("Mid", "REF-003", "Laptops")
+	mid.Stock = 5
+
+	mockCacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			return []string{low.ID, high.ID, mid.ID}, nil
+		},
+		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
+			return []*entity.Product{low, high, mid}, nil
+		},
+	}
+
+	uc := NewSearchProductsByCategoryUseCase(&MockProductRepository{}, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	sort := repository.SortOptions{Field: repository.SortByStock, Direction: repository.SortDesc}
+	result, err := uc.Execute(context.Background(), "Laptops", repository.ListFilter{}, sort, 2, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result) != 2 || result[0] != high || result[1] != mid {
+		t.Errorf("Expected the two highest stocks in descending order, got %v", result)
+	}
+}
+
+func TestSearchProductsByCategoryUseCase_Execute_RecordsCacheHitAndMiss(t *testing.T) {
+	product := newTestProductWithData("MacBook Pro", "REF-001", "Laptops")
+	cached := false
+
+	mockProductRepo := &MockProductRepository{
+		FindByCategoryFunc: func(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			return []*entity.Product{product}, nil
+		},
+	}
+	mockCacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			if !cached {
+				return []string{}, nil
+			}
+			return []string{product.ID}, nil
+		},
+		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
+			return []*entity.Product{product}, nil
+		},
+	}
+
+	recorder := &MockCacheRecorder{}
+	uc := NewSearchProductsByCategoryUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, SearchProductsOptions{
+		CacheRecorder: recorder,
+	})
+
+	if _, err := uc.Execute(context.Background(), "Laptops", repository.ListFilter{}, repository.SortOptions{}, 10, 0); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	cached = true
+	if _, err := uc.Execute(context.Background(), "Laptops", repository.ListFilter{}, repository.SortOptions{}, 10, 0); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(recorder.Misses) != 1 || recorder.Misses[0] != "search_category" {
+		t.Errorf("Expected a single search_category miss, got %v", recorder.Misses)
+	}
+	if len(recorder.Hits) != 1 || recorder.Hits[0] != "search_category" {
+		t.Errorf("Expected a single search_category hit, got %v", recorder.Hits)
+	}
+}
+
+func TestSearchProductsByCategoryUseCase_Execute_IndexesOversizedProducts(t *testing.T) {
+	products := []*entity.Product{
+		newTestProductWithData("MacBook Pro", "REF-001", "Laptops"),
+		newTestProductWithData("ThinkPad", "REF-002", "Laptops"),
+	}
+	products[0].ID = "A"
+	products[1].ID = "B"
+
+	mockProductRepo := &MockProductRepository{
+		CountByCategoryFunc: func(ctx context.Context, category string, filter repository.ListFilter) (int, error) {
+			return len(products), nil
+		},
+		FindByCategoryFunc: func(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			return products, nil
+		},
+	}
+
+	var indexed []string
+	mockCacheRepo := &MockCacheRepository{
+		SetFunc: func(ctx context.Context, key string, product *entity.Product) error {
+			if product.ID == "A" {
+				return repository.ErrCacheEntryTooLarge
+			}
+			return nil
+		},
+		AddAllToSetFunc: func(ctx context.Context, setKey string, productIDs []string) error {
+			indexed = productIDs
+			return nil
+		},
+	}
+
+	tasks := NewBackgroundTasks()
+	uc := NewSearchProductsByCategoryUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, SearchProductsOptions{Background: tasks})
+
+	if _, err := uc.Execute(context.Background(), "Laptops", repository.ListFilter{}, repository.SortOptions{}, 10, 0); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := tasks.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected cache write to finish, got %v", err)
+	}
+
+	if len(indexed) != 2 {
+		t.Errorf("Expected the oversized product to stay in the index, got %v", indexed)
+	}
+}
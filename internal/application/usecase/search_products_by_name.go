@@ -1,7 +1,12 @@
 package usecase
 
 import (
+	"cmp"
 	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/dowglassantana/product-redis-api/internal/application/port"
 	"github.com/dowglassantana/product-redis-api/internal/application/utils"
@@ -9,11 +14,31 @@ import (
 	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
 )
 
+// SearchProductsOptions ajusta as buscas por nome, categoria e tag.
+// FallbackRecorder recebe a latência da consulta ao banco após um cache miss, e
+// CacheRecorder conta cada busca como hit ou miss do índice.
+// Com PopulateCache, os produtos vindos do banco são gravados no cache e
+// indexados por nome e categoria antes de a busca retornar. Com StrictCache,
+// falhas do Redis (leitura do índice ou gravação do PopulateCache) viram
+// repository.ErrCacheUnavailable em vez de fallback silencioso. Background
+// executa o read-through das buscas por nome, categoria e tag; nil usa uma
+// goroutine sem acompanhamento. Enquanto Backfill reconstrói os índices, os
+// de nome, categoria e tag ainda estão incompletos e as buscas vão ao banco.
+type SearchProductsOptions struct {
+	FallbackRecorder port.FallbackRecorder
+	CacheRecorder    port.CacheRecorder
+	PopulateCache    bool
+	StrictCache      bool
+	Background       port.BackgroundRunner
+	Backfill         port.CacheBackfiller
+}
+
 type SearchProductsByNameUseCase struct {
 	productRepo repository.ProductRepository
 	cacheRepo   repository.CacheRepository
 	cacheKeys   port.CacheKeyGenerator
 	logger      port.Logger
+	options     SearchProductsOptions
 }
 
 func NewSearchProductsByNameUseCase(
@@ -22,31 +47,60 @@ func NewSearchProductsByNameUseCase(
 	cacheKeys port.CacheKeyGenerator,
 	logger port.Logger,
 ) *SearchProductsByNameUseCase {
+	return NewSearchProductsByNameUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, logger, SearchProductsOptions{})
+}
+
+func NewSearchProductsByNameUseCaseWithOptions(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+	options SearchProductsOptions,
+) *SearchProductsByNameUseCase {
+	options.FallbackRecorder = fallbackRecorderOrNoop(options.FallbackRecorder)
+	options.CacheRecorder = cacheRecorderOrNoop(options.CacheRecorder)
+	options.Background = backgroundRunnerOrGo(options.Background)
+
 	return &SearchProductsByNameUseCase{
 		productRepo: productRepo,
 		cacheRepo:   cacheRepo,
 		cacheKeys:   cacheKeys,
 		logger:      logger,
+		options:     options,
 	}
 }
 
-func (uc *SearchProductsByNameUseCase) Execute(ctx context.Context, name string, limit, offset int) ([]*entity.Product, error) {
+// Execute aplica o filtro em memória quando o resultado vem do índice do nome
+// ou do read-through, que guardam todas as correspondências.
+func (uc *SearchProductsByNameUseCase) Execute(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 	uc.logger.Debug("searching products by name",
 		"name", name,
+		"order", order,
+		"sort", sort.Field,
+		"direction", sort.Direction,
 		"limit", limit,
 		"offset", offset,
 	)
 
-	products := uc.searchInCache(ctx, name)
+	products, cacheErr := uc.searchInCache(ctx, name)
+	if cacheErr != nil && uc.options.StrictCache {
+		return nil, cacheErr
+	}
 	if len(products) > 0 {
+		observeCacheHit(ctx, uc.options.CacheRecorder, "search_name")
+		products = utils.FilterProducts(products, filter)
+		sortByName(products, name, order, sort)
 		return utils.PaginateProducts(products, limit, offset), nil
 	}
 
+	observeCacheMiss(ctx, uc.options.CacheRecorder, "search_name")
 	uc.logger.Debug("cache miss - searching in database",
 		"name", name,
 	)
 
-	products, err := uc.productRepo.FindByName(ctx, name, limit, offset)
+	start := time.Now()
+	products, err := uc.searchInDatabase(ctx, name, filter, order, sort, limit, offset)
+	uc.options.FallbackRecorder.ObserveDBFallback("search_by_name", time.Since(start))
 	if err != nil {
 		uc.logger.Error("failed to search products by name in database",
 			"error", err,
@@ -55,15 +109,133 @@ func (uc *SearchProductsByNameUseCase) Execute(ctx context.Context, name string,
 		return nil, err
 	}
 
+	if uc.options.PopulateCache {
+		err := populateSearchCache(ctx, uc.cacheRepo, uc.cacheKeys, uc.logger, products)
+		if err != nil && uc.options.StrictCache {
+			return nil, err
+		}
+	}
+
 	return products, nil
 }
 
-func (uc *SearchProductsByNameUseCase) searchInCache(ctx context.Context, name string) []*entity.Product {
+// searchInDatabase carrega todas as correspondências quando elas cabem no
+// read-through, grava o cache em background e pagina em memória; senão, busca
+// só a página pedida. Com PopulateCache (aquecimento) a página já é gravada
+// por Execute.
+func (uc *SearchProductsByNameUseCase) searchInDatabase(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+	if uc.options.PopulateCache {
+		return uc.productRepo.FindByName(ctx, name, filter, order, sort, limit, offset)
+	}
+
+	total, err := uc.productRepo.CountByName(ctx, name, repository.ListFilter{})
+	if err != nil || total == 0 || total > readThroughLimit {
+		return uc.productRepo.FindByName(ctx, name, filter, order, sort, limit, offset)
+	}
+
+	products, err := uc.productRepo.FindByName(ctx, name, repository.ListFilter{}, order, sort, readThroughLimit+1, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(products) <= readThroughLimit {
+		uc.cacheName(ctx, name, products)
+	}
+
+	return utils.PaginateProducts(utils.FilterProducts(products, filter), limit, offset), nil
+}
+
+// cacheName grava em background as chaves dos produtos encontrados e indexa
+// no set do termo, de uma vez, os que têm exatamente esse nome (normalizado
+// pelo NameKey, como na leitura). Correspondências parciais não entram: o set é
+// mantido pelas escritas com o nome de cada produto, e um produto criado ou
+// renomeado depois nunca seria adicionado ou removido do set de outro termo.
+// Um termo com espaços nas pontas não é indexado, já que o LIKE pode ter
+// deixado de fora produtos com o mesmo nome normalizado. É best-effort: falhas
+// só são logadas. Se algum produto não for gravado, o índice não é escrito,
+// já que um set sem ele seria lido como completo; o produto que passou do
+// limite de tamanho é a exceção e segue indexado para a busca não perdê-lo.
+func (uc *SearchProductsByNameUseCase) cacheName(ctx context.Context, name string, products []*entity.Product) {
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), repopulateTimeout)
+	nameKey := uc.cacheKeys.NameKey(name)
+	indexable := name == strings.TrimSpace(name)
+
+	uc.options.Background.Go(func() {
+		defer cancel()
+
+		indexed := make([]string, 0, len(products))
+		for _, product := range products {
+			if err := uc.cacheRepo.Set(writeCtx, uc.cacheKeys.ProductKey(product.ID), product); err != nil && !skippedCacheWrite(uc.logger, err, product) {
+				uc.logger.Warn("failed to cache product from name search - skipping name index",
+					"error", err,
+					"product_id", product.HashID(),
+					"name", name,
+				)
+				return
+			}
+
+			if indexable && uc.cacheKeys.NameKey(product.Name) == nameKey {
+				indexed = append(indexed, product.ID)
+			}
+		}
+
+		if err := uc.cacheRepo.AddAllToSet(writeCtx, nameKey, indexed); err != nil {
+			uc.logger.Warn("failed to rebuild name index",
+				"error", err,
+				"name", name,
+			)
+			return
+		}
+
+		uc.logger.Debug("name search results cached after miss",
+			"name", name,
+			"count", len(products),
+			"indexed", len(indexed),
+		)
+	})
+}
+
+// sortByName ordena os produtos vindos do índice (um set, sem ordem) como o
+// FindByName ordenaria: por sort e, na relevância, antes pelas faixas de nome
+// igual, prefixo e conteúdo.
+func sortByName(products []*entity.Product, name string, order repository.NameSearchOrder, sort repository.SortOptions) {
+	utils.SortProducts(products, sort.WithDefaults(repository.SortByName, repository.SortAsc))
+	if order != repository.NameOrderRelevance {
+		return
+	}
+
+	term := strings.ToLower(name)
+	band := func(p *entity.Product) int {
+		productName := strings.ToLower(p.Name)
+		switch {
+		case productName == term:
+			return 0
+		case strings.HasPrefix(productName, term):
+			return 1
+		default:
+			return 2
+		}
+	}
+
+	slices.SortStableFunc(products, func(a, b *entity.Product) int {
+		return cmp.Compare(band(a), band(b))
+	})
+}
+
+// searchInCache devolve os produtos do índice; um miss (índice vazio ou
+// incompleto, ou ainda em reconstrução) não é erro, só falhas do Redis.
+func (uc *SearchProductsByNameUseCase) searchInCache(ctx context.Context, name string) ([]*entity.Product, error) {
+	if backfillRunning(uc.options.Backfill) {
+		return nil, nil
+	}
+
 	nameKey := uc.cacheKeys.NameKey(name)
 
 	productIDs, err := uc.cacheRepo.GetSet(ctx, nameKey)
-	if err != nil || len(productIDs) == 0 {
-		return nil
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", repository.ErrCacheUnavailable, err)
+	}
+	if len(productIDs) == 0 {
+		return nil, nil
 	}
 
 	keys := make([]string, len(productIDs))
@@ -76,11 +248,11 @@ func (uc *SearchProductsByNameUseCase) searchInCache(ctx context.Context, name s
 		uc.logger.Debug("failed to get products from cache",
 			"error", err,
 		)
-		return nil
+		return nil, fmt.Errorf("%w: %v", repository.ErrCacheUnavailable, err)
 	}
 
 	if len(products) < len(productIDs) {
-		return nil
+		return nil, nil
 	}
 
 	uc.logger.Debug("cache hit for name search",
@@ -88,5 +260,5 @@ func (uc *SearchProductsByNameUseCase) searchInCache(ctx context.Context, name s
 		"count", len(products),
 	)
 
-	return products
+	return products, nil
 }
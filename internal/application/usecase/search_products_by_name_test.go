@@ -3,9 +3,11 @@ package usecase
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 
 	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
 )
 
 func TestSearchProductsByNameUseCase_Execute_CacheHit(t *testing.T) {
@@ -31,7 +33,7 @@ func TestSearchProductsByNameUseCase_Execute_CacheHit(t *testing.T) {
 	logger := &MockLogger{}
 	uc := NewSearchProductsByNameUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), "iPhone", 10, 0)
+	result, err := uc.Execute(context.Background(), "iPhone", repository.ListFilter{}, repository.NameOrderAlphabetical, repository.SortOptions{}, 10, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -50,7 +52,7 @@ func TestSearchProductsByNameUseCase_Execute_CacheMiss_DatabaseSuccess(t *testin
 	dbCalled := false
 
 	mockProductRepo := &MockProductRepository{
-		FindByNameFunc: func(ctx context.Context, name string, limit, offset int) ([]*entity.Product, error) {
+		FindByNameFunc: func(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 			dbCalled = true
 			if name == "Samsung" {
 				return products, nil
@@ -69,7 +71,7 @@ func TestSearchProductsByNameUseCase_Execute_CacheMiss_DatabaseSuccess(t *testin
 	logger := &MockLogger{}
 	uc := NewSearchProductsByNameUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), "Samsung", 10, 0)
+	result, err := uc.Execute(context.Background(), "Samsung", repository.ListFilter{}, repository.NameOrderAlphabetical, repository.SortOptions{}, 10, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -84,11 +86,40 @@ func TestSearchProductsByNameUseCase_Execute_CacheMiss_DatabaseSuccess(t *testin
 	}
 }
 
+func TestSearchProductsByNameUseCase_Execute_SkipsCacheDuringBackfill(t *testing.T) {
+	dbCalled := false
+	mockProductRepo := &MockProductRepository{
+		FindByNameFunc: func(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			dbCalled = true
+			return []*entity.Product{{ID: "A"}, {ID: "B"}}, nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			t.Error("Expected a partial index not to be read during backfill")
+			return []string{"A"}, nil
+		},
+	}
+
+	uc := NewSearchProductsByNameUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, SearchProductsOptions{
+		Backfill: &stubBackfiller{running: true},
+	})
+
+	result, err := uc.Execute(context.Background(), "Samsung", repository.ListFilter{}, repository.NameOrderAlphabetical, repository.SortOptions{}, 10, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !dbCalled || len(result) != 2 {
+		t.Errorf("Expected search during backfill to be served from the database, got %d products", len(result))
+	}
+}
+
 func TestSearchProductsByNameUseCase_Execute_DatabaseError(t *testing.T) {
 	dbError := errors.New("database error")
 
 	mockProductRepo := &MockProductRepository{
-		FindByNameFunc: func(ctx context.Context, name string, limit, offset int) ([]*entity.Product, error) {
+		FindByNameFunc: func(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 			return nil, dbError
 		},
 	}
@@ -103,7 +134,7 @@ func TestSearchProductsByNameUseCase_Execute_DatabaseError(t *testing.T) {
 	logger := &MockLogger{}
 	uc := NewSearchProductsByNameUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), "Product", 10, 0)
+	result, err := uc.Execute(context.Background(), "Product", repository.ListFilter{}, repository.NameOrderAlphabetical, repository.SortOptions{}, 10, 0)
 
 	if err == nil {
 		t.Error("Expected error, got nil")
@@ -122,7 +153,7 @@ func TestSearchProductsByNameUseCase_Execute_CacheError_FallbackToDatabase(t *te
 	dbCalled := false
 
 	mockProductRepo := &MockProductRepository{
-		FindByNameFunc: func(ctx context.Context, name string, limit, offset int) ([]*entity.Product, error) {
+		FindByNameFunc: func(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 			dbCalled = true
 			return products, nil
 		},
@@ -138,7 +169,7 @@ func TestSearchProductsByNameUseCase_Execute_CacheError_FallbackToDatabase(t *te
 	logger := &MockLogger{}
 	uc := NewSearchProductsByNameUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), "Product", 10, 0)
+	result, err := uc.Execute(context.Background(), "Product", repository.ListFilter{}, repository.NameOrderAlphabetical, repository.SortOptions{}, 10, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -159,7 +190,7 @@ func TestSearchProductsByNameUseCase_Execute_PartialCacheMiss(t *testing.T) {
 	dbCalled := false
 
 	mockProductRepo := &MockProductRepository{
-		FindByNameFunc: func(ctx context.Context, name string, limit, offset int) ([]*entity.Product, error) {
+		FindByNameFunc: func(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 			dbCalled = true
 			return []*entity.Product{product}, nil
 		},
@@ -178,7 +209,7 @@ func TestSearchProductsByNameUseCase_Execute_PartialCacheMiss(t *testing.T) {
 	logger := &MockLogger{}
 	uc := NewSearchProductsByNameUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), "Product", 10, 0)
+	result, err := uc.Execute(context.Background(), "Product", repository.ListFilter{}, repository.NameOrderAlphabetical, repository.SortOptions{}, 10, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -218,7 +249,7 @@ func TestSearchProductsByNameUseCase_Execute_Pagination(t *testing.T) {
 	logger := &MockLogger{}
 	uc := NewSearchProductsByNameUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), "Product", 2, 0)
+	result, err := uc.Execute(context.Background(), "Product", repository.ListFilter{}, repository.NameOrderAlphabetical, repository.SortOptions{}, 2, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -228,7 +259,7 @@ func TestSearchProductsByNameUseCase_Execute_Pagination(t *testing.T) {
 		t.Errorf("Expected 2 products with limit=2, got %d", len(result))
 	}
 
-	result, err = uc.Execute(context.Background(), "Product", 2, 2)
+	result, err = uc.Execute(context.Background(), "Product", repository.ListFilter{}, repository.NameOrderAlphabetical, repository.SortOptions{}, 2, 2)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -241,7 +272,7 @@ func TestSearchProductsByNameUseCase_Execute_Pagination(t *testing.T) {
 
 func TestSearchProductsByNameUseCase_Execute_EmptyResult(t *testing.T) {
 	mockProductRepo := &MockProductRepository{
-		FindByNameFunc: func(ctx context.Context, name string, limit, offset int) ([]*entity.Product, error) {
+		FindByNameFunc: func(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 			return []*entity.Product{}, nil
 		},
 	}
@@ -256,7 +287,7 @@ func TestSearchProductsByNameUseCase_Execute_EmptyResult(t *testing.T) {
 	logger := &MockLogger{}
 	uc := NewSearchProductsByNameUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), "NonExistent", 10, 0)
+	result, err := uc.Execute(context.Background(), "NonExistent", repository.ListFilter{}, repository.NameOrderAlphabetical, repository.SortOptions{}, 10, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -273,7 +304,7 @@ func TestSearchProductsByNameUseCase_Execute_GetMultipleError(t *testing.T) {
 	dbCalled := false
 
 	mockProductRepo := &MockProductRepository{
-		FindByNameFunc: func(ctx context.Context, name string, limit, offset int) ([]*entity.Product, error) {
+		FindByNameFunc: func(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
 			dbCalled = true
 			return []*entity.Product{product}, nil
 		},
@@ -292,7 +323,7 @@ func TestSearchProductsByNameUseCase_Execute_GetMultipleError(t *testing.T) {
 	logger := &MockLogger{}
 	uc := NewSearchProductsByNameUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	result, err := uc.Execute(context.Background(), "Product", 10, 0)
+	result, err := uc.Execute(context.Background(), "Product", repository.ListFilter{}, repository.NameOrderAlphabetical, repository.SortOptions{}, 10, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -329,7 +360,7 @@ func TestSearchProductsByNameUseCase_Execute_CacheKeyGeneration(t *testing.T) {
 	logger := &MockLogger{}
 	uc := NewSearchProductsByNameUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
 
-	_, err := uc.Execute(context.Background(), "IPHONE", 10, 0)
+	_, err := uc.Execute(context.Background(), "IPHONE", repository.ListFilter{}, repository.NameOrderAlphabetical, repository.SortOptions{}, 10, 0)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -339,3 +370,252 @@ func TestSearchProductsByNameUseCase_Execute_CacheKeyGeneration(t *testing.T) {
 		t.Errorf("Expected key 'product_by_name_IPHONE', got '%s'", calledWithKey)
 	}
 }
+
+func TestSearchProductsByNameUseCase_Execute_PassesOrderToDatabase(t *testing.T) {
+	var gotOrder repository.NameSearchOrder
+
+	mockProductRepo := &MockProductRepository{
+		FindByNameFunc: func(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			gotOrder = order
+			return []*entity.Product{}, nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			return []string{}, nil
+		},
+	}
+
+	mockCacheKeys := &MockCacheKeyGenerator{}
+	logger := &MockLogger{}
+	uc := NewSearchProductsByNameUseCase(mockProductRepo, mockCacheRepo, mockCacheKeys, logger)
+
+	if _, err := uc.Execute(context.Background(), "phone", repository.ListFilter{}, repository.NameOrderRelevance, repository.SortOptions{}, 10, 0); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if gotOrder != repository.NameOrderRelevance {
+		t.Errorf("Expected relevance order to reach the repository, got %q", gotOrder)
+	}
+}
+
+func TestSearchProductsByNameUseCase_Execute_CacheHitHonorsRelevance(t *testing.T) {
+	contains := newTestProductWithData("Smartphone", "REF-001", "Phones")
+	prefix := newTestProductWithData("Phone Case", "REF-002", "Accessories")
+	exact := newTestProductWithData("phone", "REF-003", "Phones")
+	alsoPrefix := newTestProductWithData("Phone Charger", "REF-004", "Accessories")
+
+	mockCacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			return []string{contains.ID, prefix.ID, exact.ID, alsoPrefix.ID}, nil
+		},
+		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
+			return []*entity.Product{contains, prefix, exact, alsoPrefix}, nil
+		},
+	}
+
+	uc := NewSearchProductsByNameUseCase(&MockProductRepository{}, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	sort := repository.SortOptions{Direction: repository.SortDesc}
+	result, err := uc.Execute(context.Background(), "Phone", repository.ListFilter{}, repository.NameOrderRelevance, sort, 10, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := []*entity.Product{exact, alsoPrefix, prefix, contains}
+	for i := range want {
+		if result[i] != want[i] {
+			t.Fatalf("Expected exact, prefix (name descending) then contains, got %v at position %d", result[i].Name, i)
+		}
+	}
+}
+
+func TestSearchProductsByNameUseCase_Execute_ReadThrough(t *testing.T) {
+	exact := newTestProductWithData("Dell", "REF-001", "Laptops")
+	prefix := newTestProductWithData("Dell XPS", "REF-002", "Laptops")
+	contains := newTestProductWithData("Notebook Dell", "REF-003", "Laptops")
+	products := []*entity.Product{exact, prefix, contains}
+
+	var queried [2]int
+	mockProductRepo := &MockProductRepository{
+		CountByNameFunc: func(ctx context.Context, name string, filter repository.ListFilter) (int, error) {
+			return len(products), nil
+		},
+		FindByNameFunc: func(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			queried = [2]int{limit, offset}
+			return products, nil
+		},
+	}
+
+	var mu sync.Mutex
+	cachedKeys := make(map[string]bool)
+	indexed := make(map[string][]string)
+	mockCacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			return []string{}, nil
+		},
+		SetFunc: func(ctx context.Context, key string, product *entity.Product) error {
+			mu.Lock()
+			defer mu.Unlock()
+			cachedKeys[key] = true
+			return nil
+		},
+		AddAllToSetFunc: func(ctx context.Context, setKey string, productIDs []string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			indexed[setKey] = append(indexed[setKey], productIDs...)
+			return nil
+		},
+	}
+
+	tasks := NewBackgroundTasks()
+	uc := NewSearchProductsByNameUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, SearchProductsOptions{
+		Background: tasks,
+	})
+
+	result, err := uc.Execute(context.Background(), "Dell", repository.ListFilter{}, repository.NameOrderRelevance, repository.SortOptions{}, 2, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := tasks.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected cache writes to finish, got %v", err)
+	}
+
+	if queried != [2]int{readThroughLimit + 1, 0} {
+		t.Errorf("Expected all matches to be loaded, got limit/offset %v", queried)
+	}
+	if len(result) != 2 || result[0] != exact || result[1] != prefix {
+		t.Errorf("Expected the requested page paginated in memory, got %d products", len(result))
+	}
+
+	if len(cachedKeys) != 3 {
+		t.Errorf("Expected every match cached by product key, got %v", cachedKeys)
+	}
+	if len(indexed) != 1 {
+		t.Errorf("Expected only the search term index to be written, got %v", indexed)
+	}
+	index := indexed["product_by_name_Dell"]
+	if len(index) != 1 || index[0] != exact.ID {
+		t.Errorf("Expected only the exact name match in the name index, got %v", index)
+	}
+}
+
+func TestSearchProductsByNameUseCase_Execute_ReadThroughSkipsIndexAfterFailedWrite(t *testing.T) {
+	products := []*entity.Product{
+		newTestProductWithData("Dell", "REF-001", "Laptops"),
+		newTestProductWithData("Dell", "REF-002", "Monitors"),
+	}
+
+	mockProductRepo := &MockProductRepository{
+		CountByNameFunc: func(ctx context.Context, name string, filter repository.ListFilter) (int, error) {
+			return len(products), nil
+		},
+		FindByNameFunc: func(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			return products, nil
+		},
+	}
+
+	indexWritten := false
+	mockCacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			return []string{}, nil
+		},
+		SetFunc: func(ctx context.Context, key string, product *entity.Product) error {
+			if product.Category == "Monitors" {
+				return errors.New("redis down")
+			}
+			return nil
+		},
+		AddAllToSetFunc: func(ctx context.Context, setKey string, productIDs []string) error {
+			indexWritten = true
+			return nil
+		},
+	}
+
+	tasks := NewBackgroundTasks()
+	uc := NewSearchProductsByNameUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, SearchProductsOptions{
+		Background: tasks,
+	})
+
+	if _, err := uc.Execute(context.Background(), "Dell", repository.ListFilter{}, repository.NameOrderRelevance, repository.SortOptions{}, 10, 0); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := tasks.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected cache writes to finish, got %v", err)
+	}
+
+	if indexWritten {
+		t.Error("Expected the name index not to be written after a failed product write")
+	}
+}
+
+func TestSearchProductsByNameUseCase_Execute_ReadThroughSkipsUntrimmedTermIndex(t *testing.T) {
+	mockProductRepo := &MockProductRepository{
+		CountByNameFunc: func(ctx context.Context, name string, filter repository.ListFilter) (int, error) {
+			return 1, nil
+		},
+		FindByNameFunc: func(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			return []*entity.Product{newTestProductWithData(" Dell ", "REF-001", "Laptops")}, nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			return []string{}, nil
+		},
+		AddAllToSetFunc: func(ctx context.Context, setKey string, productIDs []string) error {
+			if len(productIDs) > 0 {
+				t.Errorf("Expected no index for an untrimmed term, got %v in %s", productIDs, setKey)
+			}
+			return nil
+		},
+	}
+
+	tasks := NewBackgroundTasks()
+	uc := NewSearchProductsByNameUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, SearchProductsOptions{
+		Background: tasks,
+	})
+
+	if _, err := uc.Execute(context.Background(), " Dell ", repository.ListFilter{}, repository.NameOrderAlphabetical, repository.SortOptions{}, 10, 0); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_ = tasks.Wait(context.Background())
+}
+
+func TestSearchProductsByNameUseCase_Execute_LargeResultSkipsReadThrough(t *testing.T) {
+	var queried [2]int
+	mockProductRepo := &MockProductRepository{
+		CountByNameFunc: func(ctx context.Context, name string, filter repository.ListFilter) (int, error) {
+			return readThroughLimit + 1, nil
+		},
+		FindByNameFunc: func(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			queried = [2]int{limit, offset}
+			return []*entity.Product{newTestProductWithData("Dell", "REF-001", "Laptops")}, nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			return []string{}, nil
+		},
+		AddAllToSetFunc: func(ctx context.Context, setKey string, productIDs []string) error {
+			t.Error("Expected no partial name index")
+			return nil
+		},
+	}
+
+	tasks := NewBackgroundTasks()
+	uc := NewSearchProductsByNameUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, SearchProductsOptions{
+		Background: tasks,
+	})
+
+	if _, err := uc.Execute(context.Background(), "Dell", repository.ListFilter{}, repository.NameOrderAlphabetical, repository.SortOptions{}, 10, 20); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_ = tasks.Wait(context.Background())
+
+	if queried != [2]int{10, 20} {
+		t.Errorf("Expected only the requested page from the database, got %v", queried)
+	}
+}
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/application/utils"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// SearchProductsByTagUseCase busca produtos pela tag, primeiro no índice
+// product_by_tag_* e depois no banco.
+type SearchProductsByTagUseCase struct {
+	productRepo repository.ProductRepository
+	cacheRepo   repository.CacheRepository
+	cacheKeys   port.CacheKeyGenerator
+	logger      port.Logger
+	options     SearchProductsOptions
+}
+
+func NewSearchProductsByTagUseCase(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+) *SearchProductsByTagUseCase {
+	return NewSearchProductsByTagUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, logger, SearchProductsOptions{})
+}
+
+func NewSearchProductsByTagUseCaseWithOptions(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+	options SearchProductsOptions,
+) *SearchProductsByTagUseCase {
+	options.FallbackRecorder = fallbackRecorderOrNoop(options.FallbackRecorder)
+	options.CacheRecorder = cacheRecorderOrNoop(options.CacheRecorder)
+	options.Background = backgroundRunnerOrGo(options.Background)
+
+	return &SearchProductsByTagUseCase{
+		productRepo: productRepo,
+		cacheRepo:   cacheRepo,
+		cacheKeys:   cacheKeys,
+		logger:      logger,
+		options:     options,
+	}
+}
+
+func (uc *SearchProductsByTagUseCase) Execute(ctx context.Context, tag string, limit, offset int) ([]*entity.Product, error) {
+	tag = entity.NormalizeTag(tag)
+
+	uc.logger.Debug("searching products by tag",
+		"tag", tag,
+		"limit", limit,
+		"offset", offset,
+	)
+
+	products, cacheErr := uc.searchInCache(ctx, tag)
+	if cacheErr != nil && uc.options.StrictCache {
+		return nil, cacheErr
+	}
+	if len(products) > 0 {
+		observeCacheHit(ctx, uc.options.CacheRecorder, "search_tag")
+		return utils.PaginateProducts(products, limit, offset), nil
+	}
+
+	observeCacheMiss(ctx, uc.options.CacheRecorder, "search_tag")
+	uc.logger.Debug("cache miss - searching in database",
+		"tag", tag,
+	)
+
+	start := time.Now()
+	products, err := uc.searchInDatabase(ctx, tag, limit, offset)
+	uc.options.FallbackRecorder.ObserveDBFallback("search_by_tag", time.Since(start))
+	if err != nil {
+		uc.logger.Error("failed to search products by tag in database",
+			"error", err,
+			"tag", tag,
+		)
+		return nil, err
+	}
+
+	if uc.options.PopulateCache {
+		err := populateSearchCache(ctx, uc.cacheRepo, uc.cacheKeys, uc.logger, products)
+		if err != nil && uc.options.StrictCache {
+			return nil, err
+		}
+	}
+
+	return products, nil
+}
+
+// searchInDatabase segue a busca por categoria: carrega a tag inteira quando
+// ela cabe no read-through, grava o cache em background e pagina em memória;
+// senão, busca só a página pedida.
+func (uc *SearchProductsByTagUseCase) searchInDatabase(ctx context.Context, tag string, limit, offset int) ([]*entity.Product, error) {
+	if uc.options.PopulateCache {
+		return uc.productRepo.FindByTag(ctx, tag, limit, offset)
+	}
+
+	total, err := uc.productRepo.CountByTag(ctx, tag)
+	if err != nil || total == 0 || total > readThroughLimit {
+		return uc.productRepo.FindByTag(ctx, tag, limit, offset)
+	}
+
+	products, err := uc.productRepo.FindByTag(ctx, tag, readThroughLimit+1, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(products) <= readThroughLimit {
+		uc.cacheTag(ctx, tag, products)
+	}
+
+	return utils.PaginateProducts(products, limit, offset), nil
+}
+
+// cacheTag grava em background as chaves dos produtos e reconstrói o índice
+// da tag de uma vez. É best-effort, e um produto que não foi gravado fica fora
+// do índice, exceto o que passou do limite de tamanho, que segue indexado.
+func (uc *SearchProductsByTagUseCase) cacheTag(ctx context.Context, tag string, products []*entity.Product) {
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), repopulateTimeout)
+	tagKey := uc.cacheKeys.TagKey(tag)
+
+	uc.options.Background.Go(func() {
+		defer cancel()
+
+		cached := make([]string, 0, len(products))
+		for _, product := range products {
+			if err := uc.cacheRepo.Set(writeCtx, uc.cacheKeys.ProductKey(product.ID), product); err != nil && !skippedCacheWrite(uc.logger, err, product) {
+				uc.logger.Warn("failed to cache product from tag search",
+					"error", err,
+					"product_id", product.HashID(),
+				)
+				continue
+			}
+			cached = append(cached, product.ID)
+		}
+
+		if err := uc.cacheRepo.AddAllToSet(writeCtx, tagKey, cached); err != nil {
+			uc.logger.Warn("failed to rebuild tag index",
+				"error", err,
+				"tag", tag,
+			)
+			return
+		}
+
+		uc.logger.Debug("tag cached after search miss",
+			"tag", tag,
+			"count", len(cached),
+		)
+	})
+}
+
+// searchInCache devolve os produtos do índice; um miss (índice vazio ou
+// incompleto, ou ainda em reconstrução) não é erro, só falhas do Redis.
+func (uc *SearchProductsByTagUseCase) searchInCache(ctx context.Context, tag string) ([]*entity.Product, error) {
+	if backfillRunning(uc.options.Backfill) {
+		return nil, nil
+	}
+
+	tagKey := uc.cacheKeys.TagKey(tag)
+
+	productIDs, err := uc.cacheRepo.GetSet(ctx, tagKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", repository.ErrCacheUnavailable, err)
+	}
+	if len(productIDs) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(productIDs))
+	for i, id := range productIDs {
+		keys[i] = uc.cacheKeys.ProductKey(id)
+	}
+
+	products, err := uc.cacheRepo.GetMultiple(ctx, keys)
+	if err != nil {
+		uc.logger.Debug("failed to get products from cache",
+			"error", err,
+		)
+		return nil, fmt.Errorf("%w: %v", repository.ErrCacheUnavailable, err)
+	}
+
+	if len(products) < len(productIDs) {
+		return nil, nil
+	}
+
+	uc.logger.Debug("cache hit for tag search",
+		"tag", tag,
+		"count", len(products),
+	)
+
+	return products, nil
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+)
+
+func TestSearchProductsByTagUseCase_Execute_CacheHit(t *testing.T) {
+	products := []*entity.Product{
+		newTestProductWithData("iPhone 15", "REF-001", "Smartphones"),
+		newTestProductWithData("Samsung Galaxy", "REF-002", "Smartphones"),
+	}
+
+	mockProductRepo := &MockProductRepository{
+		FindByTagFunc: func(ctx context.Context, tag string, limit, offset int) ([]*entity.Product, error) {
+			t.Error("Expected database not to be called on cache hit")
+			return nil, nil
+		},
+	}
+	mockCacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			if setKey == "product_by_tag_promo" {
+				return []string{products[0].ID, products[1].ID}, nil
+			}
+			return []string{}, nil
+		},
+		GetMultipleFunc: func(ctx context.Context, keys []string) ([]*entity.Product, error) {
+			return products, nil
+		},
+	}
+
+	uc := NewSearchProductsByTagUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	result, err := uc.Execute(context.Background(), "  Promo ", 10, 0)
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if len(result) != 2 {
+		t.Errorf("Expected 2 products, got %d", len(result))
+	}
+}
+
+func TestSearchProductsByTagUseCase_Execute_CacheMiss_DatabaseSuccess(t *testing.T) {
+	product := newTestProductWithData("MacBook Pro", "REF-001", "Laptops")
+	var searchedTag string
+
+	mockProductRepo := &MockProductRepository{
+		FindByTagFunc: func(ctx context.Context, tag string, limit, offset int) ([]*entity.Product, error) {
+			searchedTag = tag
+			return []*entity.Product{product}, nil
+		},
+	}
+	mockCacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			return []string{}, nil
+		},
+	}
+
+	uc := NewSearchProductsByTagUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	result, err := uc.Execute(context.Background(), "Apple", 10, 0)
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if searchedTag != "apple" {
+		t.Errorf("Expected normalized tag 'apple', got %q", searchedTag)
+	}
+
+	if len(result) != 1 {
+		t.Errorf("Expected 1 product, got %d", len(result))
+	}
+}
+
+func TestSearchProductsByTagUseCase_Execute_ExpiredIndexIsRebuilt(t *testing.T) {
+	products := []*entity.Product{
+		newTestProductWithData("iPhone 15", "REF-001", "Smartphones"),
+		newTestProductWithData("Galaxy S24", "REF-002", "Smartphones"),
+	}
+
+	var queried [2]int
+	mockProductRepo := &MockProductRepository{
+		CountByTagFunc: func(ctx context.Context, tag string) (int, error) {
+			return len(products), nil
+		},
+		FindByTagFunc: func(ctx context.Context, tag string, limit, offset int) ([]*entity.Product, error) {
+			queried = [2]int{limit, offset}
+			return products, nil
+		},
+	}
+
+	var mu sync.Mutex
+	var rebuiltKey string
+	var rebuilt []string
+	mockCacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			return []string{}, nil
+		},
+		AddAllToSetFunc: func(ctx context.Context, setKey string, productIDs []string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			rebuiltKey = setKey
+			rebuilt = productIDs
+			return nil
+		},
+	}
+
+	tasks := NewBackgroundTasks()
+	uc := NewSearchProductsByTagUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, SearchProductsOptions{
+		Background: tasks,
+	})
+
+	result, err := uc.Execute(context.Background(), "Promo", 1, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := tasks.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected cache writes to finish, got %v", err)
+	}
+
+	if queried != [2]int{readThroughLimit + 1, 0} {
+		t.Errorf("Expected the whole tag to be loaded, got limit/offset %v", queried)
+	}
+	if len(result) != 1 || result[0] != products[1] {
+		t.Errorf("Expected the requested page paginated in memory, got %d products", len(result))
+	}
+	if rebuiltKey != "product_by_tag_promo" || len(rebuilt) != 2 {
+		t.Errorf("Expected the tag index rebuilt with every product, got %s %v", rebuiltKey, rebuilt)
+	}
+}
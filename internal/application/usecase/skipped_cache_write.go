@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// skippedCacheWrite indica se o Set falhou só porque o produto serializado
+// passa do limite de tamanho do cache ou porque o Redis está sem memória,
+// registrando o descarte. Nos dois casos o produto segue nos índices e as
+// leituras dele vão ao banco; o OOM já é contado pela métrica do cache, então
+// fica em debug para não inundar o log enquanto durar.
+func skippedCacheWrite(logger port.Logger, err error, product *entity.Product) bool {
+	switch {
+	case errors.Is(err, repository.ErrCacheEntryTooLarge):
+		logger.Info("product not cached: serialized size above limit",
+			"error", err,
+			"product_id", product.HashID(),
+		)
+	case errors.Is(err, repository.ErrCacheOutOfMemory):
+		logger.Debug("product not cached: cache out of memory",
+			"error", err,
+			"product_id", product.HashID(),
+		)
+	default:
+		return false
+	}
+	return true
+}
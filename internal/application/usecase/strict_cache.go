@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StrictCacheWarm é o aquecimento de buscas do admin (POST /api/v1/admin/warm).
+const StrictCacheWarm = "warm"
+
+// strictCacheSupported lista as operações que honram o modo estrito.
+var strictCacheSupported = []string{StrictCacheWarm}
+
+// StrictCacheOperations são as operações em que uma falha do Redis vira erro
+// (HTTP 500) em vez de fallback para o banco. As demais mantêm o padrão
+// tolerante.
+type StrictCacheOperations map[string]bool
+
+// ParseStrictCacheOperations valida os nomes configurados; nomes
+// desconhecidos são erro para que um typo não desligue o modo estrito em
+// silêncio.
+func ParseStrictCacheOperations(names []string) (StrictCacheOperations, error) {
+	ops := StrictCacheOperations{}
+
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		supported := false
+		for _, op := range strictCacheSupported {
+			if op == name {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return nil, fmt.Errorf("unknown strict cache operation %q (valid: %s)", name, strings.Join(strictCacheSupported, ", "))
+		}
+
+		ops[name] = true
+	}
+
+	return ops, nil
+}
+
+func (s StrictCacheOperations) Has(op string) bool {
+	return s[op]
+}
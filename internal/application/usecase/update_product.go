@@ -4,17 +4,41 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/dowglassantana/product-redis-api/internal/application/port"
 	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
 	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
 )
 
+// UpdateProductOptions ajusta o comportamento da atualização de produto.
+//
+// SerializationRetries é quantas vezes a escrita é repetida quando o banco a
+// aborta por conflito de serialização (isolamento REPEATABLE READ ou
+// SERIALIZABLE), com espera crescente de RetryBackoff entre as tentativas.
+//
+// AllowedCategories restringe a categoria quando ela é alterada; produtos
+// existentes mantêm a categoria atual enquanto ela não mudar.
+//
+// MaxSpecDepth limita o aninhamento das especificações; zero desativa.
+//
+// Events recebe um product.updated por atualização gravada; nil descarta.
+type UpdateProductOptions struct {
+	SerializationRetries int
+	RetryBackoff         time.Duration
+	AllowedCategories    entity.CategorySet
+	PriceRounding        entity.PriceRounding
+	MaxSpecDepth         int
+	Events               port.ChangePublisher
+	Audit                port.AuditLogger
+}
+
 type UpdateProductUseCase struct {
 	productRepo repository.ProductRepository
 	cacheRepo   repository.CacheRepository
 	cacheKeys   port.CacheKeyGenerator
 	logger      port.Logger
+	options     UpdateProductOptions
 }
 
 func NewUpdateProductUseCase(
@@ -23,15 +47,39 @@ func NewUpdateProductUseCase(
 	cacheKeys port.CacheKeyGenerator,
 	logger port.Logger,
 ) *UpdateProductUseCase {
+	return NewUpdateProductUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, logger, UpdateProductOptions{})
+}
+
+func NewUpdateProductUseCaseWithOptions(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+	options UpdateProductOptions,
+) *UpdateProductUseCase {
+	options.Events = changePublisherOrNoop(options.Events)
+	options.Audit = auditLoggerOrNoop(options.Audit)
+
 	return &UpdateProductUseCase{
 		productRepo: productRepo,
 		cacheRepo:   cacheRepo,
 		cacheKeys:   cacheKeys,
 		logger:      logger,
+		options:     options,
 	}
 }
 
 func (uc *UpdateProductUseCase) Execute(ctx context.Context, id string, input port.UpdateProductInput) (*entity.Product, error) {
+	return uc.execute(ctx, id, func(*entity.Product) (port.UpdateProductInput, error) {
+		return input, nil
+	})
+}
+
+// execute monta a entrada a partir do produto atual com build e grava o
+// resultado condicionado à versão lida, então a entrada nunca é aplicada sobre
+// uma versão diferente da que build viu. Com input.ExpectedVersion, a versão
+// condicionada é a do cliente, e a divergência vira ErrPreconditionFailed.
+func (uc *UpdateProductUseCase) execute(ctx context.Context, id string, build func(current *entity.Product) (port.UpdateProductInput, error)) (*entity.Product, error) {
 	uc.logger.Info("attempting to update product",
 		"product_id", id[:min(8, len(id))],
 	)
@@ -41,11 +89,34 @@ func (uc *UpdateProductUseCase) Execute(ctx context.Context, id string, input po
 		return nil, err
 	}
 
+	input, err := build(currentProduct)
+	if err != nil {
+		return nil, err
+	}
+
+	conditional := input.ExpectedVersion > 0
+	if conditional && input.ExpectedVersion != currentProduct.Version {
+		uc.logger.Warn("precondition failed on update",
+			"product_id", id[:min(8, len(id))],
+			"expected_version", input.ExpectedVersion,
+			"current_version", currentProduct.Version,
+		)
+		return nil, fmt.Errorf("product is at version %d: %w", currentProduct.Version, entity.ErrPreconditionFailed)
+	}
+
 	oldCategory := currentProduct.Category
 	oldName := currentProduct.Name
+	oldTags := currentProduct.Tags
+	oldSKU := currentProduct.SKU
 	expectedVersion := currentProduct.Version
+	if conditional {
+		expectedVersion = input.ExpectedVersion
+	}
 
 	updatedProduct := *currentProduct
+	invalid := entity.ValidationErrors{}
+	price, priceErr := entity.ResolvePrice(input.Price, input.PriceAmount, input.Currency, uc.options.PriceRounding)
+	invalid.Add("price", priceErr)
 	err = updatedProduct.Update(
 		input.Name,
 		input.Category,
@@ -53,15 +124,29 @@ func (uc *UpdateProductUseCase) Execute(ctx context.Context, id string, input po
 		input.SKU,
 		input.Brand,
 		input.Stock,
+		price,
+		input.Currency,
 		input.Images,
 		input.Specifications,
 	)
-	if err != nil {
-		uc.logger.Error("failed to validate updated product",
-			"error", err,
+	if err != nil && !errors.As(err, &invalid) {
+		return nil, fmt.Errorf("invalid product data: %w", err)
+	}
+	invalid.Add("thumbnail_url", updatedProduct.SetThumbnailURL(input.ThumbnailURL))
+	invalid.Add("tags", updatedProduct.SetTags(input.Tags))
+	invalid.Add("weight_grams", updatedProduct.SetWeight(input.WeightGrams))
+	invalid.Add("dimensions", updatedProduct.SetDimensions(input.Dimensions))
+	invalid.Add("specifications", entity.CheckSpecificationsDepth(updatedProduct.Specifications, uc.options.MaxSpecDepth))
+	if entity.NormalizeCategory(updatedProduct.Category) != entity.NormalizeCategory(oldCategory) {
+		invalid.Add("category", uc.options.AllowedCategories.Check(updatedProduct.Category))
+	}
+
+	if len(invalid) > 0 {
+		uc.logger.Warn("invalid product data",
+			"fields", invalid.Messages(),
 			"product_id", id[:min(8, len(id))],
 		)
-		return nil, fmt.Errorf("invalid product data: %w", err)
+		return nil, fmt.Errorf("invalid product data: %w", invalid)
 	}
 
 	if currentProduct.Equals(&updatedProduct) {
@@ -71,12 +156,15 @@ func (uc *UpdateProductUseCase) Execute(ctx context.Context, id string, input po
 		return currentProduct, nil
 	}
 
-	if err := uc.productRepo.Update(ctx, &updatedProduct, expectedVersion); err != nil {
+	if err := uc.saveWithRetry(ctx, &updatedProduct, expectedVersion); err != nil {
 		if errors.Is(err, repository.ErrVersionConflict) {
 			uc.logger.Warn("version conflict detected",
 				"product_id", id[:min(8, len(id))],
 				"expected_version", expectedVersion,
 			)
+			if conditional {
+				return nil, fmt.Errorf("product was modified by another process: %w", entity.ErrPreconditionFailed)
+			}
 			return nil, fmt.Errorf("product was modified by another process: %w", err)
 		}
 
@@ -92,11 +180,50 @@ func (uc *UpdateProductUseCase) Execute(ctx context.Context, id string, input po
 		"new_version", updatedProduct.Version,
 	)
 
-	uc.updateCache(ctx, &updatedProduct, oldCategory, oldName)
+	uc.options.Audit.Record(ctx, entity.AuditUpdate, updatedProduct.ID, entity.DiffProducts(currentProduct, &updatedProduct))
+	uc.updateCache(ctx, &updatedProduct, oldCategory, oldName, oldSKU, oldTags)
+	uc.options.Events.Publish(port.ChangeEvent{
+		Type:       port.ProductUpdated,
+		ProductID:  updatedProduct.ID,
+		Version:    updatedProduct.Version,
+		OccurredAt: updatedProduct.UpdatedAt,
+	})
 
 	return &updatedProduct, nil
 }
 
+// saveWithRetry repete o Update enquanto o banco reportar conflito de
+// serialização, até o limite configurado.
+func (uc *UpdateProductUseCase) saveWithRetry(ctx context.Context, product *entity.Product, expectedVersion int) error {
+	return retrySerialization(ctx, uc.logger, "update", product.ID, uc.options.SerializationRetries, uc.options.RetryBackoff, func() error {
+		return uc.productRepo.Update(ctx, product, expectedVersion)
+	})
+}
+
+// retrySerialization repete write enquanto ela falhar com
+// repository.ErrSerializationFailure, até retries vezes, esperando
+// backoff × tentativa entre elas.
+func retrySerialization(ctx context.Context, logger port.Logger, operation, id string, retries int, backoff time.Duration, write func() error) error {
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = write()
+		if !errors.Is(err, repository.ErrSerializationFailure) || attempt >= retries {
+			return err
+		}
+
+		logger.Warn("serialization failure - retrying "+operation,
+			"product_id", id[:min(8, len(id))],
+			"attempt", attempt+1,
+		)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff * time.Duration(attempt+1)):
+		}
+	}
+}
+
 func (uc *UpdateProductUseCase) getCurrentProduct(ctx context.Context, id string) (*entity.Product, error) {
 	cacheKey := uc.cacheKeys.ProductKey(id)
 	product, err := uc.cacheRepo.Get(ctx, cacheKey)
@@ -126,8 +253,8 @@ func (uc *UpdateProductUseCase) getCurrentProduct(ctx context.Context, id string
 	return product, nil
 }
 
-func (uc *UpdateProductUseCase) updateCache(ctx context.Context, product *entity.Product, oldCategory, oldName string) {
-	if err := uc.cacheRepo.Set(ctx, uc.cacheKeys.ProductKey(product.ID), product); err != nil {
+func (uc *UpdateProductUseCase) updateCache(ctx context.Context, product *entity.Product, oldCategory, oldName, oldSKU string, oldTags []string) {
+	if err := uc.cacheRepo.Set(ctx, uc.cacheKeys.ProductKey(product.ID), product); err != nil && !skippedCacheWrite(uc.logger, err, product) {
 		uc.logger.Error("failed to update cache",
 			"error", err,
 			"product_id", product.HashID(),
@@ -174,11 +301,75 @@ func (uc *UpdateProductUseCase) updateCache(ctx context.Context, product *entity
 		}
 	}
 
+	oldSKUKey, newSKUKey := uc.cacheKeys.SKUKey(oldSKU), uc.cacheKeys.SKUKey(product.SKU)
+	if oldSKUKey != newSKUKey {
+		if oldSKU != "" {
+			if err := uc.cacheRepo.RemoveFromSet(ctx, oldSKUKey, product.ID); err != nil {
+				uc.logger.Error("failed to remove from old sku index",
+					"error", err,
+					"product_id", product.HashID(),
+					"old_sku", oldSKU,
+				)
+			}
+		}
+
+		if product.SKU != "" {
+			if err := uc.cacheRepo.AddToSet(ctx, newSKUKey, product.ID); err != nil {
+				uc.logger.Error("failed to add to new sku index",
+					"error", err,
+					"product_id", product.HashID(),
+					"new_sku", product.SKU,
+				)
+			}
+		}
+	}
+
+	removed, added := diffTags(oldTags, product.Tags)
+	for _, tag := range removed {
+		if err := uc.cacheRepo.RemoveFromSet(ctx, uc.cacheKeys.TagKey(tag), product.ID); err != nil {
+			uc.logger.Error("failed to remove from old tag index",
+				"error", err,
+				"product_id", product.HashID(),
+				"old_tag", tag,
+			)
+		}
+	}
+	for _, tag := range added {
+		if err := uc.cacheRepo.AddToSet(ctx, uc.cacheKeys.TagKey(tag), product.ID); err != nil {
+			uc.logger.Error("failed to add to new tag index",
+				"error", err,
+				"product_id", product.HashID(),
+				"new_tag", tag,
+			)
+		}
+	}
+
 	uc.logger.Info("cache and indices updated successfully",
 		"product_id", product.HashID(),
 	)
 }
 
+// diffTags devolve as tags que saíram e as que entraram entre as duas listas.
+func diffTags(oldTags, newTags []string) (removed, added []string) {
+	current := make(map[string]bool, len(newTags))
+	for _, tag := range newTags {
+		current[tag] = true
+	}
+	previous := make(map[string]bool, len(oldTags))
+	for _, tag := range oldTags {
+		previous[tag] = true
+		if !current[tag] {
+			removed = append(removed, tag)
+		}
+	}
+	for _, tag := range newTags {
+		if !previous[tag] {
+			added = append(added, tag)
+		}
+	}
+	return removed, added
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
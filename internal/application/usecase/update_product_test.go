@@ -3,6 +3,8 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/dowglassantana/product-redis-api/internal/application/port"
@@ -237,6 +239,49 @@ func TestUpdateProductUseCase_Execute_InvalidInput(t *testing.T) {
 	}
 }
 
+func TestUpdateProductUseCase_Execute_CollectsAllFieldErrors(t *testing.T) {
+	existingProduct := newTestProductWithData("Old Name", "REF-001", "Category")
+
+	mockProductRepo := &MockProductRepository{
+		UpdateFunc: func(ctx context.Context, product *entity.Product, expectedVersion int) error {
+			t.Error("Expected product not to be saved")
+			return nil
+		},
+	}
+	mockCacheRepo := &MockCacheRepository{
+		GetFunc: func(ctx context.Context, key string) (*entity.Product, error) {
+			return existingProduct, nil
+		},
+	}
+
+	uc := NewUpdateProductUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	tags := make([]string, entity.MaxTags+1)
+	for i := range tags {
+		tags[i] = fmt.Sprintf("tag-%d", i)
+	}
+
+	_, err := uc.Execute(context.Background(), existingProduct.ID, port.UpdateProductInput{
+		Category: "Electronics",
+		Price:    -1,
+		Tags:     tags,
+	})
+
+	var invalid entity.ValidationErrors
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+
+	want := map[string]string{
+		"name":  "is required",
+		"price": "cannot be negative",
+		"tags":  "cannot have more than 20 tags",
+	}
+	if got := invalid.Messages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected field errors %v, got %v", want, got)
+	}
+}
+
 func TestUpdateProductUseCase_Execute_DatabaseError(t *testing.T) {
 	existingProduct := newTestProductWithData("Old Name", "REF-001", "Category")
 	dbError := errors.New("database error")
@@ -422,3 +467,322 @@ func TestUpdateProductUseCase_Execute_NameIndexUpdate(t *testing.T) {
 		t.Error("Expected new name index to be updated")
 	}
 }
+
+func TestUpdateProductUseCase_Execute_RetriesSerializationFailure(t *testing.T) {
+	existingProduct := newTestProductWithData("Product", "REF-001", "Category")
+
+	attempts := 0
+	mockProductRepo := &MockProductRepository{
+		UpdateFunc: func(ctx context.Context, product *entity.Product, expectedVersion int) error {
+			attempts++
+			if attempts < 3 {
+				return repository.ErrSerializationFailure
+			}
+			return nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetFunc: func(ctx context.Context, key string) (*entity.Product, error) {
+			return existingProduct, nil
+		},
+	}
+
+	uc := NewUpdateProductUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{},
+		UpdateProductOptions{SerializationRetries: 3})
+
+	input := port.UpdateProductInput{
+		Name:     "Product",
+		Category: "Category",
+		Stock:    10,
+	}
+
+	product, err := uc.Execute(context.Background(), existingProduct.ID, input)
+
+	if err != nil {
+		t.Fatalf("Expected update to succeed after retries, got %v", err)
+	}
+
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+
+	if product.Stock != 10 {
+		t.Errorf("Expected stock 10, got %d", product.Stock)
+	}
+}
+
+func TestUpdateProductUseCase_Execute_SerializationRetriesExhausted(t *testing.T) {
+	existingProduct := newTestProductWithData("Product", "REF-001", "Category")
+
+	attempts := 0
+	mockProductRepo := &MockProductRepository{
+		UpdateFunc: func(ctx context.Context, product *entity.Product, expectedVersion int) error {
+			attempts++
+			return repository.ErrSerializationFailure
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetFunc: func(ctx context.Context, key string) (*entity.Product, error) {
+			return existingProduct, nil
+		},
+	}
+
+	uc := NewUpdateProductUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{},
+		UpdateProductOptions{SerializationRetries: 2})
+
+	input := port.UpdateProductInput{
+		Name:     "Product",
+		Category: "Category",
+		Stock:    10,
+	}
+
+	_, err := uc.Execute(context.Background(), existingProduct.ID, input)
+
+	if !errors.Is(err, repository.ErrSerializationFailure) {
+		t.Errorf("Expected ErrSerializationFailure, got %v", err)
+	}
+
+	if attempts != 3 {
+		t.Errorf("Expected 1 attempt plus 2 retries, got %d", attempts)
+	}
+}
+
+func TestUpdateProductUseCase_Execute_NoRetryByDefault(t *testing.T) {
+	existingProduct := newTestProductWithData("Product", "REF-001", "Category")
+
+	attempts := 0
+	mockProductRepo := &MockProductRepository{
+		UpdateFunc: func(ctx context.Context, product *entity.Product, expectedVersion int) error {
+			attempts++
+			return repository.ErrSerializationFailure
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetFunc: func(ctx context.Context, key string) (*entity.Product, error) {
+			return existingProduct, nil
+		},
+	}
+
+	uc := NewUpdateProductUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	input := port.UpdateProductInput{
+		Name:     "Product",
+		Category: "Category",
+		Stock:    10,
+	}
+
+	if _, err := uc.Execute(context.Background(), existingProduct.ID, input); err == nil {
+		t.Error("Expected error, got nil")
+	}
+
+	if attempts != 1 {
+		t.Errorf("Expected a single attempt without retries configured, got %d", attempts)
+	}
+}
+
+func TestUpdateProductUseCase_Execute_CategoryNotAllowed(t *testing.T) {
+	existingProduct := newTestProductWithData("Product", "REF-001", "Legacy")
+
+	mockProductRepo := &MockProductRepository{
+		UpdateFunc: func(ctx context.Context, product *entity.Product, expectedVersion int) error {
+			return nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetFunc: func(ctx context.Context, key string) (*entity.Product, error) {
+			copied := *existingProduct
+			return &copied, nil
+		},
+	}
+
+	uc := NewUpdateProductUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, UpdateProductOptions{
+		AllowedCategories: entity.NewCategorySet([]string{"Electronics"}),
+	})
+
+	_, err := uc.Execute(context.Background(), existingProduct.ID, port.UpdateProductInput{
+		Name:     "Product",
+		Category: "Toys",
+		Stock:    10,
+	})
+
+	if !errors.Is(err, entity.ErrCategoryNotAllowed) {
+		t.Errorf("Expected ErrCategoryNotAllowed, got %v", err)
+	}
+
+	_, err = uc.Execute(context.Background(), existingProduct.ID, port.UpdateProductInput{
+		Name:     "Product",
+		Category: "Legacy",
+		Stock:    20,
+	})
+
+	if err != nil {
+		t.Errorf("Expected unchanged legacy category to be accepted, got %v", err)
+	}
+
+	_, err = uc.Execute(context.Background(), existingProduct.ID, port.UpdateProductInput{
+		Name:     "Product",
+		Category: " ELECTRONICS ",
+		Stock:    20,
+	})
+
+	if err != nil {
+		t.Errorf("Expected allowed category to match case-insensitively, got %v", err)
+	}
+}
+
+func TestUpdateProductUseCase_Execute_TagIndexUpdate(t *testing.T) {
+	existingProduct := newTestProductWithData("Product", "REF-001", "Category")
+	existingProduct.Tags = []string{"kept", "old"}
+
+	removed := map[string]bool{}
+	added := map[string]bool{}
+
+	mockProductRepo := &MockProductRepository{
+		UpdateFunc: func(ctx context.Context, product *entity.Product, expectedVersion int) error {
+			return nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetFunc: func(ctx context.Context, key string) (*entity.Product, error) {
+			return existingProduct, nil
+		},
+		RemoveFromSetFunc: func(ctx context.Context, setKey, productID string) error {
+			removed[setKey] = true
+			return nil
+		},
+		AddToSetFunc: func(ctx context.Context, setKey, productID string) error {
+			added[setKey] = true
+			return nil
+		},
+	}
+
+	uc := NewUpdateProductUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	input := port.UpdateProductInput{
+		Name:     "Product",
+		Category: "Category",
+		Tags:     []string{"Kept", "New"},
+	}
+
+	_, err := uc.Execute(context.Background(), existingProduct.ID, input)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !removed["product_by_tag_old"] {
+		t.Error("Expected removed tag to leave its index")
+	}
+
+	if !added["product_by_tag_new"] {
+		t.Error("Expected new tag to join its index")
+	}
+
+	if removed["product_by_tag_kept"] || added["product_by_tag_kept"] {
+		t.Error("Expected unchanged tag index to be left alone")
+	}
+}
+
+func TestUpdateProductUseCase_Execute_SKUIndexUpdate(t *testing.T) {
+	existingProduct := newTestProductWithData("Name", "REF-001", "Category")
+	oldSKURemoved := false
+	newSKUAdded := false
+
+	mockProductRepo := &MockProductRepository{
+		UpdateFunc: func(ctx context.Context, product *entity.Product, expectedVersion int) error {
+			return nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetFunc: func(ctx context.Context, key string) (*entity.Product, error) {
+			return existingProduct, nil
+		},
+		RemoveFromSetFunc: func(ctx context.Context, setKey, productID string) error {
+			if setKey == "product_by_sku_SKU-001" {
+				oldSKURemoved = true
+			}
+			return nil
+		},
+		AddToSetFunc: func(ctx context.Context, setKey, productID string) error {
+			if setKey == "product_by_sku_SKU-002" {
+				newSKUAdded = true
+			}
+			return nil
+		},
+	}
+
+	uc := NewUpdateProductUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	input := port.UpdateProductInput{
+		Name:     "Name",
+		Category: "Category",
+		SKU:      "SKU-002",
+	}
+
+	if _, err := uc.Execute(context.Background(), existingProduct.ID, input); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !oldSKURemoved {
+		t.Error("Expected product removed from the old sku index")
+	}
+	if !newSKUAdded {
+		t.Error("Expected product added to the new sku index")
+	}
+}
+
+func TestUpdateProductUseCase_Execute_ExpectedVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		expected  int
+		updateErr error
+		wantErr   error
+		wantSaved int
+	}{
+		{name: "matching version", expected: 1, wantSaved: 1},
+		{name: "stale version", expected: 3, wantErr: entity.ErrPreconditionFailed},
+		{name: "concurrent write", expected: 1, updateErr: repository.ErrVersionConflict, wantErr: entity.ErrPreconditionFailed, wantSaved: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existingProduct := newTestProductWithData("Old Name", "REF-001", "Category")
+
+			var savedVersion int
+			mockProductRepo := &MockProductRepository{
+				UpdateFunc: func(ctx context.Context, product *entity.Product, expectedVersion int) error {
+					savedVersion = expectedVersion
+					return tt.updateErr
+				},
+			}
+			mockCacheRepo := &MockCacheRepository{
+				GetFunc: func(ctx context.Context, key string) (*entity.Product, error) {
+					return existingProduct, nil
+				},
+			}
+			uc := NewUpdateProductUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+			_, err := uc.Execute(context.Background(), existingProduct.ID, port.UpdateProductInput{
+				Name:            "New Name",
+				Category:        "Category",
+				ExpectedVersion: tt.expected,
+			})
+
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if savedVersion != tt.wantSaved {
+				t.Errorf("Expected repository update with version %d, got %d", tt.wantSaved, savedVersion)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// UpdateStockOptions ajusta o PATCH de estoque. Events recebe um
+// product.updated com a nova versão; nil descarta.
+//
+// SerializationRetries e RetryBackoff repetem a escrita quando o banco a aborta
+// por conflito de serialização, como na atualização de produto.
+type UpdateStockOptions struct {
+	Events               port.ChangePublisher
+	SerializationRetries int
+	RetryBackoff         time.Duration
+}
+
+// UpdateStockUseCase é o caminho rápido para mudanças frequentes de estoque:
+// grava só a coluna stock, incrementando a versão para que um PUT ou PATCH
+// concorrente não a desfaça, e altera estoque e versão da entrada em cache no
+// próprio Redis, sem regravar o produto nem mexer nos índices.
+type UpdateStockUseCase struct {
+	productRepo repository.ProductRepository
+	cacheRepo   repository.CacheRepository
+	cacheKeys   port.CacheKeyGenerator
+	logger      port.Logger
+	options     UpdateStockOptions
+}
+
+func NewUpdateStockUseCase(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+) *UpdateStockUseCase {
+	return NewUpdateStockUseCaseWithOptions(productRepo, cacheRepo, cacheKeys, logger, UpdateStockOptions{})
+}
+
+func NewUpdateStockUseCaseWithOptions(
+	productRepo repository.ProductRepository,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+	options UpdateStockOptions,
+) *UpdateStockUseCase {
+	options.Events = changePublisherOrNoop(options.Events)
+
+	return &UpdateStockUseCase{
+		productRepo: productRepo,
+		cacheRepo:   cacheRepo,
+		cacheKeys:   cacheKeys,
+		logger:      logger,
+		options:     options,
+	}
+}
+
+func (uc *UpdateStockUseCase) Execute(ctx context.Context, id string, stock int) error {
+	if stock < 0 {
+		return fmt.Errorf("invalid product data: %w", entity.ErrInvalidStock)
+	}
+
+	var revision repository.Revision
+	err := retrySerialization(ctx, uc.logger, "stock update", id, uc.options.SerializationRetries, uc.options.RetryBackoff, func() error {
+		var err error
+		revision, err = uc.productRepo.UpdateStock(ctx, id, stock)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrProductNotFound) {
+			return err
+		}
+
+		uc.logger.Error("failed to update stock in database",
+			"error", err,
+			"product_id", id[:min(8, len(id))],
+		)
+		return fmt.Errorf("failed to update stock: %w", err)
+	}
+
+	patchCachedStock(ctx, uc.cacheRepo, uc.cacheKeys, uc.logger, id, stock, revision)
+	publishRevision(uc.options.Events, id, revision)
+
+	uc.logger.Info("stock updated",
+		"product_id", id[:min(8, len(id))],
+		"stock", stock,
+	)
+
+	return nil
+}
+
+// publishRevision avisa os assinantes de uma escrita parcial (estoque, tags)
+// com a versão e o instante gravados pelo banco.
+func publishRevision(events port.ChangePublisher, id string, revision repository.Revision) {
+	events.Publish(port.ChangeEvent{
+		Type:       port.ProductUpdated,
+		ProductID:  id,
+		Version:    revision.Version,
+		OccurredAt: revision.UpdatedAt,
+	})
+}
+
+// patchCachedStock altera estoque e versão da entrada em cache no próprio
+// Redis. Se o patch falhar (layout não reconhecido, Redis fora), a entrada é
+// removida para que a próxima leitura venha do banco.
+func patchCachedStock(ctx context.Context, cacheRepo repository.CacheRepository, cacheKeys port.CacheKeyGenerator, logger port.Logger, id string, stock int, revision repository.Revision) {
+	cacheKey := cacheKeys.ProductKey(id)
+	if err := cacheRepo.PatchStock(ctx, cacheKey, stock, revision); err != nil {
+		logger.Warn("failed to patch cached stock - invalidating entry",
+			"error", err,
+			"product_id", id[:min(8, len(id))],
+		)
+		if err := cacheRepo.Delete(ctx, cacheKey); err != nil {
+			logger.Error("failed to invalidate cached product",
+				"error", err,
+				"product_id", id[:min(8, len(id))],
+			)
+		}
+	}
+}
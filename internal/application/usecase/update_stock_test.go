@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+func TestUpdateStockUseCase_Execute_Success(t *testing.T) {
+	var dbStock, cacheStock int
+	var patchedKey string
+	var patchedRevision repository.Revision
+	revision := repository.Revision{Version: 4, UpdatedAt: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)}
+
+	mockProductRepo := &MockProductRepository{
+		UpdateStockFunc: func(ctx context.Context, id string, stock int) (repository.Revision, error) {
+			dbStock = stock
+			return revision, nil
+		},
+		UpdateFunc: func(ctx context.Context, product *entity.Product, expectedVersion int) error {
+			t.Error("Expected stock update not to go through the full update path")
+			return nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		PatchStockFunc: func(ctx context.Context, key string, stock int, revision repository.Revision) error {
+			patchedKey = key
+			cacheStock = stock
+			patchedRevision = revision
+			return nil
+		},
+		SetFunc: func(ctx context.Context, key string, product *entity.Product) error {
+			t.Error("Expected cached entry to be patched, not rewritten")
+			return nil
+		},
+	}
+
+	events := &recordingPublisher{}
+	uc := NewUpdateStockUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, UpdateStockOptions{
+		Events: events,
+	})
+
+	if err := uc.Execute(context.Background(), "abc", 42); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if dbStock != 42 || cacheStock != 42 {
+		t.Errorf("Expected stock 42 in database and cache, got %d and %d", dbStock, cacheStock)
+	}
+
+	if patchedKey != "product_abc" {
+		t.Errorf("Expected product_abc to be patched, got %s", patchedKey)
+	}
+	if patchedRevision != revision {
+		t.Errorf("Expected the cached entry to get version %d, got %+v", revision.Version, patchedRevision)
+	}
+
+	want := port.ChangeEvent{Type: port.ProductUpdated, ProductID: "abc", Version: 4, OccurredAt: revision.UpdatedAt}
+	if len(events.events) != 1 || events.events[0] != want {
+		t.Errorf("Expected %+v to be published, got %+v", want, events.events)
+	}
+}
+
+func TestUpdateStockUseCase_Execute_NegativeStock(t *testing.T) {
+	uc := NewUpdateStockUseCase(&MockProductRepository{}, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	err := uc.Execute(context.Background(), "abc", -1)
+
+	if !errors.Is(err, entity.ErrInvalidStock) {
+		t.Errorf("Expected ErrInvalidStock, got %v", err)
+	}
+}
+
+func TestUpdateStockUseCase_Execute_NotFound(t *testing.T) {
+	mockProductRepo := &MockProductRepository{
+		UpdateStockFunc: func(ctx context.Context, id string, stock int) (repository.Revision, error) {
+			return repository.Revision{}, repository.ErrProductNotFound
+		},
+	}
+
+	uc := NewUpdateStockUseCase(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	err := uc.Execute(context.Background(), "abc", 5)
+
+	if !errors.Is(err, repository.ErrProductNotFound) {
+		t.Errorf("Expected ErrProductNotFound, got %v", err)
+	}
+}
+
+func TestUpdateStockUseCase_Execute_RetriesSerializationFailure(t *testing.T) {
+	attempts := 0
+	mockProductRepo := &MockProductRepository{
+		UpdateStockFunc: func(ctx context.Context, id string, stock int) (repository.Revision, error) {
+			attempts++
+			if attempts < 3 {
+				return repository.Revision{}, repository.ErrSerializationFailure
+			}
+			return repository.Revision{Version: 2}, nil
+		},
+	}
+
+	uc := NewUpdateStockUseCaseWithOptions(mockProductRepo, &MockCacheRepository{}, &MockCacheKeyGenerator{}, &MockLogger{}, UpdateStockOptions{
+		SerializationRetries: 3,
+	})
+
+	if err := uc.Execute(context.Background(), "abc", 5); err != nil {
+		t.Fatalf("Expected stock update to succeed after retries, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestUpdateStockUseCase_Execute_PatchFailureInvalidates(t *testing.T) {
+	deleted := ""
+
+	mockCacheRepo := &MockCacheRepository{
+		PatchStockFunc: func(ctx context.Context, key string, stock int, revision repository.Revision) error {
+			return errors.New("redis down")
+		},
+		DeleteFunc: func(ctx context.Context, key string) error {
+			deleted = key
+			return nil
+		},
+	}
+
+	uc := NewUpdateStockUseCase(&MockProductRepository{}, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	if err := uc.Execute(context.Background(), "abc", 5); err != nil {
+		t.Fatalf("Expected cache failure not to fail the update, got %v", err)
+	}
+
+	if deleted != "product_abc" {
+		t.Errorf("Expected cached entry to be invalidated, got %q", deleted)
+	}
+}
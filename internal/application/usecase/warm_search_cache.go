@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// warmupResultLimit é quantos produtos cada busca aquecida traz do banco, o
+// mesmo teto de limit aceito pelas rotas de busca.
+const warmupResultLimit = 5000
+
+// errWarmupLoadFailed é a mensagem de uma busca aquecida que falhou. O
+// relatório volta ao cliente, então o erro original só vai para o log.
+var errWarmupLoadFailed = errors.New("failed to load products")
+
+// WarmSearchCacheUseCase executa buscas no servidor para pré-aquecer o cache
+// antes de um pico previsto. Os searchers devem ser construídos com
+// SearchProductsOptions.PopulateCache, para que um cache miss grave os
+// produtos e os índices de nome e categoria.
+type WarmSearchCacheUseCase struct {
+	byName     port.ProductSearcherByName
+	byCategory port.ProductSearcherByCategory
+	logger     port.Logger
+}
+
+func NewWarmSearchCacheUseCase(
+	byName port.ProductSearcherByName,
+	byCategory port.ProductSearcherByCategory,
+	logger port.Logger,
+) *WarmSearchCacheUseCase {
+	return &WarmSearchCacheUseCase{
+		byName:     byName,
+		byCategory: byCategory,
+		logger:     logger,
+	}
+}
+
+// Execute aquece cada busca em sequência. Falha de uma busca entra no
+// relatório e não interrompe as demais, exceto repository.ErrCacheUnavailable
+// (searchers em modo estrito), que aborta o aquecimento com erro.
+func (uc *WarmSearchCacheUseCase) Execute(ctx context.Context, input port.WarmupInput) (*port.WarmupReport, error) {
+	report := &port.WarmupReport{
+		Queries: []port.WarmupQueryResult{},
+	}
+
+	for _, name := range input.Names {
+		result, err := uc.warm(ctx, "name", name, func(query string) ([]*entity.Product, error) {
+			return uc.byName.Execute(ctx, query, repository.ListFilter{}, repository.NameOrderAlphabetical, repository.SortOptions{}, warmupResultLimit, 0)
+		})
+		if err != nil {
+			return nil, err
+		}
+		report.Queries = append(report.Queries, result)
+		report.Warmed += result.Products
+	}
+
+	for _, category := range input.Categories {
+		result, err := uc.warm(ctx, "category", category, func(query string) ([]*entity.Product, error) {
+			return uc.byCategory.Execute(ctx, query, repository.ListFilter{}, repository.SortOptions{}, warmupResultLimit, 0)
+		})
+		if err != nil {
+			return nil, err
+		}
+		report.Queries = append(report.Queries, result)
+		report.Warmed += result.Products
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("search cache warmup finished",
+		"queries", len(report.Queries),
+		"warmed", report.Warmed,
+	)
+
+	return report, nil
+}
+
+func (uc *WarmSearchCacheUseCase) warm(ctx context.Context, kind, query string, search func(string) ([]*entity.Product, error)) (port.WarmupQueryResult, error) {
+	query = strings.TrimSpace(query)
+	result := port.WarmupQueryResult{Type: kind, Query: query}
+
+	if query == "" {
+		result.Error = "query is empty"
+		return result, nil
+	}
+	if err := ctx.Err(); err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+
+	products, err := search(query)
+	if err != nil {
+		uc.logger.Warn("failed to warm search",
+			"error", err,
+			"type", kind,
+			"query", query,
+		)
+		if errors.Is(err, repository.ErrCacheUnavailable) {
+			return result, err
+		}
+		result.Error = errWarmupLoadFailed.Error()
+		return result, nil
+	}
+
+	result.Products = len(products)
+	return result, nil
+}
+
+// populateSearchCache grava os produtos vindos do banco e os indexa pelo
+// próprio nome e categoria, como a criação faz. O índice all_products não é
+// tocado: uma busca traz só parte do catálogo e deixaria a listagem com
+// buracos. Os índices só são escritos depois de todas as chaves gravadas: se
+// alguma falhar, nenhum índice é tocado, porque um set sem o produto seria lido
+// como completo. Falhas são logadas e a primeira é devolvida como
+// repository.ErrCacheUnavailable, para quem estiver em modo estrito.
+func populateSearchCache(
+	ctx context.Context,
+	cacheRepo repository.CacheRepository,
+	cacheKeys port.CacheKeyGenerator,
+	logger port.Logger,
+	products []*entity.Product,
+) error {
+	var firstErr error
+	fail := func(err error) {
+		if firstErr == nil {
+			firstErr = fmt.Errorf("%w: %v", repository.ErrCacheUnavailable, err)
+		}
+	}
+
+	for _, product := range products {
+		if err := cacheRepo.Set(ctx, cacheKeys.ProductKey(product.ID), product); err != nil && !skippedCacheWrite(logger, err, product) {
+			logger.Warn("failed to cache product from search - skipping indexes",
+				"error", err,
+				"product_id", product.HashID(),
+			)
+			fail(err)
+			return firstErr
+		}
+	}
+
+	for _, product := range products {
+		if err := cacheRepo.AddToSet(ctx, cacheKeys.NameKey(product.Name), product.ID); err != nil {
+			logger.Warn("failed to add to name index",
+				"error", err,
+				"product_id", product.HashID(),
+			)
+			fail(err)
+		}
+
+		if err := cacheRepo.AddToSet(ctx, cacheKeys.CategoryKey(product.Category), product.ID); err != nil {
+			logger.Warn("failed to add to category index",
+				"error", err,
+				"product_id", product.HashID(),
+			)
+			fail(err)
+		}
+
+		for _, tag := range product.Tags {
+			if err := cacheRepo.AddToSet(ctx, cacheKeys.TagKey(tag), product.ID); err != nil {
+				logger.Warn("failed to add to tag index",
+					"error", err,
+					"product_id", product.HashID(),
+				)
+				fail(err)
+			}
+		}
+	}
+
+	return firstErr
+}
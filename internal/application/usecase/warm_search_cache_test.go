@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+func TestWarmSearchCacheUseCase_Execute(t *testing.T) {
+	phone := &entity.Product{ID: "P1", Name: "iPhone", Category: "Smartphones"}
+	tablet := &entity.Product{ID: "P2", Name: "iPad", Category: "Tablets"}
+
+	mockProductRepo := &MockProductRepository{
+		FindByCategoryFunc: func(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			if limit != warmupResultLimit || offset != 0 {
+				t.Errorf("Expected warmup to fetch the first %d results, got limit=%d offset=%d", warmupResultLimit, limit, offset)
+			}
+			return []*entity.Product{phone}, nil
+		},
+		FindByNameFunc: func(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			if name == "broken" {
+				return nil, repository.ErrDatabaseConnection
+			}
+			return []*entity.Product{tablet}, nil
+		},
+	}
+
+	cached := map[string]bool{}
+	indexed := map[string][]string{}
+	mockCacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			return nil, nil
+		},
+		SetFunc: func(ctx context.Context, key string, product *entity.Product) error {
+			cached[key] = true
+			return nil
+		},
+		AddToSetFunc: func(ctx context.Context, setKey, productID string) error {
+			indexed[setKey] = append(indexed[setKey], productID)
+			return nil
+		},
+	}
+
+	keys := &MockCacheKeyGenerator{}
+	logger := &MockLogger{}
+	options := SearchProductsOptions{PopulateCache: true}
+
+	uc := NewWarmSearchCacheUseCase(
+		NewSearchProductsByNameUseCaseWithOptions(mockProductRepo, mockCacheRepo, keys, logger, options),
+		NewSearchProductsByCategoryUseCaseWithOptions(mockProductRepo, mockCacheRepo, keys, logger, options),
+		logger,
+	)
+
+	report, err := uc.Execute(context.Background(), port.WarmupInput{
+		Names:      []string{"ipad", "broken", " "},
+		Categories: []string{"Smartphones"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if report.Warmed != 2 {
+		t.Errorf("Expected 2 warmed products, got %d", report.Warmed)
+	}
+
+	if len(report.Queries) != 4 {
+		t.Fatalf("Expected 4 query results, got %d", len(report.Queries))
+	}
+
+	if report.Queries[1].Error != errWarmupLoadFailed.Error() {
+		t.Errorf("Expected a generic message for the failed query, got %q", report.Queries[1].Error)
+	}
+	if report.Queries[2].Error == "" {
+		t.Error("Expected the empty query to be reported")
+	}
+
+	if !cached["product_P1"] || !cached["product_P2"] {
+		t.Errorf("Expected warmed products to be cached, got %v", cached)
+	}
+
+	if len(indexed["product_by_category_Smartphones"]) != 1 || len(indexed["product_by_name_iPad"]) != 1 {
+		t.Errorf("Expected warmed products to be indexed by name and category, got %v", indexed)
+	}
+
+	if _, ok := indexed["all_products"]; ok {
+		t.Error("Expected warmup not to touch all_products")
+	}
+}
+
+func TestSearchProductsByCategoryUseCase_Execute_DoesNotPopulateByDefault(t *testing.T) {
+	mockProductRepo := &MockProductRepository{
+		FindByCategoryFunc: func(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			return []*entity.Product{{ID: "P1", Name: "iPhone", Category: category}}, nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			return nil, errors.New("miss")
+		},
+		SetFunc: func(ctx context.Context, key string, product *entity.Product) error {
+			t.Error("Expected no cache writes without PopulateCache")
+			return nil
+		},
+	}
+
+	uc := NewSearchProductsByCategoryUseCase(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{})
+
+	if _, err := uc.Execute(context.Background(), "Smartphones", repository.ListFilter{}, repository.SortOptions{}, 10, 0); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestWarmSearchCacheUseCase_Execute_StrictCacheAborts(t *testing.T) {
+	mockProductRepo := &MockProductRepository{
+		FindByNameFunc: func(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			return []*entity.Product{{ID: "P1", Name: name, Category: "Tablets"}}, nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			return nil, nil
+		},
+		SetFunc: func(ctx context.Context, key string, product *entity.Product) error {
+			return errors.New("redis: connection refused")
+		},
+	}
+
+	keys := &MockCacheKeyGenerator{}
+	logger := &MockLogger{}
+
+	tolerant := NewWarmSearchCacheUseCase(
+		NewSearchProductsByNameUseCaseWithOptions(mockProductRepo, mockCacheRepo, keys, logger, SearchProductsOptions{PopulateCache: true}),
+		NewSearchProductsByCategoryUseCase(mockProductRepo, mockCacheRepo, keys, logger),
+		logger,
+	)
+	if _, err := tolerant.Execute(context.Background(), port.WarmupInput{Names: []string{"iPad"}}); err != nil {
+		t.Errorf("Expected tolerant warmup to ignore cache failures, got %v", err)
+	}
+
+	strict := NewWarmSearchCacheUseCase(
+		NewSearchProductsByNameUseCaseWithOptions(mockProductRepo, mockCacheRepo, keys, logger, SearchProductsOptions{PopulateCache: true, StrictCache: true}),
+		NewSearchProductsByCategoryUseCase(mockProductRepo, mockCacheRepo, keys, logger),
+		logger,
+	)
+	if _, err := strict.Execute(context.Background(), port.WarmupInput{Names: []string{"iPad"}}); !errors.Is(err, repository.ErrCacheUnavailable) {
+		t.Errorf("Expected ErrCacheUnavailable in strict mode, got %v", err)
+	}
+}
+
+func TestPopulateSearchCache_SkipsIndexesAfterFailedWrite(t *testing.T) {
+	products := []*entity.Product{
+		{ID: "P1", Name: "iPad", Category: "Tablets", Tags: []string{"apple"}},
+		{ID: "P2", Name: "iPad", Category: "Tablets"},
+	}
+
+	indexed := 0
+	mockCacheRepo := &MockCacheRepository{
+		SetFunc: func(ctx context.Context, key string, product *entity.Product) error {
+			if product.ID == "P2" {
+				return errors.New("redis: connection refused")
+			}
+			return nil
+		},
+		AddToSetFunc: func(ctx context.Context, setKey string, productID string) error {
+			indexed++
+			return nil
+		},
+	}
+
+	err := populateSearchCache(context.Background(), mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, products)
+	if !errors.Is(err, repository.ErrCacheUnavailable) {
+		t.Errorf("Expected ErrCacheUnavailable, got %v", err)
+	}
+	if indexed != 0 {
+		t.Errorf("Expected no index writes after a failed product write, got %d", indexed)
+	}
+}
+
+func TestSearchProductsByCategoryUseCase_Execute_StrictCacheReadFailure(t *testing.T) {
+	mockProductRepo := &MockProductRepository{
+		FindByCategoryFunc: func(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+			return []*entity.Product{{ID: "P1"}}, nil
+		},
+	}
+
+	mockCacheRepo := &MockCacheRepository{
+		GetSetFunc: func(ctx context.Context, setKey string) ([]string, error) {
+			return nil, errors.New("redis: i/o timeout")
+		},
+	}
+
+	uc := NewSearchProductsByCategoryUseCaseWithOptions(mockProductRepo, mockCacheRepo, &MockCacheKeyGenerator{}, &MockLogger{}, SearchProductsOptions{StrictCache: true})
+
+	if _, err := uc.Execute(context.Background(), "Tablets", repository.ListFilter{}, repository.SortOptions{}, 10, 0); !errors.Is(err, repository.ErrCacheUnavailable) {
+		t.Errorf("Expected cache read failure to propagate in strict mode, got %v", err)
+	}
+}
+
+func TestParseStrictCacheOperations(t *testing.T) {
+	ops, err := ParseStrictCacheOperations([]string{" WARM ", ""})
+	if err != nil || !ops.Has(StrictCacheWarm) {
+		t.Errorf("Expected warm to be enabled, got %v, %v", ops, err)
+	}
+
+	if _, err := ParseStrictCacheOperations([]string{"reindex"}); err == nil {
+		t.Error("Expected unknown operation to be rejected")
+	}
+}
@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// FilterProducts devolve, numa nova fatia e na mesma ordem, os produtos que
+// passam pelo filtro. Um filtro vazio devolve a própria entrada.
+func FilterProducts(products []*entity.Product, filter repository.ListFilter) []*entity.Product {
+	if filter.IsZero() {
+		return products
+	}
+
+	filtered := make([]*entity.Product, 0, len(products))
+	for _, product := range products {
+		if filter.Matches(product) {
+			filtered = append(filtered, product)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+func TestFilterProducts(t *testing.T) {
+	products := []*entity.Product{
+		{ID: "a", Stock: 0, Price: 1000},
+		{ID: "b", Stock: 5, Price: 2500},
+		{ID: "c", Stock: 3, Price: 5000},
+		{ID: "d", Stock: 0, Price: 7500},
+	}
+	inStock := true
+	minPrice, maxPrice := int64(2500), int64(5000)
+
+	tests := []struct {
+		name   string
+		filter repository.ListFilter
+		want   []string
+	}{
+		{name: "no filter", filter: repository.ListFilter{}, want: []string{"a", "b", "c", "d"}},
+		{name: "in stock", filter: repository.ListFilter{InStock: &inStock}, want: []string{"b", "c"}},
+		{name: "inclusive price band", filter: repository.ListFilter{MinPrice: &minPrice, MaxPrice: &maxPrice}, want: []string{"b", "c"}},
+		{name: "min price only", filter: repository.ListFilter{MinPrice: &maxPrice}, want: []string{"c", "d"}},
+		{name: "max price only", filter: repository.ListFilter{MaxPrice: &minPrice}, want: []string{"a", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sortedIDs(FilterProducts(products, tt.filter))
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Expected %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterProducts_ShippingRanges(t *testing.T) {
+	products := []*entity.Product{
+		{ID: "a"},
+		{ID: "b", WeightGrams: 500, Dimensions: entity.Dimensions{LengthMM: 100, WidthMM: 100, HeightMM: 100}},
+		{ID: "c", WeightGrams: 2000, Dimensions: entity.Dimensions{LengthMM: 300, WidthMM: 200, HeightMM: 100}},
+		{ID: "d", WeightGrams: 200, Dimensions: entity.Dimensions{LengthMM: 300, WidthMM: 200}},
+	}
+	zero, oneKilo, oneLiter := int64(0), int64(1000), int64(1000)
+
+	tests := []struct {
+		name   string
+		filter repository.ListFilter
+		want   []string
+	}{
+		{name: "max weight skips unset weight", filter: repository.ListFilter{MaxWeight: &oneKilo}, want: []string{"b", "d"}},
+		{name: "min weight", filter: repository.ListFilter{MinWeight: &oneKilo}, want: []string{"c"}},
+		{name: "min weight zero still skips unset weight", filter: repository.ListFilter{MinWeight: &zero}, want: []string{"b", "c", "d"}},
+		{name: "inclusive max volume in cm3", filter: repository.ListFilter{MaxVolume: &oneLiter}, want: []string{"b"}},
+		{name: "volume needs all dimensions", filter: repository.ListFilter{MinVolume: &zero}, want: []string{"b", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sortedIDs(FilterProducts(products, tt.filter))
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Expected %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
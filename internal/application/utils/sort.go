@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// SortProducts ordena products no lugar, na mesma ordem que o banco usaria
+// para sort: o campo pedido e depois o ID, ambos no sentido informado. Os
+// padrões de sort devem já ter sido aplicados (SortOptions.WithDefaults).
+func SortProducts(products []*entity.Product, sort repository.SortOptions) {
+	compare := productComparator(sort.Field)
+
+	slices.SortStableFunc(products, func(a, b *entity.Product) int {
+		c := compare(a, b)
+		if c == 0 {
+			c = cmp.Compare(a.ID, b.ID)
+		}
+		if sort.Direction == repository.SortDesc {
+			return -c
+		}
+		return c
+	})
+}
+
+func productComparator(field repository.SortField) func(a, b *entity.Product) int {
+	switch field {
+	case repository.SortByName:
+		return func(a, b *entity.Product) int {
+			return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		}
+	case repository.SortByUpdatedAt:
+		return func(a, b *entity.Product) int {
+			return a.UpdatedAt.Compare(b.UpdatedAt)
+		}
+	case repository.SortByStock:
+		return func(a, b *entity.Product) int {
+			return cmp.Compare(a.Stock, b.Stock)
+		}
+	default:
+		return func(a, b *entity.Product) int {
+			return a.CreatedAt.Compare(b.CreatedAt)
+		}
+	}
+}
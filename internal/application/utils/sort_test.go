@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+func sortedIDs(products []*entity.Product) []string {
+	ids := make([]string, len(products))
+	for i, p := range products {
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+func TestSortProducts(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newProducts := func() []*entity.Product {
+		return []*entity.Product{
+			{ID: "b", Name: "banana", Stock: 5, CreatedAt: base.Add(2 * time.Hour), UpdatedAt: base},
+			{ID: "a", Name: "Abacate", Stock: 5, CreatedAt: base, UpdatedAt: base.Add(time.Hour)},
+			{ID: "c", Name: "cereja", Stock: 1, CreatedAt: base.Add(time.Hour), UpdatedAt: base.Add(2 * time.Hour)},
+		}
+	}
+
+	tests := []struct {
+		name string
+		sort repository.SortOptions
+		want []string
+	}{
+		{"name ascending ignores case", repository.SortOptions{Field: repository.SortByName, Direction: repository.SortAsc}, []string{"a", "b", "c"}},
+		{"name descending", repository.SortOptions{Field: repository.SortByName, Direction: repository.SortDesc}, []string{"c", "b", "a"}},
+		{"created_at descending", repository.SortOptions{Field: repository.SortByCreatedAt, Direction: repository.SortDesc}, []string{"b", "c", "a"}},
+		{"updated_at ascending", repository.SortOptions{Field: repository.SortByUpdatedAt, Direction: repository.SortAsc}, []string{"b", "a", "c"}},
+		{"stock ties broken by id", repository.SortOptions{Field: repository.SortByStock, Direction: repository.SortAsc}, []string{"c", "a", "b"}},
+		{"stock descending reverses tie-breaker", repository.SortOptions{Field: repository.SortByStock, Direction: repository.SortDesc}, []string{"b", "a", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			products := newProducts()
+			SortProducts(products, tt.sort)
+
+			got := sortedIDs(products)
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("order = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
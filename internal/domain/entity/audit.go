@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"reflect"
+	"time"
+)
+
+// Operações registradas na trilha de compliance (audit_log).
+const (
+	AuditCreate = "create"
+	AuditUpdate = "update"
+	AuditDelete = "delete"
+)
+
+// AuditEntry registra uma alteração bem-sucedida de produto: quem (Subject,
+// a claim sub do token), o quê (Operation e Changes) e quando.
+type AuditEntry struct {
+	ProductID  string
+	Subject    string
+	Operation  string
+	OccurredAt time.Time
+	Changes    map[string]FieldChange
+}
+
+// FieldChange guarda o valor de um campo antes e depois da alteração; Old é
+// nil na criação.
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// DiffProducts lista, pelo nome JSON, os campos editáveis que mudaram entre
+// before e after. Com before nil (criação), lista todos os campos preenchidos
+// de after. Versão e timestamps ficam de fora: mudam em toda escrita.
+func DiffProducts(before, after *Product) map[string]FieldChange {
+	if before == nil {
+		before = &Product{}
+	}
+
+	changes := map[string]FieldChange{}
+	diff := func(field string, old, new interface{}) {
+		if isEmptyValue(old) && isEmptyValue(new) {
+			return
+		}
+		if reflect.DeepEqual(old, new) {
+			return
+		}
+		changes[field] = FieldChange{Old: old, New: new}
+	}
+
+	diff("name", before.Name, after.Name)
+	diff("reference_number", before.ReferenceNumber, after.ReferenceNumber)
+	diff("category", before.Category, after.Category)
+	diff("description", before.Description, after.Description)
+	diff("sku", before.SKU, after.SKU)
+	diff("brand", before.Brand, after.Brand)
+	diff("stock", before.Stock, after.Stock)
+	diff("price", before.Price, after.Price)
+	diff("currency", before.Currency, after.Currency)
+	diff("images", before.Images, after.Images)
+	diff("specifications", before.Specifications, after.Specifications)
+	diff("thumbnail_url", before.ThumbnailURL, after.ThumbnailURL)
+	diff("tags", before.Tags, after.Tags)
+	diff("weight_grams", before.WeightGrams, after.WeightGrams)
+	diff("dimensions", before.Dimensions, after.Dimensions)
+
+	return changes
+}
+
+// isEmptyValue trata listas e mapas vazios como nil, para que uma lista
+// gravada como [] não apareça como alteração.
+func isEmptyValue(value interface{}) bool {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
@@ -0,0 +1,42 @@
+package entity
+
+import "testing"
+
+func TestDiffProducts(t *testing.T) {
+	before := &Product{Name: "Notebook", Category: "Laptops", Stock: 3, Tags: []string{"promo"}}
+
+	t.Run("create lists filled fields", func(t *testing.T) {
+		changes := DiffProducts(nil, before)
+		if len(changes) != 4 {
+			t.Fatalf("Expected 4 changed fields, got %v", changes)
+		}
+		if change := changes["stock"]; change.Old != 0 || change.New != 3 {
+			t.Errorf("Expected stock from 0 to 3, got %+v", change)
+		}
+	})
+
+	t.Run("update lists only changed fields", func(t *testing.T) {
+		after := *before
+		after.Name = "Notebook Pro"
+		after.Tags = nil
+
+		changes := DiffProducts(before, &after)
+		if len(changes) != 2 {
+			t.Fatalf("Expected 2 changed fields, got %v", changes)
+		}
+		if change := changes["name"]; change.Old != "Notebook" || change.New != "Notebook Pro" {
+			t.Errorf("Expected name change, got %+v", change)
+		}
+		if _, ok := changes["tags"]; !ok {
+			t.Error("Expected removed tags to be listed")
+		}
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		same := *before
+		same.Images = []string{}
+		if changes := DiffProducts(before, &same); len(changes) != 0 {
+			t.Errorf("Expected no changes, got %v", changes)
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrCategoryNotAllowed = errors.New("product category is not allowed")
+
+// CategorySet é uma taxonomia fechada de categorias. O conjunto vazio (ou nil)
+// aceita qualquer categoria.
+type CategorySet map[string]struct{}
+
+func NewCategorySet(categories []string) CategorySet {
+	set := make(CategorySet, len(categories))
+	for _, category := range categories {
+		if normalized := NormalizeCategory(category); normalized != "" {
+			set[normalized] = struct{}{}
+		}
+	}
+	return set
+}
+
+// NormalizeCategory padroniza a categoria para comparação: sem espaços nas
+// pontas e em minúsculas.
+func NormalizeCategory(category string) string {
+	return strings.ToLower(strings.TrimSpace(category))
+}
+
+// Allows informa se a categoria pertence ao conjunto.
+func (s CategorySet) Allows(category string) bool {
+	if len(s) == 0 {
+		return true
+	}
+	_, ok := s[NormalizeCategory(category)]
+	return ok
+}
+
+// Check retorna ErrCategoryNotAllowed quando a categoria está fora do conjunto.
+func (s CategorySet) Check(category string) error {
+	if !s.Allows(category) {
+		return ErrCategoryNotAllowed
+	}
+	return nil
+}
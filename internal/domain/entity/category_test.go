@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCategorySet(t *testing.T) {
+	set := NewCategorySet([]string{"Electronics", " books ", ""})
+
+	tests := []struct {
+		category string
+		want     bool
+	}{
+		{category: "Electronics", want: true},
+		{category: "electronics", want: true},
+		{category: "  ELECTRONICS ", want: true},
+		{category: "Books", want: true},
+		{category: "Toys", want: false},
+		{category: "", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := set.Allows(tt.category); got != tt.want {
+			t.Errorf("Allows(%q) = %v, want %v", tt.category, got, tt.want)
+		}
+	}
+
+	if err := set.Check("Toys"); !errors.Is(err, ErrCategoryNotAllowed) {
+		t.Errorf("Check() error = %v, want %v", err, ErrCategoryNotAllowed)
+	}
+}
+
+func TestCategorySet_EmptyAllowsAll(t *testing.T) {
+	var set CategorySet
+
+	if !set.Allows("anything") {
+		t.Error("Expected nil set to allow any category")
+	}
+
+	if !NewCategorySet(nil).Allows("anything") {
+		t.Error("Expected empty set to allow any category")
+	}
+}
@@ -0,0 +1,137 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidPriceRounding = errors.New("invalid price rounding")
+
+	ErrInvalidPriceAmount  = errors.New("product price_amount must be a non-negative decimal number")
+	ErrPricePrecision      = errors.New("product price has more decimal places than the currency allows")
+	ErrPriceAmountConflict = errors.New("product price and price_amount cannot be sent together")
+)
+
+// defaultMinorUnits vale para moedas fora da tabela e para produtos sem
+// moeda, mantendo o preço em centavos.
+const defaultMinorUnits = 2
+
+// minorUnits lista as casas decimais (ISO 4217) das moedas que não usam duas.
+var minorUnits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0,
+	"XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// CurrencyMinorUnits retorna quantas casas decimais a moeda tem, que é também
+// a escala de price: JPY 0 (price em ienes), USD e BRL 2 (em centavos), KWD 3.
+func CurrencyMinorUnits(currency string) int {
+	if units, ok := minorUnits[normalizeCurrency(currency)]; ok {
+		return units
+	}
+	return defaultMinorUnits
+}
+
+// Modos aceitos para price_amount com mais casas do que a moeda permite.
+const (
+	PriceRoundingReject   PriceRounding = "reject"
+	PriceRoundingHalfUp   PriceRounding = "half_up"
+	PriceRoundingHalfEven PriceRounding = "half_even"
+)
+
+// PriceRounding define o que fazer com um price_amount mais preciso que a
+// moeda: rejeitar (o valor zero) ou arredondar para a unidade menor.
+type PriceRounding string
+
+// ParsePriceRounding interpreta "reject", "half_up" ou "half_even". Vazio
+// equivale a reject.
+func ParsePriceRounding(value string) (PriceRounding, error) {
+	switch mode := PriceRounding(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "", PriceRoundingReject:
+		return PriceRoundingReject, nil
+	case PriceRoundingHalfUp, PriceRoundingHalfEven:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w: unknown mode %q", ErrInvalidPriceRounding, value)
+	}
+}
+
+// ParsePriceAmount converte um valor decimal na unidade principal da moeda
+// ("9.99" em USD) para o inteiro em unidades menores (999), sem passar por
+// ponto flutuante. Casas além das da moeda seguem rounding.
+func ParsePriceAmount(amount, currency string, rounding PriceRounding) (int64, error) {
+	whole, fraction, _ := strings.Cut(strings.TrimSpace(amount), ".")
+	if !digitsOnly(whole) || (fraction != "" && !digitsOnly(fraction)) {
+		return 0, ErrInvalidPriceAmount
+	}
+
+	units := CurrencyMinorUnits(currency)
+	var extra string
+	if len(fraction) > units {
+		fraction, extra = fraction[:units], fraction[units:]
+	}
+	fraction += strings.Repeat("0", units-len(fraction))
+
+	price, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidPriceAmount
+	}
+	if strings.Trim(extra, "0") == "" {
+		return price, nil
+	}
+	if rounding == "" || rounding == PriceRoundingReject {
+		return 0, ErrPricePrecision
+	}
+
+	if roundsUp(extra, price, rounding) {
+		price++
+	}
+	return price, nil
+}
+
+// roundsUp decide o arredondamento pelos dígitos descartados: acima da
+// metade sobe, abaixo desce e, na metade exata, half_up sobe e half_even só
+// sobe se o resultado ficaria ímpar.
+func roundsUp(extra string, price int64, rounding PriceRounding) bool {
+	switch {
+	case extra[0] > '5':
+		return true
+	case extra[0] < '5':
+		return false
+	case strings.Trim(extra[1:], "0") != "":
+		return true
+	case rounding == PriceRoundingHalfUp:
+		return true
+	default:
+		return price%2 == 1
+	}
+}
+
+// ResolvePrice devolve o preço em unidades menores: o próprio price ou, se
+// price_amount veio, o valor convertido. Os dois juntos são rejeitados.
+func ResolvePrice(price int64, amount, currency string, rounding PriceRounding) (int64, error) {
+	if amount == "" {
+		return price, nil
+	}
+	if price != 0 {
+		return 0, ErrPriceAmountConflict
+	}
+	return ParsePriceAmount(amount, currency, rounding)
+}
+
+func digitsOnly(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCurrencyMinorUnits(t *testing.T) {
+	tests := []struct {
+		currency string
+		want     int
+	}{
+		{currency: "JPY", want: 0},
+		{currency: "krw", want: 0},
+		{currency: "USD", want: 2},
+		{currency: "BRL", want: 2},
+		{currency: " kwd ", want: 3},
+		{currency: "BHD", want: 3},
+		{currency: "", want: 2},
+		{currency: "XYZ", want: 2},
+	}
+
+	for _, tt := range tests {
+		if got := CurrencyMinorUnits(tt.currency); got != tt.want {
+			t.Errorf("CurrencyMinorUnits(%q) = %d, want %d", tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestParsePriceAmount(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency string
+		rounding PriceRounding
+		want     int64
+		wantErr  error
+	}{
+		{amount: "9.99", currency: "USD", want: 999},
+		{amount: "9.9", currency: "USD", want: 990},
+		{amount: "9", currency: "USD", want: 900},
+		{amount: "9.990", currency: "USD", want: 999},
+		{amount: "9.999", currency: "USD", wantErr: ErrPricePrecision},
+		{amount: "9.999", currency: "USD", rounding: PriceRoundingHalfUp, want: 1000},
+		{amount: "9.994", currency: "USD", rounding: PriceRoundingHalfUp, want: 999},
+
+		// Moeda sem casas decimais.
+		{amount: "1500", currency: "JPY", want: 1500},
+		{amount: "1500.00", currency: "JPY", want: 1500},
+		{amount: "1500.5", currency: "JPY", wantErr: ErrPricePrecision},
+		{amount: "1500.5", currency: "JPY", rounding: PriceRoundingHalfUp, want: 1501},
+		{amount: "1500.5", currency: "JPY", rounding: PriceRoundingHalfEven, want: 1500},
+		{amount: "1501.5", currency: "JPY", rounding: PriceRoundingHalfEven, want: 1502},
+		{amount: "1500.51", currency: "JPY", rounding: PriceRoundingHalfEven, want: 1501},
+
+		// Moeda com três casas decimais.
+		{amount: "1.234", currency: "KWD", want: 1234},
+		{amount: "1.2", currency: "KWD", want: 1200},
+		{amount: "1.2345", currency: "KWD", wantErr: ErrPricePrecision},
+		{amount: "1.2345", currency: "KWD", rounding: PriceRoundingHalfEven, want: 1234},
+		{amount: "1.2355", currency: "KWD", rounding: PriceRoundingHalfEven, want: 1236},
+
+		{amount: "", currency: "USD", wantErr: ErrInvalidPriceAmount},
+		{amount: "-1.00", currency: "USD", wantErr: ErrInvalidPriceAmount},
+		{amount: "1,50", currency: "BRL", wantErr: ErrInvalidPriceAmount},
+		{amount: ".50", currency: "USD", wantErr: ErrInvalidPriceAmount},
+		{amount: "1e3", currency: "USD", wantErr: ErrInvalidPriceAmount},
+		{amount: "99999999999999999999", currency: "USD", wantErr: ErrInvalidPriceAmount},
+	}
+
+	for _, tt := range tests {
+		got, err := ParsePriceAmount(tt.amount, tt.currency, tt.rounding)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParsePriceAmount(%q, %q, %q) error = %v, want %v", tt.amount, tt.currency, tt.rounding, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParsePriceAmount(%q, %q, %q) unexpected error = %v", tt.amount, tt.currency, tt.rounding, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParsePriceAmount(%q, %q, %q) = %d, want %d", tt.amount, tt.currency, tt.rounding, got, tt.want)
+		}
+	}
+}
+
+func TestParsePriceRounding(t *testing.T) {
+	for value, want := range map[string]PriceRounding{
+		"":          PriceRoundingReject,
+		"reject":    PriceRoundingReject,
+		"HALF_UP":   PriceRoundingHalfUp,
+		"half_even": PriceRoundingHalfEven,
+	} {
+		got, err := ParsePriceRounding(value)
+		if err != nil || got != want {
+			t.Errorf("ParsePriceRounding(%q) = %q, %v, want %q", value, got, err, want)
+		}
+	}
+
+	if _, err := ParsePriceRounding("truncate"); !errors.Is(err, ErrInvalidPriceRounding) {
+		t.Errorf("ParsePriceRounding(%q) error = %v, want %v", "truncate", err, ErrInvalidPriceRounding)
+	}
+}
+
+func TestResolvePrice(t *testing.T) {
+	if got, err := ResolvePrice(999, "", "USD", PriceRoundingReject); err != nil || got != 999 {
+		t.Errorf("ResolvePrice without amount = %d, %v, want 999", got, err)
+	}
+	if got, err := ResolvePrice(0, "9.99", "USD", PriceRoundingReject); err != nil || got != 999 {
+		t.Errorf("ResolvePrice with amount = %d, %v, want 999", got, err)
+	}
+	if _, err := ResolvePrice(999, "9.99", "USD", PriceRoundingReject); !errors.Is(err, ErrPriceAmountConflict) {
+		t.Errorf("ResolvePrice with both = %v, want %v", err, ErrPriceAmountConflict)
+	}
+}
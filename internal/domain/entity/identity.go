@@ -0,0 +1,122 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/oklog/ulid/v2"
+)
+
+var (
+	ErrInvalidIDFields = errors.New("invalid product id fields")
+	ErrMissingIDField  = errors.New("product field used for identity is empty")
+)
+
+// Campos que podem compor a identidade derivada do produto.
+const (
+	IDFieldName            = "name"
+	IDFieldReferenceNumber = "reference_number"
+	IDFieldSKU             = "sku"
+	IDFieldBrand           = "brand"
+)
+
+// IDFields é a lista ordenada de campos usada para derivar o ID. A ordem faz
+// parte da identidade: name,reference_number e reference_number,name geram IDs
+// diferentes. A lista vazia (ou nil) equivale a DefaultIDFields.
+type IDFields []string
+
+// DefaultIDFields reproduz GenerateProductID (nome + referência).
+var DefaultIDFields = IDFields{IDFieldName, IDFieldReferenceNumber}
+
+// ParseIDFields interpreta uma lista separada por vírgula, como
+// "reference_number" ou "name,reference_number".
+func ParseIDFields(value string) (IDFields, error) {
+	var fields IDFields
+	seen := make(map[string]bool)
+
+	for _, raw := range strings.Split(value, ",") {
+		field := strings.ToLower(strings.TrimSpace(raw))
+		if field == "" {
+			continue
+		}
+
+		switch field {
+		case IDFieldName, IDFieldReferenceNumber, IDFieldSKU, IDFieldBrand:
+		default:
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidIDFields, field)
+		}
+
+		if seen[field] {
+			return nil, fmt.Errorf("%w: duplicated field %q", ErrInvalidIDFields, field)
+		}
+		seen[field] = true
+		fields = append(fields, field)
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: at least one field is required", ErrInvalidIDFields)
+	}
+
+	return fields, nil
+}
+
+// String retorna os campos no formato aceito por ParseIDFields.
+func (f IDFields) String() string {
+	if len(f) == 0 {
+		return DefaultIDFields.String()
+	}
+	return strings.Join(f, ",")
+}
+
+// GenerateID deriva o ID do produto a partir dos campos configurados. Todos
+// precisam estar preenchidos; com os campos padrão o resultado é o mesmo de
+// GenerateProductID.
+func (f IDFields) GenerateID(p *Product) (string, error) {
+	if len(f) == 0 {
+		f = DefaultIDFields
+	}
+
+	values := make([]string, len(f))
+	for i, field := range f {
+		var value string
+		switch field {
+		case IDFieldName:
+			value = p.Name
+		case IDFieldReferenceNumber:
+			value = p.ReferenceNumber
+		case IDFieldSKU:
+			value = p.SKU
+		case IDFieldBrand:
+			value = p.Brand
+		default:
+			return "", fmt.Errorf("%w: unknown field %q", ErrInvalidIDFields, field)
+		}
+
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			return "", fmt.Errorf("%w: %s", ErrMissingIDField, field)
+		}
+		values[i] = value
+	}
+
+	return deriveID(strings.Join(values, "|")), nil
+}
+
+// AssignID recalcula o ID do produto com os campos informados.
+func (p *Product) AssignID(fields IDFields) error {
+	id, err := fields.GenerateID(p)
+	if err != nil {
+		return err
+	}
+	p.ID = id
+	return nil
+}
+
+func deriveID(seed string) string {
+	hash := sha256.Sum256([]byte(seed))
+	entropy := hash[:16]
+	id := ulid.MustNew(0, &deterministicReader{data: entropy})
+	return id.String()
+}
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseIDFields(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "name,reference_number", want: "name,reference_number"},
+		{value: " Reference_Number ", want: "reference_number"},
+		{value: "sku,brand", want: "sku,brand"},
+		{value: "", wantErr: true},
+		{value: "price", wantErr: true},
+		{value: "sku,sku", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		fields, err := ParseIDFields(tt.value)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidIDFields) {
+				t.Errorf("ParseIDFields(%q) error = %v, want %v", tt.value, err, ErrInvalidIDFields)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseIDFields(%q) unexpected error = %v", tt.value, err)
+			continue
+		}
+		if fields.String() != tt.want {
+			t.Errorf("ParseIDFields(%q) = %s, want %s", tt.value, fields, tt.want)
+		}
+	}
+}
+
+func TestIDFields_GenerateID(t *testing.T) {
+	product := &Product{Name: "iPhone 15 Pro", ReferenceNumber: "APL-IP15P-001", SKU: "SKU-1"}
+
+	id, err := DefaultIDFields.GenerateID(product)
+	if err != nil {
+		t.Fatalf("GenerateID() unexpected error = %v", err)
+	}
+	if id != GenerateProductID(product.Name, product.ReferenceNumber) {
+		t.Error("GenerateID() with default fields must match GenerateProductID")
+	}
+
+	byRef, _ := IDFields{IDFieldReferenceNumber}.GenerateID(product)
+	renamed, _ := IDFields{IDFieldReferenceNumber}.GenerateID(&Product{Name: "Other", ReferenceNumber: "apl-ip15p-001"})
+	if byRef != renamed {
+		t.Error("GenerateID() by reference must ignore the name")
+	}
+	if byRef == id {
+		t.Error("GenerateID() with different fields must produce a different ID")
+	}
+
+	if _, err := (IDFields{IDFieldBrand}).GenerateID(product); !errors.Is(err, ErrMissingIDField) {
+		t.Errorf("GenerateID() error = %v, want %v", err, ErrMissingIDField)
+	}
+}
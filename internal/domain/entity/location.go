@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Chaves de specifications com a localização da loja, em graus decimais.
+const (
+	SpecLatitude  = "lat"
+	SpecLongitude = "lng"
+)
+
+var (
+	ErrNoCoordinates      = errors.New("product specifications have no lat/lng")
+	ErrInvalidCoordinates = errors.New("product lat/lng are not valid coordinates")
+)
+
+// Coordinates lê lat e lng das specifications. Retorna ErrNoCoordinates se
+// faltar alguma das duas e ErrInvalidCoordinates se não forem números ou
+// estiverem fora das faixas (lat entre -90 e 90, lng entre -180 e 180).
+func (p *Product) Coordinates() (lat, lng float64, err error) {
+	rawLat, hasLat := p.Specifications[SpecLatitude]
+	rawLng, hasLng := p.Specifications[SpecLongitude]
+	if !hasLat || !hasLng {
+		return 0, 0, ErrNoCoordinates
+	}
+
+	lat, latOK := coordinate(rawLat)
+	lng, lngOK := coordinate(rawLng)
+	if !latOK || !lngOK || lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return 0, 0, ErrInvalidCoordinates
+	}
+	return lat, lng, nil
+}
+
+// coordinate aceita os tipos numéricos que as specifications podem ter depois
+// do JSON da API ou da leitura do cache.
+func coordinate(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestProductCoordinates(t *testing.T) {
+	tests := []struct {
+		name    string
+		specs   map[string]interface{}
+		lat     float64
+		lng     float64
+		wantErr error
+	}{
+		{name: "valid", specs: map[string]interface{}{"lat": -23.5505, "lng": -46.6333}, lat: -23.5505, lng: -46.6333},
+		{name: "integers", specs: map[string]interface{}{"lat": 0, "lng": int64(180)}, lat: 0, lng: 180},
+		{name: "json number", specs: map[string]interface{}{"lat": json.Number("51.5"), "lng": json.Number("-0.12")}, lat: 51.5, lng: -0.12},
+		{name: "no specifications", wantErr: ErrNoCoordinates},
+		{name: "missing lng", specs: map[string]interface{}{"lat": 10.0}, wantErr: ErrNoCoordinates},
+		{name: "latitude out of range", specs: map[string]interface{}{"lat": 90.1, "lng": 0.0}, wantErr: ErrInvalidCoordinates},
+		{name: "longitude out of range", specs: map[string]interface{}{"lat": 0.0, "lng": -180.5}, wantErr: ErrInvalidCoordinates},
+		{name: "not a number", specs: map[string]interface{}{"lat": "-23.5", "lng": -46.6}, wantErr: ErrInvalidCoordinates},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product := &Product{Specifications: tt.specs}
+			lat, lng, err := product.Coordinates()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Coordinates() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Coordinates() unexpected error = %v", err)
+			}
+			if lat != tt.lat || lng != tt.lng {
+				t.Errorf("Coordinates() = (%v, %v), want (%v, %v)", lat, lng, tt.lat, tt.lng)
+			}
+		})
+	}
+}
@@ -1,12 +1,13 @@
 package entity
 
 import (
-	"crypto/sha256"
 	"errors"
+	"net/url"
+	"reflect"
+	"sort"
 	"strings"
 	"time"
-
-	"github.com/oklog/ulid/v2"
+	"unicode/utf8"
 )
 
 var (
@@ -15,7 +16,25 @@ var (
 	ErrInvalidReference = errors.New("product reference is required")
 	ErrInvalidCategory  = errors.New("product category is required")
 	ErrInvalidStock     = errors.New("product stock cannot be negative")
+	ErrInvalidPrice     = errors.New("product price cannot be negative")
 	ErrVersionConflict  = errors.New("product version conflict - concurrent modification detected")
+
+	// ErrPreconditionFailed indica que a versão enviada pelo cliente (If-Match)
+	// não é a versão atual do produto.
+	ErrPreconditionFailed = errors.New("product version does not match the expected version")
+
+	ErrInsufficientStock = errors.New("stock adjustment would make stock negative")
+
+	ErrInvalidThumbnailURL = errors.New("product thumbnail_url must be an absolute http(s) URL")
+
+	ErrDuplicateNameInCategory = errors.New("a product with the same name already exists in this category")
+
+	ErrInvalidTag  = errors.New("product tag must have between 1 and 50 characters")
+	ErrTooManyTags = errors.New("product cannot have more than 20 tags")
+
+	ErrInvalidTimestamp     = errors.New("product timestamp is malformed")
+	ErrFutureCreatedAt      = errors.New("product created_at is in the future")
+	ErrUpdatedBeforeCreated = errors.New("product updated_at is before created_at")
 )
 
 type Product struct {
@@ -27,14 +46,22 @@ type Product struct {
 	SKU             string                 `json:"sku"`
 	Brand           string                 `json:"brand"`
 	Stock           int                    `json:"stock"`
+	Price           int64                  `json:"price"`
+	Currency        string                 `json:"currency,omitempty"`
 	Images          []string               `json:"images"`
 	Specifications  map[string]interface{} `json:"specifications"`
+	ThumbnailURL    string                 `json:"thumbnail_url,omitempty"`
+	Tags            []string               `json:"tags,omitempty"`
+	WeightGrams     int                    `json:"weight_grams,omitempty"`
+	Dimensions      Dimensions             `json:"dimensions,omitzero"`
 	Version         int                    `json:"version"`
 	CreatedAt       time.Time              `json:"created_at"`
 	UpdatedAt       time.Time              `json:"updated_at"`
 }
 
-func NewProduct(name, referenceNumber, category, description, sku, brand string, stock int, images []string, specs map[string]interface{}) (*Product, error) {
+// NewProduct cria o produto com o preço em centavos (price) na moeda informada
+// (código ISO 4217, gravado em maiúsculas).
+func NewProduct(name, referenceNumber, category, description, sku, brand string, stock int, price int64, currency string, images []string, specs map[string]interface{}) (*Product, error) {
 	p := &Product{
 		Name:            strings.TrimSpace(name),
 		ReferenceNumber: strings.TrimSpace(referenceNumber),
@@ -43,6 +70,8 @@ func NewProduct(name, referenceNumber, category, description, sku, brand string,
 		SKU:             strings.TrimSpace(sku),
 		Brand:           strings.TrimSpace(brand),
 		Stock:           stock,
+		Price:           price,
+		Currency:        normalizeCurrency(currency),
 		Images:          images,
 		Specifications:  specs,
 		Version:         1,
@@ -59,29 +88,57 @@ func NewProduct(name, referenceNumber, category, description, sku, brand string,
 	return p, nil
 }
 
+// Validate confere todos os campos obrigatórios de uma vez e retorna as
+// falhas como ValidationErrors, ou nil.
 func (p *Product) Validate() error {
+	invalid := ValidationErrors{}
 	if p.Name == "" {
-		return ErrInvalidName
+		invalid.Add("name", ErrInvalidName)
 	}
 	if p.ReferenceNumber == "" {
-		return ErrInvalidReference
+		invalid.Add("reference_number", ErrInvalidReference)
 	}
 	if p.Category == "" {
-		return ErrInvalidCategory
+		invalid.Add("category", ErrInvalidCategory)
 	}
 	if p.Stock < 0 {
-		return ErrInvalidStock
+		invalid.Add("stock", ErrInvalidStock)
+	}
+	if p.Price < 0 {
+		invalid.Add("price", ErrInvalidPrice)
+	}
+	return invalid.Err()
+}
+
+func normalizeCurrency(currency string) string {
+	return strings.ToUpper(strings.TrimSpace(currency))
+}
+
+// ValidateTimestamps verifica timestamps fornecidos pelo cliente (importação):
+// created_at não pode estar no futuro além de maxSkew e updated_at não pode ser
+// anterior a created_at.
+func (p *Product) ValidateTimestamps(now time.Time, maxSkew time.Duration) error {
+	if p.CreatedAt.IsZero() || p.UpdatedAt.IsZero() {
+		return ErrInvalidTimestamp
+	}
+	if p.CreatedAt.After(now.Add(maxSkew)) {
+		return ErrFutureCreatedAt
+	}
+	if p.UpdatedAt.Before(p.CreatedAt) {
+		return ErrUpdatedBeforeCreated
 	}
 	return nil
 }
 
-func (p *Product) Update(name, category, description, sku, brand string, stock int, images []string, specs map[string]interface{}) error {
+func (p *Product) Update(name, category, description, sku, brand string, stock int, price int64, currency string, images []string, specs map[string]interface{}) error {
 	p.Name = strings.TrimSpace(name)
 	p.Category = strings.TrimSpace(category)
 	p.Description = strings.TrimSpace(description)
 	p.SKU = strings.TrimSpace(sku)
 	p.Brand = strings.TrimSpace(brand)
 	p.Stock = stock
+	p.Price = price
+	p.Currency = normalizeCurrency(currency)
 	p.Images = images
 	p.Specifications = specs
 	p.UpdatedAt = time.Now().UTC()
@@ -90,6 +147,98 @@ func (p *Product) Update(name, category, description, sku, brand string, stock i
 	return p.Validate()
 }
 
+// SetThumbnailURL define a miniatura exibida em listagens. Vazio remove a
+// miniatura própria e Thumbnail volta a usar a primeira imagem.
+func (p *Product) SetThumbnailURL(raw string) error {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		p.ThumbnailURL = ""
+		return nil
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidThumbnailURL
+	}
+
+	p.ThumbnailURL = raw
+	return nil
+}
+
+const (
+	// MaxTags é o número máximo de tags por produto.
+	MaxTags = 20
+	// MaxTagLength é o tamanho máximo de uma tag, em caracteres.
+	MaxTagLength = 50
+)
+
+// NormalizeTag deixa a tag no formato armazenado e indexado: minúscula e sem
+// espaços nas pontas.
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// ParseTag normaliza uma tag avulsa e a valida; vazia ou longa demais é
+// ErrInvalidTag.
+func ParseTag(raw string) (string, error) {
+	tag := NormalizeTag(raw)
+	if tag == "" || utf8.RuneCountInString(tag) > MaxTagLength {
+		return "", ErrInvalidTag
+	}
+	return tag, nil
+}
+
+// SetTags normaliza, remove vazias e duplicadas e ordena as tags, para que a
+// comparação em Equals não dependa da ordem enviada.
+func (p *Product) SetTags(tags []string) error {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+
+	for _, tag := range tags {
+		if NormalizeTag(tag) == "" {
+			continue
+		}
+		tag, err := ParseTag(tag)
+		if err != nil {
+			return err
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > MaxTags {
+		return ErrTooManyTags
+	}
+
+	sort.Strings(normalized)
+	if len(normalized) == 0 {
+		normalized = nil
+	}
+	p.Tags = normalized
+	return nil
+}
+
+// Thumbnail devolve a miniatura do produto: ThumbnailURL quando definida, senão
+// a primeira imagem.
+func (p *Product) Thumbnail() string {
+	if p.ThumbnailURL != "" {
+		return p.ThumbnailURL
+	}
+	if len(p.Images) > 0 {
+		return p.Images[0]
+	}
+	return ""
+}
+
+// Available indica se o produto pode ser vendido. Não é gravado: deriva do
+// estoque e, quando houver status, deve exigir também o produto ativo.
+func (p *Product) Available() bool {
+	return p.Stock > 0
+}
+
 func (p *Product) Equals(other *Product) bool {
 	if other == nil {
 		return false
@@ -101,7 +250,12 @@ func (p *Product) Equals(other *Product) bool {
 		p.Description != other.Description ||
 		p.SKU != other.SKU ||
 		p.Brand != other.Brand ||
-		p.Stock != other.Stock {
+		p.Stock != other.Stock ||
+		p.Price != other.Price ||
+		p.Currency != other.Currency ||
+		p.ThumbnailURL != other.ThumbnailURL ||
+		p.WeightGrams != other.WeightGrams ||
+		p.Dimensions != other.Dimensions {
 		return false
 	}
 
@@ -114,12 +268,21 @@ func (p *Product) Equals(other *Product) bool {
 		}
 	}
 
+	if len(p.Tags) != len(other.Tags) {
+		return false
+	}
+	for i := range p.Tags {
+		if p.Tags[i] != other.Tags[i] {
+			return false
+		}
+	}
+
 	if len(p.Specifications) != len(other.Specifications) {
 		return false
 	}
 	for key, val := range p.Specifications {
 		otherVal, exists := other.Specifications[key]
-		if !exists || val != otherVal {
+		if !exists || !reflect.DeepEqual(val, otherVal) {
 			return false
 		}
 	}
@@ -130,11 +293,7 @@ func (p *Product) Equals(other *Product) bool {
 func GenerateProductID(name, referenceNumber string) string {
 	normalizedName := strings.ToLower(strings.TrimSpace(name))
 	normalizedRef := strings.ToLower(strings.TrimSpace(referenceNumber))
-	seed := normalizedName + "|" + normalizedRef
-	hash := sha256.Sum256([]byte(seed))
-	entropy := hash[:16]
-	id := ulid.MustNew(0, &deterministicReader{data: entropy})
-	return id.String()
+	return deriveID(normalizedName + "|" + normalizedRef)
 }
 
 type deterministicReader struct {
@@ -1,7 +1,11 @@
 package entity
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewProduct(t *testing.T) {
@@ -14,6 +18,8 @@ func TestNewProduct(t *testing.T) {
 		sku             string
 		brand           string
 		stock           int
+		price           int64
+		currency        string
 		wantErr         bool
 		expectedErr     error
 	}{
@@ -26,6 +32,8 @@ func TestNewProduct(t *testing.T) {
 			sku:             "APPLE-IP15P",
 			brand:           "Apple",
 			stock:           50,
+			price:           999900,
+			currency:        " brl ",
 			wantErr:         false,
 		},
 		{
@@ -76,6 +84,17 @@ func TestNewProduct(t *testing.T) {
 			wantErr:         true,
 			expectedErr:     ErrInvalidStock,
 		},
+		{
+			name:            "negative price",
+			productName:     "iPhone 15 Pro",
+			referenceNumber: "APL-IP15P-001",
+			category:        "Smartphones",
+			stock:           50,
+			price:           -1,
+			currency:        "BRL",
+			wantErr:         true,
+			expectedErr:     ErrInvalidPrice,
+		},
 	}
 
 	for _, tt := range tests {
@@ -88,6 +107,8 @@ func TestNewProduct(t *testing.T) {
 				tt.sku,
 				tt.brand,
 				tt.stock,
+				tt.price,
+				tt.currency,
 				[]string{},
 				map[string]interface{}{},
 			)
@@ -97,7 +118,7 @@ func TestNewProduct(t *testing.T) {
 					t.Errorf("NewProduct() expected error but got none")
 					return
 				}
-				if tt.expectedErr != nil && err != tt.expectedErr {
+				if tt.expectedErr != nil && !errors.Is(err, tt.expectedErr) {
 					t.Errorf("NewProduct() error = %v, want %v", err, tt.expectedErr)
 				}
 				return
@@ -127,6 +148,10 @@ func TestNewProduct(t *testing.T) {
 			if product.Name != tt.productName {
 				t.Errorf("NewProduct() name = %s, want %s", product.Name, tt.productName)
 			}
+
+			if product.Price != tt.price || product.Currency != "BRL" {
+				t.Errorf("NewProduct() price = %d %s, want %d BRL", product.Price, product.Currency, tt.price)
+			}
 		})
 	}
 }
@@ -178,6 +203,8 @@ func TestProductEquals(t *testing.T) {
 		"APPLE-IP15P",
 		"Apple",
 		50,
+		0,
+		"",
 		[]string{"img1.jpg"},
 		map[string]interface{}{"storage": "256GB"},
 	)
@@ -190,6 +217,8 @@ func TestProductEquals(t *testing.T) {
 		"APPLE-IP15P",
 		"Apple",
 		50,
+		0,
+		"",
 		[]string{"img1.jpg"},
 		map[string]interface{}{"storage": "256GB"},
 	)
@@ -202,6 +231,8 @@ func TestProductEquals(t *testing.T) {
 		"APPLE-IP15P",
 		"Apple",
 		50,
+		0,
+		"",
 		[]string{"img1.jpg"},
 		map[string]interface{}{"storage": "256GB"},
 	)
@@ -251,6 +282,8 @@ func TestProductUpdate(t *testing.T) {
 		"APPLE-IP15P",
 		"Apple",
 		50,
+		0,
+		"",
 		[]string{"img1.jpg"},
 		map[string]interface{}{"storage": "256GB"},
 	)
@@ -264,6 +297,8 @@ func TestProductUpdate(t *testing.T) {
 		"APPLE-IP15P",
 		"Apple",
 		45,
+		0,
+		"",
 		[]string{"img1.jpg", "img2.jpg"},
 		map[string]interface{}{"storage": "256GB", "color": "Titanium"},
 	)
@@ -284,3 +319,148 @@ func TestProductUpdate(t *testing.T) {
 		t.Errorf("Product.Update() stock = %d, want 45", product.Stock)
 	}
 }
+
+func TestProductValidateTimestamps(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		createdAt   time.Time
+		updatedAt   time.Time
+		expectedErr error
+	}{
+		{
+			name:      "valid past timestamps",
+			createdAt: now.Add(-48 * time.Hour),
+			updatedAt: now.Add(-24 * time.Hour),
+		},
+		{
+			name:      "created_at within clock skew",
+			createdAt: now.Add(30 * time.Second),
+			updatedAt: now.Add(30 * time.Second),
+		},
+		{
+			name:        "created_at in the future",
+			createdAt:   now.Add(time.Hour),
+			updatedAt:   now.Add(time.Hour),
+			expectedErr: ErrFutureCreatedAt,
+		},
+		{
+			name:        "updated_at before created_at",
+			createdAt:   now.Add(-time.Hour),
+			updatedAt:   now.Add(-2 * time.Hour),
+			expectedErr: ErrUpdatedBeforeCreated,
+		},
+		{
+			name:        "missing created_at",
+			updatedAt:   now,
+			expectedErr: ErrInvalidTimestamp,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product := &Product{CreatedAt: tt.createdAt, UpdatedAt: tt.updatedAt}
+
+			err := product.ValidateTimestamps(now, time.Minute)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Product.ValidateTimestamps() error = %v, want %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestProductThumbnail(t *testing.T) {
+	product := &Product{Images: []string{"https://example.com/img1.jpg", "https://example.com/img2.jpg"}}
+
+	if got := product.Thumbnail(); got != "https://example.com/img1.jpg" {
+		t.Errorf("Thumbnail() = %q, want first image", got)
+	}
+
+	if err := product.SetThumbnailURL("  https://cdn.example.com/thumb.jpg "); err != nil {
+		t.Fatalf("SetThumbnailURL() unexpected error = %v", err)
+	}
+	if got := product.Thumbnail(); got != "https://cdn.example.com/thumb.jpg" {
+		t.Errorf("Thumbnail() = %q, want explicit thumbnail", got)
+	}
+
+	for _, raw := range []string{"thumb.jpg", "/img/thumb.jpg", "ftp://example.com/thumb.jpg", "https://", "http://exa mple.com"} {
+		if err := product.SetThumbnailURL(raw); !errors.Is(err, ErrInvalidThumbnailURL) {
+			t.Errorf("SetThumbnailURL(%q) error = %v, want ErrInvalidThumbnailURL", raw, err)
+		}
+	}
+	if product.ThumbnailURL != "https://cdn.example.com/thumb.jpg" {
+		t.Error("Expected invalid URL to keep the previous thumbnail")
+	}
+
+	if err := product.SetThumbnailURL(""); err != nil {
+		t.Fatalf("SetThumbnailURL(\"\") unexpected error = %v", err)
+	}
+	if got := product.Thumbnail(); got != "https://example.com/img1.jpg" {
+		t.Errorf("Thumbnail() = %q, want fallback to first image after clearing", got)
+	}
+
+	if got := (&Product{}).Thumbnail(); got != "" {
+		t.Errorf("Thumbnail() = %q, want empty without images", got)
+	}
+}
+
+func TestProductSetTags(t *testing.T) {
+	product := &Product{}
+
+	if err := product.SetTags([]string{" Promo ", "apple", "PROMO", "", "Black Friday"}); err != nil {
+		t.Fatalf("SetTags() unexpected error = %v", err)
+	}
+	want := []string{"apple", "black friday", "promo"}
+	if len(product.Tags) != len(want) {
+		t.Fatalf("Tags = %v, want %v", product.Tags, want)
+	}
+	for i := range want {
+		if product.Tags[i] != want[i] {
+			t.Errorf("Tags[%d] = %q, want %q", i, product.Tags[i], want[i])
+		}
+	}
+
+	if err := product.SetTags([]string{strings.Repeat("a", MaxTagLength+1)}); !errors.Is(err, ErrInvalidTag) {
+		t.Errorf("SetTags() long tag error = %v, want ErrInvalidTag", err)
+	}
+
+	tooMany := make([]string, MaxTags+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("tag-%d", i)
+	}
+	if err := product.SetTags(tooMany); !errors.Is(err, ErrTooManyTags) {
+		t.Errorf("SetTags() too many error = %v, want ErrTooManyTags", err)
+	}
+
+	other := &Product{}
+	_ = other.SetTags([]string{"promo", "Apple", "black friday"})
+	if !product.Equals(other) {
+		t.Error("Expected products with the same tags in any order to be equal")
+	}
+
+	_ = other.SetTags(nil)
+	if product.Equals(other) {
+		t.Error("Expected products with different tags not to be equal")
+	}
+	if other.Tags != nil {
+		t.Errorf("Tags = %v, want nil after clearing", other.Tags)
+	}
+}
+
+func TestProductAvailable(t *testing.T) {
+	tests := []struct {
+		stock int
+		want  bool
+	}{
+		{stock: 0, want: false},
+		{stock: 1, want: true},
+		{stock: 100, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := (&Product{Stock: tt.stock}).Available(); got != tt.want {
+			t.Errorf("Available() with stock %d = %v, want %v", tt.stock, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidReferenceNormalization = errors.New("invalid reference normalization")
+
+// Passos aceitos na normalização do reference_number.
+const (
+	ReferenceUppercase    = "uppercase"
+	ReferenceAlphanumeric = "alphanumeric"
+)
+
+// ReferenceNormalization define como o reference_number é normalizado antes
+// de derivar o ID, para que referências equivalentes (REF-001 e ref 001)
+// apontem para o mesmo produto. O valor zero mantém a referência como veio
+// (apenas sem espaços nas pontas).
+type ReferenceNormalization struct {
+	Uppercase        bool
+	AlphanumericOnly bool
+}
+
+// ParseReferenceNormalization interpreta uma lista separada por vírgula, como
+// "uppercase,alphanumeric". Vazio desativa a normalização.
+func ParseReferenceNormalization(value string) (ReferenceNormalization, error) {
+	var n ReferenceNormalization
+
+	for _, raw := range strings.Split(value, ",") {
+		step := strings.ToLower(strings.TrimSpace(raw))
+		switch step {
+		case "":
+		case ReferenceUppercase:
+			n.Uppercase = true
+		case ReferenceAlphanumeric:
+			n.AlphanumericOnly = true
+		default:
+			return ReferenceNormalization{}, fmt.Errorf("%w: unknown step %q", ErrInvalidReferenceNormalization, step)
+		}
+	}
+
+	return n, nil
+}
+
+// String retorna os passos no formato aceito por ParseReferenceNormalization.
+func (n ReferenceNormalization) String() string {
+	var steps []string
+	if n.Uppercase {
+		steps = append(steps, ReferenceUppercase)
+	}
+	if n.AlphanumericOnly {
+		steps = append(steps, ReferenceAlphanumeric)
+	}
+	return strings.Join(steps, ",")
+}
+
+// Apply normaliza a referência: remove espaços nas pontas, converte para
+// maiúsculas e descarta tudo que não é letra ou dígito, conforme os passos
+// ativos.
+func (n ReferenceNormalization) Apply(reference string) string {
+	reference = strings.TrimSpace(reference)
+
+	if n.AlphanumericOnly {
+		reference = strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, reference)
+	}
+	if n.Uppercase {
+		reference = strings.ToUpper(reference)
+	}
+
+	return reference
+}
+
+// NormalizeReference aplica a normalização ao reference_number do produto.
+// Uma referência que fica vazia (só pontuação, por exemplo) é inválida.
+func (p *Product) NormalizeReference(n ReferenceNormalization) error {
+	reference := n.Apply(p.ReferenceNumber)
+	if reference == "" {
+		return ErrInvalidReference
+	}
+	p.ReferenceNumber = reference
+	return nil
+}
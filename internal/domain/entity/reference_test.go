@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseReferenceNormalization(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "", want: ""},
+		{value: "uppercase", want: "uppercase"},
+		{value: " Alphanumeric , UPPERCASE ", want: "uppercase,alphanumeric"},
+		{value: "lowercase", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		n, err := ParseReferenceNormalization(tt.value)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidReferenceNormalization) {
+				t.Errorf("ParseReferenceNormalization(%q) error = %v, want %v", tt.value, err, ErrInvalidReferenceNormalization)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseReferenceNormalization(%q) unexpected error = %v", tt.value, err)
+			continue
+		}
+		if n.String() != tt.want {
+			t.Errorf("ParseReferenceNormalization(%q) = %q, want %q", tt.value, n.String(), tt.want)
+		}
+	}
+}
+
+func TestReferenceNormalization_Apply(t *testing.T) {
+	full := ReferenceNormalization{Uppercase: true, AlphanumericOnly: true}
+
+	tests := []struct {
+		name string
+		n    ReferenceNormalization
+		in   string
+		want string
+	}{
+		{name: "disabled", n: ReferenceNormalization{}, in: " ref-001 ", want: "ref-001"},
+		{name: "uppercase", n: ReferenceNormalization{Uppercase: true}, in: "ref-001", want: "REF-001"},
+		{name: "alphanumeric", n: ReferenceNormalization{AlphanumericOnly: true}, in: "Ref. 001/a", want: "Ref001a"},
+		{name: "full", n: full, in: "REF-001", want: "REF001"},
+		{name: "full with spaces", n: full, in: "ref 001", want: "REF001"},
+		{name: "non-ascii letters", n: full, in: "ação-1", want: "AÇÃO1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.n.Apply(tt.in); got != tt.want {
+				t.Errorf("Apply(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProductNormalizeReference_Empty(t *testing.T) {
+	p := &Product{ReferenceNumber: "--"}
+
+	err := p.NormalizeReference(ReferenceNormalization{AlphanumericOnly: true})
+
+	if !errors.Is(err, ErrInvalidReference) {
+		t.Errorf("Expected ErrInvalidReference, got %v", err)
+	}
+	if p.ReferenceNumber != "--" {
+		t.Errorf("Expected reference to be left untouched, got %q", p.ReferenceNumber)
+	}
+}
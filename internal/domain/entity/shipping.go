@@ -0,0 +1,39 @@
+package entity
+
+import "errors"
+
+var (
+	ErrInvalidWeight     = errors.New("product weight cannot be negative")
+	ErrInvalidDimensions = errors.New("product dimensions cannot be negative")
+)
+
+// Dimensions são as medidas do produto em milímetros, usadas no cálculo de
+// frete. Zero em uma medida significa que ela não foi informada.
+type Dimensions struct {
+	LengthMM int `json:"length_mm"`
+	WidthMM  int `json:"width_mm"`
+	HeightMM int `json:"height_mm"`
+}
+
+// IsZero indica que nenhuma medida foi informada.
+func (d Dimensions) IsZero() bool {
+	return d == Dimensions{}
+}
+
+// SetWeight define o peso em gramas; zero deixa o peso sem informação.
+func (p *Product) SetWeight(grams int) error {
+	if grams < 0 {
+		return ErrInvalidWeight
+	}
+	p.WeightGrams = grams
+	return nil
+}
+
+// SetDimensions define as medidas do produto; nenhuma pode ser negativa.
+func (p *Product) SetDimensions(dimensions Dimensions) error {
+	if dimensions.LengthMM < 0 || dimensions.WidthMM < 0 || dimensions.HeightMM < 0 {
+		return ErrInvalidDimensions
+	}
+	p.Dimensions = dimensions
+	return nil
+}
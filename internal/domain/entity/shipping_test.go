@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestProductShipping(t *testing.T) {
+	product := &Product{}
+
+	if err := product.SetWeight(187); err != nil || product.WeightGrams != 187 {
+		t.Fatalf("Expected weight 187, got %d (%v)", product.WeightGrams, err)
+	}
+	if err := product.SetWeight(-1); !errors.Is(err, ErrInvalidWeight) || product.WeightGrams != 187 {
+		t.Errorf("Expected ErrInvalidWeight keeping the previous weight, got %d (%v)", product.WeightGrams, err)
+	}
+
+	dimensions := Dimensions{LengthMM: 147, WidthMM: 71, HeightMM: 8}
+	if err := product.SetDimensions(dimensions); err != nil || product.Dimensions != dimensions {
+		t.Fatalf("Expected dimensions %+v, got %+v (%v)", dimensions, product.Dimensions, err)
+	}
+	if err := product.SetDimensions(Dimensions{HeightMM: -8}); !errors.Is(err, ErrInvalidDimensions) || product.Dimensions != dimensions {
+		t.Errorf("Expected ErrInvalidDimensions keeping the previous dimensions, got %+v (%v)", product.Dimensions, err)
+	}
+
+	if !(Dimensions{}).IsZero() || dimensions.IsZero() {
+		t.Error("Expected only empty dimensions to be zero")
+	}
+}
+
+func TestProductEquals_Shipping(t *testing.T) {
+	base := &Product{Name: "iPhone 15", WeightGrams: 171, Dimensions: Dimensions{LengthMM: 147, WidthMM: 71, HeightMM: 8}}
+
+	heavier := *base
+	heavier.WeightGrams = 200
+	if base.Equals(&heavier) {
+		t.Error("Expected different weights not to be equal")
+	}
+
+	taller := *base
+	taller.Dimensions.HeightMM = 9
+	if base.Equals(&taller) {
+		t.Error("Expected different dimensions not to be equal")
+	}
+
+	same := *base
+	if !base.Equals(&same) {
+		t.Error("Expected identical shipping data to be equal")
+	}
+}
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrSpecificationsTooDeep = errors.New("product specifications are nested too deeply")
+
+// CheckSpecificationsDepth recusa especificações com mais de maxDepth níveis
+// de aninhamento. O próprio mapa é o nível 1; cada objeto ou array dentro dele
+// soma um nível. maxDepth <= 0 desativa o limite. A descida para assim que o
+// limite é ultrapassado, então o custo é limitado mesmo para entradas hostis.
+func CheckSpecificationsDepth(specs map[string]interface{}, maxDepth int) error {
+	if maxDepth <= 0 || len(specs) == 0 {
+		return nil
+	}
+	if specDepthExceeds(specs, 1, maxDepth) {
+		return fmt.Errorf("%w (max %d levels)", ErrSpecificationsTooDeep, maxDepth)
+	}
+	return nil
+}
+
+func specDepthExceeds(value interface{}, depth, maxDepth int) bool {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if depth > maxDepth {
+			return true
+		}
+		for _, child := range v {
+			if specDepthExceeds(child, depth+1, maxDepth) {
+				return true
+			}
+		}
+	case []interface{}:
+		if depth > maxDepth {
+			return true
+		}
+		for _, child := range v {
+			if specDepthExceeds(child, depth+1, maxDepth) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckSpecificationsDepth(t *testing.T) {
+	flat := map[string]interface{}{"cpu": "i7", "ram": 32}
+	nested := map[string]interface{}{
+		"dimensions": map[string]interface{}{"width": 30},
+		"ports":      []interface{}{"usb-c", map[string]interface{}{"hdmi": 2}},
+	}
+
+	tests := []struct {
+		name     string
+		specs    map[string]interface{}
+		maxDepth int
+		wantErr  bool
+	}{
+		{name: "disabled", specs: nested, maxDepth: 0},
+		{name: "flat within one level", specs: flat, maxDepth: 1},
+		{name: "nested map over one level", specs: map[string]interface{}{"dimensions": map[string]interface{}{"width": 30}}, maxDepth: 1, wantErr: true},
+		{name: "array counts as a level", specs: nested, maxDepth: 2, wantErr: true},
+		{name: "nested within three levels", specs: nested, maxDepth: 3},
+		{name: "empty specs", specs: nil, maxDepth: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckSpecificationsDepth(tt.specs, tt.maxDepth)
+			if tt.wantErr != errors.Is(err, ErrSpecificationsTooDeep) {
+				t.Errorf("CheckSpecificationsDepth() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProductEquals_NestedSpecifications(t *testing.T) {
+	a := &Product{Specifications: map[string]interface{}{"dimensions": map[string]interface{}{"width": 30.0}}}
+	b := &Product{Specifications: map[string]interface{}{"dimensions": map[string]interface{}{"width": 30.0}}}
+	c := &Product{Specifications: map[string]interface{}{"dimensions": map[string]interface{}{"width": 31.0}}}
+
+	if !a.Equals(b) {
+		t.Error("Expected identical nested specifications to be equal")
+	}
+	if a.Equals(c) {
+		t.Error("Expected different nested specifications not to be equal")
+	}
+}
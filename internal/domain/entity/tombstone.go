@@ -0,0 +1,10 @@
+package entity
+
+import "time"
+
+// Tombstone é o que resta de um produto excluído logicamente: o ID e o
+// momento da exclusão, sem os dados do produto.
+type Tombstone struct {
+	ID        string
+	DeletedAt time.Time
+}
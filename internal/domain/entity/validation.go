@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// ValidationErrors reúne as falhas de validação de um produto por campo, com
+// o nome do campo no JSON da API. Unwrap expõe o erro de cada campo, então
+// errors.Is continua reconhecendo ErrInvalidName e os demais.
+type ValidationErrors map[string]error
+
+// fieldMessages são as mensagens curtas por campo, já que o nome do campo vai
+// na chave; erros fora da tabela usam a própria mensagem.
+var fieldMessages = []struct {
+	err     error
+	message string
+}{
+	{ErrInvalidName, "is required"},
+	{ErrInvalidReference, "is required"},
+	{ErrInvalidCategory, "is required"},
+	{ErrInvalidStock, "cannot be negative"},
+	{ErrInvalidPrice, "cannot be negative"},
+	{ErrInvalidPriceAmount, "must be a non-negative decimal number"},
+	{ErrPricePrecision, "has more decimal places than the currency allows"},
+	{ErrPriceAmountConflict, "cannot be sent together with price_amount"},
+	{ErrInvalidThumbnailURL, "must be an absolute http(s) URL"},
+	{ErrInvalidWeight, "cannot be negative"},
+	{ErrInvalidDimensions, "cannot be negative"},
+	{ErrInvalidTag, "must have between 1 and 50 characters"},
+	{ErrTooManyTags, "cannot have more than 20 tags"},
+	{ErrSpecificationsTooDeep, "are nested too deeply"},
+	{ErrCategoryNotAllowed, "is not in the allowed list"},
+}
+
+// Add registra err no campo. Erros nil são ignorados e a primeira falha de
+// cada campo é mantida.
+func (v ValidationErrors) Add(field string, err error) {
+	if err == nil {
+		return
+	}
+	if _, exists := v[field]; !exists {
+		v[field] = err
+	}
+}
+
+// Err retorna nil quando não há falhas, para que um mapa vazio não seja
+// devolvido como erro.
+func (v ValidationErrors) Err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return v
+}
+
+func (v ValidationErrors) Error() string {
+	parts := make([]string, 0, len(v))
+	for _, field := range v.fields() {
+		parts = append(parts, field+": "+v[field].Error())
+	}
+	return strings.Join(parts, "; ")
+}
+
+func (v ValidationErrors) Unwrap() []error {
+	errs := make([]error, 0, len(v))
+	for _, field := range v.fields() {
+		errs = append(errs, v[field])
+	}
+	return errs
+}
+
+// Messages retorna a mensagem curta de cada campo, como {"name": "is required"}.
+func (v ValidationErrors) Messages() map[string]string {
+	messages := make(map[string]string, len(v))
+	for field, err := range v {
+		messages[field] = fieldMessage(err)
+	}
+	return messages
+}
+
+func (v ValidationErrors) fields() []string {
+	fields := make([]string, 0, len(v))
+	for field := range v {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func fieldMessage(err error) string {
+	for _, known := range fieldMessages {
+		if errors.Is(err, known.err) {
+			return known.message
+		}
+	}
+	return err.Error()
+}
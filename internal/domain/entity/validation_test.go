@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestProductValidate_CollectsAllFields(t *testing.T) {
+	p := &Product{Stock: -1, Price: -100}
+
+	err := p.Validate()
+
+	var invalid ValidationErrors
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+
+	want := map[string]string{
+		"name":             "is required",
+		"reference_number": "is required",
+		"category":         "is required",
+		"stock":            "cannot be negative",
+		"price":            "cannot be negative",
+	}
+	if got := invalid.Messages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Messages() = %v, want %v", got, want)
+	}
+	for _, target := range []error{ErrInvalidName, ErrInvalidReference, ErrInvalidCategory, ErrInvalidStock, ErrInvalidPrice} {
+		if !errors.Is(err, target) {
+			t.Errorf("Expected errors.Is to match %v", target)
+		}
+	}
+}
+
+func TestProductValidate_Valid(t *testing.T) {
+	p := &Product{Name: "Notebook", ReferenceNumber: "REF-001", Category: "Computers"}
+
+	if err := p.Validate(); err != nil {
+		t.Errorf("Expected nil error, got %v", err)
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	invalid := ValidationErrors{}
+	if invalid.Err() != nil {
+		t.Fatal("Expected empty ValidationErrors to be a nil error")
+	}
+
+	invalid.Add("tags", nil)
+	invalid.Add("category", ErrInvalidCategory)
+	invalid.Add("category", ErrCategoryNotAllowed)
+	invalid.Add("sku", errors.New("sku is malformed"))
+
+	if _, ok := invalid["tags"]; ok {
+		t.Error("Expected nil errors to be ignored")
+	}
+	if !errors.Is(invalid.Err(), ErrInvalidCategory) || errors.Is(invalid.Err(), ErrCategoryNotAllowed) {
+		t.Error("Expected the first error of a field to be kept")
+	}
+
+	want := map[string]string{"category": "is required", "sku": "sku is malformed"}
+	if got := invalid.Messages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Messages() = %v, want %v", got, want)
+	}
+	if got := invalid.Error(); got != "category: product category is required; sku: sku is malformed" {
+		t.Errorf("Error() = %q", got)
+	}
+}
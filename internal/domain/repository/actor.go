@@ -0,0 +1,19 @@
+package repository
+
+import "context"
+
+type actorKey struct{}
+
+// WithActor anota no contexto o sujeito (claim sub do token) responsável pela
+// alteração. As escritas do repositório de produtos gravam esse sujeito na
+// trilha de auditoria.
+func WithActor(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, actorKey{}, subject)
+}
+
+// ActorFromContext retorna o sujeito anotado por WithActor, ou "" quando a
+// alteração não tem autor conhecido.
+func ActorFromContext(ctx context.Context) string {
+	subject, _ := ctx.Value(actorKey{}).(string)
+	return subject
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+)
+
+// AuditRepository grava a trilha de compliance das alterações de produto. É
+// só de acréscimo: entradas gravadas nunca são alteradas nem removidas.
+type AuditRepository interface {
+	// Append grava o lote num único comando: ou todas as entradas entram, ou
+	// nenhuma.
+	Append(ctx context.Context, entries []*entity.AuditEntry) error
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
 )
@@ -10,26 +11,96 @@ import (
 var (
 	ErrCacheNotFound = errors.New("cache entry not found")
 	ErrCacheMiss     = errors.New("cache miss")
+	// ErrCacheUnavailable indica uma falha do Redis que a operação, em modo
+	// estrito, não tolera com fallback para o banco.
+	ErrCacheUnavailable = errors.New("cache unavailable")
+	// ErrCacheTransient marca uma falha do Redis que pode passar sozinha
+	// (timeout, conexão perdida, réplica carregando); repetir a escrita faz
+	// sentido. Erros sem a marca não melhoram com uma nova tentativa.
+	ErrCacheTransient = errors.New("transient cache failure")
+	// ErrCacheEntryTooLarge indica que o produto serializado passa do limite
+	// de tamanho do cache e não foi gravado.
+	ErrCacheEntryTooLarge = errors.New("cache entry exceeds the size limit")
+	// ErrCacheOutOfMemory indica que o Redis recusou a escrita por ter
+	// atingido maxmemory, ou que as gravações estão suspensas depois disso.
+	// Leituras seguem funcionando.
+	ErrCacheOutOfMemory = errors.New("cache out of memory")
 )
 
+// CacheEntry é um produto em cache junto com o instante em que foi gravado.
+// CachedAt é zero para entradas gravadas antes do registro de frescor existir.
+type CacheEntry struct {
+	Product  *entity.Product
+	CachedAt time.Time
+}
+
+// Age retorna há quanto tempo a entrada foi gravada no cache.
+func (e *CacheEntry) Age() time.Duration {
+	return time.Since(e.CachedAt)
+}
+
 type CacheRepository interface {
 	Get(ctx context.Context, key string) (*entity.Product, error)
 
+	GetEntry(ctx context.Context, key string) (*CacheEntry, error)
+
+	// Set grava a entrada com a expiração padrão do repositório. Com limite de
+	// tamanho, um produto serializado maior que ele não é gravado (a entrada
+	// anterior é removida) e o erro é ErrCacheEntryTooLarge.
 	Set(ctx context.Context, key string, product *entity.Product) error
 
+	// SetWithTTL grava a entrada com uma expiração própria; zero não expira.
+	SetWithTTL(ctx context.Context, key string, product *entity.Product, ttl time.Duration) error
+
 	Delete(ctx context.Context, key string) error
 
+	// PatchStock altera o estoque, a versão e o updated_at da entrada em
+	// cache, sem regravá-la inteira. Se a entrada não puder ser alterada no
+	// lugar, é removida.
+	PatchStock(ctx context.Context, key string, stock int, revision Revision) error
+
+	// AddToSet acrescenta um membro ao índice. Com expiração de índices, só
+	// acrescenta a um set existente: um índice expirado volta inteiro pelo
+	// AddAllToSet, nunca com só o membro de uma escrita.
 	AddToSet(ctx context.Context, setKey, productID string) error
 
+	// AddAllToSet grava os membros de uma vez, criando o set se preciso e
+	// renovando sua expiração. É usado para reconstruir um índice completo.
+	AddAllToSet(ctx context.Context, setKey string, productIDs []string) error
+
 	RemoveFromSet(ctx context.Context, setKey, productID string) error
 
+	// GetSet retorna os membros do índice; com expiração de índices, a leitura
+	// de um set não vazio renova a expiração.
 	GetSet(ctx context.Context, setKey string) ([]string, error)
 
+	AddToSortedSet(ctx context.Context, setKey, productID string, score float64) error
+
+	RemoveFromSortedSet(ctx context.Context, setKey, productID string) error
+
+	// GetSortedSetRange retorna os membros entre start e stop (inclusivos),
+	// ordenados do maior score para o menor.
+	GetSortedSetRange(ctx context.Context, setKey string, start, stop int64) ([]string, error)
+
+	// SampleSortedSet retorna até count membros distintos escolhidos ao acaso.
+	SampleSortedSet(ctx context.Context, setKey string, count int) ([]string, error)
+
 	GetMultiple(ctx context.Context, keys []string) ([]*entity.Product, error)
 
 	Exists(ctx context.Context, key string) (bool, error)
 
 	DeleteSet(ctx context.Context, setKey string) error
 
+	// InvalidateProduct remove a entrada do produto e tira o ID dos sets e
+	// sorted sets informados de uma vez. Retorna quantas chaves foram de fato
+	// alteradas.
+	InvalidateProduct(ctx context.Context, productKey, productID string, setKeys, sortedSetKeys []string) (int, error)
+
+	// FlushCache apaga as chaves de cache (produtos, índices, contagem e
+	// respostas guardadas) e retorna quantas foram removidas. Chaves que
+	// dividem o banco mas não são cache, como idempotência, dead letters e
+	// rate limiting, são preservadas.
+	FlushCache(ctx context.Context) (int, error)
+
 	HealthCheck(ctx context.Context) error
 }
@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
 )
@@ -12,22 +14,236 @@ var (
 	ErrProductAlreadyExists = errors.New("product already exists")
 	ErrDatabaseConnection   = errors.New("database connection error")
 	ErrVersionConflict      = entity.ErrVersionConflict
+	// ErrSerializationFailure indica que a transação foi abortada pelo banco por
+	// conflito de serialização (SQLSTATE 40001) e pode ser repetida.
+	ErrSerializationFailure = errors.New("transaction serialization failure")
+	// ErrCircuitOpen indica que o circuit breaker do banco está aberto e a
+	// chamada foi recusada sem chegar ao banco.
+	ErrCircuitOpen = errors.New("database circuit breaker is open")
+	// ErrProductDeleted indica que a criação usaria o ID de um produto
+	// excluído logicamente. A criação não restaura: o produto volta só por
+	// Restore. Embrulha ErrProductAlreadyExists.
+	ErrProductDeleted = fmt.Errorf("%w: product is deleted", ErrProductAlreadyExists)
 )
 
+// Revision é a versão e o instante gravados por uma escrita parcial de
+// estoque, para que o cache acompanhe a versão do banco sem reler o produto.
+type Revision struct {
+	Version   int
+	UpdatedAt time.Time
+}
+
+// NameSearchOrder define a ordenação da busca por nome.
+//
+// NameOrderRelevance classifica os resultados em três faixas, nesta ordem:
+// nome igual ao termo, nome que começa com o termo e nome que apenas contém o
+// termo (comparação sem diferenciar maiúsculas). Dentro de cada faixa a ordem
+// é alfabética.
+type NameSearchOrder string
+
+const (
+	NameOrderAlphabetical NameSearchOrder = "name"
+	NameOrderRelevance    NameSearchOrder = "relevance"
+)
+
+// SortField é o campo de ordenação das listagens e buscas. SortDefault mantém
+// a ordem padrão de cada consulta.
+type SortField string
+
+const (
+	SortDefault     SortField = ""
+	SortByName      SortField = "name"
+	SortByCreatedAt SortField = "created_at"
+	SortByUpdatedAt SortField = "updated_at"
+	SortByStock     SortField = "stock"
+)
+
+// SortDirection é o sentido da ordenação; vazio usa o padrão da consulta.
+type SortDirection string
+
+const (
+	SortAsc  SortDirection = "asc"
+	SortDesc SortDirection = "desc"
+)
+
+// SortOptions ordena FindAll, FindByCategory e FindByName. O zero value mantém
+// a ordem padrão de cada consulta. Na busca por relevância, a ordenação vale
+// dentro de cada faixa.
+type SortOptions struct {
+	Field     SortField
+	Direction SortDirection
+}
+
+// WithDefaults preenche o que não foi informado. Sem campo, usa o campo e o
+// sentido padrão da consulta (respeitando um sentido explícito); com campo e
+// sem sentido, a ordem é crescente.
+func (s SortOptions) WithDefaults(field SortField, direction SortDirection) SortOptions {
+	if s.Field == SortDefault {
+		s.Field = field
+		if s.Direction == "" {
+			s.Direction = direction
+		}
+	}
+	if s.Direction == "" {
+		s.Direction = SortAsc
+	}
+	return s
+}
+
+// ListFilter restringe a listagem e as buscas por nome e categoria, com as
+// respectivas contagens. O zero value não filtra. InStock true traz só os
+// produtos com estoque (stock > 0) e false só os esgotados; MinPrice e
+// MaxPrice, em centavos, delimitam o preço com os extremos incluídos.
+// MinWeight e MaxWeight (gramas) e MinVolume e MaxVolume (cm³) servem às
+// faixas de frete: produtos sem peso, ou sem alguma das três medidas, ficam
+// fora dessas faixas em vez de contarem como zero.
+type ListFilter struct {
+	InStock   *bool
+	MinPrice  *int64
+	MaxPrice  *int64
+	MinWeight *int64
+	MaxWeight *int64
+	MinVolume *int64
+	MaxVolume *int64
+}
+
+// Matches aplica o filtro a um produto já carregado, como na listagem servida
+// pelo cache, com o mesmo critério da consulta ao banco.
+func (f ListFilter) Matches(product *entity.Product) bool {
+	if f.InStock != nil && (product.Stock > 0) != *f.InStock {
+		return false
+	}
+	if f.MinPrice != nil && product.Price < *f.MinPrice {
+		return false
+	}
+	if f.MaxPrice != nil && product.Price > *f.MaxPrice {
+		return false
+	}
+	if !inRange(int64(product.WeightGrams), f.MinWeight, f.MaxWeight, 1) {
+		return false
+	}
+	if !inRange(volumeMM3(product.Dimensions), f.MinVolume, f.MaxVolume, mm3PerCM3) {
+		return false
+	}
+	return true
+}
+
+// mm3PerCM3 converte os limites de volume (cm³) para a unidade das medidas.
+const mm3PerCM3 = 1000
+
+// inRange confere value contra a faixa, com os limites multiplicados por
+// scale. Sem limites, tudo passa; com algum, value zero (não informado) fica
+// de fora.
+func inRange(value int64, low, high *int64, scale int64) bool {
+	if low == nil && high == nil {
+		return true
+	}
+	if value <= 0 {
+		return false
+	}
+	if low != nil && value < *low*scale {
+		return false
+	}
+	if high != nil && value > *high*scale {
+		return false
+	}
+	return true
+}
+
+// volumeMM3 é o volume em mm³, ou zero se alguma medida não foi informada.
+func volumeMM3(d entity.Dimensions) int64 {
+	if d.LengthMM <= 0 || d.WidthMM <= 0 || d.HeightMM <= 0 {
+		return 0
+	}
+	return int64(d.LengthMM) * int64(d.WidthMM) * int64(d.HeightMM)
+}
+
+// IsZero indica que o filtro não restringe nada.
+func (f ListFilter) IsZero() bool {
+	return f.InStock == nil && f.MinPrice == nil && f.MaxPrice == nil &&
+		f.MinWeight == nil && f.MaxWeight == nil && f.MinVolume == nil && f.MaxVolume == nil
+}
+
 type ProductRepository interface {
 	Create(ctx context.Context, product *entity.Product) error
 
+	// CreateBatch grava vários produtos de uma vez. O primeiro retorno traz um
+	// erro por produto, na mesma ordem (nil quando gravado); o segundo indica
+	// uma falha do lote inteiro, em que nada foi gravado.
+	CreateBatch(ctx context.Context, products []*entity.Product) ([]error, error)
+
 	Update(ctx context.Context, product *entity.Product, expectedVersion int) error
 
+	// UpdateStock grava o estoque e incrementa a versão, para que um PUT ou
+	// PATCH baseado na versão anterior não sobrescreva a mudança. Retorna a
+	// nova versão.
+	UpdateStock(ctx context.Context, id string, stock int) (Revision, error)
+
+	// AdjustStock soma delta ao estoque num único UPDATE, incrementando a
+	// versão, e retorna o estoque resultante com a nova versão. Se o
+	// resultado ficaria negativo, nada muda e o retorno é o estoque atual com
+	// entity.ErrInsufficientStock.
+	AdjustStock(ctx context.Context, id string, delta int) (int, Revision, error)
+
+	// AddTag e RemoveTag alteram uma única tag (já normalizada), incrementando
+	// a versão e updated_at, e retornam a nova versão. Retornam false, sem
+	// mudar nada, quando a lista já estava no estado pedido.
+	AddTag(ctx context.Context, id, tag string) (bool, Revision, error)
+	RemoveTag(ctx context.Context, id, tag string) (bool, Revision, error)
+
+	// Delete exclui logicamente: o produto some das leituras e das escritas,
+	// mas continua ocupando o ID e a referência até ser restaurado.
 	Delete(ctx context.Context, id string) error
 
+	// DeleteIfVersion exclui logicamente só se a versão atual for a
+	// informada; caso contrário retorna ErrVersionConflict.
+	DeleteIfVersion(ctx context.Context, id string, version int) error
+
+	// Restore desfaz a exclusão lógica. Restaurar um produto ativo não é erro;
+	// um ID desconhecido retorna ErrProductNotFound.
+	Restore(ctx context.Context, id string) error
+
+	// FindTombstone retorna o ID e o deleted_at de um produto excluído
+	// logicamente. IDs desconhecidos ou de produtos ativos retornam
+	// ErrProductNotFound.
+	FindTombstone(ctx context.Context, id string) (*entity.Tombstone, error)
+
 	FindByID(ctx context.Context, id string) (*entity.Product, error)
 
-	FindAll(ctx context.Context, limit, offset int) ([]*entity.Product, error)
+	// FindByIDs busca vários produtos numa consulta só. IDs desconhecidos ou
+	// excluídos ficam de fora, sem erro, e a ordem não é garantida.
+	FindByIDs(ctx context.Context, ids []string) ([]*entity.Product, error)
+
+	// FindAll ordena por padrão do mais novo para o mais antigo.
+	FindAll(ctx context.Context, filter ListFilter, sort SortOptions, limit, offset int) ([]*entity.Product, error)
+
+	// FindByCategory ordena por padrão do mais novo para o mais antigo.
+	FindByCategory(ctx context.Context, category string, filter ListFilter, sort SortOptions, limit, offset int) ([]*entity.Product, error)
+
+	// FindByName ordena por padrão pelo nome, em ordem crescente.
+	FindByName(ctx context.Context, name string, filter ListFilter, order NameSearchOrder, sort SortOptions, limit, offset int) ([]*entity.Product, error)
+
+	// FindBySKU compara o SKU sem diferenciar maiúsculas. SKUs não são únicos
+	// no esquema: retorna o cadastro mais antigo e o total de produtos com o
+	// SKU, ou ErrProductNotFound quando não há nenhum.
+	FindBySKU(ctx context.Context, sku string) (*entity.Product, int, error)
+
+	// FindByTag retorna os produtos com a tag (já normalizada), do mais novo
+	// para o mais antigo.
+	FindByTag(ctx context.Context, tag string, limit, offset int) ([]*entity.Product, error)
 
-	FindByCategory(ctx context.Context, category string, limit, offset int) ([]*entity.Product, error)
+	// FindModifiedBy retorna os produtos cuja última alteração registrada na
+	// trilha de auditoria foi feita pelo sujeito, da mais recente para a mais
+	// antiga.
+	FindModifiedBy(ctx context.Context, subject string, limit, offset int) ([]*entity.Product, error)
 
-	FindByName(ctx context.Context, name string, limit, offset int) ([]*entity.Product, error)
+	// Count, CountByName, CountByCategory e CountByTag retornam o total de
+	// produtos que FindAll, FindByName, FindByCategory e FindByTag percorreriam
+	// sem paginação.
+	Count(ctx context.Context, filter ListFilter) (int, error)
+	CountByName(ctx context.Context, name string, filter ListFilter) (int, error)
+	CountByCategory(ctx context.Context, category string, filter ListFilter) (int, error)
+	CountByTag(ctx context.Context, tag string) (int, error)
 
 	Exists(ctx context.Context, id string) (bool, error)
 
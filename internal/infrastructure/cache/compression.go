@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Marcadores gravados no primeiro byte das entradas do CompressingSerializer.
+const (
+	markerRaw  byte = 0x00
+	markerGzip byte = 0x01
+)
+
+// DefaultCompressThreshold é o tamanho, em bytes, a partir do qual o payload
+// serializado é comprimido. Abaixo disso o gzip costuma ganhar pouco ou nada.
+const DefaultCompressThreshold = 1024
+
+var ErrCompressedPayload = errors.New("invalid compressed cache payload")
+
+// CompressingSerializer decora um Serializer comprimindo com gzip os payloads
+// com threshold bytes ou mais. Um byte marcador na frente indica se o resto
+// está comprimido, então entradas pequenas e grandes convivem. As entradas
+// gravadas sem o decorador não têm o marcador e precisam ser descartadas ao
+// ligá-lo.
+type CompressingSerializer struct {
+	inner     Serializer
+	threshold int
+}
+
+func NewCompressingSerializer(inner Serializer, threshold int) *CompressingSerializer {
+	if threshold <= 0 {
+		threshold = DefaultCompressThreshold
+	}
+	return &CompressingSerializer{inner: inner, threshold: threshold}
+}
+
+func (s *CompressingSerializer) Marshal(v interface{}) ([]byte, error) {
+	data, err := s.inner.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) >= s.threshold {
+		var buf bytes.Buffer
+		buf.WriteByte(markerGzip)
+		gz := gzip.NewWriter(&buf)
+		if _, err := gz.Write(data); err != nil {
+			return nil, err
+		}
+		if err := gz.Close(); err != nil {
+			return nil, err
+		}
+		// Payloads que não encolhem, como specifications cifradas, ficam crus.
+		if buf.Len() < len(data)+1 {
+			return buf.Bytes(), nil
+		}
+	}
+
+	raw := make([]byte, 0, len(data)+1)
+	raw = append(raw, markerRaw)
+	return append(raw, data...), nil
+}
+
+func (s *CompressingSerializer) Unmarshal(data []byte, v interface{}) error {
+	if len(data) == 0 {
+		return fmt.Errorf("%w: empty payload", ErrCompressedPayload)
+	}
+
+	switch data[0] {
+	case markerRaw:
+		return s.inner.Unmarshal(data[1:], v)
+	case markerGzip:
+		gz, err := gzip.NewReader(bytes.NewReader(data[1:]))
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrCompressedPayload, err)
+		}
+		defer gz.Close()
+
+		decompressed, err := io.ReadAll(gz)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrCompressedPayload, err)
+		}
+		return s.inner.Unmarshal(decompressed, v)
+	default:
+		return fmt.Errorf("%w: unknown marker 0x%02x", ErrCompressedPayload, data[0])
+	}
+}
+
+func (s *CompressingSerializer) Name() string {
+	return s.inner.Name() + "+gzip"
+}
@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+)
+
+// createLargeTestProduct estende o produto de teste com muitas imagens e
+// specifications, como os produtos que motivaram a compressão.
+func createLargeTestProduct() *entity.Product {
+	product := createTestProduct()
+	for i := 0; i < 40; i++ {
+		product.Images = append(product.Images, fmt.Sprintf("https://example.com/images/iphone15promax-gallery-%02d.jpg", i))
+		product.Specifications[fmt.Sprintf("feature_%02d", i)] = fmt.Sprintf("Recurso adicional número %d do iPhone 15 Pro Max", i)
+	}
+	return product
+}
+
+func TestCompressingSerializer_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		product *entity.Product
+		marker  byte
+	}{
+		{name: "small", product: createTestProduct(), marker: markerRaw},
+		{name: "large", product: createLargeTestProduct(), marker: markerGzip},
+	}
+
+	for _, tt := range tests {
+		for _, inner := range []Serializer{NewJSONSerializer(), NewMsgpackSerializer()} {
+			t.Run(tt.name+"/"+inner.Name(), func(t *testing.T) {
+				s := NewCompressingSerializer(inner, 2048)
+
+				plain, err := inner.Marshal(tt.product)
+				if err != nil {
+					t.Fatalf("marshal failed: %v", err)
+				}
+				data, err := s.Marshal(tt.product)
+				if err != nil {
+					t.Fatalf("marshal failed: %v", err)
+				}
+				if data[0] != tt.marker {
+					t.Fatalf("Expected marker 0x%02x, got 0x%02x", tt.marker, data[0])
+				}
+
+				reduction := float64(len(plain)-len(data)) / float64(len(plain)) * 100
+				t.Logf("%s %s: %d bytes -> %d bytes (%.2f%% saved)", inner.Name(), tt.name, len(plain), len(data), reduction)
+
+				var decoded entity.Product
+				if err := s.Unmarshal(data, &decoded); err != nil {
+					t.Fatalf("unmarshal failed: %v", err)
+				}
+				if decoded.ID != tt.product.ID || decoded.Price != tt.product.Price {
+					t.Errorf("scalar fields mismatch after round trip: got %+v", decoded)
+				}
+				if len(decoded.Images) != len(tt.product.Images) || len(decoded.Specifications) != len(tt.product.Specifications) {
+					t.Errorf("Expected %d images and %d specifications, got %d and %d",
+						len(tt.product.Images), len(tt.product.Specifications), len(decoded.Images), len(decoded.Specifications))
+				}
+			})
+		}
+	}
+}
+
+func TestCompressingSerializer_InvalidPayload(t *testing.T) {
+	s := NewCompressingSerializer(NewMsgpackSerializer(), 0)
+
+	for name, data := range map[string][]byte{
+		"empty":          nil,
+		"unknown marker": {0x7f, 0x01},
+		"corrupt gzip":   {markerGzip, 0x01, 0x02},
+	} {
+		var decoded entity.Product
+		if err := s.Unmarshal(data, &decoded); !errors.Is(err, ErrCompressedPayload) {
+			t.Errorf("%s: expected %v, got %v", name, ErrCompressedPayload, err)
+		}
+	}
+}
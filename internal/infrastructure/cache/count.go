@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ProductCountKey é a chave do total de produtos ativos.
+const ProductCountKey = "product_count"
+
+// RedisProductCountCache guarda o total de produtos em product_count com um
+// TTL curto, que limita a defasagem caso uma invalidação se perca.
+type RedisProductCountCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewRedisProductCountCache(client *redis.Client, ttl time.Duration) *RedisProductCountCache {
+	return &RedisProductCountCache{client: client, ttl: ttl}
+}
+
+func (c *RedisProductCountCache) Get(ctx context.Context) (int, bool, error) {
+	total, err := c.client.Get(ctx, ProductCountKey).Int()
+	if errors.Is(err, redis.Nil) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return total, true, nil
+}
+
+func (c *RedisProductCountCache) Set(ctx context.Context, total int) error {
+	return c.client.Set(ctx, ProductCountKey, total, c.ttl).Err()
+}
+
+func (c *RedisProductCountCache) Invalidate(ctx context.Context) error {
+	return c.client.Del(ctx, ProductCountKey).Err()
+}
@@ -0,0 +1,247 @@
+package cache
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+)
+
+// encryptedValuePrefix marca um valor de specification cifrado. O formato é
+// enc:v1:<key id>:<base64(nonce || ciphertext)>.
+const encryptedValuePrefix = "enc:v1:"
+
+// ErrSpecDecryption indica uma specification cifrada que não pôde ser lida:
+// chave desconhecida (removida da rotação) ou payload adulterado.
+var ErrSpecDecryption = errors.New("failed to decrypt specification")
+
+// EncryptionKey é uma chave AES (16, 24 ou 32 bytes) identificada por ID, que
+// vai gravado junto de cada valor cifrado.
+type EncryptionKey struct {
+	ID  string
+	Key []byte
+}
+
+// SpecCipher cifra com AES-GCM os valores das specifications listadas. A
+// primeira chave cifra; as demais só decifram, o que permite rotacionar sem
+// invalidar o cache de uma vez. O nome da specification entra como dado
+// associado, impedindo que um valor cifrado seja movido para outra chave.
+type SpecCipher struct {
+	fields   map[string]bool
+	activeID string
+	aeads    map[string]cipher.AEAD
+}
+
+func NewSpecCipher(fields []string, keys []EncryptionKey) (*SpecCipher, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one encryption key is required")
+	}
+
+	c := &SpecCipher{
+		fields:   make(map[string]bool, len(fields)),
+		activeID: keys[0].ID,
+		aeads:    make(map[string]cipher.AEAD, len(keys)),
+	}
+	for _, field := range fields {
+		if field = strings.TrimSpace(field); field != "" {
+			c.fields[field] = true
+		}
+	}
+
+	for _, key := range keys {
+		if key.ID == "" || strings.Contains(key.ID, ":") {
+			return nil, fmt.Errorf("invalid encryption key id %q", key.ID)
+		}
+		if _, exists := c.aeads[key.ID]; exists {
+			return nil, fmt.Errorf("duplicate encryption key id %q", key.ID)
+		}
+
+		block, err := aes.NewCipher(key.Key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key %q: %w", key.ID, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key %q: %w", key.ID, err)
+		}
+		c.aeads[key.ID] = aead
+	}
+
+	return c, nil
+}
+
+// EncryptProduct retorna uma cópia do produto com as specifications
+// designadas cifradas. O produto original não é alterado.
+func (c *SpecCipher) EncryptProduct(product *entity.Product) (*entity.Product, error) {
+	if product == nil || !c.hasDesignatedSpec(product.Specifications) {
+		return product, nil
+	}
+
+	specs := make(map[string]interface{}, len(product.Specifications))
+	for field, value := range product.Specifications {
+		if c.fields[field] {
+			encrypted, err := c.encrypt(field, value)
+			if err != nil {
+				return nil, err
+			}
+			value = encrypted
+		}
+		specs[field] = value
+	}
+
+	encrypted := *product
+	encrypted.Specifications = specs
+	return &encrypted, nil
+}
+
+// DecryptProduct decifra no lugar as specifications cifradas do produto.
+// Valores em claro, inclusive de chaves designadas gravados antes da
+// cifragem ser ligada, são mantidos como estão.
+func (c *SpecCipher) DecryptProduct(product *entity.Product) error {
+	if product == nil {
+		return nil
+	}
+
+	for field, value := range product.Specifications {
+		if !isEncryptedValue(value) {
+			continue
+		}
+		decrypted, err := c.decrypt(field, value.(string))
+		if err != nil {
+			return err
+		}
+		product.Specifications[field] = decrypted
+	}
+	return nil
+}
+
+func (c *SpecCipher) hasDesignatedSpec(specs map[string]interface{}) bool {
+	for field := range specs {
+		if c.fields[field] {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *SpecCipher) encrypt(field string, value interface{}) (string, error) {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode specification %q: %w", field, err)
+	}
+	return c.Seal(field, plaintext)
+}
+
+func (c *SpecCipher) decrypt(field, value string) (interface{}, error) {
+	plaintext, err := c.Open(field, value)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(plaintext, &decoded); err != nil {
+		return nil, fmt.Errorf("%w %q: %v", ErrSpecDecryption, field, err)
+	}
+	return decoded, nil
+}
+
+// Seal cifra plaintext com a chave ativa no formato enc:v1 das
+// specifications, com label como dado associado. Serve para outros valores
+// gravados no Redis que carregam specifications, como as respostas
+// idempotentes.
+func (c *SpecCipher) Seal(label string, plaintext []byte) (string, error) {
+	aead := c.aeads[c.activeID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := aead.Seal(nonce, nonce, plaintext, []byte(label))
+	return encryptedValuePrefix + c.activeID + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decifra um valor gerado por Seal com o mesmo label, com qualquer uma
+// das chaves configuradas.
+func (c *SpecCipher) Open(label, value string) ([]byte, error) {
+	keyID, payload, _ := strings.Cut(strings.TrimPrefix(value, encryptedValuePrefix), ":")
+
+	aead, ok := c.aeads[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w %q: unknown key %q", ErrSpecDecryption, label, keyID)
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(payload)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("%w %q: malformed payload", ErrSpecDecryption, label)
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(label))
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %v", ErrSpecDecryption, label, err)
+	}
+	return plaintext, nil
+}
+
+func isEncryptedValue(value interface{}) bool {
+	s, ok := value.(string)
+	return ok && strings.HasPrefix(s, encryptedValuePrefix)
+}
+
+// EncryptingSerializer decora um Serializer cifrando as specifications
+// designadas dos produtos (soltos ou no envelope) na gravação e decifrando
+// na leitura. Outros valores passam direto para o serializer interno.
+type EncryptingSerializer struct {
+	inner  Serializer
+	cipher *SpecCipher
+}
+
+func NewEncryptingSerializer(inner Serializer, cipher *SpecCipher) *EncryptingSerializer {
+	return &EncryptingSerializer{inner: inner, cipher: cipher}
+}
+
+func (s *EncryptingSerializer) Marshal(v interface{}) ([]byte, error) {
+	var err error
+	switch value := v.(type) {
+	case cacheEnvelope:
+		value.Product, err = s.cipher.EncryptProduct(value.Product)
+		v = value
+	case *cacheEnvelope:
+		envelope := *value
+		envelope.Product, err = s.cipher.EncryptProduct(value.Product)
+		v = &envelope
+	case *entity.Product:
+		v, err = s.cipher.EncryptProduct(value)
+	case entity.Product:
+		v, err = s.cipher.EncryptProduct(&value)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.inner.Marshal(v)
+}
+
+func (s *EncryptingSerializer) Unmarshal(data []byte, v interface{}) error {
+	if err := s.inner.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	switch value := v.(type) {
+	case *cacheEnvelope:
+		return s.cipher.DecryptProduct(value.Product)
+	case *entity.Product:
+		return s.cipher.DecryptProduct(value)
+	}
+	return nil
+}
+
+func (s *EncryptingSerializer) Name() string {
+	return s.inner.Name()
+}
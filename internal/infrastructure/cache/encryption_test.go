@@ -0,0 +1,148 @@
+package cache
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testEncryptionKey(id string, fill byte) EncryptionKey {
+	return EncryptionKey{ID: id, Key: bytes.Repeat([]byte{fill}, 32)}
+}
+
+func TestEncryptingSerializer_RoundTrip(t *testing.T) {
+	for _, inner := range []Serializer{NewMsgpackSerializer(), NewJSONSerializer()} {
+		t.Run(inner.Name(), func(t *testing.T) {
+			specCipher, err := NewSpecCipher([]string{"supplier_cost", "battery"}, []EncryptionKey{testEncryptionKey("k1", 1)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			serializer := NewEncryptingSerializer(inner, specCipher)
+			r := NewRedisRepositoryWithSerializer(nil, serializer)
+
+			product := createTestProduct()
+			product.Specifications["supplier_cost"] = "R$ 4.200,00"
+
+			data, err := serializer.Marshal(cacheEnvelope{Product: product, CachedAt: time.Now().UTC()})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if bytes.Contains(data, []byte("R$ 4.200,00")) || bytes.Contains(data, []byte("4422mAh")) {
+				t.Error("Expected designated specifications not to appear in the payload")
+			}
+			if !bytes.Contains(data, []byte("A17 Pro")) {
+				t.Error("Expected other specifications to stay in plain text")
+			}
+			if product.Specifications["supplier_cost"] != "R$ 4.200,00" {
+				t.Error("Expected the original product not to be modified")
+			}
+
+			entry, err := r.decodeEntry(data)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got := entry.Product.Specifications["supplier_cost"]; got != "R$ 4.200,00" {
+				t.Errorf("Expected decrypted supplier_cost, got %v", got)
+			}
+			if got := entry.Product.Specifications["battery"]; got != "4422mAh" {
+				t.Errorf("Expected decrypted battery, got %v", got)
+			}
+		})
+	}
+}
+
+func TestEncryptingSerializer_KeyRotation(t *testing.T) {
+	oldCipher, err := NewSpecCipher([]string{"supplier_cost"}, []EncryptionKey{testEncryptionKey("k1", 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	product := createTestProduct()
+	product.Specifications["supplier_cost"] = 4200.5
+
+	data, err := NewEncryptingSerializer(NewMsgpackSerializer(), oldCipher).Marshal(cacheEnvelope{Product: product})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("old key still decrypts", func(t *testing.T) {
+		rotated, err := NewSpecCipher([]string{"supplier_cost"}, []EncryptionKey{testEncryptionKey("k2", 2), testEncryptionKey("k1", 1)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := NewRedisRepositoryWithSerializer(nil, NewEncryptingSerializer(NewMsgpackSerializer(), rotated))
+
+		entry, err := r.decodeEntry(data)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if got := entry.Product.Specifications["supplier_cost"]; got != 4200.5 {
+			t.Errorf("Expected supplier_cost 4200.5, got %v", got)
+		}
+
+		reencoded, err := rotated.EncryptProduct(entry.Product)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value, _ := reencoded.Specifications["supplier_cost"].(string); !strings.HasPrefix(value, encryptedValuePrefix+"k2:") {
+			t.Errorf("Expected new writes to use the active key, got %q", value)
+		}
+	})
+
+	t.Run("removed key fails", func(t *testing.T) {
+		retired, err := NewSpecCipher([]string{"supplier_cost"}, []EncryptionKey{testEncryptionKey("k2", 2)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := NewRedisRepositoryWithSerializer(nil, NewEncryptingSerializer(NewMsgpackSerializer(), retired))
+
+		if _, err := r.decodeEntry(data); !errors.Is(err, ErrSpecDecryption) {
+			t.Errorf("Expected ErrSpecDecryption, got %v", err)
+		}
+	})
+}
+
+func TestEncryptingSerializer_PlainDesignatedValue(t *testing.T) {
+	specCipher, err := NewSpecCipher([]string{"supplier_cost"}, []EncryptionKey{testEncryptionKey("k1", 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	product := createTestProduct()
+	product.Specifications["supplier_cost"] = "100"
+
+	data, err := NewMsgpackSerializer().Marshal(cacheEnvelope{Product: product})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewRedisRepositoryWithSerializer(nil, NewEncryptingSerializer(NewMsgpackSerializer(), specCipher))
+	entry, err := r.decodeEntry(data)
+	if err != nil {
+		t.Fatalf("Expected entries written before encryption to decode, got %v", err)
+	}
+	if got := entry.Product.Specifications["supplier_cost"]; got != "100" {
+		t.Errorf("Expected plain supplier_cost, got %v", got)
+	}
+}
+
+func TestNewSpecCipher_InvalidKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []EncryptionKey
+	}{
+		{"no keys", nil},
+		{"short key", []EncryptionKey{{ID: "k1", Key: []byte("short")}}},
+		{"empty id", []EncryptionKey{testEncryptionKey("", 1)}},
+		{"duplicate id", []EncryptionKey{testEncryptionKey("k1", 1), testEncryptionKey("k1", 2)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSpecCipher([]string{"supplier_cost"}, tt.keys); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/redis/go-redis/v9"
+)
+
+// idempotencyPending marca uma chave reservada por uma requisição em curso.
+const idempotencyPending = "pending"
+
+// DefaultIdempotencyLockTTL é quanto uma reserva sem resposta segura a chave.
+// Passado esse tempo (requisição que caiu com a instância), a chave volta a
+// ficar livre.
+const DefaultIdempotencyLockTTL = time.Minute
+
+// idempotencyCipherLabel é o dado associado das respostas cifradas.
+const idempotencyCipherLabel = "idempotency"
+
+// RedisIdempotencyStore guarda as respostas em idempotency:<key> com TTL. A
+// reserva é um SET NX com o marcador pending, então só uma réplica da API
+// processa cada chave.
+type RedisIdempotencyStore struct {
+	client  *redis.Client
+	ttl     time.Duration
+	lockTTL time.Duration
+	cipher  *SpecCipher
+}
+
+func NewRedisIdempotencyStore(client *redis.Client, ttl time.Duration) *RedisIdempotencyStore {
+	return &RedisIdempotencyStore{client: client, ttl: ttl, lockTTL: DefaultIdempotencyLockTTL}
+}
+
+// WithCipher cifra as respostas gravadas inteiras. O corpo de uma criação traz
+// as specifications em claro, então, com REDIS_ENCRYPTED_SPECS, ele não pode
+// ir para o Redis como está.
+func (s *RedisIdempotencyStore) WithCipher(cipher *SpecCipher) *RedisIdempotencyStore {
+	s.cipher = cipher
+	return s
+}
+
+func (s *RedisIdempotencyStore) Begin(ctx context.Context, key string) (*port.IdempotentResponse, error) {
+	redisKey := idempotencyKey(key)
+
+	reserved, err := s.client.SetNX(ctx, redisKey, idempotencyPending, s.lockTTL).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	if reserved {
+		return nil, nil
+	}
+
+	data, err := s.client.Get(ctx, redisKey).Bytes()
+	if errors.Is(err, redis.Nil) {
+		// A reserva expirou entre o SET NX e o GET; trata como em curso e o
+		// cliente tenta de novo.
+		return nil, port.ErrRequestInProgress
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read idempotency key: %w", err)
+	}
+	return s.decode(data)
+}
+
+func (s *RedisIdempotencyStore) Complete(ctx context.Context, key string, response port.IdempotentResponse) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	if s.cipher != nil {
+		sealed, err := s.cipher.Seal(idempotencyCipherLabel, data)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt idempotent response: %w", err)
+		}
+		data = []byte(sealed)
+	}
+	return s.client.Set(ctx, idempotencyKey(key), data, s.ttl).Err()
+}
+
+func (s *RedisIdempotencyStore) Release(ctx context.Context, key string) error {
+	return s.client.Del(ctx, idempotencyKey(key)).Err()
+}
+
+func idempotencyKey(key string) string {
+	return "idempotency:" + key
+}
+
+// decode decifra a resposta gravada quando há cipher. Uma resposta em claro,
+// gravada antes da cifragem ser ligada, é lida como está.
+func (s *RedisIdempotencyStore) decode(data []byte) (*port.IdempotentResponse, error) {
+	if s.cipher != nil && isEncryptedValue(string(data)) {
+		plaintext, err := s.cipher.Open(idempotencyCipherLabel, string(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt idempotent response: %w", err)
+		}
+		data = plaintext
+	}
+	return decodeIdempotentResponse(data)
+}
+
+func decodeIdempotentResponse(data []byte) (*port.IdempotentResponse, error) {
+	if string(data) == idempotencyPending {
+		return nil, port.ErrRequestInProgress
+	}
+
+	var response port.IdempotentResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode idempotent response: %w", err)
+	}
+	return &response, nil
+}
@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestDecodeIdempotentResponse(t *testing.T) {
+	if _, err := decodeIdempotentResponse([]byte(idempotencyPending)); !errors.Is(err, port.ErrRequestInProgress) {
+		t.Errorf("Expected %v for a pending key, got %v", port.ErrRequestInProgress, err)
+	}
+
+	stored := port.IdempotentResponse{Status: 201, ContentType: "application/json", Body: []byte(`{"id":"A"}`)}
+	data, err := json.Marshal(stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response, err := decodeIdempotentResponse(data)
+	if err != nil {
+		t.Fatalf("Expected stored response, got %v", err)
+	}
+	if response.Status != stored.Status || response.ContentType != stored.ContentType || string(response.Body) != string(stored.Body) {
+		t.Errorf("Expected %+v, got %+v", stored, response)
+	}
+}
+
+func TestRedisIdempotencyStore_WithCipher(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	specCipher, err := NewSpecCipher([]string{"supplier_cost"}, []EncryptionKey{testEncryptionKey("k1", 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := NewRedisIdempotencyStore(client, time.Hour).WithCipher(specCipher)
+	ctx := context.Background()
+
+	if stored, err := store.Begin(ctx, "user:key"); stored != nil || err != nil {
+		t.Fatalf("Expected the key to be reserved, got %v, %v", stored, err)
+	}
+	response := port.IdempotentResponse{
+		Status:      201,
+		ContentType: "application/json",
+		Location:    "/api/v1/products/A",
+		Body:        []byte(`{"specifications":{"supplier_cost":"R$ 4.200,00"}}`),
+		RequestHash: "abc",
+	}
+	if err := store.Complete(ctx, "user:key", response); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	raw, err := server.Get(idempotencyKey("user:key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(raw, encryptedValuePrefix) || strings.Contains(raw, "4.200") {
+		t.Errorf("Expected the stored response to be encrypted, got %q", raw)
+	}
+
+	stored, err := store.Begin(ctx, "user:key")
+	if err != nil {
+		t.Fatalf("Expected the stored response, got %v", err)
+	}
+	if stored.Location != response.Location || stored.RequestHash != response.RequestHash || string(stored.Body) != string(response.Body) {
+		t.Errorf("Expected %+v, got %+v", response, stored)
+	}
+}
@@ -22,6 +22,16 @@ func (g *RedisCacheKeyGenerator) CategoryKey(category string) string {
 	return "product_by_category_" + normalizedCategory
 }
 
+func (g *RedisCacheKeyGenerator) TagKey(tag string) string {
+	normalizedTag := strings.ToLower(strings.TrimSpace(tag))
+	return "product_by_tag_" + normalizedTag
+}
+
+func (g *RedisCacheKeyGenerator) SKUKey(sku string) string {
+	normalizedSKU := strings.ToLower(strings.TrimSpace(sku))
+	return "product_by_sku_" + normalizedSKU
+}
+
 func (g *RedisCacheKeyGenerator) AllProductsKey() string {
 	return "all_products"
 }
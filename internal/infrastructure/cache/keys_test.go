@@ -167,3 +167,19 @@ func TestRedisCacheKeyGenerator_KeyConsistency(t *testing.T) {
 		t.Error("CategoryKey should produce consistent keys regardless of case")
 	}
 }
+
+func TestRedisCacheKeyGenerator_TagKey(t *testing.T) {
+	g := NewRedisCacheKeyGenerator()
+
+	if got := g.TagKey("  Black Friday "); got != "product_by_tag_black friday" {
+		t.Errorf("TagKey() = %s, want product_by_tag_black friday", got)
+	}
+}
+
+func TestRedisCacheKeyGenerator_SKUKey(t *testing.T) {
+	g := NewRedisCacheKeyGenerator()
+
+	if got := g.SKUKey(" Sku-ABC-01 "); got != "product_by_sku_sku-abc-01" {
+		t.Errorf("SKUKey() = %s, want product_by_sku_sku-abc-01", got)
+	}
+}
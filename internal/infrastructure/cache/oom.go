@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+	"github.com/redis/go-redis/v9"
+)
+
+// oomEvictScanCount é o COUNT de cada SCAN da evicção: uma página por padrão
+// a cada OOM, para que a limpeza não segure a escrita que a disparou.
+const oomEvictScanCount = 500
+
+// OOMRecorder recebe as escritas recusadas pelo Redis por maxmemory.
+type OOMRecorder interface {
+	ObserveCacheOOM(operation string)
+}
+
+// OOMPolicy define a reação a uma escrita recusada por maxmemory. Pause
+// suspende as gravações de produto por esse tempo (zero não suspende);
+// EvictPatterns lista padrões de chave (glob do SCAN) de baixa prioridade
+// apagados com UNLINK a cada novo OOM.
+type OOMPolicy struct {
+	Pause         time.Duration
+	EvictPatterns []string
+	Recorder      OOMRecorder
+}
+
+// oomState guarda a política e até quando as gravações estão suspensas, em
+// nanossegundos Unix.
+type oomState struct {
+	policy      OOMPolicy
+	pausedUntil atomic.Int64
+}
+
+// isOOM reconhece a resposta do Redis a um comando de escrita acima de
+// maxmemory com maxmemory-policy noeviction (ou sem chaves a despejar).
+func isOOM(err error) bool {
+	return err != nil && redis.HasErrorPrefix(err, "OOM")
+}
+
+// WithOOMPolicy ativa a política de OOM nas escritas do repositório.
+func (r *RedisRepository) WithOOMPolicy(policy OOMPolicy) *RedisRepository {
+	r.oom = &oomState{policy: policy}
+	return r
+}
+
+// writesPaused indica se as gravações de produto estão suspensas por um OOM
+// recente. Leituras, remoções e índices não passam por essa checagem.
+func (r *RedisRepository) writesPaused() bool {
+	return r.oom != nil && time.Now().UnixNano() < r.oom.pausedUntil.Load()
+}
+
+// handleWriteError marca a falha de uma escrita: OOM recebe
+// repository.ErrCacheOutOfMemory, conta na métrica, suspende as gravações e
+// dispara a evicção; as demais seguem para markTransient.
+func (r *RedisRepository) handleWriteError(ctx context.Context, operation string, err error) error {
+	if !isOOM(err) {
+		return markTransient(err)
+	}
+
+	if r.oom != nil {
+		if r.oom.policy.Recorder != nil {
+			r.oom.policy.Recorder.ObserveCacheOOM(operation)
+		}
+		if r.oom.policy.Pause > 0 {
+			r.oom.pausedUntil.Store(time.Now().Add(r.oom.policy.Pause).UnixNano())
+		}
+		r.evictLowPriority(ctx)
+	}
+	return fmt.Errorf("%w: %w", repository.ErrCacheOutOfMemory, err)
+}
+
+// evictLowPriority apaga uma página de chaves de cada padrão de baixa
+// prioridade. É best-effort: UNLINK é aceito mesmo acima de maxmemory, e uma
+// falha só adia a liberação para o próximo OOM.
+func (r *RedisRepository) evictLowPriority(ctx context.Context) {
+	for _, pattern := range r.oom.policy.EvictPatterns {
+		keys, _, err := r.client.Scan(ctx, 0, pattern, oomEvictScanCount).Result()
+		if err != nil || len(keys) == 0 {
+			continue
+		}
+		r.client.Unlink(ctx, keys...)
+	}
+}
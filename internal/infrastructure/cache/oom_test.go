@@ -0,0 +1,150 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+	"github.com/redis/go-redis/v9"
+)
+
+// oomHook recusa com OOM os comandos em reject, como um Redis acima de
+// maxmemory com noeviction, e devolve scanKeys aos SCAN.
+type oomHook struct {
+	reject   map[string]bool
+	scanKeys []string
+	commands []string
+}
+
+func (h *oomHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h *oomHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		name := cmd.Name()
+		h.commands = append(h.commands, name)
+		if h.reject[name] {
+			cmd.SetErr(replyError("OOM command not allowed when used memory > 'maxmemory'."))
+			return cmd.Err()
+		}
+		if scan, ok := cmd.(*redis.ScanCmd); ok {
+			scan.SetVal(h.scanKeys, 0)
+		}
+		return nil
+	}
+}
+
+func (h *oomHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+type countingOOMRecorder struct {
+	operations []string
+}
+
+func (r *countingOOMRecorder) ObserveCacheOOM(operation string) {
+	r.operations = append(r.operations, operation)
+}
+
+func newOOMRepository(policy OOMPolicy) (*RedisRepository, *oomHook) {
+	client := redis.NewClient(&redis.Options{Addr: "fake:6379", MaxRetries: -1})
+	hook := &oomHook{reject: map[string]bool{"set": true}}
+	client.AddHook(hook)
+	return NewRedisRepository(client).WithOOMPolicy(policy), hook
+}
+
+func TestRedisRepository_Set_OOM(t *testing.T) {
+	recorder := &countingOOMRecorder{}
+	repo, hook := newOOMRepository(OOMPolicy{Recorder: recorder})
+	product := &entity.Product{ID: "p1", Name: "Produto"}
+
+	err := repo.Set(context.Background(), "product_p1", product)
+	if !errors.Is(err, repository.ErrCacheOutOfMemory) {
+		t.Fatalf("Expected ErrCacheOutOfMemory, got %v", err)
+	}
+	if errors.Is(err, repository.ErrCacheTransient) {
+		t.Error("Expected OOM not to be marked transient")
+	}
+	if len(recorder.operations) != 1 || recorder.operations[0] != "set" {
+		t.Errorf("Expected one OOM recorded for set, got %v", recorder.operations)
+	}
+	if got := strings.Join(hook.commands, ","); got != "set,del" {
+		t.Errorf("Expected the stale entry to be deleted after the OOM, got %s", got)
+	}
+
+	// Sem pausa configurada, a próxima escrita volta a ser tentada.
+	hook.commands = nil
+	repo.Set(context.Background(), "product_p1", product)
+	if len(hook.commands) == 0 || hook.commands[0] != "set" {
+		t.Errorf("Expected the next write to reach Redis, got %v", hook.commands)
+	}
+}
+
+func TestRedisRepository_Set_OOMPause(t *testing.T) {
+	repo, hook := newOOMRepository(OOMPolicy{Pause: time.Minute})
+	product := &entity.Product{ID: "p1", Name: "Produto"}
+	ctx := context.Background()
+
+	repo.Set(ctx, "product_p1", product)
+
+	hook.commands = nil
+	hook.reject = nil
+	err := repo.Set(ctx, "product_p2", product)
+	if !errors.Is(err, repository.ErrCacheOutOfMemory) {
+		t.Fatalf("Expected paused writes to fail with ErrCacheOutOfMemory, got %v", err)
+	}
+	if got := strings.Join(hook.commands, ","); got != "del" {
+		t.Errorf("Expected only the previous entry to be deleted while paused, got %s", got)
+	}
+
+	// Índices e leituras não passam pela pausa.
+	hook.commands = nil
+	if err := repo.AddToSortedSet(ctx, "all_products", "p2", 1); err != nil {
+		t.Fatalf("Expected index writes to continue, got %v", err)
+	}
+	if _, err := repo.Get(ctx, "product_p1"); errors.Is(err, repository.ErrCacheOutOfMemory) {
+		t.Errorf("Expected reads to continue, got %v", err)
+	}
+	if got := strings.Join(hook.commands, ","); got != "zadd,get" {
+		t.Errorf("Expected zadd and get to reach Redis, got %s", got)
+	}
+
+	repo.oom.pausedUntil.Store(time.Now().Add(-time.Second).UnixNano())
+	hook.commands = nil
+	if err := repo.Set(ctx, "product_p2", product); err != nil {
+		t.Fatalf("Expected writes to resume after the pause, got %v", err)
+	}
+	if got := strings.Join(hook.commands, ","); got != "set" {
+		t.Errorf("Expected set after the pause, got %s", got)
+	}
+}
+
+func TestRedisRepository_OOMEviction(t *testing.T) {
+	repo, hook := newOOMRepository(OOMPolicy{EvictPatterns: []string{"product_by_name_*"}})
+	hook.reject = map[string]bool{"zadd": true}
+	hook.scanKeys = []string{"product_by_name_mouse", "product_by_name_teclado"}
+
+	err := repo.AddToSortedSet(context.Background(), "all_products", "p1", 1)
+	if !errors.Is(err, repository.ErrCacheOutOfMemory) {
+		t.Fatalf("Expected ErrCacheOutOfMemory, got %v", err)
+	}
+	if got := strings.Join(hook.commands, ","); got != "zadd,scan,unlink" {
+		t.Errorf("Expected low-priority keys to be scanned and unlinked, got %s", got)
+	}
+}
+
+func TestRedisRepository_OOMWithoutPolicy(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "fake:6379", MaxRetries: -1})
+	client.AddHook(&oomHook{reject: map[string]bool{"sadd": true}})
+	repo := NewRedisRepository(client)
+
+	err := repo.AddToSet(context.Background(), "product_by_name_mouse", "p1")
+	if !errors.Is(err, repository.ErrCacheOutOfMemory) {
+		t.Errorf("Expected OOM to be detected without a policy, got %v", err)
+	}
+}
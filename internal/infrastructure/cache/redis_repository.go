@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -18,8 +19,34 @@ var (
 type RedisRepository struct {
 	client     *redis.Client
 	serializer Serializer
+	replicas   *ReplicaPool
+
+	// TTL é a expiração das entradas gravadas por Set; zero não expira.
+	TTL time.Duration
+
+	// IndexTTL é a expiração dos sets de índice, renovada a cada leitura;
+	// zero não expira.
+	IndexTTL time.Duration
+
+	// MaxEntrySize é o tamanho máximo, em bytes, de um produto serializado;
+	// zero não limita. Acima dele, a entrada é gravada com LargeEntryTTL ou,
+	// se ele for zero, não é gravada.
+	MaxEntrySize  int
+	LargeEntryTTL time.Duration
+
+	oom *oomState
 }
 
+// addToExistingSetScript acrescenta ARGV[1] ao set KEYS[1] só se ele existir,
+// para que uma escrita não recrie um índice expirado com um único membro.
+// O SADD preserva a expiração do set.
+var addToExistingSetScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+return redis.call('SADD', KEYS[1], ARGV[1])
+`)
+
 func NewRedisRepository(client *redis.Client) *RedisRepository {
 	return &RedisRepository{
 		client:     client,
@@ -34,8 +61,60 @@ func NewRedisRepositoryWithSerializer(client *redis.Client, serializer Serialize
 	}
 }
 
+// cacheEnvelope é o formato gravado no Redis: o produto mais o instante da escrita.
+type cacheEnvelope struct {
+	Product  *entity.Product `json:"product" msgpack:"product"`
+	CachedAt time.Time       `json:"cached_at" msgpack:"cached_at"`
+}
+
+// WithReadReplicas direciona as leituras para as réplicas do pool; escritas
+// continuam no primário.
+func (r *RedisRepository) WithReadReplicas(replicas *ReplicaPool) *RedisRepository {
+	r.replicas = replicas
+	return r
+}
+
+// WithTTL define a expiração padrão das entradas de produto.
+func (r *RedisRepository) WithTTL(ttl time.Duration) *RedisRepository {
+	r.TTL = ttl
+	return r
+}
+
+// WithIndexTTL define a expiração dos sets de índice (nome, categoria e tag).
+func (r *RedisRepository) WithIndexTTL(ttl time.Duration) *RedisRepository {
+	r.IndexTTL = ttl
+	return r
+}
+
+// WithMaxEntrySize limita o tamanho das entradas de produto: as maiores que
+// maxBytes expiram em largeTTL ou, com largeTTL zero, ficam fora do cache.
+func (r *RedisRepository) WithMaxEntrySize(maxBytes int, largeTTL time.Duration) *RedisRepository {
+	r.MaxEntrySize = maxBytes
+	r.LargeEntryTTL = largeTTL
+	return r
+}
+
+// reader retorna o cliente usado para leituras: uma réplica saudável quando
+// configuradas, ou o primário.
+func (r *RedisRepository) reader() *redis.Client {
+	if r.replicas != nil {
+		if client := r.replicas.Next(); client != nil {
+			return client
+		}
+	}
+	return r.client
+}
+
 func (r *RedisRepository) Get(ctx context.Context, key string) (*entity.Product, error) {
-	data, err := r.client.Get(ctx, key).Bytes()
+	entry, err := r.GetEntry(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return entry.Product, nil
+}
+
+func (r *RedisRepository) GetEntry(ctx context.Context, key string) (*repository.CacheEntry, error) {
+	data, err := r.reader().Get(ctx, key).Bytes()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
 			return nil, ErrCacheNotFound
@@ -43,28 +122,79 @@ func (r *RedisRepository) Get(ctx context.Context, key string) (*entity.Product,
 		return nil, fmt.Errorf("failed to get from cache: %w", err)
 	}
 
-	var product entity.Product
-	if err := r.serializer.Unmarshal(data, &product); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal product: %w", err)
-	}
-
-	return &product, nil
+	return r.decodeEntry(data)
 }
 
 func (r *RedisRepository) Set(ctx context.Context, key string, product *entity.Product) error {
-	data, err := r.serializer.Marshal(product)
+	return r.SetWithTTL(ctx, key, product, r.TTL)
+}
+
+func (r *RedisRepository) SetWithTTL(ctx context.Context, key string, product *entity.Product, ttl time.Duration) error {
+	if r.writesPaused() {
+		// Como no limite de tamanho, a versão anterior sai do cache para não
+		// ser servida desatualizada enquanto as gravações estão suspensas.
+		if err := r.client.Del(ctx, key).Err(); err != nil {
+			return fmt.Errorf("failed to delete entry while writes are paused: %w", markTransient(err))
+		}
+		return fmt.Errorf("%w: writes paused", repository.ErrCacheOutOfMemory)
+	}
+
+	data, err := r.serializer.Marshal(cacheEnvelope{
+		Product:  product,
+		CachedAt: time.Now().UTC(),
+	})
 	if err != nil {
 		return fmt.Errorf("failed to marshal product: %w", err)
 	}
 
-	err = r.client.Set(ctx, key, data, 0).Err()
+	if r.MaxEntrySize > 0 && len(data) > r.MaxEntrySize {
+		if r.LargeEntryTTL <= 0 {
+			// A versão anterior pode ter cabido no limite; removê-la evita
+			// servir o produto desatualizado.
+			if err := r.client.Del(ctx, key).Err(); err != nil {
+				return fmt.Errorf("failed to delete oversized entry: %w", markTransient(err))
+			}
+			return fmt.Errorf("%w: %d bytes, limit %d", repository.ErrCacheEntryTooLarge, len(data), r.MaxEntrySize)
+		}
+		if ttl <= 0 || ttl > r.LargeEntryTTL {
+			ttl = r.LargeEntryTTL
+		}
+	}
+
+	err = r.client.Set(ctx, key, data, ttl).Err()
 	if err != nil {
-		return fmt.Errorf("failed to set cache: %w", err)
+		if isOOM(err) {
+			// DEL é aceito acima de maxmemory; sem ele, a versão anterior
+			// seguiria servida desatualizada.
+			r.client.Del(ctx, key)
+		}
+		return fmt.Errorf("failed to set cache: %w", r.handleWriteError(ctx, "set", err))
 	}
 
 	return nil
 }
 
+// decodeEntry lê o envelope e, se o payload for de um produto gravado sem
+// envelope (formato anterior), retorna o produto com CachedAt zerado. Uma
+// specification cifrada ilegível é erro, sem a tentativa do formato anterior.
+func (r *RedisRepository) decodeEntry(data []byte) (*repository.CacheEntry, error) {
+	var envelope cacheEnvelope
+	err := r.serializer.Unmarshal(data, &envelope)
+	if errors.Is(err, ErrSpecDecryption) {
+		return nil, fmt.Errorf("failed to unmarshal product: %w", err)
+	}
+	if err == nil && envelope.Product != nil && envelope.Product.ID != "" {
+		return &repository.CacheEntry{Product: envelope.Product, CachedAt: envelope.CachedAt}, nil
+	}
+
+	var product entity.Product
+	if err := r.serializer.Unmarshal(data, &product); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal product: %w", err)
+	}
+
+	return &repository.CacheEntry{Product: &product}, nil
+}
+
 func (r *RedisRepository) Delete(ctx context.Context, key string) error {
 	err := r.client.Del(ctx, key).Err()
 	if err != nil {
@@ -74,9 +204,37 @@ func (r *RedisRepository) Delete(ctx context.Context, key string) error {
 }
 
 func (r *RedisRepository) AddToSet(ctx context.Context, setKey, productID string) error {
-	err := r.client.SAdd(ctx, setKey, productID).Err()
+	var err error
+	if r.IndexTTL > 0 {
+		err = addToExistingSetScript.Run(ctx, r.client, []string{setKey}, productID).Err()
+	} else {
+		err = r.client.SAdd(ctx, setKey, productID).Err()
+	}
 	if err != nil {
-		return fmt.Errorf("failed to add to set: %w", err)
+		return fmt.Errorf("failed to add to set: %w", r.handleWriteError(ctx, "add_to_set", err))
+	}
+	return nil
+}
+
+func (r *RedisRepository) AddAllToSet(ctx context.Context, setKey string, productIDs []string) error {
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	members := make([]interface{}, len(productIDs))
+	for i, id := range productIDs {
+		members[i] = id
+	}
+
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SAdd(ctx, setKey, members...)
+		if r.IndexTTL > 0 {
+			pipe.Expire(ctx, setKey, r.IndexTTL)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add to set: %w", r.handleWriteError(ctx, "add_all_to_set", err))
 	}
 	return nil
 }
@@ -90,13 +248,58 @@ func (r *RedisRepository) RemoveFromSet(ctx context.Context, setKey, productID s
 }
 
 func (r *RedisRepository) GetSet(ctx context.Context, setKey string) ([]string, error) {
-	members, err := r.client.SMembers(ctx, setKey).Result()
+	members, err := r.reader().SMembers(ctx, setKey).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
 			return []string{}, nil
 		}
 		return nil, fmt.Errorf("failed to get set members: %w", err)
 	}
+
+	// A renovação vai ao primário mesmo quando a leitura veio de uma réplica.
+	// É best-effort: se falhar, o índice só expira antes.
+	if r.IndexTTL > 0 && len(members) > 0 {
+		r.client.Expire(ctx, setKey, r.IndexTTL)
+	}
+
+	return members, nil
+}
+
+func (r *RedisRepository) AddToSortedSet(ctx context.Context, setKey, productID string, score float64) error {
+	err := r.client.ZAdd(ctx, setKey, redis.Z{Score: score, Member: productID}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to add to sorted set: %w", r.handleWriteError(ctx, "add_to_sorted_set", err))
+	}
+	return nil
+}
+
+func (r *RedisRepository) RemoveFromSortedSet(ctx context.Context, setKey, productID string) error {
+	err := r.client.ZRem(ctx, setKey, productID).Err()
+	if err != nil {
+		return fmt.Errorf("failed to remove from sorted set: %w", err)
+	}
+	return nil
+}
+
+func (r *RedisRepository) GetSortedSetRange(ctx context.Context, setKey string, start, stop int64) ([]string, error) {
+	members, err := r.reader().ZRevRange(ctx, setKey, start, stop).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return []string{}, nil
+		}
+		return nil, fmt.Errorf("failed to get sorted set range: %w", err)
+	}
+	return members, nil
+}
+
+func (r *RedisRepository) SampleSortedSet(ctx context.Context, setKey string, count int) ([]string, error) {
+	members, err := r.reader().ZRandMember(ctx, setKey, count).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return []string{}, nil
+		}
+		return nil, fmt.Errorf("failed to sample sorted set: %w", err)
+	}
 	return members, nil
 }
 
@@ -105,7 +308,7 @@ func (r *RedisRepository) GetMultiple(ctx context.Context, keys []string) ([]*en
 		return []*entity.Product{}, nil
 	}
 
-	pipe := r.client.Pipeline()
+	pipe := r.reader().Pipeline()
 	cmds := make([]*redis.StringCmd, len(keys))
 
 	for i, key := range keys {
@@ -127,19 +330,19 @@ func (r *RedisRepository) GetMultiple(ctx context.Context, keys []string) ([]*en
 			return nil, fmt.Errorf("failed to get command result: %w", err)
 		}
 
-		var product entity.Product
-		if err := r.serializer.Unmarshal(data, &product); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal product: %w", err)
+		entry, err := r.decodeEntry(data)
+		if err != nil {
+			return nil, err
 		}
 
-		products = append(products, &product)
+		products = append(products, entry.Product)
 	}
 
 	return products, nil
 }
 
 func (r *RedisRepository) Exists(ctx context.Context, key string) (bool, error) {
-	count, err := r.client.Exists(ctx, key).Result()
+	count, err := r.reader().Exists(ctx, key).Result()
 	if err != nil {
 		return false, fmt.Errorf("failed to check existence: %w", err)
 	}
@@ -154,6 +357,32 @@ func (r *RedisRepository) DeleteSet(ctx context.Context, setKey string) error {
 	return nil
 }
 
+func (r *RedisRepository) InvalidateProduct(ctx context.Context, productKey, productID string, setKeys, sortedSetKeys []string) (int, error) {
+	cmds := make([]*redis.IntCmd, 0, 1+len(setKeys)+len(sortedSetKeys))
+
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		cmds = append(cmds, pipe.Del(ctx, productKey))
+		for _, setKey := range setKeys {
+			cmds = append(cmds, pipe.SRem(ctx, setKey, productID))
+		}
+		for _, setKey := range sortedSetKeys {
+			cmds = append(cmds, pipe.ZRem(ctx, setKey, productID))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to invalidate product: %w", err)
+	}
+
+	affected := 0
+	for _, cmd := range cmds {
+		if cmd.Val() > 0 {
+			affected++
+		}
+	}
+	return affected, nil
+}
+
 func (r *RedisRepository) HealthCheck(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
 	defer cancel()
@@ -169,6 +398,43 @@ func (r *RedisRepository) GetClient() *redis.Client {
 	return r.client
 }
 
-func (r *RedisRepository) FlushDB(ctx context.Context) error {
-	return r.client.FlushDB(ctx).Err()
+// cacheKeyPatterns casam as chaves de cache: produtos, índices, contagem e
+// respostas guardadas. Idempotência, dead letters do webhook e rate limiting
+// dividem o banco Redis, mas não são cache e ficam fora.
+var cacheKeyPatterns = []string{"product_*", "all_products", responseCacheKey("*")}
+
+// flushScanCount é o COUNT de cada SCAN do FlushCache.
+const flushScanCount = 500
+
+// FlushCache percorre as chaves de cache com SCAN e as remove com UNLINK, uma
+// página por vez. Retorna quantas foram de fato removidas.
+func (r *RedisRepository) FlushCache(ctx context.Context) (int, error) {
+	removed := 0
+	for _, pattern := range cacheKeyPatterns {
+		iter := r.client.Scan(ctx, 0, pattern, flushScanCount).Iterator()
+		batch := make([]string, 0, flushScanCount)
+		for iter.Next(ctx) {
+			batch = append(batch, iter.Val())
+			if len(batch) < flushScanCount {
+				continue
+			}
+			n, err := r.client.Unlink(ctx, batch...).Result()
+			if err != nil {
+				return removed, fmt.Errorf("failed to flush cache: %w", err)
+			}
+			removed += int(n)
+			batch = batch[:0]
+		}
+		if err := iter.Err(); err != nil {
+			return removed, fmt.Errorf("failed to flush cache: %w", err)
+		}
+		if len(batch) > 0 {
+			n, err := r.client.Unlink(ctx, batch...).Result()
+			if err != nil {
+				return removed, fmt.Errorf("failed to flush cache: %w", err)
+			}
+			removed += int(n)
+		}
+	}
+	return removed, nil
 }
@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRedisRepository_InvalidateProduct(t *testing.T) {
+	repo, hook := newRecordingRepository()
+	hook.count = 1
+
+	affected, err := repo.InvalidateProduct(context.Background(), "product_p1", "p1",
+		[]string{"product_by_name_phone", "product_by_category_phones"},
+		[]string{"all_products"},
+	)
+	if err != nil {
+		t.Fatalf("InvalidateProduct() error = %v", err)
+	}
+
+	if got := strings.Join(hook.commandNames(), " "); got != "del srem srem zrem" {
+		t.Errorf("commands = %q, want del srem srem zrem", got)
+	}
+	if affected != 4 {
+		t.Errorf("affected = %d, want 4", affected)
+	}
+}
+
+func TestRedisRepository_InvalidateProduct_CountsOnlyChangedKeys(t *testing.T) {
+	repo, _ := newRecordingRepository()
+
+	affected, err := repo.InvalidateProduct(context.Background(), "product_p1", "p1", nil, []string{"all_products"})
+	if err != nil {
+		t.Fatalf("InvalidateProduct() error = %v", err)
+	}
+	if affected != 0 {
+		t.Errorf("affected = %d, want 0 when nothing was removed", affected)
+	}
+}
+
+func TestRedisRepository_FlushCache(t *testing.T) {
+	repo, server := newMiniredisRepository(t, NewMsgpackSerializer())
+
+	cacheKeys := []string{"product_p1", "product_by_name_phone", "product_count", "all_products", "response_cache:abc"}
+	kept := []string{"idempotency:k1", "webhook_dead_letter", "ratelimit:ip:10.0.0.1"}
+	for _, key := range append(append([]string{}, cacheKeys...), kept...) {
+		server.Set(key, "x")
+	}
+
+	affected, err := repo.FlushCache(context.Background())
+	if err != nil {
+		t.Fatalf("FlushCache() error = %v", err)
+	}
+	if affected != len(cacheKeys) {
+		t.Errorf("affected = %d, want %d", affected, len(cacheKeys))
+	}
+	for _, key := range cacheKeys {
+		if server.Exists(key) {
+			t.Errorf("Expected %s to be flushed", key)
+		}
+	}
+	for _, key := range kept {
+		if !server.Exists(key) {
+			t.Errorf("Expected %s to survive the flush", key)
+		}
+	}
+}
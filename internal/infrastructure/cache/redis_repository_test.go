@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRedisRepository_DecodeEntry_Envelope(t *testing.T) {
+	for _, serializer := range []Serializer{NewMsgpackSerializer(), NewJSONSerializer()} {
+		t.Run(serializer.Name(), func(t *testing.T) {
+			r := NewRedisRepositoryWithSerializer(nil, serializer)
+			product := createTestProduct()
+			cachedAt := time.Now().UTC().Add(-time.Minute).Truncate(time.Millisecond)
+
+			data, err := serializer.Marshal(cacheEnvelope{Product: product, CachedAt: cachedAt})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			entry, err := r.decodeEntry(data)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if entry.Product.ID != product.ID {
+				t.Errorf("Expected product %s, got %s", product.ID, entry.Product.ID)
+			}
+			if entry.Product.Price != product.Price || entry.Product.Currency != product.Currency {
+				t.Errorf("Expected price %d %s, got %d %s", product.Price, product.Currency, entry.Product.Price, entry.Product.Currency)
+			}
+			if !entry.CachedAt.Equal(cachedAt) {
+				t.Errorf("Expected cached_at %v, got %v", cachedAt, entry.CachedAt)
+			}
+			if entry.Age() < time.Minute {
+				t.Errorf("Expected age >= 1m, got %v", entry.Age())
+			}
+		})
+	}
+}
+
+func TestRedisRepository_DecodeEntry_LegacyPayload(t *testing.T) {
+	for _, serializer := range []Serializer{NewMsgpackSerializer(), NewJSONSerializer()} {
+		t.Run(serializer.Name(), func(t *testing.T) {
+			r := NewRedisRepositoryWithSerializer(nil, serializer)
+			product := createTestProduct()
+
+			data, err := serializer.Marshal(product)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			entry, err := r.decodeEntry(data)
+			if err != nil {
+				t.Fatalf("Expected legacy payload to decode, got %v", err)
+			}
+
+			if entry.Product.ID != product.ID || entry.Product.Name != product.Name {
+				t.Errorf("Expected product %s, got %+v", product.ID, entry.Product)
+			}
+			if !entry.CachedAt.IsZero() {
+				t.Errorf("Expected zero cached_at for legacy payload, got %v", entry.CachedAt)
+			}
+		})
+	}
+}
+
+func TestRedisRepository_DecodeEntry_Invalid(t *testing.T) {
+	r := NewRedisRepositoryWithSerializer(nil, NewJSONSerializer())
+
+	if _, err := r.decodeEntry([]byte("not a product")); err == nil {
+		t.Error("Expected error for invalid payload")
+	}
+}
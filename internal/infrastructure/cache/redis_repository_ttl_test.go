@@ -0,0 +1,276 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+	"github.com/redis/go-redis/v9"
+)
+
+// recordingHook intercepta os comandos sem chegar à rede e guarda os
+// argumentos de cada um.
+type recordingHook struct {
+	commands [][]interface{}
+
+	// members, quando definido, é devolvido pelos comandos que retornam listas.
+	members []string
+
+	// count é devolvido pelos comandos que retornam inteiros.
+	count int64
+}
+
+func (h *recordingHook) record(cmd redis.Cmder) {
+	h.commands = append(h.commands, cmd.Args())
+	switch c := cmd.(type) {
+	case *redis.StringSliceCmd:
+		if h.members != nil {
+			c.SetVal(h.members)
+		}
+	case *redis.IntCmd:
+		c.SetVal(h.count)
+	}
+}
+
+func (h *recordingHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h *recordingHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		h.record(cmd)
+		return nil
+	}
+}
+
+func (h *recordingHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		for _, cmd := range cmds {
+			h.record(cmd)
+		}
+		return nil
+	}
+}
+
+func newRecordingRepository() (*RedisRepository, *recordingHook) {
+	client := redis.NewClient(&redis.Options{Addr: "fake:6379"})
+	hook := &recordingHook{}
+	client.AddHook(hook)
+	return NewRedisRepository(client), hook
+}
+
+func TestRedisRepository_Set_TTL(t *testing.T) {
+	product := &entity.Product{ID: "p1", Name: "Produto"}
+
+	tests := []struct {
+		name string
+		ttl  time.Duration
+		want []interface{}
+	}{
+		{name: "no expiration", ttl: 0, want: nil},
+		{name: "seconds", ttl: 90 * time.Second, want: []interface{}{"ex", int64(90)}},
+		{name: "milliseconds", ttl: 1500 * time.Millisecond, want: []interface{}{"px", int64(1500)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, hook := newRecordingRepository()
+			repo.WithTTL(tt.ttl)
+
+			if err := repo.Set(context.Background(), "product_p1", product); err != nil {
+				t.Fatalf("Set() error = %v", err)
+			}
+
+			assertSetExpiration(t, hook, tt.want)
+		})
+	}
+}
+
+func TestRedisRepository_SetWithTTL_OverridesDefault(t *testing.T) {
+	repo, hook := newRecordingRepository()
+	repo.WithTTL(time.Hour)
+
+	err := repo.SetWithTTL(context.Background(), "product_p1", &entity.Product{ID: "p1"}, 10*time.Second)
+	if err != nil {
+		t.Fatalf("SetWithTTL() error = %v", err)
+	}
+
+	assertSetExpiration(t, hook, []interface{}{"ex", int64(10)})
+}
+
+func TestRedisRepository_Set_MaxEntrySize(t *testing.T) {
+	large := &entity.Product{ID: "p1", Description: strings.Repeat("x", 2048)}
+
+	t.Run("small entry", func(t *testing.T) {
+		repo, hook := newRecordingRepository()
+		repo.WithTTL(time.Hour).WithMaxEntrySize(1024, 0)
+
+		if err := repo.Set(context.Background(), "product_p1", &entity.Product{ID: "p1"}); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+		assertSetExpiration(t, hook, []interface{}{"ex", int64(3600)})
+	})
+
+	t.Run("shorter ttl", func(t *testing.T) {
+		repo, hook := newRecordingRepository()
+		repo.WithTTL(time.Hour).WithMaxEntrySize(1024, time.Minute)
+
+		if err := repo.Set(context.Background(), "product_p1", large); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+		assertSetExpiration(t, hook, []interface{}{"ex", int64(60)})
+	})
+
+	t.Run("skipped", func(t *testing.T) {
+		repo, hook := newRecordingRepository()
+		repo.WithMaxEntrySize(1024, 0)
+
+		err := repo.Set(context.Background(), "product_p1", large)
+		if !errors.Is(err, repository.ErrCacheEntryTooLarge) {
+			t.Fatalf("Set() error = %v, want %v", err, repository.ErrCacheEntryTooLarge)
+		}
+		if len(hook.commands) != 1 || hook.commands[0][0] != "del" {
+			t.Errorf("commands = %v, want a single DEL of the previous entry", hook.commands)
+		}
+	})
+}
+
+func assertSetExpiration(t *testing.T, hook *recordingHook, want []interface{}) {
+	t.Helper()
+
+	if len(hook.commands) != 1 {
+		t.Fatalf("commands = %v, want a single SET", hook.commands)
+	}
+	args := hook.commands[0]
+	if args[0] != "set" || args[1] != "product_p1" {
+		t.Fatalf("command = %v, want set product_p1", args[:2])
+	}
+
+	got := args[3:]
+	if len(got) != len(want) {
+		t.Fatalf("expiration args = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expiration args = %v, want %v", got, want)
+		}
+	}
+}
+
+// commandNames retorna o nome de cada comando registrado, na ordem.
+func (h *recordingHook) commandNames() []string {
+	names := make([]string, len(h.commands))
+	for i, args := range h.commands {
+		names[i] = fmt.Sprint(args[0])
+	}
+	return names
+}
+
+func TestRedisRepository_AddToSet_IndexTTL(t *testing.T) {
+	t.Run("without index ttl adds directly", func(t *testing.T) {
+		repo, hook := newRecordingRepository()
+
+		if err := repo.AddToSet(context.Background(), "product_by_category_laptops", "p1"); err != nil {
+			t.Fatalf("AddToSet() error = %v", err)
+		}
+
+		if got := hook.commandNames(); len(got) != 1 || got[0] != "sadd" {
+			t.Errorf("commands = %v, want a single sadd", got)
+		}
+	})
+
+	t.Run("with index ttl only adds to an existing set", func(t *testing.T) {
+		repo, hook := newRecordingRepository()
+		repo.WithIndexTTL(time.Hour)
+
+		if err := repo.AddToSet(context.Background(), "product_by_category_laptops", "p1"); err != nil {
+			t.Fatalf("AddToSet() error = %v", err)
+		}
+
+		got := hook.commandNames()
+		if len(got) != 1 || got[0] != "evalsha" {
+			t.Fatalf("commands = %v, want the conditional add script", got)
+		}
+		if args := hook.commands[0]; args[3] != "product_by_category_laptops" || args[4] != "p1" {
+			t.Errorf("script args = %v, want the set key and the member", args)
+		}
+	})
+}
+
+func TestRedisRepository_AddAllToSet(t *testing.T) {
+	t.Run("sets the index expiration with the members", func(t *testing.T) {
+		repo, hook := newRecordingRepository()
+		repo.WithIndexTTL(time.Hour)
+
+		if err := repo.AddAllToSet(context.Background(), "product_by_tag_promo", []string{"p1", "p2"}); err != nil {
+			t.Fatalf("AddAllToSet() error = %v", err)
+		}
+
+		got := strings.Join(hook.commandNames(), " ")
+		if got != "multi sadd expire exec" {
+			t.Errorf("commands = %q, want multi sadd expire exec", got)
+		}
+	})
+
+	t.Run("without index ttl does not expire", func(t *testing.T) {
+		repo, hook := newRecordingRepository()
+
+		if err := repo.AddAllToSet(context.Background(), "product_by_tag_promo", []string{"p1"}); err != nil {
+			t.Fatalf("AddAllToSet() error = %v", err)
+		}
+
+		if got := strings.Join(hook.commandNames(), " "); got != "multi sadd exec" {
+			t.Errorf("commands = %q, want multi sadd exec", got)
+		}
+	})
+
+	t.Run("empty members is a no-op", func(t *testing.T) {
+		repo, hook := newRecordingRepository()
+
+		if err := repo.AddAllToSet(context.Background(), "product_by_tag_promo", nil); err != nil {
+			t.Fatalf("AddAllToSet() error = %v", err)
+		}
+
+		if len(hook.commands) != 0 {
+			t.Errorf("commands = %v, want none", hook.commands)
+		}
+	})
+}
+
+func TestRedisRepository_GetSet_RefreshesIndexTTL(t *testing.T) {
+	tests := []struct {
+		name    string
+		ttl     time.Duration
+		members []string
+		want    string
+	}{
+		{name: "refreshes a non-empty index", ttl: time.Hour, members: []string{"p1"}, want: "smembers expire"},
+		{name: "missing index is not touched", ttl: time.Hour, members: []string{}, want: "smembers"},
+		{name: "no index ttl", ttl: 0, members: []string{"p1"}, want: "smembers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, hook := newRecordingRepository()
+			repo.WithIndexTTL(tt.ttl)
+			hook.members = tt.members
+
+			members, err := repo.GetSet(context.Background(), "product_by_name_dell")
+			if err != nil {
+				t.Fatalf("GetSet() error = %v", err)
+			}
+			if len(members) != len(tt.members) {
+				t.Errorf("members = %v, want %v", members, tt.members)
+			}
+
+			if got := strings.Join(hook.commandNames(), " "); got != tt.want {
+				t.Errorf("commands = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
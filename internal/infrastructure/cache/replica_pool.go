@@ -0,0 +1,146 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// ReadReplica é um cliente Redis somente leitura com peso relativo na rotação.
+type ReadReplica struct {
+	Name   string
+	Client *redis.Client
+	Weight int
+}
+
+type replicaState struct {
+	replica       ReadReplica
+	healthy       bool
+	currentWeight int
+}
+
+// ReplicaPool distribui leituras entre réplicas usando weighted round-robin
+// suave (mesmo algoritmo do nginx): réplicas com peso maior recebem mais
+// leituras, intercaladas em vez de em rajadas. Réplicas que falham no health
+// check saem da rotação até voltarem a responder.
+type ReplicaPool struct {
+	mu       sync.Mutex
+	replicas []*replicaState
+	logger   *zap.Logger
+}
+
+func NewReplicaPool(replicas []ReadReplica, logger *zap.Logger) *ReplicaPool {
+	states := make([]*replicaState, 0, len(replicas))
+	for _, replica := range replicas {
+		if replica.Weight <= 0 {
+			replica.Weight = 1
+		}
+		states = append(states, &replicaState{replica: replica, healthy: true})
+	}
+
+	return &ReplicaPool{
+		replicas: states,
+		logger:   logger,
+	}
+}
+
+// Next retorna o cliente da próxima réplica saudável, ou nil quando nenhuma
+// réplica está disponível e a leitura deve ir para o primário.
+func (p *ReplicaPool) Next() *redis.Client {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var selected *replicaState
+	total := 0
+
+	for _, state := range p.replicas {
+		if !state.healthy {
+			continue
+		}
+		state.currentWeight += state.replica.Weight
+		total += state.replica.Weight
+		if selected == nil || state.currentWeight > selected.currentWeight {
+			selected = state
+		}
+	}
+
+	if selected == nil {
+		return nil
+	}
+
+	selected.currentWeight -= total
+	return selected.replica.Client
+}
+
+// HealthyCount retorna quantas réplicas estão na rotação.
+func (p *ReplicaPool) HealthyCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	count := 0
+	for _, state := range p.replicas {
+		if state.healthy {
+			count++
+		}
+	}
+	return count
+}
+
+// CheckHealth faz PING em cada réplica e atualiza a rotação.
+func (p *ReplicaPool) CheckHealth(ctx context.Context) {
+	for _, state := range p.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		err := state.replica.Client.Ping(pingCtx).Err()
+		cancel()
+
+		p.setHealthy(state, err)
+	}
+}
+
+// Start executa CheckHealth periodicamente até o contexto ser cancelado.
+func (p *ReplicaPool) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.CheckHealth(ctx)
+		}
+	}
+}
+
+// Close fecha os clientes de todas as réplicas.
+func (p *ReplicaPool) Close() error {
+	var firstErr error
+	for _, state := range p.replicas {
+		if err := state.replica.Client.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (p *ReplicaPool) setHealthy(state *replicaState, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	healthy := err == nil
+	if state.healthy == healthy {
+		return
+	}
+
+	state.healthy = healthy
+	state.currentWeight = 0
+
+	if healthy {
+		p.logger.Info("redis read replica back in rotation", zap.String("replica", state.replica.Name))
+	} else {
+		p.logger.Warn("redis read replica removed from rotation", zap.String("replica", state.replica.Name), zap.Error(err))
+	}
+}
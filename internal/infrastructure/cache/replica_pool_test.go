@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+func newTestReplicaPool(weights ...int) (*ReplicaPool, []*redis.Client) {
+	replicas := make([]ReadReplica, len(weights))
+	clients := make([]*redis.Client, len(weights))
+	for i, weight := range weights {
+		clients[i] = redis.NewClient(&redis.Options{Addr: "localhost:0"})
+		replicas[i] = ReadReplica{Name: "replica", Client: clients[i], Weight: weight}
+	}
+	return NewReplicaPool(replicas, zap.NewNop()), clients
+}
+
+func TestReplicaPool_Next_WeightedDistribution(t *testing.T) {
+	pool, clients := newTestReplicaPool(3, 1)
+
+	counts := map[*redis.Client]int{}
+	for i := 0; i < 400; i++ {
+		counts[pool.Next()]++
+	}
+
+	if counts[clients[0]] != 300 || counts[clients[1]] != 100 {
+		t.Errorf("Expected 300/100 split, got %d/%d", counts[clients[0]], counts[clients[1]])
+	}
+}
+
+func TestReplicaPool_Next_Interleaves(t *testing.T) {
+	pool, clients := newTestReplicaPool(2, 1)
+
+	sequence := []*redis.Client{pool.Next(), pool.Next(), pool.Next()}
+
+	if sequence[0] != clients[0] || sequence[1] != clients[1] || sequence[2] != clients[0] {
+		t.Error("Expected smooth weighted round-robin to interleave replicas")
+	}
+}
+
+func TestReplicaPool_Next_SkipsUnhealthy(t *testing.T) {
+	pool, clients := newTestReplicaPool(1, 1)
+
+	pool.setHealthy(pool.replicas[0], errors.New("connection refused"))
+
+	for i := 0; i < 10; i++ {
+		if got := pool.Next(); got != clients[1] {
+			t.Fatal("Expected unhealthy replica to be excluded from rotation")
+		}
+	}
+
+	if pool.HealthyCount() != 1 {
+		t.Errorf("Expected 1 healthy replica, got %d", pool.HealthyCount())
+	}
+
+	pool.setHealthy(pool.replicas[0], nil)
+
+	if pool.HealthyCount() != 2 {
+		t.Errorf("Expected replica to return to rotation, got %d healthy", pool.HealthyCount())
+	}
+}
+
+func TestReplicaPool_Next_NoHealthyReplicas(t *testing.T) {
+	pool, _ := newTestReplicaPool(1)
+
+	pool.setHealthy(pool.replicas[0], errors.New("timeout"))
+
+	if got := pool.Next(); got != nil {
+		t.Error("Expected nil so the read falls back to the primary")
+	}
+}
+
+func TestRedisRepository_Reader_FallsBackToPrimary(t *testing.T) {
+	primary := redis.NewClient(&redis.Options{Addr: "localhost:0"})
+	pool, clients := newTestReplicaPool(1)
+	r := NewRedisRepository(primary).WithReadReplicas(pool)
+
+	if r.reader() != clients[0] {
+		t.Error("Expected reads to go to the healthy replica")
+	}
+
+	pool.setHealthy(pool.replicas[0], errors.New("down"))
+
+	if r.reader() != primary {
+		t.Error("Expected reads to fall back to the primary")
+	}
+}
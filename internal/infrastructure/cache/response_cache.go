@@ -0,0 +1,52 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisResponseCache guarda as respostas de listagem em response_cache:<key>
+// com TTL, compartilhadas entre as réplicas da API. Não há invalidação: uma
+// escrita só aparece nas respostas guardadas quando elas expiram.
+type RedisResponseCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewRedisResponseCache(client *redis.Client, ttl time.Duration) *RedisResponseCache {
+	return &RedisResponseCache{client: client, ttl: ttl}
+}
+
+func (c *RedisResponseCache) Get(ctx context.Context, key string) (*port.CachedResponse, error) {
+	data, err := c.client.Get(ctx, responseCacheKey(key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached response: %w", err)
+	}
+
+	var response port.CachedResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode cached response: %w", err)
+	}
+	return &response, nil
+}
+
+func (c *RedisResponseCache) Set(ctx context.Context, key string, response port.CachedResponse) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, responseCacheKey(key), data, c.ttl).Err()
+}
+
+func responseCacheKey(key string) string {
+	return "response_cache:" + key
+}
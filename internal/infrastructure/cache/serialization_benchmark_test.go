@@ -20,6 +20,8 @@ func createTestProduct() *entity.Product {
 		SKU:             "SKU-APPLE-IP15PM-256-TIT-NAT-2024",
 		Brand:           "Apple",
 		Stock:           150,
+		Price:           1099900,
+		Currency:        "BRL",
 		Images: []string{
 			"https://example.com/images/iphone15promax-front.jpg",
 			"https://example.com/images/iphone15promax-back.jpg",
@@ -232,3 +234,102 @@ func TestPayloadSizeComparison(t *testing.T) {
 	t.Logf("Msgpack payload size: %d bytes", len(msgpackData))
 	t.Logf("Size reduction: %.2f%%", reduction)
 }
+
+// ==================== MSGPACK OPTIONS ====================
+
+var msgpackOptionVariants = []struct {
+	name    string
+	options MsgpackOptions
+}{
+	{"default", MsgpackOptions{}},
+	{"compact_ints", MsgpackOptions{UseCompactInts: true}},
+	{"compact_ints_floats", MsgpackOptions{UseCompactInts: true, UseCompactFloats: true}},
+	{"array_structs", MsgpackOptions{UseArrayEncodedStructs: true}},
+	{"all", MsgpackOptions{UseCompactInts: true, UseCompactFloats: true, UseArrayEncodedStructs: true}},
+}
+
+// Benchmark de serialização Msgpack com as opções de encoding compacto
+func BenchmarkMsgpackMarshalOptions(b *testing.B) {
+	product := createTestProduct()
+
+	for _, variant := range msgpackOptionVariants {
+		b.Run(variant.name, func(b *testing.B) {
+			s := NewMsgpackSerializerWithOptions(variant.options)
+			data, err := s.Marshal(product)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ReportMetric(float64(len(data)), "bytes/payload")
+
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := s.Marshal(product); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// Compara o tamanho do payload Msgpack para cada combinação de opções
+func TestMsgpackOptionsPayloadSize(t *testing.T) {
+	product := createTestProduct()
+
+	baseline, err := NewMsgpackSerializer().Marshal(product)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, variant := range msgpackOptionVariants {
+		data, err := NewMsgpackSerializerWithOptions(variant.options).Marshal(product)
+		if err != nil {
+			t.Fatalf("%s: marshal failed: %v", variant.name, err)
+		}
+
+		reduction := float64(len(baseline)-len(data)) / float64(len(baseline)) * 100
+		t.Logf("Msgpack %-20s %4d bytes (%.2f%% vs default)", variant.name+":", len(data), reduction)
+
+		if len(data) > len(baseline) {
+			t.Errorf("%s: expected payload <= default (%d bytes), got %d", variant.name, len(baseline), len(data))
+		}
+	}
+}
+
+func TestMsgpackOptionsRoundTrip(t *testing.T) {
+	product := createTestProduct()
+	product.CreatedAt = product.CreatedAt.UTC()
+	product.UpdatedAt = product.UpdatedAt.UTC()
+
+	for _, variant := range msgpackOptionVariants {
+		t.Run(variant.name, func(t *testing.T) {
+			s := NewMsgpackSerializerWithOptions(variant.options)
+
+			data, err := s.Marshal(product)
+			if err != nil {
+				t.Fatalf("marshal failed: %v", err)
+			}
+
+			// Um serializer com opções padrão precisa ler o que foi gravado com opções
+			// diferentes, para que a troca de configuração não invalide o cache.
+			var decoded entity.Product
+			if err := NewMsgpackSerializer().Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("unmarshal failed: %v", err)
+			}
+
+			if decoded.ID != product.ID || decoded.Stock != product.Stock || decoded.Version != product.Version {
+				t.Errorf("scalar fields mismatch after round trip: got %+v", decoded)
+			}
+			if !decoded.CreatedAt.Equal(product.CreatedAt) {
+				t.Errorf("created_at mismatch: got %v, want %v", decoded.CreatedAt, product.CreatedAt)
+			}
+			if len(decoded.Images) != len(product.Images) {
+				t.Errorf("images mismatch: got %d, want %d", len(decoded.Images), len(product.Images))
+			}
+			if decoded.Specifications["storage"] != "256GB" || decoded.Specifications["5g"] != true {
+				t.Errorf("specifications mismatch: got %v", decoded.Specifications)
+			}
+		})
+	}
+}
@@ -1,11 +1,17 @@
 package cache
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/vmihailenco/msgpack/v5"
 )
 
+var ErrUnknownSerializer = errors.New("unknown serializer")
+
 // Serializer define a interface para serialização de dados
 type Serializer interface {
 	Marshal(v interface{}) ([]byte, error)
@@ -13,6 +19,19 @@ type Serializer interface {
 	Name() string
 }
 
+// NewSerializer cria o serializer pelo nome, "json" ou "msgpack", como em
+// REDIS_SERIALIZER. Os formatos não leem as entradas um do outro.
+func NewSerializer(name string) (Serializer, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "json":
+		return NewJSONSerializer(), nil
+	case "msgpack":
+		return NewMsgpackSerializer(), nil
+	default:
+		return nil, fmt.Errorf("%w %q: must be json or msgpack", ErrUnknownSerializer, name)
+	}
+}
+
 // JSONSerializer implementa serialização usando JSON
 type JSONSerializer struct{}
 
@@ -32,15 +51,48 @@ func (s *JSONSerializer) Name() string {
 	return "json"
 }
 
+// MsgpackOptions controla o encoding do MessagePack.
+//
+// UseArrayEncodedStructs grava structs como arrays posicionais em vez de mapas,
+// eliminando os nomes dos campos do payload. O decoder aceita os dois formatos,
+// mas um payload em array depende da ordem dos campos da struct: reordenar,
+// inserir ou remover campos de entity.Product invalida as entradas já
+// gravadas, que precisam ser descartadas no deploy (DELETE
+// /api/v1/admin/cache ou expiração).
+type MsgpackOptions struct {
+	UseCompactInts         bool
+	UseCompactFloats       bool
+	UseArrayEncodedStructs bool
+}
+
 // MsgpackSerializer implementa serialização usando MessagePack
-type MsgpackSerializer struct{}
+type MsgpackSerializer struct {
+	options MsgpackOptions
+}
 
 func NewMsgpackSerializer() *MsgpackSerializer {
 	return &MsgpackSerializer{}
 }
 
+func NewMsgpackSerializerWithOptions(options MsgpackOptions) *MsgpackSerializer {
+	return &MsgpackSerializer{options: options}
+}
+
 func (s *MsgpackSerializer) Marshal(v interface{}) ([]byte, error) {
-	return msgpack.Marshal(v)
+	if s.options == (MsgpackOptions{}) {
+		return msgpack.Marshal(v)
+	}
+
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.UseCompactInts(s.options.UseCompactInts)
+	enc.UseCompactFloats(s.options.UseCompactFloats)
+	enc.UseArrayEncodedStructs(s.options.UseArrayEncodedStructs)
+
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
 }
 
 func (s *MsgpackSerializer) Unmarshal(data []byte, v interface{}) error {
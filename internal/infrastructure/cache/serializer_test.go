@@ -0,0 +1,25 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewSerializer(t *testing.T) {
+	for name, want := range map[string]string{"json": "json", "msgpack": "msgpack", " MsgPack ": "msgpack"} {
+		serializer, err := NewSerializer(name)
+		if err != nil {
+			t.Errorf("NewSerializer(%q) unexpected error = %v", name, err)
+			continue
+		}
+		if serializer.Name() != want {
+			t.Errorf("NewSerializer(%q) = %s, want %s", name, serializer.Name(), want)
+		}
+	}
+
+	for _, name := range []string{"", "protobuf"} {
+		if _, err := NewSerializer(name); !errors.Is(err, ErrUnknownSerializer) {
+			t.Errorf("NewSerializer(%q) error = %v, want %v", name, err, ErrUnknownSerializer)
+		}
+	}
+}
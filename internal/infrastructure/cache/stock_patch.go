@@ -0,0 +1,184 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+	"github.com/redis/go-redis/v9"
+)
+
+// patchStockScript altera os campos Stock, Version e UpdatedAt do produto
+// dentro do envelope MessagePack gravado em KEYS[1], sem que o chamador
+// precise ler, decodificar e regravar a entrada inteira.
+//
+// O script percorre o payload (mapa do envelope -> chave "product" -> mapa do
+// produto -> campo), localiza os bytes de cada valor e os substitui: estoque
+// (ARGV[1]) e versão (ARGV[2]) por um uint32, UpdatedAt por um timestamp
+// MessagePack de 64 bits com os segundos (ARGV[3]) e nanossegundos (ARGV[4]).
+// O TTL é preservado, e nada é gravado se algum campo não for encontrado.
+// Retorna:
+//
+//	 1  entrada atualizada
+//	 0  layout não reconhecido (JSON, structs em array, formato legado)
+//	-1  chave inexistente
+var patchStockScript = redis.NewScript(`
+local data = redis.call('GET', KEYS[1])
+if not data then
+	return -1
+end
+
+local function u8(pos)
+	return string.byte(data, pos)
+end
+
+local function uint(pos, size)
+	local n = 0
+	for i = 0, size - 1 do
+		n = n * 256 + string.byte(data, pos + i)
+	end
+	return n
+end
+
+-- header retorna o tamanho do cabeçalho, o tamanho do payload em bytes e,
+-- para mapas e arrays, a quantidade de elementos filhos.
+local function header(pos)
+	local b = u8(pos)
+	if b == nil then return nil end
+	if b <= 0x7f or b >= 0xe0 then return 1, 0, 0 end
+	if b >= 0x80 and b <= 0x8f then return 1, 0, (b - 0x80) * 2 end
+	if b >= 0x90 and b <= 0x9f then return 1, 0, b - 0x90 end
+	if b >= 0xa0 and b <= 0xbf then return 1, b - 0xa0, 0 end
+	if b == 0xc0 or b == 0xc2 or b == 0xc3 then return 1, 0, 0 end
+	if b == 0xc4 or b == 0xd9 then return 2, u8(pos + 1), 0 end
+	if b == 0xc5 or b == 0xda then return 3, uint(pos + 1, 2), 0 end
+	if b == 0xc6 or b == 0xdb then return 5, uint(pos + 1, 4), 0 end
+	if b == 0xc7 then return 3, u8(pos + 1) + 1, 0 end
+	if b == 0xc8 then return 4, uint(pos + 1, 2) + 1, 0 end
+	if b == 0xc9 then return 6, uint(pos + 1, 4) + 1, 0 end
+	if b == 0xca then return 1, 4, 0 end
+	if b == 0xcb then return 1, 8, 0 end
+	if b == 0xcc or b == 0xd0 then return 1, 1, 0 end
+	if b == 0xcd or b == 0xd1 then return 1, 2, 0 end
+	if b == 0xce or b == 0xd2 then return 1, 4, 0 end
+	if b == 0xcf or b == 0xd3 then return 1, 8, 0 end
+	if b == 0xd4 then return 1, 2, 0 end
+	if b == 0xd5 then return 1, 3, 0 end
+	if b == 0xd6 then return 1, 5, 0 end
+	if b == 0xd7 then return 1, 9, 0 end
+	if b == 0xd8 then return 1, 17, 0 end
+	if b == 0xdc then return 3, 0, uint(pos + 1, 2) end
+	if b == 0xdd then return 5, 0, uint(pos + 1, 4) end
+	if b == 0xde then return 3, 0, uint(pos + 1, 2) * 2 end
+	if b == 0xdf then return 5, 0, uint(pos + 1, 4) * 2 end
+	return nil
+end
+
+local function skip(pos)
+	local h, size, children = header(pos)
+	if h == nil then return nil end
+	pos = pos + h + size
+	for _ = 1, children do
+		pos = skip(pos)
+		if pos == nil then return nil end
+	end
+	return pos
+end
+
+local function str(pos)
+	local b = u8(pos)
+	if b == nil then return nil end
+	if (b >= 0xa0 and b <= 0xbf) or b == 0xd9 or b == 0xda or b == 0xdb then
+		local h, size = header(pos)
+		return string.sub(data, pos + h, pos + h + size - 1)
+	end
+	return nil
+end
+
+-- find procura a chave no mapa em pos e retorna a posição do valor.
+local function find(pos, key)
+	local b = u8(pos)
+	if b == nil or not ((b >= 0x80 and b <= 0x8f) or b == 0xde or b == 0xdf) then
+		return nil
+	end
+	local h, _, children = header(pos)
+	pos = pos + h
+	for _ = 1, children / 2 do
+		local k = str(pos)
+		pos = skip(pos)
+		if pos == nil then return nil end
+		if k == key then return pos end
+		pos = skip(pos)
+		if pos == nil then return nil end
+	end
+	return nil
+end
+
+local function u32(n)
+	return string.char(
+		math.floor(n / 16777216) % 256,
+		math.floor(n / 65536) % 256,
+		math.floor(n / 256) % 256,
+		n % 256)
+end
+
+-- replace troca o valor do campo do produto; as posições mudam a cada troca,
+-- então o produto é localizado de novo.
+local function replace(field, encoded)
+	local product = find(1, 'product')
+	if product == nil then return false end
+	local pos = find(product, field)
+	if pos == nil then return false end
+	local after = skip(pos)
+	if after == nil then return false end
+	data = string.sub(data, 1, pos - 1) .. encoded .. string.sub(data, after)
+	return true
+end
+
+local seconds, nanos = tonumber(ARGV[3]), tonumber(ARGV[4])
+local timestamp = string.char(0xd7, 0xff) ..
+	u32(nanos * 4 + math.floor(seconds / 4294967296)) ..
+	u32(seconds % 4294967296)
+
+if not replace('Stock', string.char(0xce) .. u32(tonumber(ARGV[1]))) or
+	not replace('Version', string.char(0xce) .. u32(tonumber(ARGV[2]))) or
+	not replace('UpdatedAt', timestamp) then
+	return 0
+end
+
+redis.call('SET', KEYS[1], data, 'KEEPTTL')
+return 1
+`)
+
+// maxPatchableStock é o maior estoque (e a maior versão) representável pelo
+// uint32 do script; maxPatchableSeconds, o maior instante do timestamp de 64
+// bits.
+const (
+	maxPatchableStock   = 1<<32 - 1
+	maxPatchableSeconds = 1<<34 - 1
+)
+
+// PatchStock atualiza o estoque, a versão e o updated_at da entrada em cache
+// no próprio Redis. Quando o layout não permite o patch (serializer JSON,
+// structs em array ou entrada legada), a chave é removida para ser repopulada
+// do banco na próxima leitura.
+func (r *RedisRepository) PatchStock(ctx context.Context, key string, stock int, revision repository.Revision) error {
+	seconds := revision.UpdatedAt.Unix()
+	if stock < 0 || stock > maxPatchableStock || revision.Version <= 0 || revision.Version > maxPatchableStock ||
+		seconds < 0 || seconds > maxPatchableSeconds {
+		return r.Delete(ctx, key)
+	}
+
+	result, err := patchStockScript.Run(ctx, r.client, []string{key},
+		stock, revision.Version, seconds, revision.UpdatedAt.Nanosecond(),
+	).Int()
+	if err != nil {
+		return fmt.Errorf("failed to patch cached stock: %w", err)
+	}
+
+	if result == 0 {
+		return r.Delete(ctx, key)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+	"github.com/redis/go-redis/v9"
+)
+
+// newMiniredisRepository roda o repositório contra um miniredis, que executa
+// os scripts Lua de verdade.
+func newMiniredisRepository(t *testing.T, serializer Serializer) (*RedisRepository, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewRedisRepositoryWithSerializer(client, serializer), server
+}
+
+func TestRedisRepository_PatchStock(t *testing.T) {
+	repo, server := newMiniredisRepository(t, NewMsgpackSerializer())
+	ctx := context.Background()
+
+	created := time.Date(2025, 1, 10, 8, 0, 0, 0, time.UTC)
+	product := &entity.Product{
+		ID:        "p1",
+		Name:      "Teclado",
+		Category:  "Periféricos",
+		Stock:     3,
+		Price:     19990,
+		Tags:      []string{"gamer"},
+		Version:   1,
+		CreatedAt: created,
+		UpdatedAt: created,
+	}
+	if err := repo.SetWithTTL(ctx, "product_p1", product, time.Hour); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	revision := repository.Revision{Version: 2, UpdatedAt: time.Date(2025, 2, 3, 14, 30, 15, 123456789, time.UTC)}
+	if err := repo.PatchStock(ctx, "product_p1", 70000, revision); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	cached, err := repo.Get(ctx, "product_p1")
+	if err != nil {
+		t.Fatalf("Expected the patched entry to decode, got %v", err)
+	}
+	if cached.Stock != 70000 || cached.Version != 2 || !cached.UpdatedAt.Equal(revision.UpdatedAt) {
+		t.Errorf("Expected stock 70000, version 2 and updated_at %v, got %d, %d and %v",
+			revision.UpdatedAt, cached.Stock, cached.Version, cached.UpdatedAt)
+	}
+	if cached.Name != product.Name || cached.Price != product.Price || len(cached.Tags) != 1 || !cached.CreatedAt.Equal(created) {
+		t.Errorf("Expected the other fields to be preserved, got %+v", cached)
+	}
+	if ttl := server.TTL("product_p1"); ttl != time.Hour {
+		t.Errorf("Expected the TTL to be preserved, got %v", ttl)
+	}
+}
+
+func TestRedisRepository_PatchStock_Invalidates(t *testing.T) {
+	product := &entity.Product{ID: "p1", Name: "Teclado", Stock: 3, Version: 1}
+	revision := repository.Revision{Version: 2, UpdatedAt: time.Now()}
+
+	tests := []struct {
+		name       string
+		serializer Serializer
+		revision   repository.Revision
+	}{
+		{name: "json layout", serializer: NewJSONSerializer(), revision: revision},
+		{name: "unknown version", serializer: NewMsgpackSerializer(), revision: repository.Revision{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, _ := newMiniredisRepository(t, tt.serializer)
+			ctx := context.Background()
+
+			if err := repo.Set(ctx, "product_p1", product); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if err := repo.PatchStock(ctx, "product_p1", 5, tt.revision); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if _, err := repo.Get(ctx, "product_p1"); !errors.Is(err, ErrCacheNotFound) {
+				t.Errorf("Expected the entry to be removed, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/redis/go-redis/v9"
+)
+
+// TimingHook soma no port.RequestTiming do contexto o tempo de cada comando
+// e pipeline enviados ao Redis. Fora de uma requisição anotada não faz nada.
+type TimingHook struct{}
+
+func (TimingHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (TimingHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		port.RecordCacheTime(ctx, time.Since(start))
+		return err
+	}
+}
+
+func (TimingHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		port.RecordCacheTime(ctx, time.Since(start))
+		return err
+	}
+}
@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+	"github.com/redis/go-redis/v9"
+)
+
+// transientReplyPrefixes são respostas do Redis para um estado passageiro do
+// servidor: carregando o dataset, cluster em failover ou script demorado.
+var transientReplyPrefixes = []string{"LOADING", "TRYAGAIN", "CLUSTERDOWN", "MASTERDOWN", "BUSY"}
+
+// markTransient acrescenta repository.ErrCacheTransient às falhas que podem
+// passar sozinhas, mantendo o erro original na cadeia.
+func markTransient(err error) error {
+	if isTransient(err) {
+		return fmt.Errorf("%w: %w", repository.ErrCacheTransient, err)
+	}
+	return err
+}
+
+// isTransient separa falhas de rede e estados passageiros do servidor de
+// erros que se repetiriam (comando inválido, tipo errado, contexto do
+// chamador cancelado).
+func isTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	for _, prefix := range transientReplyPrefixes {
+		if redis.HasErrorPrefix(err, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// replyError imita um erro devolvido pelo servidor Redis (redis.Error).
+type replyError string
+
+func (e replyError) Error() string { return string(e) }
+
+func (replyError) RedisError() {}
+
+func TestMarkTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "network timeout", err: &net.OpError{Op: "read", Err: syscall.ETIMEDOUT}, want: true},
+		{name: "connection reset", err: syscall.ECONNRESET, want: true},
+		{name: "connection closed", err: io.EOF, want: true},
+		{name: "loading dataset", err: replyError("LOADING Redis is loading the dataset in memory"), want: true},
+		{name: "cluster down", err: replyError("CLUSTERDOWN The cluster is down"), want: true},
+		{name: "wrong type", err: replyError("WRONGTYPE Operation against a key holding the wrong kind of value"), want: false},
+		{name: "out of memory", err: replyError("OOM command not allowed when used memory > 'maxmemory'"), want: false},
+		{name: "caller canceled", err: context.Canceled, want: false},
+		{name: "other", err: errors.New("boom"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := markTransient(tt.err)
+
+			if got := errors.Is(err, repository.ErrCacheTransient); got != tt.want {
+				t.Errorf("markTransient(%v) transient = %v, want %v", tt.err, got, tt.want)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("markTransient(%v) lost the original error", tt.err)
+			}
+		})
+	}
+}
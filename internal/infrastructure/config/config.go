@@ -1,19 +1,28 @@
 package config
 
 import (
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
 )
 
 type Config struct {
-	Server    ServerConfig
-	Database  DatabaseConfig
-	Redis     RedisConfig
-	Keycloak  KeycloakConfig
-	App       AppConfig
-	RateLimit RateLimitConfig
+	Server      ServerConfig
+	Database    DatabaseConfig
+	Redis       RedisConfig
+	Keycloak    KeycloakConfig
+	App         AppConfig
+	RateLimit   RateLimitConfig
+	Import      ImportConfig
+	Webhook     WebhookConfig
+	Pagination  PaginationConfig
+	HTTPS       HTTPSConfig
+	Compression CompressionConfig
 }
 
 type ServerConfig struct {
@@ -21,6 +30,66 @@ type ServerConfig struct {
 	ReadTimeout     time.Duration `envconfig:"SERVER_READ_TIMEOUT" default:"10s"`
 	WriteTimeout    time.Duration `envconfig:"SERVER_WRITE_TIMEOUT" default:"10s"`
 	ShutdownTimeout time.Duration `envconfig:"SERVER_SHUTDOWN_TIMEOUT" default:"30s"`
+
+	// ShutdownDrainTimeout limita a espera pelas tarefas em background (limpeza
+	// e repopulação de cache) no shutdown, antes de fechar Redis e banco.
+	ShutdownDrainTimeout time.Duration `envconfig:"SERVER_SHUTDOWN_DRAIN_TIMEOUT" default:"10s"`
+
+	// MaxListResponseBytes limita o tamanho das listagens; zero desativa.
+	MaxListResponseBytes int `envconfig:"SERVER_MAX_LIST_RESPONSE_BYTES" default:"0"`
+
+	// ListStreamFlushEvery escreve as listagens JSON produto a produto, com
+	// flush a cada tantos produtos; zero monta a resposta inteira antes.
+	ListStreamFlushEvery int `envconfig:"SERVER_LIST_STREAM_FLUSH_EVERY" default:"0"`
+
+	// StartupRetryAfter é o Retry-After das respostas 503 dadas às rotas
+	// /api/v1 enquanto a inicialização não termina.
+	StartupRetryAfter time.Duration `envconfig:"SERVER_STARTUP_RETRY_AFTER" default:"5s"`
+
+	// IgnoreUnknownFields descarta os caminhos de ?fields fora de
+	// specifications em vez de responder 400.
+	IgnoreUnknownFields bool `envconfig:"SERVER_IGNORE_UNKNOWN_FIELDS" default:"false"`
+
+	// CacheStatusHeader marca as leituras com X-Cache: HIT|MISS, para
+	// depuração. Ignorado em produção.
+	CacheStatusHeader bool `envconfig:"SERVER_CACHE_STATUS_HEADER" default:"false"`
+
+	// TrustProxyHeaders faz a API honrar X-Forwarded-Proto e X-Forwarded-Host
+	// (o valor do último proxy) nos links de paginação e no HTTPS_ENFORCE. Só
+	// deve ser ativado atrás de um proxy que sobrescreve esses headers;
+	// exposta direto, o cliente poderia forjá-los.
+	TrustProxyHeaders bool `envconfig:"SERVER_TRUST_PROXY_HEADERS" default:"false"`
+
+	// BatchGetMaxIDs limita os IDs de um POST /products/batch-get.
+	BatchGetMaxIDs int `envconfig:"SERVER_BATCH_GET_MAX_IDS" default:"100"`
+
+	// MaxBulkItems limita os itens de qualquer requisição em lote: criação,
+	// ajuste de estoque, importação e batch-get.
+	MaxBulkItems int `envconfig:"MAX_BULK_ITEMS" default:"500"`
+
+	// MaxBodyBytes limita o corpo de POST e PUT /products e das rotas de
+	// estoque e tags de um produto; acima dele a resposta é 413.
+	MaxBodyBytes int64 `envconfig:"SERVER_MAX_BODY_BYTES" default:"1048576"`
+
+	// MaxBulkBodyBytes limita o corpo das requisições em lote (bulk,
+	// stock/bulk, import e batch-get); acima dele a resposta é 413.
+	MaxBulkBodyBytes int64 `envconfig:"SERVER_MAX_BULK_BODY_BYTES" default:"8388608"`
+
+	// IdempotencyTTL é por quanto tempo a resposta de um POST /products com
+	// Idempotency-Key fica gravada no Redis. Zero ignora o header.
+	IdempotencyTTL time.Duration `envconfig:"SERVER_IDEMPOTENCY_TTL" default:"24h"`
+
+	// MaxQueryParams e MaxQueryBytes limitam a query string das rotas de
+	// /api/v1/products (parâmetros distintos e bytes); zero desativa.
+	MaxQueryParams int `envconfig:"SERVER_MAX_QUERY_PARAMS" default:"30"`
+	MaxQueryBytes  int `envconfig:"SERVER_MAX_QUERY_BYTES" default:"4096"`
+
+	// ResponseCacheEnabled guarda no Redis, por ResponseCacheTTL, as respostas
+	// JSON de GET /products, /search/name e /search/category. Escritas não
+	// invalidam as respostas guardadas: elas só mudam quando expiram. Fica
+	// desligado quando REDIS_ENCRYPTED_SPECS está definida.
+	ResponseCacheEnabled bool          `envconfig:"SERVER_RESPONSE_CACHE_ENABLED" default:"false"`
+	ResponseCacheTTL     time.Duration `envconfig:"SERVER_RESPONSE_CACHE_TTL" default:"5s"`
 }
 
 type DatabaseConfig struct {
@@ -33,6 +102,33 @@ type DatabaseConfig struct {
 	MaxOpenConns    int           `envconfig:"DB_MAX_OPEN_CONNS" default:"25"`
 	MaxIdleConns    int           `envconfig:"DB_MAX_IDLE_CONNS" default:"5"`
 	ConnMaxLifetime time.Duration `envconfig:"DB_CONN_MAX_LIFETIME" default:"5m"`
+
+	// StockIsolationLevel define o isolamento das escritas que alteram estoque:
+	// read_committed, repeatable_read ou serializable.
+	StockIsolationLevel  string        `envconfig:"DB_STOCK_ISOLATION_LEVEL" default:"read_committed"`
+	SerializationRetries int           `envconfig:"DB_SERIALIZATION_RETRIES" default:"3"`
+	RetryBackoff         time.Duration `envconfig:"DB_RETRY_BACKOFF" default:"20ms"`
+
+	// DegradedMode serve leituras só do cache enquanto o banco estiver fora,
+	// verificado a cada HealthInterval.
+	DegradedMode   bool          `envconfig:"DB_DEGRADED_MODE" default:"false"`
+	HealthInterval time.Duration `envconfig:"DB_HEALTH_INTERVAL" default:"5s"`
+
+	// CircuitBreaker abre após CircuitBreakerThreshold falhas consecutivas e
+	// responde 503 sem tocar no pool durante CircuitBreakerOpenTimeout.
+	CircuitBreakerEnabled     bool          `envconfig:"DB_CIRCUIT_BREAKER_ENABLED" default:"false"`
+	CircuitBreakerThreshold   int           `envconfig:"DB_CIRCUIT_BREAKER_THRESHOLD" default:"5"`
+	CircuitBreakerOpenTimeout time.Duration `envconfig:"DB_CIRCUIT_BREAKER_OPEN_TIMEOUT" default:"10s"`
+
+	// AuditLog grava em audit_log uma entrada com autor e diff por criação,
+	// atualização e exclusão. Exige a tabela; falhas não afetam a escrita.
+	// As entradas são gravadas em segundo plano, até AuditLogBatchSize por
+	// INSERT, esperando até AuditLogFlushInterval para completar o lote; com
+	// AuditLogQueueSize entradas pendentes, as novas são descartadas.
+	AuditLog              bool          `envconfig:"DB_AUDIT_LOG" default:"false"`
+	AuditLogQueueSize     int           `envconfig:"DB_AUDIT_LOG_QUEUE_SIZE" default:"10000"`
+	AuditLogBatchSize     int           `envconfig:"DB_AUDIT_LOG_BATCH_SIZE" default:"500"`
+	AuditLogFlushInterval time.Duration `envconfig:"DB_AUDIT_LOG_FLUSH_INTERVAL" default:"100ms"`
 }
 
 type RedisConfig struct {
@@ -42,23 +138,241 @@ type RedisConfig struct {
 	DB         int    `envconfig:"REDIS_DB" default:"0"`
 	MaxRetries int    `envconfig:"REDIS_MAX_RETRIES" default:"3"`
 	PoolSize   int    `envconfig:"REDIS_POOL_SIZE" default:"10"`
+
+	// CacheMaxStaleness descarta entradas de produto mais antigas que o limite
+	// na leitura por ID. Zero desativa a verificação.
+	CacheMaxStaleness time.Duration `envconfig:"REDIS_CACHE_MAX_STALENESS" default:"0"`
+
+	// CacheTTL é a expiração das entradas de produto no Redis. Zero mantém
+	// as entradas até serem invalidadas.
+	CacheTTL time.Duration `envconfig:"REDIS_CACHE_TTL" default:"0"`
+
+	// IndexTTL é a expiração dos sets de índice de nome, categoria e tag,
+	// renovada a cada busca que os lê. Zero mantém os índices para sempre.
+	IndexTTL time.Duration `envconfig:"REDIS_INDEX_TTL" default:"0"`
+
+	// MaxEntryBytes limita o tamanho de um produto serializado no cache. Os
+	// maiores expiram em LargeEntryTTL ou, se ele for zero, não são gravados.
+	// Zero não limita.
+	MaxEntryBytes int           `envconfig:"REDIS_MAX_ENTRY_BYTES" default:"0"`
+	LargeEntryTTL time.Duration `envconfig:"REDIS_LARGE_ENTRY_TTL" default:"0"`
+
+	// CountTTL é a expiração do total de produtos em product_count, invalidado
+	// a cada criação, restauração ou exclusão. Zero conta sempre no banco.
+	CountTTL time.Duration `envconfig:"REDIS_COUNT_TTL" default:"30s"`
+
+	// CacheRevalidateAfter devolve entradas mais antigas que o limite na hora
+	// e as relê do banco em background. Zero desativa.
+	CacheRevalidateAfter time.Duration `envconfig:"REDIS_CACHE_REVALIDATE_AFTER" default:"0"`
+
+	// CacheStrictOperations lista operações em que falhas do Redis viram 500
+	// em vez de fallback para o banco. Hoje só "warm" é suportada.
+	CacheStrictOperations []string `envconfig:"REDIS_CACHE_STRICT_OPERATIONS"`
+
+	// StartupWarmNames e StartupWarmCategories são buscas aquecidas no cache
+	// antes de a API aceitar tráfego em /api/v1.
+	StartupWarmNames      []string `envconfig:"REDIS_STARTUP_WARM_NAMES"`
+	StartupWarmCategories []string `envconfig:"REDIS_STARTUP_WARM_CATEGORIES"`
+
+	// Serializer é o formato das entradas de produto: msgpack ou json.
+	// Trocar exige descartar o cache, pois um formato não lê o outro.
+	Serializer string `envconfig:"REDIS_SERIALIZER" default:"msgpack"`
+
+	// CompressThreshold comprime com gzip as entradas de produto com esse
+	// tamanho serializado ou maior. Zero desativa; ligar ou desligar exige
+	// descartar o cache, pois as entradas passam a ter um byte marcador.
+	CompressThreshold int `envconfig:"REDIS_COMPRESS_THRESHOLD" default:"0"`
+
+	// EncryptedSpecs lista as chaves de specifications cifradas (AES-GCM) no
+	// Redis. EncryptionKeys lista chaves id:base64; a primeira cifra e as
+	// demais só decifram, para rotação.
+	EncryptedSpecs []string `envconfig:"REDIS_ENCRYPTED_SPECS"`
+	EncryptionKeys []string `envconfig:"REDIS_ENCRYPTION_KEYS"`
+
+	// ReadReplicas lista réplicas de leitura no formato host:port[@peso].
+	ReadReplicas          []string      `envconfig:"REDIS_READ_REPLICAS"`
+	ReplicaHealthInterval time.Duration `envconfig:"REDIS_REPLICA_HEALTH_INTERVAL" default:"5s"`
+
+	// Backfill reconstrói em background o índice all_products quando uma
+	// listagem o encontra vazio, em lotes com pausa entre eles.
+	BackfillEnabled    bool          `envconfig:"REDIS_BACKFILL_ENABLED" default:"false"`
+	BackfillBatchSize  int           `envconfig:"REDIS_BACKFILL_BATCH_SIZE" default:"500"`
+	BackfillBatchDelay time.Duration `envconfig:"REDIS_BACKFILL_BATCH_DELAY" default:"100ms"`
+	BackfillCooldown   time.Duration `envconfig:"REDIS_BACKFILL_COOLDOWN" default:"1m"`
+
+	// Warm controla o aquecimento completo (flag -warm-cache e
+	// /api/v1/admin/cache/warm): tamanho dos lotes e pausa entre eles.
+	WarmBatchSize  int           `envconfig:"REDIS_WARM_BATCH_SIZE" default:"500"`
+	WarmBatchDelay time.Duration `envconfig:"REDIS_WARM_BATCH_DELAY" default:"100ms"`
+
+	// WriteRetries e WriteRetryBackoff são o orçamento de novas tentativas, em
+	// background, das escritas de cache de uma criação que falharem por erro
+	// transitório do Redis. Zero desliga.
+	WriteRetries      int           `envconfig:"REDIS_WRITE_RETRIES" default:"2"`
+	WriteRetryBackoff time.Duration `envconfig:"REDIS_WRITE_RETRY_BACKOFF" default:"100ms"`
+
+	// ReadyIndexCheck faz /health/ready conferir se o índice all_products
+	// está populado quando o banco tem produtos. Índice vazio marca a
+	// resposta como degraded, sem derrubar a readiness.
+	ReadyIndexCheck bool `envconfig:"REDIS_READY_INDEX_CHECK" default:"false"`
+
+	// OOMWritePause suspende as gravações de produto no cache por esse tempo
+	// depois que o Redis recusa uma escrita por maxmemory. Zero não suspende.
+	// OOMEvictPatterns lista padrões de chave (glob do SCAN) de baixa
+	// prioridade apagados a cada OOM; vazio não apaga nada.
+	OOMWritePause    time.Duration `envconfig:"REDIS_OOM_WRITE_PAUSE" default:"0"`
+	OOMEvictPatterns []string      `envconfig:"REDIS_OOM_EVICT_PATTERNS"`
+}
+
+type ReplicaEndpoint struct {
+	Addr   string
+	Weight int
+}
+
+type SpecEncryptionKey struct {
+	ID  string
+	Key []byte
 }
 
 type KeycloakConfig struct {
 	URL      string `envconfig:"KEYCLOAK_URL" default:"http://localhost:8180"`
 	Realm    string `envconfig:"KEYCLOAK_REALM" default:"product-api"`
 	ClientID string `envconfig:"KEYCLOAK_CLIENT_ID" default:"product-api-client"`
+
+	// AdminRole é o realm role exigido nas rotas /api/v1/admin.
+	AdminRole string `envconfig:"KEYCLOAK_ADMIN_ROLE" default:"admin"`
+
+	// WriteRole é o realm role exigido nas escritas de /api/v1/products.
+	WriteRole string `envconfig:"KEYCLOAK_WRITE_ROLE" default:"product-admin"`
+
+	// ExpectedTokenType é o valor exigido no header "typ" do JWT (ex.: "JWT" ou
+	// "at+jwt"). Vazio aceita qualquer tipo, inclusive tokens sem o header.
+	ExpectedTokenType string `envconfig:"KEYCLOAK_EXPECTED_TOKEN_TYPE" default:""`
 }
 
 type AppConfig struct {
 	LogLevel    string `envconfig:"LOG_LEVEL" default:"info"`
 	Environment string `envconfig:"ENVIRONMENT" default:"development"`
+
+	// LogRedactFields lista campos de log cujo valor é mascarado (****).
+	// Credenciais (authorization, token, password...) são sempre mascaradas.
+	LogRedactFields []string `envconfig:"LOG_REDACT_FIELDS"`
+
+	// SlowRequestThreshold acrescenta ao access log das requisições mais
+	// lentas que o limite o tempo gasto em cache, banco e serialização. Zero
+	// desativa.
+	SlowRequestThreshold time.Duration `envconfig:"LOG_SLOW_REQUEST_THRESHOLD" default:"0"`
+
+	// LogLevelEndpoint define o acesso a /log/level: public, admin (exige
+	// token com o admin role) ou disabled. Vazio usa admin em produção e
+	// public nos demais ambientes.
+	LogLevelEndpoint string `envconfig:"LOG_LEVEL_ENDPOINT" default:""`
+
+	// RequestIDFormat define o gerador de X-Request-ID: ulid, uuid ou nanoid.
+	RequestIDFormat string `envconfig:"REQUEST_ID_FORMAT" default:"ulid"`
+
+	// AllowedCategories restringe as categorias aceitas na criação e na
+	// atualização. Vazio aceita qualquer categoria.
+	AllowedCategories []string `envconfig:"PRODUCT_ALLOWED_CATEGORIES"`
+
+	// UniqueNamePerCategory recusa na criação um produto com o nome de outro
+	// da mesma categoria.
+	UniqueNamePerCategory bool `envconfig:"PRODUCT_UNIQUE_NAME_PER_CATEGORY" default:"false"`
+
+	// MaxSpecDepth limita o aninhamento de specifications na criação, na
+	// atualização e na importação. Zero desativa.
+	MaxSpecDepth int `envconfig:"PRODUCT_MAX_SPEC_DEPTH" default:"0"`
+
+	// IDFields lista, em ordem, os campos que derivam o ID do produto
+	// (name, reference_number, sku, brand). Mudar exige migração dos dados.
+	IDFields string `envconfig:"ID_FIELDS" default:"name,reference_number"`
+
+	// ReferenceNormalization lista os passos aplicados ao reference_number
+	// antes de derivar o ID (uppercase, alphanumeric). Vazio desativa; ligar
+	// muda os IDs derivados e exige migração dos dados.
+	ReferenceNormalization string `envconfig:"REFERENCE_NORMALIZATION" default:""`
+
+	// PriceRounding define o que fazer com um price_amount com mais casas
+	// decimais que a moeda: reject (422), half_up ou half_even.
+	PriceRounding string `envconfig:"PRICE_ROUNDING" default:"reject"`
+
+	// TombstoneResponses faz o GET de um produto excluído logicamente
+	// responder 410 com id e deleted_at a quem tem o admin role, em vez de 404.
+	TombstoneResponses bool `envconfig:"PRODUCT_TOMBSTONE_RESPONSES" default:"false"`
 }
 
 type RateLimitConfig struct {
 	Enabled           bool          `envconfig:"RATE_LIMIT_ENABLED" default:"true"`
 	RequestsPerWindow int           `envconfig:"RATE_LIMIT_REQUESTS" default:"100"`
 	WindowSize        time.Duration `envconfig:"RATE_LIMIT_WINDOW" default:"1m"`
+
+	// KeyMaxAge é o TTL renovado em cada requisição; abaixo da janela (ou
+	// zero) usa a própria janela. KeyCountInterval define a frequência da
+	// métrica rate_limit_keys; zero desativa a contagem.
+	KeyMaxAge        time.Duration `envconfig:"RATE_LIMIT_KEY_MAX_AGE" default:"0"`
+	KeyCountInterval time.Duration `envconfig:"RATE_LIMIT_KEY_COUNT_INTERVAL" default:"1m"`
+
+	// RoleTiers dá limites próprios a realm roles, no formato role:limite
+	// (ex.: premium:1000). Usuários sem nenhum desses roles e chamadas
+	// anônimas ficam com RequestsPerWindow.
+	RoleTiers []string `envconfig:"RATE_LIMIT_ROLE_TIERS"`
+
+	// Mode escolhe o algoritmo: sliding (sorted set, um membro por
+	// requisição) ou fixed (um contador por janela, bem mais barato).
+	Mode string `envconfig:"RATE_LIMIT_MODE" default:"sliding"`
+}
+
+// ImportConfig controla a validação da importação em lote. ValidateTimestamps
+// rejeita created_at no futuro (além de MaxClockSkew) e updated_at < created_at.
+type ImportConfig struct {
+	ValidateTimestamps bool          `envconfig:"IMPORT_VALIDATE_TIMESTAMPS" default:"true"`
+	MaxClockSkew       time.Duration `envconfig:"IMPORT_MAX_CLOCK_SKEW" default:"1m"`
+}
+
+// WebhookConfig ativa a publicação dos eventos de mudança (criação,
+// atualização e remoção) quando URL não é vazia.
+type WebhookConfig struct {
+	URL          string        `envconfig:"WEBHOOK_URL" default:""`
+	Timeout      time.Duration `envconfig:"WEBHOOK_TIMEOUT" default:"5s"`
+	MaxRetries   int           `envconfig:"WEBHOOK_MAX_RETRIES" default:"3"`
+	RetryBackoff time.Duration `envconfig:"WEBHOOK_RETRY_BACKOFF" default:"500ms"`
+	QueueSize    int           `envconfig:"WEBHOOK_QUEUE_SIZE" default:"1000"`
+
+	// BatchWindow agrupa os eventos da janela num único POST (array), com no
+	// máximo BatchMaxSize eventos. Zero envia um evento por requisição.
+	BatchWindow  time.Duration `envconfig:"WEBHOOK_BATCH_WINDOW" default:"0"`
+	BatchMaxSize int           `envconfig:"WEBHOOK_BATCH_MAX_SIZE" default:"100"`
+
+	// DeadLetter guarda na lista DeadLetterKey do Redis os eventos cuja entrega
+	// falhou depois das novas tentativas, para inspeção e reenvio pelas rotas
+	// admin. DeadLetterMaxLen mantém só os mais recentes; zero não limita.
+	DeadLetterEnabled bool   `envconfig:"WEBHOOK_DEAD_LETTER_ENABLED" default:"true"`
+	DeadLetterKey     string `envconfig:"WEBHOOK_DEAD_LETTER_KEY" default:"webhook_dead_letter"`
+	DeadLetterMaxLen  int    `envconfig:"WEBHOOK_DEAD_LETTER_MAX_LEN" default:"10000"`
+}
+
+// PaginationConfig define o limit padrão das listagens e o máximo aceito;
+// pedidos acima do máximo são reduzidos a ele.
+type PaginationConfig struct {
+	DefaultLimit int `envconfig:"PAGINATION_DEFAULT_LIMIT" default:"50"`
+	MaxLimit     int `envconfig:"PAGINATION_MAX_LIMIT" default:"5000"`
+}
+
+// HTTPSConfig exige TLS fora do ambiente development. Com Redirect, HTTP vai
+// para HTTPS; HSTSMaxAge zero desliga o Strict-Transport-Security.
+type HTTPSConfig struct {
+	Enforce               bool          `envconfig:"HTTPS_ENFORCE" default:"false"`
+	Redirect              bool          `envconfig:"HTTPS_REDIRECT" default:"true"`
+	HSTSMaxAge            time.Duration `envconfig:"HTTPS_HSTS_MAX_AGE" default:"8760h"`
+	HSTSIncludeSubdomains bool          `envconfig:"HTTPS_HSTS_INCLUDE_SUBDOMAINS" default:"false"`
+}
+
+// CompressionConfig define os níveis de gzip (1-9) e Brotli (1-11) e o
+// tamanho mínimo, em bytes, de uma resposta comprimida.
+type CompressionConfig struct {
+	GzipLevel   int `envconfig:"COMPRESSION_GZIP_LEVEL" default:"5"`
+	BrotliLevel int `envconfig:"COMPRESSION_BROTLI_LEVEL" default:"4"`
+	MinSize     int `envconfig:"COMPRESSION_MIN_SIZE" default:"1024"`
 }
 
 func Load() (*Config, error) {
@@ -66,9 +380,25 @@ func Load() (*Config, error) {
 	if err := envconfig.Process("", &cfg); err != nil {
 		return nil, fmt.Errorf("failed to load configuration: %w", err)
 	}
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
 	return &cfg, nil
 }
 
+// validate recusa valores que só falhariam depois da subida. Os intervalos
+// das checagens periódicas viram time.NewTicker, que entra em pânico com
+// zero ou negativo.
+func (c *Config) validate() error {
+	if c.Database.HealthInterval <= 0 {
+		return fmt.Errorf("DB_HEALTH_INTERVAL must be positive, got %s", c.Database.HealthInterval)
+	}
+	if c.Redis.ReplicaHealthInterval <= 0 {
+		return fmt.Errorf("REDIS_REPLICA_HEALTH_INTERVAL must be positive, got %s", c.Redis.ReplicaHealthInterval)
+	}
+	return nil
+}
+
 func (c *DatabaseConfig) DatabaseDSN() string {
 	return fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
@@ -76,14 +406,101 @@ func (c *DatabaseConfig) DatabaseDSN() string {
 	)
 }
 
+func (c *RateLimitConfig) RoleLimits() (map[string]int, error) {
+	limits := make(map[string]int, len(c.RoleTiers))
+	for _, raw := range c.RoleTiers {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		role, limit, found := strings.Cut(raw, ":")
+		if !found || role == "" {
+			return nil, fmt.Errorf("invalid rate limit tier %q: expected role:limit", raw)
+		}
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid limit for rate limit tier %q", raw)
+		}
+		limits[role] = parsed
+	}
+	return limits, nil
+}
+
 func (c *RedisConfig) RedisAddr() string {
 	return fmt.Sprintf("%s:%d", c.Host, c.Port)
 }
 
+func (c *RedisConfig) ReadReplicaEndpoints() ([]ReplicaEndpoint, error) {
+	endpoints := make([]ReplicaEndpoint, 0, len(c.ReadReplicas))
+	for _, raw := range c.ReadReplicas {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		endpoint := ReplicaEndpoint{Addr: raw, Weight: 1}
+		if addr, weight, found := strings.Cut(raw, "@"); found {
+			parsed, err := strconv.Atoi(weight)
+			if err != nil || parsed <= 0 {
+				return nil, fmt.Errorf("invalid weight for redis replica %q", raw)
+			}
+			endpoint.Addr = addr
+			endpoint.Weight = parsed
+		}
+
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints, nil
+}
+
+func (c *RedisConfig) SpecEncryptionKeys() ([]SpecEncryptionKey, error) {
+	keys := make([]SpecEncryptionKey, 0, len(c.EncryptionKeys))
+	for _, raw := range c.EncryptionKeys {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		id, encoded, found := strings.Cut(raw, ":")
+		if !found || id == "" {
+			return nil, errors.New("invalid redis encryption key: expected id:base64")
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redis encryption key %q: %w", id, err)
+		}
+		switch len(key) {
+		case 16, 24, 32:
+		default:
+			return nil, fmt.Errorf("invalid redis encryption key %q: must be 16, 24 or 32 bytes", id)
+		}
+
+		keys = append(keys, SpecEncryptionKey{ID: id, Key: key})
+	}
+	return keys, nil
+}
+
+func (c *AppConfig) IsDevelopment() bool {
+	return c.Environment == "development"
+}
+
 func (c *AppConfig) IsProduction() bool {
 	return c.Environment == "production"
 }
 
+// LogLevelEndpointAccess retorna LOG_LEVEL_ENDPOINT ou, se vazio, o padrão do
+// ambiente.
+func (c *AppConfig) LogLevelEndpointAccess() string {
+	if c.LogLevelEndpoint != "" {
+		return c.LogLevelEndpoint
+	}
+	if c.IsProduction() {
+		return "admin"
+	}
+	return "public"
+}
+
 func (c *KeycloakConfig) JWKSURL() string {
 	return fmt.Sprintf("%s/realms/%s/protocol/openid-connect/certs", c.URL, c.Realm)
 }
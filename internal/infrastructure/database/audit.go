@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// Ações gravadas em product_audit.action.
+const (
+	auditCreate    = "create"
+	auditUpdate    = "update"
+	auditStock     = "stock"
+	auditAddTag    = "add_tag"
+	auditRemoveTag = "remove_tag"
+	auditDelete    = "delete"
+	auditRestore   = "restore"
+)
+
+// execAudited executa o INSERT, UPDATE ou DELETE e, quando o contexto traz um
+// autor (repository.WithActor), grava uma linha em product_audit para cada
+// produto alterado no mesmo comando. Retorna o número de produtos alterados.
+func execAudited(ctx context.Context, q querier, action, query string, args ...any) (int64, error) {
+	subject := repository.ActorFromContext(ctx)
+	if subject == "" {
+		result, err := q.Exec(ctx, query, args...)
+		if err != nil {
+			return 0, err
+		}
+		return result.RowsAffected(), nil
+	}
+
+	var affected int64
+	args = append(args, action, subject)
+	if err := q.QueryRow(ctx, auditedQuery(query, len(args)-2), args...).Scan(&affected); err != nil {
+		return 0, err
+	}
+	return affected, nil
+}
+
+// auditedQuery envolve o comando numa CTE que devolve os IDs alterados e os
+// registra na trilha de auditoria. Ação e sujeito vêm logo após os argCount
+// parâmetros do comando original; sujeito vazio não grava auditoria.
+func auditedQuery(query string, argCount int) string {
+	return fmt.Sprintf(`
+		WITH changed AS (%s RETURNING id),
+		audit AS (
+			INSERT INTO product_audit (product_id, action, subject)
+			SELECT id, $%d, $%[3]d FROM changed WHERE $%[3]d <> ''
+		)
+		SELECT count(*) FROM changed
+	`, strings.TrimSpace(query), argCount+1, argCount+2)
+}
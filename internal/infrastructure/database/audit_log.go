@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresAuditRepository grava as entradas de compliance em audit_log. Ao
+// contrário de product_audit, escrita no mesmo comando da alteração e que só
+// diz quem mexeu, a gravação é separada, em lotes, e traz o diff dos campos.
+type PostgresAuditRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresAuditRepository(pool *pgxpool.Pool) *PostgresAuditRepository {
+	return &PostgresAuditRepository{pool: pool}
+}
+
+// Append grava o lote num único INSERT, desaninhando um array por coluna.
+func (r *PostgresAuditRepository) Append(ctx context.Context, entries []*entity.AuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	productIDs := make([]string, len(entries))
+	subjects := make([]string, len(entries))
+	operations := make([]string, len(entries))
+	occurredAt := make([]time.Time, len(entries))
+	changes := make([]string, len(entries))
+	for i, entry := range entries {
+		encoded, err := json.Marshal(entry.Changes)
+		if err != nil {
+			return fmt.Errorf("failed to marshal audit changes: %w", err)
+		}
+		productIDs[i] = entry.ProductID
+		subjects[i] = entry.Subject
+		operations[i] = entry.Operation
+		occurredAt[i] = entry.OccurredAt
+		changes[i] = string(encoded)
+	}
+
+	query := `
+		INSERT INTO audit_log (product_id, subject, operation, occurred_at, changes)
+		SELECT * FROM unnest($1::text[], $2::text[], $3::text[], $4::timestamptz[], $5::jsonb[])
+	`
+	if _, err := r.pool.Exec(ctx, query, productIDs, subjects, operations, occurredAt, changes); err != nil {
+		return fmt.Errorf("failed to append audit entries: %w", err)
+	}
+
+	return nil
+}
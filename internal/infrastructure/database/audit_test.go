@@ -0,0 +1,22 @@
+package database
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAuditedQuery(t *testing.T) {
+	query := auditedQuery(`
+		UPDATE products SET stock = $1 WHERE id = $2
+	`, 2)
+
+	for _, want := range []string{
+		"WITH changed AS (UPDATE products SET stock = $1 WHERE id = $2 RETURNING id)",
+		"SELECT id, $3, $4 FROM changed WHERE $4 <> ''",
+		"SELECT count(*) FROM changed",
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("Expected query to contain %q, got:\n%s", want, query)
+		}
+	}
+}
@@ -0,0 +1,421 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreaker abre após Threshold falhas consecutivas de infraestrutura e
+// recusa chamadas durante OpenTimeout. Depois disso deixa passar uma única
+// chamada de sonda: sucesso fecha o circuito, falha reabre por mais um
+// OpenTimeout.
+type CircuitBreaker struct {
+	threshold   int
+	openTimeout time.Duration
+	logger      *zap.Logger
+	now         func() time.Time
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func NewCircuitBreaker(threshold int, openTimeout time.Duration, logger *zap.Logger) *CircuitBreaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	return &CircuitBreaker{
+		threshold:   threshold,
+		openTimeout: openTimeout,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// Open indica se o circuito está recusando chamadas (aberto ou sondando).
+func (b *CircuitBreaker) Open() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state != breakerClosed
+}
+
+// allow decide se a chamada pode seguir para o banco.
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.openTimeout {
+			return repository.ErrCircuitOpen
+		}
+		b.setState(breakerHalfOpen)
+		b.probing = true
+		return nil
+	case breakerHalfOpen:
+		if b.probing {
+			return repository.ErrCircuitOpen
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// record registra o resultado de uma chamada liberada por allow.
+func (b *CircuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// Cancelamento pelo cliente não diz nada sobre o banco: só libera a sonda.
+	if errors.Is(err, context.Canceled) {
+		b.probing = false
+		return
+	}
+
+	if !isInfrastructureError(err) {
+		b.failures = 0
+		b.probing = false
+		if b.state != breakerClosed {
+			b.setState(breakerClosed)
+		}
+		return
+	}
+
+	b.failures++
+	b.probing = false
+
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		b.openedAt = b.now()
+		if b.state != breakerOpen {
+			b.setState(breakerOpen)
+		}
+	}
+}
+
+func (b *CircuitBreaker) setState(state breakerState) {
+	b.logger.Warn("database circuit breaker state changed",
+		zap.String("from", b.state.String()),
+		zap.String("to", state.String()),
+		zap.Int("consecutive_failures", b.failures),
+	)
+	b.state = state
+}
+
+// isInfrastructureError reconhece só falhas do banco: conexão recusada ou
+// perdida (erros de rede e do driver), timeout (de acquire, de contexto ou
+// statement_timeout) e os SQLSTATE de conexão, recurso ou servidor (classes
+// 08, 53, 57, 58 e XX).
+// Todo o resto, inclusive as recusas de domínio (estoque insuficiente, tags
+// demais, nome repetido, conflito de versão) e as violações de constraint,
+// prova que o banco está respondendo e não conta para abrir o circuito.
+func isInfrastructureError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, repository.ErrDatabaseConnection) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		pgconn.Timeout(err) ||
+		pgconn.SafeToRetry(err) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if len(pgErr.Code) < 2 {
+			return false
+		}
+		switch pgErr.Code[:2] {
+		case "08", "53", "57", "58", "XX":
+			return true
+		}
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// CircuitBreakerRepository decora um ProductRepository com o circuit breaker,
+// devolvendo ErrCircuitOpen sem tocar no pool enquanto o circuito estiver
+// aberto. HealthCheck não passa pelo breaker, para que /health e o monitor do
+// modo degradado continuem enxergando o estado real do banco.
+type CircuitBreakerRepository struct {
+	repository.ProductRepository
+	breaker *CircuitBreaker
+}
+
+func NewCircuitBreakerRepository(repo repository.ProductRepository, breaker *CircuitBreaker) *CircuitBreakerRepository {
+	return &CircuitBreakerRepository{
+		ProductRepository: repo,
+		breaker:           breaker,
+	}
+}
+
+func (r *CircuitBreakerRepository) call(fn func() error) error {
+	if err := r.breaker.allow(); err != nil {
+		return err
+	}
+
+	err := fn()
+	r.breaker.record(err)
+	return err
+}
+
+func (r *CircuitBreakerRepository) Create(ctx context.Context, product *entity.Product) error {
+	return r.call(func() error {
+		return r.ProductRepository.Create(ctx, product)
+	})
+}
+
+func (r *CircuitBreakerRepository) CreateBatch(ctx context.Context, products []*entity.Product) ([]error, error) {
+	var results []error
+	err := r.call(func() error {
+		var err error
+		results, err = r.ProductRepository.CreateBatch(ctx, products)
+		return err
+	})
+	return results, err
+}
+
+func (r *CircuitBreakerRepository) Update(ctx context.Context, product *entity.Product, expectedVersion int) error {
+	return r.call(func() error {
+		return r.ProductRepository.Update(ctx, product, expectedVersion)
+	})
+}
+
+func (r *CircuitBreakerRepository) UpdateStock(ctx context.Context, id string, stock int) (repository.Revision, error) {
+	var revision repository.Revision
+	err := r.call(func() error {
+		var err error
+		revision, err = r.ProductRepository.UpdateStock(ctx, id, stock)
+		return err
+	})
+	return revision, err
+}
+
+func (r *CircuitBreakerRepository) AdjustStock(ctx context.Context, id string, delta int) (int, repository.Revision, error) {
+	var stock int
+	var revision repository.Revision
+	err := r.call(func() error {
+		var err error
+		stock, revision, err = r.ProductRepository.AdjustStock(ctx, id, delta)
+		return err
+	})
+	return stock, revision, err
+}
+
+func (r *CircuitBreakerRepository) AddTag(ctx context.Context, id, tag string) (bool, repository.Revision, error) {
+	var changed bool
+	var revision repository.Revision
+	err := r.call(func() error {
+		var err error
+		changed, revision, err = r.ProductRepository.AddTag(ctx, id, tag)
+		return err
+	})
+	return changed, revision, err
+}
+
+func (r *CircuitBreakerRepository) RemoveTag(ctx context.Context, id, tag string) (bool, repository.Revision, error) {
+	var changed bool
+	var revision repository.Revision
+	err := r.call(func() error {
+		var err error
+		changed, revision, err = r.ProductRepository.RemoveTag(ctx, id, tag)
+		return err
+	})
+	return changed, revision, err
+}
+
+func (r *CircuitBreakerRepository) Delete(ctx context.Context, id string) error {
+	return r.call(func() error {
+		return r.ProductRepository.Delete(ctx, id)
+	})
+}
+
+func (r *CircuitBreakerRepository) DeleteIfVersion(ctx context.Context, id string, version int) error {
+	return r.call(func() error {
+		return r.ProductRepository.DeleteIfVersion(ctx, id, version)
+	})
+}
+
+func (r *CircuitBreakerRepository) Restore(ctx context.Context, id string) error {
+	return r.call(func() error {
+		return r.ProductRepository.Restore(ctx, id)
+	})
+}
+
+func (r *CircuitBreakerRepository) FindTombstone(ctx context.Context, id string) (*entity.Tombstone, error) {
+	var tombstone *entity.Tombstone
+	err := r.call(func() error {
+		var err error
+		tombstone, err = r.ProductRepository.FindTombstone(ctx, id)
+		return err
+	})
+	return tombstone, err
+}
+
+func (r *CircuitBreakerRepository) FindByID(ctx context.Context, id string) (*entity.Product, error) {
+	var product *entity.Product
+	err := r.call(func() error {
+		var err error
+		product, err = r.ProductRepository.FindByID(ctx, id)
+		return err
+	})
+	return product, err
+}
+
+func (r *CircuitBreakerRepository) FindByIDs(ctx context.Context, ids []string) ([]*entity.Product, error) {
+	var products []*entity.Product
+	err := r.call(func() error {
+		var err error
+		products, err = r.ProductRepository.FindByIDs(ctx, ids)
+		return err
+	})
+	return products, err
+}
+
+func (r *CircuitBreakerRepository) FindAll(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+	var products []*entity.Product
+	err := r.call(func() error {
+		var err error
+		products, err = r.ProductRepository.FindAll(ctx, filter, sort, limit, offset)
+		return err
+	})
+	return products, err
+}
+
+func (r *CircuitBreakerRepository) FindByCategory(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+	var products []*entity.Product
+	err := r.call(func() error {
+		var err error
+		products, err = r.ProductRepository.FindByCategory(ctx, category, filter, sort, limit, offset)
+		return err
+	})
+	return products, err
+}
+
+func (r *CircuitBreakerRepository) FindByTag(ctx context.Context, tag string, limit, offset int) ([]*entity.Product, error) {
+	var products []*entity.Product
+	err := r.call(func() error {
+		var err error
+		products, err = r.ProductRepository.FindByTag(ctx, tag, limit, offset)
+		return err
+	})
+	return products, err
+}
+
+func (r *CircuitBreakerRepository) FindBySKU(ctx context.Context, sku string) (*entity.Product, int, error) {
+	var product *entity.Product
+	var matches int
+	err := r.call(func() error {
+		var err error
+		product, matches, err = r.ProductRepository.FindBySKU(ctx, sku)
+		return err
+	})
+	return product, matches, err
+}
+
+func (r *CircuitBreakerRepository) FindModifiedBy(ctx context.Context, subject string, limit, offset int) ([]*entity.Product, error) {
+	var products []*entity.Product
+	err := r.call(func() error {
+		var err error
+		products, err = r.ProductRepository.FindModifiedBy(ctx, subject, limit, offset)
+		return err
+	})
+	return products, err
+}
+
+func (r *CircuitBreakerRepository) FindByName(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+	var products []*entity.Product
+	err := r.call(func() error {
+		var err error
+		products, err = r.ProductRepository.FindByName(ctx, name, filter, order, sort, limit, offset)
+		return err
+	})
+	return products, err
+}
+
+func (r *CircuitBreakerRepository) Count(ctx context.Context, filter repository.ListFilter) (int, error) {
+	var total int
+	err := r.call(func() error {
+		var err error
+		total, err = r.ProductRepository.Count(ctx, filter)
+		return err
+	})
+	return total, err
+}
+
+func (r *CircuitBreakerRepository) CountByName(ctx context.Context, name string, filter repository.ListFilter) (int, error) {
+	var total int
+	err := r.call(func() error {
+		var err error
+		total, err = r.ProductRepository.CountByName(ctx, name, filter)
+		return err
+	})
+	return total, err
+}
+
+func (r *CircuitBreakerRepository) CountByCategory(ctx context.Context, category string, filter repository.ListFilter) (int, error) {
+	var total int
+	err := r.call(func() error {
+		var err error
+		total, err = r.ProductRepository.CountByCategory(ctx, category, filter)
+		return err
+	})
+	return total, err
+}
+
+func (r *CircuitBreakerRepository) CountByTag(ctx context.Context, tag string) (int, error) {
+	var total int
+	err := r.call(func() error {
+		var err error
+		total, err = r.ProductRepository.CountByTag(ctx, tag)
+		return err
+	})
+	return total, err
+}
+
+func (r *CircuitBreakerRepository) Exists(ctx context.Context, id string) (bool, error) {
+	var exists bool
+	err := r.call(func() error {
+		var err error
+		exists, err = r.ProductRepository.Exists(ctx, id)
+		return err
+	})
+	return exists, err
+}
@@ -0,0 +1,157 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+type flakyProductRepository struct {
+	repository.ProductRepository
+	err   error
+	calls int
+}
+
+func (f *flakyProductRepository) FindByID(ctx context.Context, id string) (*entity.Product, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &entity.Product{ID: id}, nil
+}
+
+func TestCircuitBreakerRepository(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	fake := &flakyProductRepository{err: repository.ErrDatabaseConnection}
+	breaker := NewCircuitBreaker(3, 10*time.Second, zap.NewNop())
+	breaker.now = func() time.Time { return now }
+	repo := NewCircuitBreakerRepository(fake, breaker)
+
+	for i := 0; i < 3; i++ {
+		if _, err := repo.FindByID(ctx, "A"); !errors.Is(err, repository.ErrDatabaseConnection) {
+			t.Fatalf("Expected database error on call %d, got %v", i+1, err)
+		}
+	}
+
+	if !breaker.Open() {
+		t.Fatal("Expected breaker to open after consecutive failures")
+	}
+
+	if _, err := repo.FindByID(ctx, "A"); !errors.Is(err, repository.ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen while open, got %v", err)
+	}
+	if fake.calls != 3 {
+		t.Errorf("Expected open breaker to skip the database, got %d calls", fake.calls)
+	}
+
+	// Sonda falha: reabre por mais um período.
+	now = now.Add(11 * time.Second)
+	if _, err := repo.FindByID(ctx, "A"); !errors.Is(err, repository.ErrDatabaseConnection) {
+		t.Errorf("Expected probe to reach the database, got %v", err)
+	}
+	if _, err := repo.FindByID(ctx, "A"); !errors.Is(err, repository.ErrCircuitOpen) {
+		t.Errorf("Expected breaker to reopen after failed probe, got %v", err)
+	}
+
+	// Sonda bem-sucedida: fecha.
+	now = now.Add(11 * time.Second)
+	fake.err = nil
+	if _, err := repo.FindByID(ctx, "A"); err != nil {
+		t.Errorf("Expected successful probe, got %v", err)
+	}
+	if breaker.Open() {
+		t.Error("Expected breaker to close after successful probe")
+	}
+}
+
+func TestCircuitBreakerRepository_DomainErrorsDoNotCount(t *testing.T) {
+	fake := &flakyProductRepository{err: repository.ErrProductNotFound}
+	breaker := NewCircuitBreaker(1, time.Minute, zap.NewNop())
+	repo := NewCircuitBreakerRepository(fake, breaker)
+
+	for i := 0; i < 3; i++ {
+		if _, err := repo.FindByID(context.Background(), "A"); !errors.Is(err, repository.ErrProductNotFound) {
+			t.Fatalf("Expected ErrProductNotFound, got %v", err)
+		}
+	}
+
+	if breaker.Open() {
+		t.Error("Expected not-found results to keep the breaker closed")
+	}
+}
+
+func TestIsInfrastructureError(t *testing.T) {
+	domain := []error{
+		entity.ErrInsufficientStock,
+		fmt.Errorf("adjust stock: %w", entity.ErrTooManyTags),
+		entity.ErrDuplicateNameInCategory,
+		repository.ErrProductDeleted,
+		fmt.Errorf("%w: %v", repository.ErrSerializationFailure, &pgconn.PgError{Code: "40001"}),
+		context.Canceled,
+		&pgconn.PgError{Code: "23505"},
+		errors.New("unexpected"),
+	}
+	for _, err := range domain {
+		if isInfrastructureError(err) {
+			t.Errorf("Expected %v not to count as a database failure", err)
+		}
+	}
+
+	infrastructure := []error{
+		repository.ErrDatabaseConnection,
+		context.DeadlineExceeded,
+		fmt.Errorf("find product: %w", &pgconn.PgError{Code: "08006"}),
+		&pgconn.PgError{Code: "53300"},
+		&pgconn.PgError{Code: "57P01"},
+		&net.OpError{Op: "dial", Err: errors.New("connection refused")},
+		io.ErrUnexpectedEOF,
+	}
+	for _, err := range infrastructure {
+		if !isInfrastructureError(err) {
+			t.Errorf("Expected %v to count as a database failure", err)
+		}
+	}
+}
+
+func TestCircuitBreakerRepository_InsufficientStockKeepsBreakerClosed(t *testing.T) {
+	fake := &flakyProductRepository{err: entity.ErrInsufficientStock}
+	breaker := NewCircuitBreaker(5, time.Minute, zap.NewNop())
+	repo := NewCircuitBreakerRepository(fake, breaker)
+
+	for i := 0; i < 10; i++ {
+		if _, err := repo.FindByID(context.Background(), "A"); !errors.Is(err, entity.ErrInsufficientStock) {
+			t.Fatalf("Expected ErrInsufficientStock on call %d, got %v", i+1, err)
+		}
+	}
+
+	if breaker.Open() {
+		t.Error("Expected domain refusals to keep the breaker closed")
+	}
+}
+
+func TestDegradedReadRepository_CircuitOpenServesCache(t *testing.T) {
+	fake := &flakyProductRepository{err: repository.ErrDatabaseConnection}
+	breaker := NewCircuitBreaker(1, time.Minute, zap.NewNop())
+	monitor := NewHealthMonitor(fake, zap.NewNop())
+	repo := NewDegradedReadRepository(NewCircuitBreakerRepository(fake, breaker), monitor)
+	ctx := context.Background()
+
+	if _, err := repo.FindByID(ctx, "A"); !errors.Is(err, repository.ErrDatabaseConnection) {
+		t.Fatalf("Expected first failure to pass through, got %v", err)
+	}
+
+	if _, err := repo.FindByID(ctx, "A"); !errors.Is(err, repository.ErrProductNotFound) {
+		t.Errorf("Expected ErrProductNotFound while circuit is open, got %v", err)
+	}
+}
@@ -0,0 +1,358 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// HealthMonitor acompanha a saúde do banco com checagens periódicas, para que
+// o caminho de leitura saiba que está degradado sem esperar timeouts.
+type HealthMonitor struct {
+	repo    repository.ProductRepository
+	healthy atomic.Bool
+	logger  *zap.Logger
+}
+
+func NewHealthMonitor(repo repository.ProductRepository, logger *zap.Logger) *HealthMonitor {
+	m := &HealthMonitor{
+		repo:   repo,
+		logger: logger,
+	}
+	m.healthy.Store(true)
+	return m
+}
+
+// Healthy retorna o resultado da última checagem.
+func (m *HealthMonitor) Healthy() bool {
+	return m.healthy.Load()
+}
+
+// Degraded é o inverso de Healthy, no formato esperado pelo middleware.
+func (m *HealthMonitor) Degraded() bool {
+	return !m.Healthy()
+}
+
+// Check executa uma checagem e atualiza o estado.
+func (m *HealthMonitor) Check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	err := m.repo.HealthCheck(ctx)
+	healthy := err == nil
+
+	if m.healthy.Swap(healthy) == healthy {
+		return
+	}
+
+	if healthy {
+		m.logger.Info("database recovered - leaving degraded mode")
+	} else {
+		m.logger.Warn("database unhealthy - serving reads from cache only", zap.Error(err))
+	}
+}
+
+// Start executa Check periodicamente até o contexto ser cancelado.
+func (m *HealthMonitor) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.Check(ctx)
+		}
+	}
+}
+
+// DegradedReadRepository decora um ProductRepository para o modo degradado:
+// enquanto o monitor indica banco fora, leituras respondem como se nada
+// existisse (ErrProductNotFound ou lista vazia) em vez de falhar, e os casos de
+// uso passam a servir apenas o que está no cache. O mesmo vale quando o
+// circuit breaker do banco recusa a leitura. É só para os casos de uso de
+// leitura: os de escrita usam DegradedWriteRepository, que responde 503.
+type DegradedReadRepository struct {
+	repository.ProductRepository
+	monitor *HealthMonitor
+}
+
+func NewDegradedReadRepository(repo repository.ProductRepository, monitor *HealthMonitor) *DegradedReadRepository {
+	return &DegradedReadRepository{
+		ProductRepository: repo,
+		monitor:           monitor,
+	}
+}
+
+func (r *DegradedReadRepository) FindByID(ctx context.Context, id string) (*entity.Product, error) {
+	if !r.monitor.Healthy() {
+		return nil, repository.ErrProductNotFound
+	}
+	product, err := r.ProductRepository.FindByID(ctx, id)
+	if errors.Is(err, repository.ErrCircuitOpen) {
+		return nil, repository.ErrProductNotFound
+	}
+	return product, err
+}
+
+// FindTombstone responde ErrProductNotFound no modo degradado, e a leitura
+// do produto excluído sai como 404, como um ID desconhecido.
+func (r *DegradedReadRepository) FindTombstone(ctx context.Context, id string) (*entity.Tombstone, error) {
+	if !r.monitor.Healthy() {
+		return nil, repository.ErrProductNotFound
+	}
+	tombstone, err := r.ProductRepository.FindTombstone(ctx, id)
+	if errors.Is(err, repository.ErrCircuitOpen) {
+		return nil, repository.ErrProductNotFound
+	}
+	return tombstone, err
+}
+
+func (r *DegradedReadRepository) FindByIDs(ctx context.Context, ids []string) ([]*entity.Product, error) {
+	if !r.monitor.Healthy() {
+		return []*entity.Product{}, nil
+	}
+	products, err := r.ProductRepository.FindByIDs(ctx, ids)
+	if errors.Is(err, repository.ErrCircuitOpen) {
+		return []*entity.Product{}, nil
+	}
+	return products, err
+}
+
+func (r *DegradedReadRepository) FindAll(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+	if !r.monitor.Healthy() {
+		return []*entity.Product{}, nil
+	}
+	products, err := r.ProductRepository.FindAll(ctx, filter, sort, limit, offset)
+	if errors.Is(err, repository.ErrCircuitOpen) {
+		return []*entity.Product{}, nil
+	}
+	return products, err
+}
+
+func (r *DegradedReadRepository) FindByCategory(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+	if !r.monitor.Healthy() {
+		return []*entity.Product{}, nil
+	}
+	products, err := r.ProductRepository.FindByCategory(ctx, category, filter, sort, limit, offset)
+	if errors.Is(err, repository.ErrCircuitOpen) {
+		return []*entity.Product{}, nil
+	}
+	return products, err
+}
+
+func (r *DegradedReadRepository) FindByTag(ctx context.Context, tag string, limit, offset int) ([]*entity.Product, error) {
+	if !r.monitor.Healthy() {
+		return []*entity.Product{}, nil
+	}
+	products, err := r.ProductRepository.FindByTag(ctx, tag, limit, offset)
+	if errors.Is(err, repository.ErrCircuitOpen) {
+		return []*entity.Product{}, nil
+	}
+	return products, err
+}
+
+func (r *DegradedReadRepository) FindBySKU(ctx context.Context, sku string) (*entity.Product, int, error) {
+	if !r.monitor.Healthy() {
+		return nil, 0, repository.ErrProductNotFound
+	}
+	product, matches, err := r.ProductRepository.FindBySKU(ctx, sku)
+	if errors.Is(err, repository.ErrCircuitOpen) {
+		return nil, 0, repository.ErrProductNotFound
+	}
+	return product, matches, err
+}
+
+func (r *DegradedReadRepository) FindModifiedBy(ctx context.Context, subject string, limit, offset int) ([]*entity.Product, error) {
+	if !r.monitor.Healthy() {
+		return []*entity.Product{}, nil
+	}
+	products, err := r.ProductRepository.FindModifiedBy(ctx, subject, limit, offset)
+	if errors.Is(err, repository.ErrCircuitOpen) {
+		return []*entity.Product{}, nil
+	}
+	return products, err
+}
+
+func (r *DegradedReadRepository) FindByName(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+	if !r.monitor.Healthy() {
+		return []*entity.Product{}, nil
+	}
+	products, err := r.ProductRepository.FindByName(ctx, name, filter, order, sort, limit, offset)
+	if errors.Is(err, repository.ErrCircuitOpen) {
+		return []*entity.Product{}, nil
+	}
+	return products, err
+}
+
+// Count e as contagens por nome, categoria e tag não têm resposta degradada
+// honesta: um zero contradiria os produtos servidos pelo cache. Com o banco
+// fora, falham logo com ErrCircuitOpen, sem esperar o timeout.
+func (r *DegradedReadRepository) Count(ctx context.Context, filter repository.ListFilter) (int, error) {
+	if !r.monitor.Healthy() {
+		return 0, repository.ErrCircuitOpen
+	}
+	return r.ProductRepository.Count(ctx, filter)
+}
+
+func (r *DegradedReadRepository) CountByName(ctx context.Context, name string, filter repository.ListFilter) (int, error) {
+	if !r.monitor.Healthy() {
+		return 0, repository.ErrCircuitOpen
+	}
+	return r.ProductRepository.CountByName(ctx, name, filter)
+}
+
+func (r *DegradedReadRepository) CountByCategory(ctx context.Context, category string, filter repository.ListFilter) (int, error) {
+	if !r.monitor.Healthy() {
+		return 0, repository.ErrCircuitOpen
+	}
+	return r.ProductRepository.CountByCategory(ctx, category, filter)
+}
+
+func (r *DegradedReadRepository) CountByTag(ctx context.Context, tag string) (int, error) {
+	if !r.monitor.Healthy() {
+		return 0, repository.ErrCircuitOpen
+	}
+	return r.ProductRepository.CountByTag(ctx, tag)
+}
+
+func (r *DegradedReadRepository) Exists(ctx context.Context, id string) (bool, error) {
+	if !r.monitor.Healthy() {
+		return false, nil
+	}
+	exists, err := r.ProductRepository.Exists(ctx, id)
+	if errors.Is(err, repository.ErrCircuitOpen) {
+		return false, nil
+	}
+	return exists, err
+}
+
+// DegradedWriteRepository decora o ProductRepository dos casos de uso de
+// escrita. Enquanto o monitor indica banco fora, as escritas e as leituras que
+// as preparam (o produto atual antes de um PUT, a checagem de duplicidade
+// antes de criar) falham logo com ErrCircuitOpen, que vira 503. Sem ele, o
+// FindByID do DegradedReadRepository responderia "não encontrado" e um PUT
+// com o banco fora viraria 404.
+type DegradedWriteRepository struct {
+	repository.ProductRepository
+	monitor *HealthMonitor
+}
+
+func NewDegradedWriteRepository(repo repository.ProductRepository, monitor *HealthMonitor) *DegradedWriteRepository {
+	return &DegradedWriteRepository{
+		ProductRepository: repo,
+		monitor:           monitor,
+	}
+}
+
+// unavailable retorna ErrCircuitOpen enquanto o banco estiver fora.
+func (r *DegradedWriteRepository) unavailable() error {
+	if !r.monitor.Healthy() {
+		return repository.ErrCircuitOpen
+	}
+	return nil
+}
+
+func (r *DegradedWriteRepository) Create(ctx context.Context, product *entity.Product) error {
+	if err := r.unavailable(); err != nil {
+		return err
+	}
+	return r.ProductRepository.Create(ctx, product)
+}
+
+func (r *DegradedWriteRepository) CreateBatch(ctx context.Context, products []*entity.Product) ([]error, error) {
+	if err := r.unavailable(); err != nil {
+		return nil, err
+	}
+	return r.ProductRepository.CreateBatch(ctx, products)
+}
+
+func (r *DegradedWriteRepository) Update(ctx context.Context, product *entity.Product, expectedVersion int) error {
+	if err := r.unavailable(); err != nil {
+		return err
+	}
+	return r.ProductRepository.Update(ctx, product, expectedVersion)
+}
+
+func (r *DegradedWriteRepository) UpdateStock(ctx context.Context, id string, stock int) (repository.Revision, error) {
+	if err := r.unavailable(); err != nil {
+		return repository.Revision{}, err
+	}
+	return r.ProductRepository.UpdateStock(ctx, id, stock)
+}
+
+func (r *DegradedWriteRepository) AdjustStock(ctx context.Context, id string, delta int) (int, repository.Revision, error) {
+	if err := r.unavailable(); err != nil {
+		return 0, repository.Revision{}, err
+	}
+	return r.ProductRepository.AdjustStock(ctx, id, delta)
+}
+
+func (r *DegradedWriteRepository) AddTag(ctx context.Context, id, tag string) (bool, repository.Revision, error) {
+	if err := r.unavailable(); err != nil {
+		return false, repository.Revision{}, err
+	}
+	return r.ProductRepository.AddTag(ctx, id, tag)
+}
+
+func (r *DegradedWriteRepository) RemoveTag(ctx context.Context, id, tag string) (bool, repository.Revision, error) {
+	if err := r.unavailable(); err != nil {
+		return false, repository.Revision{}, err
+	}
+	return r.ProductRepository.RemoveTag(ctx, id, tag)
+}
+
+func (r *DegradedWriteRepository) Delete(ctx context.Context, id string) error {
+	if err := r.unavailable(); err != nil {
+		return err
+	}
+	return r.ProductRepository.Delete(ctx, id)
+}
+
+func (r *DegradedWriteRepository) DeleteIfVersion(ctx context.Context, id string, version int) error {
+	if err := r.unavailable(); err != nil {
+		return err
+	}
+	return r.ProductRepository.DeleteIfVersion(ctx, id, version)
+}
+
+func (r *DegradedWriteRepository) Restore(ctx context.Context, id string) error {
+	if err := r.unavailable(); err != nil {
+		return err
+	}
+	return r.ProductRepository.Restore(ctx, id)
+}
+
+func (r *DegradedWriteRepository) FindByID(ctx context.Context, id string) (*entity.Product, error) {
+	if err := r.unavailable(); err != nil {
+		return nil, err
+	}
+	return r.ProductRepository.FindByID(ctx, id)
+}
+
+func (r *DegradedWriteRepository) FindByName(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+	if err := r.unavailable(); err != nil {
+		return nil, err
+	}
+	return r.ProductRepository.FindByName(ctx, name, filter, order, sort, limit, offset)
+}
+
+func (r *DegradedWriteRepository) FindBySKU(ctx context.Context, sku string) (*entity.Product, int, error) {
+	if err := r.unavailable(); err != nil {
+		return nil, 0, err
+	}
+	return r.ProductRepository.FindBySKU(ctx, sku)
+}
+
+func (r *DegradedWriteRepository) Exists(ctx context.Context, id string) (bool, error) {
+	if err := r.unavailable(); err != nil {
+		return false, err
+	}
+	return r.ProductRepository.Exists(ctx, id)
+}
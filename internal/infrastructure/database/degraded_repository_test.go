@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+type fakeProductRepository struct {
+	repository.ProductRepository
+	healthErr    error
+	tombstoneErr error
+	findCalls    int
+}
+
+func (f *fakeProductRepository) HealthCheck(ctx context.Context) error {
+	return f.healthErr
+}
+
+func (f *fakeProductRepository) FindByID(ctx context.Context, id string) (*entity.Product, error) {
+	f.findCalls++
+	return &entity.Product{ID: id}, nil
+}
+
+func (f *fakeProductRepository) FindAll(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+	f.findCalls++
+	return []*entity.Product{{ID: "A"}}, nil
+}
+
+func (f *fakeProductRepository) FindTombstone(ctx context.Context, id string) (*entity.Tombstone, error) {
+	f.findCalls++
+	if f.tombstoneErr != nil {
+		return nil, f.tombstoneErr
+	}
+	return &entity.Tombstone{ID: id}, nil
+}
+
+func TestDegradedReadRepository(t *testing.T) {
+	fake := &fakeProductRepository{}
+	monitor := NewHealthMonitor(fake, zap.NewNop())
+	repo := NewDegradedReadRepository(fake, monitor)
+	ctx := context.Background()
+
+	if _, err := repo.FindByID(ctx, "A"); err != nil {
+		t.Fatalf("Expected healthy read to pass through, got %v", err)
+	}
+
+	fake.healthErr = repository.ErrDatabaseConnection
+	monitor.Check(ctx)
+
+	if !monitor.Degraded() {
+		t.Fatal("Expected monitor to report degraded after failed check")
+	}
+
+	callsBefore := fake.findCalls
+
+	if _, err := repo.FindByID(ctx, "A"); !errors.Is(err, repository.ErrProductNotFound) {
+		t.Errorf("Expected ErrProductNotFound in degraded mode, got %v", err)
+	}
+
+	products, err := repo.FindAll(ctx, repository.ListFilter{}, repository.SortOptions{}, 10, 0)
+	if err != nil || len(products) != 0 {
+		t.Errorf("Expected empty list in degraded mode, got %d products, err %v", len(products), err)
+	}
+
+	if _, err := repo.FindTombstone(ctx, "A"); !errors.Is(err, repository.ErrProductNotFound) {
+		t.Errorf("Expected tombstone lookup to report not found in degraded mode, got %v", err)
+	}
+
+	if _, err := repo.Count(ctx, repository.ListFilter{}); !errors.Is(err, repository.ErrCircuitOpen) {
+		t.Errorf("Expected count to fail fast in degraded mode, got %v", err)
+	}
+
+	if fake.findCalls != callsBefore {
+		t.Error("Expected degraded reads not to reach the database")
+	}
+
+	fake.healthErr = nil
+	monitor.Check(ctx)
+
+	if _, err := repo.FindByID(ctx, "A"); err != nil {
+		t.Errorf("Expected reads to resume after recovery, got %v", err)
+	}
+}
+
+func TestDegradedReadRepository_FindTombstoneCircuitOpen(t *testing.T) {
+	fake := &fakeProductRepository{}
+	repo := NewDegradedReadRepository(fake, NewHealthMonitor(fake, zap.NewNop()))
+	ctx := context.Background()
+
+	tombstone, err := repo.FindTombstone(ctx, "A")
+	if err != nil || tombstone.ID != "A" {
+		t.Fatalf("Expected healthy tombstone lookup to pass through, got %v, %v", tombstone, err)
+	}
+
+	fake.tombstoneErr = repository.ErrCircuitOpen
+	if _, err := repo.FindTombstone(ctx, "A"); !errors.Is(err, repository.ErrProductNotFound) {
+		t.Errorf("Expected an open circuit to report not found, got %v", err)
+	}
+}
+
+func TestDegradedWriteRepository(t *testing.T) {
+	fake := &fakeProductRepository{}
+	monitor := NewHealthMonitor(fake, zap.NewNop())
+	repo := NewDegradedWriteRepository(fake, monitor)
+	ctx := context.Background()
+
+	if _, err := repo.FindByID(ctx, "A"); err != nil {
+		t.Fatalf("Expected healthy read to pass through, got %v", err)
+	}
+
+	fake.healthErr = repository.ErrDatabaseConnection
+	monitor.Check(ctx)
+	callsBefore := fake.findCalls
+
+	if _, err := repo.FindByID(ctx, "A"); !errors.Is(err, repository.ErrCircuitOpen) {
+		t.Errorf("Expected the read before a write to fail with ErrCircuitOpen, got %v", err)
+	}
+	if _, err := repo.UpdateStock(ctx, "A", 5); !errors.Is(err, repository.ErrCircuitOpen) {
+		t.Errorf("Expected writes to fail with ErrCircuitOpen, got %v", err)
+	}
+	if fake.findCalls != callsBefore {
+		t.Error("Expected degraded writes not to reach the database")
+	}
+}
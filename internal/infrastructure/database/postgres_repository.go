@@ -10,39 +10,99 @@ import (
 	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
 	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// serializationFailureCode é o SQLSTATE de conflito de serialização.
+const serializationFailureCode = "40001"
+
+// uniqueViolationCode é o SQLSTATE de violação de unicidade.
+const uniqueViolationCode = "23505"
+
+// nameCategoryIndex é o índice único opcional de nome por categoria (ver
+// README); uma violação dele vira entity.ErrDuplicateNameInCategory.
+const nameCategoryIndex = "idx_products_name_category_unique"
+
+// PostgresOptions ajusta o comportamento do repositório.
+//
+// StockIsolation, quando definido, faz as escritas que alteram estoque rodarem
+// numa transação com esse nível de isolamento (pgx.RepeatableRead ou
+// pgx.Serializable). Vazio mantém o padrão do banco (READ COMMITTED) sem
+// transação explícita.
+type PostgresOptions struct {
+	StockIsolation pgx.TxIsoLevel
+}
+
 type PostgresProductRepository struct {
-	pool *pgxpool.Pool
+	pool    *pgxpool.Pool
+	options PostgresOptions
 }
 
 func NewPostgresProductRepository(pool *pgxpool.Pool) *PostgresProductRepository {
+	return NewPostgresProductRepositoryWithOptions(pool, PostgresOptions{})
+}
+
+func NewPostgresProductRepositoryWithOptions(pool *pgxpool.Pool, options PostgresOptions) *PostgresProductRepository {
 	return &PostgresProductRepository{
-		pool: pool,
+		pool:    pool,
+		options: options,
 	}
 }
 
-func (r *PostgresProductRepository) Create(ctx context.Context, product *entity.Product) error {
-	query := `
+// ParseIsolationLevel converte o nome configurado para um nível do pgx.
+func ParseIsolationLevel(level string) (pgx.TxIsoLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "", "read_committed":
+		return "", nil
+	case "repeatable_read":
+		return pgx.RepeatableRead, nil
+	case "serializable":
+		return pgx.Serializable, nil
+	default:
+		return "", fmt.Errorf("unknown isolation level %q (valid: read_committed, repeatable_read, serializable)", level)
+	}
+}
+
+// isSerializationFailure verifica se o erro é um conflito de serialização do PostgreSQL.
+func isSerializationFailure(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == serializationFailureCode
+}
+
+// isUniqueViolationOf verifica se o erro é uma violação do índice único informado.
+func isUniqueViolationOf(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode && pgErr.ConstraintName == constraint
+}
+
+// insertProductQuery grava um produto novo; os argumentos vêm de insertArgs.
+const insertProductQuery = `
 		INSERT INTO products (
 			id, name, reference_number, category, description,
-			sku, brand, stock, images, specifications,
+			sku, brand, stock, price, currency, images, specifications,
+			thumbnail_url, tags, weight_grams, length_mm, width_mm, height_mm,
 			version, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
 	`
 
+func insertArgs(product *entity.Product) ([]any, error) {
 	imagesJSON, err := json.Marshal(product.Images)
 	if err != nil {
-		return fmt.Errorf("failed to marshal images: %w", err)
+		return nil, fmt.Errorf("failed to marshal images: %w", err)
 	}
 
 	specsJSON, err := json.Marshal(product.Specifications)
 	if err != nil {
-		return fmt.Errorf("failed to marshal specifications: %w", err)
+		return nil, fmt.Errorf("failed to marshal specifications: %w", err)
+	}
+
+	tagsJSON, err := marshalTags(product.Tags)
+	if err != nil {
+		return nil, err
 	}
 
-	_, err = r.pool.Exec(ctx, query,
+	return []any{
 		product.ID,
 		product.Name,
 		product.ReferenceNumber,
@@ -51,16 +111,35 @@ func (r *PostgresProductRepository) Create(ctx context.Context, product *entity.
 		product.SKU,
 		product.Brand,
 		product.Stock,
+		product.Price,
+		product.Currency,
 		imagesJSON,
 		specsJSON,
+		product.ThumbnailURL,
+		tagsJSON,
+		product.WeightGrams,
+		product.Dimensions.LengthMM,
+		product.Dimensions.WidthMM,
+		product.Dimensions.HeightMM,
 		product.Version,
 		product.CreatedAt,
 		product.UpdatedAt,
-	)
+	}, nil
+}
+
+func (r *PostgresProductRepository) Create(ctx context.Context, product *entity.Product) error {
+	args, err := insertArgs(product)
+	if err != nil {
+		return err
+	}
 
+	_, err = execAudited(ctx, r.pool, auditCreate, insertProductQuery, args...)
 	if err != nil {
+		if isUniqueViolationOf(err, nameCategoryIndex) {
+			return entity.ErrDuplicateNameInCategory
+		}
 		if strings.Contains(err.Error(), "duplicate key") {
-			return repository.ErrProductAlreadyExists
+			return r.classifyDuplicate(ctx, product.ID)
 		}
 		return fmt.Errorf("failed to create product: %w", err)
 	}
@@ -68,14 +147,181 @@ func (r *PostgresProductRepository) Create(ctx context.Context, product *entity.
 	return nil
 }
 
+// classifyDuplicate separa a colisão com um produto ativo da colisão com um
+// produto excluído logicamente, que continua ocupando o ID.
+func (r *PostgresProductRepository) classifyDuplicate(ctx context.Context, id string) error {
+	var deleted bool
+	err := r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM products WHERE id = $1 AND deleted_at IS NOT NULL)`, id).Scan(&deleted)
+	if err != nil {
+		return fmt.Errorf("failed to classify duplicate product: %w", err)
+	}
+	if deleted {
+		return repository.ErrProductDeleted
+	}
+	return repository.ErrProductAlreadyExists
+}
+
+// CreateBatch envia todos os INSERTs num único pgx.Batch (uma ida ao banco).
+// O lote roda numa transação implícita, então cada INSERT usa ON CONFLICT DO
+// NOTHING para que um duplicado não aborte os demais; os conflitos são
+// classificados depois, já com o lote gravado.
+func (r *PostgresProductRepository) CreateBatch(ctx context.Context, products []*entity.Product) ([]error, error) {
+	results := make([]error, len(products))
+	subject := repository.ActorFromContext(ctx)
+
+	batch := &pgx.Batch{}
+	queued := make([]int, 0, len(products))
+	for i, product := range products {
+		query, args, err := createBatchStatement(product, subject)
+		if err != nil {
+			results[i] = err
+			continue
+		}
+		batch.Queue(query, args...)
+		queued = append(queued, i)
+	}
+	if len(queued) == 0 {
+		return results, nil
+	}
+
+	conflicts, err := r.sendCreateBatch(ctx, batch, queued)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range conflicts {
+		var exists, deleted bool
+		err := r.pool.QueryRow(ctx,
+			`SELECT EXISTS(SELECT 1 FROM products WHERE id = $1 OR reference_number = $2),
+			        EXISTS(SELECT 1 FROM products WHERE id = $1 AND deleted_at IS NOT NULL)`,
+			products[i].ID, products[i].ReferenceNumber,
+		).Scan(&exists, &deleted)
+		switch {
+		case err != nil:
+			return nil, fmt.Errorf("failed to classify batch conflict: %w", err)
+		case deleted:
+			results[i] = repository.ErrProductDeleted
+		case exists:
+			results[i] = repository.ErrProductAlreadyExists
+		default:
+			results[i] = entity.ErrDuplicateNameInCategory
+		}
+	}
+
+	return results, nil
+}
+
+// createBatchStatement monta o INSERT auditado de um item do lote. Ação e
+// sujeito entram depois dos argumentos de insertArgs, então a posição deles
+// acompanha o número de colunas.
+func createBatchStatement(product *entity.Product, subject string) (string, []any, error) {
+	args, err := insertArgs(product)
+	if err != nil {
+		return "", nil, err
+	}
+
+	query := auditedQuery(strings.TrimSpace(insertProductQuery)+" ON CONFLICT DO NOTHING", len(args))
+	return query, append(args, auditCreate, subject), nil
+}
+
+// sendCreateBatch executa o lote e retorna os índices que não inseriram nada
+// por conflito.
+func (r *PostgresProductRepository) sendCreateBatch(ctx context.Context, batch *pgx.Batch, queued []int) ([]int, error) {
+	br := r.pool.SendBatch(ctx, batch)
+	defer br.Close()
+
+	var conflicts []int
+	for _, i := range queued {
+		var inserted int64
+		if err := br.QueryRow().Scan(&inserted); err != nil {
+			return nil, fmt.Errorf("failed to create products in batch: %w", err)
+		}
+		if inserted == 0 {
+			conflicts = append(conflicts, i)
+		}
+	}
+
+	if err := br.Close(); err != nil {
+		return nil, fmt.Errorf("failed to create products in batch: %w", err)
+	}
+	return conflicts, nil
+}
+
 func (r *PostgresProductRepository) Update(ctx context.Context, product *entity.Product, expectedVersion int) error {
+	return r.inStockTx(ctx, "update", func(q querier) error {
+		return r.update(ctx, q, product, expectedVersion)
+	})
+}
+
+// inStockTx roda uma escrita que altera estoque numa transação com
+// StockIsolation. Sem isolamento configurado, fn roda direto no pool. Um
+// conflito de serialização no commit vira repository.ErrSerializationFailure.
+func (r *PostgresProductRepository) inStockTx(ctx context.Context, operation string, fn func(q querier) error) error {
+	if r.options.StockIsolation == "" {
+		return fn(r.pool)
+	}
+
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: r.options.StockIsolation})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		if isSerializationFailure(err) {
+			return fmt.Errorf("%w: %v", repository.ErrSerializationFailure, err)
+		}
+		return fmt.Errorf("failed to commit %s: %w", operation, err)
+	}
+
+	return nil
+}
+
+// marshalTags grava produtos sem tags como array vazio, nunca null.
+func marshalTags(tags []string) ([]byte, error) {
+	if tags == nil {
+		tags = []string{}
+	}
+	data, err := json.Marshal(tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tags: %w", err)
+	}
+	return data, nil
+}
+
+func unmarshalTags(data []byte, product *entity.Product) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, &product.Tags); err != nil {
+		return fmt.Errorf("failed to unmarshal tags: %w", err)
+	}
+	if len(product.Tags) == 0 {
+		product.Tags = nil
+	}
+	return nil
+}
+
+// querier é o subconjunto comum entre *pgxpool.Pool e pgx.Tx.
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func (r *PostgresProductRepository) update(ctx context.Context, q querier, product *entity.Product, expectedVersion int) error {
 	query := `
 		UPDATE products
 		SET name = $1, category = $2, description = $3,
 		    sku = $4, brand = $5, stock = $6,
-		    images = $7, specifications = $8,
-		    version = $9, updated_at = $10
-		WHERE id = $11 AND version = $12
+		    price = $7, currency = $8,
+		    images = $9, specifications = $10, thumbnail_url = $11,
+		    tags = $12, weight_grams = $13, length_mm = $14, width_mm = $15,
+		    height_mm = $16, version = $17, updated_at = $18
+		WHERE id = $19 AND version = $20 AND deleted_at IS NULL
 	`
 
 	imagesJSON, err := json.Marshal(product.Images)
@@ -88,15 +334,28 @@ func (r *PostgresProductRepository) Update(ctx context.Context, product *entity.
 		return fmt.Errorf("failed to marshal specifications: %w", err)
 	}
 
-	result, err := r.pool.Exec(ctx, query,
+	tagsJSON, err := marshalTags(product.Tags)
+	if err != nil {
+		return err
+	}
+
+	affected, err := execAudited(ctx, q, auditUpdate, query,
 		product.Name,
 		product.Category,
 		product.Description,
 		product.SKU,
 		product.Brand,
 		product.Stock,
+		product.Price,
+		product.Currency,
 		imagesJSON,
 		specsJSON,
+		product.ThumbnailURL,
+		tagsJSON,
+		product.WeightGrams,
+		product.Dimensions.LengthMM,
+		product.Dimensions.WidthMM,
+		product.Dimensions.HeightMM,
 		product.Version,
 		product.UpdatedAt,
 		product.ID,
@@ -104,13 +363,17 @@ func (r *PostgresProductRepository) Update(ctx context.Context, product *entity.
 	)
 
 	if err != nil {
+		if isSerializationFailure(err) {
+			return fmt.Errorf("%w: %v", repository.ErrSerializationFailure, err)
+		}
 		return fmt.Errorf("failed to update product: %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
-		exists, err := r.Exists(ctx, product.ID)
+	if affected == 0 {
+		var exists bool
+		err := q.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM products WHERE id = $1 AND deleted_at IS NULL)`, product.ID).Scan(&exists)
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to check product existence: %w", err)
 		}
 		if !exists {
 			return repository.ErrProductNotFound
@@ -121,32 +384,287 @@ func (r *PostgresProductRepository) Update(ctx context.Context, product *entity.
 	return nil
 }
 
+// UpdateStock grava o estoque e a trilha de auditoria no mesmo comando,
+// incrementando a versão como qualquer outra escrita. Com StockIsolation, o
+// comando roda numa transação com esse isolamento.
+func (r *PostgresProductRepository) UpdateStock(ctx context.Context, id string, stock int) (repository.Revision, error) {
+	query := `
+		WITH changed AS (
+			UPDATE products SET stock = $1, version = version + 1, updated_at = now()
+			WHERE id = $2 AND deleted_at IS NULL
+			RETURNING id, version, updated_at
+		),
+		audit AS (
+			INSERT INTO product_audit (product_id, action, subject)
+			SELECT id, $3, $4 FROM changed WHERE $4 <> ''
+		)
+		SELECT version, updated_at FROM changed
+	`
+
+	var revision repository.Revision
+	err := r.inStockTx(ctx, "stock update", func(q querier) error {
+		err := q.QueryRow(ctx, query, stock, id, auditStock, repository.ActorFromContext(ctx)).Scan(&revision.Version, &revision.UpdatedAt)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return repository.ErrProductNotFound
+			}
+			if isSerializationFailure(err) {
+				return fmt.Errorf("%w: %v", repository.ErrSerializationFailure, err)
+			}
+			return fmt.Errorf("failed to update stock: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return repository.Revision{}, err
+	}
+
+	return revision, nil
+}
+
+// AdjustStock aplica o delta, incrementa a versão e grava a trilha de
+// auditoria no mesmo comando; a condição stock + delta >= 0 no WHERE torna a
+// verificação atômica. Com StockIsolation, o comando e a leitura que explica
+// uma recusa rodam na mesma transação.
+func (r *PostgresProductRepository) AdjustStock(ctx context.Context, id string, delta int) (int, repository.Revision, error) {
+	query := `
+		WITH changed AS (
+			UPDATE products SET stock = stock + $2, version = version + 1, updated_at = now()
+			WHERE id = $1 AND deleted_at IS NULL AND stock + $2 >= 0
+			RETURNING id, stock, version, updated_at
+		),
+		audit AS (
+			INSERT INTO product_audit (product_id, action, subject)
+			SELECT id, $3, $4 FROM changed WHERE $4 <> ''
+		)
+		SELECT stock, version, updated_at FROM changed
+	`
+
+	var stock int
+	var revision repository.Revision
+	err := r.inStockTx(ctx, "stock adjustment", func(q querier) error {
+		err := q.QueryRow(ctx, query, id, delta, auditStock, repository.ActorFromContext(ctx)).
+			Scan(&stock, &revision.Version, &revision.UpdatedAt)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			if isSerializationFailure(err) {
+				return fmt.Errorf("%w: %v", repository.ErrSerializationFailure, err)
+			}
+			return fmt.Errorf("failed to adjust stock: %w", err)
+		}
+
+		err = q.QueryRow(ctx, `SELECT stock FROM products WHERE id = $1 AND deleted_at IS NULL`, id).Scan(&stock)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return repository.ErrProductNotFound
+			}
+			return fmt.Errorf("failed to read stock: %w", err)
+		}
+		return entity.ErrInsufficientStock
+	})
+	if err != nil {
+		if errors.Is(err, entity.ErrInsufficientStock) {
+			return stock, repository.Revision{}, err
+		}
+		return 0, repository.Revision{}, err
+	}
+
+	return stock, revision, nil
+}
+
+// AddTag acrescenta a tag num único UPDATE, mantendo a lista ordenada byte a
+// byte (COLLATE "C") como entity.Product.SetTags, e incrementa a versão. Quando
+// nenhuma linha muda, a consulta seguinte separa produto inexistente, tag já
+// presente e limite de tags atingido.
+func (r *PostgresProductRepository) AddTag(ctx context.Context, id, tag string) (bool, repository.Revision, error) {
+	query := `
+		WITH changed AS (
+			UPDATE products
+			SET tags = (
+				SELECT jsonb_agg(t ORDER BY t COLLATE "C")
+				FROM (SELECT jsonb_array_elements_text(tags) UNION SELECT $2::text) AS s(t)
+			),
+			    version = version + 1,
+			    updated_at = now()
+			WHERE id = $1 AND deleted_at IS NULL
+			  AND NOT tags @> jsonb_build_array($2::text)
+			  AND jsonb_array_length(tags) < $3
+			RETURNING id, version, updated_at
+		),
+		audit AS (
+			INSERT INTO product_audit (product_id, action, subject)
+			SELECT id, $4, $5 FROM changed WHERE $5 <> ''
+		)
+		SELECT version, updated_at FROM changed
+	`
+
+	var revision repository.Revision
+	err := r.pool.QueryRow(ctx, query, id, tag, entity.MaxTags, auditAddTag, repository.ActorFromContext(ctx)).Scan(&revision.Version, &revision.UpdatedAt)
+	if err == nil {
+		return true, revision, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		if isSerializationFailure(err) {
+			return false, repository.Revision{}, fmt.Errorf("%w: %v", repository.ErrSerializationFailure, err)
+		}
+		return false, repository.Revision{}, fmt.Errorf("failed to add tag: %w", err)
+	}
+
+	var hasTag bool
+	err = r.pool.QueryRow(ctx, `SELECT tags @> jsonb_build_array($2::text) FROM products WHERE id = $1 AND deleted_at IS NULL`, id, tag).Scan(&hasTag)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, repository.Revision{}, repository.ErrProductNotFound
+		}
+		return false, repository.Revision{}, fmt.Errorf("failed to check product tags: %w", err)
+	}
+	if !hasTag {
+		return false, repository.Revision{}, entity.ErrTooManyTags
+	}
+
+	return false, repository.Revision{}, nil
+}
+
+// RemoveTag retira a tag num único UPDATE que também incrementa a versão;
+// remover uma tag ausente não é erro nem muda a versão.
+func (r *PostgresProductRepository) RemoveTag(ctx context.Context, id, tag string) (bool, repository.Revision, error) {
+	query := `
+		WITH changed AS (
+			UPDATE products
+			SET tags = tags - $2::text, version = version + 1, updated_at = now()
+			WHERE id = $1 AND deleted_at IS NULL AND tags @> jsonb_build_array($2::text)
+			RETURNING id, version, updated_at
+		),
+		audit AS (
+			INSERT INTO product_audit (product_id, action, subject)
+			SELECT id, $3, $4 FROM changed WHERE $4 <> ''
+		)
+		SELECT version, updated_at FROM changed
+	`
+
+	var revision repository.Revision
+	err := r.pool.QueryRow(ctx, query, id, tag, auditRemoveTag, repository.ActorFromContext(ctx)).Scan(&revision.Version, &revision.UpdatedAt)
+	if err == nil {
+		return true, revision, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		if isSerializationFailure(err) {
+			return false, repository.Revision{}, fmt.Errorf("%w: %v", repository.ErrSerializationFailure, err)
+		}
+		return false, repository.Revision{}, fmt.Errorf("failed to remove tag: %w", err)
+	}
+
+	var exists bool
+	err = r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM products WHERE id = $1 AND deleted_at IS NULL)`, id).Scan(&exists)
+	if err != nil {
+		return false, repository.Revision{}, fmt.Errorf("failed to check product existence: %w", err)
+	}
+	if !exists {
+		return false, repository.Revision{}, repository.ErrProductNotFound
+	}
+
+	return false, repository.Revision{}, nil
+}
+
+// Delete é uma exclusão lógica: marca deleted_at e mantém a linha, que some
+// das leituras mas pode voltar com Restore.
 func (r *PostgresProductRepository) Delete(ctx context.Context, id string) error {
-	query := `DELETE FROM products WHERE id = $1`
+	query := `UPDATE products SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL`
+
+	affected, err := execAudited(ctx, r.pool, auditDelete, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete product: %w", err)
+	}
+
+	if affected == 0 {
+		return repository.ErrProductNotFound
+	}
+
+	return nil
+}
 
-	result, err := r.pool.Exec(ctx, query, id)
+// DeleteIfVersion compara a versão no próprio UPDATE, para que uma escrita
+// concorrente entre a leitura do cliente e a exclusão não passe despercebida.
+func (r *PostgresProductRepository) DeleteIfVersion(ctx context.Context, id string, version int) error {
+	query := `UPDATE products SET deleted_at = now() WHERE id = $1 AND version = $2 AND deleted_at IS NULL`
+
+	affected, err := execAudited(ctx, r.pool, auditDelete, query, id, version)
 	if err != nil {
 		return fmt.Errorf("failed to delete product: %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
+	if affected == 0 {
+		var exists bool
+		err := r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM products WHERE id = $1 AND deleted_at IS NULL)`, id).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("failed to check product existence: %w", err)
+		}
+		if !exists {
+			return repository.ErrProductNotFound
+		}
+		return repository.ErrVersionConflict
+	}
+
+	return nil
+}
+
+// Restore limpa deleted_at. Restaurar um produto ativo não é erro.
+func (r *PostgresProductRepository) Restore(ctx context.Context, id string) error {
+	query := `UPDATE products SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	affected, err := execAudited(ctx, r.pool, auditRestore, query, id)
+	if err != nil {
+		if isUniqueViolationOf(err, nameCategoryIndex) {
+			return entity.ErrDuplicateNameInCategory
+		}
+		return fmt.Errorf("failed to restore product: %w", err)
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	var exists bool
+	err = r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM products WHERE id = $1)`, id).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check product existence: %w", err)
+	}
+	if !exists {
 		return repository.ErrProductNotFound
 	}
 
 	return nil
 }
 
+func (r *PostgresProductRepository) FindTombstone(ctx context.Context, id string) (*entity.Tombstone, error) {
+	query := `SELECT id, deleted_at FROM products WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	var tombstone entity.Tombstone
+	err := r.pool.QueryRow(ctx, query, id).Scan(&tombstone.ID, &tombstone.DeletedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to find tombstone: %w", err)
+	}
+
+	return &tombstone, nil
+}
+
 func (r *PostgresProductRepository) FindByID(ctx context.Context, id string) (*entity.Product, error) {
 	query := `
 		SELECT id, name, reference_number, category, description,
-		       sku, brand, stock, images, specifications,
+		       sku, brand, stock, price, COALESCE(currency, ''), images, specifications,
+		       COALESCE(thumbnail_url, ''), COALESCE(tags, '[]'::jsonb),
+		       weight_grams, length_mm, width_mm, height_mm,
 		       version, created_at, updated_at
 		FROM products
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	var product entity.Product
-	var imagesJSON, specsJSON []byte
+	var imagesJSON, specsJSON, tagsJSON []byte
 
 	err := r.pool.QueryRow(ctx, query, id).Scan(
 		&product.ID,
@@ -157,8 +675,16 @@ func (r *PostgresProductRepository) FindByID(ctx context.Context, id string) (*e
 		&product.SKU,
 		&product.Brand,
 		&product.Stock,
+		&product.Price,
+		&product.Currency,
 		&imagesJSON,
 		&specsJSON,
+		&product.ThumbnailURL,
+		&tagsJSON,
+		&product.WeightGrams,
+		&product.Dimensions.LengthMM,
+		&product.Dimensions.WidthMM,
+		&product.Dimensions.HeightMM,
 		&product.Version,
 		&product.CreatedAt,
 		&product.UpdatedAt,
@@ -179,20 +705,128 @@ func (r *PostgresProductRepository) FindByID(ctx context.Context, id string) (*e
 		return nil, fmt.Errorf("failed to unmarshal specifications: %w", err)
 	}
 
+	if err := unmarshalTags(tagsJSON, &product); err != nil {
+		return nil, err
+	}
+
 	return &product, nil
 }
 
-func (r *PostgresProductRepository) FindAll(ctx context.Context, limit, offset int) ([]*entity.Product, error) {
+func (r *PostgresProductRepository) FindByIDs(ctx context.Context, ids []string) ([]*entity.Product, error) {
 	query := `
 		SELECT id, name, reference_number, category, description,
-		       sku, brand, stock, images, specifications,
+		       sku, brand, stock, price, COALESCE(currency, ''), images, specifications,
+		       COALESCE(thumbnail_url, ''), COALESCE(tags, '[]'::jsonb),
+		       weight_grams, length_mm, width_mm, height_mm,
 		       version, created_at, updated_at
 		FROM products
-		ORDER BY created_at DESC
+		WHERE id = ANY($1) AND deleted_at IS NULL
+	`
+
+	rows, err := r.pool.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find products by ids: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanProducts(rows)
+}
+
+// sortColumns mapeia os campos de ordenação para colunas fixas; o ORDER BY é
+// montado só a partir desta tabela, nunca com o valor vindo da requisição.
+var sortColumns = map[repository.SortField]string{
+	repository.SortByName:      "name",
+	repository.SortByCreatedAt: "created_at",
+	repository.SortByUpdatedAt: "updated_at",
+	repository.SortByStock:     "stock",
+}
+
+// orderBy monta a lista do ORDER BY para sort, com os padrões da consulta.
+// O id desempata linhas iguais, para a paginação por offset ser estável.
+func orderBy(sort repository.SortOptions, field repository.SortField, direction repository.SortDirection) string {
+	sort = sort.WithDefaults(field, direction)
+
+	column, ok := sortColumns[sort.Field]
+	if !ok {
+		column = sortColumns[field]
+	}
+
+	dir := "ASC"
+	if sort.Direction == repository.SortDesc {
+		dir = "DESC"
+	}
+
+	return column + " " + dir + ", id " + dir
+}
+
+// filterConditions traduz o ListFilter em trechos do WHERE. Os limites das
+// faixas entram como parâmetros, numerados depois dos que já estão em args.
+func filterConditions(filter repository.ListFilter, args []any) (string, []any) {
+	var conditions strings.Builder
+	if filter.InStock != nil {
+		if *filter.InStock {
+			conditions.WriteString(" AND stock > 0")
+		} else {
+			conditions.WriteString(" AND stock = 0")
+		}
+	}
+
+	switch {
+	case filter.MinPrice != nil && filter.MaxPrice != nil:
+		args = append(args, *filter.MinPrice, *filter.MaxPrice)
+		fmt.Fprintf(&conditions, " AND price BETWEEN $%d AND $%d", len(args)-1, len(args))
+	case filter.MinPrice != nil:
+		args = append(args, *filter.MinPrice)
+		fmt.Fprintf(&conditions, " AND price >= $%d", len(args))
+	case filter.MaxPrice != nil:
+		args = append(args, *filter.MaxPrice)
+		fmt.Fprintf(&conditions, " AND price <= $%d", len(args))
+	}
+
+	if filter.MinWeight != nil || filter.MaxWeight != nil {
+		conditions.WriteString(" AND weight_grams > 0")
+		args = rangeCondition(&conditions, "weight_grams", filter.MinWeight, filter.MaxWeight, 1, args)
+	}
+	if filter.MinVolume != nil || filter.MaxVolume != nil {
+		conditions.WriteString(" AND length_mm > 0 AND width_mm > 0 AND height_mm > 0")
+		args = rangeCondition(&conditions, volumeExpression, filter.MinVolume, filter.MaxVolume, 1000, args)
+	}
+
+	return conditions.String(), args
+}
+
+// volumeExpression calcula o volume em mm³ sem estourar o INTEGER das medidas.
+const volumeExpression = "(length_mm::bigint * width_mm * height_mm)"
+
+// rangeCondition acrescenta os limites informados de column, multiplicados por
+// scale para a unidade da coluna.
+func rangeCondition(conditions *strings.Builder, column string, low, high *int64, scale int64, args []any) []any {
+	if low != nil {
+		args = append(args, *low*scale)
+		fmt.Fprintf(conditions, " AND %s >= $%d", column, len(args))
+	}
+	if high != nil {
+		args = append(args, *high*scale)
+		fmt.Fprintf(conditions, " AND %s <= $%d", column, len(args))
+	}
+	return args
+}
+
+func (r *PostgresProductRepository) FindAll(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+	conditions, args := filterConditions(filter, []any{limit, offset})
+	query := `
+		SELECT id, name, reference_number, category, description,
+		       sku, brand, stock, price, COALESCE(currency, ''), images, specifications,
+		       COALESCE(thumbnail_url, ''), COALESCE(tags, '[]'::jsonb),
+		       weight_grams, length_mm, width_mm, height_mm,
+		       version, created_at, updated_at
+		FROM products
+		WHERE deleted_at IS NULL` + conditions + `
+		ORDER BY ` + orderBy(sort, repository.SortByCreatedAt, repository.SortDesc) + `
 		LIMIT $1 OFFSET $2
 	`
 
-	rows, err := r.pool.Query(ctx, query, limit, offset)
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find all products: %w", err)
 	}
@@ -201,18 +835,21 @@ func (r *PostgresProductRepository) FindAll(ctx context.Context, limit, offset i
 	return r.scanProducts(rows)
 }
 
-func (r *PostgresProductRepository) FindByCategory(ctx context.Context, category string, limit, offset int) ([]*entity.Product, error) {
+func (r *PostgresProductRepository) FindByCategory(ctx context.Context, category string, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+	conditions, args := filterConditions(filter, []any{category, limit, offset})
 	query := `
 		SELECT id, name, reference_number, category, description,
-		       sku, brand, stock, images, specifications,
+		       sku, brand, stock, price, COALESCE(currency, ''), images, specifications,
+		       COALESCE(thumbnail_url, ''), COALESCE(tags, '[]'::jsonb),
+		       weight_grams, length_mm, width_mm, height_mm,
 		       version, created_at, updated_at
 		FROM products
-		WHERE LOWER(category) = LOWER($1)
-		ORDER BY created_at DESC
+		WHERE LOWER(category) = LOWER($1) AND deleted_at IS NULL` + conditions + `
+		ORDER BY ` + orderBy(sort, repository.SortByCreatedAt, repository.SortDesc) + `
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.pool.Query(ctx, query, category, limit, offset)
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find products by category: %w", err)
 	}
@@ -221,19 +858,175 @@ func (r *PostgresProductRepository) FindByCategory(ctx context.Context, category
 	return r.scanProducts(rows)
 }
 
-func (r *PostgresProductRepository) FindByName(ctx context.Context, name string, limit, offset int) ([]*entity.Product, error) {
+// FindByTag usa o operador de contenção do JSONB (@>), atendido pelo índice
+// GIN de tags. A tag já deve estar normalizada (entity.NormalizeTag).
+func (r *PostgresProductRepository) FindByTag(ctx context.Context, tag string, limit, offset int) ([]*entity.Product, error) {
 	query := `
 		SELECT id, name, reference_number, category, description,
-		       sku, brand, stock, images, specifications,
+		       sku, brand, stock, price, COALESCE(currency, ''), images, specifications,
+		       COALESCE(thumbnail_url, ''), COALESCE(tags, '[]'::jsonb),
+		       weight_grams, length_mm, width_mm, height_mm,
 		       version, created_at, updated_at
 		FROM products
-		WHERE LOWER(name) LIKE LOWER($1)
-		ORDER BY name ASC
+		WHERE tags @> $1::jsonb AND deleted_at IS NULL
+		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3
 	`
 
-	searchPattern := "%" + name + "%"
-	rows, err := r.pool.Query(ctx, query, searchPattern, limit, offset)
+	tagJSON, err := json.Marshal([]string{tag})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tag: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, tagJSON, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find products by tag: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanProducts(rows)
+}
+
+// FindBySKU busca pelo SKU sem diferenciar maiúsculas, atendido pelo índice
+// em LOWER(sku). O total de linhas com o SKU vem na mesma consulta, pela
+// função de janela, para o chamador detectar SKUs repetidos.
+func (r *PostgresProductRepository) FindBySKU(ctx context.Context, sku string) (*entity.Product, int, error) {
+	query := `
+		SELECT id, name, reference_number, category, description,
+		       sku, brand, stock, price, COALESCE(currency, ''), images, specifications,
+		       COALESCE(thumbnail_url, ''), COALESCE(tags, '[]'::jsonb),
+		       weight_grams, length_mm, width_mm, height_mm,
+		       version, created_at, updated_at, COUNT(*) OVER ()
+		FROM products
+		WHERE LOWER(sku) = LOWER($1) AND deleted_at IS NULL
+		ORDER BY created_at ASC, id ASC
+		LIMIT 1
+	`
+
+	var product entity.Product
+	var imagesJSON, specsJSON, tagsJSON []byte
+	var matches int
+
+	err := r.pool.QueryRow(ctx, query, sku).Scan(
+		&product.ID,
+		&product.Name,
+		&product.ReferenceNumber,
+		&product.Category,
+		&product.Description,
+		&product.SKU,
+		&product.Brand,
+		&product.Stock,
+		&product.Price,
+		&product.Currency,
+		&imagesJSON,
+		&specsJSON,
+		&product.ThumbnailURL,
+		&tagsJSON,
+		&product.WeightGrams,
+		&product.Dimensions.LengthMM,
+		&product.Dimensions.WidthMM,
+		&product.Dimensions.HeightMM,
+		&product.Version,
+		&product.CreatedAt,
+		&product.UpdatedAt,
+		&matches,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, 0, repository.ErrProductNotFound
+		}
+		return nil, 0, fmt.Errorf("failed to find product by sku: %w", err)
+	}
+
+	if err := json.Unmarshal(imagesJSON, &product.Images); err != nil {
+		return nil, 0, fmt.Errorf("failed to unmarshal images: %w", err)
+	}
+
+	if err := json.Unmarshal(specsJSON, &product.Specifications); err != nil {
+		return nil, 0, fmt.Errorf("failed to unmarshal specifications: %w", err)
+	}
+
+	if err := unmarshalTags(tagsJSON, &product); err != nil {
+		return nil, 0, err
+	}
+
+	return &product, matches, nil
+}
+
+// FindModifiedBy junta a última linha de product_audit de cada produto (maior
+// id) aos produtos atuais; produtos excluídos, inclusive logicamente, ficam de
+// fora.
+func (r *PostgresProductRepository) FindModifiedBy(ctx context.Context, subject string, limit, offset int) ([]*entity.Product, error) {
+	query := `
+		SELECT p.id, p.name, p.reference_number, p.category, p.description,
+		       p.sku, p.brand, p.stock, p.price, COALESCE(p.currency, ''), p.images, p.specifications,
+		       COALESCE(p.thumbnail_url, ''), COALESCE(p.tags, '[]'::jsonb),
+		       p.weight_grams, p.length_mm, p.width_mm, p.height_mm,
+		       p.version, p.created_at, p.updated_at
+		FROM products p
+		JOIN (
+			SELECT DISTINCT ON (product_id) product_id, subject, occurred_at
+			FROM product_audit
+			WHERE product_id IN (SELECT product_id FROM product_audit WHERE subject = $1)
+			ORDER BY product_id, id DESC
+		) last ON last.product_id = p.id
+		WHERE last.subject = $1 AND p.deleted_at IS NULL
+		ORDER BY last.occurred_at DESC, p.id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, subject, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find products modified by subject: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanProducts(rows)
+}
+
+func (r *PostgresProductRepository) FindByName(ctx context.Context, name string, filter repository.ListFilter, order repository.NameSearchOrder, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+	sortClause := orderBy(sort, repository.SortByName, repository.SortAsc)
+
+	args := []any{"%" + name + "%", limit, offset}
+	if order == repository.NameOrderRelevance {
+		args = append(args, name, name+"%")
+	}
+	conditions, args := filterConditions(filter, args)
+
+	query := `
+		SELECT id, name, reference_number, category, description,
+		       sku, brand, stock, price, COALESCE(currency, ''), images, specifications,
+		       COALESCE(thumbnail_url, ''), COALESCE(tags, '[]'::jsonb),
+		       weight_grams, length_mm, width_mm, height_mm,
+		       version, created_at, updated_at
+		FROM products
+		WHERE LOWER(name) LIKE LOWER($1) AND deleted_at IS NULL` + conditions + `
+		ORDER BY ` + sortClause + `
+		LIMIT $2 OFFSET $3
+	`
+
+	if order == repository.NameOrderRelevance {
+		query = `
+			SELECT id, name, reference_number, category, description,
+			       sku, brand, stock, price, COALESCE(currency, ''), images, specifications,
+			       COALESCE(thumbnail_url, ''), COALESCE(tags, '[]'::jsonb),
+			       weight_grams, length_mm, width_mm, height_mm,
+			       version, created_at, updated_at
+			FROM products
+			WHERE LOWER(name) LIKE LOWER($1) AND deleted_at IS NULL` + conditions + `
+			ORDER BY
+				CASE
+					WHEN LOWER(name) = LOWER($4) THEN 0
+					WHEN LOWER(name) LIKE LOWER($5) THEN 1
+					ELSE 2
+				END,
+				` + sortClause + `
+			LIMIT $2 OFFSET $3
+		`
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find products by name: %w", err)
 	}
@@ -242,8 +1035,55 @@ func (r *PostgresProductRepository) FindByName(ctx context.Context, name string,
 	return r.scanProducts(rows)
 }
 
+func (r *PostgresProductRepository) Count(ctx context.Context, filter repository.ListFilter) (int, error) {
+	conditions, args := filterConditions(filter, nil)
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM products WHERE deleted_at IS NULL`+conditions, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count products: %w", err)
+	}
+	return total, nil
+}
+
+func (r *PostgresProductRepository) CountByName(ctx context.Context, name string, filter repository.ListFilter) (int, error) {
+	conditions, args := filterConditions(filter, []any{"%" + name + "%"})
+	query := `SELECT COUNT(*) FROM products WHERE LOWER(name) LIKE LOWER($1) AND deleted_at IS NULL` + conditions
+
+	var total int
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count products by name: %w", err)
+	}
+	return total, nil
+}
+
+func (r *PostgresProductRepository) CountByCategory(ctx context.Context, category string, filter repository.ListFilter) (int, error) {
+	conditions, args := filterConditions(filter, []any{category})
+	query := `SELECT COUNT(*) FROM products WHERE LOWER(category) = LOWER($1) AND deleted_at IS NULL` + conditions
+
+	var total int
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count products by category: %w", err)
+	}
+	return total, nil
+}
+
+func (r *PostgresProductRepository) CountByTag(ctx context.Context, tag string) (int, error) {
+	query := `SELECT COUNT(*) FROM products WHERE tags @> $1::jsonb AND deleted_at IS NULL`
+
+	tagJSON, err := json.Marshal([]string{tag})
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal tag: %w", err)
+	}
+
+	var total int
+	if err := r.pool.QueryRow(ctx, query, tagJSON).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count products by tag: %w", err)
+	}
+	return total, nil
+}
+
 func (r *PostgresProductRepository) Exists(ctx context.Context, id string) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM products WHERE id = $1)`
+	query := `SELECT EXISTS(SELECT 1 FROM products WHERE id = $1 AND deleted_at IS NULL)`
 
 	var exists bool
 	err := r.pool.QueryRow(ctx, query, id).Scan(&exists)
@@ -268,7 +1108,7 @@ func (r *PostgresProductRepository) scanProducts(rows pgx.Rows) ([]*entity.Produ
 
 	for rows.Next() {
 		var product entity.Product
-		var imagesJSON, specsJSON []byte
+		var imagesJSON, specsJSON, tagsJSON []byte
 
 		err := rows.Scan(
 			&product.ID,
@@ -279,8 +1119,16 @@ func (r *PostgresProductRepository) scanProducts(rows pgx.Rows) ([]*entity.Produ
 			&product.SKU,
 			&product.Brand,
 			&product.Stock,
+			&product.Price,
+			&product.Currency,
 			&imagesJSON,
 			&specsJSON,
+			&product.ThumbnailURL,
+			&tagsJSON,
+			&product.WeightGrams,
+			&product.Dimensions.LengthMM,
+			&product.Dimensions.WidthMM,
+			&product.Dimensions.HeightMM,
 			&product.Version,
 			&product.CreatedAt,
 			&product.UpdatedAt,
@@ -301,6 +1149,10 @@ func (r *PostgresProductRepository) scanProducts(rows pgx.Rows) ([]*entity.Produ
 			}
 		}
 
+		if err := unmarshalTags(tagsJSON, &product); err != nil {
+			return nil, err
+		}
+
 		products = append(products, &product)
 	}
 
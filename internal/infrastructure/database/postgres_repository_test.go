@@ -0,0 +1,140 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestIsSerializationFailure(t *testing.T) {
+	serializationErr := &pgconn.PgError{Code: "40001", Message: "could not serialize access"}
+
+	if !isSerializationFailure(serializationErr) {
+		t.Error("Expected SQLSTATE 40001 to be detected")
+	}
+
+	if !isSerializationFailure(fmt.Errorf("failed to update: %w", serializationErr)) {
+		t.Error("Expected wrapped SQLSTATE 40001 to be detected")
+	}
+
+	if isSerializationFailure(&pgconn.PgError{Code: "23505"}) {
+		t.Error("Expected unique violation not to be treated as serialization failure")
+	}
+
+	if isSerializationFailure(errors.New("connection reset")) {
+		t.Error("Expected generic error not to be treated as serialization failure")
+	}
+}
+
+func TestIsUniqueViolationOf(t *testing.T) {
+	nameErr := &pgconn.PgError{Code: "23505", ConstraintName: nameCategoryIndex}
+
+	if !isUniqueViolationOf(fmt.Errorf("insert: %w", nameErr), nameCategoryIndex) {
+		t.Error("Expected violation of the name/category index to be detected")
+	}
+
+	if isUniqueViolationOf(&pgconn.PgError{Code: "23505", ConstraintName: "products_pkey"}, nameCategoryIndex) {
+		t.Error("Expected primary key violation not to match the name/category index")
+	}
+
+	if isUniqueViolationOf(&pgconn.PgError{Code: "40001", ConstraintName: nameCategoryIndex}, nameCategoryIndex) {
+		t.Error("Expected non-unique errors not to match")
+	}
+}
+
+func TestParseIsolationLevel(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected pgx.TxIsoLevel
+		wantErr  bool
+	}{
+		{"", "", false},
+		{"read_committed", "", false},
+		{"repeatable_read", pgx.RepeatableRead, false},
+		{"SERIALIZABLE", pgx.Serializable, false},
+		{"snapshot", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			level, err := ParseIsolationLevel(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseIsolationLevel(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if level != tt.expected {
+				t.Errorf("ParseIsolationLevel(%q) = %q, want %q", tt.input, level, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOrderBy(t *testing.T) {
+	tests := []struct {
+		name      string
+		sort      repository.SortOptions
+		field     repository.SortField
+		direction repository.SortDirection
+		want      string
+	}{
+		{"zero value uses query default", repository.SortOptions{}, repository.SortByCreatedAt, repository.SortDesc, "created_at DESC, id DESC"},
+		{"direction only keeps default field", repository.SortOptions{Direction: repository.SortAsc}, repository.SortByCreatedAt, repository.SortDesc, "created_at ASC, id ASC"},
+		{"field without direction is ascending", repository.SortOptions{Field: repository.SortByStock}, repository.SortByCreatedAt, repository.SortDesc, "stock ASC, id ASC"},
+		{"field and direction", repository.SortOptions{Field: repository.SortByUpdatedAt, Direction: repository.SortDesc}, repository.SortByName, repository.SortAsc, "updated_at DESC, id DESC"},
+		{"unknown field falls back to default column", repository.SortOptions{Field: "price; DROP TABLE products"}, repository.SortByName, repository.SortAsc, "name ASC, id ASC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := orderBy(tt.sort, tt.field, tt.direction); got != tt.want {
+				t.Errorf("orderBy() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterConditions_ShippingRanges(t *testing.T) {
+	maxWeight, minVolume := int64(1000), int64(2)
+	conditions, args := filterConditions(repository.ListFilter{MaxWeight: &maxWeight, MinVolume: &minVolume}, []any{10, 0})
+
+	want := " AND weight_grams > 0 AND weight_grams <= $3" +
+		" AND length_mm > 0 AND width_mm > 0 AND height_mm > 0 AND (length_mm::bigint * width_mm * height_mm) >= $4"
+	if conditions != want {
+		t.Errorf("filterConditions() = %q, want %q", conditions, want)
+	}
+	if len(args) != 4 || args[2] != int64(1000) || args[3] != int64(2000) {
+		t.Errorf("Expected weight in grams and volume in mm3 as args, got %v", args)
+	}
+}
+
+func TestCreateBatchStatement_PlaceholdersMatchArgs(t *testing.T) {
+	product := &entity.Product{ID: "p1", Name: "Produto", Category: "Eletrônicos"}
+
+	query, args, err := createBatchStatement(product, "user-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	highest := 0
+	for _, match := range regexp.MustCompile(`\$(\d+)`).FindAllStringSubmatch(query, -1) {
+		n, _ := strconv.Atoi(match[1])
+		highest = max(highest, n)
+	}
+	if highest != len(args) {
+		t.Fatalf("Expected the query to use $1..$%d, highest placeholder is $%d", len(args), highest)
+	}
+	if args[len(args)-2] != auditCreate || args[len(args)-1] != "user-1" {
+		t.Errorf("Expected action and subject as the last args, got %v", args[len(args)-2:])
+	}
+	if want := fmt.Sprintf("SELECT id, $%d, $%d FROM changed", len(args)-1, len(args)); !strings.Contains(query, want) {
+		t.Errorf("Expected the audit CTE to read %q, got:\n%s", want, query)
+	}
+}
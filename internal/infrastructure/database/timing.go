@@ -0,0 +1,25 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/jackc/pgx/v5"
+)
+
+type queryStartKey struct{}
+
+// TimingTracer soma no port.RequestTiming do contexto o tempo de cada query,
+// do envio até o fechamento das linhas. Instalado em ConnConfig.Tracer.
+type TimingTracer struct{}
+
+func (TimingTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryStartKey{}, time.Now())
+}
+
+func (TimingTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	if start, ok := ctx.Value(queryStartKey{}).(time.Time); ok {
+		port.RecordDBTime(ctx, time.Since(start))
+	}
+}
@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"sort"
+	"strings"
+)
+
+// Select retorna só as chaves nos caminhos informados (cada um uma sequência
+// de chaves aninhadas), mantendo a estrutura de objetos até elas. Caminhos
+// ausentes no produto são ignorados; um caminho vazio devolve o mapa inteiro.
+// O mapa original não é alterado.
+func (m SpecificationMap) Select(paths [][]string) SpecificationMap {
+	if paths == nil {
+		return m
+	}
+
+	sorted := make([][]string, len(paths))
+	copy(sorted, paths)
+	sort.SliceStable(sorted, func(i, j int) bool { return len(sorted[i]) < len(sorted[j]) })
+
+	selected := SpecificationMap{}
+	covered := make(map[string]bool)
+	for _, path := range sorted {
+		if len(path) == 0 {
+			return m
+		}
+		if coveredBy(covered, path) {
+			continue
+		}
+
+		value, ok := lookupSpec(m, path)
+		if !ok {
+			continue
+		}
+		covered[strings.Join(path, ".")] = true
+
+		target := map[string]interface{}(selected)
+		for _, key := range path[:len(path)-1] {
+			next, ok := target[key].(map[string]interface{})
+			if !ok {
+				next = make(map[string]interface{})
+				target[key] = next
+			}
+			target = next
+		}
+		target[path[len(path)-1]] = value
+	}
+
+	return selected
+}
+
+// coveredBy informa se um prefixo do caminho já foi copiado inteiro.
+func coveredBy(covered map[string]bool, path []string) bool {
+	for i := 1; i < len(path); i++ {
+		if covered[strings.Join(path[:i], ".")] {
+			return true
+		}
+	}
+	return false
+}
+
+func lookupSpec(specs map[string]interface{}, path []string) (interface{}, bool) {
+	var value interface{} = specs
+	for _, key := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+)
+
+// GeoFeatureCollection documenta o feed GeoJSON (RFC 7946) de produtos com
+// localização. A resposta é escrita feature a feature, sem montar este tipo.
+// @Description FeatureCollection com um ponto por produto
+type GeoFeatureCollection struct {
+	Type     string       `json:"type" example:"FeatureCollection"`
+	Features []GeoFeature `json:"features"`
+}
+
+// GeoFeature é um produto no feed GeoJSON
+// @Description Ponto do produto, com coordenadas em [lng, lat]
+type GeoFeature struct {
+	Type       string        `json:"type" example:"Feature"`
+	ID         string        `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Geometry   GeoPoint      `json:"geometry"`
+	Properties GeoProperties `json:"properties"`
+}
+
+// GeoPoint é a geometria de um GeoFeature. Coordinates segue a ordem do
+// GeoJSON: longitude antes da latitude.
+type GeoPoint struct {
+	Type        string     `json:"type" example:"Point"`
+	Coordinates [2]float64 `json:"coordinates" swaggertype:"array,number" example:"-46.6333,-23.5505"`
+}
+
+// GeoProperties são os dados do produto exibidos no mapa
+type GeoProperties struct {
+	Name      string `json:"name" example:"iPhone 15 Pro"`
+	Category  string `json:"category" example:"electronics"`
+	SKU       string `json:"sku" example:"SKU-IP15P-256"`
+	Brand     string `json:"brand" example:"Apple"`
+	Stock     int    `json:"stock" example:"100"`
+	Available bool   `json:"available" example:"true"`
+	Price     int64  `json:"price" example:"999900"`
+	Currency  string `json:"currency,omitempty" example:"BRL"`
+}
+
+func ToGeoFeature(feature port.GeoFeature) GeoFeature {
+	product := feature.Product
+	return GeoFeature{
+		Type: "Feature",
+		ID:   product.ID,
+		Geometry: GeoPoint{
+			Type:        "Point",
+			Coordinates: [2]float64{feature.Longitude, feature.Latitude},
+		},
+		Properties: GeoProperties{
+			Name:      product.Name,
+			Category:  product.Category,
+			SKU:       product.SKU,
+			Brand:     product.Brand,
+			Stock:     product.Stock,
+			Available: product.Available(),
+			Price:     product.Price,
+			Currency:  product.Currency,
+		},
+	}
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "github.com/dowglassantana/product-redis-api/internal/domain/entity"
+
 // CreateProductRequest representa a requisição para criar um produto
 // @Description Dados para criação de um novo produto
 type CreateProductRequest struct {
@@ -10,8 +12,15 @@ type CreateProductRequest struct {
 	SKU             string                 `json:"sku" example:"SKU-IP15P-256"`
 	Brand           string                 `json:"brand" example:"Apple"`
 	Stock           int                    `json:"stock" example:"100"`
+	Price           int64                  `json:"price" example:"999900"`
+	PriceAmount     string                 `json:"price_amount,omitempty" example:"9999.00"`
+	Currency        string                 `json:"currency,omitempty" example:"BRL"`
 	Images          []string               `json:"images" example:"https://example.com/image1.jpg,https://example.com/image2.jpg"`
 	Specifications  map[string]interface{} `json:"specifications"`
+	ThumbnailURL    string                 `json:"thumbnail_url,omitempty" example:"https://example.com/thumb.jpg"`
+	Tags            []string               `json:"tags,omitempty" example:"promo,apple"`
+	WeightGrams     int                    `json:"weight_grams,omitempty" example:"187"`
+	Dimensions      *Dimensions            `json:"dimensions,omitempty"`
 }
 
 // UpdateProductRequest representa a requisição para atualizar um produto
@@ -23,6 +32,114 @@ type UpdateProductRequest struct {
 	SKU            string                 `json:"sku" example:"SKU-IP15PM-256"`
 	Brand          string                 `json:"brand" example:"Apple"`
 	Stock          int                    `json:"stock" example:"50"`
+	Price          int64                  `json:"price" example:"1099900"`
+	PriceAmount    string                 `json:"price_amount,omitempty" example:"10999.00"`
+	Currency       string                 `json:"currency,omitempty" example:"BRL"`
 	Images         []string               `json:"images" example:"https://example.com/image1.jpg"`
 	Specifications map[string]interface{} `json:"specifications"`
+	ThumbnailURL   string                 `json:"thumbnail_url,omitempty" example:"https://example.com/thumb.jpg"`
+	Tags           []string               `json:"tags,omitempty" example:"promo,apple"`
+	WeightGrams    int                    `json:"weight_grams,omitempty" example:"221"`
+	Dimensions     *Dimensions            `json:"dimensions,omitempty"`
+}
+
+// PatchProductRequest representa a requisição de atualização parcial
+// @Description Só os campos enviados são alterados; ausentes ou null mantêm o valor atual
+type PatchProductRequest struct {
+	Name           *string                 `json:"name,omitempty" example:"iPhone 15 Pro Max"`
+	Category       *string                 `json:"category,omitempty" example:"electronics"`
+	Description    *string                 `json:"description,omitempty" example:"Smartphone Apple com chip A17 Pro"`
+	SKU            *string                 `json:"sku,omitempty" example:"SKU-IP15PM-256"`
+	Brand          *string                 `json:"brand,omitempty" example:"Apple"`
+	Stock          *int                    `json:"stock,omitempty" example:"50"`
+	Price          *int64                  `json:"price,omitempty" example:"1099900"`
+	PriceAmount    *string                 `json:"price_amount,omitempty" example:"10999.00"`
+	Currency       *string                 `json:"currency,omitempty" example:"BRL"`
+	Images         *[]string               `json:"images,omitempty" example:"https://example.com/image1.jpg"`
+	Specifications *map[string]interface{} `json:"specifications,omitempty"`
+	ThumbnailURL   *string                 `json:"thumbnail_url,omitempty" example:"https://example.com/thumb.jpg"`
+	Tags           *[]string               `json:"tags,omitempty" example:"promo,apple"`
+	WeightGrams    *int                    `json:"weight_grams,omitempty" example:"221"`
+	Dimensions     *Dimensions             `json:"dimensions,omitempty"`
+}
+
+// Dimensions representa as medidas do produto para cálculo de frete
+// @Description Comprimento, largura e altura em milímetros; zero é não informado
+type Dimensions struct {
+	LengthMM int `json:"length_mm" xml:"length_mm" example:"160"`
+	WidthMM  int `json:"width_mm" xml:"width_mm" example:"77"`
+	HeightMM int `json:"height_mm" xml:"height_mm" example:"8"`
+}
+
+// Entity converte as medidas recebidas; ausentes ficam sem informação.
+func (d *Dimensions) Entity() entity.Dimensions {
+	if d == nil {
+		return entity.Dimensions{}
+	}
+	return entity.Dimensions{LengthMM: d.LengthMM, WidthMM: d.WidthMM, HeightMM: d.HeightMM}
+}
+
+// UpdateStockRequest representa a requisição de alteração de estoque
+// @Description Novo estoque do produto
+type UpdateStockRequest struct {
+	Stock *int `json:"stock" example:"42"`
+}
+
+// AdjustStockRequest representa a requisição de ajuste relativo de estoque
+// @Description Variação (positiva ou negativa) somada ao estoque atual
+type AdjustStockRequest struct {
+	Delta *int `json:"delta" example:"-3"`
+}
+
+// StockAdjustmentRequest é um item do ajuste de estoque em lote
+// @Description Produto e variação (positiva ou negativa) do estoque
+type StockAdjustmentRequest struct {
+	ID    string `json:"id" example:"01HN8Z9QXXXXXXXXXXXXXXXXXX"`
+	Delta int    `json:"delta" example:"-3"`
+}
+
+// BulkStockAdjustmentRequest representa a requisição de ajuste de estoque em lote
+// @Description Ajustes de estoque de uma reconciliação de armazém
+type BulkStockAdjustmentRequest struct {
+	Adjustments []StockAdjustmentRequest `json:"adjustments"`
+}
+
+// BatchGetRequest representa a busca de vários produtos por ID
+// @Description IDs dos produtos a buscar
+type BatchGetRequest struct {
+	IDs []string `json:"ids" example:"01HQZX3Y4K5M6N7P8Q9R0S1T2V,01HQZX3Y4K5M6N7P8Q9R0S1T2W"`
+}
+
+// AddTagRequest representa a requisição de inclusão de uma tag
+// @Description Tag a adicionar ao produto
+type AddTagRequest struct {
+	Tag string `json:"tag" example:"promo"`
+}
+
+// ImportProductRow representa uma linha da importação em lote
+// @Description Produto com timestamps do sistema de origem (RFC 3339)
+type ImportProductRow struct {
+	CreateProductRequest
+	CreatedAt string `json:"created_at" example:"2023-01-10T08:00:00Z"`
+	UpdatedAt string `json:"updated_at" example:"2023-02-10T08:00:00Z"`
+}
+
+// ImportProductsRequest representa a requisição de importação em lote
+// @Description Lote de produtos para migração
+type ImportProductsRequest struct {
+	Products []ImportProductRow `json:"products"`
+}
+
+// ReconcileRequest define a amostra da reconciliação de versões
+// @Description Tamanho da amostra e modo somente leitura
+type ReconcileRequest struct {
+	SampleSize int  `json:"sample_size" example:"200"`
+	DryRun     bool `json:"dry_run" example:"false"`
+}
+
+// WarmupRequest representa as buscas a pré-aquecer no cache
+// @Description Nomes e categorias cujas buscas serão executadas no servidor
+type WarmupRequest struct {
+	Names      []string `json:"names" example:"iPhone 15 Pro"`
+	Categories []string `json:"categories" example:"electronics"`
 }
@@ -1,27 +1,39 @@
 package dto
 
 import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
 	"time"
 
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
 	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
 )
 
 // ProductResponse representa a resposta de um produto
 // @Description Dados completos de um produto
 type ProductResponse struct {
-	ID              string                 `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Name            string                 `json:"name" example:"iPhone 15 Pro"`
-	ReferenceNumber string                 `json:"reference_number" example:"REF-12345"`
-	Category        string                 `json:"category" example:"electronics"`
-	Description     string                 `json:"description" example:"Smartphone Apple com chip A17 Pro"`
-	SKU             string                 `json:"sku" example:"SKU-IP15P-256"`
-	Brand           string                 `json:"brand" example:"Apple"`
-	Stock           int                    `json:"stock" example:"100"`
-	Images          []string               `json:"images" example:"https://example.com/image1.jpg"`
-	Specifications  map[string]interface{} `json:"specifications"`
-	Version         int                    `json:"version" example:"1"`
-	CreatedAt       time.Time              `json:"created_at" example:"2024-01-15T10:30:00Z"`
-	UpdatedAt       time.Time              `json:"updated_at" example:"2024-01-15T10:30:00Z"`
+	XMLName         xml.Name         `json:"-" xml:"product" swaggerignore:"true"`
+	ID              string           `json:"id" xml:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name            string           `json:"name" xml:"name" example:"iPhone 15 Pro"`
+	ReferenceNumber string           `json:"reference_number" xml:"reference_number" example:"REF-12345"`
+	Category        string           `json:"category" xml:"category" example:"electronics"`
+	Description     string           `json:"description" xml:"description" example:"Smartphone Apple com chip A17 Pro"`
+	SKU             string           `json:"sku" xml:"sku" example:"SKU-IP15P-256"`
+	Brand           string           `json:"brand" xml:"brand" example:"Apple"`
+	Stock           int              `json:"stock" xml:"stock" example:"100"`
+	Available       bool             `json:"available" xml:"available" example:"true" readonly:"true"` // Derivado do estoque; somente leitura
+	Price           int64            `json:"price" xml:"price" example:"999900"`
+	Currency        string           `json:"currency,omitempty" xml:"currency,omitempty" example:"BRL"`
+	Images          []string         `json:"images" xml:"images>image" example:"https://example.com/image1.jpg"`
+	Specifications  SpecificationMap `json:"specifications" xml:"specifications"`
+	ThumbnailURL    string           `json:"thumbnail_url,omitempty" xml:"thumbnail_url,omitempty" example:"https://example.com/thumb.jpg"`
+	Tags            []string         `json:"tags,omitempty" xml:"tags>tag,omitempty" example:"promo,apple"`
+	WeightGrams     int              `json:"weight_grams,omitempty" xml:"weight_grams,omitempty" example:"187"`
+	Dimensions      *Dimensions      `json:"dimensions,omitempty" xml:"dimensions,omitempty"`
+	Version         int              `json:"version" xml:"version" example:"1"`
+	CreatedAt       time.Time        `json:"created_at" xml:"created_at" example:"2024-01-15T10:30:00Z"`
+	UpdatedAt       time.Time        `json:"updated_at" xml:"updated_at" example:"2024-01-15T10:30:00Z"`
 }
 
 func ToProductResponse(product *entity.Product) *ProductResponse {
@@ -34,28 +46,282 @@ func ToProductResponse(product *entity.Product) *ProductResponse {
 		SKU:             product.SKU,
 		Brand:           product.Brand,
 		Stock:           product.Stock,
+		Available:       product.Available(),
+		Price:           product.Price,
+		Currency:        product.Currency,
 		Images:          product.Images,
 		Specifications:  product.Specifications,
+		ThumbnailURL:    product.Thumbnail(),
+		Tags:            product.Tags,
+		WeightGrams:     product.WeightGrams,
+		Dimensions:      toDimensions(product.Dimensions),
 		Version:         product.Version,
 		CreatedAt:       product.CreatedAt,
 		UpdatedAt:       product.UpdatedAt,
 	}
 }
 
+// toDimensions omite as medidas quando nenhuma foi informada.
+func toDimensions(dimensions entity.Dimensions) *Dimensions {
+	if dimensions.IsZero() {
+		return nil
+	}
+	return &Dimensions{LengthMM: dimensions.LengthMM, WidthMM: dimensions.WidthMM, HeightMM: dimensions.HeightMM}
+}
+
+// ToProductResponseIn converte created_at e updated_at para o fuso
+// informado; nil mantém os timestamps como gravados (UTC).
+func ToProductResponseIn(product *entity.Product, location *time.Location) *ProductResponse {
+	response := ToProductResponse(product)
+	if location != nil {
+		response.CreatedAt = response.CreatedAt.In(location)
+		response.UpdatedAt = response.UpdatedAt.In(location)
+	}
+	return response
+}
+
 func ToProductResponseList(products []*entity.Product) []*ProductResponse {
+	return ToProductResponseListIn(products, nil)
+}
+
+func ToProductResponseListIn(products []*entity.Product, location *time.Location) []*ProductResponse {
 	responses := make([]*ProductResponse, len(products))
 	for i, product := range products {
-		responses[i] = ToProductResponse(product)
+		responses[i] = ToProductResponseIn(product, location)
 	}
 	return responses
 }
 
+// ProductListMeta descreve a página retornada quando há orçamento de bytes
+// @Description Metadados da listagem
+type ProductListMeta struct {
+	Count     int  `json:"count" example:"50"`
+	Truncated bool `json:"truncated" example:"false"`
+	Total     *int `json:"total,omitempty" example:"1234"`
+}
+
+// PaginationLinks traz as URLs absolutas das páginas vizinhas
+// @Description Links de paginação; next é omitido na última página e prev na primeira
+type PaginationLinks struct {
+	Self  string `json:"self" example:"https://api.example.com/api/v1/products?limit=50&offset=50"`
+	First string `json:"first" example:"https://api.example.com/api/v1/products?limit=50&offset=0"`
+	Prev  string `json:"prev,omitempty" example:"https://api.example.com/api/v1/products?limit=50&offset=0"`
+	Next  string `json:"next,omitempty" example:"https://api.example.com/api/v1/products?limit=50&offset=100"`
+}
+
+// PaginatedResponse é o formato padrão de List, SearchByName e SearchByCategory
+// @Description Página de produtos com o total do conjunto completo
+type PaginatedResponse struct {
+	Data   []*ProductResponse `json:"data"`
+	Total  int                `json:"total" example:"1234"`
+	Limit  int                `json:"limit" example:"50"`
+	Offset int                `json:"offset" example:"0"`
+}
+
+// ProductListResponse é o formato das listagens com orçamento de bytes ativo
+// @Description Lista de produtos com metadados de truncamento e links de paginação
+type ProductListResponse struct {
+	Data  []*ProductResponse `json:"data"`
+	Meta  ProductListMeta    `json:"meta"`
+	Links *PaginationLinks   `json:"links,omitempty"`
+}
+
+// ImportRowErrorResponse descreve a falha de uma linha da importação
+// @Description Erro de uma linha da importação
+type ImportRowErrorResponse struct {
+	Row             int    `json:"row" example:"2"`
+	ReferenceNumber string `json:"reference_number" example:"REF-12345"`
+	Error           string `json:"error" example:"product created_at is in the future"`
+}
+
+// ImportReportResponse representa o relatório da importação
+// @Description Resultado da importação em lote
+type ImportReportResponse struct {
+	Total    int                      `json:"total" example:"3"`
+	Imported int                      `json:"imported" example:"2"`
+	Failed   int                      `json:"failed" example:"1"`
+	Errors   []ImportRowErrorResponse `json:"errors"`
+}
+
+func ToImportReportResponse(report *port.ImportReport) *ImportReportResponse {
+	rowErrors := make([]ImportRowErrorResponse, len(report.Errors))
+	for i, rowErr := range report.Errors {
+		rowErrors[i] = ImportRowErrorResponse{
+			Row:             rowErr.Row,
+			ReferenceNumber: rowErr.ReferenceNumber,
+			Error:           rowErr.Error,
+		}
+	}
+
+	return &ImportReportResponse{
+		Total:    report.Total,
+		Imported: report.Imported,
+		Failed:   report.Failed,
+		Errors:   rowErrors,
+	}
+}
+
+// AdjustStockResponse representa o resultado de um ajuste de estoque
+// @Description Produto e estoque após o ajuste
+type AdjustStockResponse struct {
+	ID    string `json:"id" example:"01HN8Z9QXXXXXXXXXXXXXXXXXX"`
+	Stock int    `json:"stock" example:"97"`
+}
+
+// CountResponse representa a contagem de produtos
+// @Description Total de produtos ativos; com category, só os da categoria
+type CountResponse struct {
+	Total    int    `json:"total" example:"1250"`
+	Category string `json:"category,omitempty" example:"Electronics"`
+}
+
+// TombstoneResponse representa um produto excluído logicamente
+// @Description Resposta 410 para admins: o produto existiu e foi excluído em deleted_at
+type TombstoneResponse struct {
+	Error     string    `json:"error" example:"product_deleted"`
+	ID        string    `json:"id" example:"01HN8Z9QXXXXXXXXXXXXXXXXXX"`
+	DeletedAt time.Time `json:"deleted_at" example:"2024-01-20T14:00:00Z"`
+}
+
+// ToTombstoneResponseIn converte deleted_at para o fuso informado; nil mantém
+// o timestamp como gravado (UTC).
+func ToTombstoneResponseIn(tombstone *entity.Tombstone, location *time.Location) *TombstoneResponse {
+	response := &TombstoneResponse{
+		Error:     "product_deleted",
+		ID:        tombstone.ID,
+		DeletedAt: tombstone.DeletedAt,
+	}
+	if location != nil {
+		response.DeletedAt = response.DeletedAt.In(location)
+	}
+	return response
+}
+
+// StockAdjustmentResultResponse descreve um item do ajuste em lote
+// @Description Resultado de um ajuste; stock é o estoque final ou, se recusado por ficar negativo, o atual
+type StockAdjustmentResultResponse struct {
+	ID      string `json:"id" example:"01HN8Z9QXXXXXXXXXXXXXXXXXX"`
+	Delta   int    `json:"delta" example:"-3"`
+	Stock   int    `json:"stock" example:"97"`
+	Applied bool   `json:"applied" example:"true"`
+	Error   string `json:"error,omitempty" example:"stock adjustment would make stock negative"`
+}
+
+// BatchGetResponse representa o resultado da busca de vários produtos por ID
+// @Description Produtos indexados pelo ID pedido; IDs não encontrados vêm com null
+type BatchGetResponse struct {
+	Products map[string]*ProductResponse `json:"products"`
+
+	// order guarda os IDs na ordem do pedido, usada pelo MarshalJSON no lugar
+	// da ordem alfabética das chaves.
+	order []string
+}
+
+// NewBatchGetResponse cria a resposta com os IDs na ordem do pedido, todos
+// null até serem preenchidos por Set.
+func NewBatchGetResponse(ids []string) *BatchGetResponse {
+	response := &BatchGetResponse{
+		Products: make(map[string]*ProductResponse, len(ids)),
+		order:    make([]string, 0, len(ids)),
+	}
+	for _, id := range ids {
+		if _, ok := response.Products[id]; ok {
+			continue
+		}
+		response.Products[id] = nil
+		response.order = append(response.order, id)
+	}
+	return response
+}
+
+func (r *BatchGetResponse) Set(id string, product *ProductResponse) {
+	if _, ok := r.Products[id]; !ok {
+		r.order = append(r.order, id)
+	}
+	r.Products[id] = product
+}
+
+// MarshalJSON escreve products na ordem do pedido.
+func (r BatchGetResponse) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(`{"products":{`)
+	for i, id := range r.order {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(id)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(r.Products[id])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteString(`}}`)
+	return buf.Bytes(), nil
+}
+
+// BulkStockAdjustmentResponse representa o relatório do ajuste em lote
+// @Description Resultado do ajuste de estoque em lote
+type BulkStockAdjustmentResponse struct {
+	Total   int                             `json:"total" example:"3"`
+	Applied int                             `json:"applied" example:"2"`
+	Failed  int                             `json:"failed" example:"1"`
+	Results []StockAdjustmentResultResponse `json:"results"`
+}
+
+func ToBulkStockAdjustmentResponse(report *port.StockAdjustmentReport) *BulkStockAdjustmentResponse {
+	results := make([]StockAdjustmentResultResponse, len(report.Results))
+	for i, result := range report.Results {
+		results[i] = StockAdjustmentResultResponse{
+			ID:      result.ID,
+			Delta:   result.Delta,
+			Stock:   result.Stock,
+			Applied: result.Applied,
+			Error:   result.Error,
+		}
+	}
+
+	return &BulkStockAdjustmentResponse{
+		Total:   report.Total,
+		Applied: report.Applied,
+		Failed:  report.Failed,
+		Results: results,
+	}
+}
+
+// BulkCreateItemResponse descreve um item da criação em lote
+// @Description Resultado de um item; status é o código HTTP que a criação unitária teria retornado
+type BulkCreateItemResponse struct {
+	Index   int               `json:"index" example:"0"`
+	Status  int               `json:"status" example:"201"`
+	Product *ProductResponse  `json:"product,omitempty"`
+	Error   string            `json:"error,omitempty" example:"product_exists"`
+	Message string            `json:"message,omitempty" example:"Product already exists"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+// BulkCreateResponse representa o relatório da criação em lote
+// @Description Resultado da criação em lote
+type BulkCreateResponse struct {
+	Total   int                      `json:"total" example:"3"`
+	Created int                      `json:"created" example:"2"`
+	Failed  int                      `json:"failed" example:"1"`
+	Results []BulkCreateItemResponse `json:"results"`
+}
+
 // ErrorResponse representa uma resposta de erro
 // @Description Estrutura de resposta de erro da API
 type ErrorResponse struct {
-	Error   string `json:"error" example:"validation_error"`
-	Message string `json:"message,omitempty" example:"Invalid request body"`
-	Code    string `json:"code,omitempty" example:"400"`
+	Error   string            `json:"error" example:"validation_error"`
+	Message string            `json:"message,omitempty" example:"Invalid request body"`
+	Code    string            `json:"code,omitempty" example:"400"`
+	Fields  map[string]string `json:"fields,omitempty"`
+	Details []string          `json:"details,omitempty" example:"failed to save product: connection refused,connection refused"`
 }
 
 // SuccessResponse representa uma resposta de sucesso genérica
@@ -64,3 +330,120 @@ type SuccessResponse struct {
 	Message string      `json:"message" example:"Operation completed successfully"`
 	Data    interface{} `json:"data,omitempty"`
 }
+
+// WarmupQueryResponse descreve uma busca aquecida
+// @Description Resultado do aquecimento de uma busca
+type WarmupQueryResponse struct {
+	Type     string `json:"type" example:"category"`
+	Query    string `json:"query" example:"electronics"`
+	Products int    `json:"products" example:"42"`
+	Error    string `json:"error,omitempty" example:"failed to load products"`
+}
+
+// WarmupResponse representa o resultado do aquecimento do cache
+// @Description Total de produtos aquecidos e detalhe por busca
+type WarmupResponse struct {
+	Warmed  int                   `json:"warmed" example:"42"`
+	Queries []WarmupQueryResponse `json:"queries"`
+}
+
+func ToWarmupResponse(report *port.WarmupReport) *WarmupResponse {
+	queries := make([]WarmupQueryResponse, len(report.Queries))
+	for i, query := range report.Queries {
+		queries[i] = WarmupQueryResponse{
+			Type:     query.Type,
+			Query:    query.Query,
+			Products: query.Products,
+			Error:    query.Error,
+		}
+	}
+
+	return &WarmupResponse{
+		Warmed:  report.Warmed,
+		Queries: queries,
+	}
+}
+
+// CacheInvalidationResponse representa o resultado de uma invalidação do cache
+// @Description Quantidade de chaves removidas ou alteradas no Redis
+type CacheInvalidationResponse struct {
+	KeysAffected int `json:"keys_affected" example:"3"`
+}
+
+// DeadLetterResponse descreve um evento de mudança que o webhook não entregou
+// @Description Evento não entregue, com o último erro e o momento da falha
+type DeadLetterResponse struct {
+	Type       string    `json:"type" example:"product.updated"`
+	ProductID  string    `json:"product_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Version    int       `json:"version,omitempty" example:"3"`
+	OccurredAt time.Time `json:"occurred_at" example:"2024-01-15T10:30:00Z"`
+	Error      string    `json:"error" example:"webhook responded 502"`
+	FailedAt   time.Time `json:"failed_at" example:"2024-01-15T10:30:02Z"`
+}
+
+// DeadLetterListResponse representa os eventos não entregues mais antigos
+// @Description Eventos no dead letter do webhook, do mais antigo ao mais novo
+type DeadLetterListResponse struct {
+	Total  int                  `json:"total" example:"12"`
+	Events []DeadLetterResponse `json:"events"`
+}
+
+func ToDeadLetterListResponse(letters []port.DeadLetter, total int) *DeadLetterListResponse {
+	events := make([]DeadLetterResponse, len(letters))
+	for i, letter := range letters {
+		events[i] = DeadLetterResponse{
+			Type:       letter.Event.Type,
+			ProductID:  letter.Event.ProductID,
+			Version:    letter.Event.Version,
+			OccurredAt: letter.Event.OccurredAt,
+			Error:      letter.Error,
+			FailedAt:   letter.FailedAt,
+		}
+	}
+
+	return &DeadLetterListResponse{
+		Total:  total,
+		Events: events,
+	}
+}
+
+// DeadLetterReplayResponse representa o resultado de um reenvio do dead letter
+// @Description Quantidade de eventos reenviados com sucesso
+type DeadLetterReplayResponse struct {
+	Replayed int `json:"replayed" example:"12"`
+}
+
+// StaleEntryResponse descreve uma entrada em cache defasada
+// @Description Versões em cache e no banco; db_version 0 indica produto removido
+type StaleEntryResponse struct {
+	ProductID     string `json:"product_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CachedVersion int    `json:"cached_version" example:"2"`
+	DBVersion     int    `json:"db_version" example:"3"`
+}
+
+// ReconcileResponse representa o resultado da reconciliação de versões
+// @Description Produtos sorteados, conferidos, defasados e removidos do cache
+type ReconcileResponse struct {
+	Sampled int                  `json:"sampled" example:"200"`
+	Checked int                  `json:"checked" example:"198"`
+	Evicted int                  `json:"evicted" example:"1"`
+	Stale   []StaleEntryResponse `json:"stale"`
+}
+
+func ToReconcileResponse(report *port.ReconcileReport) *ReconcileResponse {
+	stale := make([]StaleEntryResponse, len(report.Stale))
+	for i, entry := range report.Stale {
+		stale[i] = StaleEntryResponse{
+			ProductID:     entry.ProductID,
+			CachedVersion: entry.CachedVersion,
+			DBVersion:     entry.DBVersion,
+		}
+	}
+
+	return &ReconcileResponse{
+		Sampled: report.Sampled,
+		Checked: report.Checked,
+		Evicted: report.Evicted,
+		Stale:   stale,
+	}
+}
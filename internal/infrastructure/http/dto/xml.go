@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+)
+
+// SpecificationMap são as especificações livres do produto. Em JSON é o
+// próprio objeto; em XML, que não tem mapas, vira uma lista de pares:
+//
+//	<specifications><spec key="color">black</spec></specifications>
+//
+// Valores compostos (objetos, listas) saem como JSON dentro do elemento.
+type SpecificationMap map[string]interface{}
+
+type specificationEntry struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+func (m SpecificationMap) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]specificationEntry, len(keys))
+	for i, key := range keys {
+		value, err := specificationText(m[key])
+		if err != nil {
+			return fmt.Errorf("failed to encode specification %q: %w", key, err)
+		}
+		entries[i] = specificationEntry{Key: key, Value: value}
+	}
+
+	return e.EncodeElement(struct {
+		Entries []specificationEntry `xml:"spec"`
+	}{entries}, start)
+}
+
+func specificationText(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool, float64, float32, int, int64:
+		return fmt.Sprint(v), nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+}
+
+// ProductListXML é o envelope das listagens em XML.
+type ProductListXML struct {
+	XMLName  xml.Name           `xml:"products"`
+	Products []*ProductResponse `xml:"product"`
+}
@@ -0,0 +1,456 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/dto"
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/middleware"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// maxWarmupQueries limita quantas buscas um único aquecimento pode disparar.
+const maxWarmupQueries = 100
+
+// maxAdminBodyBytes limita o corpo das rotas admin; cem buscas de
+// aquecimento cabem com folga.
+const maxAdminBodyBytes = 64 << 10
+
+// defaultReconcileSample e maxReconcileSample limitam a amostra da
+// reconciliação, que faz uma consulta ao banco por produto sorteado.
+const (
+	defaultReconcileSample = 100
+	maxReconcileSample     = 1000
+)
+
+// defaultDeadLetterLimit e maxDeadLetterLimit limitam quantos eventos do dead
+// letter uma consulta ou um reenvio alcança.
+const (
+	defaultDeadLetterLimit = 100
+	maxDeadLetterLimit     = 1000
+)
+
+type AdminHandler struct {
+	warmer      port.SearchWarmer
+	reconciler  port.CacheVersionReconciler
+	cacheWarmer port.CacheWarmer
+	invalidator port.CacheInvalidator
+	deadLetters port.DeadLetterQueue
+	logger      *zap.Logger
+}
+
+func NewAdminHandler(warmer port.SearchWarmer, reconciler port.CacheVersionReconciler, logger *zap.Logger) *AdminHandler {
+	return &AdminHandler{
+		warmer:     warmer,
+		reconciler: reconciler,
+		logger:     logger,
+	}
+}
+
+// WithCacheWarmer habilita o aquecimento completo em /api/v1/admin/cache/warm.
+func (h *AdminHandler) WithCacheWarmer(cacheWarmer port.CacheWarmer) *AdminHandler {
+	h.cacheWarmer = cacheWarmer
+	return h
+}
+
+// WithCacheInvalidator habilita a invalidação em /api/v1/admin/cache.
+func (h *AdminHandler) WithCacheInvalidator(invalidator port.CacheInvalidator) *AdminHandler {
+	h.invalidator = invalidator
+	return h
+}
+
+// WithDeadLetters habilita a consulta e o reenvio dos eventos de mudança não
+// entregues em /api/v1/admin/webhook/dead-letters.
+func (h *AdminHandler) WithDeadLetters(deadLetters port.DeadLetterQueue) *AdminHandler {
+	h.deadLetters = deadLetters
+	return h
+}
+
+// WhoAmIResponse representa as claims extraídas do token validado
+// @Description Identidade e roles do token usado na requisição
+type WhoAmIResponse struct {
+	Subject           string   `json:"subject" example:"8d2f1c9e-4b7a-4f3e-9c1d-2a6b5e7f8a90"`
+	Email             string   `json:"email" example:"admin@example.com"`
+	PreferredUsername string   `json:"preferred_username" example:"admin"`
+	Roles             []string `json:"roles" example:"admin,offline_access"`
+}
+
+// WhoAmI godoc
+// @Summary      Claims do token atual
+// @Description  Retorna as claims decodificadas do JWT validado (subject, email e roles), para depurar integrações
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  WhoAmIResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/admin/whoami [get]
+func (h *AdminHandler) WhoAmI(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		h.respondJSON(w, http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "No authenticated user in request",
+		})
+		return
+	}
+
+	roles := user.RealmRoles
+	if roles == nil {
+		roles = []string{}
+	}
+
+	h.respondJSON(w, http.StatusOK, WhoAmIResponse{
+		Subject:           user.Subject,
+		Email:             user.Email,
+		PreferredUsername: user.PreferredUsername,
+		Roles:             roles,
+	})
+}
+
+// Warm godoc
+// @Summary      Pré-aquecer buscas no cache
+// @Description  Executa no servidor as buscas por nome e categoria informadas, gravando produtos e índices no Redis antes de um pico de tráfego. Até 100 buscas por requisição. Com "warm" em REDIS_CACHE_STRICT_OPERATIONS, uma falha do Redis aborta com 500 cache_unavailable.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.WarmupRequest  true  "Buscas a aquecer"
+// @Success      200      {object}  dto.WarmupResponse
+// @Failure      400      {object}  dto.ErrorResponse
+// @Failure      401      {object}  dto.ErrorResponse
+// @Failure      403      {object}  dto.ErrorResponse
+// @Failure      413      {object}  dto.ErrorResponse
+// @Failure      500      {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/admin/warm [post]
+func (h *AdminHandler) Warm(w http.ResponseWriter, r *http.Request) {
+	var req dto.WarmupRequest
+	if err := decodeLimited(w, r, &req, maxAdminBodyBytes); err != nil {
+		h.respondBodyError(w, err)
+		return
+	}
+
+	total := len(req.Names) + len(req.Categories)
+	if total == 0 || total > maxWarmupQueries {
+		h.respondJSON(w, http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Warmup must contain between 1 and 100 queries",
+		})
+		return
+	}
+
+	report, err := h.warmer.Execute(r.Context(), port.WarmupInput{
+		Names:      req.Names,
+		Categories: req.Categories,
+	})
+	if errors.Is(err, repository.ErrCacheUnavailable) {
+		h.logger.Error("search warmup aborted by cache failure", zap.Error(err))
+		h.respondJSON(w, http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "cache_unavailable",
+			Message: "Cache failed during warmup",
+		})
+		return
+	}
+	if err != nil {
+		h.logger.Error("search warmup failed", zap.Error(err))
+		h.respondJSON(w, http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to warm search cache",
+		})
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, dto.ToWarmupResponse(report))
+}
+
+// WarmCache godoc
+// @Summary      Aquecer o cache inteiro
+// @Description  Dispara em background a recarga de todos os produtos do banco no Redis, em lotes de REDIS_WARM_BATCH_SIZE, reconstruindo os índices all_products, de nome, de categoria e de tag ao final. O resultado vai para o log. Responde 409 se já houver um aquecimento em andamento.
+// @Tags         admin
+// @Produce      json
+// @Success      202  {object}  dto.SuccessResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      409  {object}  dto.ErrorResponse
+// @Failure      503  {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/admin/cache/warm [post]
+func (h *AdminHandler) WarmCache(w http.ResponseWriter, r *http.Request) {
+	if h.cacheWarmer == nil {
+		h.respondJSON(w, http.StatusServiceUnavailable, dto.ErrorResponse{
+			Error:   "unavailable",
+			Message: "Cache warm is not available",
+		})
+		return
+	}
+
+	if !h.cacheWarmer.Trigger() {
+		h.respondJSON(w, http.StatusConflict, dto.ErrorResponse{
+			Error:   "warm_in_progress",
+			Message: "A cache warm is already in progress",
+		})
+		return
+	}
+
+	h.respondJSON(w, http.StatusAccepted, dto.SuccessResponse{
+		Message: "Cache warm started",
+	})
+}
+
+// InvalidateProductCache godoc
+// @Summary      Invalidar o cache de um produto
+// @Description  Remove do Redis a entrada do produto e tira o ID de all_products e dos índices de nome, categoria e tag, sem alterar o banco. Os índices são descobertos pelo produto no banco e pela cópia em cache. A próxima leitura recarrega o produto do banco.
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      string  true  "Product ID"
+// @Success      200  {object}  dto.CacheInvalidationResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Failure      503  {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/admin/cache/products/{id} [delete]
+func (h *AdminHandler) InvalidateProductCache(w http.ResponseWriter, r *http.Request) {
+	if h.invalidator == nil {
+		h.respondInvalidationUnavailable(w)
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	affected, err := h.invalidator.InvalidateProduct(r.Context(), id)
+	if err != nil {
+		h.logger.Error("product cache invalidation failed", zap.Error(err))
+		h.respondJSON(w, http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "cache_unavailable",
+			Message: "Failed to invalidate product cache",
+		})
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, dto.CacheInvalidationResponse{KeysAffected: affected})
+}
+
+// FlushCache godoc
+// @Summary      Esvaziar o cache
+// @Description  Remove só as chaves de cache (produtos, índices, contagem e respostas guardadas), com SCAN e UNLINK. Idempotência, dead letter do webhook e rate limiting ficam intactos. O catálogo volta aos poucos pelas leituras ou de uma vez por /api/v1/admin/cache/warm.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  dto.CacheInvalidationResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Failure      503  {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/admin/cache [delete]
+func (h *AdminHandler) FlushCache(w http.ResponseWriter, r *http.Request) {
+	if h.invalidator == nil {
+		h.respondInvalidationUnavailable(w)
+		return
+	}
+
+	affected, err := h.invalidator.FlushAll(r.Context())
+	if err != nil {
+		h.logger.Error("cache flush failed", zap.Error(err))
+		h.respondJSON(w, http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "cache_unavailable",
+			Message: "Failed to flush cache",
+		})
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, dto.CacheInvalidationResponse{KeysAffected: affected})
+}
+
+func (h *AdminHandler) respondInvalidationUnavailable(w http.ResponseWriter) {
+	h.respondJSON(w, http.StatusServiceUnavailable, dto.ErrorResponse{
+		Error:   "unavailable",
+		Message: "Cache invalidation is not available",
+	})
+}
+
+// ReconcileVersions godoc
+// @Summary      Reconciliar versões do cache
+// @Description  Sorteia produtos de all_products e compara a versão em cache com a do banco. Entradas com versão atrás da do banco são removidas do cache (recarregadas na próxima leitura); as de produtos removidos saem também dos índices. Com dry_run, só reporta. Amostra padrão de 100, até 1000.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ReconcileRequest  false  "Amostra"
+// @Success      200      {object}  dto.ReconcileResponse
+// @Failure      400      {object}  dto.ErrorResponse
+// @Failure      401      {object}  dto.ErrorResponse
+// @Failure      403      {object}  dto.ErrorResponse
+// @Failure      413      {object}  dto.ErrorResponse
+// @Failure      500      {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/admin/reconcile/versions [post]
+func (h *AdminHandler) ReconcileVersions(w http.ResponseWriter, r *http.Request) {
+	var req dto.ReconcileRequest
+	if err := decodeLimited(w, r, &req, maxAdminBodyBytes); err != nil && !errors.Is(err, io.EOF) {
+		h.respondBodyError(w, err)
+		return
+	}
+
+	if req.SampleSize == 0 {
+		req.SampleSize = defaultReconcileSample
+	}
+	if req.SampleSize < 0 || req.SampleSize > maxReconcileSample {
+		h.respondJSON(w, http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "sample_size must be between 1 and 1000",
+		})
+		return
+	}
+
+	report, err := h.reconciler.Execute(r.Context(), port.ReconcileInput{
+		SampleSize: req.SampleSize,
+		DryRun:     req.DryRun,
+	})
+	if errors.Is(err, repository.ErrCacheUnavailable) {
+		h.logger.Error("cache version reconciliation aborted by cache failure", zap.Error(err))
+		h.respondJSON(w, http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "cache_unavailable",
+			Message: "Cache failed during reconciliation",
+		})
+		return
+	}
+	if err != nil {
+		h.logger.Error("cache version reconciliation failed", zap.Error(err))
+		h.respondJSON(w, http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to reconcile cache versions",
+		})
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, dto.ToReconcileResponse(report))
+}
+
+// ListDeadLetters godoc
+// @Summary      Listar eventos não entregues
+// @Description  Lista, do mais antigo ao mais novo, os eventos de mudança que o webhook não entregou depois das novas tentativas, com o último erro. Não remove os eventos. Padrão de 100, até 1000.
+// @Tags         admin
+// @Produce      json
+// @Param        limit  query     int  false  "Quantidade de eventos (máx 1000)"  default(100)
+// @Success      200    {object}  dto.DeadLetterListResponse
+// @Failure      400    {object}  dto.ErrorResponse
+// @Failure      401    {object}  dto.ErrorResponse
+// @Failure      403    {object}  dto.ErrorResponse
+// @Failure      500    {object}  dto.ErrorResponse
+// @Failure      503    {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/admin/webhook/dead-letters [get]
+func (h *AdminHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	if h.deadLetters == nil {
+		h.respondDeadLettersUnavailable(w)
+		return
+	}
+
+	limit, ok := h.deadLetterLimit(w, r)
+	if !ok {
+		return
+	}
+
+	letters, total, err := h.deadLetters.DeadLetters(r.Context(), limit)
+	if err != nil {
+		h.logger.Error("failed to read webhook dead letters", zap.Error(err))
+		h.respondJSON(w, http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "cache_unavailable",
+			Message: "Failed to read webhook dead letters",
+		})
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, dto.ToDeadLetterListResponse(letters, total))
+}
+
+// ReplayDeadLetters godoc
+// @Summary      Reenviar eventos não entregues
+// @Description  Reenvia ao webhook, na ordem em que falharam, até limit eventos do dead letter, sem novas tentativas. Na primeira falha os eventos restantes voltam ao dead letter e a resposta é 502. Padrão de 100, até 1000.
+// @Tags         admin
+// @Produce      json
+// @Param        limit  query     int  false  "Quantidade de eventos (máx 1000)"  default(100)
+// @Success      200    {object}  dto.DeadLetterReplayResponse
+// @Failure      400    {object}  dto.ErrorResponse
+// @Failure      401    {object}  dto.ErrorResponse
+// @Failure      403    {object}  dto.ErrorResponse
+// @Failure      500    {object}  dto.ErrorResponse
+// @Failure      502    {object}  dto.ErrorResponse
+// @Failure      503    {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/admin/webhook/dead-letters/replay [post]
+func (h *AdminHandler) ReplayDeadLetters(w http.ResponseWriter, r *http.Request) {
+	if h.deadLetters == nil {
+		h.respondDeadLettersUnavailable(w)
+		return
+	}
+
+	limit, ok := h.deadLetterLimit(w, r)
+	if !ok {
+		return
+	}
+
+	replayed, err := h.deadLetters.Replay(r.Context(), limit)
+	if errors.Is(err, port.ErrWebhookUnavailable) {
+		h.logger.Warn("webhook dead letter replay interrupted", zap.Error(err), zap.Int("replayed", replayed))
+		h.respondJSON(w, http.StatusBadGateway, dto.ErrorResponse{
+			Error:   "webhook_unavailable",
+			Message: fmt.Sprintf("Webhook failed after %d events were replayed; the rest stay in the dead letter", replayed),
+		})
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to replay webhook dead letters", zap.Error(err))
+		h.respondJSON(w, http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "cache_unavailable",
+			Message: "Failed to read webhook dead letters",
+		})
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, dto.DeadLetterReplayResponse{Replayed: replayed})
+}
+
+func (h *AdminHandler) deadLetterLimit(w http.ResponseWriter, r *http.Request) (int, bool) {
+	l := r.URL.Query().Get("limit")
+	if l == "" {
+		return defaultDeadLetterLimit, true
+	}
+
+	limit, err := strconv.Atoi(l)
+	if err != nil || limit <= 0 || limit > maxDeadLetterLimit {
+		h.respondJSON(w, http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: fmt.Sprintf("limit must be between 1 and %d", maxDeadLetterLimit),
+		})
+		return 0, false
+	}
+	return limit, true
+}
+
+func (h *AdminHandler) respondDeadLettersUnavailable(w http.ResponseWriter) {
+	h.respondJSON(w, http.StatusServiceUnavailable, dto.ErrorResponse{
+		Error:   "unavailable",
+		Message: "Webhook dead letter is not enabled",
+	})
+}
+
+// respondBodyError responde o erro de decodificação traduzido por bodyError.
+func (h *AdminHandler) respondBodyError(w http.ResponseWriter, err error) {
+	status, code, message := bodyError(err)
+	h.respondJSON(w, status, dto.ErrorResponse{Error: code, Message: message})
+}
+
+func (h *AdminHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("failed to encode response", zap.Error(err))
+	}
+}
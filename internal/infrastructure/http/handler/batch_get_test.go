@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+type stubBatchGetter struct {
+	products map[string]*entity.Product
+	calls    int
+}
+
+func (s *stubBatchGetter) Execute(ctx context.Context, ids []string) (map[string]*entity.Product, error) {
+	s.calls++
+	return s.products, nil
+}
+
+func TestBatchGet(t *testing.T) {
+	getter := &stubBatchGetter{products: map[string]*entity.Product{
+		"A": {ID: "A", Name: "Notebook"},
+	}}
+	h := NewProductHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getter, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	h.BatchGet(rec, httptest.NewRequest(http.MethodPost, "/api/v1/products/batch-get", strings.NewReader(`{"ids":["A","B"]}`)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var body struct {
+		Products map[string]*struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"products"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(body.Products) != 2 {
+		t.Fatalf("Expected an entry per requested id, got %v", body.Products)
+	}
+	if body.Products["A"] == nil || body.Products["A"].Name != "Notebook" {
+		t.Errorf("Expected product A, got %+v", body.Products["A"])
+	}
+	if product, ok := body.Products["B"]; !ok || product != nil {
+		t.Errorf("Expected null for unknown id B, got %+v", product)
+	}
+}
+
+func TestBatchGet_KeepsRequestOrder(t *testing.T) {
+	getter := &stubBatchGetter{products: map[string]*entity.Product{
+		"A": {ID: "A", Name: "Notebook"},
+		"C": {ID: "C", Name: "Mouse"},
+	}}
+	h := NewProductHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getter, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	h.BatchGet(rec, httptest.NewRequest(http.MethodPost, "/api/v1/products/batch-get", strings.NewReader(`{"ids":["C","B","A","C"]}`)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	body := rec.Body.String()
+	c, b, a := strings.Index(body, `"C":`), strings.Index(body, `"B":null`), strings.Index(body, `"A":`)
+	if c < 0 || b < 0 || a < 0 || !(c < b && b < a) {
+		t.Errorf("Expected products in request order C, B, A, got %s", body)
+	}
+	if strings.Count(body, `"C":`) != 1 {
+		t.Errorf("Expected repeated id once, got %s", body)
+	}
+}
+
+func TestBatchGet_RejectsInvalidIDLists(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		code string
+	}{
+		{name: "empty list", body: `{"ids":[]}`, code: "invalid_request"},
+		{name: "over the limit", body: `{"ids":["A","B","C"]}`, code: "too_many_items"},
+		{name: "malformed body", body: `{"ids":`, code: "invalid_request"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getter := &stubBatchGetter{}
+			h := NewProductHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getter, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop()).
+				WithBatchGetLimit(2)
+
+			rec := httptest.NewRecorder()
+			h.BatchGet(rec, httptest.NewRequest(http.MethodPost, "/api/v1/products/batch-get", strings.NewReader(tt.body)))
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("Expected status 400, got %d", rec.Code)
+			}
+			if !strings.Contains(rec.Body.String(), `"`+tt.code+`"`) {
+				t.Errorf("Expected error %q, got %s", tt.code, rec.Body.String())
+			}
+			if getter.calls != 0 {
+				t.Error("Expected use case not to be called")
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// decodeStrict lê o corpo JSON em v com no máximo h.maxBodyBytes e sem
+// aceitar campos desconhecidos, para que um erro de digitação no nome de um
+// campo volte como 400 em vez de ser ignorado.
+func (h *ProductHandler) decodeStrict(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	return decodeLimited(w, r, v, h.maxBodyBytes)
+}
+
+// decodeBulk é o decodeStrict das requisições em lote, limitado a
+// h.maxBulkBodyBytes.
+func (h *ProductHandler) decodeBulk(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	return decodeLimited(w, r, v, h.maxBulkBodyBytes)
+}
+
+// decodeLimited lê o corpo JSON em v com no máximo limit bytes, recusando
+// campos desconhecidos.
+func decodeLimited(w http.ResponseWriter, r *http.Request, v interface{}, limit int64) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, limit))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+// bodyError traduz um erro de decodificação em status, código e mensagem:
+// 413 quando o corpo passou do limite e 400 nos demais, citando o campo
+// quando ele é desconhecido.
+func bodyError(err error) (int, string, string) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return http.StatusRequestEntityTooLarge, "payload_too_large",
+			fmt.Sprintf("Request body must not exceed %d bytes", tooLarge.Limit)
+	}
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return http.StatusBadRequest, "invalid_request", "Unknown field " + field + " in request body"
+	}
+	return http.StatusBadRequest, "invalid_request", "Invalid request body"
+}
+
+// respondBodyError responde o erro de decodificação traduzido por bodyError;
+// só os 400 levam o erro, já que no 413 o limite está na mensagem.
+func (h *ProductHandler) respondBodyError(w http.ResponseWriter, err error) {
+	status, code, message := bodyError(err)
+	if status == http.StatusRequestEntityTooLarge {
+		err = nil
+	}
+	h.respondError(w, status, code, message, err)
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type stubCreator struct {
+	calls int
+}
+
+func (s *stubCreator) Execute(ctx context.Context, input port.CreateProductInput) (*entity.Product, error) {
+	s.calls++
+	return &entity.Product{ID: "A", Name: input.Name, Version: 1}, nil
+}
+
+type stubUpdater struct {
+	calls int
+}
+
+func (s *stubUpdater) Execute(ctx context.Context, id string, input port.UpdateProductInput) (*entity.Product, error) {
+	s.calls++
+	return &entity.Product{ID: id, Name: input.Name, Version: 2}, nil
+}
+
+func TestCreateAndUpdate_RequestBody(t *testing.T) {
+	creator := &stubCreator{}
+	updater := &stubUpdater{}
+	h := NewProductHandler(creator, nil, updater, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop()).
+		WithBodyLimit(256)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		code   string
+	}{
+		{name: "valid", body: `{"name":"Notebook","category":"Laptops"}`, status: 0},
+		{name: "unknown field", body: `{"nmae":"Notebook","category":"Laptops"}`, status: http.StatusBadRequest, code: "invalid_request"},
+		{name: "read-only field", body: `{"name":"Notebook","available":true}`, status: http.StatusBadRequest, code: "invalid_request"},
+		{name: "too large", body: `{"name":"Notebook","description":"` + strings.Repeat("x", 300) + `"}`, status: http.StatusRequestEntityTooLarge, code: "payload_too_large"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.Create(rec, httptest.NewRequest(http.MethodPost, "/api/v1/products", strings.NewReader(tt.body)))
+			assertBodyResponse(t, "create", rec, http.StatusCreated, tt.status, tt.code)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/products/A", strings.NewReader(tt.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "A")
+			rec = httptest.NewRecorder()
+			h.Update(rec, req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx)))
+			assertBodyResponse(t, "update", rec, http.StatusOK, tt.status, tt.code)
+		})
+	}
+
+	if creator.calls != 1 || updater.calls != 1 {
+		t.Errorf("Expected only the valid body to reach the use cases, got %d creates and %d updates", creator.calls, updater.calls)
+	}
+}
+
+func assertBodyResponse(t *testing.T, op string, rec *httptest.ResponseRecorder, okStatus, status int, code string) {
+	t.Helper()
+
+	if status == 0 {
+		status = okStatus
+	}
+	if rec.Code != status {
+		t.Fatalf("%s: expected status %d, got %d: %s", op, status, rec.Code, rec.Body.String())
+	}
+	if code == "" {
+		return
+	}
+
+	var body struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("%s: expected JSON error body, got %v", op, err)
+	}
+	if body.Error != code {
+		t.Errorf("%s: expected error %q, got %q", op, code, body.Error)
+	}
+}
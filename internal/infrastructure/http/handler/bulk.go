@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// DefaultBulkLimit é o máximo de itens por requisição em lote (criação,
+// ajuste de estoque, importação e batch-get), salvo WithBulkLimit.
+const DefaultBulkLimit = 500
+
+// checkBulkSize valida a quantidade de itens de um lote contra limit,
+// respondendo 400 com o limite na mensagem quando o lote está vazio ou o
+// excede. Todos os endpoints em lote passam por aqui.
+func (h *ProductHandler) checkBulkSize(w http.ResponseWriter, field string, items, limit int) bool {
+	if items == 0 {
+		h.respondError(w, http.StatusBadRequest, "invalid_request", fmt.Sprintf("%s must contain at least one item", field), nil)
+		return false
+	}
+	if items > limit {
+		h.respondError(w, http.StatusBadRequest, "too_many_items", fmt.Sprintf("%s must contain at most %d items, got %d", field, limit, items), nil)
+		return false
+	}
+	return true
+}
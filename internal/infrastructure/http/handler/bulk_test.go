@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestBulkEndpoints_EnforceBulkLimit(t *testing.T) {
+	h := NewProductHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &stubBatchGetter{}, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop()).
+		WithBatchGetLimit(100).
+		WithBulkLimit(2)
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		body    string
+		code    string
+	}{
+		{name: "bulk create empty", handler: h.BulkCreate, body: `[]`, code: "invalid_request"},
+		{name: "bulk create over limit", handler: h.BulkCreate, body: `[{},{},{}]`, code: "too_many_items"},
+		{name: "bulk stock over limit", handler: h.BulkAdjustStock, body: `{"adjustments":[{},{},{}]}`, code: "too_many_items"},
+		{name: "import over limit", handler: h.Import, body: `{"products":[{},{},{}]}`, code: "too_many_items"},
+		{name: "batch get over bulk limit", handler: h.BatchGet, body: `{"ids":["A","B","C"]}`, code: "too_many_items"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tt.handler(rec, httptest.NewRequest(http.MethodPost, "/api/v1/products/bulk", strings.NewReader(tt.body)))
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("Expected status 400, got %d: %s", rec.Code, rec.Body.String())
+			}
+			var body struct {
+				Error   string `json:"error"`
+				Message string `json:"message"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Expected JSON error body, got %v", err)
+			}
+			if body.Error != tt.code {
+				t.Errorf("Expected error %q, got %q", tt.code, body.Error)
+			}
+			if tt.code == "too_many_items" && !strings.Contains(body.Message, "at most 2 items") {
+				t.Errorf("Expected the limit in the message, got %q", body.Message)
+			}
+		})
+	}
+}
+
+func TestBulkEndpoints_RequestBody(t *testing.T) {
+	h := NewProductHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &stubBatchGetter{}, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop()).
+		WithBulkLimit(2).
+		WithBodyLimit(16).
+		WithBulkBodyLimit(64)
+
+	large := strings.Repeat("x", 100)
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		body    string
+		status  int
+		code    string
+	}{
+		{name: "bulk create unknown field", handler: h.BulkCreate, body: `[{"nmae":"Mouse"}]`, status: http.StatusBadRequest, code: "invalid_request"},
+		{name: "bulk create too large", handler: h.BulkCreate, body: `[{"name":"` + large + `"}]`, status: http.StatusRequestEntityTooLarge, code: "payload_too_large"},
+		{name: "bulk stock unknown field", handler: h.BulkAdjustStock, body: `{"adjustment":[]}`, status: http.StatusBadRequest, code: "invalid_request"},
+		{name: "bulk stock too large", handler: h.BulkAdjustStock, body: `{"adjustments":[{"id":"` + large + `"}]}`, status: http.StatusRequestEntityTooLarge, code: "payload_too_large"},
+		{name: "import unknown field", handler: h.Import, body: `{"items":[]}`, status: http.StatusBadRequest, code: "invalid_request"},
+		{name: "batch get too large", handler: h.BatchGet, body: `{"ids":["` + large + `"]}`, status: http.StatusRequestEntityTooLarge, code: "payload_too_large"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tt.handler(rec, httptest.NewRequest(http.MethodPost, "/api/v1/products/bulk", strings.NewReader(tt.body)))
+			assertBodyResponse(t, tt.name, rec, 0, tt.status, tt.code)
+		})
+	}
+
+	// O limite do lote não depende do limite de um produto só: o corpo passa
+	// dos 16 bytes e chega à contagem de itens.
+	rec := httptest.NewRecorder()
+	h.BulkCreate(rec, httptest.NewRequest(http.MethodPost, "/api/v1/products/bulk", strings.NewReader(`[{},{},{}]`+strings.Repeat(" ", 20))))
+	assertBodyResponse(t, "bulk create above the body limit", rec, 0, http.StatusBadRequest, "too_many_items")
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/dto"
+	"go.uber.org/zap"
+)
+
+type stubCounter struct {
+	categories []string
+}
+
+func (s *stubCounter) Count(ctx context.Context, filter repository.ListFilter) (int, error) {
+	return 42, nil
+}
+
+func (s *stubCounter) CountByName(ctx context.Context, name string, filter repository.ListFilter) (int, error) {
+	return 0, nil
+}
+
+func (s *stubCounter) CountByCategory(ctx context.Context, category string, filter repository.ListFilter) (int, error) {
+	s.categories = append(s.categories, category)
+	return 7, nil
+}
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		status int
+		want   dto.CountResponse
+	}{
+		{name: "total", target: "/api/v1/products/count", status: http.StatusOK, want: dto.CountResponse{Total: 42}},
+		{name: "category", target: "/api/v1/products/count?category=Laptops", status: http.StatusOK, want: dto.CountResponse{Total: 7, Category: "Laptops"}},
+		{name: "empty category", target: "/api/v1/products/count?category=%20", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewProductHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &stubCounter{}, nil, nil, nil, nil, nil, nil, zap.NewNop())
+
+			rec := httptest.NewRecorder()
+			h.Count(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			if rec.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			var got dto.CountResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("Expected JSON body, got %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
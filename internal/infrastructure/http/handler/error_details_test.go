@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/dto"
+	"go.uber.org/zap"
+)
+
+func TestErrorChain(t *testing.T) {
+	root := errors.New("connection refused")
+	err := fmt.Errorf("failed to save product: %w", errors.Join(root, errors.New("retry exhausted")))
+
+	want := []string{
+		"failed to save product: connection refused\nretry exhausted",
+		"connection refused\nretry exhausted",
+		"connection refused",
+		"retry exhausted",
+	}
+	if got := errorChain(err); !reflect.DeepEqual(got, want) {
+		t.Errorf("errorChain() = %q, want %q", got, want)
+	}
+}
+
+func TestRespondError_Details(t *testing.T) {
+	err := fmt.Errorf("failed to save product: %w", errors.New("connection refused"))
+
+	tests := []struct {
+		name    string
+		enabled bool
+		want    []string
+	}{
+		{"development includes chain", true, []string{"failed to save product: connection refused", "connection refused"}},
+		{"production omits chain", false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := (&ProductHandler{logger: zap.NewNop()}).WithErrorDetails(tt.enabled)
+			w := httptest.NewRecorder()
+
+			h.respondError(w, http.StatusInternalServerError, "internal_error", "Failed to create product", err)
+
+			var body dto.ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Expected valid JSON, got %v", err)
+			}
+			if body.Message != "Failed to create product" || !reflect.DeepEqual(body.Details, tt.want) {
+				t.Errorf("Unexpected error response %+v", body)
+			}
+		})
+	}
+}
+
+func TestHandleDomainError_ValidationFields(t *testing.T) {
+	h := &ProductHandler{logger: zap.NewNop()}
+	invalid := entity.ValidationErrors{}
+	invalid.Add("name", entity.ErrInvalidName)
+	invalid.Add("stock", entity.ErrInvalidStock)
+	w := httptest.NewRecorder()
+
+	h.handleDomainError(w, fmt.Errorf("invalid product data: %w", invalid), "Failed to create product")
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status 422, got %d", w.Code)
+	}
+	var body dto.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	want := map[string]string{"name": "is required", "stock": "cannot be negative"}
+	if body.Error != "validation_error" || !reflect.DeepEqual(body.Fields, want) {
+		t.Errorf("Unexpected error response %+v", body)
+	}
+}
+
+func TestTranslateDomainError_DeletedProduct(t *testing.T) {
+	deleted := TranslateDomainError(fmt.Errorf("failed to save product: %w", repository.ErrProductDeleted))
+	if deleted.StatusCode != http.StatusConflict || deleted.Code != "product_deleted" {
+		t.Errorf("Expected 409 product_deleted, got %d %s", deleted.StatusCode, deleted.Code)
+	}
+
+	active := TranslateDomainError(repository.ErrProductAlreadyExists)
+	if active.StatusCode != http.StatusConflict || active.Code != "product_exists" {
+		t.Errorf("Expected 409 product_exists, got %d %s", active.StatusCode, active.Code)
+	}
+}
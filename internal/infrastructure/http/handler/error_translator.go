@@ -13,6 +13,7 @@ type HTTPError struct {
 	StatusCode int
 	Code       string
 	Message    string
+	Fields     map[string]string
 }
 
 // TranslateDomainError traduz erros de domínio para erros HTTP.
@@ -32,6 +33,14 @@ func TranslateDomainError(err error) *HTTPError {
 		}
 	}
 
+	if errors.Is(err, repository.ErrProductDeleted) {
+		return &HTTPError{
+			StatusCode: http.StatusConflict,
+			Code:       "product_deleted",
+			Message:    "Product was deleted; restore it with POST /api/v1/products/{id}/restore",
+		}
+	}
+
 	if errors.Is(err, repository.ErrProductAlreadyExists) {
 		return &HTTPError{
 			StatusCode: http.StatusConflict,
@@ -40,6 +49,22 @@ func TranslateDomainError(err error) *HTTPError {
 		}
 	}
 
+	if errors.Is(err, entity.ErrDuplicateNameInCategory) {
+		return &HTTPError{
+			StatusCode: http.StatusConflict,
+			Code:       "duplicate_name",
+			Message:    "A product with the same name already exists in this category",
+		}
+	}
+
+	if errors.Is(err, entity.ErrInsufficientStock) {
+		return &HTTPError{
+			StatusCode: http.StatusConflict,
+			Code:       "insufficient_stock",
+			Message:    "Stock adjustment would make stock negative",
+		}
+	}
+
 	if errors.Is(err, repository.ErrVersionConflict) {
 		return &HTTPError{
 			StatusCode: http.StatusConflict,
@@ -48,7 +73,41 @@ func TranslateDomainError(err error) *HTTPError {
 		}
 	}
 
+	if errors.Is(err, entity.ErrPreconditionFailed) {
+		return &HTTPError{
+			StatusCode: http.StatusPreconditionFailed,
+			Code:       "precondition_failed",
+			Message:    "Product version does not match If-Match",
+		}
+	}
+
+	if errors.Is(err, repository.ErrSerializationFailure) {
+		return &HTTPError{
+			StatusCode: http.StatusConflict,
+			Code:       "serialization_conflict",
+			Message:    "Concurrent modification detected, please retry",
+		}
+	}
+
+	if errors.Is(err, repository.ErrCircuitOpen) {
+		return &HTTPError{
+			StatusCode: http.StatusServiceUnavailable,
+			Code:       "service_unavailable",
+			Message:    "Database temporarily unavailable, please retry later",
+		}
+	}
+
 	// Erros de validação de entidade
+	var invalid entity.ValidationErrors
+	if errors.As(err, &invalid) {
+		return &HTTPError{
+			StatusCode: http.StatusUnprocessableEntity,
+			Code:       "validation_error",
+			Message:    "One or more fields are invalid",
+			Fields:     invalid.Messages(),
+		}
+	}
+
 	if errors.Is(err, entity.ErrInvalidName) {
 		return &HTTPError{
 			StatusCode: http.StatusBadRequest,
@@ -81,6 +140,78 @@ func TranslateDomainError(err error) *HTTPError {
 		}
 	}
 
+	if errors.Is(err, entity.ErrInvalidPrice) {
+		return &HTTPError{
+			StatusCode: http.StatusBadRequest,
+			Code:       "validation_error",
+			Message:    "Invalid price value",
+		}
+	}
+
+	if errors.Is(err, entity.ErrInvalidThumbnailURL) {
+		return &HTTPError{
+			StatusCode: http.StatusBadRequest,
+			Code:       "validation_error",
+			Message:    "Invalid thumbnail URL",
+		}
+	}
+
+	if errors.Is(err, entity.ErrInvalidWeight) {
+		return &HTTPError{
+			StatusCode: http.StatusBadRequest,
+			Code:       "validation_error",
+			Message:    "Invalid weight value",
+		}
+	}
+
+	if errors.Is(err, entity.ErrInvalidDimensions) {
+		return &HTTPError{
+			StatusCode: http.StatusBadRequest,
+			Code:       "validation_error",
+			Message:    "Invalid dimensions",
+		}
+	}
+
+	if errors.Is(err, entity.ErrInvalidTag) {
+		return &HTTPError{
+			StatusCode: http.StatusBadRequest,
+			Code:       "validation_error",
+			Message:    "Tags must have between 1 and 50 characters",
+		}
+	}
+
+	if errors.Is(err, entity.ErrTooManyTags) {
+		return &HTTPError{
+			StatusCode: http.StatusBadRequest,
+			Code:       "validation_error",
+			Message:    "Too many tags",
+		}
+	}
+
+	if errors.Is(err, entity.ErrSpecificationsTooDeep) {
+		return &HTTPError{
+			StatusCode: http.StatusBadRequest,
+			Code:       "validation_error",
+			Message:    "Specifications are nested too deeply",
+		}
+	}
+
+	if errors.Is(err, entity.ErrCategoryNotAllowed) {
+		return &HTTPError{
+			StatusCode: http.StatusBadRequest,
+			Code:       "validation_error",
+			Message:    "Category is not in the allowed list",
+		}
+	}
+
+	if errors.Is(err, entity.ErrMissingIDField) {
+		return &HTTPError{
+			StatusCode: http.StatusBadRequest,
+			Code:       "validation_error",
+			Message:    "A field required for the product identity is empty",
+		}
+	}
+
 	// Erro desconhecido - retorna nil para que o handler trate como erro interno
 	return nil
 }
@@ -90,7 +221,16 @@ func IsValidationError(err error) bool {
 	return errors.Is(err, entity.ErrInvalidName) ||
 		errors.Is(err, entity.ErrInvalidReference) ||
 		errors.Is(err, entity.ErrInvalidCategory) ||
-		errors.Is(err, entity.ErrInvalidStock)
+		errors.Is(err, entity.ErrCategoryNotAllowed) ||
+		errors.Is(err, entity.ErrMissingIDField) ||
+		errors.Is(err, entity.ErrInvalidThumbnailURL) ||
+		errors.Is(err, entity.ErrInvalidWeight) ||
+		errors.Is(err, entity.ErrInvalidDimensions) ||
+		errors.Is(err, entity.ErrInvalidTag) ||
+		errors.Is(err, entity.ErrTooManyTags) ||
+		errors.Is(err, entity.ErrSpecificationsTooDeep) ||
+		errors.Is(err, entity.ErrInvalidStock) ||
+		errors.Is(err, entity.ErrInvalidPrice)
 }
 
 // IsNotFoundError verifica se o erro é um erro de não encontrado.
@@ -101,5 +241,8 @@ func IsNotFoundError(err error) bool {
 // IsConflictError verifica se o erro é um erro de conflito.
 func IsConflictError(err error) bool {
 	return errors.Is(err, repository.ErrProductAlreadyExists) ||
-		errors.Is(err, repository.ErrVersionConflict)
+		errors.Is(err, entity.ErrDuplicateNameInCategory) ||
+		errors.Is(err, entity.ErrInsufficientStock) ||
+		errors.Is(err, repository.ErrVersionConflict) ||
+		errors.Is(err, repository.ErrSerializationFailure)
 }
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/middleware"
+)
+
+var (
+	errInvalidIfMatch      = errors.New(`If-Match must be "*", a quoted product version or the product ETag`)
+	errIfMatchOtherProduct = errors.New("If-Match names another product")
+)
+
+// resultsETag calcula um ETag fraco sobre a sequência ordenada de ID+versão
+// dos produtos. Qualquer entrada, saída, reordenação ou nova versão de um
+// produto muda o valor.
+func resultsETag(products []*entity.Product) string {
+	hash := sha256.New()
+	for _, product := range products {
+		hash.Write([]byte(product.ID))
+		hash.Write([]byte{':'})
+		hash.Write([]byte(strconv.Itoa(product.Version)))
+		hash.Write([]byte{';'})
+	}
+	return `W/"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+}
+
+// productETag identifica a versão de um produto: W/"<id>-<versão>". Version
+// muda a cada escrita, então o valor muda junto com o conteúdo.
+func productETag(product *entity.Product) string {
+	return `W/"` + product.ID + "-" + strconv.Itoa(product.Version) + `"`
+}
+
+// etagMatches aplica a comparação fraca do If-None-Match (RFC 9110 13.1.2).
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	target := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == target {
+			return true
+		}
+	}
+	return false
+}
+
+// notModified define o ETag da resposta e, se o cliente já tem essa versão,
+// responde 304 e retorna true.
+func notModified(w http.ResponseWriter, r *http.Request, products []*entity.Product) bool {
+	return notModifiedETag(w, r, resultsETag(products))
+}
+
+// notModifiedETag é o notModified para um ETag já calculado. O 304 sai sem
+// corpo, mas com o Vary: Accept da resposta negociada que ele substitui.
+func notModifiedETag(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+	middleware.AddVary(w.Header(), "Accept")
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return true
+	}
+	return false
+}
+
+// ifMatchVersion lê a versão de um If-Match para o produto id. Aceita o
+// ETag devolvido nas leituras, W/"<id>-<versão>" ou "<id>-<versão>", e o
+// formato curto "<versão>". Sem header ou com "*", conditional é false e a
+// escrita segue incondicional. O ETag fraco é aceito porque a versão
+// identifica exatamente o conteúdo do produto; um W/"<versão>" solto continua
+// inválido. Um ETag de outro produto retorna errIfMatchOtherProduct.
+func ifMatchVersion(ifMatch, id string) (version int, conditional bool, err error) {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" || ifMatch == "*" {
+		return 0, false, nil
+	}
+
+	tag := strings.TrimPrefix(ifMatch, "W/")
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, false, errInvalidIfMatch
+	}
+	value := tag[1 : len(tag)-1]
+
+	sep := strings.LastIndexByte(value, '-')
+	switch {
+	case sep > 0:
+		if value[:sep] != id {
+			return 0, false, errIfMatchOtherProduct
+		}
+		value = value[sep+1:]
+	case len(tag) != len(ifMatch):
+		return 0, false, errInvalidIfMatch
+	}
+
+	version, err = strconv.Atoi(value)
+	if err != nil || version < 1 {
+		return 0, false, errInvalidIfMatch
+	}
+	return version, true, nil
+}
+
+// ifMatch lê o If-Match de uma escrita no produto id. Um valor fora do
+// formato responde 400 e um ETag de outro produto, 412; nesses casos ok é
+// false e a resposta já foi escrita.
+func (h *ProductHandler) ifMatch(w http.ResponseWriter, r *http.Request, id string) (version int, conditional, ok bool) {
+	version, conditional, err := ifMatchVersion(r.Header.Get("If-Match"), id)
+	switch {
+	case errors.Is(err, errIfMatchOtherProduct):
+		h.respondError(w, http.StatusPreconditionFailed, "precondition_failed", err.Error(), nil)
+		return 0, false, false
+	case err != nil:
+		h.respondError(w, http.StatusBadRequest, "invalid_if_match", err.Error(), nil)
+		return 0, false, false
+	}
+	return version, conditional, true
+}
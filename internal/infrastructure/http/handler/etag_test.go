@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+)
+
+func TestResultsETag(t *testing.T) {
+	a := &entity.Product{ID: "A", Version: 1}
+	b := &entity.Product{ID: "B", Version: 1}
+
+	base := resultsETag([]*entity.Product{a, b})
+
+	if base != resultsETag([]*entity.Product{{ID: "A", Version: 1}, {ID: "B", Version: 1}}) {
+		t.Error("Expected identical results to produce the same ETag")
+	}
+
+	if base == resultsETag([]*entity.Product{b, a}) {
+		t.Error("Expected reordered results to change the ETag")
+	}
+
+	if base == resultsETag([]*entity.Product{a, {ID: "B", Version: 2}}) {
+		t.Error("Expected a version bump to change the ETag")
+	}
+
+	if base == resultsETag([]*entity.Product{a}) {
+		t.Error("Expected a removed result to change the ETag")
+	}
+}
+
+func TestEtagMatches(t *testing.T) {
+	etag := `W/"abc"`
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		want        bool
+	}{
+		{name: "empty header", ifNoneMatch: "", want: false},
+		{name: "exact weak match", ifNoneMatch: `W/"abc"`, want: true},
+		{name: "strong form matches weakly", ifNoneMatch: `"abc"`, want: true},
+		{name: "list with match", ifNoneMatch: `"x", W/"abc"`, want: true},
+		{name: "wildcard", ifNoneMatch: "*", want: true},
+		{name: "different tag", ifNoneMatch: `W/"def"`, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := etagMatches(tt.ifNoneMatch, etag); got != tt.want {
+				t.Errorf("etagMatches(%q) = %v, want %v", tt.ifNoneMatch, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIfMatchVersion(t *testing.T) {
+	tests := []struct {
+		name            string
+		ifMatch         string
+		wantVersion     int
+		wantConditional bool
+		id              string
+		wantErr         bool
+	}{
+		{name: "absent", ifMatch: ""},
+		{name: "any", ifMatch: "*"},
+		{name: "quoted version", ifMatch: `"3"`, wantVersion: 3, wantConditional: true},
+		{name: "surrounding spaces", ifMatch: ` "12" `, wantVersion: 12, wantConditional: true},
+		{name: "unquoted", ifMatch: "3", wantErr: true},
+		{name: "weak", ifMatch: `W/"3"`, wantErr: true},
+		{name: "not a number", ifMatch: `"abc"`, wantErr: true},
+		{name: "zero", ifMatch: `"0"`, wantErr: true},
+		{name: "list", ifMatch: `"3", "4"`, wantErr: true},
+		{name: "weak product etag", ifMatch: `W/"A-3"`, wantVersion: 3, wantConditional: true},
+		{name: "strong product etag", ifMatch: `"A-3"`, wantVersion: 3, wantConditional: true},
+		{name: "hyphenated id", ifMatch: `W/"a-b-c-7"`, id: "a-b-c", wantVersion: 7, wantConditional: true},
+		{name: "other product", ifMatch: `W/"B-3"`, wantErr: true},
+		{name: "etag without version", ifMatch: `W/"A-"`, wantErr: true},
+		{name: "negative version", ifMatch: `"-3"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := tt.id
+			if id == "" {
+				id = "A"
+			}
+			version, conditional, err := ifMatchVersion(tt.ifMatch, id)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ifMatchVersion(%q) error = %v, wantErr %v", tt.ifMatch, err, tt.wantErr)
+			}
+			if version != tt.wantVersion || conditional != tt.wantConditional {
+				t.Errorf("ifMatchVersion(%q) = %d, %v, want %d, %v", tt.ifMatch, version, conditional, tt.wantVersion, tt.wantConditional)
+			}
+		})
+	}
+}
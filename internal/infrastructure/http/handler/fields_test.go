@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/middleware"
+	"go.uber.org/zap"
+)
+
+func TestRespondProductList_SpecFields(t *testing.T) {
+	h := &ProductHandler{logger: zap.NewNop()}
+	newProducts := func() []*entity.Product {
+		return []*entity.Product{{
+			ID:   "A",
+			Name: "Notebook",
+			Specifications: map[string]interface{}{
+				"color":  "silver",
+				"weight": 1.4,
+				"display": map[string]interface{}{
+					"size":       15.6,
+					"resolution": "1920x1080",
+				},
+			},
+		}}
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  map[string]interface{}
+	}{
+		{
+			name:  "no fields",
+			query: "",
+			want:  newProducts()[0].Specifications,
+		},
+		{
+			name:  "top level key",
+			query: "?fields=specifications.color",
+			want:  map[string]interface{}{"color": "silver"},
+		},
+		{
+			name:  "nested key keeps parents",
+			query: "?fields=specifications.display.size,specifications.weight",
+			want: map[string]interface{}{
+				"weight":  1.4,
+				"display": map[string]interface{}{"size": 15.6},
+			},
+		},
+		{
+			name:  "parent covers child",
+			query: "?fields=specifications.display.size,specifications.display",
+			want: map[string]interface{}{
+				"display": map[string]interface{}{"size": 15.6, "resolution": "1920x1080"},
+			},
+		},
+		{
+			name:  "missing keys are skipped",
+			query: "?fields=specifications.battery,specifications.color.shade",
+			want:  map[string]interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			products := newProducts()
+			w := httptest.NewRecorder()
+			middleware.SpecFields(false)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				h.respondProductList(w, r, products, nil)
+			})).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/products"+tt.query, nil))
+
+			var body []struct {
+				Name           string                 `json:"name"`
+				Specifications map[string]interface{} `json:"specifications"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatalf("Expected JSON array, got %v", err)
+			}
+			if len(body) != 1 {
+				t.Fatalf("Expected 1 product, got %d", len(body))
+			}
+			if body[0].Name != "Notebook" {
+				t.Errorf("Expected other fields to stay, got name %q", body[0].Name)
+			}
+			if !reflect.DeepEqual(body[0].Specifications, tt.want) {
+				t.Errorf("Expected specifications %v, got %v", tt.want, body[0].Specifications)
+			}
+			if !reflect.DeepEqual(products[0].Specifications, newProducts()[0].Specifications) {
+				t.Error("Expected the product specifications to stay untouched")
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+const (
+	invalidInStockMessage       = "in_stock must be true or false"
+	invalidPriceRangeMessage    = "min_price and max_price must be non-negative integers (cents) with min_price <= max_price"
+	invalidShippingRangeMessage = "min_weight/max_weight (grams) and min_volume/max_volume (cm³) must be non-negative integers with min <= max"
+)
+
+// maxVolumeCM3 mantém o limite de volume convertido para mm³ dentro do int64.
+const maxVolumeCM3 = math.MaxInt64 / 1000
+
+// parseListFilter lê in_stock da listagem. Ausente, não filtra; qualquer
+// valor além de true e false é inválido.
+func parseListFilter(r *http.Request) (repository.ListFilter, bool) {
+	var filter repository.ListFilter
+	switch r.URL.Query().Get("in_stock") {
+	case "":
+	case "true":
+		inStock := true
+		filter.InStock = &inStock
+	case "false":
+		inStock := false
+		filter.InStock = &inStock
+	default:
+		return repository.ListFilter{}, false
+	}
+	return filter, true
+}
+
+// parsePriceRange lê min_price e max_price, em centavos. Cada um é opcional;
+// com os dois, min_price não pode passar de max_price.
+func parsePriceRange(r *http.Request) (minPrice, maxPrice *int64, ok bool) {
+	return parseRange(r, "min_price", "max_price", math.MaxInt64)
+}
+
+// parseShippingRange lê as faixas de frete: min_weight e max_weight, em
+// gramas, e min_volume e max_volume, em cm³. Produtos sem peso ou medidas
+// ficam de fora da faixa correspondente (ver repository.ListFilter).
+func parseShippingRange(r *http.Request, filter *repository.ListFilter) bool {
+	var ok bool
+	if filter.MinWeight, filter.MaxWeight, ok = parseRange(r, "min_weight", "max_weight", math.MaxInt32); !ok {
+		return false
+	}
+	filter.MinVolume, filter.MaxVolume, ok = parseRange(r, "min_volume", "max_volume", maxVolumeCM3)
+	return ok
+}
+
+// parseRange lê o par de limites inteiros informado. Cada um é opcional, não
+// pode passar de limit e, com os dois, o mínimo não pode passar do máximo.
+func parseRange(r *http.Request, minParam, maxParam string, limit int64) (low, high *int64, ok bool) {
+	query := r.URL.Query()
+
+	low, ok = parsePrice(query.Get(minParam))
+	if !ok || (low != nil && *low > limit) {
+		return nil, nil, false
+	}
+	high, ok = parsePrice(query.Get(maxParam))
+	if !ok || (high != nil && *high > limit) {
+		return nil, nil, false
+	}
+	if low != nil && high != nil && *low > *high {
+		return nil, nil, false
+	}
+	return low, high, true
+}
+
+// parsePrice lê um inteiro não negativo; vazio é ausente.
+func parsePrice(raw string) (*int64, bool) {
+	if raw == "" {
+		return nil, true
+	}
+	price, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || price < 0 {
+		return nil, false
+	}
+	return &price, true
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+func TestParseListFilter(t *testing.T) {
+	tests := []struct {
+		query   string
+		inStock *bool
+		ok      bool
+	}{
+		{query: "", ok: true},
+		{query: "?in_stock=true", inStock: boolPtr(true), ok: true},
+		{query: "?in_stock=false", inStock: boolPtr(false), ok: true},
+		{query: "?in_stock=1", ok: false},
+		{query: "?in_stock=yes", ok: false},
+	}
+
+	for _, tt := range tests {
+		filter, ok := parseListFilter(httptest.NewRequest("GET", "/api/v1/products"+tt.query, nil))
+		if ok != tt.ok {
+			t.Errorf("parseListFilter(%q): expected ok %v, got %v", tt.query, tt.ok, ok)
+			continue
+		}
+		if (filter.InStock == nil) != (tt.inStock == nil) || (filter.InStock != nil && *filter.InStock != *tt.inStock) {
+			t.Errorf("parseListFilter(%q): expected in_stock %v, got %v", tt.query, tt.inStock, filter.InStock)
+		}
+	}
+}
+
+func TestParsePriceRange(t *testing.T) {
+	tests := []struct {
+		query    string
+		minPrice int64
+		maxPrice int64
+		ok       bool
+	}{
+		{query: "", minPrice: -1, maxPrice: -1, ok: true},
+		{query: "?min_price=1000", minPrice: 1000, maxPrice: -1, ok: true},
+		{query: "?max_price=5000", minPrice: -1, maxPrice: 5000, ok: true},
+		{query: "?min_price=1000&max_price=1000", minPrice: 1000, maxPrice: 1000, ok: true},
+		{query: "?min_price=0&max_price=5000", minPrice: 0, maxPrice: 5000, ok: true},
+		{query: "?min_price=5000&max_price=1000", ok: false},
+		{query: "?min_price=-1", ok: false},
+		{query: "?max_price=10.50", ok: false},
+		{query: "?min_price=abc", ok: false},
+	}
+
+	for _, tt := range tests {
+		minPrice, maxPrice, ok := parsePriceRange(httptest.NewRequest("GET", "/api/v1/products"+tt.query, nil))
+		if ok != tt.ok {
+			t.Errorf("parsePriceRange(%q): expected ok %v, got %v", tt.query, tt.ok, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if got := priceOrNone(minPrice); got != tt.minPrice {
+			t.Errorf("parsePriceRange(%q): expected min_price %d, got %d", tt.query, tt.minPrice, got)
+		}
+		if got := priceOrNone(maxPrice); got != tt.maxPrice {
+			t.Errorf("parsePriceRange(%q): expected max_price %d, got %d", tt.query, tt.maxPrice, got)
+		}
+	}
+}
+
+func TestParseShippingRange(t *testing.T) {
+	tests := []struct {
+		query     string
+		maxWeight int64
+		minVolume int64
+		ok        bool
+	}{
+		{query: "", maxWeight: -1, minVolume: -1, ok: true},
+		{query: "?max_weight=1000", maxWeight: 1000, minVolume: -1, ok: true},
+		{query: "?min_weight=0&max_weight=1000&min_volume=500", maxWeight: 1000, minVolume: 500, ok: true},
+		{query: "?min_weight=2000&max_weight=1000", ok: false},
+		{query: "?min_volume=10&max_volume=5", ok: false},
+		{query: "?max_weight=-1", ok: false},
+		{query: "?max_weight=1.5", ok: false},
+		{query: "?max_weight=99999999999", ok: false},
+		{query: "?max_volume=9223372036854775807", ok: false},
+	}
+
+	for _, tt := range tests {
+		var filter repository.ListFilter
+		ok := parseShippingRange(httptest.NewRequest("GET", "/api/v1/products"+tt.query, nil), &filter)
+		if ok != tt.ok {
+			t.Errorf("parseShippingRange(%q): expected ok %v, got %v", tt.query, tt.ok, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if got := priceOrNone(filter.MaxWeight); got != tt.maxWeight {
+			t.Errorf("parseShippingRange(%q): expected max_weight %d, got %d", tt.query, tt.maxWeight, got)
+		}
+		if got := priceOrNone(filter.MinVolume); got != tt.minVolume {
+			t.Errorf("parseShippingRange(%q): expected min_volume %d, got %d", tt.query, tt.minVolume, got)
+		}
+	}
+}
+
+func priceOrNone(price *int64) int64 {
+	if price == nil {
+		return -1
+	}
+	return *price
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/dto"
+	"go.uber.org/zap"
+)
+
+const geoJSONContentType = "application/geo+json"
+
+// GeoFeed godoc
+// @Summary      Feed GeoJSON de produtos
+// @Description  Exporta como FeatureCollection GeoJSON os produtos cujas specifications têm lat e lng numéricos e dentro das faixas (lat entre -90 e 90, lng entre -180 e 180). Produtos sem coordenadas, ou com coordenadas inválidas, ficam de fora. O catálogo é lido do banco em páginas e a resposta é escrita à medida que os produtos chegam.
+// @Tags         products
+// @Produce      application/geo+json
+// @Success      200  {object}  dto.GeoFeatureCollection
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Failure      503  {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/products/geo [get]
+func (h *ProductHandler) GeoFeed(w http.ResponseWriter, r *http.Request) {
+	if h.geoExporter == nil {
+		h.respondError(w, http.StatusNotFound, "not_found", "Geo feed is not enabled", nil)
+		return
+	}
+
+	// O cabeçalho só sai com a primeira feature, para que uma falha na
+	// primeira página ainda vire uma resposta de erro.
+	started := false
+	start := func() error {
+		started = true
+		w.Header().Set("Content-Type", geoJSONContentType)
+		w.WriteHeader(http.StatusOK)
+		_, err := io.WriteString(w, `{"type":"FeatureCollection","features":[`)
+		return err
+	}
+
+	err := h.geoExporter.Execute(r.Context(), func(feature port.GeoFeature) error {
+		separator := ","
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+			separator = ""
+		}
+
+		data, err := json.Marshal(dto.ToGeoFeature(feature))
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, separator); err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	})
+	if err != nil {
+		if !started {
+			h.handleDomainError(w, err, "Failed to export geo feed")
+			return
+		}
+		// Com a resposta já começada, só resta interromper o corpo; o JSON
+		// incompleto sinaliza a falha ao cliente.
+		h.logger.Error("geo feed interrupted", zap.Error(err))
+		return
+	}
+
+	if !started {
+		if err := start(); err != nil {
+			h.logger.Error("failed to write response", zap.Error(err))
+			return
+		}
+	}
+	if _, err := io.WriteString(w, "]}\n"); err != nil {
+		h.logger.Error("failed to write response", zap.Error(err))
+	}
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+type stubGeoExporter struct {
+	features []port.GeoFeature
+	err      error
+}
+
+func (s *stubGeoExporter) Execute(ctx context.Context, emit func(feature port.GeoFeature) error) error {
+	for _, feature := range s.features {
+		if err := emit(feature); err != nil {
+			return err
+		}
+	}
+	return s.err
+}
+
+func TestGeoFeed(t *testing.T) {
+	store := &entity.Product{ID: "A", Name: "Loja Paulista", Stock: 3, Price: 1990, Currency: "BRL"}
+	other := &entity.Product{ID: "B", Name: "Loja Centro"}
+
+	tests := []struct {
+		name     string
+		exporter *stubGeoExporter
+		ids      []string
+	}{
+		{name: "features", exporter: &stubGeoExporter{features: []port.GeoFeature{
+			{Product: store, Latitude: -23.5614, Longitude: -46.6559},
+			{Product: other, Latitude: -22.9068, Longitude: -43.1729},
+		}}, ids: []string{"A", "B"}},
+		{name: "empty catalog", exporter: &stubGeoExporter{}, ids: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &ProductHandler{logger: zap.NewNop(), geoExporter: tt.exporter}
+
+			rec := httptest.NewRecorder()
+			h.GeoFeed(rec, httptest.NewRequest(http.MethodGet, "/api/v1/products/geo", nil))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d", rec.Code)
+			}
+			if got := rec.Header().Get("Content-Type"); got != "application/geo+json" {
+				t.Errorf("Expected application/geo+json, got %q", got)
+			}
+
+			var collection struct {
+				Type     string `json:"type"`
+				Features []struct {
+					Type     string `json:"type"`
+					ID       string `json:"id"`
+					Geometry struct {
+						Type        string     `json:"type"`
+						Coordinates [2]float64 `json:"coordinates"`
+					} `json:"geometry"`
+				} `json:"features"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &collection); err != nil {
+				t.Fatalf("Expected valid JSON, got %v: %s", err, rec.Body.String())
+			}
+			if collection.Type != "FeatureCollection" || len(collection.Features) != len(tt.ids) {
+				t.Fatalf("Expected FeatureCollection with %d features, got %s", len(tt.ids), rec.Body.String())
+			}
+			for i, feature := range collection.Features {
+				if feature.ID != tt.ids[i] || feature.Type != "Feature" || feature.Geometry.Type != "Point" {
+					t.Errorf("Unexpected feature %d: %+v", i, feature)
+				}
+			}
+			if len(collection.Features) > 0 && collection.Features[0].Geometry.Coordinates != [2]float64{-46.6559, -23.5614} {
+				t.Errorf("Expected [lng, lat] coordinates, got %v", collection.Features[0].Geometry.Coordinates)
+			}
+		})
+	}
+}
+
+func TestGeoFeed_ErrorBeforeFirstFeature(t *testing.T) {
+	h := &ProductHandler{logger: zap.NewNop(), geoExporter: &stubGeoExporter{err: errors.New("database down")}}
+
+	rec := httptest.NewRecorder()
+	h.GeoFeed(rec, httptest.NewRequest(http.MethodGet, "/api/v1/products/geo", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", rec.Code)
+	}
+}
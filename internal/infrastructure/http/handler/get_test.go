@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type stubGetter struct {
+	product *entity.Product
+}
+
+func (s *stubGetter) Execute(ctx context.Context, id string) (*entity.Product, error) {
+	return s.product, nil
+}
+
+func getRequest(id, ifNoneMatch string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/"+id, nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestGet_ETag(t *testing.T) {
+	getter := &stubGetter{product: &entity.Product{ID: "A", Name: "Notebook", Version: 3}}
+	h := NewProductHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getter, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop())
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		status      int
+	}{
+		{name: "no validator", status: http.StatusOK},
+		{name: "stale version", ifNoneMatch: `W/"A-2"`, status: http.StatusOK},
+		{name: "current version", ifNoneMatch: `W/"A-3"`, status: http.StatusNotModified},
+		{name: "strong form of current version", ifNoneMatch: `"B-1", "A-3"`, status: http.StatusNotModified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.Get(rec, getRequest("A", tt.ifNoneMatch))
+
+			if rec.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d", tt.status, rec.Code)
+			}
+			if got := rec.Header().Get("ETag"); got != `W/"A-3"` {
+				t.Errorf(`Expected ETag W/"A-3", got %q`, got)
+			}
+
+			if tt.status == http.StatusNotModified {
+				if rec.Body.Len() != 0 {
+					t.Errorf("Expected empty body on 304, got %s", rec.Body.String())
+				}
+				if got := rec.Header().Get("Content-Type"); got != "" {
+					t.Errorf("Expected no Content-Type on 304, got %q", got)
+				}
+			} else if rec.Body.Len() == 0 {
+				t.Error("Expected product body on 200")
+			}
+		})
+	}
+}
@@ -4,30 +4,69 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
 	"go.uber.org/zap"
 )
 
+// HealthChecker é qualquer dependência capaz de reportar a própria saúde.
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
 type HealthHandler struct {
-	productRepo repository.ProductRepository
-	cacheRepo   repository.CacheRepository
-	logger      *zap.Logger
+	productRepo      repository.ProductRepository
+	cacheRepo        repository.CacheRepository
+	identityProvider HealthChecker
+	logger           *zap.Logger
+	started          func() bool
+	allProductsKey   string
+
+	lastErrorsMutex sync.Mutex
+	lastErrors      map[string]dependencyError
+}
+
+type dependencyError struct {
+	message    string
+	occurredAt time.Time
 }
 
 func NewHealthHandler(
 	productRepo repository.ProductRepository,
 	cacheRepo repository.CacheRepository,
+	identityProvider HealthChecker,
 	logger *zap.Logger,
 ) *HealthHandler {
 	return &HealthHandler{
-		productRepo: productRepo,
-		cacheRepo:   cacheRepo,
-		logger:      logger,
+		productRepo:      productRepo,
+		cacheRepo:        cacheRepo,
+		identityProvider: identityProvider,
+		logger:           logger,
+		lastErrors:       make(map[string]dependencyError),
 	}
 }
 
+// WithStartup faz /health/startup e /health/ready responderem 503 enquanto
+// started retornar false. Sem ela a aplicação é considerada iniciada.
+func (h *HealthHandler) WithStartup(started func() bool) *HealthHandler {
+	h.started = started
+	return h
+}
+
+// WithIndexCheck faz /health/ready conferir o índice allProductsKey: se o
+// banco tem produtos e o índice está vazio, a resposta sai como degraded, mas
+// com 200, já que as leituras seguem atendidas pelo banco.
+func (h *HealthHandler) WithIndexCheck(allProductsKey string) *HealthHandler {
+	h.allProductsKey = allProductsKey
+	return h
+}
+
+func (h *HealthHandler) isStarted() bool {
+	return h.started == nil || h.started()
+}
+
 // HealthResponse representa a resposta de health check
 // @Description Status de saúde da aplicação e seus serviços
 type HealthResponse struct {
@@ -36,6 +75,24 @@ type HealthResponse struct {
 	Services  map[string]string `json:"services"`
 }
 
+// DependencyHealth representa o resultado da verificação de uma dependência
+// @Description Status, latência e último erro observado de uma dependência
+type DependencyHealth struct {
+	Status      string     `json:"status" example:"healthy"`
+	LatencyMs   float64    `json:"latency_ms" example:"1.25"`
+	Error       string     `json:"error,omitempty" example:"redis health check failed: context deadline exceeded"`
+	LastError   string     `json:"last_error,omitempty" example:"redis health check failed: connection refused"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty" example:"2024-01-15T10:30:00Z"`
+}
+
+// DetailedHealthResponse representa a resposta do health check detalhado
+// @Description Status agregado e detalhes por dependência (database, cache, keycloak)
+type DetailedHealthResponse struct {
+	Status       string                      `json:"status" example:"healthy"`
+	Timestamp    time.Time                   `json:"timestamp" example:"2024-01-15T10:30:00Z"`
+	Dependencies map[string]DependencyHealth `json:"dependencies"`
+}
+
 // Liveness godoc
 // @Summary      Liveness check
 // @Description  Verifica se a aplicação está rodando
@@ -55,9 +112,36 @@ func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// Startup godoc
+// @Summary      Startup check
+// @Description  Verifica se a inicialização (warmup do cache) terminou; até lá as rotas /api/v1 respondem 503 com Retry-After
+// @Tags         health
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  HealthResponse
+// @Failure      503  {object}  HealthResponse
+// @Router       /health/startup [get]
+func (h *HealthHandler) Startup(w http.ResponseWriter, r *http.Request) {
+	status := "healthy"
+	statusCode := http.StatusOK
+	if !h.isStarted() {
+		status = "starting"
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	response := HealthResponse{
+		Status:    status,
+		Timestamp: time.Now().UTC(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(response)
+}
+
 // Readiness godoc
 // @Summary      Readiness check
-// @Description  Verifica se a aplicação está pronta para receber requisições (database e cache)
+// @Description  Verifica se a aplicação está pronta para receber requisições (database e cache). Com REDIS_READY_INDEX_CHECK, um índice all_products vazio com produtos no banco responde 200 com status degraded
 // @Tags         health
 // @Accept       json
 // @Produce      json
@@ -71,6 +155,11 @@ func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
 	services := make(map[string]string)
 	allHealthy := true
 
+	if !h.isStarted() {
+		services["startup"] = "starting"
+		allHealthy = false
+	}
+
 	if err := h.productRepo.HealthCheck(ctx); err != nil {
 		services["database"] = "unhealthy"
 		allHealthy = false
@@ -87,11 +176,19 @@ func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
 		services["cache"] = "healthy"
 	}
 
+	degraded := false
+	if h.allProductsKey != "" && allHealthy {
+		services["cache_index"] = h.indexStatus(ctx)
+		degraded = services["cache_index"] != "healthy"
+	}
+
 	status := "healthy"
 	statusCode := http.StatusOK
 	if !allHealthy {
 		status = "unhealthy"
 		statusCode = http.StatusServiceUnavailable
+	} else if degraded {
+		status = "degraded"
 	}
 
 	response := HealthResponse{
@@ -104,3 +201,119 @@ func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(statusCode)
 	json.NewEncoder(w).Encode(response)
 }
+
+// indexStatus retorna "empty" quando o banco tem produtos e o índice
+// all_products não tem nenhum. Um banco vazio deixa o índice vazio também,
+// então nesse caso o índice é considerado saudável.
+func (h *HealthHandler) indexStatus(ctx context.Context) string {
+	indexed, err := h.cacheRepo.GetSortedSetRange(ctx, h.allProductsKey, 0, 0)
+	if err != nil {
+		h.logger.Warn("cache index health check failed", zap.Error(err))
+		return "unknown"
+	}
+	if len(indexed) > 0 {
+		return "healthy"
+	}
+
+	products, err := h.productRepo.FindAll(ctx, repository.ListFilter{}, repository.SortOptions{}, 1, 0)
+	if err != nil {
+		h.logger.Warn("cache index health check failed", zap.Error(err))
+		return "unknown"
+	}
+	if len(products) > 0 {
+		h.logger.Warn("all_products index is empty but the database has products")
+		return "empty"
+	}
+	return "healthy"
+}
+
+// Detailed godoc
+// @Summary      Detailed health check
+// @Description  Verifica database, cache e Keycloak (JWKS) em paralelo, com timeout compartilhado, retornando status, latência e último erro de cada dependência. Exige o admin role, já que expõe latências e mensagens de erro das dependências
+// @Tags         health
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  DetailedHealthResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      503  {object}  DetailedHealthResponse
+// @Security     BearerAuth
+// @Router       /health/detailed [get]
+func (h *HealthHandler) Detailed(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	checks := map[string]func(context.Context) error{
+		"database": h.productRepo.HealthCheck,
+		"cache":    h.cacheRepo.HealthCheck,
+	}
+	if h.identityProvider != nil {
+		checks["keycloak"] = h.identityProvider.HealthCheck
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	dependencies := make(map[string]DependencyHealth, len(checks))
+
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check func(context.Context) error) {
+			defer wg.Done()
+			result := h.runCheck(ctx, name, check)
+
+			mu.Lock()
+			dependencies[name] = result
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+
+	status := "healthy"
+	statusCode := http.StatusOK
+	for _, dependency := range dependencies {
+		if dependency.Status != "healthy" {
+			status = "unhealthy"
+			statusCode = http.StatusServiceUnavailable
+			break
+		}
+	}
+
+	response := DetailedHealthResponse{
+		Status:       status,
+		Timestamp:    time.Now().UTC(),
+		Dependencies: dependencies,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *HealthHandler) runCheck(ctx context.Context, name string, check func(context.Context) error) DependencyHealth {
+	start := time.Now()
+	err := check(ctx)
+	latency := time.Since(start)
+
+	result := DependencyHealth{
+		Status:    "healthy",
+		LatencyMs: float64(latency.Microseconds()) / 1000,
+	}
+
+	h.lastErrorsMutex.Lock()
+	defer h.lastErrorsMutex.Unlock()
+
+	if err != nil {
+		result.Status = "unhealthy"
+		result.Error = err.Error()
+		h.lastErrors[name] = dependencyError{message: err.Error(), occurredAt: time.Now().UTC()}
+		h.logger.Warn("dependency health check failed", zap.String("dependency", name), zap.Error(err))
+	}
+
+	if last, ok := h.lastErrors[name]; ok {
+		occurredAt := last.occurredAt
+		result.LastError = last.message
+		result.LastErrorAt = &occurredAt
+	}
+
+	return result
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+type healthProductRepo struct {
+	repository.ProductRepository
+	products []*entity.Product
+}
+
+func (r *healthProductRepo) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+func (r *healthProductRepo) FindAll(ctx context.Context, filter repository.ListFilter, sort repository.SortOptions, limit, offset int) ([]*entity.Product, error) {
+	return r.products[:min(limit, len(r.products))], nil
+}
+
+type healthCacheRepo struct {
+	repository.CacheRepository
+	index []string
+}
+
+func (r *healthCacheRepo) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+func (r *healthCacheRepo) GetSortedSetRange(ctx context.Context, setKey string, start, stop int64) ([]string, error) {
+	if setKey != "all_products" {
+		return nil, nil
+	}
+	return r.index[:min(int(stop)+1, len(r.index))], nil
+}
+
+func TestReadiness_IndexCheck(t *testing.T) {
+	product := &entity.Product{ID: "A"}
+
+	tests := []struct {
+		name        string
+		indexCheck  bool
+		products    []*entity.Product
+		indexed     []string
+		status      string
+		indexStatus string
+	}{
+		{name: "check disabled", products: []*entity.Product{product}, status: "healthy"},
+		{name: "index populated", indexCheck: true, products: []*entity.Product{product}, indexed: []string{"A"}, status: "healthy", indexStatus: "healthy"},
+		{name: "fresh database", indexCheck: true, status: "healthy", indexStatus: "healthy"},
+		{name: "index empty", indexCheck: true, products: []*entity.Product{product}, status: "degraded", indexStatus: "empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHealthHandler(&healthProductRepo{products: tt.products}, &healthCacheRepo{index: tt.indexed}, nil, zap.NewNop())
+			if tt.indexCheck {
+				h.WithIndexCheck("all_products")
+			}
+
+			rec := httptest.NewRecorder()
+			h.Readiness(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d", rec.Code)
+			}
+			var response HealthResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Expected JSON body, got %v", err)
+			}
+			if response.Status != tt.status {
+				t.Errorf("Expected status %q, got %q", tt.status, response.Status)
+			}
+			if got := response.Services["cache_index"]; got != tt.indexStatus {
+				t.Errorf("Expected cache_index %q, got %q", tt.indexStatus, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/middleware"
+	"go.uber.org/zap"
+)
+
+// IdempotencyKeyHeader carrega a chave de idempotência do POST /products, e
+// IdempotentReplayedHeader marca as respostas devolvidas da gravação.
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// maxIdempotencyKeyLength limita a chave enviada pelo cliente.
+const maxIdempotencyKeyLength = 255
+
+// captureWriter repassa a resposta e guarda uma cópia para a gravação.
+type captureWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *captureWriter) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *captureWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// idempotent executa serve sob o header Idempotency-Key. A primeira
+// requisição com a chave roda e, se der 2xx, tem a resposta gravada junto com
+// o hash do corpo; as repetições com o mesmo corpo recebem a resposta gravada
+// sem rodar serve, as com outro corpo recebem 422 e as que chegam com a
+// primeira ainda em curso recebem 409. Sem store ou sem header, só roda serve.
+// Uma falha do store também não impede a requisição.
+func (h *ProductHandler) idempotent(w http.ResponseWriter, r *http.Request, serve http.HandlerFunc) {
+	key := r.Header.Get(IdempotencyKeyHeader)
+	if h.idempotency == nil || key == "" {
+		serve(w, r)
+		return
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		h.respondError(w, http.StatusBadRequest, "invalid_idempotency_key", "Idempotency-Key must have at most 255 characters", nil)
+		return
+	}
+
+	requestHash, err := hashRequestBody(r, h.maxBodyBytes)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid_request", "Invalid request body", err)
+		return
+	}
+
+	scoped := idempotencyScope(r, key)
+	stored, err := h.idempotency.Begin(r.Context(), scoped)
+	switch {
+	case errors.Is(err, port.ErrRequestInProgress):
+		h.respondError(w, http.StatusConflict, "request_in_progress", "A request with this Idempotency-Key is still in progress", nil)
+		return
+	case err != nil:
+		h.logger.Warn("idempotency store unavailable - processing without idempotency", zap.Error(err))
+		serve(w, r)
+		return
+	case stored != nil && stored.RequestHash != "" && stored.RequestHash != requestHash:
+		// Respostas gravadas antes do hash existir não têm com o que comparar.
+		h.respondError(w, http.StatusUnprocessableEntity, "idempotency_key_reused", "Idempotency-Key was already used with a different request body", nil)
+		return
+	case stored != nil:
+		w.Header().Set("Content-Type", stored.ContentType)
+		if stored.Location != "" {
+			w.Header().Set("Location", stored.Location)
+		}
+		if stored.ETag != "" {
+			w.Header().Set("ETag", stored.ETag)
+		}
+		w.Header().Set(IdempotentReplayedHeader, "true")
+		w.WriteHeader(stored.Status)
+		w.Write(stored.Body)
+		return
+	}
+
+	capture := &captureWriter{ResponseWriter: w, status: http.StatusOK}
+	serve(capture, r)
+
+	// A gravação não depende do cliente ainda estar conectado.
+	ctx := context.WithoutCancel(r.Context())
+	if capture.status >= 200 && capture.status < 300 {
+		err = h.idempotency.Complete(ctx, scoped, port.IdempotentResponse{
+			Status:      capture.status,
+			ContentType: capture.Header().Get("Content-Type"),
+			Location:    capture.Header().Get("Location"),
+			ETag:        capture.Header().Get("ETag"),
+			Body:        capture.body.Bytes(),
+			RequestHash: requestHash,
+		})
+	} else {
+		err = h.idempotency.Release(ctx, scoped)
+	}
+	if err != nil {
+		h.logger.Warn("failed to store idempotent response", zap.Error(err))
+	}
+}
+
+// idempotencyScope isola a chave por usuário autenticado: o mesmo
+// Idempotency-Key de dois usuários não colide. A chave entra como hash, o
+// que mantém o nome no Redis curto e sem caracteres do cliente.
+func idempotencyScope(r *http.Request, key string) string {
+	subject := ""
+	if user := middleware.GetUserFromContext(r.Context()); user != nil {
+		subject = user.Subject
+	}
+	sum := sha256.Sum256([]byte(key))
+	return subject + ":" + hex.EncodeToString(sum[:])
+}
+
+// hashRequestBody resume em sha256 até maxBytes+1 bytes do corpo e devolve o
+// corpo intacto em r.Body, para que serve aplique o próprio limite (um corpo
+// maior responde 413 e nunca é gravado).
+func hashRequestBody(r *http.Request, maxBytes int64) (string, error) {
+	if r.Body == nil {
+		sum := sha256.Sum256(nil)
+		return hex.EncodeToString(sum[:]), nil
+	}
+
+	head, err := io.ReadAll(io.LimitReader(r.Body, maxBytes+1))
+	if err != nil {
+		return "", err
+	}
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), r.Body), r.Body}
+
+	sum := sha256.Sum256(head)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/middleware"
+	"go.uber.org/zap"
+)
+
+type memoryIdempotency struct {
+	mu        sync.Mutex
+	pending   map[string]bool
+	responses map[string]port.IdempotentResponse
+}
+
+func newMemoryIdempotency() *memoryIdempotency {
+	return &memoryIdempotency{pending: map[string]bool{}, responses: map[string]port.IdempotentResponse{}}
+}
+
+func (m *memoryIdempotency) Begin(ctx context.Context, key string) (*port.IdempotentResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if response, ok := m.responses[key]; ok {
+		return &response, nil
+	}
+	if m.pending[key] {
+		return nil, port.ErrRequestInProgress
+	}
+	m.pending[key] = true
+	return nil, nil
+}
+
+func (m *memoryIdempotency) Complete(ctx context.Context, key string, response port.IdempotentResponse) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.pending, key)
+	m.responses[key] = response
+	return nil
+}
+
+func (m *memoryIdempotency) Release(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.pending, key)
+	return nil
+}
+
+func TestCreate_IdempotencyKey(t *testing.T) {
+	creator := &stubCreator{}
+	store := newMemoryIdempotency()
+	h := NewProductHandler(creator, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop()).
+		WithIdempotency(store)
+
+	create := func(subject, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/products", strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserContextKey, &middleware.UserClaims{Subject: subject}))
+		rec := httptest.NewRecorder()
+		h.Create(rec, req)
+		return rec
+	}
+	const valid = `{"name":"Notebook","category":"Laptops"}`
+
+	first := create("user-1", "key-1", valid)
+	if first.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", first.Code, first.Body.String())
+	}
+
+	replay := create("user-1", "key-1", valid)
+	if replay.Code != http.StatusCreated || replay.Body.String() != first.Body.String() {
+		t.Errorf("Expected stored response, got %d: %s", replay.Code, replay.Body.String())
+	}
+	if replay.Header().Get("Location") != "/api/v1/products/A" || replay.Header().Get("ETag") != first.Header().Get("ETag") || first.Header().Get("ETag") == "" {
+		t.Errorf("Expected Location and ETag to be replayed, got %v", replay.Header())
+	}
+	if replay.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Error("Expected replayed response to be marked")
+	}
+	if creator.calls != 1 {
+		t.Fatalf("Expected the use case to run once, got %d", creator.calls)
+	}
+
+	reused := create("user-1", "key-1", `{"name":"Tablet","category":"Tablets"}`)
+	assertBodyResponse(t, "create", reused, http.StatusCreated, http.StatusUnprocessableEntity, "idempotency_key_reused")
+	if creator.calls != 1 {
+		t.Fatalf("Expected a reused key not to run the use case, got %d calls", creator.calls)
+	}
+
+	if rec := create("user-2", "key-1", valid); rec.Header().Get(IdempotentReplayedHeader) != "" || creator.calls != 2 {
+		t.Errorf("Expected the key to be isolated per user, got %d calls", creator.calls)
+	}
+
+	if rec := create("user-1", "key-2", `{"nmae":"Notebook"}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d", rec.Code)
+	}
+	if rec := create("user-1", "key-2", valid); rec.Code != http.StatusCreated || creator.calls != 3 {
+		t.Errorf("Expected failed requests not to be stored, got %d with %d calls", rec.Code, creator.calls)
+	}
+
+	create("user-1", "", valid)
+	create("user-1", "", valid)
+	if creator.calls != 5 {
+		t.Errorf("Expected requests without key to always run, got %d calls", creator.calls)
+	}
+}
+
+func TestCreate_IdempotencyKeyInProgress(t *testing.T) {
+	creator := &stubCreator{}
+	store := newMemoryIdempotency()
+	h := NewProductHandler(creator, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop()).
+		WithIdempotency(store)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products", strings.NewReader(`{"name":"Notebook"}`))
+	req.Header.Set(IdempotencyKeyHeader, "key-1")
+	if _, err := store.Begin(req.Context(), idempotencyScope(req, "key-1")); err != nil {
+		t.Fatalf("Expected key to be reserved, got %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	h.Create(rec, req)
+
+	assertBodyResponse(t, "create", rec, http.StatusCreated, http.StatusConflict, "request_in_progress")
+	if creator.calls != 0 {
+		t.Errorf("Expected the use case not to run, got %d calls", creator.calls)
+	}
+}
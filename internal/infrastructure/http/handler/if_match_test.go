@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// versionedWriter simula um produto gravado na versão 2.
+type versionedWriter struct {
+	expected []int
+}
+
+func (s *versionedWriter) write(id string, expectedVersion int) (*entity.Product, error) {
+	s.expected = append(s.expected, expectedVersion)
+	if expectedVersion > 0 && expectedVersion != 2 {
+		return nil, entity.ErrPreconditionFailed
+	}
+	return &entity.Product{ID: id, Name: "Notebook", Version: 3}, nil
+}
+
+func (s *versionedWriter) Execute(ctx context.Context, id string, input port.UpdateProductInput) (*entity.Product, error) {
+	return s.write(id, input.ExpectedVersion)
+}
+
+type versionedPatcher struct {
+	*versionedWriter
+}
+
+func (s versionedPatcher) Execute(ctx context.Context, id string, patch port.PatchProductInput) (*entity.Product, error) {
+	return s.write(id, patch.ExpectedVersion)
+}
+
+func TestUpdateAndPatch_IfMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		ifMatch  string
+		status   int
+		code     string
+		expected int
+	}{
+		{name: "no header", status: http.StatusOK},
+		{name: "wildcard", ifMatch: "*", status: http.StatusOK},
+		{name: "matched version", ifMatch: `"2"`, status: http.StatusOK, expected: 2},
+		{name: "mismatched version", ifMatch: `"1"`, status: http.StatusPreconditionFailed, code: "precondition_failed", expected: 1},
+		{name: "matched etag", ifMatch: `W/"A-2"`, status: http.StatusOK, expected: 2},
+		{name: "mismatched etag", ifMatch: `"A-1"`, status: http.StatusPreconditionFailed, code: "precondition_failed", expected: 1},
+		{name: "etag of another product", ifMatch: `W/"B-2"`, status: http.StatusPreconditionFailed, code: "precondition_failed", expected: -1},
+		{name: "malformed header", ifMatch: "2", status: http.StatusBadRequest, code: "invalid_if_match", expected: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := &versionedWriter{}
+			h := NewProductHandler(nil, nil, writer, versionedPatcher{writer}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop())
+
+			for _, call := range []struct {
+				method string
+				serve  http.HandlerFunc
+			}{
+				{method: http.MethodPut, serve: h.Update},
+				{method: http.MethodPatch, serve: h.Patch},
+			} {
+				req := httptest.NewRequest(call.method, "/api/v1/products/A", strings.NewReader(`{"name":"Notebook","category":"Laptops"}`))
+				if tt.ifMatch != "" {
+					req.Header.Set("If-Match", tt.ifMatch)
+				}
+				rctx := chi.NewRouteContext()
+				rctx.URLParams.Add("id", "A")
+				rec := httptest.NewRecorder()
+				call.serve(rec, req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx)))
+				assertBodyResponse(t, call.method, rec, http.StatusOK, tt.status, tt.code)
+			}
+
+			if tt.expected < 0 {
+				if len(writer.expected) != 0 {
+					t.Errorf("Expected the use cases not to be called, got %v", writer.expected)
+				}
+				return
+			}
+			for _, got := range writer.expected {
+				if got != tt.expected {
+					t.Errorf("Expected version %d to reach the use case, got %d", tt.expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdate_IfMatchWithReadETag(t *testing.T) {
+	getter := &stubGetter{product: &entity.Product{ID: "A", Name: "Notebook", Version: 2}}
+	writer := &versionedWriter{}
+	h := NewProductHandler(nil, nil, writer, nil, nil, nil, nil, nil, nil, nil, getter, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	h.Get(rec, getRequest("A", ""))
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("Expected the read to return an ETag")
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/products/A", strings.NewReader(`{"name":"Notebook","category":"Laptops"}`))
+	req.Header.Set("If-Match", etag)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "A")
+	rec = httptest.NewRecorder()
+	h.Update(rec, req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected the ETag from GET to be accepted by PUT, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(writer.expected) != 1 || writer.expected[0] != 2 {
+		t.Errorf("Expected version 2 to reach the use case, got %v", writer.expected)
+	}
+}
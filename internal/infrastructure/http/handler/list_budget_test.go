@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/dto"
+	"go.uber.org/zap"
+)
+
+func TestStreamProductList_ByteBudget(t *testing.T) {
+	one, _ := json.Marshal(dto.ToProductResponse(testProducts(1)[0]))
+	size := len(one)
+
+	tests := []struct {
+		name      string
+		budget    int
+		count     int
+		truncated bool
+		next      string
+	}{
+		{name: "fits", budget: 3 * size, count: 3, next: "http://localhost/api/v1/products?limit=3&offset=3"},
+		{name: "drops the products past the budget", budget: 2*size + size/2, count: 2, truncated: true, next: "http://localhost/api/v1/products?limit=3&offset=2"},
+		{name: "first product over the budget", budget: size - 1, count: 0, truncated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := (&ProductHandler{logger: zap.NewNop()}).WithListResponseLimit(tt.budget)
+			r := httptest.NewRequest(http.MethodGet, "http://localhost/api/v1/products?limit=3", nil)
+			w := httptest.NewRecorder()
+
+			h.respondProductList(w, r, testProducts(3), &page{limit: 3, hasNext: true})
+
+			var body dto.ProductListResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Expected valid JSON, got %v: %s", err, w.Body.String())
+			}
+			if len(body.Data) != tt.count || body.Meta.Count != tt.count || body.Meta.Truncated != tt.truncated {
+				t.Errorf("Expected %d products (truncated=%v), got %d with meta %+v", tt.count, tt.truncated, len(body.Data), body.Meta)
+			}
+			if body.Links.Next != tt.next {
+				t.Errorf("Expected next link %q, got %q", tt.next, body.Links.Next)
+			}
+		})
+	}
+}
+
+func TestStreamProductList_ByteBudgetSkipsUnencodableProduct(t *testing.T) {
+	one, _ := json.Marshal(dto.ToProductResponse(testProducts(1)[0]))
+	h := (&ProductHandler{logger: zap.NewNop()}).WithListResponseLimit(2 * len(one))
+	r := httptest.NewRequest(http.MethodGet, "http://localhost/api/v1/products", nil)
+	w := httptest.NewRecorder()
+
+	products := testProducts(3)
+	products[1].Specifications = map[string]interface{}{"weight": math.Inf(1)}
+	total := 57
+	h.streamProductList(w, r, products, nil, &total)
+
+	var body dto.ProductListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Expected valid JSON, got %v: %s", err, w.Body.String())
+	}
+	if len(body.Data) != 2 || body.Data[0].ID != "A" || body.Data[1].ID != "C" {
+		t.Fatalf("Expected the unencodable product to be skipped without using the budget, got %+v", body.Data)
+	}
+	if body.Meta.Truncated || body.Meta.Total == nil || *body.Meta.Total != 57 {
+		t.Errorf("Expected an untruncated page with the total, got %+v", body.Meta)
+	}
+}
+
+func TestRespondProductList_WithoutByteBudget(t *testing.T) {
+	h := (&ProductHandler{logger: zap.NewNop()}).WithListResponseLimit(0)
+	r := httptest.NewRequest(http.MethodGet, "http://localhost/api/v1/products", nil)
+	w := httptest.NewRecorder()
+
+	h.respondProductList(w, r, testProducts(3), nil)
+
+	if body := strings.TrimSpace(w.Body.String()); !strings.HasPrefix(body, "[") {
+		t.Errorf("Expected the plain array without a budget, got %s", body)
+	}
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/dto"
+	"go.uber.org/zap"
+)
+
+type flushCounter struct {
+	*httptest.ResponseRecorder
+	flushes int
+}
+
+func (f *flushCounter) Flush() {
+	f.flushes++
+	f.ResponseRecorder.Flush()
+}
+
+func TestListStreaming(t *testing.T) {
+	count := func() (int, error) { return 57, nil }
+
+	t.Run("array matches buffered response", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://localhost/api/v1/products/search?q=notebook", nil)
+
+		buffered := httptest.NewRecorder()
+		(&ProductHandler{logger: zap.NewNop()}).respondProductList(buffered, r, testProducts(5), nil)
+
+		streamed := &flushCounter{ResponseRecorder: httptest.NewRecorder()}
+		(&ProductHandler{logger: zap.NewNop()}).WithListStreaming(2).respondProductList(streamed, r, testProducts(5), nil)
+
+		var want, got []*dto.ProductResponse
+		if err := json.Unmarshal(buffered.Body.Bytes(), &want); err != nil {
+			t.Fatalf("Expected valid JSON, got %v", err)
+		}
+		if err := json.Unmarshal(streamed.Body.Bytes(), &got); err != nil {
+			t.Fatalf("Expected valid JSON, got %v: %s", err, streamed.Body.String())
+		}
+		if len(got) != len(want) || got[4].ID != want[4].ID {
+			t.Errorf("Expected %d products, got %d", len(want), len(got))
+		}
+		if streamed.flushes != 2 {
+			t.Errorf("Expected a flush every 2 products, got %d flushes", streamed.flushes)
+		}
+		if ct := streamed.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Expected application/json, got %q", ct)
+		}
+	})
+
+	t.Run("paginated envelope", func(t *testing.T) {
+		h := (&ProductHandler{logger: zap.NewNop()}).WithListStreaming(10)
+		r := httptest.NewRequest(http.MethodGet, "http://localhost/api/v1/products?limit=3&offset=6", nil)
+		w := httptest.NewRecorder()
+
+		h.respondCountedList(w, r, testProducts(3), &page{limit: 3, offset: 6, hasNext: true}, count)
+
+		var body dto.PaginatedResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Expected valid JSON, got %v: %s", err, w.Body.String())
+		}
+		if body.Total != 57 || body.Limit != 3 || body.Offset != 6 || len(body.Data) != 3 {
+			t.Errorf("Unexpected paginated response %+v", body)
+		}
+	})
+
+	t.Run("encode failure truncates", func(t *testing.T) {
+		h := (&ProductHandler{logger: zap.NewNop()}).WithListStreaming(1)
+		r := httptest.NewRequest(http.MethodGet, "http://localhost/api/v1/products", nil)
+		w := httptest.NewRecorder()
+
+		products := testProducts(3)
+		products[1].Specifications = map[string]interface{}{"weight": math.Inf(1)}
+		h.respondCountedList(w, r, products, &page{limit: 3}, count)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+		var body dto.PaginatedResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Expected valid JSON after truncation, got %v: %s", err, w.Body.String())
+		}
+		if len(body.Data) != 1 || body.Data[0].ID != products[0].ID {
+			t.Errorf("Expected only the products before the failure, got %d", len(body.Data))
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/middleware"
+)
+
+// negotiateXML é o wantsXML das respostas: como o formato passa a depender do
+// Accept, acrescenta Vary: Accept para que um cache intermediário não sirva a
+// um cliente o formato negociado por outro. Toda resposta negociada passa por
+// aqui, em JSON ou em XML.
+func negotiateXML(w http.ResponseWriter, r *http.Request) bool {
+	middleware.AddVary(w.Header(), "Accept")
+	return wantsXML(r)
+}
+
+// wantsXML aplica a negociação de conteúdo do header Accept. XML é escolhido
+// quando application/xml ou text/xml é pedido explicitamente com qualidade
+// maior que a de application/json e não menor que a de um curinga. Sem header,
+// com curinga apenas ou em empate com JSON, o padrão continua sendo JSON.
+func wantsXML(r *http.Request) bool {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return false
+	}
+
+	jsonQ, xmlQ, wildcardQ := -1.0, -1.0, -1.0
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if raw, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(raw, 64); err == nil {
+				q = parsed
+			}
+		}
+
+		switch mediaType {
+		case "application/json":
+			jsonQ = max(jsonQ, q)
+		case "application/*", "*/*":
+			wildcardQ = max(wildcardQ, q)
+		case "application/xml", "text/xml":
+			xmlQ = max(xmlQ, q)
+		}
+	}
+
+	return xmlQ > 0 && xmlQ > jsonQ && xmlQ >= wildcardQ
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+func TestWantsXML(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		want   bool
+	}{
+		{name: "no header", accept: "", want: false},
+		{name: "json", accept: "application/json", want: false},
+		{name: "wildcard", accept: "*/*", want: false},
+		{name: "application xml", accept: "application/xml", want: true},
+		{name: "text xml", accept: "text/xml", want: true},
+		{name: "xml with wildcard fallback", accept: "application/xml, */*;q=0.8", want: true},
+		{name: "json preferred", accept: "application/xml;q=0.5, application/json", want: false},
+		{name: "tie keeps json", accept: "application/json, application/xml", want: false},
+		{name: "xml refused", accept: "application/xml;q=0", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+
+			if got := wantsXML(r); got != tt.want {
+				t.Errorf("wantsXML(%q) = %v, want %v", tt.accept, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRespondProductList_XML(t *testing.T) {
+	h := &ProductHandler{logger: zap.NewNop()}
+	products := []*entity.Product{{
+		ID:             "A",
+		Name:           "Notebook",
+		Images:         []string{"a.jpg"},
+		Specifications: map[string]interface{}{"ram": "16GB", "ports": []interface{}{"usb", "hdmi"}},
+	}}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "application/xml")
+	w := httptest.NewRecorder()
+
+	h.respondProductList(w, r, products, nil)
+
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/xml") {
+		t.Fatalf("Expected XML content type, got %q", ct)
+	}
+
+	body := w.Body.String()
+	for _, want := range []string{
+		"<products><product>",
+		"<id>A</id>",
+		"<images><image>a.jpg</image></images>",
+		`<spec key="ports">[&#34;usb&#34;,&#34;hdmi&#34;]</spec><spec key="ram">16GB</spec>`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected body to contain %s, got %s", want, body)
+		}
+	}
+}
+
+func TestNegotiatedResponses_VaryAccept(t *testing.T) {
+	products := []*entity.Product{{ID: "A", Name: "Notebook", Version: 1}}
+	count := func() (int, error) { return 1, nil }
+
+	tests := []struct {
+		name    string
+		handler *ProductHandler
+		target  string
+		accept  string
+		serve   func(h *ProductHandler, w http.ResponseWriter, r *http.Request)
+	}{
+		{
+			name: "single product as json",
+			serve: func(h *ProductHandler, w http.ResponseWriter, r *http.Request) {
+				h.respond(w, r, http.StatusOK, productResponse(r, products[0]))
+			},
+		},
+		{
+			name:   "list as xml",
+			accept: "application/xml",
+			serve: func(h *ProductHandler, w http.ResponseWriter, r *http.Request) {
+				h.respondProductList(w, r, products, nil)
+			},
+		},
+		{
+			name: "plain list as json",
+			serve: func(h *ProductHandler, w http.ResponseWriter, r *http.Request) {
+				h.respondProductList(w, r, products, nil)
+			},
+		},
+		{
+			name:    "list with byte budget",
+			handler: &ProductHandler{logger: zap.NewNop(), maxListBytes: 1 << 20},
+			serve: func(h *ProductHandler, w http.ResponseWriter, r *http.Request) {
+				h.respondProductList(w, r, products, nil)
+			},
+		},
+		{
+			name: "counted list",
+			serve: func(h *ProductHandler, w http.ResponseWriter, r *http.Request) {
+				h.respondCountedList(w, r, products, &page{limit: 10}, count)
+			},
+		},
+		{
+			name:   "counted list as array",
+			target: "/?format=array",
+			serve: func(h *ProductHandler, w http.ResponseWriter, r *http.Request) {
+				h.respondCountedList(w, r, products, &page{limit: 10}, count)
+			},
+		},
+		{
+			name: "not modified",
+			serve: func(h *ProductHandler, w http.ResponseWriter, r *http.Request) {
+				r.Header.Set("If-None-Match", productETag(products[0]))
+				if !notModifiedETag(w, r, productETag(products[0])) {
+					t.Error("Expected a 304 for the current ETag")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := tt.handler
+			if h == nil {
+				h = &ProductHandler{logger: zap.NewNop()}
+			}
+			target := tt.target
+			if target == "" {
+				target = "/"
+			}
+
+			r := httptest.NewRequest(http.MethodGet, target, nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			tt.serve(h, w, r)
+
+			if got := w.Header().Values("Vary"); len(got) != 1 || got[0] != "Accept" {
+				t.Errorf("Expected Vary: Accept once, got %v", got)
+			}
+		})
+	}
+}
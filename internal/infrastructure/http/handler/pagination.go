@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/dto"
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/middleware"
+)
+
+// page descreve a janela pedida numa listagem paginada por offset. hasNext é
+// descoberto pedindo um produto além do limite, sem precisar do total.
+type page struct {
+	limit   int
+	offset  int
+	hasNext bool
+}
+
+// fetchPage chama fetch com limit+1 e devolve só os limit primeiros produtos,
+// registrando se havia mais.
+func fetchPage(limit, offset int, fetch func(limit, offset int) ([]*entity.Product, error)) ([]*entity.Product, *page, error) {
+	products, err := fetch(limit+1, offset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pg := &page{limit: limit, offset: offset}
+	if len(products) > limit {
+		pg.hasNext = true
+		products = products[:limit]
+	}
+
+	return products, pg, nil
+}
+
+// links monta as URLs absolutas da página a partir da requisição, trocando só
+// limit e offset na query. count é quantos produtos foram de fato enviados
+// (menor que limit quando o orçamento de bytes truncou a resposta), então a
+// próxima página começa logo após o último enviado.
+func (p *page) links(r *http.Request, trustProxy bool, count int, truncated bool) *dto.PaginationLinks {
+	links := &dto.PaginationLinks{
+		Self:  pageURL(r, trustProxy, p.limit, p.offset),
+		First: pageURL(r, trustProxy, p.limit, 0),
+	}
+
+	if p.offset > 0 {
+		links.Prev = pageURL(r, trustProxy, p.limit, max(0, p.offset-p.limit))
+	}
+
+	if (p.hasNext || truncated) && count > 0 {
+		links.Next = pageURL(r, trustProxy, p.limit, p.offset+count)
+	}
+
+	return links
+}
+
+// setLinkHeader publica os links no header Link (RFC 8288), usado nas
+// respostas em array simples e em XML, que não têm onde levá-los no corpo.
+func setLinkHeader(w http.ResponseWriter, links *dto.PaginationLinks) {
+	var parts []string
+	for _, link := range []struct{ rel, href string }{
+		{"first", links.First},
+		{"prev", links.Prev},
+		{"next", links.Next},
+	} {
+		if link.href != "" {
+			parts = append(parts, fmt.Sprintf(`<%s>; rel="%s"`, link.href, link.rel))
+		}
+	}
+	w.Header().Set("Link", strings.Join(parts, ", "))
+}
+
+func pageURL(r *http.Request, trustProxy bool, limit, offset int) string {
+	query := r.URL.Query()
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("offset", strconv.Itoa(offset))
+
+	u := url.URL{
+		Scheme:   requestScheme(r, trustProxy),
+		Host:     requestHost(r, trustProxy),
+		Path:     r.URL.Path,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+// requestScheme respeita X-Forwarded-Proto só com trustProxy, quando a API
+// fica atrás de um proxy que termina o TLS; sem ele o header pode ter sido
+// enviado pelo próprio cliente.
+func requestScheme(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if proto := middleware.ForwardedValue(r, "X-Forwarded-Proto"); proto != "" {
+			return strings.ToLower(proto)
+		}
+	}
+	if r.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// requestHost respeita X-Forwarded-Host só com trustProxy, como requestScheme.
+func requestHost(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if host := middleware.ForwardedValue(r, "X-Forwarded-Host"); host != "" {
+			return host
+		}
+	}
+	return r.Host
+}
@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/dto"
+	"go.uber.org/zap"
+)
+
+func testProducts(n int) []*entity.Product {
+	products := make([]*entity.Product, n)
+	for i := range products {
+		products[i] = &entity.Product{ID: string(rune('A' + i)), Name: "Notebook"}
+	}
+	return products
+}
+
+func TestFetchPage(t *testing.T) {
+	var requested int
+	fetch := func(available int) func(limit, offset int) ([]*entity.Product, error) {
+		return func(limit, offset int) ([]*entity.Product, error) {
+			requested = limit
+			return testProducts(min(limit, available)), nil
+		}
+	}
+
+	products, pg, err := fetchPage(2, 0, fetch(5))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if requested != 3 {
+		t.Errorf("Expected one extra product to be requested, got limit %d", requested)
+	}
+	if len(products) != 2 || !pg.hasNext {
+		t.Errorf("Expected 2 products and a next page, got %d (hasNext=%v)", len(products), pg.hasNext)
+	}
+
+	products, pg, _ = fetchPage(2, 0, fetch(2))
+	if len(products) != 2 || pg.hasNext {
+		t.Errorf("Expected exact last page without next, got %d (hasNext=%v)", len(products), pg.hasNext)
+	}
+}
+
+func TestRespondProductList_Links(t *testing.T) {
+	h := &ProductHandler{logger: zap.NewNop(), maxListBytes: 1 << 20, trustProxyHeaders: true}
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/products/search/category?q=Notebooks&limit=2&offset=2", nil)
+	r.Host = "internal:8080"
+	r.Header.Set("X-Forwarded-Proto", "http, https")
+	r.Header.Set("X-Forwarded-Host", "evil.example.com, api.example.com")
+	w := httptest.NewRecorder()
+
+	h.respondProductList(w, r, testProducts(2), &page{limit: 2, offset: 2, hasNext: true})
+
+	var body dto.ProductListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Expected valid JSON, got %v: %s", err, w.Body.String())
+	}
+	if body.Links == nil {
+		t.Fatal("Expected links in the response")
+	}
+
+	want := dto.PaginationLinks{
+		Self:  "https://api.example.com/api/v1/products/search/category?limit=2&offset=2&q=Notebooks",
+		First: "https://api.example.com/api/v1/products/search/category?limit=2&offset=0&q=Notebooks",
+		Prev:  "https://api.example.com/api/v1/products/search/category?limit=2&offset=0&q=Notebooks",
+		Next:  "https://api.example.com/api/v1/products/search/category?limit=2&offset=4&q=Notebooks",
+	}
+	if *body.Links != want {
+		t.Errorf("Links = %+v, want %+v", *body.Links, want)
+	}
+}
+
+func TestRespondProductList_IgnoresForwardedHeadersByDefault(t *testing.T) {
+	h := &ProductHandler{logger: zap.NewNop()}
+
+	r := httptest.NewRequest(http.MethodGet, "http://internal:8080/api/v1/products?limit=50", nil)
+	r.Header.Set("X-Forwarded-Proto", "https")
+	r.Header.Set("X-Forwarded-Host", "evil.example.com")
+	w := httptest.NewRecorder()
+
+	h.respondProductList(w, r, testProducts(3), &page{limit: 50})
+
+	link := w.Header().Get("Link")
+	if link != `<http://internal:8080/api/v1/products?limit=50&offset=0>; rel="first"` {
+		t.Errorf("Expected the forwarded headers to be ignored without a trusted proxy, got %q", link)
+	}
+}
+
+func TestRespondProductList_LinkHeaderOnLastPage(t *testing.T) {
+	h := &ProductHandler{logger: zap.NewNop()}
+
+	r := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/products?limit=50", nil)
+	w := httptest.NewRecorder()
+
+	h.respondProductList(w, r, testProducts(3), &page{limit: 50})
+
+	link := w.Header().Get("Link")
+	if link != `<http://localhost:8080/api/v1/products?limit=50&offset=0>; rel="first"` {
+		t.Errorf("Expected only the first link on a single page, got %q", link)
+	}
+	if !strings.HasPrefix(strings.TrimSpace(w.Body.String()), "[") {
+		t.Error("Expected the plain array format to be kept")
+	}
+}
+
+func TestRespondProductList_TruncatedNextStartsAfterLastSent(t *testing.T) {
+	h := &ProductHandler{logger: zap.NewNop()}
+	one, _ := json.Marshal(dto.ToProductResponse(testProducts(1)[0]))
+	h.maxListBytes = len(one) + 10
+
+	r := httptest.NewRequest(http.MethodGet, "http://localhost/api/v1/products?limit=3&offset=6", nil)
+	w := httptest.NewRecorder()
+
+	h.respondProductList(w, r, testProducts(3), &page{limit: 3, offset: 6})
+
+	var body dto.ProductListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	if !body.Meta.Truncated || body.Meta.Count != 1 {
+		t.Fatalf("Expected truncation after one product, got %+v", body.Meta)
+	}
+	if body.Links.Next != "http://localhost/api/v1/products?limit=3&offset=7" {
+		t.Errorf("Expected next page right after the last product sent, got %q", body.Links.Next)
+	}
+}
+
+func TestRespondCountedList(t *testing.T) {
+	count := func(total int, err error) func() (int, error) {
+		return func() (int, error) { return total, err }
+	}
+
+	t.Run("paginated response with total", func(t *testing.T) {
+		h := &ProductHandler{logger: zap.NewNop()}
+		r := httptest.NewRequest(http.MethodGet, "http://localhost/api/v1/products?limit=2&offset=4", nil)
+		w := httptest.NewRecorder()
+
+		h.respondCountedList(w, r, testProducts(2), &page{limit: 2, offset: 4, hasNext: true}, count(57, nil))
+
+		var body dto.PaginatedResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Expected valid JSON, got %v: %s", err, w.Body.String())
+		}
+		if body.Total != 57 || body.Limit != 2 || body.Offset != 4 || len(body.Data) != 2 {
+			t.Errorf("Unexpected paginated response %+v", body)
+		}
+		if !strings.Contains(w.Header().Get("Link"), `rel="next"`) {
+			t.Errorf("Expected next link in header, got %q", w.Header().Get("Link"))
+		}
+	})
+
+	t.Run("array format skips count", func(t *testing.T) {
+		h := &ProductHandler{logger: zap.NewNop()}
+		r := httptest.NewRequest(http.MethodGet, "http://localhost/api/v1/products?format=array", nil)
+		w := httptest.NewRecorder()
+
+		h.respondCountedList(w, r, testProducts(1), &page{limit: 50}, func() (int, error) {
+			t.Fatal("Expected no count for the array format")
+			return 0, nil
+		})
+
+		if !strings.HasPrefix(strings.TrimSpace(w.Body.String()), "[") {
+			t.Errorf("Expected the plain array format, got %s", w.Body.String())
+		}
+	})
+
+	t.Run("count failure falls back to lower bound", func(t *testing.T) {
+		h := &ProductHandler{logger: zap.NewNop()}
+		r := httptest.NewRequest(http.MethodGet, "http://localhost/api/v1/products?limit=2&offset=4", nil)
+		w := httptest.NewRecorder()
+
+		h.respondCountedList(w, r, testProducts(2), &page{limit: 2, offset: 4, hasNext: true}, count(0, errors.New("circuit open")))
+
+		var body dto.PaginatedResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Expected valid JSON, got %v", err)
+		}
+		if body.Total != 7 {
+			t.Errorf("Expected lower bound 7, got %d", body.Total)
+		}
+	})
+
+	t.Run("byte budget carries total in meta", func(t *testing.T) {
+		h := &ProductHandler{logger: zap.NewNop(), maxListBytes: 1 << 20}
+		r := httptest.NewRequest(http.MethodGet, "http://localhost/api/v1/products", nil)
+		w := httptest.NewRecorder()
+
+		h.respondCountedList(w, r, testProducts(3), &page{limit: 50}, count(3, nil))
+
+		var body dto.ProductListResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Expected valid JSON, got %v", err)
+		}
+		if body.Meta.Total == nil || *body.Meta.Total != 3 {
+			t.Errorf("Expected meta.total 3, got %+v", body.Meta)
+		}
+	})
+}
+
+func TestGetPagination(t *testing.T) {
+	h := NewProductHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop()).
+		WithPagination(20, 200)
+
+	tests := []struct {
+		name   string
+		query  string
+		limit  int
+		offset int
+	}{
+		{name: "configured default", query: "", limit: 20},
+		{name: "within bounds", query: "?limit=150&offset=40", limit: 150, offset: 40},
+		{name: "at the max", query: "?limit=200", limit: 200},
+		{name: "above the max is clamped", query: "?limit=201", limit: 200},
+		{name: "far above the max is clamped", query: "?limit=100000", limit: 200},
+		{name: "zero uses default", query: "?limit=0", limit: 20},
+		{name: "negative uses default", query: "?limit=-5&offset=-1", limit: 20},
+		{name: "not a number uses default", query: "?limit=abc", limit: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, offset := h.getPagination(httptest.NewRequest(http.MethodGet, "/api/v1/products"+tt.query, nil))
+			if limit != tt.limit || offset != tt.offset {
+				t.Errorf("Expected limit %d offset %d, got %d %d", tt.limit, tt.offset, limit, offset)
+			}
+		})
+	}
+}
+
+func TestWithPagination(t *testing.T) {
+	newHandler := func() *ProductHandler {
+		return NewProductHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop())
+	}
+
+	tests := []struct {
+		name         string
+		defaultLimit int
+		maxLimit     int
+		wantDefault  int
+		wantMax      int
+	}{
+		{name: "unset keeps built-in limits", wantDefault: DefaultPageLimit, wantMax: MaxPageLimit},
+		{name: "larger pages", defaultLimit: 100, maxLimit: 10000, wantDefault: 100, wantMax: 10000},
+		{name: "default above max is clamped", defaultLimit: 50, maxLimit: 10, wantDefault: 10, wantMax: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHandler().WithPagination(tt.defaultLimit, tt.maxLimit)
+			if h.defaultPageLimit != tt.wantDefault || h.maxPageLimit != tt.wantMax {
+				t.Errorf("Expected default %d max %d, got %d %d", tt.wantDefault, tt.wantMax, h.defaultPageLimit, h.maxPageLimit)
+			}
+		})
+	}
+}
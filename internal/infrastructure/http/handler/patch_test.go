@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type stubPatcher struct {
+	calls int
+	input port.PatchProductInput
+}
+
+func (s *stubPatcher) Execute(ctx context.Context, id string, patch port.PatchProductInput) (*entity.Product, error) {
+	s.calls++
+	s.input = patch
+	return &entity.Product{ID: id, Name: "Notebook", Version: 3}, nil
+}
+
+func TestPatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		ifMatch string
+		status  int
+		code    string
+		version int
+	}{
+		{name: "partial body", body: `{"stock":5}`, status: http.StatusOK},
+		{name: "with If-Match", body: `{"stock":5}`, ifMatch: `"2"`, status: http.StatusOK, version: 2},
+		{name: "invalid If-Match", body: `{"stock":5}`, ifMatch: `W/"2"`, status: http.StatusBadRequest, code: "invalid_if_match"},
+		{name: "unknown field", body: `{"stok":5}`, status: http.StatusBadRequest, code: "invalid_request"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patcher := &stubPatcher{}
+			h := NewProductHandler(nil, nil, nil, patcher, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop())
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/products/A", strings.NewReader(tt.body))
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "A")
+			rec := httptest.NewRecorder()
+			h.Patch(rec, req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx)))
+			assertBodyResponse(t, "patch", rec, http.StatusOK, tt.status, tt.code)
+
+			if tt.status != http.StatusOK {
+				if patcher.calls != 0 {
+					t.Errorf("Expected the use case not to be called, got %d calls", patcher.calls)
+				}
+				return
+			}
+			if patcher.input.Stock == nil || *patcher.input.Stock != 5 {
+				t.Errorf("Expected stock 5 in the patch, got %v", patcher.input.Stock)
+			}
+			if patcher.input.Name != nil || patcher.input.Tags != nil {
+				t.Errorf("Expected omitted fields to stay nil, got %+v", patcher.input)
+			}
+			if patcher.input.ExpectedVersion != tt.version {
+				t.Errorf("Expected version %d, got %d", tt.version, patcher.input.ExpectedVersion)
+			}
+		})
+	}
+}
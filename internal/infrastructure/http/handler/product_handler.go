@@ -2,104 +2,404 @@ package handler
 
 import (
 	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
 	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/dto"
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/middleware"
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 )
 
 type ProductHandler struct {
 	createUseCase           port.ProductCreator
+	bulkCreateUseCase       port.ProductBulkCreator
 	updateUseCase           port.ProductUpdater
+	patchUseCase            port.ProductPatcher
+	stockUseCase            port.ProductStockUpdater
+	bulkStockUseCase        port.ProductStockBulkAdjuster
+	adjustStockUseCase      port.ProductStockAdjuster
+	tagUseCase              port.ProductTagEditor
 	deleteUseCase           port.ProductDeleter
+	restoreUseCase          port.ProductRestorer
 	getUseCase              port.ProductGetter
+	getBySKUUseCase         port.ProductGetterBySKU
+	batchGetUseCase         port.ProductBatchGetter
 	listUseCase             port.ProductLister
+	countUseCase            port.ProductCounter
+	modifiedByUseCase       port.ProductModifiedByLister
+	recentUseCase           port.RecentProductsLister
 	searchByNameUseCase     port.ProductSearcherByName
 	searchByCategoryUseCase port.ProductSearcherByCategory
+	searchByTagUseCase      port.ProductSearcherByTag
+	importUseCase           port.ProductImporter
 	logger                  *zap.Logger
+
+	// maxListBytes é o orçamento de bytes dos produtos numa listagem; zero
+	// mantém a resposta como array simples sem limite.
+	maxListBytes int
+
+	// streamFlushEvery, quando positivo, faz as listagens JSON saírem produto
+	// a produto, com flush a cada tantos produtos.
+	streamFlushEvery int
+
+	// errorDetails inclui a cadeia do erro nas respostas de erro. Só fora de
+	// produção: a cadeia expõe detalhes internos (SQL, endereços, etc.).
+	errorDetails bool
+
+	// defaultPageLimit é o limit das listagens sem ?limit válido; pedidos
+	// acima de maxPageLimit são reduzidos a ele.
+	defaultPageLimit int
+	maxPageLimit     int
+
+	// maxBatchGetIDs limita os IDs de um POST /products/batch-get, sem passar
+	// de maxBulkItems.
+	maxBatchGetIDs int
+
+	// maxBulkItems limita os itens de qualquer requisição em lote.
+	maxBulkItems int
+
+	// maxBodyBytes limita o corpo da criação, do update e das rotas de
+	// estoque e tags de um produto.
+	maxBodyBytes int64
+
+	// maxBulkBodyBytes limita o corpo das requisições em lote.
+	maxBulkBodyBytes int64
+
+	// trustProxyHeaders faz os links de paginação seguirem X-Forwarded-Proto
+	// e X-Forwarded-Host.
+	trustProxyHeaders bool
+
+	// geoExporter alimenta GET /products/geo; sem ele a rota responde 404.
+	geoExporter port.ProductGeoExporter
+
+	// idempotency guarda as respostas do POST /products por Idempotency-Key;
+	// sem ele o header é ignorado.
+	idempotency port.IdempotencyStore
+
+	// tombstones, com tombstoneRole, faz o GET de um produto excluído
+	// logicamente responder 410 aos usuários com esse role; os demais e os
+	// IDs que nunca existiram seguem com 404.
+	tombstones    port.ProductTombstoneFinder
+	tombstoneRole string
 }
 
+// DefaultBatchGetLimit é o máximo de IDs por busca em lote, salvo
+// WithBatchGetLimit.
+const DefaultBatchGetLimit = 100
+
+// DefaultBodyLimit é o tamanho máximo, em bytes, do corpo da criação e do
+// update, salvo WithBodyLimit.
+const DefaultBodyLimit = 1 << 20
+
+// DefaultBulkBodyLimit é o tamanho máximo, em bytes, do corpo das requisições
+// em lote (bulk, stock/bulk, import e batch-get), salvo WithBulkBodyLimit.
+const DefaultBulkBodyLimit = 8 << 20
+
+// DefaultPageLimit e MaxPageLimit são os limites de paginação usados quando
+// a configuração não define outros.
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 5000
+)
+
+// defaultRecentLimit e maxRecentLimit mantêm a rota de recentes numa janela
+// pequena do topo de all_products, a parte mais quente do cache.
+const (
+	defaultRecentLimit = 10
+	maxRecentLimit     = 50
+)
+
 func NewProductHandler(
 	createUseCase port.ProductCreator,
+	bulkCreateUseCase port.ProductBulkCreator,
 	updateUseCase port.ProductUpdater,
+	patchUseCase port.ProductPatcher,
+	stockUseCase port.ProductStockUpdater,
+	bulkStockUseCase port.ProductStockBulkAdjuster,
+	adjustStockUseCase port.ProductStockAdjuster,
+	tagUseCase port.ProductTagEditor,
 	deleteUseCase port.ProductDeleter,
+	restoreUseCase port.ProductRestorer,
 	getUseCase port.ProductGetter,
+	getBySKUUseCase port.ProductGetterBySKU,
+	batchGetUseCase port.ProductBatchGetter,
 	listUseCase port.ProductLister,
+	countUseCase port.ProductCounter,
+	modifiedByUseCase port.ProductModifiedByLister,
+	recentUseCase port.RecentProductsLister,
 	searchByNameUseCase port.ProductSearcherByName,
 	searchByCategoryUseCase port.ProductSearcherByCategory,
+	searchByTagUseCase port.ProductSearcherByTag,
+	importUseCase port.ProductImporter,
 	logger *zap.Logger,
 ) *ProductHandler {
 	return &ProductHandler{
 		createUseCase:           createUseCase,
+		bulkCreateUseCase:       bulkCreateUseCase,
 		updateUseCase:           updateUseCase,
+		patchUseCase:            patchUseCase,
+		stockUseCase:            stockUseCase,
+		bulkStockUseCase:        bulkStockUseCase,
+		adjustStockUseCase:      adjustStockUseCase,
+		tagUseCase:              tagUseCase,
 		deleteUseCase:           deleteUseCase,
+		restoreUseCase:          restoreUseCase,
 		getUseCase:              getUseCase,
+		getBySKUUseCase:         getBySKUUseCase,
+		batchGetUseCase:         batchGetUseCase,
 		listUseCase:             listUseCase,
+		countUseCase:            countUseCase,
+		modifiedByUseCase:       modifiedByUseCase,
+		recentUseCase:           recentUseCase,
 		searchByNameUseCase:     searchByNameUseCase,
 		searchByCategoryUseCase: searchByCategoryUseCase,
+		searchByTagUseCase:      searchByTagUseCase,
+		importUseCase:           importUseCase,
 		logger:                  logger,
+		defaultPageLimit:        DefaultPageLimit,
+		maxPageLimit:            MaxPageLimit,
+		maxBatchGetIDs:          DefaultBatchGetLimit,
+		maxBulkItems:            DefaultBulkLimit,
+		maxBodyBytes:            DefaultBodyLimit,
+		maxBulkBodyBytes:        DefaultBulkBodyLimit,
+	}
+}
+
+// WithListResponseLimit ativa o orçamento de bytes das listagens. Com limite,
+// a resposta passa a ser dto.ProductListResponse, escrita incrementalmente e
+// truncada (meta.truncated=true) quando o próximo produto excederia o limite.
+func (h *ProductHandler) WithListResponseLimit(maxBytes int) *ProductHandler {
+	h.maxListBytes = maxBytes
+	return h
+}
+
+// WithListStreaming faz as listagens JSON serem escritas incrementalmente, com
+// flush a cada flushEvery produtos, em vez de montadas inteiras antes do envio.
+// Zero mantém a resposta montada.
+func (h *ProductHandler) WithListStreaming(flushEvery int) *ProductHandler {
+	h.streamFlushEvery = max(flushEvery, 0)
+	return h
+}
+
+// WithBatchGetLimit troca o máximo de IDs por busca em lote; valores não
+// positivos mantêm o atual.
+func (h *ProductHandler) WithBatchGetLimit(maxIDs int) *ProductHandler {
+	if maxIDs > 0 {
+		h.maxBatchGetIDs = maxIDs
+	}
+	return h
+}
+
+// WithBulkLimit troca o máximo de itens das requisições em lote; valores não
+// positivos mantêm o atual.
+func (h *ProductHandler) WithBulkLimit(maxItems int) *ProductHandler {
+	if maxItems > 0 {
+		h.maxBulkItems = maxItems
+	}
+	return h
+}
+
+// WithBodyLimit troca o tamanho máximo do corpo da criação e do update;
+// valores não positivos mantêm o atual.
+func (h *ProductHandler) WithBodyLimit(maxBytes int64) *ProductHandler {
+	if maxBytes > 0 {
+		h.maxBodyBytes = maxBytes
 	}
+	return h
+}
+
+// WithBulkBodyLimit troca o tamanho máximo do corpo das requisições em lote;
+// valores não positivos mantêm o atual.
+func (h *ProductHandler) WithBulkBodyLimit(maxBytes int64) *ProductHandler {
+	if maxBytes > 0 {
+		h.maxBulkBodyBytes = maxBytes
+	}
+	return h
+}
+
+// WithTrustedProxy faz os links de paginação usarem o esquema e o host de
+// X-Forwarded-Proto e X-Forwarded-Host. Só deve ser ativado com a API atrás de
+// um proxy que sobrescreve esses headers.
+func (h *ProductHandler) WithTrustedProxy(trust bool) *ProductHandler {
+	h.trustProxyHeaders = trust
+	return h
+}
+
+// WithGeoFeed ativa o feed GeoJSON dos produtos com localização.
+func (h *ProductHandler) WithGeoFeed(exporter port.ProductGeoExporter) *ProductHandler {
+	h.geoExporter = exporter
+	return h
+}
+
+// WithTombstones ativa o 410 Gone com id e deleted_at no GET de produtos
+// excluídos logicamente, só para usuários com o realm role informado.
+func (h *ProductHandler) WithTombstones(finder port.ProductTombstoneFinder, role string) *ProductHandler {
+	h.tombstones = finder
+	h.tombstoneRole = role
+	return h
+}
+
+// WithIdempotency ativa o header Idempotency-Key na criação.
+func (h *ProductHandler) WithIdempotency(store port.IdempotencyStore) *ProductHandler {
+	h.idempotency = store
+	return h
+}
+
+// WithPagination troca os limites de paginação das listagens. Valores não
+// positivos mantêm os atuais, e um padrão acima do máximo é reduzido a ele.
+func (h *ProductHandler) WithPagination(defaultLimit, maxLimit int) *ProductHandler {
+	if maxLimit > 0 {
+		h.maxPageLimit = maxLimit
+	}
+	if defaultLimit > 0 {
+		h.defaultPageLimit = defaultLimit
+	}
+	h.defaultPageLimit = min(h.defaultPageLimit, h.maxPageLimit)
+	return h
+}
+
+// WithErrorDetails faz as respostas de erro trazerem err.Error() e a cadeia
+// de erros embrulhados em details, para depuração local.
+func (h *ProductHandler) WithErrorDetails(enabled bool) *ProductHandler {
+	h.errorDetails = enabled
+	return h
 }
 
 // Create godoc
 // @Summary      Criar produto
-// @Description  Cria um novo produto no sistema
+// @Description  Cria um novo produto no sistema e responde com Location e ETag do produto criado. Campos inválidos respondem 422 com as falhas de todos eles em fields. O ID de um produto excluído responde 409 product_deleted: a criação não restaura, use POST /api/v1/products/{id}/restore. Com Idempotency-Key, uma repetição com o mesmo corpo devolve a resposta gravada da primeira, com os mesmos Location e ETag (e Idempotent-Replayed: true); uma repetição com outro corpo responde 422 idempotency_key_reused e uma com a primeira ainda em curso responde 409 request_in_progress.
 // @Tags         products
 // @Accept       json
-// @Produce      json
-// @Param        product  body      dto.CreateProductRequest  true  "Dados do produto"
-// @Success      201      {object}  dto.ProductResponse
-// @Failure      400      {object}  dto.ErrorResponse
-// @Failure      401      {object}  dto.ErrorResponse
-// @Failure      409      {object}  dto.ErrorResponse
-// @Failure      500      {object}  dto.ErrorResponse
+// @Produce      json,application/xml
+// @Param        product          body      dto.CreateProductRequest  true   "Dados do produto"
+// @Param        Idempotency-Key  header    string                    false  "Chave de idempotência, isolada por usuário (máx 255 caracteres)"
+// @Param        tz               query     string                    false  "Fuso IANA para created_at e updated_at (padrão UTC)"
+// @Param        fields           query     string                    false  "Caminhos em specifications separados por vírgula (ex.: specifications.color)"
+// @Success      201              {object}  dto.ProductResponse
+// @Header       201              {string}  Location  "URL do produto criado"
+// @Header       201              {string}  ETag      "Versão do produto criado"
+// @Failure      400              {object}  dto.ErrorResponse
+// @Failure      401              {object}  dto.ErrorResponse
+// @Failure      403              {object}  dto.ErrorResponse
+// @Failure      409              {object}  dto.ErrorResponse
+// @Failure      413              {object}  dto.ErrorResponse
+// @Failure      422              {object}  dto.ErrorResponse
+// @Failure      500              {object}  dto.ErrorResponse
+// @Failure      503              {object}  dto.ErrorResponse
 // @Security     BearerAuth
 // @Router       /api/v1/products [post]
 func (h *ProductHandler) Create(w http.ResponseWriter, r *http.Request) {
+	h.idempotent(w, r, h.create)
+}
+
+func (h *ProductHandler) create(w http.ResponseWriter, r *http.Request) {
 	var req dto.CreateProductRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid_request", "Invalid request body", err)
+	if err := h.decodeStrict(w, r, &req); err != nil {
+		h.respondBodyError(w, err)
 		return
 	}
 
-	input := port.CreateProductInput{
-		Name:            req.Name,
-		ReferenceNumber: req.ReferenceNumber,
-		Category:        req.Category,
-		Description:     req.Description,
-		SKU:             req.SKU,
-		Brand:           req.Brand,
-		Stock:           req.Stock,
-		Images:          req.Images,
-		Specifications:  req.Specifications,
+	product, err := h.createUseCase.Execute(r.Context(), toCreateProductInput(req))
+	if err != nil {
+		h.handleDomainError(w, err, "Failed to create product")
+		return
 	}
 
-	product, err := h.createUseCase.Execute(r.Context(), input)
+	w.Header().Set("Location", "/api/v1/products/"+product.ID)
+	w.Header().Set("ETag", productETag(product))
+	h.respond(w, r, http.StatusCreated, productResponse(r, product))
+}
+
+// BulkCreate godoc
+// @Summary      Criar produtos em lote
+// @Description  Cria vários produtos numa única ida ao banco. Cada item é validado como na criação unitária e o resultado vem por índice, com o status e o código de erro que a criação unitária teria retornado. Retorna 201 quando todos são criados e 207 quando algum falha.
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        products  body      []dto.CreateProductRequest  true  "Produtos (máx MAX_BULK_ITEMS, padrão 500)"
+// @Param        tz        query     string                      false  "Fuso IANA para created_at e updated_at (padrão UTC)"
+// @Param        fields    query     string                      false  "Caminhos em specifications separados por vírgula (ex.: specifications.color)"
+// @Success      201       {object}  dto.BulkCreateResponse
+// @Success      207       {object}  dto.BulkCreateResponse
+// @Failure      400       {object}  dto.ErrorResponse
+// @Failure      401       {object}  dto.ErrorResponse
+// @Failure      403       {object}  dto.ErrorResponse
+// @Failure      413       {object}  dto.ErrorResponse
+// @Failure      500       {object}  dto.ErrorResponse
+// @Failure      503       {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/products/bulk [post]
+func (h *ProductHandler) BulkCreate(w http.ResponseWriter, r *http.Request) {
+	var req []dto.CreateProductRequest
+	if err := h.decodeBulk(w, r, &req); err != nil {
+		h.respondBodyError(w, err)
+		return
+	}
+
+	if !h.checkBulkSize(w, "Products", len(req), h.maxBulkItems) {
+		return
+	}
+
+	inputs := make([]port.CreateProductInput, len(req))
+	for i, item := range req {
+		inputs[i] = toCreateProductInput(item)
+	}
+
+	report, err := h.bulkCreateUseCase.Execute(r.Context(), inputs)
 	if err != nil {
-		h.handleDomainError(w, err, "Failed to create product")
+		h.handleDomainError(w, err, "Failed to create products")
 		return
 	}
 
-	h.respondJSON(w, http.StatusCreated, dto.ToProductResponse(product))
+	resp := dto.BulkCreateResponse{
+		Total:   report.Total,
+		Created: report.Created,
+		Failed:  report.Failed,
+		Results: make([]dto.BulkCreateItemResponse, len(report.Results)),
+	}
+	for i, result := range report.Results {
+		resp.Results[i] = h.bulkCreateItem(r, result)
+	}
+
+	status := http.StatusCreated
+	if report.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+	h.respondJSON(w, status, resp)
 }
 
 // Update godoc
 // @Summary      Atualizar produto
-// @Description  Atualiza um produto existente pelo ID
+// @Description  Atualiza um produto existente pelo ID. Campos inválidos respondem 422 com as falhas de todos eles em fields. Com If-Match, responde 412 se o produto já estiver em outra versão.
 // @Tags         products
 // @Accept       json
-// @Produce      json
-// @Param        id       path      string                    true  "ID do produto"
-// @Param        product  body      dto.UpdateProductRequest  true  "Dados atualizados do produto"
-// @Success      200      {object}  dto.ProductResponse
-// @Failure      400      {object}  dto.ErrorResponse
-// @Failure      401      {object}  dto.ErrorResponse
-// @Failure      404      {object}  dto.ErrorResponse
-// @Failure      409      {object}  dto.ErrorResponse
-// @Failure      500      {object}  dto.ErrorResponse
+// @Produce      json,application/xml
+// @Param        id        path      string                    true   "ID do produto"
+// @Param        If-Match  header    string                    false  "Versão esperada do produto (ETag)"
+// @Param        product   body      dto.UpdateProductRequest  true   "Dados atualizados do produto"
+// @Param        tz        query     string                    false  "Fuso IANA para created_at e updated_at (padrão UTC)"
+// @Param        fields    query     string                    false  "Caminhos em specifications separados por vírgula (ex.: specifications.color)"
+// @Success      200       {object}  dto.ProductResponse
+// @Failure      400       {object}  dto.ErrorResponse
+// @Failure      401       {object}  dto.ErrorResponse
+// @Failure      403       {object}  dto.ErrorResponse
+// @Failure      404       {object}  dto.ErrorResponse
+// @Failure      409       {object}  dto.ErrorResponse
+// @Failure      412       {object}  dto.ErrorResponse
+// @Failure      413       {object}  dto.ErrorResponse
+// @Failure      422       {object}  dto.ErrorResponse
+// @Failure      500       {object}  dto.ErrorResponse
+// @Failure      503       {object}  dto.ErrorResponse
 // @Security     BearerAuth
 // @Router       /api/v1/products/{id} [put]
 func (h *ProductHandler) Update(w http.ResponseWriter, r *http.Request) {
@@ -109,21 +409,34 @@ func (h *ProductHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	version, _, ok := h.ifMatch(w, r, id)
+	if !ok {
+		return
+	}
+
 	var req dto.UpdateProductRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid_request", "Invalid request body", err)
+	if err := h.decodeStrict(w, r, &req); err != nil {
+		h.respondBodyError(w, err)
 		return
 	}
 
 	input := port.UpdateProductInput{
-		Name:           req.Name,
-		Category:       req.Category,
-		Description:    req.Description,
-		SKU:            req.SKU,
-		Brand:          req.Brand,
-		Stock:          req.Stock,
-		Images:         req.Images,
-		Specifications: req.Specifications,
+		Name:            req.Name,
+		Category:        req.Category,
+		Description:     req.Description,
+		SKU:             req.SKU,
+		Brand:           req.Brand,
+		Stock:           req.Stock,
+		Price:           req.Price,
+		PriceAmount:     req.PriceAmount,
+		Currency:        req.Currency,
+		Images:          req.Images,
+		Specifications:  req.Specifications,
+		ThumbnailURL:    req.ThumbnailURL,
+		Tags:            req.Tags,
+		WeightGrams:     req.WeightGrams,
+		Dimensions:      req.Dimensions.Entity(),
+		ExpectedVersion: version,
 	}
 
 	product, err := h.updateUseCase.Execute(r.Context(), id, input)
@@ -132,208 +445,1284 @@ func (h *ProductHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.respondJSON(w, http.StatusOK, dto.ToProductResponse(product))
+	h.respond(w, r, http.StatusOK, productResponse(r, product))
 }
 
-// Delete godoc
-// @Summary      Deletar produto
-// @Description  Remove um produto pelo ID
+// Patch godoc
+// @Summary      Atualizar produto parcialmente
+// @Description  Altera só os campos enviados; ausentes ou null mantêm o valor atual. Com If-Match, responde 412 se o produto já estiver em outra versão.
 // @Tags         products
 // @Accept       json
-// @Produce      json
-// @Param        id   path      string  true  "ID do produto"
-// @Success      200  {object}  dto.SuccessResponse
-// @Failure      400  {object}  dto.ErrorResponse
-// @Failure      401  {object}  dto.ErrorResponse
-// @Failure      404  {object}  dto.ErrorResponse
-// @Failure      500  {object}  dto.ErrorResponse
+// @Produce      json,application/xml
+// @Param        id        path      string                   true   "ID do produto"
+// @Param        If-Match  header    string                   false  "Versão esperada do produto (ETag)"
+// @Param        product   body      dto.PatchProductRequest  true   "Campos a alterar"
+// @Param        tz        query     string                   false  "Fuso IANA para created_at e updated_at (padrão UTC)"
+// @Param        fields    query     string                   false  "Caminhos em specifications separados por vírgula (ex.: specifications.color)"
+// @Success      200       {object}  dto.ProductResponse
+// @Failure      400       {object}  dto.ErrorResponse
+// @Failure      401       {object}  dto.ErrorResponse
+// @Failure      403       {object}  dto.ErrorResponse
+// @Failure      404       {object}  dto.ErrorResponse
+// @Failure      409       {object}  dto.ErrorResponse
+// @Failure      412       {object}  dto.ErrorResponse
+// @Failure      413       {object}  dto.ErrorResponse
+// @Failure      422       {object}  dto.ErrorResponse
+// @Failure      500       {object}  dto.ErrorResponse
+// @Failure      503       {object}  dto.ErrorResponse
 // @Security     BearerAuth
-// @Router       /api/v1/products/{id} [delete]
-func (h *ProductHandler) Delete(w http.ResponseWriter, r *http.Request) {
+// @Router       /api/v1/products/{id} [patch]
+func (h *ProductHandler) Patch(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
 		h.respondError(w, http.StatusBadRequest, "invalid_id", "Product ID is required", nil)
 		return
 	}
 
-	if err := h.deleteUseCase.Execute(r.Context(), id); err != nil {
-		h.handleDomainError(w, err, "Failed to delete product")
+	version, _, ok := h.ifMatch(w, r, id)
+	if !ok {
 		return
 	}
 
-	h.respondJSON(w, http.StatusOK, dto.SuccessResponse{
-		Message: "Product deleted successfully",
-	})
+	var req dto.PatchProductRequest
+	if err := h.decodeStrict(w, r, &req); err != nil {
+		h.respondBodyError(w, err)
+		return
+	}
+
+	input := port.PatchProductInput{
+		Name:            req.Name,
+		Category:        req.Category,
+		Description:     req.Description,
+		SKU:             req.SKU,
+		Brand:           req.Brand,
+		Stock:           req.Stock,
+		Price:           req.Price,
+		PriceAmount:     req.PriceAmount,
+		Currency:        req.Currency,
+		Images:          req.Images,
+		Specifications:  req.Specifications,
+		ThumbnailURL:    req.ThumbnailURL,
+		Tags:            req.Tags,
+		WeightGrams:     req.WeightGrams,
+		ExpectedVersion: version,
+	}
+	if req.Dimensions != nil {
+		dimensions := req.Dimensions.Entity()
+		input.Dimensions = &dimensions
+	}
+
+	product, err := h.patchUseCase.Execute(r.Context(), id, input)
+	if err != nil {
+		h.handleDomainError(w, err, "Failed to update product")
+		return
+	}
+
+	h.respond(w, r, http.StatusOK, productResponse(r, product))
 }
 
-// Get godoc
-// @Summary      Buscar produto por ID
-// @Description  Retorna um produto específico pelo ID
+// UpdateStock godoc
+// @Summary      Atualizar estoque
+// @Description  Altera apenas o estoque do produto e incrementa a versão, para que um PUT ou PATCH concorrente não desfaça a mudança. Estoque e versão da entrada em cache são ajustados no próprio Redis.
 // @Tags         products
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "ID do produto"
-// @Success      200  {object}  dto.ProductResponse
-// @Failure      400  {object}  dto.ErrorResponse
-// @Failure      401  {object}  dto.ErrorResponse
-// @Failure      404  {object}  dto.ErrorResponse
-// @Failure      500  {object}  dto.ErrorResponse
+// @Param        id     path      string                  true  "ID do produto"
+// @Param        stock  body      dto.UpdateStockRequest  true  "Novo estoque"
+// @Success      204    "Estoque atualizado"
+// @Failure      400    {object}  dto.ErrorResponse
+// @Failure      401    {object}  dto.ErrorResponse
+// @Failure      403    {object}  dto.ErrorResponse
+// @Failure      404    {object}  dto.ErrorResponse
+// @Failure      413    {object}  dto.ErrorResponse
+// @Failure      500    {object}  dto.ErrorResponse
+// @Failure      503    {object}  dto.ErrorResponse
 // @Security     BearerAuth
-// @Router       /api/v1/products/{id} [get]
-func (h *ProductHandler) Get(w http.ResponseWriter, r *http.Request) {
+// @Router       /api/v1/products/{id}/stock [patch]
+func (h *ProductHandler) UpdateStock(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
 		h.respondError(w, http.StatusBadRequest, "invalid_id", "Product ID is required", nil)
 		return
 	}
 
-	product, err := h.getUseCase.Execute(r.Context(), id)
-	if err != nil {
-		h.handleDomainError(w, err, "Failed to get product")
+	var req dto.UpdateStockRequest
+	if err := h.decodeStrict(w, r, &req); err != nil {
+		h.respondBodyError(w, err)
+		return
+	}
+
+	if req.Stock == nil {
+		h.respondError(w, http.StatusBadRequest, "invalid_request", "Stock is required", nil)
+		return
+	}
+
+	if err := h.stockUseCase.Execute(r.Context(), id, *req.Stock); err != nil {
+		h.handleDomainError(w, err, "Failed to update stock")
 		return
 	}
 
-	h.respondJSON(w, http.StatusOK, dto.ToProductResponse(product))
+	w.WriteHeader(http.StatusNoContent)
 }
 
-// List godoc
-// @Summary      Listar produtos
-// @Description  Retorna uma lista paginada de produtos
+// AdjustStock godoc
+// @Summary      Ajustar estoque
+// @Description  Soma a variação (delta) ao estoque do produto num único UPDATE atômico, sem a leitura prévia que faria ajustes concorrentes se sobrescreverem. Se o estoque ficaria negativo, nada muda e a resposta é 409 insufficient_stock. A versão é incrementada e estoque e versão da entrada em cache são ajustados no próprio Redis.
 // @Tags         products
 // @Accept       json
 // @Produce      json
-// @Param        limit   query     int  false  "Limite de resultados (máx 5000)"  default(50)
-// @Param        offset  query     int  false  "Offset para paginação"            default(0)
-// @Success      200     {array}   dto.ProductResponse
-// @Failure      401     {object}  dto.ErrorResponse
-// @Failure      500     {object}  dto.ErrorResponse
+// @Param        id     path      string                  true  "ID do produto"
+// @Param        delta  body      dto.AdjustStockRequest  true  "Variação do estoque"
+// @Success      200    {object}  dto.AdjustStockResponse
+// @Failure      400    {object}  dto.ErrorResponse
+// @Failure      401    {object}  dto.ErrorResponse
+// @Failure      403    {object}  dto.ErrorResponse
+// @Failure      404    {object}  dto.ErrorResponse
+// @Failure      409    {object}  dto.ErrorResponse
+// @Failure      413    {object}  dto.ErrorResponse
+// @Failure      500    {object}  dto.ErrorResponse
+// @Failure      503    {object}  dto.ErrorResponse
 // @Security     BearerAuth
-// @Router       /api/v1/products [get]
-func (h *ProductHandler) List(w http.ResponseWriter, r *http.Request) {
-	limit, offset := h.getPagination(r)
+// @Router       /api/v1/products/{id}/stock [post]
+func (h *ProductHandler) AdjustStock(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		h.respondError(w, http.StatusBadRequest, "invalid_id", "Product ID is required", nil)
+		return
+	}
+
+	var req dto.AdjustStockRequest
+	if err := h.decodeStrict(w, r, &req); err != nil {
+		h.respondBodyError(w, err)
+		return
+	}
+
+	if req.Delta == nil || *req.Delta == 0 {
+		h.respondError(w, http.StatusBadRequest, "invalid_request", "Delta is required and must not be zero", nil)
+		return
+	}
 
-	products, err := h.listUseCase.Execute(r.Context(), limit, offset)
+	stock, err := h.adjustStockUseCase.Execute(r.Context(), id, *req.Delta)
 	if err != nil {
-		h.respondError(w, http.StatusInternalServerError, "internal_error", "Failed to list products", err)
+		h.handleDomainError(w, err, "Failed to adjust stock")
 		return
 	}
 
-	h.respondJSON(w, http.StatusOK, dto.ToProductResponseList(products))
+	h.respondJSON(w, http.StatusOK, dto.AdjustStockResponse{ID: id, Stock: stock})
 }
 
-// SearchByName godoc
-// @Summary      Buscar produtos por nome
-// @Description  Retorna produtos que correspondem ao termo de busca no nome
+// BulkAdjustStock godoc
+// @Summary      Ajustar estoque em lote
+// @Description  Soma a variação (delta) ao estoque de cada produto, para reconciliações de armazém. Cada item é aplicado atomicamente e de forma independente: itens que deixariam o estoque negativo ou de produtos inexistentes são recusados e listados no relatório, sem afetar os demais. A versão de cada produto ajustado é incrementada e as entradas em cache são ajustadas no próprio Redis. Uma falha do banco no meio do lote o interrompe: o item atual e os seguintes vêm no relatório como não aplicados.
 // @Tags         products
 // @Accept       json
 // @Produce      json
-// @Param        q       query     string  true   "Termo de busca"
-// @Param        limit   query     int     false  "Limite de resultados (máx 5000)"  default(50)
-// @Param        offset  query     int     false  "Offset para paginação"            default(0)
-// @Success      200     {array}   dto.ProductResponse
-// @Failure      400     {object}  dto.ErrorResponse
-// @Failure      401     {object}  dto.ErrorResponse
-// @Failure      500     {object}  dto.ErrorResponse
+// @Param        adjustments  body      dto.BulkStockAdjustmentRequest  true  "Ajustes (máx MAX_BULK_ITEMS, padrão 500)"
+// @Success      200          {object}  dto.BulkStockAdjustmentResponse
+// @Failure      400          {object}  dto.ErrorResponse
+// @Failure      401          {object}  dto.ErrorResponse
+// @Failure      403          {object}  dto.ErrorResponse
+// @Failure      413          {object}  dto.ErrorResponse
+// @Failure      500          {object}  dto.ErrorResponse
+// @Failure      503          {object}  dto.ErrorResponse
 // @Security     BearerAuth
-// @Router       /api/v1/products/search/name [get]
-func (h *ProductHandler) SearchByName(w http.ResponseWriter, r *http.Request) {
-	name := r.URL.Query().Get("q")
-	if name == "" {
-		h.respondError(w, http.StatusBadRequest, "invalid_query", "Search query is required", nil)
+// @Router       /api/v1/products/stock/bulk [post]
+func (h *ProductHandler) BulkAdjustStock(w http.ResponseWriter, r *http.Request) {
+	var req dto.BulkStockAdjustmentRequest
+	if err := h.decodeBulk(w, r, &req); err != nil {
+		h.respondBodyError(w, err)
 		return
 	}
 
-	limit, offset := h.getPagination(r)
+	if !h.checkBulkSize(w, "Adjustments", len(req.Adjustments), h.maxBulkItems) {
+		return
+	}
+
+	adjustments := make([]port.StockAdjustment, len(req.Adjustments))
+	for i, item := range req.Adjustments {
+		adjustments[i] = port.StockAdjustment{ID: item.ID, Delta: item.Delta}
+	}
 
-	products, err := h.searchByNameUseCase.Execute(r.Context(), name, limit, offset)
+	report, err := h.bulkStockUseCase.Execute(r.Context(), adjustments)
 	if err != nil {
-		h.respondError(w, http.StatusInternalServerError, "internal_error", "Failed to search products", err)
+		h.handleDomainError(w, err, "Failed to adjust stock")
 		return
 	}
 
-	h.respondJSON(w, http.StatusOK, dto.ToProductResponseList(products))
+	h.respondJSON(w, http.StatusOK, dto.ToBulkStockAdjustmentResponse(report))
 }
 
-// SearchByCategory godoc
-// @Summary      Buscar produtos por categoria
-// @Description  Retorna produtos que correspondem à categoria especificada
+// AddTag godoc
+// @Summary      Adicionar tag
+// @Description  Adiciona uma tag ao produto e incrementa a versão. Adicionar uma tag já presente não é erro e não muda a versão.
 // @Tags         products
 // @Accept       json
 // @Produce      json
-// @Param        q       query     string  true   "Nome da categoria"
-// @Param        limit   query     int     false  "Limite de resultados (máx 5000)"  default(50)
-// @Param        offset  query     int     false  "Offset para paginação"            default(0)
-// @Success      200     {array}   dto.ProductResponse
-// @Failure      400     {object}  dto.ErrorResponse
-// @Failure      401     {object}  dto.ErrorResponse
-// @Failure      500     {object}  dto.ErrorResponse
+// @Param        id   path      string             true  "ID do produto"
+// @Param        tag  body      dto.AddTagRequest  true  "Tag a adicionar"
+// @Success      204  "Tag adicionada"
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      413  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Failure      503  {object}  dto.ErrorResponse
 // @Security     BearerAuth
-// @Router       /api/v1/products/search/category [get]
-func (h *ProductHandler) SearchByCategory(w http.ResponseWriter, r *http.Request) {
-	category := r.URL.Query().Get("q")
-	if category == "" {
-		h.respondError(w, http.StatusBadRequest, "invalid_query", "Category query is required", nil)
+// @Router       /api/v1/products/{id}/tags [post]
+func (h *ProductHandler) AddTag(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		h.respondError(w, http.StatusBadRequest, "invalid_id", "Product ID is required", nil)
 		return
 	}
 
-	limit, offset := h.getPagination(r)
+	var req dto.AddTagRequest
+	if err := h.decodeStrict(w, r, &req); err != nil {
+		h.respondBodyError(w, err)
+		return
+	}
 
-	products, err := h.searchByCategoryUseCase.Execute(r.Context(), category, limit, offset)
-	if err != nil {
-		h.respondError(w, http.StatusInternalServerError, "internal_error", "Failed to search products", err)
+	if err := h.tagUseCase.Add(r.Context(), id, req.Tag); err != nil {
+		h.handleDomainError(w, err, "Failed to add tag")
 		return
 	}
 
-	h.respondJSON(w, http.StatusOK, dto.ToProductResponseList(products))
+	w.WriteHeader(http.StatusNoContent)
 }
 
-func (h *ProductHandler) getPagination(r *http.Request) (limit, offset int) {
-	limit = 50 // default
-	offset = 0
-
-	if l := r.URL.Query().Get("limit"); l != "" {
-		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 5000 {
-			limit = parsed
-		}
+// RemoveTag godoc
+// @Summary      Remover tag
+// @Description  Remove uma tag do produto e incrementa a versão. Remover uma tag ausente não é erro e não muda a versão.
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "ID do produto"
+// @Param        tag  path      string  true  "Tag a remover"
+// @Success      204  "Tag removida"
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Failure      503  {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/products/{id}/tags/{tag} [delete]
+func (h *ProductHandler) RemoveTag(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		h.respondError(w, http.StatusBadRequest, "invalid_id", "Product ID is required", nil)
+		return
 	}
 
-	if o := r.URL.Query().Get("offset"); o != "" {
-		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
-			offset = parsed
-		}
+	if err := h.tagUseCase.Remove(r.Context(), id, chi.URLParam(r, "tag")); err != nil {
+		h.handleDomainError(w, err, "Failed to remove tag")
+		return
 	}
 
-	return limit, offset
+	w.WriteHeader(http.StatusNoContent)
 }
 
-func (h *ProductHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	if err := json.NewEncoder(w).Encode(data); err != nil {
-		h.logger.Error("failed to encode response", zap.Error(err))
+// Delete godoc
+// @Summary      Deletar produto
+// @Description  Remove um produto pelo ID. A exclusão é lógica: o produto some das leituras e do cache, mas pode ser restaurado. Com If-Match (o ETag do produto ou "<version>"), só exclui se a versão atual no banco for a informada, respondendo 409 version_conflict caso contrário.
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string  true   "ID do produto"
+// @Param        If-Match  header    string  false  "ETag do produto ou versão esperada, entre aspas duplas"
+// @Success      200       {object}  dto.SuccessResponse
+// @Failure      400       {object}  dto.ErrorResponse
+// @Failure      401       {object}  dto.ErrorResponse
+// @Failure      403       {object}  dto.ErrorResponse
+// @Failure      404       {object}  dto.ErrorResponse
+// @Failure      409       {object}  dto.ErrorResponse
+// @Failure      500       {object}  dto.ErrorResponse
+// @Failure      503       {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/products/{id} [delete]
+func (h *ProductHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		h.respondError(w, http.StatusBadRequest, "invalid_id", "Product ID is required", nil)
+		return
 	}
-}
 
-func (h *ProductHandler) respondError(w http.ResponseWriter, status int, code, message string, err error) {
+	version, conditional, ok := h.ifMatch(w, r, id)
+	if !ok {
+		return
+	}
+
+	var err error
+	if conditional {
+		err = h.deleteUseCase.ExecuteIfVersion(r.Context(), id, version)
+	} else {
+		err = h.deleteUseCase.Execute(r.Context(), id)
+	}
 	if err != nil {
-		h.logger.Error("request error",
-			zap.String("code", code),
-			zap.String("message", message),
-			zap.Error(err),
-		)
+		h.handleDomainError(w, err, "Failed to delete product")
+		return
 	}
 
-	h.respondJSON(w, status, dto.ErrorResponse{
-		Error:   code,
-		Message: message,
+	h.respondJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "Product deleted successfully",
 	})
 }
 
+// Restore godoc
+// @Summary      Restaurar produto
+// @Description  Desfaz a exclusão lógica de um produto e o devolve ao cache e aos índices. Restaurar um produto ativo não é erro.
+// @Tags         products
+// @Accept       json
+// @Produce      json,application/xml
+// @Param        id      path      string  true  "ID do produto"
+// @Param        tz      query     string  false  "Fuso IANA para created_at e updated_at (padrão UTC)"
+// @Param        fields  query     string  false  "Caminhos em specifications separados por vírgula (ex.: specifications.color)"
+// @Success      200     {object}  dto.ProductResponse
+// @Failure      400     {object}  dto.ErrorResponse
+// @Failure      401     {object}  dto.ErrorResponse
+// @Failure      403     {object}  dto.ErrorResponse
+// @Failure      404     {object}  dto.ErrorResponse
+// @Failure      500     {object}  dto.ErrorResponse
+// @Failure      503     {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/products/{id}/restore [post]
+func (h *ProductHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		h.respondError(w, http.StatusBadRequest, "invalid_id", "Product ID is required", nil)
+		return
+	}
+
+	product, err := h.restoreUseCase.Execute(r.Context(), id)
+	if err != nil {
+		h.handleDomainError(w, err, "Failed to restore product")
+		return
+	}
+
+	h.respond(w, r, http.StatusOK, productResponse(r, product))
+}
+
+// Get godoc
+// @Summary      Buscar produto por ID
+// @Description  Retorna um produto específico pelo ID. A resposta traz o ETag fraco W/"<id>-<versão>"; com If-None-Match igual, responde 304 sem corpo. Com PRODUCT_TOMBSTONE_RESPONSES, um produto excluído logicamente responde 410 com id e deleted_at a usuários com o admin role; para os demais, e para IDs que nunca existiram, a resposta é 404.
+// @Tags         products
+// @Accept       json
+// @Produce      json,application/xml
+// @Param        id             path      string  true   "ID do produto"
+// @Param        tz             query     string  false  "Fuso IANA para created_at e updated_at (padrão UTC)"
+// @Param        fields         query     string  false  "Caminhos em specifications separados por vírgula (ex.: specifications.color)"
+// @Param        If-None-Match  header    string  false  "ETag de uma resposta anterior"
+// @Success      200            {object}  dto.ProductResponse
+// @Success      304            "Produto não mudou desde o ETag informado"
+// @Failure      400            {object}  dto.ErrorResponse
+// @Failure      401            {object}  dto.ErrorResponse
+// @Failure      404            {object}  dto.ErrorResponse
+// @Failure      410            {object}  dto.TombstoneResponse
+// @Failure      500            {object}  dto.ErrorResponse
+// @Failure      503            {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/products/{id} [get]
+func (h *ProductHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		h.respondError(w, http.StatusBadRequest, "invalid_id", "Product ID is required", nil)
+		return
+	}
+
+	product, err := h.getUseCase.Execute(r.Context(), id)
+	if err != nil {
+		if IsNotFoundError(err) && h.respondTombstone(w, r, id) {
+			return
+		}
+		h.handleDomainError(w, err, "Failed to get product")
+		return
+	}
+
+	if notModifiedETag(w, r, productETag(product)) {
+		return
+	}
+
+	h.respond(w, r, http.StatusOK, productResponse(r, product))
+}
+
+// respondTombstone responde 410 quando as tombstones estão ativas, o usuário
+// tem o role exigido e o produto foi excluído logicamente. Retorna false para
+// que o chamador siga com o 404, inclusive se a consulta falhar.
+func (h *ProductHandler) respondTombstone(w http.ResponseWriter, r *http.Request, id string) bool {
+	if h.tombstones == nil {
+		return false
+	}
+	if user := middleware.GetUserFromContext(r.Context()); user == nil || !user.HasRole(h.tombstoneRole) {
+		return false
+	}
+
+	tombstone, err := h.tombstones.Execute(r.Context(), id)
+	if err != nil {
+		return false
+	}
+
+	h.respondJSON(w, http.StatusGone, dto.ToTombstoneResponseIn(tombstone, middleware.GetTimezone(r.Context())))
+	return true
+}
+
+// BatchGet godoc
+// @Summary      Buscar vários produtos por ID
+// @Description  Busca os produtos dos IDs informados numa requisição só, primeiro no Redis e depois, para os que faltarem, numa única consulta ao PostgreSQL. A resposta é um mapa pelo ID pedido, na ordem do pedido, com null nos IDs não encontrados. Mais IDs que o limite configurado (SERVER_BATCH_GET_MAX_IDS, sem passar de MAX_BULK_ITEMS) respondem 400.
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        ids     body      dto.BatchGetRequest  true  "IDs dos produtos"
+// @Param        tz      query     string               false  "Fuso IANA para created_at e updated_at (padrão UTC)"
+// @Param        fields  query     string               false  "Caminhos em specifications separados por vírgula (ex.: specifications.color)"
+// @Success      200     {object}  dto.BatchGetResponse
+// @Failure      400     {object}  dto.ErrorResponse
+// @Failure      401     {object}  dto.ErrorResponse
+// @Failure      413     {object}  dto.ErrorResponse
+// @Failure      500     {object}  dto.ErrorResponse
+// @Failure      503     {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/products/batch-get [post]
+func (h *ProductHandler) BatchGet(w http.ResponseWriter, r *http.Request) {
+	var req dto.BatchGetRequest
+	if err := h.decodeBulk(w, r, &req); err != nil {
+		h.respondBodyError(w, err)
+		return
+	}
+
+	if !h.checkBulkSize(w, "ids", len(req.IDs), min(h.maxBatchGetIDs, h.maxBulkItems)) {
+		return
+	}
+
+	products, err := h.batchGetUseCase.Execute(r.Context(), req.IDs)
+	if err != nil {
+		h.handleDomainError(w, err, "Failed to get products")
+		return
+	}
+
+	response := dto.NewBatchGetResponse(req.IDs)
+	for _, id := range req.IDs {
+		if product, ok := products[id]; ok {
+			response.Set(id, productResponse(r, product))
+		}
+	}
+
+	h.respondJSON(w, http.StatusOK, response)
+}
+
+// GetBySKU godoc
+// @Summary      Buscar produto por SKU
+// @Description  Retorna o produto com o SKU, sem diferenciar maiúsculas. SKUs não são únicos: havendo mais de um produto com o SKU, retorna o cadastrado primeiro.
+// @Tags         products
+// @Accept       json
+// @Produce      json,application/xml
+// @Param        sku     path      string  true  "SKU do produto"
+// @Param        tz      query     string  false  "Fuso IANA para created_at e updated_at (padrão UTC)"
+// @Param        fields  query     string  false  "Caminhos em specifications separados por vírgula (ex.: specifications.color)"
+// @Success      200     {object}  dto.ProductResponse
+// @Failure      400     {object}  dto.ErrorResponse
+// @Failure      401     {object}  dto.ErrorResponse
+// @Failure      404     {object}  dto.ErrorResponse
+// @Failure      500     {object}  dto.ErrorResponse
+// @Failure      503     {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/products/sku/{sku} [get]
+func (h *ProductHandler) GetBySKU(w http.ResponseWriter, r *http.Request) {
+	sku := chi.URLParam(r, "sku")
+	if sku == "" {
+		h.respondError(w, http.StatusBadRequest, "invalid_sku", "SKU is required", nil)
+		return
+	}
+
+	product, err := h.getBySKUUseCase.Execute(r.Context(), sku)
+	if err != nil {
+		h.handleDomainError(w, err, "Failed to get product by SKU")
+		return
+	}
+
+	h.respond(w, r, http.StatusOK, productResponse(r, product))
+}
+
+// List godoc
+// @Summary      Listar produtos
+// @Description  Retorna uma página de produtos com o total do conjunto completo (dto.PaginatedResponse). Com format=array, retorna o array simples antigo. Com SERVER_MAX_LIST_RESPONSE_BYTES definido, a resposta é um dto.ProductListResponse com meta.truncated e meta.total. Com modified_by (somente admin), retorna os produtos alterados por último por esse usuário, segundo a trilha de auditoria, do mais recente para o mais antigo.
+// @Tags         products
+// @Accept       json
+// @Produce      json,application/xml
+// @Param        modified_by  query     string  false  "Subject (claim sub) do autor da última alteração; exige o role de admin"
+// @Param        sort         query     string  false  "Campo de ordenação (padrão created_at decrescente)"  Enums(name, created_at, updated_at, stock)
+// @Param        order        query     string  false  "Sentido da ordenação; com sort e sem order, asc"  Enums(asc, desc)
+// @Param        in_stock     query     bool    false  "true traz só produtos com estoque; false, só os esgotados"
+// @Param        min_price    query     int     false  "Preço mínimo em centavos (inclusivo)"
+// @Param        max_price    query     int     false  "Preço máximo em centavos (inclusivo)"
+// @Param        min_weight   query     int     false  "Peso mínimo em gramas (inclusivo); exclui produtos sem peso"
+// @Param        max_weight   query     int     false  "Peso máximo em gramas (inclusivo); exclui produtos sem peso"
+// @Param        min_volume   query     int     false  "Volume mínimo em cm³ (inclusivo); exclui produtos sem as três medidas"
+// @Param        max_volume   query     int     false  "Volume máximo em cm³ (inclusivo); exclui produtos sem as três medidas"
+// @Param        format       query     string  false  "array mantém o formato antigo, sem total"  Enums(array)
+// @Param        limit        query     int     false  "Limite de resultados (acima do máximo configurado, usa o máximo)"  default(50)
+// @Param        offset       query     int     false  "Offset para paginação"            default(0)
+// @Param        tz           query     string  false  "Fuso IANA para created_at e updated_at (padrão UTC)"
+// @Param        fields       query     string  false  "Caminhos em specifications separados por vírgula (ex.: specifications.color)"
+// @Success      200          {object}  dto.PaginatedResponse
+// @Header       200          {string}  Link  "Links first, prev e next (RFC 8288)"
+// @Failure      400          {object}  dto.ErrorResponse
+// @Failure      401          {object}  dto.ErrorResponse
+// @Failure      403          {object}  dto.ErrorResponse
+// @Failure      500          {object}  dto.ErrorResponse
+// @Failure      503     {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/products [get]
+func (h *ProductHandler) List(w http.ResponseWriter, r *http.Request) {
+	sort, ok := parseSort(r)
+	if !ok {
+		h.respondError(w, http.StatusBadRequest, "invalid_sort", invalidSortMessage, nil)
+		return
+	}
+
+	filter, ok := parseListFilter(r)
+	if !ok {
+		h.respondError(w, http.StatusBadRequest, "invalid_query", invalidInStockMessage, nil)
+		return
+	}
+	if filter.MinPrice, filter.MaxPrice, ok = parsePriceRange(r); !ok {
+		h.respondError(w, http.StatusBadRequest, "invalid_price_range", invalidPriceRangeMessage, nil)
+		return
+	}
+	if !parseShippingRange(r, &filter) {
+		h.respondError(w, http.StatusBadRequest, "invalid_shipping_range", invalidShippingRangeMessage, nil)
+		return
+	}
+
+	limit, offset := h.getPagination(r)
+
+	products, pg, err := fetchPage(limit, offset, func(limit, offset int) ([]*entity.Product, error) {
+		return h.listUseCase.Execute(r.Context(), filter, sort, limit, offset)
+	})
+	if err != nil {
+		h.handleDomainError(w, err, "Failed to list products")
+		return
+	}
+
+	h.respondCountedList(w, r, products, pg, func() (int, error) {
+		return h.countUseCase.Count(r.Context(), filter)
+	})
+}
+
+// ListModifiedBy atende GET /products?modified_by=...; o router só chega aqui
+// depois de exigir o role de admin.
+func (h *ProductHandler) ListModifiedBy(w http.ResponseWriter, r *http.Request) {
+	subject := r.URL.Query().Get("modified_by")
+	if subject == "" {
+		h.respondError(w, http.StatusBadRequest, "invalid_query", "modified_by must not be empty", nil)
+		return
+	}
+
+	limit, offset := h.getPagination(r)
+
+	products, pg, err := fetchPage(limit, offset, func(limit, offset int) ([]*entity.Product, error) {
+		return h.modifiedByUseCase.Execute(r.Context(), subject, limit, offset)
+	})
+	if err != nil {
+		h.handleDomainError(w, err, "Failed to list products modified by user")
+		return
+	}
+
+	h.respondProductList(w, r, products, pg)
+}
+
+// Recent godoc
+// @Summary      Produtos recentes
+// @Description  Retorna os produtos criados mais recentemente (created_at decrescente), para vitrines de novidades. Servido pelo índice all_products do cache quando disponível.
+// @Tags         products
+// @Accept       json
+// @Produce      json,application/xml
+// @Param        limit   query     int  false  "Quantidade de produtos (máx 50)"  default(10)
+// @Param        tz      query     string  false  "Fuso IANA para created_at e updated_at (padrão UTC)"
+// @Param        fields  query     string  false  "Caminhos em specifications separados por vírgula (ex.: specifications.color)"
+// @Success      200     {array}   dto.ProductResponse
+// @Failure      400     {object}  dto.ErrorResponse
+// @Failure      401     {object}  dto.ErrorResponse
+// @Failure      500     {object}  dto.ErrorResponse
+// @Failure      503     {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/products/recent [get]
+func (h *ProductHandler) Recent(w http.ResponseWriter, r *http.Request) {
+	limit := defaultRecentLimit
+	if l := r.URL.Query().Get("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil || parsed <= 0 || parsed > maxRecentLimit {
+			h.respondError(w, http.StatusBadRequest, "invalid_request", fmt.Sprintf("limit must be between 1 and %d", maxRecentLimit), nil)
+			return
+		}
+		limit = parsed
+	}
+
+	products, err := h.recentUseCase.Execute(r.Context(), limit)
+	if err != nil {
+		h.handleDomainError(w, err, "Failed to list recent products")
+		return
+	}
+
+	h.respondProductList(w, r, products, nil)
+}
+
+// SearchByName godoc
+// @Summary      Buscar produtos por nome
+// @Description  Retorna produtos que correspondem ao termo de busca no nome, com o total de correspondências (dto.PaginatedResponse); com format=array, o array simples antigo. A resposta traz um ETag fraco derivado dos IDs e versões; com If-None-Match igual, responde 304.
+// @Tags         products
+// @Accept       json
+// @Produce      json,application/xml
+// @Param        q              query     string  true   "Termo de busca"
+// @Param        sort           query     string  false  "Ordenação: relevance (exato, prefixo, contém; alfabética dentro de cada faixa) ou um campo"  Enums(relevance, name, created_at, updated_at, stock)  default(name)
+// @Param        order          query     string  false  "Sentido da ordenação; com sort e sem order, asc"  Enums(asc, desc)
+// @Param        min_price      query     int     false  "Preço mínimo em centavos (inclusivo)"
+// @Param        max_price      query     int     false  "Preço máximo em centavos (inclusivo)"
+// @Param        format         query     string  false  "array mantém o formato antigo, sem total"  Enums(array)
+// @Param        limit          query     int     false  "Limite de resultados (acima do máximo configurado, usa o máximo)"  default(50)
+// @Param        offset         query     int     false  "Offset para paginação"            default(0)
+// @Param        If-None-Match  header    string  false  "ETag de uma resposta anterior"
+// @Param        tz             query     string  false  "Fuso IANA para created_at e updated_at (padrão UTC)"
+// @Param        fields         query     string  false  "Caminhos em specifications separados por vírgula (ex.: specifications.color)"
+// @Success      200            {object}  dto.PaginatedResponse
+// @Header       200            {string}  Link  "Links first, prev e next (RFC 8288)"
+// @Success      304            "Resultado inalterado"
+// @Failure      400            {object}  dto.ErrorResponse
+// @Failure      401            {object}  dto.ErrorResponse
+// @Failure      500            {object}  dto.ErrorResponse
+// @Failure      503            {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/products/search/name [get]
+func (h *ProductHandler) SearchByName(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("q")
+	if name == "" {
+		h.respondError(w, http.StatusBadRequest, "invalid_query", "Search query is required", nil)
+		return
+	}
+
+	order, sort, ok := parseNameSort(r)
+	if !ok {
+		h.respondError(w, http.StatusBadRequest, "invalid_sort", "Sort must be one of 'relevance', 'name', 'created_at', 'updated_at' or 'stock' and order must be 'asc' or 'desc'", nil)
+		return
+	}
+
+	var filter repository.ListFilter
+	if filter.MinPrice, filter.MaxPrice, ok = parsePriceRange(r); !ok {
+		h.respondError(w, http.StatusBadRequest, "invalid_price_range", invalidPriceRangeMessage, nil)
+		return
+	}
+
+	limit, offset := h.getPagination(r)
+
+	products, pg, err := fetchPage(limit, offset, func(limit, offset int) ([]*entity.Product, error) {
+		return h.searchByNameUseCase.Execute(r.Context(), name, filter, order, sort, limit, offset)
+	})
+	if err != nil {
+		h.handleDomainError(w, err, "Failed to search products")
+		return
+	}
+
+	if notModified(w, r, products) {
+		return
+	}
+
+	h.respondCountedList(w, r, products, pg, func() (int, error) {
+		return h.countUseCase.CountByName(r.Context(), name, filter)
+	})
+}
+
+// SearchByCategory godoc
+// @Summary      Buscar produtos por categoria
+// @Description  Retorna produtos que correspondem à categoria especificada, com o total da categoria (dto.PaginatedResponse); com format=array, o array simples antigo. A resposta traz um ETag fraco derivado dos IDs e versões; com If-None-Match igual, responde 304.
+// @Tags         products
+// @Accept       json
+// @Produce      json,application/xml
+// @Param        q              query     string  true   "Nome da categoria"
+// @Param        sort           query     string  false  "Campo de ordenação (padrão created_at decrescente)"  Enums(name, created_at, updated_at, stock)
+// @Param        order          query     string  false  "Sentido da ordenação; com sort e sem order, asc"  Enums(asc, desc)
+// @Param        min_price      query     int     false  "Preço mínimo em centavos (inclusivo)"
+// @Param        max_price      query     int     false  "Preço máximo em centavos (inclusivo)"
+// @Param        format         query     string  false  "array mantém o formato antigo, sem total"  Enums(array)
+// @Param        limit          query     int     false  "Limite de resultados (acima do máximo configurado, usa o máximo)"  default(50)
+// @Param        offset         query     int     false  "Offset para paginação"            default(0)
+// @Param        If-None-Match  header    string  false  "ETag de uma resposta anterior"
+// @Param        tz             query     string  false  "Fuso IANA para created_at e updated_at (padrão UTC)"
+// @Param        fields         query     string  false  "Caminhos em specifications separados por vírgula (ex.: specifications.color)"
+// @Success      200            {object}  dto.PaginatedResponse
+// @Header       200            {string}  Link  "Links first, prev e next (RFC 8288)"
+// @Success      304            "Resultado inalterado"
+// @Failure      400            {object}  dto.ErrorResponse
+// @Failure      401            {object}  dto.ErrorResponse
+// @Failure      500            {object}  dto.ErrorResponse
+// @Failure      503            {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/products/search/category [get]
+func (h *ProductHandler) SearchByCategory(w http.ResponseWriter, r *http.Request) {
+	category := r.URL.Query().Get("q")
+	if category == "" {
+		h.respondError(w, http.StatusBadRequest, "invalid_query", "Category query is required", nil)
+		return
+	}
+
+	sort, ok := parseSort(r)
+	if !ok {
+		h.respondError(w, http.StatusBadRequest, "invalid_sort", invalidSortMessage, nil)
+		return
+	}
+
+	var filter repository.ListFilter
+	if filter.MinPrice, filter.MaxPrice, ok = parsePriceRange(r); !ok {
+		h.respondError(w, http.StatusBadRequest, "invalid_price_range", invalidPriceRangeMessage, nil)
+		return
+	}
+
+	limit, offset := h.getPagination(r)
+
+	products, pg, err := fetchPage(limit, offset, func(limit, offset int) ([]*entity.Product, error) {
+		return h.searchByCategoryUseCase.Execute(r.Context(), category, filter, sort, limit, offset)
+	})
+	if err != nil {
+		h.handleDomainError(w, err, "Failed to search products")
+		return
+	}
+
+	if notModified(w, r, products) {
+		return
+	}
+
+	h.respondCountedList(w, r, products, pg, func() (int, error) {
+		return h.countUseCase.CountByCategory(r.Context(), category, filter)
+	})
+}
+
+// Count godoc
+// @Summary      Contar produtos
+// @Description  Retorna o total de produtos ativos sem trazer as linhas; com category, o total da categoria. O total geral vem de um cache curto, invalidado a cada criação ou exclusão.
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        category  query     string  false  "Nome da categoria"
+// @Success      200       {object}  dto.CountResponse
+// @Failure      400       {object}  dto.ErrorResponse
+// @Failure      401       {object}  dto.ErrorResponse
+// @Failure      500       {object}  dto.ErrorResponse
+// @Failure      503       {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/products/count [get]
+func (h *ProductHandler) Count(w http.ResponseWriter, r *http.Request) {
+	if !r.URL.Query().Has("category") {
+		total, err := h.countUseCase.Count(r.Context(), repository.ListFilter{})
+		if err != nil {
+			h.handleDomainError(w, err, "Failed to count products")
+			return
+		}
+		h.respondJSON(w, http.StatusOK, dto.CountResponse{Total: total})
+		return
+	}
+
+	category := r.URL.Query().Get("category")
+	if strings.TrimSpace(category) == "" {
+		h.respondError(w, http.StatusBadRequest, "invalid_query", "Category must not be empty", nil)
+		return
+	}
+
+	total, err := h.countUseCase.CountByCategory(r.Context(), category, repository.ListFilter{})
+	if err != nil {
+		h.handleDomainError(w, err, "Failed to count products")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, dto.CountResponse{Total: total, Category: category})
+}
+
+// SearchByTag godoc
+// @Summary      Buscar produtos por tag
+// @Description  Retorna os produtos marcados com a tag, do mais novo para o mais antigo. A tag é comparada sem diferenciar maiúsculas. A resposta traz um ETag fraco derivado dos IDs e versões; com If-None-Match igual, responde 304.
+// @Tags         products
+// @Accept       json
+// @Produce      json,application/xml
+// @Param        q              query     string  true   "Tag"
+// @Param        limit          query     int     false  "Limite de resultados (acima do máximo configurado, usa o máximo)"  default(50)
+// @Param        offset         query     int     false  "Offset para paginação"            default(0)
+// @Param        If-None-Match  header    string  false  "ETag de uma resposta anterior"
+// @Param        tz             query     string  false  "Fuso IANA para created_at e updated_at (padrão UTC)"
+// @Param        fields         query     string  false  "Caminhos em specifications separados por vírgula (ex.: specifications.color)"
+// @Success      200            {array}   dto.ProductResponse
+// @Header       200            {string}  Link  "Links first, prev e next (RFC 8288)"
+// @Success      304            "Resultado inalterado"
+// @Failure      400            {object}  dto.ErrorResponse
+// @Failure      401            {object}  dto.ErrorResponse
+// @Failure      500            {object}  dto.ErrorResponse
+// @Failure      503            {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/products/search/tag [get]
+func (h *ProductHandler) SearchByTag(w http.ResponseWriter, r *http.Request) {
+	tag := r.URL.Query().Get("q")
+	if entity.NormalizeTag(tag) == "" {
+		h.respondError(w, http.StatusBadRequest, "invalid_query", "Tag query is required", nil)
+		return
+	}
+
+	limit, offset := h.getPagination(r)
+
+	products, pg, err := fetchPage(limit, offset, func(limit, offset int) ([]*entity.Product, error) {
+		return h.searchByTagUseCase.Execute(r.Context(), tag, limit, offset)
+	})
+	if err != nil {
+		h.handleDomainError(w, err, "Failed to search products")
+		return
+	}
+
+	if notModified(w, r, products) {
+		return
+	}
+
+	h.respondProductList(w, r, products, pg)
+}
+
+// Import godoc
+// @Summary      Importar produtos
+// @Description  Importa um lote de produtos preservando created_at/updated_at do sistema de origem. Linhas inválidas (timestamps malformados, no futuro ou updated_at anterior a created_at) são rejeitadas individualmente e listadas no relatório.
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        products  body      dto.ImportProductsRequest  true  "Lote de produtos (máx MAX_BULK_ITEMS, padrão 500)"
+// @Success      200       {object}  dto.ImportReportResponse
+// @Failure      400       {object}  dto.ErrorResponse
+// @Failure      401       {object}  dto.ErrorResponse
+// @Failure      403       {object}  dto.ErrorResponse
+// @Failure      413       {object}  dto.ErrorResponse
+// @Failure      500       {object}  dto.ErrorResponse
+// @Failure      503       {object}  dto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/products/import [post]
+func (h *ProductHandler) Import(w http.ResponseWriter, r *http.Request) {
+	var req dto.ImportProductsRequest
+	if err := h.decodeBulk(w, r, &req); err != nil {
+		h.respondBodyError(w, err)
+		return
+	}
+
+	if !h.checkBulkSize(w, "Products", len(req.Products), h.maxBulkItems) {
+		return
+	}
+
+	rows := make([]port.ImportProductInput, len(req.Products))
+	for i, row := range req.Products {
+		rows[i] = port.ImportProductInput{
+			CreateProductInput: port.CreateProductInput{
+				Name:            row.Name,
+				ReferenceNumber: row.ReferenceNumber,
+				Category:        row.Category,
+				Description:     row.Description,
+				SKU:             row.SKU,
+				Brand:           row.Brand,
+				Stock:           row.Stock,
+				Price:           row.Price,
+				PriceAmount:     row.PriceAmount,
+				Currency:        row.Currency,
+				Images:          row.Images,
+				Specifications:  row.Specifications,
+				ThumbnailURL:    row.ThumbnailURL,
+				Tags:            row.Tags,
+				WeightGrams:     row.WeightGrams,
+				Dimensions:      row.Dimensions.Entity(),
+			},
+			CreatedAt: row.CreatedAt,
+			UpdatedAt: row.UpdatedAt,
+		}
+	}
+
+	report, err := h.importUseCase.Execute(r.Context(), rows)
+	if err != nil {
+		h.handleDomainError(w, err, "Failed to import products")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, dto.ToImportReportResponse(report))
+}
+
+// getPagination lê limit e offset da query. Um limit inválido usa o padrão
+// e um acima do máximo é reduzido ao máximo.
+func (h *ProductHandler) getPagination(r *http.Request) (limit, offset int) {
+	limit = h.defaultPageLimit
+	offset = 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = min(parsed, h.maxPageLimit)
+		}
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+
+	return limit, offset
+}
+
+func (h *ProductHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(data)
+	if err != nil {
+		h.logger.Error("failed to encode response", zap.Error(err))
+	}
+	return err
+}
+
+// respond escreve dados de produto no formato negociado pelo header Accept:
+// XML para integrações legadas que o pedem, JSON nos demais casos.
+func (h *ProductHandler) respond(w http.ResponseWriter, r *http.Request, status int, data interface{}) {
+	defer recordSerializeTime(r, time.Now())
+
+	if !negotiateXML(w, r) {
+		h.respondJSON(w, status, data)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(status)
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		h.logger.Error("failed to write response", zap.Error(err))
+		return
+	}
+	if err := xml.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("failed to encode response", zap.Error(err))
+	}
+}
+
+// respondProductList escreve uma listagem. Em XML, a lista vem completa dentro
+// de <products>. Em JSON, sem orçamento configurado usa o array simples; com
+// orçamento, serializa produto a produto direto no writer e para antes do
+// primeiro que estouraria o limite. Com pg, os links de paginação vão no corpo
+// (formato com orçamento) ou no header Link (array simples e XML).
+func (h *ProductHandler) respondProductList(w http.ResponseWriter, r *http.Request, products []*entity.Product, pg *page) {
+	if negotiateXML(w, r) {
+		if pg != nil {
+			setLinkHeader(w, pg.links(r, h.trustProxyHeaders, len(products), false))
+		}
+		h.respond(w, r, http.StatusOK, dto.ProductListXML{Products: productResponseList(r, products)})
+		return
+	}
+
+	if h.maxListBytes <= 0 {
+		if pg != nil {
+			setLinkHeader(w, pg.links(r, h.trustProxyHeaders, len(products), false))
+		}
+		if h.streamFlushEvery > 0 {
+			h.streamJSONList(w, r, products, "[", "]")
+			return
+		}
+		defer recordSerializeTime(r, time.Now())
+		if h.respondJSON(w, http.StatusOK, productResponseList(r, products)) == nil {
+			middleware.MarkResponseComplete(r.Context())
+		}
+		return
+	}
+
+	h.streamProductList(w, r, products, pg, nil)
+}
+
+// respondCountedList é a listagem das rotas com total. Em JSON, o padrão é o
+// dto.PaginatedResponse (ou, com orçamento de bytes, o total em meta.total);
+// ?format=array e XML mantêm os formatos antigos, sem consultar o total.
+func (h *ProductHandler) respondCountedList(w http.ResponseWriter, r *http.Request, products []*entity.Product, pg *page, count func() (int, error)) {
+	if negotiateXML(w, r) || r.URL.Query().Get("format") == "array" {
+		h.respondProductList(w, r, products, pg)
+		return
+	}
+
+	total, err := count()
+	if err != nil {
+		// Sem o total (banco fora no modo degradado), informa o mínimo que a
+		// própria página garante em vez de falhar a listagem.
+		total = pg.offset + len(products)
+		if pg.hasNext {
+			total++
+		}
+		h.logger.Warn("list total unavailable - using lower bound",
+			zap.Error(err),
+			zap.Int("total", total),
+		)
+	}
+
+	if h.maxListBytes > 0 {
+		h.streamProductList(w, r, products, pg, &total)
+		return
+	}
+
+	setLinkHeader(w, pg.links(r, h.trustProxyHeaders, len(products), false))
+	if h.streamFlushEvery > 0 {
+		h.streamJSONList(w, r, products, `{"data":[`, fmt.Sprintf(`],"total":%d,"limit":%d,"offset":%d}`, total, pg.limit, pg.offset))
+		return
+	}
+	defer recordSerializeTime(r, time.Now())
+	err = h.respondJSON(w, http.StatusOK, dto.PaginatedResponse{
+		Data:   productResponseList(r, products),
+		Total:  total,
+		Limit:  pg.limit,
+		Offset: pg.offset,
+	})
+	if err == nil {
+		middleware.MarkResponseComplete(r.Context())
+	}
+}
+
+// streamProductList escreve o formato com orçamento de bytes. total, quando
+// informado, vai em meta.total.
+func (h *ProductHandler) streamProductList(w http.ResponseWriter, r *http.Request, products []*entity.Product, pg *page, total *int) {
+	defer recordSerializeTime(r, time.Now())
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	meta := dto.ProductListMeta{Total: total}
+	used := 0
+	skipped := false
+
+	if _, err := io.WriteString(w, `{"data":[`); err != nil {
+		h.logger.Error("failed to write response", zap.Error(err))
+		return
+	}
+
+	for _, product := range products {
+		data, err := json.Marshal(productResponse(r, product))
+		if err != nil {
+			h.logger.Error("failed to encode product", zap.Error(err), zap.String("product_id", product.ID))
+			skipped = true
+			continue
+		}
+
+		if used+len(data) > h.maxListBytes {
+			meta.Truncated = true
+			break
+		}
+
+		if meta.Count > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				h.logger.Error("failed to write response", zap.Error(err))
+				return
+			}
+		}
+		if _, err := w.Write(data); err != nil {
+			h.logger.Error("failed to write response", zap.Error(err))
+			return
+		}
+
+		used += len(data)
+		meta.Count++
+		if h.streamFlushEvery > 0 && meta.Count%h.streamFlushEvery == 0 {
+			http.NewResponseController(w).Flush()
+		}
+	}
+
+	metaJSON, _ := json.Marshal(meta)
+	tail := fmt.Sprintf(`],"meta":%s`, metaJSON)
+	if pg != nil {
+		linksJSON, _ := json.Marshal(pg.links(r, h.trustProxyHeaders, meta.Count, meta.Truncated))
+		tail += fmt.Sprintf(`,"links":%s`, linksJSON)
+	}
+	if _, err := io.WriteString(w, tail+"}\n"); err != nil {
+		h.logger.Error("failed to write response", zap.Error(err))
+	} else if !skipped {
+		middleware.MarkResponseComplete(r.Context())
+	}
+
+	if meta.Truncated {
+		h.logger.Warn("list response truncated by byte budget",
+			zap.Int("returned", meta.Count),
+			zap.Int("available", len(products)),
+			zap.Int("max_bytes", h.maxListBytes),
+		)
+	}
+}
+
+// streamJSONList escreve prefix, os produtos um a um e suffix, com flush a
+// cada streamFlushEvery produtos, o que antecipa o primeiro byte e evita montar
+// a resposta inteira em memória. O status 200 sai antes dos produtos: um
+// produto que não serializa encerra a lista ali (o erro vai para o log) e a
+// resposta fecha como JSON válido com os anteriores, mas sem ser marcada como
+// completa para o cache de respostas; uma falha de escrita (cliente
+// desconectado) só é registrada.
+func (h *ProductHandler) streamJSONList(w http.ResponseWriter, r *http.Request, products []*entity.Product, prefix, suffix string) {
+	defer recordSerializeTime(r, time.Now())
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	flusher := http.NewResponseController(w)
+	if _, err := io.WriteString(w, prefix); err != nil {
+		h.logger.Error("failed to write response", zap.Error(err))
+		return
+	}
+
+	truncated := false
+	for i, product := range products {
+		data, err := json.Marshal(productResponse(r, product))
+		if err != nil {
+			h.logger.Error("list stream truncated - failed to encode product",
+				zap.Error(err),
+				zap.String("product_id", product.ID),
+				zap.Int("written", i),
+				zap.Int("available", len(products)),
+			)
+			truncated = true
+			break
+		}
+		if i > 0 {
+			data = append([]byte{','}, data...)
+		}
+		if _, err := w.Write(data); err != nil {
+			h.logger.Error("list stream aborted - failed to write response", zap.Error(err), zap.Int("written", i))
+			return
+		}
+		if (i+1)%h.streamFlushEvery == 0 {
+			if err := flusher.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+				h.logger.Error("list stream aborted - failed to flush response", zap.Error(err), zap.Int("written", i+1))
+				return
+			}
+		}
+	}
+
+	if _, err := io.WriteString(w, suffix+"\n"); err != nil {
+		h.logger.Error("failed to write response", zap.Error(err))
+	} else if !truncated {
+		middleware.MarkResponseComplete(r.Context())
+	}
+}
+
+// recordSerializeTime soma ao port.RequestTiming da requisição o tempo gasto
+// desde start montando e escrevendo a resposta.
+func recordSerializeTime(r *http.Request, start time.Time) {
+	port.RecordSerializeTime(r.Context(), time.Since(start))
+}
+
+// productResponse converte o produto para a resposta no fuso pedido em ?tz.
+func productResponse(r *http.Request, product *entity.Product) *dto.ProductResponse {
+	response := dto.ToProductResponseIn(product, middleware.GetTimezone(r.Context()))
+	response.Specifications = response.Specifications.Select(middleware.GetSpecFields(r.Context()))
+	return response
+}
+
+func productResponseList(r *http.Request, products []*entity.Product) []*dto.ProductResponse {
+	responses := dto.ToProductResponseListIn(products, middleware.GetTimezone(r.Context()))
+	if paths := middleware.GetSpecFields(r.Context()); paths != nil {
+		for _, response := range responses {
+			response.Specifications = response.Specifications.Select(paths)
+		}
+	}
+	return responses
+}
+
+func (h *ProductHandler) respondError(w http.ResponseWriter, status int, code, message string, err error) {
+	h.respondFieldErrors(w, status, code, message, nil, err)
+}
+
+// respondFieldErrors é o respondError com as mensagens por campo de um
+// entity.ValidationErrors.
+func (h *ProductHandler) respondFieldErrors(w http.ResponseWriter, status int, code, message string, fields map[string]string, err error) {
+	if err != nil {
+		h.logger.Error("request error",
+			zap.String("code", code),
+			zap.String("message", message),
+			zap.Error(err),
+		)
+	}
+
+	resp := dto.ErrorResponse{
+		Error:   code,
+		Message: message,
+		Fields:  fields,
+	}
+	if h.errorDetails && err != nil {
+		resp.Details = errorChain(err)
+	}
+
+	h.respondJSON(w, status, resp)
+}
+
+// errorChain lista a mensagem do erro seguida das de cada erro embrulhado,
+// em profundidade, incluindo os de errors.Join.
+func errorChain(err error) []string {
+	var chain []string
+	var walk func(error)
+	walk = func(err error) {
+		for err != nil {
+			chain = append(chain, err.Error())
+			switch e := err.(type) {
+			case interface{ Unwrap() []error }:
+				for _, inner := range e.Unwrap() {
+					walk(inner)
+				}
+				return
+			default:
+				err = errors.Unwrap(err)
+			}
+		}
+	}
+	walk(err)
+	return chain
+}
+
 // handleDomainError usa o tradutor de erros para converter erros de domínio em respostas HTTP.
 func (h *ProductHandler) handleDomainError(w http.ResponseWriter, err error, fallbackMessage string) {
 	if httpErr := TranslateDomainError(err); httpErr != nil {
-		h.respondError(w, httpErr.StatusCode, httpErr.Code, httpErr.Message, err)
+		h.respondFieldErrors(w, httpErr.StatusCode, httpErr.Code, httpErr.Message, httpErr.Fields, err)
 		return
 	}
 	h.respondError(w, http.StatusInternalServerError, "internal_error", fallbackMessage, err)
 }
+
+// bulkCreateItem traduz o resultado de um item da criação em lote com o mesmo
+// mapeamento de erros da criação unitária.
+func (h *ProductHandler) bulkCreateItem(r *http.Request, result port.BulkCreateResult) dto.BulkCreateItemResponse {
+	if result.Err == nil {
+		return dto.BulkCreateItemResponse{
+			Index:   result.Index,
+			Status:  http.StatusCreated,
+			Product: productResponse(r, result.Product),
+		}
+	}
+
+	httpErr := TranslateDomainError(result.Err)
+	if httpErr == nil {
+		h.logger.Error("bulk create item failed",
+			zap.Int("index", result.Index),
+			zap.Error(result.Err),
+		)
+		httpErr = &HTTPError{
+			StatusCode: http.StatusInternalServerError,
+			Code:       "internal_error",
+			Message:    "Failed to create product",
+		}
+	}
+
+	return dto.BulkCreateItemResponse{
+		Index:   result.Index,
+		Status:  httpErr.StatusCode,
+		Error:   httpErr.Code,
+		Message: httpErr.Message,
+		Fields:  httpErr.Fields,
+	}
+}
+
+func toCreateProductInput(req dto.CreateProductRequest) port.CreateProductInput {
+	return port.CreateProductInput{
+		Name:            req.Name,
+		ReferenceNumber: req.ReferenceNumber,
+		Category:        req.Category,
+		Description:     req.Description,
+		SKU:             req.SKU,
+		Brand:           req.Brand,
+		Stock:           req.Stock,
+		Price:           req.Price,
+		PriceAmount:     req.PriceAmount,
+		Currency:        req.Currency,
+		Images:          req.Images,
+		Specifications:  req.Specifications,
+		ThumbnailURL:    req.ThumbnailURL,
+		Tags:            req.Tags,
+		WeightGrams:     req.WeightGrams,
+		Dimensions:      req.Dimensions.Entity(),
+	}
+}
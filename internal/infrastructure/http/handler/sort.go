@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+// invalidSortMessage descreve os valores aceitos por parseSort.
+const invalidSortMessage = "Sort must be one of 'name', 'created_at', 'updated_at' or 'stock' and order must be 'asc' or 'desc'"
+
+// parseSort lê os parâmetros sort e order das listagens; vazios mantêm a ordem
+// padrão de cada uma. Só valores da lista permitida são aceitos.
+func parseSort(r *http.Request) (repository.SortOptions, bool) {
+	query := r.URL.Query()
+	return parseSortValues(query.Get("sort"), query.Get("order"))
+}
+
+func parseSortValues(field, direction string) (repository.SortOptions, bool) {
+	sort := repository.SortOptions{
+		Field:     repository.SortField(field),
+		Direction: repository.SortDirection(direction),
+	}
+
+	switch sort.Field {
+	case repository.SortDefault, repository.SortByName, repository.SortByCreatedAt,
+		repository.SortByUpdatedAt, repository.SortByStock:
+	default:
+		return repository.SortOptions{}, false
+	}
+
+	switch sort.Direction {
+	case "", repository.SortAsc, repository.SortDesc:
+	default:
+		return repository.SortOptions{}, false
+	}
+
+	return sort, true
+}
+
+// parseNameSort lê sort e order da busca por nome, que também aceita
+// sort=relevance: as faixas de relevância vêm primeiro e, dentro delas, vale a
+// ordem alfabética no sentido de order.
+func parseNameSort(r *http.Request) (repository.NameSearchOrder, repository.SortOptions, bool) {
+	query := r.URL.Query()
+	field := query.Get("sort")
+
+	order := repository.NameOrderAlphabetical
+	if field == string(repository.NameOrderRelevance) {
+		order = repository.NameOrderRelevance
+		field = ""
+	}
+
+	sort, ok := parseSortValues(field, query.Get("order"))
+	return order, sort, ok
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+)
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  repository.SortOptions
+		ok    bool
+	}{
+		{"empty keeps default", "", repository.SortOptions{}, true},
+		{"field only", "sort=stock", repository.SortOptions{Field: repository.SortByStock}, true},
+		{"field and order", "sort=updated_at&order=desc", repository.SortOptions{Field: repository.SortByUpdatedAt, Direction: repository.SortDesc}, true},
+		{"order only", "order=asc", repository.SortOptions{Direction: repository.SortAsc}, true},
+		{"unknown field", "sort=price", repository.SortOptions{}, false},
+		{"sql in field", "sort=name%3BDROP%20TABLE%20products", repository.SortOptions{}, false},
+		{"relevance is only for name search", "sort=relevance", repository.SortOptions{}, false},
+		{"unknown order", "sort=name&order=up", repository.SortOptions{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/v1/products?"+tt.query, nil)
+
+			got, ok := parseSort(r)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("parseSort() = %+v, %v, want %+v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestParseNameSort(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantOrder repository.NameSearchOrder
+		wantSort  repository.SortOptions
+		ok        bool
+	}{
+		{"empty is alphabetical", "", repository.NameOrderAlphabetical, repository.SortOptions{}, true},
+		{"name", "sort=name", repository.NameOrderAlphabetical, repository.SortOptions{Field: repository.SortByName}, true},
+		{"relevance keeps default field", "sort=relevance&order=desc", repository.NameOrderRelevance, repository.SortOptions{Direction: repository.SortDesc}, true},
+		{"other field", "sort=created_at&order=desc", repository.NameOrderAlphabetical, repository.SortOptions{Field: repository.SortByCreatedAt, Direction: repository.SortDesc}, true},
+		{"unknown field", "sort=popularity", "", repository.SortOptions{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/v1/products/search/name?q=x&"+tt.query, nil)
+
+			order, sort, ok := parseNameSort(r)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if ok && (order != tt.wantOrder || sort != tt.wantSort) {
+				t.Errorf("parseNameSort() = %q, %+v, want %q, %+v", order, sort, tt.wantOrder, tt.wantSort)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/middleware"
+	"go.uber.org/zap"
+)
+
+func TestRespondProductList_Timezone(t *testing.T) {
+	h := &ProductHandler{logger: zap.NewNop()}
+	createdAt := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	products := []*entity.Product{{ID: "A", Name: "Notebook", CreatedAt: createdAt, UpdatedAt: createdAt}}
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "default utc", query: "", want: "2024-01-15T10:30:00Z"},
+		{name: "sao paulo", query: "?tz=America/Sao_Paulo", want: "2024-01-15T07:30:00-03:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			middleware.Timezone(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				h.respondProductList(w, r, products, nil)
+			})).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/products"+tt.query, nil))
+
+			var body []map[string]interface{}
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatalf("Expected JSON array, got %v", err)
+			}
+			if len(body) != 1 {
+				t.Fatalf("Expected 1 product, got %d", len(body))
+			}
+			if body[0]["created_at"] != tt.want || body[0]["updated_at"] != tt.want {
+				t.Errorf("Expected timestamps %s, got %v / %v", tt.want, body[0]["created_at"], body[0]["updated_at"])
+			}
+		})
+	}
+
+	if products[0].CreatedAt.Location() != time.UTC {
+		t.Error("Expected the product itself to stay in UTC")
+	}
+}
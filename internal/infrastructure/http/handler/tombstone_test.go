@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/dto"
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/middleware"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type missingGetter struct{}
+
+func (missingGetter) Execute(ctx context.Context, id string) (*entity.Product, error) {
+	return nil, repository.ErrProductNotFound
+}
+
+// stubTombstones conhece só o produto excluído "deleted".
+type stubTombstones struct {
+	deletedAt time.Time
+}
+
+func (s stubTombstones) Execute(ctx context.Context, id string) (*entity.Tombstone, error) {
+	if id != "deleted" {
+		return nil, repository.ErrProductNotFound
+	}
+	return &entity.Tombstone{ID: id, DeletedAt: s.deletedAt}, nil
+}
+
+func TestGet_Tombstones(t *testing.T) {
+	deletedAt := time.Date(2024, 1, 20, 14, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		enabled bool
+		roles   []string
+		id      string
+		status  int
+		code    string
+	}{
+		{name: "admin sees deleted product", enabled: true, roles: []string{"admin"}, id: "deleted", status: http.StatusGone, code: "product_deleted"},
+		{name: "admin never existed", enabled: true, roles: []string{"admin"}, id: "unknown", status: http.StatusNotFound, code: "product_not_found"},
+		{name: "non-admin", enabled: true, roles: []string{"reader"}, id: "deleted", status: http.StatusNotFound, code: "product_not_found"},
+		{name: "disabled", roles: []string{"admin"}, id: "deleted", status: http.StatusNotFound, code: "product_not_found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewProductHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, missingGetter{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop())
+			if tt.enabled {
+				h.WithTombstones(stubTombstones{deletedAt: deletedAt}, "admin")
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/products/"+tt.id, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.id)
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			ctx = context.WithValue(ctx, middleware.UserContextKey, &middleware.UserClaims{Subject: "user", RealmRoles: tt.roles})
+
+			rec := httptest.NewRecorder()
+			h.Get(rec, req.WithContext(ctx))
+			assertBodyResponse(t, http.MethodGet, rec, http.StatusOK, tt.status, tt.code)
+
+			if tt.status != http.StatusGone {
+				return
+			}
+			var got dto.TombstoneResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("Expected JSON body, got %v", err)
+			}
+			if got.ID != "deleted" || !got.DeletedAt.Equal(deletedAt) {
+				t.Errorf("Expected id and deleted_at of the tombstone, got %+v", got)
+			}
+		})
+	}
+}
@@ -12,15 +12,19 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dowglassantana/product-redis-api/internal/domain/repository"
 	"github.com/dowglassantana/product-redis-api/internal/infrastructure/config"
 	"github.com/golang-jwt/jwt/v5"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 )
 
 type authContextKey string
 
 const UserContextKey authContextKey = "user"
 
+const jwksRefreshInterval = 5 * time.Minute
+
 type UserClaims struct {
 	Subject           string   `json:"sub"`
 	Email             string   `json:"email"`
@@ -34,6 +38,11 @@ type JWTAuth struct {
 	jwks           *JWKS
 	jwksMutex      sync.RWMutex
 	lastFetch      time.Time
+
+	// jwksRefresh agrupa buscas concorrentes do JWKS numa só requisição, para
+	// que uma rajada de tokens com kid desconhecido não dispare um fetch por
+	// goroutine contra o Keycloak durante a rotação de chaves.
+	jwksRefresh singleflight.Group
 }
 
 type JWKS struct {
@@ -80,6 +89,7 @@ func (j *JWTAuth) Middleware(next http.Handler) http.Handler {
 		}
 
 		ctx := context.WithValue(r.Context(), UserContextKey, claims)
+		ctx = repository.WithActor(ctx, claims.Subject)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
@@ -106,6 +116,13 @@ func (j *JWTAuth) validateToken(tokenString string) (*UserClaims, error) {
 		return nil, fmt.Errorf("invalid token")
 	}
 
+	if expected := j.keycloakConfig.ExpectedTokenType; expected != "" {
+		typ, _ := token.Header["typ"].(string)
+		if !tokenTypeMatches(typ, expected) {
+			return nil, fmt.Errorf("unexpected token type: expected %s, got %q", expected, typ)
+		}
+	}
+
 	mapClaims, ok := token.Claims.(jwt.MapClaims)
 	if !ok {
 		return nil, fmt.Errorf("invalid claims type")
@@ -137,6 +154,16 @@ func (j *JWTAuth) validateToken(tokenString string) (*UserClaims, error) {
 	return userClaims, nil
 }
 
+// tokenTypeMatches compara o "typ" sem diferenciar maiúsculas e aceitando o
+// prefixo "application/" opcional (RFC 7515, seção 4.1.9).
+func tokenTypeMatches(typ, expected string) bool {
+	normalize := func(s string) string {
+		s = strings.ToLower(strings.TrimSpace(s))
+		return strings.TrimPrefix(s, "application/")
+	}
+	return typ != "" && normalize(typ) == normalize(expected)
+}
+
 func (j *JWTAuth) getPublicKey(kid string) (interface{}, error) {
 	j.jwksMutex.RLock()
 	jwks := j.jwks
@@ -144,8 +171,8 @@ func (j *JWTAuth) getPublicKey(kid string) (interface{}, error) {
 	j.jwksMutex.RUnlock()
 
 	// Refresh JWKS every 5 minutes or if not fetched yet
-	if jwks == nil || time.Since(lastFetch) > 5*time.Minute {
-		if err := j.fetchJWKS(); err != nil {
+	if jwks == nil || time.Since(lastFetch) > jwksRefreshInterval {
+		if err := j.fetchJWKS(context.Background()); err != nil {
 			return nil, err
 		}
 		j.jwksMutex.RLock()
@@ -160,7 +187,7 @@ func (j *JWTAuth) getPublicKey(kid string) (interface{}, error) {
 	}
 
 	// Key not found, try refreshing JWKS
-	if err := j.fetchJWKS(); err != nil {
+	if err := j.fetchJWKS(context.Background()); err != nil {
 		return nil, err
 	}
 
@@ -176,12 +203,44 @@ func (j *JWTAuth) getPublicKey(kid string) (interface{}, error) {
 	return nil, fmt.Errorf("key with kid %s not found", kid)
 }
 
-func (j *JWTAuth) fetchJWKS() error {
-	j.jwksMutex.Lock()
-	defer j.jwksMutex.Unlock()
+// HealthCheck reports whether the Keycloak JWKS is usable: a cached key set
+// younger than the refresh interval is enough, otherwise a fresh fetch is attempted.
+func (j *JWTAuth) HealthCheck(ctx context.Context) error {
+	j.jwksMutex.RLock()
+	fresh := j.jwks != nil && len(j.jwks.Keys) > 0 && time.Since(j.lastFetch) <= jwksRefreshInterval
+	j.jwksMutex.RUnlock()
+
+	if fresh {
+		return nil
+	}
+
+	return j.fetchJWKS(ctx)
+}
+
+// fetchJWKS busca o JWKS, compartilhando o resultado com as chamadas que
+// chegarem enquanto uma busca já estiver em andamento. Cada chamador ainda
+// respeita o próprio contexto enquanto espera.
+func (j *JWTAuth) fetchJWKS(ctx context.Context) error {
+	result := j.jwksRefresh.DoChan("jwks", func() (interface{}, error) {
+		return nil, j.doFetchJWKS(context.WithoutCancel(ctx))
+	})
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("failed to fetch JWKS: %w", ctx.Err())
+	case res := <-result:
+		return res.Err
+	}
+}
+
+func (j *JWTAuth) doFetchJWKS(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.keycloakConfig.JWKSURL(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to build JWKS request: %w", err)
+	}
 
 	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Get(j.keycloakConfig.JWKSURL())
+	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to fetch JWKS: %w", err)
 	}
@@ -196,8 +255,11 @@ func (j *JWTAuth) fetchJWKS() error {
 		return fmt.Errorf("failed to decode JWKS: %w", err)
 	}
 
+	j.jwksMutex.Lock()
 	j.jwks = &jwks
 	j.lastFetch = time.Now()
+	j.jwksMutex.Unlock()
+
 	j.logger.Debug("JWKS fetched successfully", zap.Int("keys", len(jwks.Keys)))
 
 	return nil
@@ -251,3 +313,40 @@ func GetUserFromContext(ctx context.Context) *UserClaims {
 	}
 	return nil
 }
+
+// HasRole verifica se o token traz o realm role informado.
+func (c *UserClaims) HasRole(role string) bool {
+	for _, r := range c.RealmRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// RequireRole restringe as rotas a usuários com o realm role informado nas
+// claims validadas por este JWTAuth. Deve rodar depois de j.Middleware.
+func (j *JWTAuth) RequireRole(role string) func(http.Handler) http.Handler {
+	return RequireRole(role)
+}
+
+// RequireRole restringe as rotas a usuários autenticados com o realm role
+// informado. Deve rodar depois de JWTAuth.Middleware.
+func RequireRole(role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := GetUserFromContext(r.Context())
+			if user == nil || !user.HasRole(role) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				json.NewEncoder(w).Encode(map[string]string{
+					"error":   "forbidden",
+					"message": fmt.Sprintf("Role %q is required", role),
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
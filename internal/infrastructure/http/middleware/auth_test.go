@@ -0,0 +1,221 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/config"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+)
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name   string
+		user   *UserClaims
+		status int
+	}{
+		{name: "no user", user: nil, status: http.StatusForbidden},
+		{name: "missing role", user: &UserClaims{Subject: "u1", RealmRoles: []string{"viewer"}}, status: http.StatusForbidden},
+		{name: "has role", user: &UserClaims{Subject: "u1", RealmRoles: []string{"viewer", "admin"}}, status: http.StatusOK},
+	}
+
+	handler := RequireRole("admin")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/whoami", nil)
+			if tt.user != nil {
+				req = req.WithContext(context.WithValue(req.Context(), UserContextKey, tt.user))
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, rec.Code)
+			}
+		})
+	}
+}
+
+func TestJWTAuth_RequireRole(t *testing.T) {
+	j := NewJWTAuth(&config.KeycloakConfig{}, zap.NewNop())
+
+	tests := []struct {
+		name   string
+		user   *UserClaims
+		status int
+	}{
+		{name: "no user", user: nil, status: http.StatusForbidden},
+		{name: "without write role", user: &UserClaims{Subject: "u1", RealmRoles: []string{"offline_access"}}, status: http.StatusForbidden},
+		{name: "admin role only", user: &UserClaims{Subject: "u1", RealmRoles: []string{"admin"}}, status: http.StatusForbidden},
+		{name: "with write role", user: &UserClaims{Subject: "u1", RealmRoles: []string{"offline_access", "product-admin"}}, status: http.StatusCreated},
+	}
+
+	handler := j.RequireRole("product-admin")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/products", nil)
+			if tt.user != nil {
+				req = req.WithContext(context.WithValue(req.Context(), UserContextKey, tt.user))
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, rec.Code)
+			}
+			if tt.status == http.StatusForbidden {
+				var body map[string]string
+				if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+					t.Fatalf("Expected JSON body, got %v", err)
+				}
+				if body["error"] != "forbidden" {
+					t.Errorf("Expected error forbidden, got %q", body["error"])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateToken_ConcurrentJWKSRefreshIsCoalesced(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		time.Sleep(100 * time.Millisecond)
+		json.NewEncoder(w).Encode(JWKS{Keys: []JWK{{
+			Kid: "rotated",
+			Kty: "RSA",
+			Alg: "RS256",
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	}))
+	defer server.Close()
+
+	cfg := &config.KeycloakConfig{URL: server.URL, Realm: "test"}
+	auth := NewJWTAuth(cfg, zap.NewNop())
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss": cfg.Issuer(),
+		"sub": "user-1",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	token.Header["kid"] = "rotated"
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	const workers = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := auth.validateToken(signed); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("Expected token to validate, got %v", err)
+	}
+
+	if got := fetches.Load(); got != 1 {
+		t.Errorf("Expected a single JWKS fetch, got %d", got)
+	}
+}
+
+func TestValidateToken_ExpectedTokenType(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(JWKS{Keys: []JWK{{
+			Kid: "k1",
+			Kty: "RSA",
+			Alg: "RS256",
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		expected string
+		typ      string
+		valid    bool
+	}{
+		{name: "permissive by default", typ: "id+jwt", valid: true},
+		{name: "permissive without typ", valid: true},
+		{name: "matching type", expected: "JWT", typ: "JWT", valid: true},
+		{name: "case insensitive", expected: "at+jwt", typ: "AT+JWT", valid: true},
+		{name: "media type prefix", expected: "at+jwt", typ: "application/at+jwt", valid: true},
+		{name: "unexpected type", expected: "at+jwt", typ: "JWT"},
+		{name: "missing typ", expected: "JWT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.KeycloakConfig{URL: server.URL, Realm: "test", ExpectedTokenType: tt.expected}
+			auth := NewJWTAuth(cfg, zap.NewNop())
+
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+				"iss": cfg.Issuer(),
+				"sub": "user-1",
+				"exp": time.Now().Add(time.Hour).Unix(),
+			})
+			token.Header["kid"] = "k1"
+			if tt.typ == "" {
+				delete(token.Header, "typ")
+			} else {
+				token.Header["typ"] = tt.typ
+			}
+			signed, err := token.SignedString(key)
+			if err != nil {
+				t.Fatalf("failed to sign token: %v", err)
+			}
+
+			_, err = auth.validateToken(signed)
+			if tt.valid && err != nil {
+				t.Errorf("Expected token to validate, got %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Error("Expected token to be rejected")
+			}
+		})
+	}
+}
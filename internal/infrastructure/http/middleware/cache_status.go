@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+)
+
+// CacheStatusHeader informa se a leitura saiu do cache (HIT) ou do banco
+// (MISS), como nas CDNs.
+const CacheStatusHeader = "X-Cache"
+
+type cacheStatusWriter struct {
+	http.ResponseWriter
+	source      *port.CacheSource
+	wroteHeader bool
+}
+
+func (w *cacheStatusWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if value := w.source.Value(); value != "" {
+			w.Header().Set(CacheStatusHeader, value)
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *cacheStatusWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *cacheStatusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// CacheStatus anota no contexto um port.CacheSource e, antes do cabeçalho da
+// resposta sair, escreve X-Cache com a origem registrada pelos casos de uso.
+// Respostas sem leitura no cache (escritas, erros de validação) ficam sem o
+// cabeçalho.
+func CacheStatus(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, source := port.WithCacheSource(r.Context())
+		next.ServeHTTP(&cacheStatusWriter{ResponseWriter: w, source: source}, r.WithContext(ctx))
+	})
+}
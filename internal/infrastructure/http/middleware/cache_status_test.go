@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+)
+
+func TestCacheStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		record []func(ctx context.Context)
+		want   string
+	}{
+		{name: "no cache read", want: ""},
+		{name: "hit", record: []func(ctx context.Context){port.RecordCacheHit}, want: "HIT"},
+		{name: "miss", record: []func(ctx context.Context){port.RecordCacheMiss}, want: "MISS"},
+		{name: "miss wins over hit", record: []func(ctx context.Context){port.RecordCacheMiss, port.RecordCacheHit}, want: "MISS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := CacheStatus(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for _, record := range tt.record {
+					record(r.Context())
+				}
+				w.Write([]byte("{}"))
+			}))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/products/1", nil))
+
+			if got := rec.Header().Get(CacheStatusHeader); got != tt.want {
+				t.Errorf("Expected X-Cache %q, got %q", tt.want, got)
+			}
+			if rec.Code != http.StatusOK {
+				t.Errorf("Expected status 200, got %d", rec.Code)
+			}
+		})
+	}
+}
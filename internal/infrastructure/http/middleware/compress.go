@@ -0,0 +1,296 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+)
+
+// Níveis usados quando CompressOptions não define um.
+const (
+	DefaultGzipLevel   = 5
+	DefaultBrotliLevel = 4
+)
+
+// CompressOptions configura Compress. GzipLevel vai de 1 a 9 e BrotliLevel de
+// 1 a 11; zero usa o padrão. Respostas com menos de MinSize bytes saem sem
+// compressão, já que o cabeçalho do formato pode deixá-las maiores.
+type CompressOptions struct {
+	GzipLevel   int
+	BrotliLevel int
+	MinSize     int
+}
+
+// Validate confere as faixas dos níveis, para a configuração falhar na
+// subida e não na primeira resposta.
+func (o CompressOptions) Validate() error {
+	if o.GzipLevel < 0 || o.GzipLevel > gzip.BestCompression {
+		return fmt.Errorf("invalid gzip level %d: must be between 1 and 9", o.GzipLevel)
+	}
+	if o.BrotliLevel < 0 || o.BrotliLevel > brotli.BestCompression {
+		return fmt.Errorf("invalid brotli level %d: must be between 1 and 11", o.BrotliLevel)
+	}
+	if o.MinSize < 0 {
+		return fmt.Errorf("invalid compression min size %d", o.MinSize)
+	}
+	return nil
+}
+
+// compressibleTypes lista os Content-Type comprimidos; imagens e demais
+// formatos binários já chegam comprimidos.
+var compressibleTypes = []string{
+	"application/json",
+	"application/xml",
+	"application/javascript",
+	"image/svg+xml",
+}
+
+func compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	if strings.HasPrefix(mediaType, "text/") ||
+		strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml") {
+		return true
+	}
+	for _, t := range compressibleTypes {
+		if mediaType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Compress comprime as respostas com Brotli quando o cliente o aceita e com
+// gzip nos demais casos, conforme o Accept-Encoding (com os pesos q). A
+// resposta fica em buffer até MinSize bytes, para decidir se vale comprimir.
+func Compress(opts CompressOptions) func(http.Handler) http.Handler {
+	if opts.GzipLevel == 0 {
+		opts.GzipLevel = DefaultGzipLevel
+	}
+	if opts.BrotliLevel == 0 {
+		opts.BrotliLevel = DefaultBrotliLevel
+	}
+
+	gzipPool := &sync.Pool{New: func() interface{} {
+		w, _ := gzip.NewWriterLevel(io.Discard, opts.GzipLevel)
+		return w
+	}}
+	brotliPool := &sync.Pool{New: func() interface{} {
+		return brotli.NewWriterLevel(io.Discard, opts.BrotliLevel)
+	}}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{
+				ResponseWriter: w,
+				encoding:       encoding,
+				minSize:        opts.MinSize,
+				gzipPool:       gzipPool,
+				brotliPool:     brotliPool,
+				status:         http.StatusOK,
+			}
+			defer cw.close()
+
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// negotiateEncoding escolhe "br" ou "gzip" pelo maior q; no empate, Brotli.
+// Retorna "" quando o cliente não aceita nenhum dos dois.
+func negotiateEncoding(accept string) string {
+	brQ, gzipQ, wildcardQ := -1.0, -1.0, -1.0
+
+	for _, part := range strings.Split(accept, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "br":
+			brQ = max(brQ, q)
+		case "gzip", "x-gzip":
+			gzipQ = max(gzipQ, q)
+		case "*":
+			wildcardQ = max(wildcardQ, q)
+		}
+	}
+
+	if brQ < 0 {
+		brQ = wildcardQ
+	}
+	if gzipQ < 0 {
+		gzipQ = wildcardQ
+	}
+
+	switch {
+	case brQ > 0 && brQ >= gzipQ:
+		return "br"
+	case gzipQ > 0:
+		return "gzip"
+	default:
+		return ""
+	}
+}
+
+type compressWriter struct {
+	http.ResponseWriter
+	encoding   string
+	minSize    int
+	gzipPool   *sync.Pool
+	brotliPool *sync.Pool
+
+	status      int
+	wroteHeader bool
+	buf         bytes.Buffer
+
+	// started indica que o cabeçalho já saiu; encoder é nil quando a
+	// resposta segue sem compressão.
+	started bool
+	encoder io.WriteCloser
+}
+
+func (cw *compressWriter) WriteHeader(code int) {
+	if cw.wroteHeader {
+		return
+	}
+	cw.wroteHeader = true
+	cw.status = code
+}
+
+func (cw *compressWriter) Write(b []byte) (int, error) {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if cw.started {
+		return cw.writeOut(b)
+	}
+
+	cw.buf.Write(b)
+	if cw.buf.Len() >= cw.minSize {
+		if err := cw.start(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+// start envia o cabeçalho e o que estiver no buffer. Com full=false (fim da
+// resposta abaixo do limite), a resposta sai sem compressão.
+func (cw *compressWriter) start(full bool) error {
+	cw.started = true
+	header := cw.Header()
+
+	if full && cw.shouldCompress() {
+		header.Set("Content-Encoding", cw.encoding)
+		header.Del("Content-Length")
+		cw.encoder = cw.newEncoder()
+	}
+
+	cw.ResponseWriter.WriteHeader(cw.status)
+	if cw.buf.Len() == 0 {
+		return nil
+	}
+	_, err := cw.writeOut(cw.buf.Bytes())
+	cw.buf.Reset()
+	return err
+}
+
+func (cw *compressWriter) shouldCompress() bool {
+	header := cw.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	if cw.status < http.StatusOK || cw.status == http.StatusNoContent || cw.status == http.StatusNotModified {
+		return false
+	}
+	return compressible(header.Get("Content-Type"))
+}
+
+func (cw *compressWriter) writeOut(b []byte) (int, error) {
+	if cw.encoder != nil {
+		return cw.encoder.Write(b)
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+func (cw *compressWriter) newEncoder() io.WriteCloser {
+	if cw.encoding == "br" {
+		w := cw.brotliPool.Get().(*brotli.Writer)
+		w.Reset(cw.ResponseWriter)
+		return w
+	}
+	w := cw.gzipPool.Get().(*gzip.Writer)
+	w.Reset(cw.ResponseWriter)
+	return w
+}
+
+// Flush envia o que já foi escrito, para respostas em streaming. Se o
+// cabeçalho ainda não saiu, a decisão de comprimir é tomada agora, sem
+// esperar o tamanho mínimo.
+func (cw *compressWriter) Flush() {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if !cw.started {
+		if err := cw.start(true); err != nil {
+			return
+		}
+	}
+	if flusher, ok := cw.encoder.(interface{ Flush() error }); ok {
+		if err := flusher.Flush(); err != nil {
+			return
+		}
+	}
+	http.NewResponseController(cw.ResponseWriter).Flush()
+}
+
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// close termina a resposta: envia o que ficou no buffer e fecha o encoder,
+// devolvendo-o ao pool.
+func (cw *compressWriter) close() {
+	if !cw.started {
+		if !cw.wroteHeader {
+			return
+		}
+		cw.start(false)
+	}
+	if cw.encoder == nil {
+		return
+	}
+
+	cw.encoder.Close()
+	switch w := cw.encoder.(type) {
+	case *brotli.Writer:
+		cw.brotliPool.Put(w)
+	case *gzip.Writer:
+		cw.gzipPool.Put(w)
+	}
+}
@@ -0,0 +1,228 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+
+	"github.com/dowglassantana/product-redis-api/internal/domain/entity"
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/dto"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{accept: "", want: ""},
+		{accept: "gzip", want: "gzip"},
+		{accept: "gzip, deflate, br", want: "br"},
+		{accept: "br;q=0.5, gzip", want: "gzip"},
+		{accept: "br;q=0, gzip;q=0.1", want: "gzip"},
+		{accept: "identity", want: ""},
+		{accept: "*", want: "br"},
+		{accept: "*;q=0.5, gzip", want: "gzip"},
+		{accept: "x-gzip", want: "gzip"},
+		{accept: "gzip;q=0, br;q=0", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := negotiateEncoding(tt.accept); got != tt.want {
+			t.Errorf("negotiateEncoding(%q): expected %q, got %q", tt.accept, tt.want, got)
+		}
+	}
+}
+
+func TestCompress(t *testing.T) {
+	large := strings.Repeat(`{"name":"iPhone 15 Pro","category":"Smartphones"},`, 100)
+
+	tests := []struct {
+		name        string
+		accept      string
+		contentType string
+		body        string
+		encoding    string
+	}{
+		{name: "brotli", accept: "gzip, br", contentType: "application/json", body: large, encoding: "br"},
+		{name: "gzip fallback", accept: "gzip, deflate", contentType: "application/json", body: large, encoding: "gzip"},
+		{name: "below threshold", accept: "gzip, br", contentType: "application/json", body: `{"status":"ok"}`},
+		{name: "not accepted", accept: "identity", contentType: "application/json", body: large},
+		{name: "binary content", accept: "gzip, br", contentType: "image/png", body: large},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := Compress(CompressOptions{MinSize: 256})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(http.StatusOK)
+				io.WriteString(w, tt.body)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/products", nil)
+			req.Header.Set("Accept-Encoding", tt.accept)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get("Vary"); got != "Accept-Encoding" {
+				t.Errorf("Expected Vary: Accept-Encoding, got %q", got)
+			}
+			if got := rec.Header().Get("Content-Encoding"); got != tt.encoding {
+				t.Fatalf("Expected Content-Encoding %q, got %q", tt.encoding, got)
+			}
+			if got := decode(t, tt.encoding, rec.Body.Bytes()); got != tt.body {
+				t.Errorf("Expected body to round-trip, got %d bytes", len(got))
+			}
+		})
+	}
+}
+
+func TestCompress_NoContent(t *testing.T) {
+	handler := Compress(CompressOptions{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/products/1", nil)
+	req.Header.Set("Accept-Encoding", "br")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("Expected no Content-Encoding, got %q", got)
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("Expected empty body, got %d bytes", rec.Body.Len())
+	}
+}
+
+func TestCompressOptions_Validate(t *testing.T) {
+	if err := (CompressOptions{GzipLevel: 9, BrotliLevel: 11, MinSize: 1024}).Validate(); err != nil {
+		t.Errorf("Expected valid options, got %v", err)
+	}
+	for _, opts := range []CompressOptions{{GzipLevel: 10}, {BrotliLevel: 12}, {MinSize: -1}} {
+		if err := opts.Validate(); err == nil {
+			t.Errorf("Expected error for %+v", opts)
+		}
+	}
+}
+
+func decode(t *testing.T, encoding string, body []byte) string {
+	t.Helper()
+
+	var reader io.Reader = bytes.NewReader(body)
+	switch encoding {
+	case "gzip":
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			t.Fatalf("Expected gzip body, got %v", err)
+		}
+		reader = gz
+	case "br":
+		reader = brotli.NewReader(reader)
+	}
+
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Expected body to decode, got %v", err)
+	}
+	return string(decoded)
+}
+
+// BenchmarkCompress_ProductListing compara o tamanho de uma listagem de 50
+// produtos sem compressão, com gzip e com Brotli, nos níveis padrão.
+func BenchmarkCompress_ProductListing(b *testing.B) {
+	body := productListing(b, 50)
+
+	for _, accept := range []string{"identity", "gzip", "br"} {
+		b.Run(accept, func(b *testing.B) {
+			handler := Compress(CompressOptions{MinSize: 1024})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write(body)
+			}))
+
+			var size int
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/products?limit=50", nil)
+				req.Header.Set("Accept-Encoding", accept)
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, req)
+				size = rec.Body.Len()
+			}
+			b.ReportMetric(float64(size), "bytes/response")
+			b.ReportMetric(float64(size)/float64(len(body))*100, "%_of_identity")
+		})
+	}
+}
+
+func productListing(b *testing.B, n int) []byte {
+	b.Helper()
+
+	products := make([]*entity.Product, n)
+	for i := range products {
+		product, err := entity.NewProduct(
+			fmt.Sprintf("iPhone 15 Pro %d", i),
+			fmt.Sprintf("REF-%05d", i),
+			"Smartphones",
+			"Smartphone Apple com chip A17 Pro, tela Super Retina XDR de 6,1 polegadas e câmera de 48 MP",
+			fmt.Sprintf("APL-IP15P-%03d", i),
+			"Apple",
+			100+i,
+			799900+int64(i)*100,
+			"BRL",
+			[]string{fmt.Sprintf("https://cdn.example.com/products/%d/front.jpg", i)},
+			map[string]interface{}{"color": "Titânio Natural", "storage": "256GB", "ram": "8GB"},
+		)
+		if err != nil {
+			b.Fatalf("Expected valid product, got %v", err)
+		}
+		products[i] = product
+	}
+
+	body, err := json.Marshal(dto.PaginatedResponse{
+		Data:  dto.ToProductResponseList(products),
+		Total: n,
+		Limit: n,
+	})
+	if err != nil {
+		b.Fatalf("Expected listing to marshal, got %v", err)
+	}
+	return body
+}
+
+func TestCompress_Flush(t *testing.T) {
+	handler := Compress(CompressOptions{MinSize: 1 << 20})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `[{"id":"A"}`)
+		if err := http.NewResponseController(w).Flush(); err != nil {
+			t.Errorf("Expected flush to be supported, got %v", err)
+		}
+		io.WriteString(w, `]`)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if !rec.Flushed {
+		t.Error("Expected the response to be flushed")
+	}
+	if got := rec.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("Expected streamed response to be compressed, got %q", got)
+	}
+	if got := decode(t, "gzip", rec.Body.Bytes()); got != `[{"id":"A"}]` {
+		t.Errorf("Expected body to round-trip, got %q", got)
+	}
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import "net/http"
+
+// DegradedHeader sinaliza respostas servidas apenas do cache.
+const DegradedHeader = "X-Degraded"
+
+// Degraded marca com X-Degraded: true as leituras atendidas enquanto
+// isDegraded retorna true, para que o cliente saiba que o resultado pode
+// estar incompleto.
+func Degraded(isDegraded func() bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if (r.Method == http.MethodGet || r.Method == http.MethodHead) && isDegraded() {
+				w.Header().Set(DegradedHeader, "true")
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+const SpecFieldsContextKey contextKey = "spec_fields"
+
+// maxFieldPaths limita quantos caminhos um único fields pode pedir.
+const maxFieldPaths = 50
+
+// specificationsField é a raiz dos caminhos aceitos em fields.
+const specificationsField = "specifications"
+
+// SpecFields lê o parâmetro fields (caminhos separados por vírgula, como
+// specifications.color ou specifications.display.size) e guarda no contexto
+// os caminhos dentro de specifications, para que a resposta traga só essas
+// chaves. Os demais campos do produto continuam sempre presentes. Caminhos
+// malformados respondem 400 invalid_fields; caminhos fora de specifications
+// também, a menos que ignoreUnknown seja true, quando são descartados.
+func SpecFields(ignoreUnknown bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			raw := r.URL.Query().Get("fields")
+			if raw == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			paths, message := parseSpecFields(raw, ignoreUnknown)
+			if message != "" {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{
+					"error":   "invalid_fields",
+					"message": message,
+				})
+				return
+			}
+			if len(paths) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), SpecFieldsContextKey, paths)))
+		})
+	}
+}
+
+// parseSpecFields retorna os caminhos sem a raiz specifications; um caminho
+// vazio pede o mapa inteiro. A mensagem não vazia descreve o erro.
+func parseSpecFields(raw string, ignoreUnknown bool) ([][]string, string) {
+	var paths [][]string
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		segments := strings.Split(field, ".")
+		for _, segment := range segments {
+			if segment == "" {
+				return nil, "fields has an empty path segment in " + field
+			}
+		}
+
+		if segments[0] != specificationsField {
+			if ignoreUnknown {
+				continue
+			}
+			return nil, "fields only supports specifications paths, such as specifications.color; got " + field
+		}
+
+		paths = append(paths, segments[1:])
+		if len(paths) > maxFieldPaths {
+			return nil, "fields accepts at most 50 paths"
+		}
+	}
+	return paths, ""
+}
+
+// GetSpecFields retorna os caminhos pedidos em fields dentro de
+// specifications, ou nil quando a resposta deve trazer o mapa inteiro.
+func GetSpecFields(ctx context.Context) [][]string {
+	if paths, ok := ctx.Value(SpecFieldsContextKey).([][]string); ok {
+		return paths
+	}
+	return nil
+}
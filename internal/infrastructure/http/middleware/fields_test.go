@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSpecFields(t *testing.T) {
+	tooMany := strings.TrimSuffix(strings.Repeat("specifications.k,", maxFieldPaths+1), ",")
+
+	tests := []struct {
+		name          string
+		query         string
+		ignoreUnknown bool
+		status        int
+		paths         [][]string
+	}{
+		{name: "no fields", query: "", status: http.StatusOK},
+		{name: "top level key", query: "?fields=specifications.color", status: http.StatusOK, paths: [][]string{{"color"}}},
+		{name: "nested keys", query: "?fields=specifications.display.size,%20specifications.color", status: http.StatusOK, paths: [][]string{{"display", "size"}, {"color"}}},
+		{name: "whole map", query: "?fields=specifications", status: http.StatusOK, paths: [][]string{{}}},
+		{name: "empty segment", query: "?fields=specifications..size", status: http.StatusBadRequest},
+		{name: "empty segment ignoring unknown", query: "?fields=specifications.", ignoreUnknown: true, status: http.StatusBadRequest},
+		{name: "unknown root", query: "?fields=name", status: http.StatusBadRequest},
+		{name: "unknown root ignored", query: "?fields=name,specifications.color", ignoreUnknown: true, status: http.StatusOK, paths: [][]string{{"color"}}},
+		{name: "only unknown roots ignored", query: "?fields=name", ignoreUnknown: true, status: http.StatusOK},
+		{name: "too many paths", query: "?fields=" + tooMany, status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][]string
+			handler := SpecFields(tt.ignoreUnknown)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = GetSpecFields(r.Context())
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/products"+tt.query, nil))
+
+			if rec.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d", tt.status, rec.Code)
+			}
+			if tt.status == http.StatusBadRequest && !strings.Contains(rec.Body.String(), "invalid_fields") {
+				t.Errorf("Expected invalid_fields error, got %s", rec.Body.String())
+			}
+			if !reflect.DeepEqual(got, tt.paths) {
+				t.Errorf("Expected paths %v, got %v", tt.paths, got)
+			}
+		})
+	}
+}
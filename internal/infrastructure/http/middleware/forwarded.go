@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ForwardedValue devolve o último valor do header X-Forwarded-* name, o
+// gravado pelo proxy mais próximo da API, ou "" sem o header. Os valores
+// anteriores vêm de quem estava antes dele e podem ter sido forjados pelo
+// cliente, então só o último é usado, e apenas atrás de um proxy confiável.
+func ForwardedValue(r *http.Request, name string) string {
+	values := r.Header.Values(name)
+	if len(values) == 0 {
+		return ""
+	}
+	last := values[len(values)-1]
+	if i := strings.LastIndex(last, ","); i >= 0 {
+		last = last[i+1:]
+	}
+	return strings.TrimSpace(last)
+}
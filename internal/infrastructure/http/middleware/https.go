@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HTTPSOptions configura EnforceHTTPS. Redirect manda as requisições HTTP para
+// a mesma URL em HTTPS; HSTSMaxAge, quando maior que zero, vai no
+// Strict-Transport-Security das respostas HTTPS. ExemptPaths lista prefixos
+// de path que seguem aceitando HTTP, como os probes do Kubernetes, que batem
+// direto no pod. TrustProxy faz o X-Forwarded-Proto do último proxy contar;
+// sem ele, só a conexão TLS direta conta como HTTPS.
+type HTTPSOptions struct {
+	Redirect              bool
+	HSTSMaxAge            time.Duration
+	HSTSIncludeSubdomains bool
+	ExemptPaths           []string
+	TrustProxy            bool
+}
+
+// EnforceHTTPS exige TLS. Atrás de um proxy confiável que termina o TLS
+// (opts.TrustProxy), a requisição conta como HTTPS pelo X-Forwarded-Proto. Leituras são redirecionadas com
+// 301; os demais métodos com 308, que preserva método e corpo. O HSTS só vai
+// em respostas HTTPS, já que navegadores o ignoram em HTTP.
+func EnforceHTTPS(opts HTTPSOptions) func(http.Handler) http.Handler {
+	hsts := ""
+	if opts.HSTSMaxAge > 0 {
+		hsts = "max-age=" + strconv.FormatInt(int64(opts.HSTSMaxAge/time.Second), 10)
+		if opts.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, prefix := range opts.ExemptPaths {
+				if strings.HasPrefix(r.URL.Path, prefix) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			if isHTTPS(r, opts.TrustProxy) {
+				if hsts != "" {
+					w.Header().Set("Strict-Transport-Security", hsts)
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !opts.Redirect {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			status := http.StatusPermanentRedirect
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				status = http.StatusMovedPermanently
+			}
+			http.Redirect(w, r, "https://"+r.Host+r.URL.RequestURI(), status)
+		})
+	}
+}
+
+// isHTTPS considera, com trustProxy, só o último valor de X-Forwarded-Proto,
+// o gravado pelo proxy diante da API; os anteriores podem vir do cliente.
+func isHTTPS(r *http.Request, trustProxy bool) bool {
+	if r.TLS != nil {
+		return true
+	}
+	return trustProxy && strings.EqualFold(ForwardedValue(r, "X-Forwarded-Proto"), "https")
+}
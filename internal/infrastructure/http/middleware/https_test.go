@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestEnforceHTTPS(t *testing.T) {
+	opts := HTTPSOptions{
+		Redirect:    true,
+		HSTSMaxAge:  24 * time.Hour,
+		ExemptPaths: []string{"/health/"},
+		TrustProxy:  true,
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		proto    string
+		tls      bool
+		status   int
+		location string
+		hsts     string
+	}{
+		{name: "http get redirects", method: http.MethodGet, target: "http://api.example.com/api/v1/products?limit=5", status: http.StatusMovedPermanently, location: "https://api.example.com/api/v1/products?limit=5"},
+		{name: "http post keeps method", method: http.MethodPost, target: "http://api.example.com/api/v1/products", status: http.StatusPermanentRedirect, location: "https://api.example.com/api/v1/products"},
+		{name: "tls connection", method: http.MethodGet, target: "https://api.example.com/api/v1/products", tls: true, status: http.StatusOK, hsts: "max-age=86400"},
+		{name: "forwarded https", method: http.MethodGet, target: "http://api.example.com/api/v1/products", proto: "http, HTTPS", status: http.StatusOK, hsts: "max-age=86400"},
+		{name: "client-supplied first hop", method: http.MethodGet, target: "http://api.example.com/api/v1/products", proto: "https, http", status: http.StatusMovedPermanently, location: "https://api.example.com/api/v1/products"},
+		{name: "forwarded http", method: http.MethodGet, target: "http://api.example.com/api/v1/products", proto: "http", status: http.StatusMovedPermanently, location: "https://api.example.com/api/v1/products"},
+		{name: "exempt path", method: http.MethodGet, target: "http://api.example.com/health/live", status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := EnforceHTTPS(opts)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			if !tt.tls {
+				req.TLS = nil
+			} else if req.TLS == nil {
+				req.TLS = &tls.ConnectionState{}
+			}
+			if tt.proto != "" {
+				req.Header.Set("X-Forwarded-Proto", tt.proto)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d", tt.status, rec.Code)
+			}
+			if got := rec.Header().Get("Location"); got != tt.location {
+				t.Errorf("Expected Location %q, got %q", tt.location, got)
+			}
+			if got := rec.Header().Get("Strict-Transport-Security"); got != tt.hsts {
+				t.Errorf("Expected HSTS %q, got %q", tt.hsts, got)
+			}
+		})
+	}
+}
+
+func TestEnforceHTTPS_WithoutRedirect(t *testing.T) {
+	handler := EnforceHTTPS(HTTPSOptions{HSTSMaxAge: time.Hour, HSTSIncludeSubdomains: true, TrustProxy: true})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://api.example.com/api/v1/products", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected plain HTTP to be served, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Strict-Transport-Security"); got != "" {
+		t.Errorf("Expected no HSTS over HTTP, got %q", got)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "http://api.example.com/api/v1/products", nil)
+	req.Header.Set("X-Forwarded-Proto", "https")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if got := rec.Header().Get("Strict-Transport-Security"); got != "max-age=3600; includeSubDomains" {
+		t.Errorf("Expected HSTS with subdomains, got %q", got)
+	}
+}
+
+func TestEnforceHTTPS_UntrustedProxy(t *testing.T) {
+	handler := EnforceHTTPS(HTTPSOptions{Redirect: true})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "http://api.example.com/api/v1/products", nil)
+	req.Header.Set("X-Forwarded-Proto", "https")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusMovedPermanently {
+		t.Errorf("Expected X-Forwarded-Proto to be ignored without a trusted proxy, got %d", rec.Code)
+	}
+}
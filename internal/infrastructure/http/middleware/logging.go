@@ -1,9 +1,11 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"time"
 
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
 	"go.uber.org/zap"
 )
 
@@ -24,11 +26,30 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	return n, err
 }
 
+// Unwrap expõe o writer original ao http.ResponseController (Flush).
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 func Logging(logger *zap.Logger) func(http.Handler) http.Handler {
+	return LoggingWithSlowThreshold(logger, 0)
+}
+
+// LoggingWithSlowThreshold registra o access log como Logging e, nas
+// requisições que levarem slowThreshold ou mais, acrescenta quanto foi gasto
+// no Redis, no PostgreSQL e serializando a resposta. Zero desativa o detalhe.
+func LoggingWithSlowThreshold(logger *zap.Logger, slowThreshold time.Duration) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
 
+			var timing *port.RequestTiming
+			if slowThreshold > 0 {
+				var ctx context.Context
+				ctx, timing = port.WithRequestTiming(r.Context())
+				r = r.WithContext(ctx)
+			}
+
 			wrapped := &responseWriter{
 				ResponseWriter: w,
 				statusCode:     http.StatusOK,
@@ -37,7 +58,7 @@ func Logging(logger *zap.Logger) func(http.Handler) http.Handler {
 			next.ServeHTTP(wrapped, r)
 
 			duration := time.Since(start)
-			logger.Info("http request",
+			fields := []zap.Field{
 				zap.String("method", r.Method),
 				zap.String("path", r.URL.Path),
 				zap.String("remote_addr", r.RemoteAddr),
@@ -45,7 +66,16 @@ func Logging(logger *zap.Logger) func(http.Handler) http.Handler {
 				zap.Int64("bytes", wrapped.written),
 				zap.Duration("duration", duration),
 				zap.String("user_agent", r.UserAgent()),
-			)
+			}
+			if timing != nil && duration >= slowThreshold {
+				fields = append(fields,
+					zap.Bool("slow", true),
+					zap.Duration("cache_duration", timing.Cache()),
+					zap.Duration("db_duration", timing.DB()),
+					zap.Duration("serialize_duration", timing.Serialize()),
+				)
+			}
+			logger.Info("http request", fields...)
 		})
 	}
 }
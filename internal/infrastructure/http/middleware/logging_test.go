@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+const testToken = "eyJhbGciOiJSUzI1NiJ9.payload.signature"
+
+func TestLogging_DoesNotLogCredentials(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+
+	handler := Logging(zap.New(core))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products?access_token="+testToken, nil)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	for _, entry := range logs.All() {
+		for key, value := range entry.ContextMap() {
+			if s, ok := value.(string); ok && strings.Contains(s, testToken) {
+				t.Errorf("Expected access log field %s not to contain the token", key)
+			}
+		}
+	}
+}
+
+func TestLoggingWithSlowThreshold(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		slow      bool
+	}{
+		{name: "disabled", threshold: 0},
+		{name: "fast request", threshold: time.Hour},
+		{name: "slow request", threshold: time.Nanosecond, slow: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zap.InfoLevel)
+
+			handler := LoggingWithSlowThreshold(zap.New(core), tt.threshold)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				port.RecordCacheTime(r.Context(), 2*time.Millisecond)
+				port.RecordDBTime(r.Context(), 30*time.Millisecond)
+				port.RecordDBTime(r.Context(), 10*time.Millisecond)
+				port.RecordSerializeTime(r.Context(), time.Millisecond)
+				w.WriteHeader(http.StatusOK)
+			}))
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/products", nil))
+
+			entries := logs.FilterMessage("http request").All()
+			if len(entries) != 1 {
+				t.Fatalf("Expected one access log entry, got %d", len(entries))
+			}
+			fields := entries[0].ContextMap()
+
+			if _, ok := fields["db_duration"]; ok != tt.slow {
+				t.Fatalf("Expected timing breakdown present = %v, got fields %v", tt.slow, fields)
+			}
+			if !tt.slow {
+				return
+			}
+			if fields["slow"] != true {
+				t.Errorf("Expected slow = true, got %v", fields["slow"])
+			}
+			for key, want := range map[string]time.Duration{
+				"cache_duration":     2 * time.Millisecond,
+				"db_duration":        40 * time.Millisecond,
+				"serialize_duration": time.Millisecond,
+			} {
+				if fields[key] != want {
+					t.Errorf("Expected %s = %v, got %v", key, want, fields[key])
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimiter_IdentifierDoesNotUseToken(t *testing.T) {
+	rl := &RateLimiter{}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products", nil)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	req = req.WithContext(context.WithValue(req.Context(), UserContextKey, &UserClaims{Subject: "user-1"}))
+
+	if got := rl.getIdentifier(req); got != "user:user-1" {
+		t.Errorf("Expected identifier from subject, got %q", got)
+	}
+}
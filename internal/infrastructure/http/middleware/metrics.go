@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// unmatchedRoute rotula as requisições que não casaram com nenhuma rota, para
+// que paths arbitrários (404) não criem séries novas.
+const unmatchedRoute = "unmatched"
+
+// Metrics registra http_request_duration_seconds e http_requests_total por
+// método, padrão de rota do chi (ex.: /api/v1/products/{id}) e status no
+// registerer padrão do Prometheus. Deve ficar antes dos demais middlewares
+// para medir também o tempo gasto neles.
+func Metrics(logger *zap.Logger) func(http.Handler) http.Handler {
+	return metricsWithRegisterer(prometheus.DefaultRegisterer, logger)
+}
+
+func metricsWithRegisterer(registerer prometheus.Registerer, logger *zap.Logger) func(http.Handler) http.Handler {
+	labels := []string{"method", "route", "status"}
+
+	duration := registerCollector(registerer, logger, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency by method, route pattern and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, labels))
+	requests := registerCollector(registerer, logger, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests by method, route pattern and status code.",
+	}, labels))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			wrapped := &responseWriter{
+				ResponseWriter: w,
+				statusCode:     http.StatusOK,
+			}
+
+			next.ServeHTTP(wrapped, r)
+
+			route := unmatchedRoute
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				if pattern := rctx.RoutePattern(); pattern != "" {
+					route = pattern
+				}
+			}
+
+			status := strconv.Itoa(wrapped.statusCode)
+			duration.WithLabelValues(r.Method, route, status).Observe(time.Since(start).Seconds())
+			requests.WithLabelValues(r.Method, route, status).Inc()
+		})
+	}
+}
+
+// registerCollector registra o coletor e, se um equivalente já existir (um
+// segundo router no mesmo processo), reaproveita o registrado. Outras falhas
+// só são logadas: a requisição segue medida num coletor fora do registry.
+func registerCollector[T prometheus.Collector](registerer prometheus.Registerer, logger *zap.Logger, collector T) T {
+	if err := registerer.Register(collector); err != nil {
+		var already prometheus.AlreadyRegisteredError
+		if errors.As(err, &already) {
+			if existing, ok := already.ExistingCollector.(T); ok {
+				return existing
+			}
+		}
+		logger.Warn("failed to register http metrics", zap.Error(err))
+	}
+	return collector
+}
@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+)
+
+func TestMetrics_LabelsByRoutePattern(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	r := chi.NewRouter()
+	r.Use(metricsWithRegisterer(registry, zap.NewNop()))
+	r.Route("/api/v1/products", func(r chi.Router) {
+		r.Get("/{id}", func(w http.ResponseWriter, r *http.Request) {
+			if chi.URLParam(r, "id") == "missing" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		})
+	})
+
+	for _, path := range []string{"/api/v1/products/1", "/api/v1/products/2", "/api/v1/products/missing", "/unknown/path"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	tests := []struct {
+		route  string
+		status string
+		want   float64
+	}{
+		{"/api/v1/products/{id}", "200", 2},
+		{"/api/v1/products/{id}", "404", 1},
+		{unmatchedRoute, "404", 1},
+	}
+	for _, tt := range tests {
+		if got := requestCount(t, registry, http.MethodGet, tt.route, tt.status); got != tt.want {
+			t.Errorf("http_requests_total{route=%q,status=%q} = %v, want %v", tt.route, tt.status, got, tt.want)
+		}
+	}
+
+	if got := testutil.CollectAndCount(registry, "http_requests_total"); got != 3 {
+		t.Errorf("Expected 3 http_requests_total series, got %d", got)
+	}
+	if got := testutil.CollectAndCount(registry, "http_request_duration_seconds"); got != 3 {
+		t.Errorf("Expected 3 http_request_duration_seconds series, got %d", got)
+	}
+}
+
+func TestMetrics_ReusesRegisteredCollectors(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	first := metricsWithRegisterer(registry, zap.NewNop())
+	second := metricsWithRegisterer(registry, zap.NewNop())
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	first(ok).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	second(ok).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got := requestCount(t, registry, http.MethodGet, unmatchedRoute, "200"); got != 2 {
+		t.Errorf("Expected both middlewares to share the counter, got %v", got)
+	}
+}
+
+func requestCount(t *testing.T, registry *prometheus.Registry, method, route, status string) float64 {
+	t.Helper()
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := map[string]string{"method": method, "route": route, "status": status}
+	for _, family := range families {
+		if family.GetName() != "http_requests_total" {
+			continue
+		}
+	metrics:
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if want[label.GetName()] != label.GetValue() {
+					continue metrics
+				}
+			}
+			return metric.GetCounter().GetValue()
+		}
+	}
+	return 0
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// QueryLimitOptions limita a query string antes de qualquer parse: MaxParams
+// é o número de parâmetros distintos e MaxBytes o tamanho da query crua. Zero
+// desliga o respectivo limite.
+type QueryLimitOptions struct {
+	MaxParams int
+	MaxBytes  int
+}
+
+// QueryLimit responde 400 query_too_large quando a query passa dos limites,
+// para que uma URL com milhares de parâmetros não chegue ao parse dos filtros.
+func QueryLimit(opts QueryLimitOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if message := checkQuery(r.URL.RawQuery, opts); message != "" {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{
+					"error":   "query_too_large",
+					"message": message,
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// checkQuery conta os nomes de parâmetro sem decodificá-los, parando assim
+// que o limite é ultrapassado. A mensagem não vazia descreve o erro.
+func checkQuery(raw string, opts QueryLimitOptions) string {
+	if opts.MaxBytes > 0 && len(raw) > opts.MaxBytes {
+		return fmt.Sprintf("query string exceeds %d bytes", opts.MaxBytes)
+	}
+	if opts.MaxParams <= 0 {
+		return ""
+	}
+
+	seen := make(map[string]struct{})
+	for raw != "" {
+		var pair string
+		pair, raw, _ = strings.Cut(raw, "&")
+		if pair == "" {
+			continue
+		}
+		name, _, _ := strings.Cut(pair, "=")
+		seen[name] = struct{}{}
+		if len(seen) > opts.MaxParams {
+			return fmt.Sprintf("query string exceeds %d distinct parameters", opts.MaxParams)
+		}
+	}
+	return ""
+}
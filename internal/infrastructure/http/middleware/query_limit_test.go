@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestQueryLimit(t *testing.T) {
+	manyParams := make([]string, 11)
+	for i := range manyParams {
+		manyParams[i] = "p" + strings.Repeat("x", i) + "=1"
+	}
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{name: "no query", query: "", status: http.StatusOK},
+		{name: "within limits", query: "category=phones&limit=20&offset=0", status: http.StatusOK},
+		{name: "repeated name counts once", query: strings.Repeat("tag=a&", 20), status: http.StatusOK},
+		{name: "too many params", query: strings.Join(manyParams, "&"), status: http.StatusBadRequest},
+		{name: "too many bytes", query: "q=" + strings.Repeat("a", 300), status: http.StatusBadRequest},
+	}
+
+	handler := QueryLimit(QueryLimitOptions{MaxParams: 10, MaxBytes: 256})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/products?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d", tt.status, rec.Code)
+			}
+			if tt.status == http.StatusBadRequest && !strings.Contains(rec.Body.String(), `"query_too_large"`) {
+				t.Errorf("Expected query_too_large error, got %s", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestQueryLimit_ZeroDisables(t *testing.T) {
+	handler := QueryLimit(QueryLimitOptions{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products?"+strings.Repeat("a=1&b=2&", 500), nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", rec.Code)
+	}
+}
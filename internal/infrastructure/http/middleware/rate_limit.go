@@ -6,32 +6,153 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
 
+// rateLimitKeyPrefix prefixa as chaves dos contadores no Redis.
+const rateLimitKeyPrefix = "ratelimit:"
+
+// fixedWindowKeyPrefix separa os contadores da janela fixa (strings) dos
+// sorted sets da janela deslizante, para que trocar o modo não esbarre em
+// chaves do tipo errado.
+const fixedWindowKeyPrefix = rateLimitKeyPrefix + "fixed:"
+
+// RateLimitMode define o algoritmo de contagem das requisições.
+type RateLimitMode string
+
+const (
+	// RateLimitSliding guarda um membro por requisição num sorted set: a
+	// janela desliza com precisão, ao custo de memória proporcional ao limite.
+	RateLimitSliding RateLimitMode = "sliding"
+	// RateLimitFixed guarda um único contador por janela (INCR + PEXPIRE):
+	// memória constante, mas permite até o dobro do limite na virada da janela.
+	RateLimitFixed RateLimitMode = "fixed"
+)
+
+// ParseRateLimitMode valida o modo configurado; vazio usa a janela deslizante.
+func ParseRateLimitMode(raw string) (RateLimitMode, error) {
+	switch mode := RateLimitMode(raw); mode {
+	case "", RateLimitSliding:
+		return RateLimitSliding, nil
+	case RateLimitFixed:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown rate limit mode %q (valid: sliding, fixed)", raw)
+	}
+}
+
+// RateLimitConfig configura o limitador. KeyMaxAge é o TTL renovado a cada
+// requisição, aceita ou não; menor que WindowSize (ou zero) usa WindowSize.
+// RoleLimits substitui RequestsPerWindow para usuários com algum dos realm
+// roles listados; com mais de um, vale o maior limite. Mode vazio usa a
+// janela deslizante; na fixa, a chave expira com a janela e KeyMaxAge não se
+// aplica.
 type RateLimitConfig struct {
 	RequestsPerWindow int
 	WindowSize        time.Duration
 	Enabled           bool
+	KeyMaxAge         time.Duration
+	RoleLimits        map[string]int
+	Mode              RateLimitMode
+}
+
+// KeyCountRecorder recebe a contagem periódica de chaves de rate limit.
+type KeyCountRecorder interface {
+	SetKeyCount(count int)
 }
 
 type RateLimiter struct {
-	redis  *redis.Client
-	config RateLimitConfig
-	logger *zap.Logger
+	redis     *redis.Client
+	config    RateLimitConfig
+	logger    *zap.Logger
+	seq       atomic.Uint64
+	script    *redis.Script
+	keyPrefix string
 }
 
 func NewRateLimiter(redisClient *redis.Client, config RateLimitConfig, logger *zap.Logger) *RateLimiter {
+	if config.KeyMaxAge < config.WindowSize {
+		config.KeyMaxAge = config.WindowSize
+	}
+
+	script, keyPrefix := rateLimitScript, rateLimitKeyPrefix
+	if config.Mode == RateLimitFixed {
+		script, keyPrefix = fixedWindowScript, fixedWindowKeyPrefix
+	}
+
 	return &RateLimiter{
-		redis:  redisClient,
-		config: config,
-		logger: logger,
+		redis:     redisClient,
+		config:    config,
+		logger:    logger,
+		script:    script,
+		keyPrefix: keyPrefix,
 	}
 }
 
+// rateLimitScript usa o relógio do Redis (TIME), não o da instância, para que
+// o corte da janela seja o mesmo em todas as réplicas da API: com relógios
+// diferentes, membros "no futuro" sobreviveriam ao ZREMRANGEBYSCORE. O membro
+// leva um sufixo único vindo da aplicação, porque math.random no Lua do Redis
+// repete a sequência a cada execução e dois ZADD no mesmo milissegundo se
+// sobrescreveriam. O PEXPIRE roda em toda chamada, inclusive nas recusadas.
+// Como os scripts de todos os modos, devolve {aceita, restantes, ms até o
+// reset}.
+var rateLimitScript = redis.NewScript(`
+	local key = KEYS[1]
+	local limit = tonumber(ARGV[1])
+	local window_ms = tonumber(ARGV[2])
+	local max_age_ms = tonumber(ARGV[3])
+	local member = ARGV[4]
+
+	local time = redis.call('TIME')
+	local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+	redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window_ms)
+
+	local current = redis.call('ZCARD', key)
+	local allowed = 0
+	if current < limit then
+		redis.call('ZADD', key, now, now .. ':' .. member)
+		current = current + 1
+		allowed = 1
+	end
+
+	redis.call('PEXPIRE', key, max_age_ms)
+	return {allowed, math.max(limit - current, 0), window_ms}
+`)
+
+// fixedWindowScript conta as requisições da janela atual numa única chave. A
+// janela começa na primeira requisição e termina quando a chave expira; o
+// PEXPIRE só roda na criação, para que requisições seguidas não adiem o reset.
+// Um contador sem TTL (PEXPIRE perdido) ganha um novo, em vez de bloquear o
+// cliente para sempre.
+var fixedWindowScript = redis.NewScript(`
+	local key = KEYS[1]
+	local limit = tonumber(ARGV[1])
+	local window_ms = tonumber(ARGV[2])
+
+	local current = redis.call('INCR', key)
+	if current == 1 then
+		redis.call('PEXPIRE', key, window_ms)
+	end
+
+	local ttl = redis.call('PTTL', key)
+	if ttl < 0 then
+		redis.call('PEXPIRE', key, window_ms)
+		ttl = window_ms
+	end
+
+	local allowed = 0
+	if current <= limit then
+		allowed = 1
+	end
+	return {allowed, math.max(limit - current, 0), ttl}
+`)
+
 func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !rl.config.Enabled {
@@ -40,23 +161,24 @@ func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 		}
 
 		identifier := rl.getIdentifier(r)
-		key := fmt.Sprintf("ratelimit:%s", identifier)
+		key := rl.keyPrefix + identifier
+		limit := rl.limitFor(GetUserFromContext(r.Context()))
 
-		allowed, remaining, resetTime, err := rl.checkRateLimit(r.Context(), key)
+		allowed, remaining, resetTime, err := rl.checkRateLimit(r.Context(), key, limit)
 		if err != nil {
 			rl.logger.Error("rate limit check failed", zap.Error(err), zap.String("identifier", identifier))
 			next.ServeHTTP(w, r)
 			return
 		}
 
-		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(rl.config.RequestsPerWindow))
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit))
 		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
 		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(resetTime, 10))
 
 		if !allowed {
 			rl.logger.Warn("rate limit exceeded",
 				zap.String("identifier", identifier),
-				zap.Int("limit", rl.config.RequestsPerWindow),
+				zap.Int("limit", limit),
 			)
 			rl.rateLimitExceededResponse(w, resetTime)
 			return
@@ -82,44 +204,35 @@ func (rl *RateLimiter) getIdentifier(r *http.Request) string {
 	return "ip:" + ip
 }
 
-func (rl *RateLimiter) checkRateLimit(ctx context.Context, key string) (bool, int, int64, error) {
+// limitFor resolve o limite pelo tier do usuário: o maior entre os realm
+// roles com limite próprio ou, sem nenhum (inclusive anônimos), o limite base.
+func (rl *RateLimiter) limitFor(user *UserClaims) int {
+	if user == nil {
+		return rl.config.RequestsPerWindow
+	}
+
+	limit, tiered := 0, false
+	for _, role := range user.RealmRoles {
+		if roleLimit, ok := rl.config.RoleLimits[role]; ok && (!tiered || roleLimit > limit) {
+			limit, tiered = roleLimit, true
+		}
+	}
+	if !tiered {
+		return rl.config.RequestsPerWindow
+	}
+	return limit
+}
+
+func (rl *RateLimiter) checkRateLimit(ctx context.Context, key string, limit int) (bool, int, int64, error) {
 	now := time.Now()
-	windowStart := now.Add(-rl.config.WindowSize)
 	resetTime := now.Add(rl.config.WindowSize).Unix()
+	member := strconv.FormatUint(rl.seq.Add(1), 36) + "-" + strconv.FormatInt(now.UnixNano(), 36)
 
-	script := redis.NewScript(`
-		local key = KEYS[1]
-		local now = tonumber(ARGV[1])
-		local window_start = tonumber(ARGV[2])
-		local limit = tonumber(ARGV[3])
-		local window_size_ms = tonumber(ARGV[4])
-
-		-- Remove old entries outside the window
-		redis.call('ZREMRANGEBYSCORE', key, '-inf', window_start)
-
-		-- Count current requests in window
-		local current = redis.call('ZCARD', key)
-
-		if current < limit then
-			-- Add current request
-			redis.call('ZADD', key, now, now .. ':' .. math.random())
-			-- Set expiry on the key
-			redis.call('PEXPIRE', key, window_size_ms)
-			return {1, limit - current - 1}
-		else
-			return {0, 0}
-		end
-	`)
-
-	nowMs := now.UnixMilli()
-	windowStartMs := windowStart.UnixMilli()
-	windowSizeMs := rl.config.WindowSize.Milliseconds()
-
-	result, err := script.Run(ctx, rl.redis, []string{key},
-		nowMs,
-		windowStartMs,
-		rl.config.RequestsPerWindow,
-		windowSizeMs,
+	result, err := rl.script.Run(ctx, rl.redis, []string{key},
+		limit,
+		rl.config.WindowSize.Milliseconds(),
+		rl.config.KeyMaxAge.Milliseconds(),
+		member,
 	).Slice()
 
 	if err != nil {
@@ -128,10 +241,49 @@ func (rl *RateLimiter) checkRateLimit(ctx context.Context, key string) (bool, in
 
 	allowed := result[0].(int64) == 1
 	remaining := int(result[1].(int64))
+	resetTime = now.Add(time.Duration(result[2].(int64)) * time.Millisecond).Unix()
 
 	return allowed, remaining, resetTime, nil
 }
 
+// CountKeys conta as chaves de rate limit com SCAN, sem bloquear o Redis.
+func (rl *RateLimiter) CountKeys(ctx context.Context) (int, error) {
+	count := 0
+	iter := rl.redis.Scan(ctx, 0, rateLimitKeyPrefix+"*", 1000).Iterator()
+	for iter.Next(ctx) {
+		count++
+	}
+	if err := iter.Err(); err != nil {
+		return 0, fmt.Errorf("failed to count rate limit keys: %w", err)
+	}
+	return count, nil
+}
+
+// MonitorKeys publica a contagem de chaves a cada interval até o contexto
+// ser cancelado.
+func (rl *RateLimiter) MonitorKeys(ctx context.Context, interval time.Duration, recorder KeyCountRecorder) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		count, err := rl.CountKeys(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			rl.logger.Warn("failed to count rate limit keys", zap.Error(err))
+		} else {
+			recorder.SetKeyCount(count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 func (rl *RateLimiter) rateLimitExceededResponse(w http.ResponseWriter, resetTime int64) {
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Retry-After", strconv.FormatInt(resetTime-time.Now().Unix(), 10))
@@ -143,26 +295,3 @@ func (rl *RateLimiter) rateLimitExceededResponse(w http.ResponseWriter, resetTim
 		"retry_after": resetTime - time.Now().Unix(),
 	})
 }
-
-func (rl *RateLimiter) GetRateLimitInfo(ctx context.Context, identifier string) (int, int, error) {
-	key := fmt.Sprintf("ratelimit:%s", identifier)
-	now := time.Now()
-	windowStart := now.Add(-rl.config.WindowSize)
-
-	pipe := rl.redis.Pipeline()
-	pipe.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(windowStart.UnixMilli(), 10))
-	countCmd := pipe.ZCard(ctx, key)
-
-	_, err := pipe.Exec(ctx)
-	if err != nil {
-		return 0, 0, err
-	}
-
-	current := int(countCmd.Val())
-	remaining := rl.config.RequestsPerWindow - current
-	if remaining < 0 {
-		remaining = 0
-	}
-
-	return current, remaining, nil
-}
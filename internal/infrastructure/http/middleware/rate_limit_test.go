@@ -0,0 +1,214 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// newMiniredisLimiter roda o limitador contra um miniredis, que executa os
+// scripts Lua de verdade. O relógio do servidor (TIME) fica fixo até o teste
+// avançá-lo com SetTime.
+func newMiniredisLimiter(t *testing.T, config RateLimitConfig) (*RateLimiter, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	server.SetTime(time.Date(2025, 1, 10, 8, 0, 0, 0, time.UTC))
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewRateLimiter(client, config, zap.NewNop()), server
+}
+
+// serveRateLimited faz uma requisição pelo limitador com o usuário informado
+// (nil para anônimo, identificado pelo IP).
+func serveRateLimited(limiter *RateLimiter, user *UserClaims) *httptest.ResponseRecorder {
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	if user != nil {
+		req = req.WithContext(context.WithValue(req.Context(), UserContextKey, user))
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimiter_Tiers(t *testing.T) {
+	limiter, _ := newMiniredisLimiter(t, RateLimitConfig{
+		Enabled:           true,
+		RequestsPerWindow: 100,
+		WindowSize:        time.Minute,
+		RoleLimits:        map[string]int{"premium": 1000, "partner": 500},
+	})
+
+	tests := []struct {
+		name string
+		user *UserClaims
+		want int
+	}{
+		{name: "anonymous", want: 100},
+		{name: "base", user: &UserClaims{Subject: "user-1", RealmRoles: []string{"product-admin"}}, want: 100},
+		{name: "premium", user: &UserClaims{Subject: "user-2", RealmRoles: []string{"premium"}}, want: 1000},
+		{name: "highest tier wins", user: &UserClaims{Subject: "user-3", RealmRoles: []string{"partner", "premium"}}, want: 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveRateLimited(limiter, tt.user)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d", rec.Code)
+			}
+			if got := rec.Header().Get("X-RateLimit-Limit"); got != strconv.Itoa(tt.want) {
+				t.Errorf("Expected X-RateLimit-Limit %d, got %q", tt.want, got)
+			}
+			// O restante vem do script, então confirma o limite aplicado no Redis.
+			if got := rec.Header().Get("X-RateLimit-Remaining"); got != strconv.Itoa(tt.want-1) {
+				t.Errorf("Expected X-RateLimit-Remaining %d, got %q", tt.want-1, got)
+			}
+		})
+	}
+}
+
+func TestRateLimiter_Modes(t *testing.T) {
+	for _, mode := range []RateLimitMode{RateLimitSliding, RateLimitFixed} {
+		t.Run(string(mode), func(t *testing.T) {
+			limiter, server := newMiniredisLimiter(t, RateLimitConfig{
+				Enabled:           true,
+				RequestsPerWindow: 3,
+				WindowSize:        time.Minute,
+				Mode:              mode,
+			})
+
+			for i := 1; i <= 4; i++ {
+				rec := serveRateLimited(limiter, nil)
+
+				wantStatus, wantRemaining := http.StatusOK, strconv.Itoa(3-i)
+				if i == 4 {
+					wantStatus, wantRemaining = http.StatusTooManyRequests, "0"
+				}
+				if rec.Code != wantStatus {
+					t.Fatalf("Request %d: expected status %d, got %d", i, wantStatus, rec.Code)
+				}
+				if got := rec.Header().Get("X-RateLimit-Limit"); got != "3" {
+					t.Errorf("Request %d: expected X-RateLimit-Limit 3, got %q", i, got)
+				}
+				if got := rec.Header().Get("X-RateLimit-Remaining"); got != wantRemaining {
+					t.Errorf("Request %d: expected X-RateLimit-Remaining %s, got %q", i, wantRemaining, got)
+				}
+				if i == 4 && rec.Header().Get("Retry-After") == "" {
+					t.Error("Expected Retry-After on the rejected request")
+				}
+			}
+
+			keys := server.Keys()
+			if len(keys) != 1 || strings.HasPrefix(keys[0], fixedWindowKeyPrefix) != (mode == RateLimitFixed) {
+				t.Errorf("Expected a single %s key, got %v", mode, keys)
+			}
+		})
+	}
+}
+
+func TestRateLimiter_SlidingWindow(t *testing.T) {
+	limiter, server := newMiniredisLimiter(t, RateLimitConfig{
+		Enabled:           true,
+		RequestsPerWindow: 2,
+		WindowSize:        time.Minute,
+		KeyMaxAge:         time.Hour,
+	})
+	start := time.Date(2025, 1, 10, 8, 0, 0, 0, time.UTC)
+	key := rateLimitKeyPrefix + "ip:10.0.0.1:1234"
+
+	serveRateLimited(limiter, nil)
+	server.SetTime(start.Add(30 * time.Second))
+	serveRateLimited(limiter, nil)
+	if rec := serveRateLimited(limiter, nil); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected the third request in the window to be rejected, got %d", rec.Code)
+	}
+	if members, _ := server.ZMembers(key); len(members) != 2 {
+		t.Errorf("Expected rejected requests not to be recorded, got %d members", len(members))
+	}
+	if ttl := server.TTL(key); ttl != time.Hour {
+		t.Errorf("Expected the key to live for KeyMaxAge, got %v", ttl)
+	}
+
+	// Um minuto depois da primeira, só ela saiu da janela.
+	server.SetTime(start.Add(time.Minute + time.Millisecond))
+	if rec := serveRateLimited(limiter, nil); rec.Code != http.StatusOK || rec.Header().Get("X-RateLimit-Remaining") != "0" {
+		t.Fatalf("Expected one slot freed by the sliding window, got %d with %q remaining", rec.Code, rec.Header().Get("X-RateLimit-Remaining"))
+	}
+	if rec := serveRateLimited(limiter, nil); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected the second request at that point to be rejected, got %d", rec.Code)
+	}
+}
+
+func TestRateLimiter_FixedWindow(t *testing.T) {
+	limiter, server := newMiniredisLimiter(t, RateLimitConfig{
+		Enabled:           true,
+		RequestsPerWindow: 1,
+		WindowSize:        time.Minute,
+		KeyMaxAge:         time.Hour,
+		Mode:              RateLimitFixed,
+	})
+	key := fixedWindowKeyPrefix + "ip:10.0.0.1:1234"
+
+	serveRateLimited(limiter, nil)
+	server.FastForward(40 * time.Second)
+	if rec := serveRateLimited(limiter, nil); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected the second request in the window to be rejected, got %d", rec.Code)
+	}
+	if ttl := server.TTL(key); ttl != 20*time.Second {
+		t.Errorf("Expected later requests not to push the reset back, got TTL %v", ttl)
+	}
+
+	server.FastForward(20 * time.Second)
+	if rec := serveRateLimited(limiter, nil); rec.Code != http.StatusOK {
+		t.Errorf("Expected a new window once the key expires, got %d", rec.Code)
+	}
+
+	// Um contador que perdeu o TTL ganha um novo em vez de bloquear para sempre.
+	server.Set(key, "5")
+	serveRateLimited(limiter, nil)
+	if ttl := server.TTL(key); ttl != time.Minute {
+		t.Errorf("Expected a counter without TTL to get the window TTL, got %v", ttl)
+	}
+}
+
+func TestRateLimiter_CountKeys(t *testing.T) {
+	limiter, server := newMiniredisLimiter(t, RateLimitConfig{
+		Enabled:           true,
+		RequestsPerWindow: 10,
+		WindowSize:        time.Minute,
+	})
+
+	serveRateLimited(limiter, nil)
+	serveRateLimited(limiter, &UserClaims{Subject: "user-1"})
+	server.Set("product_p1", "{}")
+
+	count, err := limiter.CountKeys(context.Background())
+	if err != nil || count != 2 {
+		t.Errorf("Expected 2 rate limit keys, got %d (%v)", count, err)
+	}
+}
+
+func TestParseRateLimitMode(t *testing.T) {
+	if mode, err := ParseRateLimitMode(""); err != nil || mode != RateLimitSliding {
+		t.Errorf("Expected empty mode to default to sliding, got %q (%v)", mode, err)
+	}
+	if mode, err := ParseRateLimitMode("fixed"); err != nil || mode != RateLimitFixed {
+		t.Errorf("Expected fixed mode, got %q (%v)", mode, err)
+	}
+	if _, err := ParseRateLimitMode("token-bucket"); err == nil {
+		t.Error("Expected an error for an unknown mode")
+	}
+}
@@ -2,6 +2,9 @@ package middleware
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
 	"net/http"
 
 	"github.com/oklog/ulid/v2"
@@ -11,18 +14,49 @@ type contextKey string
 
 const RequestIDKey contextKey = "request_id"
 
+const (
+	RequestIDFormatULID   = "ulid"
+	RequestIDFormatUUID   = "uuid"
+	RequestIDFormatNanoID = "nanoid"
+)
+
+// RequestIDGenerator gera IDs de requisição. Todos os formatos suportados usam
+// apenas caracteres seguros para URLs e logs.
+type RequestIDGenerator func() string
+
+// NewRequestIDGenerator retorna o gerador do formato informado: ulid (padrão),
+// uuid (v4) ou nanoid (21 caracteres, alfabeto URL-safe).
+func NewRequestIDGenerator(format string) (RequestIDGenerator, error) {
+	switch format {
+	case "", RequestIDFormatULID:
+		return generateULID, nil
+	case RequestIDFormatUUID:
+		return generateUUIDv4, nil
+	case RequestIDFormatNanoID:
+		return generateNanoID, nil
+	default:
+		return nil, fmt.Errorf("unknown request id format %q (valid: ulid, uuid, nanoid)", format)
+	}
+}
+
 func RequestID(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		requestID := r.Header.Get("X-Request-ID")
-		if requestID == "" {
-			requestID = ulid.Make().String()
-		}
+	return RequestIDWithGenerator(generateULID)(next)
+}
+
+func RequestIDWithGenerator(generate RequestIDGenerator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get("X-Request-ID")
+			if requestID == "" {
+				requestID = generate()
+			}
 
-		w.Header().Set("X-Request-ID", requestID)
+			w.Header().Set("X-Request-ID", requestID)
 
-		ctx := context.WithValue(r.Context(), RequestIDKey, requestID)
-		next.ServeHTTP(w, r.WithContext(ctx))
-	})
+			ctx := context.WithValue(r.Context(), RequestIDKey, requestID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
 }
 
 func GetRequestID(ctx context.Context) string {
@@ -31,3 +65,43 @@ func GetRequestID(ctx context.Context) string {
 	}
 	return ""
 }
+
+func generateULID() string {
+	return ulid.Make().String()
+}
+
+func generateUUIDv4() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return generateULID()
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	var out [36]byte
+	hex.Encode(out[0:8], b[0:4])
+	out[8] = '-'
+	hex.Encode(out[9:13], b[4:6])
+	out[13] = '-'
+	hex.Encode(out[14:18], b[6:8])
+	out[18] = '-'
+	hex.Encode(out[19:23], b[8:10])
+	out[23] = '-'
+	hex.Encode(out[24:], b[10:])
+	return string(out[:])
+}
+
+const nanoIDAlphabet = "_-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// generateNanoID gera 21 símbolos de um alfabeto de 64 (126 bits de entropia),
+// o mesmo tamanho padrão da biblioteca nanoid.
+func generateNanoID() string {
+	var b [21]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return generateULID()
+	}
+	for i := range b {
+		b[i] = nanoIDAlphabet[b[i]&63]
+	}
+	return string(b[:])
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"testing"
+)
+
+func TestNewRequestIDGenerator_Formats(t *testing.T) {
+	tests := []struct {
+		format  string
+		pattern *regexp.Regexp
+	}{
+		{"", regexp.MustCompile(`^[0-9A-HJKMNP-TV-Z]{26}$`)},
+		{RequestIDFormatULID, regexp.MustCompile(`^[0-9A-HJKMNP-TV-Z]{26}$`)},
+		{RequestIDFormatUUID, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)},
+		{RequestIDFormatNanoID, regexp.MustCompile(`^[A-Za-z0-9_-]{21}$`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			generate, err := NewRequestIDGenerator(tt.format)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			seen := make(map[string]bool)
+			for i := 0; i < 1000; i++ {
+				id := generate()
+				if !tt.pattern.MatchString(id) {
+					t.Fatalf("ID %q does not match expected format", id)
+				}
+				if url.PathEscape(id) != id {
+					t.Fatalf("ID %q is not URL-safe", id)
+				}
+				if seen[id] {
+					t.Fatalf("Duplicate ID generated: %s", id)
+				}
+				seen[id] = true
+			}
+		})
+	}
+}
+
+func TestNewRequestIDGenerator_UnknownFormat(t *testing.T) {
+	if _, err := NewRequestIDGenerator("snowflake"); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
+
+func TestRequestIDWithGenerator(t *testing.T) {
+	var ctxID string
+	handler := RequestIDWithGenerator(func() string { return "fixed-id" })(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxID = GetRequestID(r.Context())
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Header().Get("X-Request-ID") != "fixed-id" || ctxID != "fixed-id" {
+		t.Errorf("Expected generated ID in header and context, got %q / %q", rec.Header().Get("X-Request-ID"), ctxID)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Request-ID", "client-id")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Header().Get("X-Request-ID") != "client-id" {
+		t.Errorf("Expected client-provided ID to be preserved, got %q", rec.Header().Get("X-Request-ID"))
+	}
+}
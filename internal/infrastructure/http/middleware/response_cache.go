@@ -0,0 +1,155 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"go.uber.org/zap"
+)
+
+// maxCachedResponseBytes limita o corpo guardado; respostas maiores são
+// servidas normalmente e ficam fora do cache.
+const maxCachedResponseBytes = 1 << 20
+
+const responseCompleteContextKey contextKey = "response_complete"
+
+// MarkResponseComplete sinaliza ao ResponseCache que o handler escreveu a
+// resposta inteira. Uma listagem interrompida no meio (produto que não
+// serializa, falha de escrita) não chama a função e fica fora do cache, mesmo
+// com status 200. Fora do ResponseCache, não faz nada.
+func MarkResponseComplete(ctx context.Context) {
+	if complete, ok := ctx.Value(responseCompleteContextKey).(*bool); ok {
+		*complete = true
+	}
+}
+
+// AddVary acrescenta value ao header Vary se ele ainda não estiver lá.
+func AddVary(header http.Header, value string) {
+	for _, existing := range header.Values("Vary") {
+		for _, field := range strings.Split(existing, ",") {
+			if strings.EqualFold(strings.TrimSpace(field), value) {
+				return
+			}
+		}
+	}
+	header.Add("Vary", value)
+}
+
+// responseRecorder repassa a resposta ao cliente e guarda uma cópia do corpo
+// até maxCachedResponseBytes.
+type responseRecorder struct {
+	http.ResponseWriter
+	status   int
+	body     bytes.Buffer
+	overflow bool
+}
+
+func (w *responseRecorder) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if !w.overflow {
+		if w.body.Len()+len(b) > maxCachedResponseBytes {
+			w.overflow = true
+			w.body.Reset()
+		} else {
+			w.body.Write(b)
+		}
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// ResponseCache devolve do store as respostas JSON 200 já serializadas,
+// chaveadas pelo path, pela query string (com os parâmetros ordenados) e pelo
+// host e esquema que entram nos links de paginação. Pedidos de XML e
+// condicionais (If-None-Match) seguem direto para o handler, sem ler nem
+// gravar o cache. Falhas do store só são registradas: a requisição é servida
+// normalmente. Só é guardado o corpo que o handler marcou como completo com
+// MarkResponseComplete.
+func ResponseCache(store port.ResponseCache, logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// O corpo depende do Accept (JSON ou XML) em hits, misses e
+			// pedidos que passam direto.
+			AddVary(w.Header(), "Accept")
+			if strings.Contains(r.Header.Get("Accept"), "xml") || r.Header.Get("If-None-Match") != "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := responseCacheKey(r)
+			cached, err := store.Get(r.Context(), key)
+			if err != nil {
+				logger.Warn("response cache unavailable", zap.Error(err))
+			}
+			if cached != nil {
+				port.RecordCacheHit(r.Context())
+				w.Header().Set("Content-Type", cached.ContentType)
+				if cached.Link != "" {
+					w.Header().Set("Link", cached.Link)
+				}
+				if cached.ETag != "" {
+					w.Header().Set("ETag", cached.ETag)
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write(cached.Body)
+				return
+			}
+
+			complete := false
+			recorder := &responseRecorder{ResponseWriter: w}
+			next.ServeHTTP(recorder, r.WithContext(context.WithValue(r.Context(), responseCompleteContextKey, &complete)))
+
+			contentType := w.Header().Get("Content-Type")
+			if !complete || recorder.status != http.StatusOK || recorder.overflow || !strings.HasPrefix(contentType, "application/json") {
+				return
+			}
+
+			err = store.Set(r.Context(), key, port.CachedResponse{
+				ContentType: contentType,
+				Link:        w.Header().Get("Link"),
+				ETag:        w.Header().Get("ETag"),
+				Body:        recorder.body.Bytes(),
+			})
+			if err != nil {
+				logger.Warn("failed to store cached response", zap.Error(err))
+			}
+		})
+	}
+}
+
+// responseCacheKey resume em sha256 o que define o corpo da resposta: path,
+// query normalizada e a origem usada nos links (X-Forwarded-Proto,
+// X-Forwarded-Host, Host e TLS).
+func responseCacheKey(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		r.URL.Path,
+		r.URL.Query().Encode(),
+		scheme,
+		r.Header.Get("X-Forwarded-Proto"),
+		r.Header.Get("X-Forwarded-Host"),
+		r.Host,
+	}, "\n")))
+	return hex.EncodeToString(sum[:])
+}
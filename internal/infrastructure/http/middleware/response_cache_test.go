@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+	"go.uber.org/zap"
+)
+
+type memoryResponseCache struct {
+	responses map[string]port.CachedResponse
+	err       error
+}
+
+func (c *memoryResponseCache) Get(ctx context.Context, key string) (*port.CachedResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	response, ok := c.responses[key]
+	if !ok {
+		return nil, nil
+	}
+	return &response, nil
+}
+
+func (c *memoryResponseCache) Set(ctx context.Context, key string, response port.CachedResponse) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.responses[key] = response
+	return nil
+}
+
+// countingListHandler responde uma listagem JSON e conta as chamadas.
+func countingListHandler(calls *int, status int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Link", `</api/v1/products?offset=50>; rel="next"`)
+		w.WriteHeader(status)
+		w.Write([]byte(`{"data":[],"query":"` + r.URL.RawQuery + `"}`))
+		MarkResponseComplete(r.Context())
+	})
+}
+
+func TestResponseCache(t *testing.T) {
+	store := &memoryResponseCache{responses: map[string]port.CachedResponse{}}
+	calls := 0
+	handler := ResponseCache(store, zap.NewNop())(countingListHandler(&calls, http.StatusOK))
+
+	serve := func(target string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := serve("/api/v1/products?limit=10&offset=0", nil)
+	second := serve("/api/v1/products?offset=0&limit=10", nil)
+	if calls != 1 {
+		t.Fatalf("Expected the reordered query to hit the cache, handler ran %d times", calls)
+	}
+	if second.Body.String() != first.Body.String() {
+		t.Errorf("Expected the cached body %q, got %q", first.Body.String(), second.Body.String())
+	}
+	if second.Header().Get("Link") != first.Header().Get("Link") || second.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("Expected the cached headers to be replayed, got %v", second.Header())
+	}
+	for _, rec := range []*httptest.ResponseRecorder{first, second} {
+		if vary := rec.Header().Values("Vary"); len(vary) != 1 || vary[0] != "Accept" {
+			t.Errorf("Expected a single Vary: Accept on hits and misses, got %v", vary)
+		}
+	}
+
+	serve("/api/v1/products?limit=20", nil)
+	if calls != 2 {
+		t.Errorf("Expected a different query to miss, handler ran %d times", calls)
+	}
+
+	serve("/api/v1/products?limit=10&offset=0", http.Header{"X-Forwarded-Host": {"api.example.com"}})
+	if calls != 3 {
+		t.Errorf("Expected another host to miss, since links embed it; handler ran %d times", calls)
+	}
+
+	for _, header := range []http.Header{
+		{"Accept": {"application/xml"}},
+		{"If-None-Match": {`W/"abc"`}},
+	} {
+		before := calls
+		serve("/api/v1/products?limit=10&offset=0", header)
+		if calls != before+1 {
+			t.Errorf("Expected %v to bypass the cache", header)
+		}
+	}
+}
+
+func TestResponseCache_OnlyStoresOK(t *testing.T) {
+	store := &memoryResponseCache{responses: map[string]port.CachedResponse{}}
+	calls := 0
+	handler := ResponseCache(store, zap.NewNop())(countingListHandler(&calls, http.StatusServiceUnavailable))
+
+	for i := 0; i < 2; i++ {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/products", nil))
+	}
+	if calls != 2 || len(store.responses) != 0 {
+		t.Errorf("Expected error responses not to be cached, handler ran %d times, %d stored", calls, len(store.responses))
+	}
+}
+
+func TestResponseCache_StoreFailure(t *testing.T) {
+	store := &memoryResponseCache{err: errors.New("redis down")}
+	calls := 0
+	handler := ResponseCache(store, zap.NewNop())(countingListHandler(&calls, http.StatusOK))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/products", nil))
+	if calls != 1 || rec.Code != http.StatusOK {
+		t.Errorf("Expected the request to be served without the cache, got status %d after %d calls", rec.Code, calls)
+	}
+}
+
+func TestResponseCache_SkipsIncompleteBody(t *testing.T) {
+	store := &memoryResponseCache{responses: map[string]port.CachedResponse{}}
+	handler := ResponseCache(store, zap.NewNop())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		// Um produto que não serializa interrompe a listagem sem marcar a
+		// resposta como completa.
+		w.Write([]byte(`{"data":[{"id":"a"}`))
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/products", nil))
+	if len(store.responses) != 0 {
+		t.Errorf("Expected an incomplete body not to be cached, got %d stored", len(store.responses))
+	}
+}
+
+func TestAddVary(t *testing.T) {
+	header := http.Header{"Vary": {"Accept-Encoding, accept"}}
+	AddVary(header, "Accept")
+	if values := header.Values("Vary"); len(values) != 1 {
+		t.Errorf("Expected an existing field not to be repeated, got %v", values)
+	}
+
+	AddVary(header, "Origin")
+	if values := header.Values("Vary"); len(values) != 2 || values[1] != "Origin" {
+		t.Errorf("Expected Origin to be added, got %v", values)
+	}
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Startup responde 503 com Retry-After enquanto isReady retornar false, para
+// que o load balancer segure o tráfego até o fim da inicialização (warmup do
+// cache) em vez de mandá-lo para um servidor ainda frio.
+func Startup(isReady func() bool, retryAfter time.Duration) func(http.Handler) http.Handler {
+	seconds := int64(retryAfter.Round(time.Second) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isReady() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error":       "starting_up",
+				"message":     "Service is starting up. Please try again later.",
+				"retry_after": seconds,
+			})
+		})
+	}
+}
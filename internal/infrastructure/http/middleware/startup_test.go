@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStartup(t *testing.T) {
+	var ready atomic.Bool
+
+	called := 0
+	handler := Startup(ready.Load, 5*time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called++
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/products", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503 while starting, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "5" {
+		t.Errorf("Expected Retry-After 5, got %q", got)
+	}
+	if called != 0 {
+		t.Error("Expected next handler not to be called while starting")
+	}
+
+	ready.Store(true)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/products", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status 200 once ready, got %d", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") != "" {
+		t.Error("Expected no Retry-After once ready")
+	}
+	if called != 1 {
+		t.Errorf("Expected next handler to be called once, got %d", called)
+	}
+}
+
+func TestStartup_RetryAfterAtLeastOneSecond(t *testing.T) {
+	handler := Startup(func() bool { return false }, 0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/products", nil))
+
+	if got := rec.Header().Get("Retry-After"); got != "1" {
+		t.Errorf("Expected Retry-After 1, got %q", got)
+	}
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+const TimezoneContextKey contextKey = "timezone"
+
+var errInvalidTimezone = errors.New("invalid time zone")
+
+// Timezone lê o parâmetro tz (nome IANA, ex.: America/Sao_Paulo) e guarda o
+// fuso no contexto, para que created_at e updated_at sejam respondidos nele.
+// Um nome desconhecido responde 400 invalid_timezone antes do handler; sem tz,
+// os timestamps continuam em UTC.
+func Timezone(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("tz")
+		if name == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		location, err := loadTimezone(name)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{
+				"error":   "invalid_timezone",
+				"message": "tz must be an IANA time zone name, such as America/Sao_Paulo",
+			})
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), TimezoneContextKey, location)))
+	})
+}
+
+// loadTimezone recusa "Local", que time.LoadLocation aceita mas dependeria
+// do fuso do servidor.
+func loadTimezone(name string) (*time.Location, error) {
+	if name == "Local" {
+		return nil, errInvalidTimezone
+	}
+	return time.LoadLocation(name)
+}
+
+// GetTimezone retorna o fuso pedido em tz, ou nil quando não informado.
+func GetTimezone(ctx context.Context) *time.Location {
+	if location, ok := ctx.Value(TimezoneContextKey).(*time.Location); ok {
+		return location
+	}
+	return nil
+}
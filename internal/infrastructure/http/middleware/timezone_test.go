@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTimezone(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		status   int
+		location string
+	}{
+		{name: "no tz", query: "", status: http.StatusOK, location: ""},
+		{name: "iana name", query: "?tz=America/Sao_Paulo", status: http.StatusOK, location: "America/Sao_Paulo"},
+		{name: "utc", query: "?tz=UTC", status: http.StatusOK, location: "UTC"},
+		{name: "unknown zone", query: "?tz=Mars/Olympus_Mons", status: http.StatusBadRequest},
+		{name: "server local zone", query: "?tz=Local", status: http.StatusBadRequest},
+		{name: "path traversal", query: "?tz=../../etc/passwd", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := Timezone(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if location := GetTimezone(r.Context()); location != nil {
+					got = location.String()
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/products"+tt.query, nil))
+
+			if rec.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d", tt.status, rec.Code)
+			}
+			if got != tt.location {
+				t.Errorf("Expected location %q, got %q", tt.location, got)
+			}
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package router
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
 	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/handler"
 	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/middleware"
 	customlogger "github.com/dowglassantana/product-redis-api/internal/infrastructure/logger"
@@ -14,32 +18,122 @@ import (
 	"go.uber.org/zap"
 )
 
+// Modos de acesso ao endpoint /log/level.
+const (
+	LogLevelPublic   LogLevelAccess = "public"
+	LogLevelAdmin    LogLevelAccess = "admin"
+	LogLevelDisabled LogLevelAccess = "disabled"
+)
+
+// LogLevelAccess define quem pode consultar e alterar o nível de log em
+// /log/level: qualquer um (public), só o admin role (admin) ou ninguém
+// (disabled, a rota responde 404).
+type LogLevelAccess string
+
+// ParseLogLevelAccess interpreta "public", "admin" ou "disabled".
+func ParseLogLevelAccess(value string) (LogLevelAccess, error) {
+	switch access := LogLevelAccess(strings.ToLower(strings.TrimSpace(value))); access {
+	case LogLevelPublic, LogLevelAdmin, LogLevelDisabled:
+		return access, nil
+	default:
+		return "", fmt.Errorf("invalid log level endpoint access %q: must be public, admin or disabled", value)
+	}
+}
+
+// Options reúne as configurações opcionais do router. Campos zerados mantêm o
+// comportamento padrão.
+type Options struct {
+	RequestIDGenerator middleware.RequestIDGenerator
+
+	// Degraded, quando definido, marca as leituras com X-Degraded enquanto
+	// retornar true.
+	Degraded func() bool
+
+	// Started, quando definido, faz as rotas /api/v1 responderem 503 com
+	// Retry-After (StartupRetryAfter) enquanto retornar false. Os health checks
+	// seguem disponíveis.
+	Started           func() bool
+	StartupRetryAfter time.Duration
+
+	// AdminRole é o realm role exigido nas rotas /api/v1/admin. Vazio usa
+	// "admin".
+	AdminRole string
+
+	// WriteRole é o realm role exigido em POST, PUT, PATCH e DELETE sob
+	// /api/v1/products. Vazio usa "product-admin".
+	WriteRole string
+
+	// IgnoreUnknownFields descarta, em vez de responder 400, os caminhos de
+	// fields fora de specifications.
+	IgnoreUnknownFields bool
+
+	// CacheStatusHeader escreve X-Cache: HIT|MISS nas leituras de /api/v1
+	// que passaram pelo cache.
+	CacheStatusHeader bool
+
+	// HTTPS, quando definido, exige TLS em todas as rotas, exceto health
+	// checks e /metrics.
+	HTTPS *middleware.HTTPSOptions
+
+	// Compression define os níveis e o tamanho mínimo das respostas
+	// comprimidas. O zero value comprime tudo com os níveis padrão.
+	Compression middleware.CompressOptions
+
+	// QueryLimit limita a query string sob /api/v1/products; o zero value não
+	// limita.
+	QueryLimit middleware.QueryLimitOptions
+
+	// SlowRequestThreshold detalha no access log o tempo em cache, banco e
+	// serialização das requisições que levarem pelo menos isso. Zero desativa.
+	SlowRequestThreshold time.Duration
+
+	// LogLevelAccess controla o endpoint /log/level. Vazio equivale a public.
+	LogLevelAccess LogLevelAccess
+
+	// ResponseCache, quando definido, guarda as respostas JSON de GET
+	// /products (sem modified_by), /search/name e /search/category.
+	ResponseCache port.ResponseCache
+}
+
 func SetupRouter(
 	productHandler *handler.ProductHandler,
 	healthHandler *handler.HealthHandler,
+	adminHandler *handler.AdminHandler,
 	jwtAuth *middleware.JWTAuth,
 	rateLimiter *middleware.RateLimiter,
 	atomicLevel *zap.AtomicLevel,
 	logger *zap.Logger,
+	opts Options,
 ) http.Handler {
 	r := chi.NewRouter()
 
+	r.Use(middleware.Metrics(logger))
 	r.Use(chimiddleware.RealIP)
-	r.Use(middleware.RequestID)
+	if opts.RequestIDGenerator != nil {
+		r.Use(middleware.RequestIDWithGenerator(opts.RequestIDGenerator))
+	} else {
+		r.Use(middleware.RequestID)
+	}
 	r.Use(middleware.Recovery(logger))
-	r.Use(middleware.Logging(logger))
-	r.Use(chimiddleware.Compress(5))
+	r.Use(middleware.LoggingWithSlowThreshold(logger, opts.SlowRequestThreshold))
+	if opts.HTTPS != nil {
+		httpsOpts := *opts.HTTPS
+		httpsOpts.ExemptPaths = append(httpsOpts.ExemptPaths, "/health/", "/metrics")
+		r.Use(middleware.EnforceHTTPS(httpsOpts))
+	}
+	r.Use(middleware.Compress(opts.Compression))
 
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Request-ID"},
-		ExposedHeaders:   []string{"X-Request-ID", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Request-ID", "If-None-Match", "If-Match", "Idempotency-Key"},
+		ExposedHeaders:   []string{"ETag", "X-Request-ID", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "X-Degraded", "X-Cache", "Idempotent-Replayed"},
 		AllowCredentials: false,
 		MaxAge:           300,
 	}))
 
 	r.Get("/health/live", healthHandler.Liveness)
+	r.Get("/health/startup", healthHandler.Startup)
 	r.Get("/health/ready", healthHandler.Readiness)
 	r.Handle("/metrics", promhttp.Handler())
 
@@ -47,24 +141,109 @@ func SetupRouter(
 		httpSwagger.URL("/swagger/doc.json"),
 	))
 
+	adminRole := opts.AdminRole
+	if adminRole == "" {
+		adminRole = "admin"
+	}
+	requireAdmin := jwtAuth.RequireRole(adminRole)
+
+	// O relatório detalhado expõe latências e mensagens de erro das
+	// dependências, então fica restrito ao admin role.
+	r.With(jwtAuth.Middleware, requireAdmin).Get("/health/detailed", healthHandler.Detailed)
+
 	logLevelHandler := customlogger.NewAtomicLevelServer(atomicLevel)
-	r.HandleFunc("/log/level", logLevelHandler.ServeHTTP)
+	switch opts.LogLevelAccess {
+	case LogLevelDisabled:
+	case LogLevelAdmin:
+		r.With(jwtAuth.Middleware, requireAdmin).HandleFunc("/log/level", logLevelHandler.ServeHTTP)
+	default:
+		r.HandleFunc("/log/level", logLevelHandler.ServeHTTP)
+	}
 
 	r.Route("/api/v1", func(r chi.Router) {
+		if opts.Started != nil {
+			r.Use(middleware.Startup(opts.Started, opts.StartupRetryAfter))
+		}
 		r.Use(jwtAuth.Middleware)
 		r.Use(rateLimiter.Middleware)
+		if opts.Degraded != nil {
+			r.Use(middleware.Degraded(opts.Degraded))
+		}
+		if opts.CacheStatusHeader {
+			r.Use(middleware.CacheStatus)
+		}
+
+		writeRole := opts.WriteRole
+		if writeRole == "" {
+			writeRole = "product-admin"
+		}
+
+		cacheResponse := func(next http.Handler) http.Handler { return next }
+		if opts.ResponseCache != nil {
+			cacheResponse = middleware.ResponseCache(opts.ResponseCache, logger)
+		}
 
 		r.Route("/products", func(r chi.Router) {
-			r.Get("/", productHandler.List)
-			r.Post("/", productHandler.Create)
+			r.Use(middleware.QueryLimit(opts.QueryLimit))
+			r.Use(middleware.Timezone)
+			r.Use(middleware.SpecFields(opts.IgnoreUnknownFields))
+			r.Get("/", listProducts(productHandler, requireAdmin, cacheResponse))
+			r.Get("/recent", productHandler.Recent)
+			r.Get("/count", productHandler.Count)
+			r.Get("/geo", productHandler.GeoFeed)
+			r.Get("/sku/{sku}", productHandler.GetBySKU)
 			r.Get("/{id}", productHandler.Get)
-			r.Put("/{id}", productHandler.Update)
-			r.Delete("/{id}", productHandler.Delete)
+			r.Post("/batch-get", productHandler.BatchGet)
+
+			r.With(cacheResponse).Get("/search/name", productHandler.SearchByName)
+			r.With(cacheResponse).Get("/search/category", productHandler.SearchByCategory)
+			r.Get("/search/tag", productHandler.SearchByTag)
+
+			r.Group(func(r chi.Router) {
+				r.Use(jwtAuth.RequireRole(writeRole))
+				r.Post("/", productHandler.Create)
+				r.Post("/bulk", productHandler.BulkCreate)
+				r.Post("/import", productHandler.Import)
+				r.Post("/stock/bulk", productHandler.BulkAdjustStock)
+				r.Put("/{id}", productHandler.Update)
+				r.Patch("/{id}", productHandler.Patch)
+				r.Patch("/{id}/stock", productHandler.UpdateStock)
+				r.Post("/{id}/stock", productHandler.AdjustStock)
+				r.Post("/{id}/tags", productHandler.AddTag)
+				r.Delete("/{id}/tags/{tag}", productHandler.RemoveTag)
+				r.Delete("/{id}", productHandler.Delete)
+				r.Post("/{id}/restore", productHandler.Restore)
+			})
+		})
 
-			r.Get("/search/name", productHandler.SearchByName)
-			r.Get("/search/category", productHandler.SearchByCategory)
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(requireAdmin)
+			r.Get("/whoami", adminHandler.WhoAmI)
+			r.Post("/warm", adminHandler.Warm)
+			r.Post("/cache/warm", adminHandler.WarmCache)
+			r.Delete("/cache", adminHandler.FlushCache)
+			r.Delete("/cache/products/{id}", adminHandler.InvalidateProductCache)
+			r.Post("/reconcile/versions", adminHandler.ReconcileVersions)
+			r.Get("/webhook/dead-letters", adminHandler.ListDeadLetters)
+			r.Post("/webhook/dead-letters/replay", adminHandler.ReplayDeadLetters)
 		})
 	})
 
 	return r
 }
+
+// listProducts encaminha a listagem filtrada por modified_by, restrita a
+// admins, e deixa a listagem comum aberta a qualquer usuário autenticado. Só
+// a listagem comum passa pelo cache de respostas, que não separa por usuário.
+func listProducts(productHandler *handler.ProductHandler, requireAdmin, cacheResponse func(http.Handler) http.Handler) http.HandlerFunc {
+	modifiedBy := requireAdmin(http.HandlerFunc(productHandler.ListModifiedBy))
+	list := cacheResponse(http.HandlerFunc(productHandler.List))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("modified_by") {
+			modifiedBy.ServeHTTP(w, r)
+			return
+		}
+		list.ServeHTTP(w, r)
+	}
+}
@@ -0,0 +1,70 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/config"
+	"github.com/dowglassantana/product-redis-api/internal/infrastructure/http/middleware"
+	"go.uber.org/zap"
+)
+
+func TestParseLogLevelAccess(t *testing.T) {
+	for value, want := range map[string]LogLevelAccess{
+		"public":    LogLevelPublic,
+		"ADMIN":     LogLevelAdmin,
+		" disabled": LogLevelDisabled,
+	} {
+		got, err := ParseLogLevelAccess(value)
+		if err != nil || got != want {
+			t.Errorf("ParseLogLevelAccess(%q) = %q, %v, want %q", value, got, err, want)
+		}
+	}
+
+	for _, value := range []string{"", "private"} {
+		if _, err := ParseLogLevelAccess(value); err == nil {
+			t.Errorf("ParseLogLevelAccess(%q): expected error", value)
+		}
+	}
+}
+
+func TestSetupRouter_LogLevelAccess(t *testing.T) {
+	tests := []struct {
+		access LogLevelAccess
+		status int
+	}{
+		{access: "", status: http.StatusOK},
+		{access: LogLevelPublic, status: http.StatusOK},
+		{access: LogLevelAdmin, status: http.StatusUnauthorized},
+		{access: LogLevelDisabled, status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.access), func(t *testing.T) {
+			level := zap.NewAtomicLevel()
+			jwtAuth := middleware.NewJWTAuth(&config.KeycloakConfig{}, zap.NewNop())
+			r := SetupRouter(nil, nil, nil, jwtAuth, nil, &level, zap.NewNop(), Options{LogLevelAccess: tt.access})
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/log/level", nil))
+
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %d, got %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestSetupRouter_DetailedHealthRequiresAuth(t *testing.T) {
+	level := zap.NewAtomicLevel()
+	jwtAuth := middleware.NewJWTAuth(&config.KeycloakConfig{}, zap.NewNop())
+	r := SetupRouter(nil, nil, nil, jwtAuth, nil, &level, zap.NewNop(), Options{})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/detailed", nil))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d without a token, got %d: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// RedactedValue substitui o valor dos campos mascarados.
+const RedactedValue = "****"
+
+// alwaysRedacted são campos de credencial mascarados mesmo sem configuração,
+// para que tokens e headers de autenticação nunca cheguem inteiros aos logs.
+var alwaysRedacted = []string{
+	"authorization",
+	"token",
+	"access_token",
+	"refresh_token",
+	"id_token",
+	"password",
+	"client_secret",
+}
+
+// WithRedaction devolve um logger que mascara os campos informados (além das
+// credenciais de alwaysRedacted). A comparação das chaves ignora maiúsculas e
+// vale para campos de topo, inclusive os adicionados via With.
+func WithRedaction(logger *zap.Logger, fields []string) *zap.Logger {
+	keys := make(map[string]struct{}, len(fields)+len(alwaysRedacted))
+	for _, list := range [][]string{alwaysRedacted, fields} {
+		for _, field := range list {
+			if key := strings.ToLower(strings.TrimSpace(field)); key != "" {
+				keys[key] = struct{}{}
+			}
+		}
+	}
+
+	return logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &redactingCore{Core: core, keys: keys}
+	}))
+}
+
+type redactingCore struct {
+	zapcore.Core
+	keys map[string]struct{}
+}
+
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{Core: c.Core.With(c.redact(fields)), keys: c.keys}
+}
+
+// Check consulta o core interno (nível e amostragem da config de produção) e,
+// se a entrada passar, registra este core para que Write aplique a máscara.
+func (c *redactingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Core.Check(entry, nil) != nil {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *redactingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, c.redact(fields))
+}
+
+func (c *redactingCore) redact(fields []zapcore.Field) []zapcore.Field {
+	var redacted []zapcore.Field
+
+	for i, field := range fields {
+		if _, ok := c.keys[strings.ToLower(field.Key)]; !ok {
+			continue
+		}
+		if redacted == nil {
+			redacted = make([]zapcore.Field, len(fields))
+			copy(redacted, fields)
+		}
+		redacted[i] = zap.String(field.Key, RedactedValue)
+	}
+
+	if redacted == nil {
+		return fields
+	}
+	return redacted
+}
@@ -0,0 +1,43 @@
+package logger
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithRedaction(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	log := WithRedaction(zap.New(core), []string{"Reference", "description"})
+
+	log.With(zap.String("description", "secret notes")).Info("product created",
+		zap.String("product_id", "01HN8Z9Q"),
+		zap.String("reference", "REF-123"),
+		zap.String("Authorization", "Bearer eyJhbGciOi"),
+	)
+	NewZapAdapter(log).Info("sugared", "reference", "REF-456", "name", "Notebook")
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	structured := entries[0].ContextMap()
+	for _, key := range []string{"description", "reference", "Authorization"} {
+		if structured[key] != RedactedValue {
+			t.Errorf("Expected %s to be redacted, got %v", key, structured[key])
+		}
+	}
+	if structured["product_id"] != "01HN8Z9Q" {
+		t.Errorf("Expected product_id to be kept, got %v", structured["product_id"])
+	}
+
+	sugared := entries[1].ContextMap()
+	if sugared["reference"] != RedactedValue {
+		t.Errorf("Expected sugared reference to be redacted, got %v", sugared["reference"])
+	}
+	if sugared["name"] != "Notebook" {
+		t.Errorf("Expected name to be kept, got %v", sugared["name"])
+	}
+}
@@ -0,0 +1,30 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// PrometheusCacheRecorder expõe cache_lookups_total{operation, result}, com
+// result hit ou miss, para acompanhar a taxa de acerto do Redis por operação.
+type PrometheusCacheRecorder struct {
+	lookups *prometheus.CounterVec
+}
+
+func NewPrometheusCacheRecorder(registerer prometheus.Registerer) (*PrometheusCacheRecorder, error) {
+	lookups := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_lookups_total",
+		Help: "Cache lookups by operation and result (hit or miss).",
+	}, []string{"operation", "result"})
+
+	if err := registerer.Register(lookups); err != nil {
+		return nil, err
+	}
+
+	return &PrometheusCacheRecorder{lookups: lookups}, nil
+}
+
+func (r *PrometheusCacheRecorder) ObserveCacheHit(operation string) {
+	r.lookups.WithLabelValues(operation, "hit").Inc()
+}
+
+func (r *PrometheusCacheRecorder) ObserveCacheMiss(operation string) {
+	r.lookups.WithLabelValues(operation, "miss").Inc()
+}
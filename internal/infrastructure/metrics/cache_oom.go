@@ -0,0 +1,26 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// PrometheusCacheOOMRecorder expõe cache_oom_errors_total{operation}, as
+// escritas recusadas pelo Redis por ter atingido maxmemory.
+type PrometheusCacheOOMRecorder struct {
+	rejected *prometheus.CounterVec
+}
+
+func NewPrometheusCacheOOMRecorder(registerer prometheus.Registerer) (*PrometheusCacheOOMRecorder, error) {
+	rejected := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_oom_errors_total",
+		Help: "Cache writes rejected by Redis for exceeding maxmemory, by operation.",
+	}, []string{"operation"})
+
+	if err := registerer.Register(rejected); err != nil {
+		return nil, err
+	}
+
+	return &PrometheusCacheOOMRecorder{rejected: rejected}, nil
+}
+
+func (r *PrometheusCacheOOMRecorder) ObserveCacheOOM(operation string) {
+	r.rejected.WithLabelValues(operation).Inc()
+}
@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestPrometheusCacheOOMRecorder(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	recorder, err := NewPrometheusCacheOOMRecorder(registry)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	recorder.ObserveCacheOOM("set")
+	recorder.ObserveCacheOOM("set")
+	recorder.ObserveCacheOOM("add_to_sorted_set")
+
+	if got := testutil.ToFloat64(recorder.rejected.WithLabelValues("set")); got != 2 {
+		t.Errorf("cache_oom_errors_total{operation=\"set\"} = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(recorder.rejected.WithLabelValues("add_to_sorted_set")); got != 1 {
+		t.Errorf("cache_oom_errors_total{operation=\"add_to_sorted_set\"} = %v, want 1", got)
+	}
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestPrometheusCacheRecorder(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	recorder, err := NewPrometheusCacheRecorder(registry)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	recorder.ObserveCacheHit("get")
+	recorder.ObserveCacheHit("get")
+	recorder.ObserveCacheMiss("get")
+	recorder.ObserveCacheMiss("search_name")
+
+	tests := []struct {
+		operation string
+		result    string
+		want      float64
+	}{
+		{"get", "hit", 2},
+		{"get", "miss", 1},
+		{"search_name", "hit", 0},
+		{"search_name", "miss", 1},
+	}
+	for _, tt := range tests {
+		if got := testutil.ToFloat64(recorder.lookups.WithLabelValues(tt.operation, tt.result)); got != tt.want {
+			t.Errorf("cache_lookups_total{operation=%q,result=%q} = %v, want %v", tt.operation, tt.result, got, tt.want)
+		}
+	}
+
+	if _, err := NewPrometheusCacheRecorder(registry); err == nil {
+		t.Error("Expected duplicate registration to fail")
+	}
+}
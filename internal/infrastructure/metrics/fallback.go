@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusFallbackRecorder expõe db_fallback_duration_seconds{operation}, a
+// latência das consultas ao banco disparadas por cache miss. Como só o caminho
+// frio é medido, o histograma serve de base para um SLO de latência de cauda.
+type PrometheusFallbackRecorder struct {
+	duration *prometheus.HistogramVec
+}
+
+func NewPrometheusFallbackRecorder(registerer prometheus.Registerer) (*PrometheusFallbackRecorder, error) {
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_fallback_duration_seconds",
+		Help:    "Latency of database queries triggered by a cache miss.",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"operation"})
+
+	if err := registerer.Register(duration); err != nil {
+		return nil, err
+	}
+
+	return &PrometheusFallbackRecorder{duration: duration}, nil
+}
+
+func (r *PrometheusFallbackRecorder) ObserveDBFallback(operation string, duration time.Duration) {
+	r.duration.WithLabelValues(operation).Observe(duration.Seconds())
+}
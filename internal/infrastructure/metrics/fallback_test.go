@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestPrometheusFallbackRecorder(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	recorder, err := NewPrometheusFallbackRecorder(registry)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	recorder.ObserveDBFallback("get_product", 12*time.Millisecond)
+	recorder.ObserveDBFallback("get_product", 30*time.Millisecond)
+	recorder.ObserveDBFallback("list_products", 5*time.Millisecond)
+
+	if got := testutil.CollectAndCount(registry, "db_fallback_duration_seconds"); got != 2 {
+		t.Errorf("Expected 2 operation series, got %d", got)
+	}
+
+	if _, err := NewPrometheusFallbackRecorder(registry); err == nil {
+		t.Error("Expected duplicate registration to fail")
+	}
+}
@@ -0,0 +1,27 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// PrometheusRateLimitRecorder expõe rate_limit_keys, o número de chaves de
+// rate limit no Redis na última contagem, para acompanhar a memória usada
+// pelos contadores.
+type PrometheusRateLimitRecorder struct {
+	keys prometheus.Gauge
+}
+
+func NewPrometheusRateLimitRecorder(registerer prometheus.Registerer) (*PrometheusRateLimitRecorder, error) {
+	keys := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "rate_limit_keys",
+		Help: "Number of rate limit keys in Redis at the last count.",
+	})
+
+	if err := registerer.Register(keys); err != nil {
+		return nil, err
+	}
+
+	return &PrometheusRateLimitRecorder{keys: keys}, nil
+}
+
+func (r *PrometheusRateLimitRecorder) SetKeyCount(count int) {
+	r.keys.Set(float64(count))
+}
@@ -0,0 +1,23 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestPrometheusRateLimitRecorder(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	recorder, err := NewPrometheusRateLimitRecorder(registry)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	recorder.SetKeyCount(42)
+
+	if got := testutil.ToFloat64(recorder.keys); got != 42 {
+		t.Errorf("Expected rate_limit_keys 42, got %v", got)
+	}
+}
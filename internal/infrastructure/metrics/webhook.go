@@ -0,0 +1,27 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// PrometheusWebhookRecorder expõe webhook_queue_overflow_total{outcome}, os
+// eventos de mudança que encontraram a fila do webhook cheia: dead_letter
+// (guardados para o reenvio) ou dropped (perdidos).
+type PrometheusWebhookRecorder struct {
+	overflow *prometheus.CounterVec
+}
+
+func NewPrometheusWebhookRecorder(registerer prometheus.Registerer) (*PrometheusWebhookRecorder, error) {
+	overflow := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "webhook_queue_overflow_total",
+		Help: "Change events that found the webhook queue full, by outcome.",
+	}, []string{"outcome"})
+
+	if err := registerer.Register(overflow); err != nil {
+		return nil, err
+	}
+
+	return &PrometheusWebhookRecorder{overflow: overflow}, nil
+}
+
+func (r *PrometheusWebhookRecorder) ObserveQueueOverflow(outcome string) {
+	r.overflow.WithLabelValues(outcome).Inc()
+}
@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestPrometheusWebhookRecorder(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	recorder, err := NewPrometheusWebhookRecorder(registry)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	recorder.ObserveQueueOverflow("dead_letter")
+	recorder.ObserveQueueOverflow("dead_letter")
+	recorder.ObserveQueueOverflow("dropped")
+
+	if got := testutil.ToFloat64(recorder.overflow.WithLabelValues("dead_letter")); got != 2 {
+		t.Errorf("webhook_queue_overflow_total{outcome=\"dead_letter\"} = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(recorder.overflow.WithLabelValues("dropped")); got != 1 {
+		t.Errorf("webhook_queue_overflow_total{outcome=\"dropped\"} = %v, want 1", got)
+	}
+}
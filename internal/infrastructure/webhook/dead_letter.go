@@ -0,0 +1,147 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+)
+
+// DeadLetterStore guarda, em ordem de falha, os eventos que o Publisher não
+// conseguiu entregar.
+type DeadLetterStore interface {
+	// Push adiciona os eventos no fim e retorna quantos dos mais antigos
+	// saíram por causa do limite de tamanho.
+	Push(ctx context.Context, letters []port.DeadLetter) (int, error)
+	// Peek lê os limit primeiros sem removê-los e retorna o total guardado.
+	Peek(ctx context.Context, limit int) ([]port.DeadLetter, int, error)
+	// Pop remove e retorna até count eventos do início.
+	Pop(ctx context.Context, count int) ([]port.DeadLetter, error)
+	// Requeue devolve eventos ao início, na ordem em que estão.
+	Requeue(ctx context.Context, letters []port.DeadLetter) error
+}
+
+// RedisDeadLetterStore guarda os eventos numa lista do Redis: falhas entram
+// pelo fim (RPUSH) e o reenvio consome pelo início (LPOP), então réplicas da
+// API não reenviam o mesmo evento. maxLen maior que zero mantém só os mais
+// recentes.
+type RedisDeadLetterStore struct {
+	client *redis.Client
+	key    string
+	maxLen int
+}
+
+func NewRedisDeadLetterStore(client *redis.Client, key string, maxLen int) *RedisDeadLetterStore {
+	return &RedisDeadLetterStore{
+		client: client,
+		key:    key,
+		maxLen: maxLen,
+	}
+}
+
+func (s *RedisDeadLetterStore) Push(ctx context.Context, letters []port.DeadLetter) (int, error) {
+	if len(letters) == 0 {
+		return 0, nil
+	}
+
+	values, err := encodeDeadLetters(letters)
+	if err != nil {
+		return 0, err
+	}
+
+	var length *redis.IntCmd
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		length = pipe.RPush(ctx, s.key, values...)
+		if s.maxLen > 0 {
+			pipe.LTrim(ctx, s.key, int64(-s.maxLen), -1)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to push webhook dead letters: %w", err)
+	}
+
+	if s.maxLen > 0 && length.Val() > int64(s.maxLen) {
+		return int(length.Val()) - s.maxLen, nil
+	}
+	return 0, nil
+}
+
+func (s *RedisDeadLetterStore) Peek(ctx context.Context, limit int) ([]port.DeadLetter, int, error) {
+	var entries *redis.StringSliceCmd
+	var length *redis.IntCmd
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		entries = pipe.LRange(ctx, s.key, 0, int64(limit-1))
+		length = pipe.LLen(ctx, s.key)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read webhook dead letters: %w", err)
+	}
+
+	return decodeDeadLetters(entries.Val()), int(length.Val()), nil
+}
+
+// Pop usa LPOP com count (Redis 6.2+). Entradas que não decodificam, que só
+// existem se alguém gravou na lista por fora da API, são descartadas.
+func (s *RedisDeadLetterStore) Pop(ctx context.Context, count int) ([]port.DeadLetter, error) {
+	entries, err := s.client.LPopCount(ctx, s.key, count).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to pop webhook dead letters: %w", err)
+	}
+
+	return decodeDeadLetters(entries), nil
+}
+
+func (s *RedisDeadLetterStore) Requeue(ctx context.Context, letters []port.DeadLetter) error {
+	if len(letters) == 0 {
+		return nil
+	}
+
+	values, err := encodeDeadLetters(letters)
+	if err != nil {
+		return err
+	}
+
+	// LPUSH insere um valor de cada vez no início; invertidos, os eventos
+	// voltam na ordem original.
+	for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+		values[i], values[j] = values[j], values[i]
+	}
+
+	if err := s.client.LPush(ctx, s.key, values...).Err(); err != nil {
+		return fmt.Errorf("failed to requeue webhook dead letters: %w", err)
+	}
+	return nil
+}
+
+func encodeDeadLetters(letters []port.DeadLetter) ([]interface{}, error) {
+	values := make([]interface{}, len(letters))
+	for i, letter := range letters {
+		data, err := json.Marshal(letter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode webhook dead letter: %w", err)
+		}
+		values[i] = data
+	}
+	return values, nil
+}
+
+func decodeDeadLetters(entries []string) []port.DeadLetter {
+	letters := make([]port.DeadLetter, 0, len(entries))
+	for _, entry := range entries {
+		var letter port.DeadLetter
+		if err := json.Unmarshal([]byte(entry), &letter); err != nil {
+			continue
+		}
+		letters = append(letters, letter)
+	}
+	return letters
+}
@@ -0,0 +1,192 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+)
+
+type memoryDeadLetters struct {
+	mu      sync.Mutex
+	letters []port.DeadLetter
+	pushErr error
+}
+
+func (m *memoryDeadLetters) Push(ctx context.Context, letters []port.DeadLetter) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.pushErr != nil {
+		return 0, m.pushErr
+	}
+	m.letters = append(m.letters, letters...)
+	return 0, nil
+}
+
+func (m *memoryDeadLetters) Peek(ctx context.Context, limit int) ([]port.DeadLetter, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]port.DeadLetter(nil), m.letters[:min(limit, len(m.letters))]...), len(m.letters), nil
+}
+
+func (m *memoryDeadLetters) Pop(ctx context.Context, count int) ([]port.DeadLetter, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := min(count, len(m.letters))
+	popped := append([]port.DeadLetter(nil), m.letters[:n]...)
+	m.letters = m.letters[n:]
+	return popped, nil
+}
+
+func (m *memoryDeadLetters) Requeue(ctx context.Context, letters []port.DeadLetter) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.letters = append(append([]port.DeadLetter(nil), letters...), m.letters...)
+	return nil
+}
+
+func (m *memoryDeadLetters) ids() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ids := make([]string, len(m.letters))
+	for i, letter := range m.letters {
+		ids[i] = letter.Event.ProductID
+	}
+	return ids
+}
+
+func TestPublisher_MovesFailedDeliveryToDeadLetter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	store := &memoryDeadLetters{}
+	p := NewPublisher(Options{URL: server.URL, MaxRetries: 1, RetryBackoff: time.Millisecond, DeadLetter: store}, zap.NewNop())
+	p.Publish(port.ChangeEvent{Type: port.ProductUpdated, ProductID: "a", Version: 2})
+	p.Publish(port.ChangeEvent{Type: port.ProductDeleted, ProductID: "b"})
+
+	if err := p.Close(context.Background()); err != nil {
+		t.Fatalf("Close() unexpected error = %v", err)
+	}
+
+	letters, total, err := p.DeadLetters(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("DeadLetters() unexpected error = %v", err)
+	}
+	if total != 2 || len(letters) != 2 {
+		t.Fatalf("Expected 2 dead letters, got %d (total %d)", len(letters), total)
+	}
+	if letters[0].Event.ProductID != "a" || letters[0].Event.Version != 2 || letters[1].Event.ProductID != "b" {
+		t.Errorf("Expected events in failure order, got %+v", letters)
+	}
+	if letters[0].Error != "webhook responded 502" || letters[0].FailedAt.IsZero() {
+		t.Errorf("Expected failure details, got %+v", letters[0])
+	}
+}
+
+func TestPublisher_ReplaySendsDeadLettersInOrder(t *testing.T) {
+	rec := &recorder{}
+	server := httptest.NewServer(http.HandlerFunc(rec.handler))
+	defer server.Close()
+
+	store := &memoryDeadLetters{}
+	for _, id := range []string{"a", "b", "c"} {
+		store.letters = append(store.letters, port.DeadLetter{Event: port.ChangeEvent{Type: port.ProductCreated, ProductID: id}})
+	}
+
+	p := NewPublisher(Options{URL: server.URL, DeadLetter: store}, zap.NewNop())
+	defer p.Close(context.Background())
+
+	replayed, err := p.Replay(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("Replay() unexpected error = %v", err)
+	}
+	if replayed != 2 {
+		t.Errorf("Expected 2 events replayed, got %d", replayed)
+	}
+
+	var ids []string
+	for _, body := range rec.bodies {
+		var event port.ChangeEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			t.Fatalf("Expected object payload, got %s", body)
+		}
+		ids = append(ids, event.ProductID)
+	}
+	if len(ids) != 2 || ids[0] != "a" || ids[1] != "b" {
+		t.Errorf("Expected the two oldest events in order, got %v", ids)
+	}
+	if remaining := store.ids(); len(remaining) != 1 || remaining[0] != "c" {
+		t.Errorf("Expected only c left in the dead letter, got %v", remaining)
+	}
+}
+
+func TestPublisher_ReplayRequeuesOnFailure(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) > 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := &memoryDeadLetters{}
+	for _, id := range []string{"a", "b", "c"} {
+		store.letters = append(store.letters, port.DeadLetter{Event: port.ChangeEvent{Type: port.ProductCreated, ProductID: id}})
+	}
+
+	p := NewPublisher(Options{URL: server.URL, DeadLetter: store}, zap.NewNop())
+	defer p.Close(context.Background())
+
+	replayed, err := p.Replay(context.Background(), 10)
+	if !errors.Is(err, port.ErrWebhookUnavailable) {
+		t.Fatalf("Expected ErrWebhookUnavailable, got %v", err)
+	}
+	if replayed != 1 {
+		t.Errorf("Expected 1 event replayed before the failure, got %d", replayed)
+	}
+	if remaining := store.ids(); len(remaining) != 2 || remaining[0] != "b" || remaining[1] != "c" {
+		t.Errorf("Expected b and c back in order, got %v", remaining)
+	}
+}
+
+func TestPublisher_ReplayHonorsContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	store := &memoryDeadLetters{}
+	store.letters = append(store.letters, port.DeadLetter{Event: port.ChangeEvent{Type: port.ProductCreated, ProductID: "a"}})
+
+	p := NewPublisher(Options{URL: server.URL, DeadLetter: store}, zap.NewNop())
+	defer p.Close(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := p.Replay(ctx, 10); !errors.Is(err, port.ErrWebhookUnavailable) {
+		t.Fatalf("Expected ErrWebhookUnavailable, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the canceled request to stop the POST, took %v", elapsed)
+	}
+	if remaining := store.ids(); len(remaining) != 1 || remaining[0] != "a" {
+		t.Errorf("Expected a back in the dead letter, got %v", remaining)
+	}
+}
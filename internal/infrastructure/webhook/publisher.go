@@ -0,0 +1,358 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+)
+
+// Options configura o Publisher.
+//
+// BatchWindow agrupa os eventos recebidos dentro da janela num único POST com
+// um array; zero envia cada evento sozinho, como objeto. MaxBatchSize fecha o
+// lote antes da janela acabar. QueueSize limita os eventos pendentes; com a
+// fila cheia o evento vai direto para o dead letter. DeadLetter recebe esses
+// eventos e os cuja entrega falhou depois das novas tentativas; nil os
+// descarta. Recorder conta os eventos que encontraram a fila cheia.
+type Options struct {
+	URL          string
+	Timeout      time.Duration
+	MaxRetries   int
+	RetryBackoff time.Duration
+	BatchWindow  time.Duration
+	MaxBatchSize int
+	QueueSize    int
+	DeadLetter   DeadLetterStore
+	Recorder     OverflowRecorder
+}
+
+// OverflowRecorder recebe cada evento que encontrou a fila cheia. outcome é
+// "dead_letter" quando ele foi guardado para o reenvio e "dropped" quando se
+// perdeu (sem dead letter ou com o Redis fora).
+type OverflowRecorder interface {
+	ObserveQueueOverflow(outcome string)
+}
+
+// errQueueFull é o erro gravado no dead letter para os eventos que não
+// couberam na fila.
+var errQueueFull = errors.New("webhook queue full")
+
+// deadLetterTimeout limita a gravação dos eventos não entregues, feita fora
+// do ciclo de qualquer requisição.
+const deadLetterTimeout = 5 * time.Second
+
+// Publisher envia os eventos de mudança para um webhook. Uma única goroutine
+// faz as entregas, então a ordem dos eventos é preservada dentro e entre os
+// lotes.
+type Publisher struct {
+	client  *http.Client
+	options Options
+	logger  *zap.Logger
+
+	mu     sync.RWMutex
+	closed bool
+	events chan port.ChangeEvent
+	done   chan struct{}
+}
+
+func NewPublisher(options Options, logger *zap.Logger) *Publisher {
+	if options.MaxBatchSize <= 0 {
+		options.MaxBatchSize = 100
+	}
+	if options.QueueSize <= 0 {
+		options.QueueSize = 1000
+	}
+
+	p := &Publisher{
+		client:  &http.Client{Timeout: options.Timeout},
+		options: options,
+		logger:  logger,
+		events:  make(chan port.ChangeEvent, options.QueueSize),
+		done:    make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+func (p *Publisher) Publish(event port.ChangeEvent) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		p.logger.Warn("webhook publisher closed - dropping event",
+			zap.String("type", event.Type),
+			zap.String("product_id", event.ProductID),
+		)
+		return
+	}
+
+	select {
+	case p.events <- event:
+	default:
+		p.overflow(event)
+	}
+}
+
+// overflow desvia para o dead letter o evento que não coube na fila, para
+// que o reenvio o entregue depois, fora de ordem em relação aos que estavam
+// na fila. Sem dead letter, ou com ele indisponível, o evento é descartado.
+func (p *Publisher) overflow(event port.ChangeEvent) {
+	outcome := "dropped"
+	defer func() {
+		if p.options.Recorder != nil {
+			p.options.Recorder.ObserveQueueOverflow(outcome)
+		}
+	}()
+
+	if p.options.DeadLetter == nil {
+		p.logger.Warn("webhook queue full - dropping event",
+			zap.String("type", event.Type),
+			zap.String("product_id", event.ProductID),
+		)
+		return
+	}
+
+	dropped, err := p.pushDeadLetters([]port.ChangeEvent{event}, errQueueFull)
+	if err != nil {
+		p.logger.Error("webhook queue full and dead letter unavailable - dropping event",
+			zap.Error(err),
+			zap.String("type", event.Type),
+			zap.String("product_id", event.ProductID),
+		)
+		return
+	}
+
+	outcome = "dead_letter"
+	p.logger.Warn("webhook queue full - event moved to dead letter",
+		zap.String("type", event.Type),
+		zap.String("product_id", event.ProductID),
+	)
+	p.warnDeadLetterFull(dropped)
+}
+
+// Close para de aceitar eventos e espera a entrega dos pendentes, incluindo
+// o lote aberto, até o contexto expirar.
+func (p *Publisher) Close(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.events)
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *Publisher) run() {
+	defer close(p.done)
+
+	if p.options.BatchWindow <= 0 {
+		for event := range p.events {
+			p.deliver(event, []port.ChangeEvent{event})
+		}
+		return
+	}
+
+	for event := range p.events {
+		batch := []port.ChangeEvent{event}
+		timer := time.NewTimer(p.options.BatchWindow)
+
+	collect:
+		for len(batch) < p.options.MaxBatchSize {
+			select {
+			case next, ok := <-p.events:
+				if !ok {
+					break collect
+				}
+				batch = append(batch, next)
+			case <-timer.C:
+				break collect
+			}
+		}
+		timer.Stop()
+
+		p.deliver(batch, batch)
+	}
+}
+
+// deliver faz o POST com até MaxRetries novas tentativas, com backoff linear.
+// Esgotadas as tentativas, os eventos vão para o dead letter.
+func (p *Publisher) deliver(payload any, events []port.ChangeEvent) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		p.logger.Error("failed to encode webhook payload", zap.Error(err))
+		return
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = p.post(context.Background(), body)
+		if err == nil {
+			return
+		}
+		if attempt >= p.options.MaxRetries {
+			break
+		}
+		time.Sleep(p.options.RetryBackoff * time.Duration(attempt+1))
+	}
+
+	p.deadLetter(events, err)
+}
+
+func (p *Publisher) deadLetter(events []port.ChangeEvent, cause error) {
+	if p.options.DeadLetter == nil {
+		p.logger.Error("webhook delivery failed - dropping events",
+			zap.Error(cause),
+			zap.Int("events", len(events)),
+		)
+		return
+	}
+
+	dropped, err := p.pushDeadLetters(events, cause)
+	if err != nil {
+		p.logger.Error("webhook delivery failed and dead letter unavailable - dropping events",
+			zap.Error(cause),
+			zap.NamedError("dead_letter_error", err),
+			zap.Int("events", len(events)),
+		)
+		return
+	}
+
+	p.logger.Error("webhook delivery failed - events moved to dead letter",
+		zap.Error(cause),
+		zap.Int("events", len(events)),
+	)
+	p.warnDeadLetterFull(dropped)
+}
+
+// pushDeadLetters grava os eventos no dead letter com cause e o momento da
+// falha. dropped é quantos dos mais antigos saíram pelo limite da lista.
+func (p *Publisher) pushDeadLetters(events []port.ChangeEvent, cause error) (dropped int, err error) {
+	failedAt := time.Now().UTC()
+	letters := make([]port.DeadLetter, len(events))
+	for i, event := range events {
+		letters[i] = port.DeadLetter{Event: event, Error: cause.Error(), FailedAt: failedAt}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), deadLetterTimeout)
+	defer cancel()
+
+	return p.options.DeadLetter.Push(ctx, letters)
+}
+
+func (p *Publisher) warnDeadLetterFull(dropped int) {
+	if dropped > 0 {
+		p.logger.Warn("webhook dead letter full - dropping oldest events",
+			zap.Int("dropped", dropped),
+		)
+	}
+}
+
+// DeadLetters lista os eventos não entregues mais antigos. Sem dead letter
+// configurado, a lista é sempre vazia.
+func (p *Publisher) DeadLetters(ctx context.Context, limit int) ([]port.DeadLetter, int, error) {
+	if p.options.DeadLetter == nil {
+		return nil, 0, nil
+	}
+	return p.options.DeadLetter.Peek(ctx, limit)
+}
+
+// Replay tira do dead letter até limit eventos e os reenvia na ordem em que
+// falharam, agrupados como nas entregas normais e sem novas tentativas. Na
+// primeira falha, os que não foram entregues voltam ao início do dead letter
+// e o erro embrulha port.ErrWebhookUnavailable.
+func (p *Publisher) Replay(ctx context.Context, limit int) (int, error) {
+	if p.options.DeadLetter == nil {
+		return 0, nil
+	}
+
+	letters, err := p.options.DeadLetter.Pop(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	replayed := 0
+	for len(letters) > 0 {
+		size := 1
+		if p.options.BatchWindow > 0 {
+			size = min(p.options.MaxBatchSize, len(letters))
+		}
+
+		if err := p.post(ctx, replayPayload(letters[:size], p.options.BatchWindow > 0)); err != nil {
+			p.requeue(ctx, letters)
+			return replayed, fmt.Errorf("%w: %v", port.ErrWebhookUnavailable, err)
+		}
+
+		replayed += size
+		letters = letters[size:]
+	}
+
+	if replayed > 0 {
+		p.logger.Info("webhook dead letters replayed", zap.Int("events", replayed))
+	}
+	return replayed, nil
+}
+
+// requeue devolve os eventos ao dead letter mesmo que a requisição do reenvio
+// tenha sido cancelada, para não perdê-los.
+func (p *Publisher) requeue(ctx context.Context, letters []port.DeadLetter) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), deadLetterTimeout)
+	defer cancel()
+
+	if err := p.options.DeadLetter.Requeue(ctx, letters); err != nil {
+		p.logger.Error("failed to requeue webhook dead letters - dropping events",
+			zap.Error(err),
+			zap.Int("events", len(letters)),
+		)
+	}
+}
+
+// replayPayload monta o corpo no mesmo formato das entregas normais: um
+// objeto por evento ou, com lotes, um array.
+func replayPayload(letters []port.DeadLetter, batched bool) []byte {
+	if !batched {
+		body, _ := json.Marshal(letters[0].Event)
+		return body
+	}
+
+	events := make([]port.ChangeEvent, len(letters))
+	for i, letter := range letters {
+		events[i] = letter.Event
+	}
+	body, _ := json.Marshal(events)
+	return body
+}
+
+// post envia o corpo ao webhook. No reenvio, ctx é o da requisição de
+// administração: cancelá-la interrompe o POST em andamento.
+func (p *Publisher) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.options.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded %d", resp.StatusCode)
+	}
+	return nil
+}
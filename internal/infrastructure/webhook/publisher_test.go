@@ -0,0 +1,196 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dowglassantana/product-redis-api/internal/application/port"
+)
+
+type recorder struct {
+	mu     sync.Mutex
+	bodies [][]byte
+}
+
+func (rec *recorder) handler(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rec.mu.Lock()
+	rec.bodies = append(rec.bodies, body)
+	rec.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestPublisher_BatchesWithinWindowAndFlushesOnClose(t *testing.T) {
+	rec := &recorder{}
+	server := httptest.NewServer(http.HandlerFunc(rec.handler))
+	defer server.Close()
+
+	p := NewPublisher(Options{URL: server.URL, BatchWindow: time.Hour, MaxBatchSize: 3}, zap.NewNop())
+
+	for _, id := range []string{"a", "b", "c", "d"} {
+		p.Publish(port.ChangeEvent{Type: port.ProductCreated, ProductID: id})
+	}
+
+	if err := p.Close(context.Background()); err != nil {
+		t.Fatalf("Close() unexpected error = %v", err)
+	}
+
+	if len(rec.bodies) != 2 {
+		t.Fatalf("Expected 2 deliveries (full batch + flush), got %d", len(rec.bodies))
+	}
+
+	var ids []string
+	for _, body := range rec.bodies {
+		var batch []port.ChangeEvent
+		if err := json.Unmarshal(body, &batch); err != nil {
+			t.Fatalf("Expected array payload, got %s", body)
+		}
+		for _, event := range batch {
+			ids = append(ids, event.ProductID)
+		}
+	}
+	if len(ids) != 4 || ids[0] != "a" || ids[1] != "b" || ids[2] != "c" || ids[3] != "d" {
+		t.Errorf("Expected events in publish order, got %v", ids)
+	}
+
+	p.Publish(port.ChangeEvent{Type: port.ProductDeleted, ProductID: "late"})
+}
+
+func TestPublisher_WithoutWindowSendsSingleEvents(t *testing.T) {
+	rec := &recorder{}
+	server := httptest.NewServer(http.HandlerFunc(rec.handler))
+	defer server.Close()
+
+	p := NewPublisher(Options{URL: server.URL}, zap.NewNop())
+	p.Publish(port.ChangeEvent{Type: port.ProductUpdated, ProductID: "a", Version: 2})
+
+	if err := p.Close(context.Background()); err != nil {
+		t.Fatalf("Close() unexpected error = %v", err)
+	}
+
+	if len(rec.bodies) != 1 {
+		t.Fatalf("Expected 1 delivery, got %d", len(rec.bodies))
+	}
+
+	var event port.ChangeEvent
+	if err := json.Unmarshal(rec.bodies[0], &event); err != nil {
+		t.Fatalf("Expected object payload, got %s", rec.bodies[0])
+	}
+	if event.ProductID != "a" || event.Version != 2 {
+		t.Errorf("Unexpected event %+v", event)
+	}
+}
+
+func TestPublisher_RetriesFailedDelivery(t *testing.T) {
+	var mu sync.Mutex
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	p := NewPublisher(Options{URL: server.URL, MaxRetries: 2, RetryBackoff: time.Millisecond}, zap.NewNop())
+	p.Publish(port.ChangeEvent{Type: port.ProductCreated, ProductID: "a"})
+
+	if err := p.Close(context.Background()); err != nil {
+		t.Fatalf("Close() unexpected error = %v", err)
+	}
+
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+}
+
+type overflowCounter struct {
+	mu       sync.Mutex
+	outcomes map[string]int
+}
+
+func (c *overflowCounter) ObserveQueueOverflow(outcome string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.outcomes == nil {
+		c.outcomes = map[string]int{}
+	}
+	c.outcomes[outcome]++
+}
+
+// blockedPublisher devolve um Publisher com fila de um evento cuja entrega
+// fica presa em "first" até release ser fechado, para que a fila encha.
+func blockedPublisher(t *testing.T, options Options) (p *Publisher, release chan struct{}) {
+	t.Helper()
+
+	started := make(chan struct{})
+	release = make(chan struct{})
+	var once sync.Once
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		once.Do(func() { close(started) })
+		<-release
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	options.URL = server.URL
+	options.QueueSize = 1
+	p = NewPublisher(options, zap.NewNop())
+
+	p.Publish(port.ChangeEvent{Type: port.ProductCreated, ProductID: "first"})
+	<-started
+	p.Publish(port.ChangeEvent{Type: port.ProductCreated, ProductID: "queued"})
+	return p, release
+}
+
+func TestPublisher_QueueOverflowGoesToDeadLetter(t *testing.T) {
+	store := &memoryDeadLetters{}
+	counter := &overflowCounter{}
+	p, release := blockedPublisher(t, Options{DeadLetter: store, Recorder: counter})
+
+	p.Publish(port.ChangeEvent{Type: port.ProductUpdated, ProductID: "overflow", Version: 2})
+	close(release)
+
+	if err := p.Close(context.Background()); err != nil {
+		t.Fatalf("Close() unexpected error = %v", err)
+	}
+
+	letters, _, _ := store.Peek(context.Background(), 10)
+	if len(letters) != 1 || letters[0].Event.ProductID != "overflow" || letters[0].Event.Version != 2 {
+		t.Fatalf("Expected the overflowing event in the dead letter, got %+v", letters)
+	}
+	if letters[0].Error != errQueueFull.Error() || letters[0].FailedAt.IsZero() {
+		t.Errorf("Expected queue full details, got %+v", letters[0])
+	}
+	if counter.outcomes["dead_letter"] != 1 || counter.outcomes["dropped"] != 0 {
+		t.Errorf("Expected one dead_letter overflow, got %v", counter.outcomes)
+	}
+}
+
+func TestPublisher_QueueOverflowWithoutDeadLetterIsCounted(t *testing.T) {
+	counter := &overflowCounter{}
+	p, release := blockedPublisher(t, Options{Recorder: counter})
+
+	p.Publish(port.ChangeEvent{Type: port.ProductUpdated, ProductID: "overflow"})
+	close(release)
+
+	if err := p.Close(context.Background()); err != nil {
+		t.Fatalf("Close() unexpected error = %v", err)
+	}
+
+	if counter.outcomes["dropped"] != 1 {
+		t.Errorf("Expected one dropped overflow, got %v", counter.outcomes)
+	}
+}